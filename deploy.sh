@@ -36,6 +36,8 @@ if [ -z "$OPENROUTER_API_KEY" ]; then
 fi
 
 echo "📦 Building Docker images..."
+# Stamped into both images so the manager can spot version skew
+export FETCH_COMMIT=$(git rev-parse --short HEAD 2>/dev/null || echo unknown)
 docker compose build
 
 echo ""
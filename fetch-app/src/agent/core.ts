@@ -30,6 +30,7 @@ import { getInstinctRegistry, FetchMode, type InstinctAction } from '../instinct
 import { getIdentityManager } from '../identity/manager.js';
 import { getSkillManager } from '../skills/manager.js';
 import { env } from '../config/env.js';
+import type { SenderRole } from '../security/gate.js';
 import { pipeline } from '../config/pipeline.js';
 import { modeDetector } from '../conversation/detector.js'; // Phase 8: Mode Detection
 import { threadManager } from '../conversation/thread.js'; // Phase 8: Threading
@@ -52,6 +53,8 @@ export interface AgentResponse {
   taskId?: string;
   /** Detected conversation mode */
   mode?: string;
+  /** Tokens spent across every completion for this message */
+  usage?: TokenUsage;
 }
 
 /**
@@ -63,6 +66,48 @@ export interface ToolCallRecord {
   result: unknown;
 }
 
+/**
+ * Token usage summed over a message's completions
+ */
+export interface TokenUsage {
+  promptTokens: number;
+  completionTokens: number;
+  totalTokens: number;
+}
+
+/**
+ * Circuit breaker state of one session, for the manager's limits screen
+ */
+export interface SessionBreakerStatus {
+  sessionId: string;
+  /** closed: requests go through; open: refused until retryAt; half_open: the next request decides */
+  state: 'closed' | 'open' | 'half_open';
+  errorCount: number;
+  lastErrorAt: string;
+  /** When an open breaker lets a request through, null otherwise */
+  retryAt: string | null;
+}
+
+/**
+ * Circuit breaker configuration and the sessions it is tracking
+ */
+export interface CircuitBreakerStatus {
+  threshold: number;
+  backoffMs: number[];
+  resetMs: number;
+  sessions: SessionBreakerStatus[];
+}
+
+/**
+ * Add a completion's reported usage to a running total
+ */
+function addUsage(total: TokenUsage, usage: OpenAI.Completions.CompletionUsage | undefined): void {
+  if (!usage) return;
+  total.promptTokens += usage.prompt_tokens;
+  total.completionTokens += usage.completion_tokens;
+  total.totalTokens += usage.total_tokens;
+}
+
 // =============================================================================
 // CONSTANTS
 // =============================================================================
@@ -122,6 +167,40 @@ function getBackoffTime(sessionId: string): number {
   return ERROR_BACKOFF_MS[index] ?? 0;
 }
 
+/**
+ * Get the circuit breaker configuration and every session whose errors
+ * still count toward it
+ */
+export function getCircuitBreakerStatus(): CircuitBreakerStatus {
+  const now = Date.now();
+  const sessions: SessionBreakerStatus[] = [];
+  for (const [sessionId, tracker] of errorTracker) {
+    // The next error starts the count over, as trackError does
+    if (now - tracker.lastError > pipeline.circuitBreakerResetMs) continue;
+
+    let state: SessionBreakerStatus['state'] = 'closed';
+    let retryAt: string | null = null;
+    if (tracker.count >= MAX_CONSECUTIVE_ERRORS) {
+      const retry = tracker.lastError + getBackoffTime(sessionId);
+      state = now < retry ? 'open' : 'half_open';
+      retryAt = state === 'open' ? new Date(retry).toISOString() : null;
+    }
+    sessions.push({
+      sessionId,
+      state,
+      errorCount: tracker.count,
+      lastErrorAt: new Date(tracker.lastError).toISOString(),
+      retryAt,
+    });
+  }
+  return {
+    threshold: MAX_CONSECUTIVE_ERRORS,
+    backoffMs: ERROR_BACKOFF_MS,
+    resetMs: pipeline.circuitBreakerResetMs,
+    sessions,
+  };
+}
+
 /**
  * Determine if an error is retriable
  * 400-level errors (except 429) are generally not retriable
@@ -298,12 +377,14 @@ async function handleInstinctAction(
  * @param message - User message
  * @param session - Current session
  * @param onProgress - Optional callback for intermediate progress messages
+ * @param role - What the sender may do; tools refuse what it doesn't allow
  * @returns Agent response
  */
 export async function processMessage(
   message: string,
   session: Session,
-  onProgress?: (text: string) => Promise<void>
+  onProgress?: (text: string) => Promise<void>,
+  role: SenderRole = 'owner'
 ): Promise<AgentResponse> {
   const startTime = Date.now();
   const sManager = await getSessionManager();
@@ -413,7 +494,7 @@ export async function processMessage(
     switch (intent.type) {
       case 'conversation':
         response = await handleWithRetry(
-          (attempt) => handleConversation(message, session, attempt),
+          (attempt) => handleConversation(message, session, attempt, role),
           session.id,
           onProgress
         );
@@ -421,7 +502,7 @@ export async function processMessage(
 
       case 'action':
         response = await handleWithRetry(
-          (attempt) => handleWithTools(message, session, attempt),
+          (attempt) => handleWithTools(message, session, attempt, role),
           session.id,
           onProgress
         );
@@ -436,7 +517,7 @@ export async function processMessage(
 
       default:
         response = await handleWithRetry(
-          (attempt) => handleConversation(message, session, attempt),
+          (attempt) => handleConversation(message, session, attempt, role),
           session.id,
           onProgress
         );
@@ -444,7 +525,7 @@ export async function processMessage(
 
     // Success - reset error count
     resetErrorCount(session.id);
-    return response;
+    return { ...response, mode: response.mode ?? detectedMode.mode };
 
   } catch (error) {
     logger.error('Agent error', { error, sessionId: session.id });
@@ -493,7 +574,8 @@ const CONVERSATION_TOOLS = ['workspace_list', 'workspace_select', 'workspace_sta
 async function handleConversation(
   message: string,
   session: Session,
-  attempt: number = 1
+  attempt: number = 1,
+  role: SenderRole = 'owner'
 ): Promise<AgentResponse> {
   const openai = getOpenAI();
 
@@ -528,6 +610,9 @@ async function handleConversation(
     { role: 'user', content: message },
   ];
 
+  const usage: TokenUsage = { promptTokens: 0, completionTokens: 0, totalTokens: 0 };
+  const toolCalls: ToolCallRecord[] = [];
+
   let response = await openai.chat.completions.create({
     model: MODEL,
     messages,
@@ -536,6 +621,7 @@ async function handleConversation(
     max_tokens: pipeline.chatMaxTokens,
     temperature: pipeline.chatTemperature,
   });
+  addUsage(usage, response.usage);
 
   // Handle tool calls if conversation LLM decides to use read-only tools
   let callCount = 0;
@@ -590,7 +676,9 @@ async function handleConversation(
       const result = await registry.execute(toolName, toolArgs, {
         sessionId: session.id,
         autonomyLevel: session.preferences.autonomyLevel,
+        role,
       });
+      toolCalls.push({ name: toolName, args: toolArgs, result });
 
       // Sync workspace selection from conversation too
       if (toolName === 'workspace_select' && result.success) {
@@ -634,12 +722,15 @@ async function handleConversation(
       max_tokens: pipeline.chatMaxTokens,
       temperature: pipeline.chatTemperature,
     });
+    addUsage(usage, response.usage);
   }
 
   const text = response.choices[0]?.message?.content ?? "Hey! 🐕";
 
   return {
     text,
+    toolCalls,
+    usage,
   };
 }
 
@@ -653,12 +744,14 @@ async function handleConversation(
 async function handleWithTools(
   message: string,
   session: Session,
-  attempt: number = 1
+  attempt: number = 1,
+  role: SenderRole = 'owner'
 ): Promise<AgentResponse> {
   const openai = getOpenAI();
   const registry = getToolRegistry();
   const tools = registry.toOpenAIFormat();
   const toolCalls: ToolCallRecord[] = [];
+  const usage: TokenUsage = { promptTokens: 0, completionTokens: 0, totalTokens: 0 };
 
   // Match skills against this message and build activated context
   const skillManager = getSkillManager();
@@ -690,6 +783,7 @@ async function handleWithTools(
     max_tokens: pipeline.toolMaxTokens,
     temperature: pipeline.toolTemperature,
   });
+  addUsage(usage, response.usage);
 
   let callCount = 0;
 
@@ -786,6 +880,7 @@ async function handleWithTools(
       const result = await registry.execute(toolName, finalArgs, {
         sessionId: session.id,
         autonomyLevel: session.preferences.autonomyLevel,
+        role,
       });
 
       toolCalls.push({
@@ -918,6 +1013,7 @@ async function handleWithTools(
       max_tokens: pipeline.toolMaxTokens,
       temperature: pipeline.toolTemperature,
     });
+    addUsage(usage, response.usage);
   }
 
   // Get final text response
@@ -938,6 +1034,7 @@ async function handleWithTools(
     toolCalls,
     taskStarted: !!taskCall,
     taskId,
+    usage,
   };
 }
 
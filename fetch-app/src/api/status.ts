@@ -7,6 +7,7 @@
  * 
  * @module api/status
  * @see {@link startStatusServer} - Start the HTTP server
+ * @see {@link createStatusServer} - Build the server without listening (tests)
  * @see {@link updateStatus} - Update bridge status
  * @see {@link getStatus} - Get current status
  * 
@@ -15,6 +16,32 @@
  * | Method | Path | Description |
  * |--------|------|------------|
  * | GET | /api/status | Current bridge status (JSON) |
+ * | GET | /api/events | Bridge status on every state change (server-sent events) |
+ * | GET | /api/version | Bridge version, image commit, and Node.js version |
+ * | POST | /api/logout | Disconnect WhatsApp (admin token) |
+ * | POST | /api/pairing-code | Get a code to link WhatsApp by phone number instead of the QR code (admin token) |
+ * | POST | /api/test-message | Run a message through the agent (admin token) |
+ * | POST | /api/whitelist/reload | Re-read data/whitelist.json (admin token) |
+ * | GET | /api/whitelist | Trusted numbers and their details (admin token) |
+ * | POST | /api/whitelist | Trust a number (admin token) |
+ * | PATCH | /api/whitelist/{number} | Replace a number's label, note, role and expiry (admin token) |
+ * | DELETE | /api/whitelist/{number} | Stop trusting a number (admin token) |
+ * | GET | /api/groups | WhatsApp groups the account is in (admin token) |
+ * | POST | /api/owner/verify | Send a verification code to a new owner number (admin token) |
+ * | POST | /api/owner | Change the owner number without a restart (admin token) |
+ * | GET | /api/stats | Messages, tool calls and errors per hour for the last 24 hours (admin token) |
+ * | GET | /api/limits | Circuit breaker and rate limiter state (admin token) |
+ * | GET | /api/activity | What each number did: messages, commands, tasks (admin token) |
+ * | POST | /api/shutdown | Finish replies in progress and flush data before a stop (admin token) |
+ * | POST | /api/shutdown/cancel | Take messages again when the stop was cancelled or failed (admin token) |
+ * | GET | /api/tasks | Pending, running and recent kennel tasks (admin token) |
+ * | POST | /api/tasks/{id}/cancel | Cancel a pending or running task (admin token) |
+ * | POST | /api/tasks/{id}/retry | Queue a failed or cancelled task again (admin token) |
+ * | POST | /api/tasks/{id}/respond | Answer the question a task is waiting on (admin token) |
+ * | GET | /api/summaries | Stored conversation summaries and recent summarizer failures (admin token) |
+ * | GET | /api/workspaces | Kennel workspaces with git state and last task (admin token) |
+ * | GET | /api/workspaces/{id}/diff | Uncommitted changes, or the last commit when clean (admin token) |
+ * | DELETE | /api/workspaces/{id} | Delete a workspace directory (admin token) |
  * | GET | /docs/* | Documentation site (static) |
  * 
  * ## Status States
@@ -23,6 +50,7 @@
  * |-------|------------|
  * | initializing | Bridge starting up |
  * | qr_pending | QR code displayed, awaiting scan |
+ * | authenticating | QR scanned, WhatsApp loading chats |
  * | authenticated | WhatsApp connected |
  * | disconnected | Connection lost |
  * | error | Error occurred |
@@ -36,7 +64,8 @@
  *   "qrUrl": null,
  *   "uptime": 3600,
  *   "messageCount": 42,
- *   "lastError": null
+ *   "lastError": null,
+ *   "device": { "name": "Fetch", "phone": "15551234567" }
  * }
  * ```
  * 
@@ -55,6 +84,7 @@ import fs from 'fs';
 import path from 'path';
 import { logger } from '../utils/logger.js';
 import { env } from '../config/env.js';
+import { TRUST_ROLES, type TrustRole, type TrustedContact, type WhitelistSnapshot } from '../security/whitelist.js';
 
 // =============================================================================
 // CONFIGURATION
@@ -66,6 +96,9 @@ const PORT = 8765;
 /** Path to documentation files */
 const DOCS_PATH = '/app/docs';
 
+/** Package manifest, read once for /api/version */
+const PACKAGE_JSON_PATH = path.resolve('package.json');
+
 // =============================================================================
 // TYPES
 // =============================================================================
@@ -76,7 +109,7 @@ const DOCS_PATH = '/app/docs';
  */
 export interface BridgeStatus {
   /** Current connection state */
-  state: 'initializing' | 'qr_pending' | 'authenticated' | 'disconnected' | 'error';
+  state: 'initializing' | 'qr_pending' | 'authenticating' | 'authenticated' | 'disconnected' | 'error';
   /** QR code data (when state is qr_pending) */
   qrCode: string | null;
   /** URL to view QR code in browser */
@@ -87,12 +120,57 @@ export interface BridgeStatus {
   messageCount: number;
   /** Last error message (if any) */
   lastError: string | null;
+  /** Linked WhatsApp account (when authenticated) */
+  device: LinkedDevice | null;
+}
+
+/**
+ * The WhatsApp account the bridge is linked to.
+ * @interface
+ */
+export interface LinkedDevice {
+  /** Profile name shown in WhatsApp */
+  name: string | null;
+  /** Phone number without the leading + */
+  phone: string | null;
+}
+
+/**
+ * Actions the manager can take on a kennel task.
+ */
+export type TaskAction = 'cancel' | 'retry';
+
+/**
+ * Thrown by a callback to answer with a specific status code instead of
+ * 500, e.g. 404 for a task that doesn't exist.
+ */
+export class StatusApiError extends Error {
+  constructor(readonly statusCode: number, message: string) {
+    super(message);
+    this.name = 'StatusApiError';
+  }
+}
+
+/**
+ * Bridge build information for /api/version.
+ * @interface
+ */
+export interface VersionInfo {
+  /** Version from package.json */
+  version: string | null;
+  /** Git commit the image was built from (FETCH_COMMIT build argument) */
+  commit: string | null;
+  /** Node.js version */
+  node: string;
 }
 
 // =============================================================================
 // GLOBAL STATE
 // =============================================================================
 
+/** Build information, read on first request */
+let versionInfo: VersionInfo | null = null;
+
 /** Global status (updated by bridge events) */
 let status: BridgeStatus = {
   state: 'initializing',
@@ -100,16 +178,125 @@ let status: BridgeStatus = {
   qrUrl: null,
   uptime: 0,
   messageCount: 0,
-  lastError: null
+  lastError: null,
+  device: null
 };
 
 /** Server start time for uptime calculation */
 const startTime = Date.now();
 
+/** Connections following /api/events */
+const eventClients = new Set<http.ServerResponse>();
+
+/** Comment sent to event clients so idle proxies keep the stream open */
+const EVENT_KEEPALIVE_MS = 25_000;
+
 /** Callback for logout action */
 let logoutCallback: (() => Promise<void>) | null = null;
 
-/** Admin token for protected endpoints (logout) */
+/** Callback that asks WhatsApp for a pairing code for a phone number */
+let pairingCodeCallback: ((phoneNumber: string) => Promise<string>) | null = null;
+
+/** Callback for the manager's test console */
+let testMessageCallback: ((message: string) => Promise<unknown>) | null = null;
+
+/** Callback that reloads the whitelist and returns the trusted count */
+let whitelistReloadCallback: (() => Promise<number>) | null = null;
+
+/** Callback that returns the trusted numbers and their details */
+let whitelistCallback: (() => Promise<WhitelistSnapshot>) | null = null;
+
+/** Callback that trusts a number; false if it already was */
+let whitelistAddCallback: ((phoneNumber: string, contact: TrustedContact) => Promise<boolean>) | null = null;
+
+/** Callback that replaces a number's details; false if it isn't trusted */
+let whitelistUpdateCallback: ((phoneNumber: string, contact: TrustedContact) => Promise<boolean>) | null = null;
+
+/** Callback that stops trusting a number; false if it wasn't trusted */
+let whitelistRemoveCallback: ((phoneNumber: string) => Promise<boolean>) | null = null;
+
+/** Whitelist entry paths: /api/whitelist/{number} */
+const WHITELIST_ENTRY_PATTERN = /^\/api\/whitelist\/([^/]+)$/;
+
+/** Trusted numbers: digits with country code, no +, at E.164 lengths */
+const WHITELIST_NUMBER_PATTERN = /^\d{8,15}$/;
+
+/** Largest whitelist request body accepted, in bytes */
+const MAX_WHITELIST_BODY_BYTES = 4 * 1024;
+
+/** Callback that lists the account's WhatsApp groups */
+let groupsCallback: (() => Promise<unknown[]>) | null = null;
+
+/** Callback that sends an owner verification code; false if not on WhatsApp */
+let ownerVerifyCallback: ((phoneNumber: string, code: string) => Promise<boolean>) | null = null;
+
+/** Callback that changes the owner number */
+let ownerChangeCallback: ((phoneNumber: string) => void) | null = null;
+
+/** Callback that returns per-hour traffic counters, oldest first */
+let statsCallback: (() => unknown[]) | null = null;
+
+/** Callback that returns the circuit breaker and rate limiter state */
+let limitsCallback: (() => { circuitBreaker: unknown; rateLimit: unknown }) | null = null;
+
+/** Callback that returns per-number activity */
+let activityCallback: (() => Record<string, unknown>) | null = null;
+
+/** Callback that prepares for a stop; resolves to messages still in progress */
+let shutdownCallback: ((goodbye: boolean) => Promise<number>) | null = null;
+
+/** Callback that takes messages again after a shutdown request; false if none was made */
+let shutdownCancelCallback: (() => boolean) | null = null;
+
+/** Callback that lists pending, running and recent tasks */
+let tasksCallback: (() => Promise<unknown[]>) | null = null;
+
+/** Callback that cancels or retries a task */
+let taskActionCallback: ((taskId: string, action: TaskAction) => Promise<void>) | null = null;
+
+/** Task action paths: /api/tasks/{id}/{action} */
+const TASK_ACTION_PATTERN = /^\/api\/tasks\/([^/]+)\/(cancel|retry)$/;
+
+/** Callback that answers a task's question */
+let taskRespondCallback: ((taskId: string, response: string) => Promise<void>) | null = null;
+
+/** Task respond paths: /api/tasks/{id}/respond */
+const TASK_RESPOND_PATTERN = /^\/api\/tasks\/([^/]+)\/respond$/;
+
+/** Largest task response body accepted, in bytes */
+const MAX_TASK_RESPONSE_BYTES = 16 * 1024;
+
+/** Callback that returns conversation summaries and summarizer failures */
+let summariesCallback: (() => Promise<{ summaries: unknown[]; failures: unknown[] }>) | null = null;
+
+/** Callback that lists kennel workspaces */
+let workspacesCallback: (() => Promise<unknown[]>) | null = null;
+
+/** Callback that returns a workspace's diff */
+let workspaceDiffCallback: ((workspaceId: string) => Promise<string>) | null = null;
+
+/** Callback that deletes a workspace */
+let workspaceDeleteCallback: ((workspaceId: string) => Promise<void>) | null = null;
+
+/** Workspace diff paths: /api/workspaces/{id}/diff */
+const WORKSPACE_DIFF_PATTERN = /^\/api\/workspaces\/([^/]+)\/diff$/;
+
+/** Workspace paths: /api/workspaces/{id} */
+const WORKSPACE_ENTRY_PATTERN = /^\/api\/workspaces\/([^/]+)$/;
+
+/** Largest shutdown request body accepted, in bytes */
+const MAX_SHUTDOWN_BODY_BYTES = 1024;
+
+/** Owner numbers: digits with country code, no + */
+const OWNER_NUMBER_PATTERN = /^\d{8,15}$/;
+
+/** Largest owner request body accepted, in bytes */
+const MAX_OWNER_BODY_BYTES = 1024;
+
+/** Largest test message body accepted, in bytes */
+const MAX_TEST_MESSAGE_BYTES = 64 * 1024;
+
+/** Admin token for protected endpoints (logout, test messages) */
 const ADMIN_TOKEN = env.ADMIN_TOKEN || crypto.randomBytes(24).toString('hex');
 
 /**
@@ -120,6 +307,240 @@ export function setLogoutCallback(callback: () => Promise<void>): void {
   logoutCallback = callback;
 }
 
+/**
+ * Registers the pairing code callback.
+ * Called before WhatsApp starts, since the code is only offered while the
+ * QR code is showing.
+ */
+export function setPairingCodeCallback(callback: (phoneNumber: string) => Promise<string>): void {
+  pairingCodeCallback = callback;
+}
+
+/**
+ * Registers the test console callback.
+ * Called at startup to route test messages into the message handler.
+ */
+export function setTestMessageCallback(callback: (message: string) => Promise<unknown>): void {
+  testMessageCallback = callback;
+}
+
+/**
+ * Registers the whitelist reload callback.
+ * Called at startup so the manager can apply edits to data/whitelist.json.
+ */
+export function setWhitelistReloadCallback(callback: () => Promise<number>): void {
+  whitelistReloadCallback = callback;
+}
+
+/**
+ * Registers the whitelist listing callback.
+ * Called at startup, for the manager's Trusted Numbers screen.
+ */
+export function setWhitelistCallback(callback: () => Promise<WhitelistSnapshot>): void {
+  whitelistCallback = callback;
+}
+
+/**
+ * Registers the callback that trusts a number.
+ * Called at startup, for the manager's Trusted Numbers screen.
+ */
+export function setWhitelistAddCallback(callback: (phoneNumber: string, contact: TrustedContact) => Promise<boolean>): void {
+  whitelistAddCallback = callback;
+}
+
+/**
+ * Registers the callback that replaces a number's details.
+ * Called at startup, for the manager's Trusted Numbers screen.
+ */
+export function setWhitelistUpdateCallback(callback: (phoneNumber: string, contact: TrustedContact) => Promise<boolean>): void {
+  whitelistUpdateCallback = callback;
+}
+
+/**
+ * Registers the callback that stops trusting a number.
+ * Called at startup, for the manager's Trusted Numbers screen.
+ */
+export function setWhitelistRemoveCallback(callback: (phoneNumber: string) => Promise<boolean>): void {
+  whitelistRemoveCallback = callback;
+}
+
+/**
+ * Registers the group listing callback.
+ * Called once WhatsApp is initialized, for the manager's group allow-list.
+ */
+export function setGroupsCallback(callback: () => Promise<unknown[]>): void {
+  groupsCallback = callback;
+}
+
+/**
+ * Registers the owner verification callback.
+ * Called once WhatsApp is initialized, for the manager's owner change flow.
+ */
+export function setOwnerVerifyCallback(callback: (phoneNumber: string, code: string) => Promise<boolean>): void {
+  ownerVerifyCallback = callback;
+}
+
+/**
+ * Registers the owner change callback.
+ * Called once the security gate exists, for the manager's owner change flow.
+ */
+export function setOwnerChangeCallback(callback: (phoneNumber: string) => void): void {
+  ownerChangeCallback = callback;
+}
+
+/**
+ * Registers the traffic stats callback.
+ * Called at startup, for the manager's Message Statistics screen.
+ */
+export function setStatsCallback(callback: () => unknown[]): void {
+  statsCallback = callback;
+}
+
+/**
+ * Registers the limits callback.
+ * Called once WhatsApp is ready, since the rate limiter lives in the bridge.
+ */
+export function setLimitsCallback(callback: () => { circuitBreaker: unknown; rateLimit: unknown }): void {
+  limitsCallback = callback;
+}
+
+/**
+ * Registers the activity callback.
+ * Called at startup, for the per-number activity on the manager's Trusted Numbers screen.
+ */
+export function setActivityCallback(callback: () => Record<string, unknown>): void {
+  activityCallback = callback;
+}
+
+/**
+ * Registers the callback that prepares the bridge for a stop.
+ * Called by the manager before `docker compose down`.
+ */
+export function setShutdownCallback(callback: (goodbye: boolean) => Promise<number>): void {
+  shutdownCallback = callback;
+}
+
+/**
+ * Registers the callback that calls off a shutdown.
+ * Called at startup, for when Stop Fetch is cancelled or fails.
+ */
+export function setShutdownCancelCallback(callback: () => boolean): void {
+  shutdownCancelCallback = callback;
+}
+
+/**
+ * Registers the task listing callback.
+ * Called at startup, for the manager's task queue screen.
+ */
+export function setTasksCallback(callback: () => Promise<unknown[]>): void {
+  tasksCallback = callback;
+}
+
+/**
+ * Registers the callback that cancels or retries a task.
+ * Throw a {@link StatusApiError} for unknown tasks or a wrong state.
+ */
+export function setTaskActionCallback(callback: (taskId: string, action: TaskAction) => Promise<void>): void {
+  taskActionCallback = callback;
+}
+
+/**
+ * Registers the callback that answers a task waiting on a question.
+ * Throw a {@link StatusApiError} for unknown tasks or a wrong state.
+ */
+export function setTaskRespondCallback(callback: (taskId: string, response: string) => Promise<void>): void {
+  taskRespondCallback = callback;
+}
+
+/**
+ * Registers the conversation summaries callback.
+ * Called at startup, for the manager's summaries screen.
+ */
+export function setSummariesCallback(callback: () => Promise<{ summaries: unknown[]; failures: unknown[] }>): void {
+  summariesCallback = callback;
+}
+
+/**
+ * Registers the workspace listing callback.
+ * Called at startup, for the manager's workspaces screen.
+ */
+export function setWorkspacesCallback(callback: () => Promise<unknown[]>): void {
+  workspacesCallback = callback;
+}
+
+/**
+ * Registers the workspace diff callback.
+ * Throw a {@link StatusApiError} for unknown workspaces.
+ */
+export function setWorkspaceDiffCallback(callback: (workspaceId: string) => Promise<string>): void {
+  workspaceDiffCallback = callback;
+}
+
+/**
+ * Registers the workspace delete callback.
+ * Throw a {@link StatusApiError} for unknown workspaces or ones in use.
+ */
+export function setWorkspaceDeleteCallback(callback: (workspaceId: string) => Promise<void>): void {
+  workspaceDeleteCallback = callback;
+}
+
+/**
+ * Reads a request body up to limit bytes.
+ * Rejects when the body is larger.
+ */
+function readBody(req: http.IncomingMessage, limit: number): Promise<string> {
+  return new Promise((resolve, reject) => {
+    let size = 0;
+    const chunks: Buffer[] = [];
+    req.on('data', (chunk: Buffer) => {
+      size += chunk.length;
+      if (size > limit) {
+        reject(new Error('Body too large'));
+        req.destroy();
+        return;
+      }
+      chunks.push(chunk);
+    });
+    req.on('end', () => resolve(Buffer.concat(chunks).toString('utf8')));
+    req.on('error', reject);
+  });
+}
+
+/**
+ * Picks the contact fields out of a whitelist request body. Returns an
+ * error message when a field has the wrong type or value.
+ */
+function parseContact(body: Record<string, unknown>): TrustedContact | string {
+  const contact: TrustedContact = {};
+  for (const field of ['label', 'note', 'addedAt', 'expiresAt'] as const) {
+    const value = body[field];
+    if (value === undefined || value === null || value === '') continue;
+    if (typeof value !== 'string') return `${field} must be a string`;
+    contact[field] = value;
+  }
+  if (contact.expiresAt && Number.isNaN(Date.parse(contact.expiresAt))) {
+    return 'expiresAt must be an ISO 8601 time';
+  }
+  const role = body.role;
+  if (role !== undefined && role !== null && role !== '') {
+    if (!TRUST_ROLES.includes(role as TrustRole)) return `role must be one of ${TRUST_ROLES.join(', ')}`;
+    contact.role = role as TrustRole;
+  }
+  return contact;
+}
+
+/**
+ * Decodes one percent-encoded path segment, or returns null when it is
+ * malformed or empty.
+ */
+function decodePathSegment(segment: string): string | null {
+  try {
+    return decodeURIComponent(segment) || null;
+  } catch {
+    return null;
+  }
+}
+
 /**
  * Triggers logout/disconnect from WhatsApp.
  * Returns true if successful.
@@ -148,8 +569,22 @@ export async function triggerLogout(): Promise<boolean> {
  * @param {Partial<BridgeStatus>} update - Fields to update
  */
 export function updateStatus(update: Partial<BridgeStatus>): void {
+  const previous = status;
   status = { ...status, ...update };
   logger.debug('Status updated:', { state: status.state });
+  if (status.state !== previous.state || status.qrCode !== previous.qrCode) {
+    broadcastStatus();
+  }
+}
+
+/**
+ * Sends the current status to every /api/events connection.
+ */
+function broadcastStatus(): void {
+  const event = `event: status\ndata: ${JSON.stringify(getStatus())}\n\n`;
+  for (const client of eventClients) {
+    client.write(event);
+  }
 }
 
 /**
@@ -172,12 +607,64 @@ export function getStatus(): BridgeStatus {
   };
 }
 
+/**
+ * Gets the bridge version, the commit its image was built from, and the
+ * Node.js version. The manager flags skew against the kennel and its own
+ * checkout.
+ *
+ * @returns {VersionInfo} Build information
+ */
+export function getVersionInfo(): VersionInfo {
+  if (versionInfo) {
+    return versionInfo;
+  }
+  let version: string | null = null;
+  try {
+    version = JSON.parse(fs.readFileSync(PACKAGE_JSON_PATH, 'utf8')).version ?? null;
+  } catch (error) {
+    logger.warn('Could not read package version:', error);
+  }
+  const commit = process.env.FETCH_COMMIT;
+  versionInfo = {
+    version,
+    commit: commit && commit !== 'unknown' ? commit : null,
+    node: process.version,
+  };
+  return versionInfo;
+}
+
 /**
  * Starts the status API HTTP server.
  * Listens on PORT (8765) for status requests and serves docs.
  */
 export function startStatusServer(): void {
-  const server = http.createServer(async (req, res) => {
+  const server = createStatusServer();
+
+  server.listen(PORT, '0.0.0.0', () => {
+    logger.info(`Status API listening on port ${PORT}`);
+    logger.info(`Documentation available at http://localhost:${PORT}/docs`);
+    if (!env.ADMIN_TOKEN) {
+      logger.info(`Admin token (auto-generated): ${ADMIN_TOKEN}`);
+    }
+  });
+
+  server.on('error', (err) => {
+    logger.error('Status API server error:', err);
+  });
+
+  setInterval(() => {
+    for (const client of eventClients) {
+      client.write(': keepalive\n\n');
+    }
+  }, EVENT_KEEPALIVE_MS).unref();
+}
+
+/**
+ * Builds the status API server without listening, so tests can serve the
+ * real routes on a port of their choosing.
+ */
+export function createStatusServer(): http.Server {
+  return http.createServer(async (req, res) => {
     const url = req.url || '/';
     
     // CORS headers for local development
@@ -191,12 +678,34 @@ export function startStatusServer(): void {
       return;
     }
     
+    // Status stream: the current status, then one event per state change
+    if (req.method === 'GET' && url === '/api/events') {
+      res.writeHead(200, {
+        'Content-Type': 'text/event-stream',
+        'Cache-Control': 'no-cache',
+        'Connection': 'keep-alive'
+      });
+      res.write(`event: status\ndata: ${JSON.stringify(getStatus())}\n\n`);
+      eventClients.add(res);
+      req.on('close', () => eventClients.delete(res));
+      return;
+    }
+
     if (req.method === 'GET' && url === '/api/health') {
       res.setHeader('Content-Type', 'application/json');
       res.writeHead(200);
       res.end(JSON.stringify({ healthy: true }));
       return;
     }
+
+    // Version endpoint; the manager compares it with the kennel and its
+    // own checkout to spot partial updates
+    if (req.method === 'GET' && url === '/api/version') {
+      res.setHeader('Content-Type', 'application/json');
+      res.writeHead(200);
+      res.end(JSON.stringify(getVersionInfo()));
+      return;
+    }
     
     // Logout/Disconnect endpoint (requires admin token)
     if (req.method === 'POST' && url === '/api/logout') {
@@ -220,23 +729,706 @@ export function startStatusServer(): void {
       return;
     }
     
-    // Documentation Routes
-    if (req.method === 'GET' && (url === '/docs' || url === '/docs/')) {
-      res.writeHead(302, { Location: '/docs/index.html' });
-      res.end();
-      return;
-    }
-    
-    if (req.method === 'GET' && url.startsWith('/docs/')) {
-      const filePath = path.join(DOCS_PATH, url.slice(6)); // Remove '/docs/'
-      serveStaticFile(filePath, res);
+    // Pairing code endpoint (requires admin token); an alternative to
+    // scanning the QR code. Errors carry a message the manager shows
+    if (req.method === 'POST' && url === '/api/pairing-code') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ message: 'Unauthorized' }));
+        return;
+      }
+      if (!pairingCodeCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ message: 'WhatsApp is still starting' }));
+        return;
+      }
+      if (status.state !== 'qr_pending') {
+        res.writeHead(409);
+        res.end(JSON.stringify({
+          message: status.state === 'authenticated' ? 'WhatsApp is already linked' : 'WhatsApp is not waiting to be linked yet',
+        }));
+        return;
+      }
+
+      let phoneNumber: unknown;
+      try {
+        ({ phoneNumber } = JSON.parse(await readBody(req, MAX_OWNER_BODY_BYTES)));
+      } catch {
+        res.writeHead(400);
+        res.end(JSON.stringify({ message: 'Expected a JSON body like {"phoneNumber": "..."}' }));
+        return;
+      }
+      if (typeof phoneNumber !== 'string' || !OWNER_NUMBER_PATTERN.test(phoneNumber)) {
+        res.writeHead(400);
+        res.end(JSON.stringify({ message: 'phoneNumber must be 8-15 digits with the country code' }));
+        return;
+      }
+
+      try {
+        const code = await pairingCodeCallback(phoneNumber);
+        res.writeHead(200);
+        res.end(JSON.stringify({
+          code,
+          message: 'On the phone: WhatsApp → Linked devices → Link a device → Link with phone number instead',
+        }));
+      } catch (error) {
+        logger.error('Requesting a pairing code failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ message: error instanceof Error ? error.message : 'Requesting a pairing code failed' }));
+      }
       return;
     }
-    
-    // Root redirect to docs
-    if (req.method === 'GET' && url === '/') {
-      res.writeHead(302, { Location: '/docs/' });
-      res.end();
+
+    // Test console endpoint (requires admin token)
+    if (req.method === 'POST' && url === '/api/test-message') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!testMessageCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Message handler not ready' }));
+        return;
+      }
+
+      let message: unknown;
+      try {
+        message = JSON.parse(await readBody(req, MAX_TEST_MESSAGE_BYTES)).message;
+      } catch {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: 'Expected a JSON body like {"message": "..."}' }));
+        return;
+      }
+      if (typeof message !== 'string' || message.trim() === '') {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: 'message must be a non-empty string' }));
+        return;
+      }
+
+      try {
+        const result = await testMessageCallback(message.trim());
+        res.writeHead(200);
+        res.end(JSON.stringify(result));
+      } catch (error) {
+        logger.error('Test message failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Test message failed' }));
+      }
+      return;
+    }
+    
+    // Whitelist reload endpoint (requires admin token)
+    if (req.method === 'POST' && url === '/api/whitelist/reload') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!whitelistReloadCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Whitelist not ready' }));
+        return;
+      }
+
+      try {
+        const count = await whitelistReloadCallback();
+        res.writeHead(200);
+        res.end(JSON.stringify({ success: true, count }));
+      } catch (error) {
+        logger.error('Whitelist reload failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Whitelist reload failed' }));
+      }
+      return;
+    }
+
+    // Whitelist endpoints (requires admin token); the manager's Trusted
+    // Numbers screen edits the list through them so changes apply at once
+    const whitelistEntry = WHITELIST_ENTRY_PATTERN.exec(url);
+    if ((url === '/api/whitelist' && (req.method === 'GET' || req.method === 'POST')) ||
+        (whitelistEntry && (req.method === 'PATCH' || req.method === 'DELETE'))) {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!whitelistCallback || !whitelistAddCallback || !whitelistUpdateCallback || !whitelistRemoveCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Whitelist not ready' }));
+        return;
+      }
+
+      let phoneNumber: unknown = whitelistEntry ? decodePathSegment(whitelistEntry[1]) : null;
+      let contact: TrustedContact | string = {};
+      if (req.method === 'POST' || req.method === 'PATCH') {
+        let body: Record<string, unknown>;
+        try {
+          body = JSON.parse(await readBody(req, MAX_WHITELIST_BODY_BYTES));
+          if (!body || typeof body !== 'object') throw new Error('not an object');
+        } catch {
+          res.writeHead(400);
+          res.end(JSON.stringify({ error: 'Expected a JSON body like {"number": "...", "label": "..."}' }));
+          return;
+        }
+        if (req.method === 'POST') phoneNumber = body.number;
+        contact = parseContact(body);
+      }
+      if (req.method !== 'GET' && (typeof phoneNumber !== 'string' || !WHITELIST_NUMBER_PATTERN.test(phoneNumber))) {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: 'number must be 8-15 digits' }));
+        return;
+      }
+      if (typeof contact === 'string') {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: contact }));
+        return;
+      }
+
+      try {
+        const number = phoneNumber as string;
+        switch (req.method) {
+          case 'GET':
+            res.writeHead(200);
+            res.end(JSON.stringify(await whitelistCallback()));
+            return;
+          case 'POST':
+            if (!(await whitelistAddCallback(number, contact))) {
+              res.writeHead(409);
+              res.end(JSON.stringify({ error: 'Number is already trusted' }));
+              return;
+            }
+            res.writeHead(201);
+            break;
+          case 'PATCH':
+            if (!(await whitelistUpdateCallback(number, contact))) {
+              res.writeHead(404);
+              res.end(JSON.stringify({ error: 'Number is not trusted' }));
+              return;
+            }
+            res.writeHead(200);
+            break;
+          default:
+            if (!(await whitelistRemoveCallback(number))) {
+              res.writeHead(404);
+              res.end(JSON.stringify({ error: 'Number is not trusted' }));
+              return;
+            }
+            res.writeHead(200);
+        }
+        res.end(JSON.stringify({ success: true }));
+      } catch (error) {
+        logger.error('Whitelist change failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Whitelist change failed' }));
+      }
+      return;
+    }
+
+    // Group listing endpoint (requires admin token)
+    if (req.method === 'GET' && url === '/api/groups') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!groupsCallback || status.state !== 'authenticated') {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'WhatsApp is not connected' }));
+        return;
+      }
+
+      try {
+        const groups = await groupsCallback();
+        res.writeHead(200);
+        res.end(JSON.stringify({ groups }));
+      } catch (error) {
+        logger.error('Listing groups failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Listing groups failed' }));
+      }
+      return;
+    }
+
+    // Owner verification endpoint (requires admin token)
+    if (req.method === 'POST' && url === '/api/owner/verify') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!ownerVerifyCallback || status.state !== 'authenticated') {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'WhatsApp is not connected' }));
+        return;
+      }
+
+      let phoneNumber: unknown;
+      let code: unknown;
+      try {
+        ({ phoneNumber, code } = JSON.parse(await readBody(req, MAX_OWNER_BODY_BYTES)));
+      } catch {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: 'Expected a JSON body like {"phoneNumber": "...", "code": "..."}' }));
+        return;
+      }
+      if (typeof phoneNumber !== 'string' || !OWNER_NUMBER_PATTERN.test(phoneNumber) ||
+          typeof code !== 'string' || !/^\d{6}$/.test(code)) {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: 'phoneNumber must be 8-15 digits and code 6 digits' }));
+        return;
+      }
+
+      try {
+        if (!(await ownerVerifyCallback(phoneNumber, code))) {
+          res.writeHead(422);
+          res.end(JSON.stringify({ error: 'Number is not on WhatsApp' }));
+          return;
+        }
+        res.writeHead(200);
+        res.end(JSON.stringify({ success: true }));
+      } catch (error) {
+        logger.error('Sending owner verification failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Sending verification failed' }));
+      }
+      return;
+    }
+
+    // Owner change endpoint (requires admin token)
+    if (req.method === 'POST' && url === '/api/owner') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!ownerChangeCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Security gate not ready' }));
+        return;
+      }
+
+      let phoneNumber: unknown;
+      try {
+        ({ phoneNumber } = JSON.parse(await readBody(req, MAX_OWNER_BODY_BYTES)));
+      } catch {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: 'Expected a JSON body like {"phoneNumber": "..."}' }));
+        return;
+      }
+      if (typeof phoneNumber !== 'string' || !OWNER_NUMBER_PATTERN.test(phoneNumber)) {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: 'phoneNumber must be 8-15 digits' }));
+        return;
+      }
+
+      try {
+        ownerChangeCallback(phoneNumber);
+        res.writeHead(200);
+        res.end(JSON.stringify({ success: true, owner: phoneNumber }));
+      } catch (error) {
+        logger.error('Owner change failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Owner change failed' }));
+      }
+      return;
+    }
+
+    // Traffic stats endpoint (requires admin token)
+    if (req.method === 'GET' && url === '/api/stats') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!statsCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Stats not ready' }));
+        return;
+      }
+
+      res.writeHead(200);
+      res.end(JSON.stringify({ hours: statsCallback() }));
+      return;
+    }
+
+    // Circuit breaker and rate limiter endpoint (requires admin token)
+    if (req.method === 'GET' && url === '/api/limits') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!limitsCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Limits not ready' }));
+        return;
+      }
+
+      const { circuitBreaker, rateLimit } = limitsCallback();
+      res.writeHead(200);
+      res.end(JSON.stringify({ circuitBreaker, rateLimit }));
+      return;
+    }
+
+    // Per-number activity endpoint (requires admin token)
+    if (req.method === 'GET' && url === '/api/activity') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!activityCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Activity log not ready' }));
+        return;
+      }
+
+      res.writeHead(200);
+      res.end(JSON.stringify({ numbers: activityCallback() }));
+      return;
+    }
+
+    // Shutdown preparation endpoint (requires admin token); the manager
+    // stops the containers once it answers
+    if (req.method === 'POST' && url === '/api/shutdown') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!shutdownCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Bridge not ready' }));
+        return;
+      }
+
+      let goodbye = false;
+      try {
+        const body = await readBody(req, MAX_SHUTDOWN_BODY_BYTES);
+        goodbye = body.trim() !== '' && JSON.parse(body).goodbye === true;
+      } catch {
+        res.writeHead(400);
+        res.end(JSON.stringify({ error: 'Expected an empty body or JSON like {"goodbye": true}' }));
+        return;
+      }
+
+      try {
+        const pending = await shutdownCallback(goodbye);
+        res.writeHead(200);
+        res.end(JSON.stringify({ success: true, pending }));
+      } catch (error) {
+        logger.error('Shutdown preparation failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Shutdown preparation failed' }));
+      }
+      return;
+    }
+
+    // Shutdown cancel endpoint (requires admin token); the manager calls it
+    // when the stop is cancelled or compose down fails
+    if (req.method === 'POST' && url === '/api/shutdown/cancel') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!shutdownCancelCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Bridge not ready' }));
+        return;
+      }
+
+      res.writeHead(200);
+      res.end(JSON.stringify({ success: true, resumed: shutdownCancelCallback() }));
+      return;
+    }
+
+    // Task queue endpoint (requires admin token)
+    if (req.method === 'GET' && url === '/api/tasks') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!tasksCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Task manager not ready' }));
+        return;
+      }
+
+      try {
+        const tasks = await tasksCallback();
+        res.writeHead(200);
+        res.end(JSON.stringify({ tasks }));
+      } catch (error) {
+        logger.error('Listing tasks failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Listing tasks failed' }));
+      }
+      return;
+    }
+
+    // Task cancel and retry endpoints (requires admin token); errors carry
+    // a message the manager shows as is
+    const taskAction = req.method === 'POST' ? TASK_ACTION_PATTERN.exec(url) : null;
+    if (taskAction) {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ success: false, message: 'Unauthorized' }));
+        return;
+      }
+      if (!taskActionCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ success: false, message: 'Task manager not ready' }));
+        return;
+      }
+
+      const taskId = decodePathSegment(taskAction[1]);
+      if (!taskId) {
+        res.writeHead(400);
+        res.end(JSON.stringify({ success: false, message: 'Malformed task ID' }));
+        return;
+      }
+      const action = taskAction[2] as TaskAction;
+      try {
+        await taskActionCallback(taskId, action);
+        res.writeHead(200);
+        res.end(JSON.stringify({ success: true }));
+      } catch (error) {
+        if (error instanceof StatusApiError) {
+          res.writeHead(error.statusCode);
+          res.end(JSON.stringify({ success: false, message: error.message }));
+          return;
+        }
+        logger.error(`Task ${action} failed:`, error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ success: false, message: error instanceof Error ? error.message : `Task ${action} failed` }));
+      }
+      return;
+    }
+
+    // Task respond endpoint (requires admin token); errors carry a message
+    // the manager shows as is
+    const taskRespond = req.method === 'POST' ? TASK_RESPOND_PATTERN.exec(url) : null;
+    if (taskRespond) {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ success: false, message: 'Unauthorized' }));
+        return;
+      }
+      if (!taskRespondCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ success: false, message: 'Task manager not ready' }));
+        return;
+      }
+
+      const taskId = decodePathSegment(taskRespond[1]);
+      if (!taskId) {
+        res.writeHead(400);
+        res.end(JSON.stringify({ success: false, message: 'Malformed task ID' }));
+        return;
+      }
+      let response: unknown;
+      try {
+        const body = JSON.parse(await readBody(req, MAX_TASK_RESPONSE_BYTES));
+        response = body?.response;
+      } catch {
+        res.writeHead(400);
+        res.end(JSON.stringify({ success: false, message: 'Invalid request body' }));
+        return;
+      }
+      if (typeof response !== 'string' || !response.trim()) {
+        res.writeHead(400);
+        res.end(JSON.stringify({ success: false, message: 'response must be a non-empty string' }));
+        return;
+      }
+
+      try {
+        await taskRespondCallback(taskId, response);
+        res.writeHead(200);
+        res.end(JSON.stringify({ success: true }));
+      } catch (error) {
+        if (error instanceof StatusApiError) {
+          res.writeHead(error.statusCode);
+          res.end(JSON.stringify({ success: false, message: error.message }));
+          return;
+        }
+        logger.error('Task respond failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ success: false, message: error instanceof Error ? error.message : 'Task respond failed' }));
+      }
+      return;
+    }
+
+    // Conversation summaries endpoint (requires admin token)
+    if (req.method === 'GET' && url === '/api/summaries') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!summariesCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Sessions not ready' }));
+        return;
+      }
+
+      try {
+        const { summaries, failures } = await summariesCallback();
+        res.writeHead(200);
+        res.end(JSON.stringify({ summaries, failures }));
+      } catch (error) {
+        logger.error('Listing summaries failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Listing summaries failed' }));
+      }
+      return;
+    }
+
+    // Workspace listing endpoint (requires admin token)
+    if (req.method === 'GET' && url === '/api/workspaces') {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ error: 'Unauthorized' }));
+        return;
+      }
+      if (!workspacesCallback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ error: 'Workspaces not ready' }));
+        return;
+      }
+
+      try {
+        const workspaces = await workspacesCallback();
+        res.writeHead(200);
+        res.end(JSON.stringify({ workspaces }));
+      } catch (error) {
+        logger.error('Listing workspaces failed:', error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ error: error instanceof Error ? error.message : 'Listing workspaces failed' }));
+      }
+      return;
+    }
+
+    // Workspace diff and delete endpoints (requires admin token); errors
+    // carry a message the manager shows as is
+    const workspaceDiff = req.method === 'GET' ? WORKSPACE_DIFF_PATTERN.exec(url) : null;
+    const workspaceEntry = req.method === 'DELETE' ? WORKSPACE_ENTRY_PATTERN.exec(url) : null;
+    if (workspaceDiff || workspaceEntry) {
+      res.setHeader('Content-Type', 'application/json');
+
+      const authHeader = req.headers.authorization;
+      if (!authHeader || authHeader !== `Bearer ${ADMIN_TOKEN}`) {
+        res.writeHead(401);
+        res.end(JSON.stringify({ success: false, message: 'Unauthorized' }));
+        return;
+      }
+      const callback = workspaceDiff ? workspaceDiffCallback : workspaceDeleteCallback;
+      if (!callback) {
+        res.writeHead(503);
+        res.end(JSON.stringify({ success: false, message: 'Workspaces not ready' }));
+        return;
+      }
+
+      const workspaceId = decodePathSegment((workspaceDiff ?? workspaceEntry)![1]);
+      if (!workspaceId) {
+        res.writeHead(400);
+        res.end(JSON.stringify({ success: false, message: 'Malformed workspace ID' }));
+        return;
+      }
+      try {
+        if (workspaceDiff) {
+          const diff = await workspaceDiffCallback!(workspaceId);
+          res.writeHead(200);
+          res.end(JSON.stringify({ diff }));
+        } else {
+          await workspaceDeleteCallback!(workspaceId);
+          res.writeHead(200);
+          res.end(JSON.stringify({ success: true }));
+        }
+      } catch (error) {
+        if (error instanceof StatusApiError) {
+          res.writeHead(error.statusCode);
+          res.end(JSON.stringify({ success: false, message: error.message }));
+          return;
+        }
+        const action = workspaceDiff ? 'diff' : 'delete';
+        logger.error(`Workspace ${action} failed:`, error);
+        res.writeHead(500);
+        res.end(JSON.stringify({ success: false, message: error instanceof Error ? error.message : `Workspace ${action} failed` }));
+      }
+      return;
+    }
+
+    // Documentation Routes
+    if (req.method === 'GET' && (url === '/docs' || url === '/docs/')) {
+      res.writeHead(302, { Location: '/docs/index.html' });
+      res.end();
+      return;
+    }
+    
+    if (req.method === 'GET' && url.startsWith('/docs/')) {
+      const filePath = path.join(DOCS_PATH, url.slice(6)); // Remove '/docs/'
+      serveStaticFile(filePath, res);
+      return;
+    }
+    
+    // Root redirect to docs
+    if (req.method === 'GET' && url === '/') {
+      res.writeHead(302, { Location: '/docs/' });
+      res.end();
       return;
     }
     
@@ -245,18 +1437,6 @@ export function startStatusServer(): void {
     res.writeHead(404);
     res.end(JSON.stringify({ error: 'Not found' }));
   });
-
-  server.listen(PORT, '0.0.0.0', () => {
-    logger.info(`Status API listening on port ${PORT}`);
-    logger.info(`Documentation available at http://localhost:${PORT}/docs`);
-    if (!env.ADMIN_TOKEN) {
-      logger.info(`Admin token (auto-generated): ${ADMIN_TOKEN}`);
-    }
-  });
-
-  server.on('error', (err) => {
-    logger.error('Status API server error:', err);
-  });
 }
 
 /**
@@ -69,15 +69,25 @@ type Message = pkg.Message;
 type ClientType = InstanceType<typeof Client>;
 import { logger } from '../utils/logger.js';
 import { pipeline } from '../config/pipeline.js';
-import { SecurityGate, RateLimiter, validateInput } from '../security/index.js';
+import { SecurityGate, RateLimiter, validateInput, type RateLimitStatus } from '../security/index.js';
 import { handleMessage, initializeHandler, shutdown, registerWhatsAppSender } from '../handler/index.js';
 import { getTaskIntegration } from '../task/integration.js';
-import { updateStatus, incrementMessageCount } from '../api/status.js';
+import { updateStatus, getStatus, incrementMessageCount } from '../api/status.js';
 import { transcribeAudio, isTranscriptionAvailable } from '../transcription/index.js';
 import { analyzeImage, isVisionAvailable } from '../vision/index.js';
 import * as fs from 'fs';
 import * as path from 'path';
 
+// =============================================================================
+// SHUTDOWN
+// =============================================================================
+
+/**
+ * Longest a drain keeps new messages out when no stop follows it, e.g.
+ * when the manager quits in the middle of Stop Fetch
+ */
+const DRAIN_RESUME_MS = 2 * 60 * 1000;
+
 // =============================================================================
 // TASK EVENT INTERFACES
 // =============================================================================
@@ -206,6 +216,17 @@ class MessageDeduplicator {
 // BRIDGE CLASS
 // =============================================================================
 
+/**
+ * A WhatsApp group as listed to the manager.
+ * @interface
+ */
+export interface GroupSummary {
+  /** Group JID (…@g.us) */
+  id: string;
+  name: string;
+  participants: number;
+}
+
 /**
  * WhatsApp Web bridge client.
  * 
@@ -228,6 +249,11 @@ export class Bridge {
   private isReconnecting = false;
   private destroyed = false;
 
+  // Shutdown drain state
+  private draining = false;
+  private drainResumeTimer: ReturnType<typeof setTimeout> | null = null;
+  private inFlight = 0;
+
   constructor() {
     this.client = new Client({
       authStrategy: new LocalAuth({
@@ -305,7 +331,13 @@ export class Bridge {
 
     // Ready event
     this.client.on('ready', () => {
-      updateStatus({ state: 'authenticated', qrCode: null, qrUrl: null });
+      const info = this.client.info;
+      updateStatus({
+        state: 'authenticated',
+        qrCode: null,
+        qrUrl: null,
+        device: info ? { name: info.pushname || null, phone: info.wid?.user || null } : null
+      });
 
       // Reset reconnection state on successful connection
       if (this.isReconnecting) {
@@ -320,9 +352,11 @@ export class Bridge {
       logger.divider();
     });
 
-    // Authentication success
+    // Authentication success; 'ready' follows once chats have loaded
     this.client.on('authenticated', () => {
-      updateStatus({ state: 'authenticated', qrCode: null, qrUrl: null });
+      if (getStatus().state !== 'authenticated') {
+        updateStatus({ state: 'authenticating', qrCode: null, qrUrl: null });
+      }
       logger.success('WhatsApp authentication successful');
     });
 
@@ -334,7 +368,7 @@ export class Bridge {
 
     // Disconnected — attempt reconnection with exponential backoff
     this.client.on('disconnected', (reason: string) => {
-      updateStatus({ state: 'disconnected', lastError: reason });
+      updateStatus({ state: 'disconnected', lastError: reason, device: null });
       logger.warn('WhatsApp disconnected', reason);
 
       // Attempt reconnection unless intentionally destroyed
@@ -394,6 +428,13 @@ export class Bridge {
     
     // Use message_create to catch ALL messages including self-chat
     this.client.on('message_create', async (message: Message) => {
+      // Checked before deduplication, so a message skipped here is not
+      // marked seen and is handled if WhatsApp delivers it again
+      if (this.draining) {
+        logger.info('Skipped message: shutting down');
+        return;
+      }
+
       // DEDUPLICATION: WhatsApp fires message_create multiple times for same message
       // eslint-disable-next-line @typescript-eslint/no-explicit-any -- whatsapp-web.js Message.id not in type defs
       const msgId = (message as any).id?._serialized || (message as any).id?.id || String(message.timestamp);
@@ -452,7 +493,12 @@ export class Bridge {
       }
       
       incrementMessageCount();
-      await this.handleIncomingMessage(message, isReplyToFetch);
+      this.inFlight++;
+      try {
+        await this.handleIncomingMessage(message, isReplyToFetch);
+      } finally {
+        this.inFlight--;
+      }
     });
   }
 
@@ -623,11 +669,11 @@ export class Bridge {
     
     // SECURITY GATE 1: Validate authorization
     // For thread replies, we skip the @fetch trigger check but still verify identity
+    const isGroup = senderId.endsWith('@g.us');
+    const checkId: string | undefined = isGroup ? participantId : senderId;
     if (isThreadReply) {
       // For thread replies, verify owner OR trusted whitelist member (no @fetch required)
-      const isGroup = senderId.endsWith('@g.us');
-      const checkId = isGroup ? participantId : senderId;
-      if (!checkId) return;
+      if (!checkId || !this.securityGate.isChatAllowed(senderId)) return;
       if (!this.securityGate.isOwnerMessage(senderId, participantId) &&
           !this.securityGate.getWhitelist()?.has(checkId.replace(/@(c|g|s)\.us$/, '').replace(/\D/g, '') || '')) {
         return;
@@ -639,6 +685,10 @@ export class Bridge {
       }
     }
 
+    // What the sender may ask for; the command parser and tools check it
+    const role = checkId ? this.securityGate.roleOf(checkId) : null;
+    if (!role) return;
+
     // Strip the @fetch trigger from the message (if present)
     const command = this.securityGate.stripTrigger(messageBody);
 
@@ -682,7 +732,8 @@ export class Bridge {
             firstMessageSent = true;
           }
           await message.reply(output);
-        }
+        },
+        role
       );
       
       // Send all response messages
@@ -793,10 +844,149 @@ export class Bridge {
     }, delay);
   }
 
+  // ===========================================================================
+  // GROUPS
+  // ===========================================================================
+
+  /**
+   * Lists the WhatsApp groups this account is in, for the manager's group
+   * allow-list.
+   */
+  async listGroups(): Promise<GroupSummary[]> {
+    const chats = await this.client.getChats();
+    return chats
+      .filter((chat) => chat.isGroup)
+      .map((chat) => ({
+        id: chat.id._serialized,
+        name: chat.name,
+        // eslint-disable-next-line @typescript-eslint/no-explicit-any -- whatsapp-web.js GroupChat.participants not on Chat
+        participants: ((chat as any).participants as unknown[] | undefined)?.length ?? 0,
+      }));
+  }
+
+  // ===========================================================================
+  // RATE LIMITS
+  // ===========================================================================
+
+  /**
+   * Reports the rate limiter's configuration and the senders with requests
+   * in the window, keyed by phone number, for the manager's limits screen.
+   */
+  getRateLimitStatus(): RateLimitStatus {
+    const status = this.rateLimiter.snapshot();
+    return {
+      ...status,
+      keys: status.keys.map((entry) => ({ ...entry, key: entry.key.split('@')[0] })),
+    };
+  }
+
+  // ===========================================================================
+  // OWNER
+  // ===========================================================================
+
+  /**
+   * Sends a verification code to a number before the manager makes it the
+   * owner, proving the number is on WhatsApp and in the user's hands.
+   *
+   * @returns false if the number has no WhatsApp account
+   */
+  async sendOwnerVerification(phoneNumber: string, code: string): Promise<boolean> {
+    const id = await this.client.getNumberId(phoneNumber);
+    if (!id) return false;
+    await this.client.sendMessage(
+      id._serialized,
+      `🐕 Fetch verification code: ${code}\n\nEnter it in the Fetch manager to make this number Fetch's owner. If you didn't ask for this, ignore this message.`
+    );
+    logger.info(`Owner verification code sent to +${phoneNumber}`);
+    return true;
+  }
+
+  /**
+   * Asks WhatsApp for an 8-character code that links this bridge when
+   * typed into the phone, instead of scanning the QR code. Only works
+   * while the QR code is showing.
+   *
+   * @param phoneNumber - The account's number, digits with country code
+   */
+  async requestPairingCode(phoneNumber: string): Promise<string> {
+    const code = await this.client.requestPairingCode(phoneNumber);
+    logger.info(`Pairing code requested for +${phoneNumber}`);
+    return code;
+  }
+
+  /**
+   * Makes a number the owner without a restart.
+   */
+  setOwner(phoneNumber: string): void {
+    this.securityGate.setOwner(phoneNumber);
+  }
+
   // ===========================================================================
   // LIFECYCLE
   // ===========================================================================
 
+  /**
+   * Prepare for a shutdown: stop taking messages, wait for the replies in
+   * progress, and optionally tell the owner Fetch is going away.
+   *
+   * Messages are taken again by {@link resume}, or after
+   * {@link DRAIN_RESUME_MS} if the stop never comes.
+   *
+   * @param timeoutMs - Longest wait for messages in progress
+   * @param goodbye - Send the owner a goodbye message
+   * @returns Messages still in progress when the wait ended
+   */
+  async drain(timeoutMs: number, goodbye: boolean): Promise<number> {
+    this.draining = true;
+    if (this.drainResumeTimer) clearTimeout(this.drainResumeTimer);
+    this.drainResumeTimer = setTimeout(() => {
+      logger.warn('No stop followed the shutdown request; taking messages again');
+      this.resume();
+    }, DRAIN_RESUME_MS);
+    this.drainResumeTimer.unref?.();
+
+    const deadline = Date.now() + timeoutMs;
+    while (this.draining && this.inFlight > 0 && Date.now() < deadline) {
+      await new Promise((resolve) => setTimeout(resolve, 200));
+    }
+    if (!this.draining) {
+      // Resumed while waiting: the stop was cancelled
+      return this.inFlight;
+    }
+    if (this.inFlight > 0) {
+      logger.warn(`Shutting down with ${this.inFlight} message(s) still in progress`);
+    }
+
+    if (goodbye && getStatus().state === 'authenticated') {
+      try {
+        await this.client.sendMessage(
+          `${this.securityGate.getOwnerNumber()}@c.us`,
+          '🐕 Fetch is shutting down. Messages sent until it is back are not answered.'
+        );
+      } catch (error) {
+        logger.error('Failed to send goodbye message', error);
+      }
+    }
+    return this.inFlight;
+  }
+
+  /**
+   * Take messages again after {@link drain}, when the stop was cancelled or
+   * failed and the bridge keeps running.
+   *
+   * @returns false if the bridge wasn't draining
+   */
+  resume(): boolean {
+    if (this.drainResumeTimer) {
+      clearTimeout(this.drainResumeTimer);
+      this.drainResumeTimer = null;
+    }
+    if (!this.draining) return false;
+    this.draining = false;
+    logger.info('Taking messages again: the stop was called off');
+    return true;
+  }
+
   async destroy(): Promise<void> {
     this.destroyed = true;
 
@@ -25,6 +25,7 @@ import { SessionManager } from '../session/manager.js';
 import { formatHelp, formatStatus } from '../agent/format.js';
 import { formatProjectInfo } from '../session/project.js';
 import { handleTrustCommand } from './trust.js';
+import { canRunTasks, canAdminister, type SenderRole } from '../security/gate.js';
 import type { CommandResult } from './types.js';
 
 // Handler modules
@@ -60,6 +61,9 @@ import {
 // Re-export the shared type so existing imports don't break
 export type { CommandResult } from './types.js';
 
+/** Commands a chat-only number may use: they only read */
+const CHAT_COMMANDS = new Set(['help', 'h', '?', 'status', 'st', 'version', 'v', 'projects', 'ls']);
+
 // =============================================================================
 // MAIN ROUTER
 // =============================================================================
@@ -70,12 +74,14 @@ export type { CommandResult } from './types.js';
  * @param message  - Raw user message
  * @param session  - Current session
  * @param sessionManager - Session manager
+ * @param role - What the sender may do; chat-only numbers get read-only commands
  * @returns Parse result — `handled: true` when a command was matched.
  */
 export async function parseCommand(
   message: string,
   session: Session,
-  sessionManager: SessionManager
+  sessionManager: SessionManager,
+  role: SenderRole = 'owner'
 ): Promise<CommandResult> {
   const trimmed = message.trim();
 
@@ -86,6 +92,13 @@ export async function parseCommand(
   const [command, ...args] = trimmed.slice(1).split(/\s+/);
   const argString = args.join(' ');
 
+  if (!canRunTasks(role) && !CHAT_COMMANDS.has(command.toLowerCase())) {
+    return {
+      handled: true,
+      responses: [`🔒 This number is set to chat only, so /${command} isn't available. Ask the owner for task access.`],
+    };
+  }
+
   switch (command.toLowerCase()) {
     // ─── Project Management ────────────────────────────────────────────
     case 'projects':
@@ -204,6 +217,9 @@ export async function parseCommand(
 
     // ─── Security ──────────────────────────────────────────────────────
     case 'trust': {
+      if (!canAdminister(role)) {
+        return { handled: true, responses: ['🔒 Only the owner and admins can manage trusted numbers.'] };
+      }
       const result = await handleTrustCommand(argString);
       return { handled: true, responses: [result.response] };
     }
@@ -15,6 +15,7 @@ import { promisify } from 'util';
 import { mkdir } from 'fs/promises';
 import { join } from 'path';
 import { env } from '../config/env.js';
+import { checkRepoAllowed } from '../security/repos.js';
 import type { CommandResult } from './types.js';
 
 const execAsync = promisify(exec);
@@ -119,6 +120,11 @@ export async function handleClone(
     return { handled: true, responses: ['Invalid git URL. Use HTTPS or SSH format.'] };
   }
 
+  const check = await checkRepoAllowed(url);
+  if (!check.allowed) {
+    return { handled: true, responses: [`🔒 ${check.reason}`] };
+  }
+
   const targetPath = join(WORKSPACE_ROOT, repoName);
 
   try {
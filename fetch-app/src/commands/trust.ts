@@ -180,7 +180,7 @@ async function handleTrustList(): Promise<TrustCommandResult> {
     }
 
     const numberList = numbers
-      .map((num, i) => `${i + 1}. +${num}`)
+      .map((num, i) => `${i + 1}. +${num}${whitelist.has(num) ? '' : ' _(expired)_'}`)
       .join('\n');
 
     return {
@@ -7,13 +7,18 @@
  * @module handler
  * @see {@link processMessage} - Agent entry point
  * @see {@link handleMessage} - Main message handler
+ * @see {@link handleTestMessage} - Manager test console entry point
  */
 
 import { SessionManager, getSessionManager } from '../session/manager.js';
-import { processMessage, type AgentResponse } from '../agent/core.js';
+import { processMessage, type AgentResponse, type ToolCallRecord, type TokenUsage } from '../agent/core.js';
 import { TaskManager, getTaskManager as getPersistentTaskManager } from '../task/manager.js';
-import type { TaskId } from '../task/types.js';
+import type { TaskId, TaskEvent, Task } from '../task/types.js';
+import { getActivityLog } from '../security/activity.js';
+import type { SenderRole } from '../security/gate.js';
+import { getSessionStore } from '../session/store.js';
 import { logger } from '../utils/logger.js';
+import { getTrafficStats } from '../utils/traffic.js';
 
 // =============================================================================
 // SINGLETON STATE
@@ -60,6 +65,21 @@ export async function initializeHandler(): Promise<void> {
   taskManager = await getPersistentTaskManager();
   logger.success('Task manager ready');
 
+  // Audit which number each task was started for
+  taskManager.on('task:created', async (event: TaskEvent) => {
+    const { task } = event.data as { task: Task };
+    try {
+      // Tasks carry the session ID; older code paths pass the user ID
+      const session = await getSessionStore().getById(task.sessionId);
+      const userId = session?.userId ?? (task.sessionId.includes('@') ? task.sessionId : null);
+      if (userId) {
+        getActivityLog().recordTask(userId, task.id, task.goal);
+      }
+    } catch (err) {
+      logger.warn('Failed to record task activity', err);
+    }
+  });
+
   // Log if there's an active task from previous session
   const currentTask = taskManager.getCurrentTask();
   if (currentTask && ['pending', 'running', 'waiting_input'].includes(currentTask.status)) {
@@ -137,13 +157,81 @@ export async function initializeHandler(): Promise<void> {
  * @param userId - WhatsApp JID (phone number)
  * @param message - Incoming message text
  * @param onProgress - Optional callback for intermediate messages
+ * @param role - What the sender may ask for (from the security gate)
  * @returns Array of response messages to send
  */
 export async function handleMessage(
   userId: string,
   message: string,
-  onProgress?: (text: string) => Promise<void>
+  onProgress?: (text: string) => Promise<void>,
+  role: SenderRole = 'owner'
 ): Promise<string[]> {
+  getActivityLog().recordMessage(userId, message);
+  getTrafficStats().record('messages');
+  const { responses, failed } = await runMessage(userId, message, onProgress, role);
+  if (failed) {
+    getTrafficStats().record('errors');
+  }
+  return responses;
+}
+
+/**
+ * Session ID used by the manager's test console, kept apart from real chats
+ */
+export const TEST_CONSOLE_USER_ID = 'manager-console';
+
+/**
+ * Result of a test console message
+ */
+export interface TestMessageResult {
+  /** Messages that would have been sent back on WhatsApp */
+  responses: string[];
+  /** Intermediate progress messages, in order */
+  progress: string[];
+  /** Tools the agent called */
+  toolCalls: ToolCallRecord[];
+  /** Tokens spent (null for slash commands and instinct replies) */
+  usage: TokenUsage | null;
+  /** Detected conversation mode */
+  mode: string | null;
+  /** Wall-clock time in milliseconds */
+  durationMs: number;
+}
+
+/**
+ * Run a message through the full pipeline as if it came from WhatsApp,
+ * returning the agent's details alongside the replies.
+ *
+ * @param message - Message text
+ * @returns Replies, progress, tool calls and token usage
+ */
+export async function handleTestMessage(message: string): Promise<TestMessageResult> {
+  const startTime = Date.now();
+  const progress: string[] = [];
+  const { responses, agent } = await runMessage(TEST_CONSOLE_USER_ID, message, async (text) => {
+    progress.push(text);
+  });
+  return {
+    responses,
+    progress,
+    toolCalls: agent?.toolCalls ?? [],
+    usage: agent?.usage ?? null,
+    mode: agent?.mode ?? null,
+    durationMs: Date.now() - startTime,
+  };
+}
+
+/**
+ * Shared body of handleMessage and handleTestMessage. The agent response is
+ * absent when a slash command or an error produced the replies; failed
+ * marks the error. The test console speaks for the owner.
+ */
+async function runMessage(
+  userId: string,
+  message: string,
+  onProgress?: (text: string) => Promise<void>,
+  role: SenderRole = 'owner'
+): Promise<{ responses: string[]; agent?: AgentResponse; failed?: boolean }> {
   // Ensure initialized
   if (!initialized) {
     await initializeHandler();
@@ -166,15 +254,15 @@ export async function handleMessage(
     // Check for slash commands (these bypass the agent)
     if (message.startsWith('/')) {
       const { parseCommand } = await import('../commands/parser.js');
-      const result = await parseCommand(message, session, sManager);
+      const result = await parseCommand(message, session, sManager, role);
       if (result.handled) {
         // Format slash command responses for WhatsApp too
-        return (result.responses || []).map(r => formatForWhatsApp(r));
+        return { responses: (result.responses || []).map(r => formatForWhatsApp(r)) };
       }
     }
 
     // Process with agent
-    const response = await processMessage(message, session, onProgress);
+    const response = await processMessage(message, session, onProgress, role);
 
     // Build response array
     const responses = buildResponses(response);
@@ -188,15 +276,18 @@ export async function handleMessage(
     await sManager.addUserMessage(session, message);
     await sManager.addAssistantMessage(session, response.text);
 
-    return responses;
+    return { responses, agent: response };
   } catch (error) {
     logger.error('[V2] Message handling failed', error);
 
     const errorMessage =
       error instanceof Error ? error.message : 'Unknown error';
-    return [
-      `🐕 Oops! Something went wrong: ${errorMessage}\n\nTry again or type /help.`,
-    ];
+    return {
+      responses: [
+        `🐕 Oops! Something went wrong: ${errorMessage}\n\nTry again or type /help.`,
+      ],
+      failed: true,
+    };
   }
 }
 
@@ -61,16 +61,31 @@
 import 'dotenv/config';
 import { Bridge } from './bridge/client.js';
 import { logger } from './utils/logger.js';
-import { startStatusServer, setLogoutCallback } from './api/status.js';
-import { initModes } from './modes/index.js';
+import { startStatusServer, setLogoutCallback, setPairingCodeCallback, setTestMessageCallback, setWhitelistReloadCallback, setWhitelistCallback, setWhitelistAddCallback, setWhitelistUpdateCallback, setWhitelistRemoveCallback, setGroupsCallback, setOwnerVerifyCallback, setOwnerChangeCallback, setActivityCallback, setLimitsCallback, setStatsCallback, setShutdownCallback, setShutdownCancelCallback, setTasksCallback, setTaskActionCallback, setTaskRespondCallback, setSummariesCallback, setWorkspacesCallback, setWorkspaceDiffCallback, setWorkspaceDeleteCallback, StatusApiError, updateStatus } from './api/status.js';
+import { handleTestMessage } from './handler/index.js';
+import { initModes, getModeManager, FetchMode } from './modes/index.js';
 import { getProactiveSystem } from './proactive/index.js';
 import { validateEnv } from './config/env.js';
 import { getSessionStore } from './session/store.js';
+import { getSessionManager } from './session/manager.js';
 import { getTaskStore } from './task/store.js';
+import { getTaskManager } from './task/manager.js';
+import { getTaskIntegration } from './task/integration.js';
+import type { TaskId } from './task/types.js';
+import { getWhitelistStore, getActivityLog } from './security/index.js';
+import { getTrafficStats } from './utils/traffic.js';
+import { getCircuitBreakerStatus } from './agent/core.js';
+import { workspaceManager } from './workspace/manager.js';
 
 /** Module-scoped bridge reference for graceful shutdown */
 let activeBridge: Bridge | null = null;
 
+/** Longest wait for messages in progress when the manager stops Fetch */
+const SHUTDOWN_DRAIN_MS = 15_000;
+
+/** Most tasks listed on the manager's task queue screen */
+const TASK_LIST_LIMIT = 50;
+
 /**
  * Main application entry point.
  * 
@@ -103,6 +118,11 @@ async function main(): Promise<void> {
 
   try {
     const bridge = new Bridge();
+
+    // The manager's setup screen can link by phone number instead of the
+    // QR code, which shows while the bridge initializes
+    setPairingCodeCallback((phoneNumber) => bridge.requestPairingCode(phoneNumber));
+
     await bridge.initialize();
     activeBridge = bridge;
     
@@ -111,8 +131,169 @@ async function main(): Promise<void> {
       logger.info('🔌 Logout requested via API, destroying bridge...');
       await bridge.destroy();
       activeBridge = null;
+      updateStatus({ state: 'disconnected', lastError: null, device: null });
       logger.info('✅ Bridge destroyed, WhatsApp disconnected');
     });
+
+    // Let the manager's test console drive the agent without WhatsApp
+    setTestMessageCallback(async (message) => {
+      logger.info('🧪 Test console message received');
+      return handleTestMessage(message);
+    });
+
+    // The manager edits data/whitelist.json directly and asks for a reload
+    setWhitelistReloadCallback(async () => (await getWhitelistStore()).reload());
+
+    // While the bridge runs, the Trusted Numbers screen edits it through the API
+    setWhitelistCallback(async () => (await getWhitelistStore()).snapshot());
+    setWhitelistAddCallback(async (phoneNumber, contact) => (await getWhitelistStore()).add(phoneNumber, contact));
+    setWhitelistUpdateCallback(async (phoneNumber, contact) => (await getWhitelistStore()).setContact(phoneNumber, contact));
+    setWhitelistRemoveCallback(async (phoneNumber) => (await getWhitelistStore()).remove(phoneNumber));
+
+    // What each number did, for the manager's Trusted Numbers screen
+    setActivityCallback(() => getActivityLog().all());
+
+    // Hourly traffic for the manager's Message Statistics screen
+    setStatsCallback(() => getTrafficStats().hours());
+
+    // Circuit breaker and rate limiter state for the manager's limits screen
+    setLimitsCallback(() => ({
+      circuitBreaker: getCircuitBreakerStatus(),
+      rateLimit: bridge.getRateLimitStatus(),
+    }));
+
+    // Groups to pick from on the manager's group allow-list
+    setGroupsCallback(() => bridge.listGroups());
+
+    // The manager's owner change flow verifies the number, then switches it
+    setOwnerVerifyCallback((phoneNumber, code) => bridge.sendOwnerVerification(phoneNumber, code));
+    setOwnerChangeCallback((phoneNumber) => bridge.setOwner(phoneNumber));
+
+    // The manager's task queue screen lists tasks and cancels or retries them
+    setTasksCallback(async () => (await getTaskManager()).getRecentTasks(TASK_LIST_LIMIT));
+    setTaskActionCallback(async (taskId, action) => {
+      const task = (await getTaskManager()).getTask(taskId as TaskId);
+      if (!task) {
+        throw new StatusApiError(404, `Task not found: ${taskId}`);
+      }
+      const finished = ['completed', 'failed', 'cancelled'].includes(task.status);
+      if (action === 'cancel') {
+        if (finished) {
+          throw new StatusApiError(409, `Task is already ${task.status}`);
+        }
+        await getTaskIntegration().cancelExecution(task.id);
+        return;
+      }
+      if (task.status !== 'failed' && task.status !== 'cancelled') {
+        throw new StatusApiError(409, `Only failed or cancelled tasks can be retried; this one is ${task.status}`);
+      }
+      try {
+        await getTaskIntegration().retryTask(task.id);
+      } catch (error) {
+        // Another task is running; the kennel takes one at a time
+        throw new StatusApiError(409, error instanceof Error ? error.message : String(error));
+      }
+    });
+
+    // The manager answers a task's question the way a WhatsApp reply would
+    setTaskRespondCallback(async (taskId, response) => {
+      const task = (await getTaskManager()).getTask(taskId as TaskId);
+      if (!task) {
+        throw new StatusApiError(404, `Task not found: ${taskId}`);
+      }
+      if (task.status !== 'waiting_input') {
+        throw new StatusApiError(409, `Task is ${task.status}, not waiting for an answer`);
+      }
+      try {
+        await getTaskIntegration().respondToTask(task.id, response);
+      } catch (error) {
+        // The harness exited or stopped asking before the answer arrived
+        throw new StatusApiError(409, error instanceof Error ? error.message : String(error));
+      }
+      // Release the lock WhatsApp would otherwise still be waiting on
+      const modes = getModeManager();
+      if (modes.getState().mode === FetchMode.GUARDING) {
+        await modes.transitionTo(FetchMode.WORKING, 'Task answered from the manager');
+      }
+    });
+
+    // What the agent remembers of each conversation, for the manager's
+    // summaries screen
+    setSummariesCallback(async () => {
+      const sessions = await getSessionManager();
+      return {
+        summaries: sessions.getSummaries().map((row) => ({
+          id: row.id,
+          sessionId: row.session_id,
+          threadId: row.thread_id ?? '',
+          content: row.content,
+          createdAt: row.created_at,
+        })),
+        failures: sessions.getSummaryFailures(),
+      };
+    });
+
+    // The manager's workspaces screen lists, diffs and deletes workspaces
+    setWorkspacesCallback(async () => {
+      const tasks = await getTaskManager();
+      const { workspaces } = await workspaceManager.listWorkspaces(true);
+      const details = await Promise.all(workspaces.map((ws) => workspaceManager.getWorkspace(ws.id)));
+      return details.filter((ws) => ws !== null).map((ws) => {
+        const lastTask = tasks.getLastTaskForWorkspace(ws.id);
+        return {
+          id: ws.id,
+          path: ws.path,
+          projectType: ws.projectType,
+          branch: ws.git?.branch ?? '',
+          dirty: ws.git?.dirty ?? false,
+          ahead: ws.git?.ahead ?? 0,
+          behind: ws.git?.behind ?? 0,
+          changedFiles: ws.git
+            ? ws.git.modifiedFiles.length + ws.git.stagedFiles.length + ws.git.untrackedFiles.length
+            : 0,
+          remoteUrl: ws.git?.remoteUrl ?? '',
+          isActive: ws.isActive,
+          lastAccessedAt: ws.lastAccessedAt ?? null,
+          lastTask: lastTask
+            ? { id: lastTask.id, goal: lastTask.goal, status: lastTask.status, completedAt: lastTask.completedAt ?? null }
+            : null,
+        };
+      });
+    });
+    setWorkspaceDiffCallback(async (workspaceId) => {
+      const diff = await workspaceManager.getWorkspaceDiff(workspaceId);
+      if (diff === null) {
+        throw new StatusApiError(404, `Workspace not found: ${workspaceId}`);
+      }
+      return diff;
+    });
+    setWorkspaceDeleteCallback(async (workspaceId) => {
+      if (!(await workspaceManager.getWorkspace(workspaceId))) {
+        throw new StatusApiError(404, `Workspace not found: ${workspaceId}`);
+      }
+      if (workspaceId === workspaceManager.getActiveWorkspaceId()) {
+        throw new StatusApiError(409, 'Cannot delete the active workspace. Select a different workspace first.');
+      }
+      const tasks = await getTaskManager();
+      const current = tasks.getCurrentTask();
+      if (tasks.hasRunningTask() && current?.workspace === workspaceId) {
+        throw new StatusApiError(409, `Task ${current.id} is running in this workspace`);
+      }
+      await workspaceManager.deleteWorkspace(workspaceId);
+    });
+
+    // Stop Fetch in the manager lets replies in progress finish and flushes
+    // data before `docker compose down`
+    setShutdownCallback(async (goodbye) => {
+      logger.info('🛑 Shutdown requested via API, finishing messages in progress...');
+      const pending = await bridge.drain(SHUTDOWN_DRAIN_MS, goodbye);
+      await getActivityLog().persist();
+      try { getSessionStore().flush(); } catch { /* may not be initialized */ }
+      try { getTaskStore().flush(); } catch { /* may not be initialized */ }
+      logger.info('✅ Ready to stop');
+      return pending;
+    });
+    setShutdownCancelCallback(() => bridge.resume());
     
     logger.info('✅ Fetch Bridge is ready and listening!');
   } catch (error) {
@@ -157,6 +338,7 @@ async function shutdown(signal: string): Promise<void> {
     }
 
     // 4. Flush & close SQLite databases
+    try { await getActivityLog().persist(); } catch { /* best effort */ }
     try { getSessionStore().close(); } catch { /* may not be initialized */ }
     try { getTaskStore().close(); } catch { /* may not be initialized */ }
   } catch (error) {
@@ -191,5 +373,14 @@ process.on('uncaughtException', (error: Error) => {
 process.on('SIGINT', () => { shutdown('SIGINT'); });
 process.on('SIGTERM', () => { shutdown('SIGTERM'); });
 
+/**
+ * SIGHUP reloads the whitelist (sent by the manager when the API is down).
+ */
+process.on('SIGHUP', () => {
+  getWhitelistStore()
+    .then((store) => store.reload())
+    .catch((error) => logger.error('Whitelist reload on SIGHUP failed', { error }));
+});
+
 // Start the application
 main();
@@ -0,0 +1,238 @@
+/**
+ * @fileoverview Activity Log - Per-Number Audit Trail
+ *
+ * Records what each number that reached the agent actually did: when it
+ * was last seen, how many messages it sent, which slash commands it issued
+ * and which tasks it started. The manager shows this next to each trusted
+ * number so the owner can audit the access they granted.
+ *
+ * @module security/activity
+ * @see {@link ActivityLog} - Main activity class
+ *
+ * ## Data Source
+ *
+ * File: data/activity.json (written a few seconds after each change)
+ *
+ * ## Privacy
+ *
+ * Only slash commands and task goals are kept as text, truncated; ordinary
+ * messages are counted, not stored.
+ *
+ * @example
+ * ```typescript
+ * const log = getActivityLog();
+ * log.recordMessage('15551234567@c.us', '/status');
+ * log.get('15551234567'); // { messages: 1, commands: { '/status': 1 }, ... }
+ * ```
+ */
+
+import { promises as fs } from 'fs';
+import { join, dirname } from 'path';
+import { fileURLToPath } from 'url';
+import { logger } from '../utils/logger.js';
+
+// =============================================================================
+// CONFIGURATION
+// =============================================================================
+
+const __dirname = dirname(fileURLToPath(import.meta.url));
+const DATA_DIR = join(__dirname, '..', '..', 'data');
+const ACTIVITY_FILE = join(DATA_DIR, 'activity.json');
+
+/** Recent events kept per number */
+const MAX_RECENT_EVENTS = 50;
+
+/** Longest command or task goal kept, in characters */
+const MAX_DETAIL_LENGTH = 80;
+
+/** Delay before writing changes, so bursts of messages write once */
+const PERSIST_DELAY_MS = 2000;
+
+// =============================================================================
+// TYPES
+// =============================================================================
+
+/** One audited action */
+export interface ActivityEvent {
+  /** When it happened (ISO 8601) */
+  at: string;
+  /** A slash command or a task the agent started */
+  kind: 'command' | 'task';
+  /** The command line, or the task ID and goal */
+  detail: string;
+}
+
+/** What one number has done */
+export interface NumberActivity {
+  /** First message seen (ISO 8601) */
+  firstSeen: string;
+  /** Latest message seen (ISO 8601) */
+  lastSeen: string;
+  /** Messages that reached the agent, commands included */
+  messages: number;
+  /** Slash command name → times issued */
+  commands: Record<string, number>;
+  /** Tasks started on the number's behalf */
+  tasksStarted: number;
+  /** Latest commands and tasks, newest last */
+  recent: ActivityEvent[];
+}
+
+interface ActivityData {
+  /** Normalized number → activity */
+  numbers: Record<string, NumberActivity>;
+  /** Version for future migrations */
+  version: number;
+}
+
+// =============================================================================
+// ACTIVITY LOG CLASS
+// =============================================================================
+
+/**
+ * Keeps per-number activity in memory and persists it to JSON.
+ *
+ * @class
+ */
+export class ActivityLog {
+  private numbers: Record<string, NumberActivity> = {};
+  private loaded: Promise<void> | null = null;
+  private persistTimer: ReturnType<typeof setTimeout> | null = null;
+
+  /**
+   * Load the file once. Recording before the load finishes is safe; the
+   * loaded entries are merged under the new ones.
+   */
+  initialize(): Promise<void> {
+    if (!this.loaded) {
+      this.loaded = this.loadFromFile();
+    }
+    return this.loaded;
+  }
+
+  private async loadFromFile(): Promise<void> {
+    try {
+      const content = await fs.readFile(ACTIVITY_FILE, 'utf-8');
+      const data: ActivityData = JSON.parse(content);
+      this.numbers = { ...(data.numbers ?? {}), ...this.numbers };
+    } catch (error) {
+      if ((error as NodeJS.ErrnoException).code !== 'ENOENT') {
+        logger.warn('Failed to load activity file', error);
+      }
+    }
+  }
+
+  /**
+   * Normalize a WhatsApp ID or phone number to digits.
+   */
+  private normalize(id: string): string {
+    return id.replace(/@(c|g|s)\.us$/, '').replace(/\D/g, '');
+  }
+
+  private entry(id: string): NumberActivity | null {
+    const number = this.normalize(id);
+    if (!number) return null;
+    const now = new Date().toISOString();
+    this.numbers[number] ??= {
+      firstSeen: now,
+      lastSeen: now,
+      messages: 0,
+      commands: {},
+      tasksStarted: 0,
+      recent: [],
+    };
+    return this.numbers[number];
+  }
+
+  private addEvent(entry: NumberActivity, kind: ActivityEvent['kind'], detail: string): void {
+    const trimmed = detail.length > MAX_DETAIL_LENGTH ? `${detail.slice(0, MAX_DETAIL_LENGTH - 1)}…` : detail;
+    entry.recent.push({ at: new Date().toISOString(), kind, detail: trimmed });
+    if (entry.recent.length > MAX_RECENT_EVENTS) {
+      entry.recent.splice(0, entry.recent.length - MAX_RECENT_EVENTS);
+    }
+  }
+
+  /**
+   * Record a message that passed the security gate.
+   *
+   * @param userId - Sender's WhatsApp ID or number
+   * @param text - Message with the @fetch trigger stripped
+   */
+  recordMessage(userId: string, text: string): void {
+    const entry = this.entry(userId);
+    if (!entry) return;
+    entry.lastSeen = new Date().toISOString();
+    entry.messages++;
+    if (text.startsWith('/')) {
+      const name = text.split(/\s+/, 1)[0].toLowerCase();
+      entry.commands[name] = (entry.commands[name] ?? 0) + 1;
+      this.addEvent(entry, 'command', text);
+    }
+    this.schedulePersist();
+  }
+
+  /**
+   * Record a task the agent started for a number.
+   *
+   * @param userId - WhatsApp ID or number the task was started for
+   * @param taskId - Task ID
+   * @param goal - Task goal
+   */
+  recordTask(userId: string, taskId: string, goal: string): void {
+    const entry = this.entry(userId);
+    if (!entry) return;
+    entry.tasksStarted++;
+    this.addEvent(entry, 'task', `${taskId}: ${goal}`);
+    this.schedulePersist();
+  }
+
+  /**
+   * Activity of one number, or null if it was never seen.
+   */
+  get(phoneNumber: string): NumberActivity | null {
+    return this.numbers[this.normalize(phoneNumber)] ?? null;
+  }
+
+  /**
+   * Activity of every number seen.
+   */
+  all(): Record<string, NumberActivity> {
+    return this.numbers;
+  }
+
+  private schedulePersist(): void {
+    if (this.persistTimer) return;
+    this.persistTimer = setTimeout(() => {
+      this.persistTimer = null;
+      this.persist().catch((error) => logger.error('Failed to persist activity', error));
+    }, PERSIST_DELAY_MS);
+    this.persistTimer.unref?.();
+  }
+
+  /**
+   * Write the activity file now.
+   */
+  async persist(): Promise<void> {
+    await this.initialize();
+    await fs.mkdir(DATA_DIR, { recursive: true });
+    const data: ActivityData = { numbers: this.numbers, version: 1 };
+    await fs.writeFile(ACTIVITY_FILE, JSON.stringify(data, null, 2), 'utf-8');
+  }
+}
+
+// =============================================================================
+// SINGLETON
+// =============================================================================
+
+let activityLog: ActivityLog | null = null;
+
+/**
+ * Get the singleton activity log. Starts loading the file on first call.
+ */
+export function getActivityLog(): ActivityLog {
+  if (!activityLog) {
+    activityLog = new ActivityLog();
+    void activityLog.initialize();
+  }
+  return activityLog;
+}
@@ -29,6 +29,14 @@
  *      ↓ No
  * DROP (silent)
  * ```
+ *
+ * ## Roles
+ *
+ * An authorized sender's role limits what Fetch does for them: the owner
+ * may do anything, and trusted numbers have the role set in the manager
+ * (`chat`, `tasks` or `admin`, see {@link TrustRole}). The command parser
+ * and tool registry check it with {@link canRunTasks} and
+ * {@link canAdminister}.
  * 
  * ## Configuration
  * 
@@ -57,7 +65,7 @@
 
 import { logger } from '../utils/logger.js';
 import { env } from '../config/env.js';
-import { getWhitelistStore, type WhitelistStore } from './whitelist.js';
+import { getWhitelistStore, type WhitelistStore, type TrustRole } from './whitelist.js';
 
 // =============================================================================
 // CONFIGURATION
@@ -66,6 +74,26 @@ import { getWhitelistStore, type WhitelistStore } from './whitelist.js';
 /** The trigger prefix (case-insensitive) */
 const FETCH_TRIGGER = '@fetch';
 
+/**
+ * Who a sender is to Fetch: the owner, or a trusted number's role.
+ */
+export type SenderRole = 'owner' | TrustRole;
+
+/**
+ * Whether a sender may start, answer or cancel coding tasks and change
+ * workspaces.
+ */
+export function canRunTasks(role: SenderRole): boolean {
+  return role !== 'chat';
+}
+
+/**
+ * Whether a sender may manage trusted numbers with /trust.
+ */
+export function canAdminister(role: SenderRole): boolean {
+  return role === 'owner' || role === 'admin';
+}
+
 // =============================================================================
 // SECURITY GATE CLASS
 // =============================================================================
@@ -80,7 +108,7 @@ const FETCH_TRIGGER = '@fetch';
  * @throws {Error} If OWNER_PHONE_NUMBER is not set
  */
 export class SecurityGate {
-  private readonly ownerNumberClean: string;
+  private ownerNumberClean: string;
   private whitelist: WhitelistStore | null = null;
 
   constructor() {
@@ -117,6 +145,32 @@ export class SecurityGate {
     logger.divider();
   }
 
+  /**
+   * Change the owner while running (the manager's owner change flow).
+   * Also updates process.env so later reads of OWNER_PHONE_NUMBER agree;
+   * the manager writes the same value to .env for the next start.
+   *
+   * @param phoneNumber - New owner number with country code
+   * @throws {Error} If the number has fewer than 8 digits
+   */
+  setOwner(phoneNumber: string): void {
+    const clean = phoneNumber.replace(/\D/g, '');
+    if (clean.length < 8) {
+      throw new Error(`Invalid owner number: ${phoneNumber}`);
+    }
+    const previous = this.ownerNumberClean;
+    this.ownerNumberClean = clean;
+    process.env.OWNER_PHONE_NUMBER = clean;
+    logger.warn(`Owner changed: +${previous} → +${clean}`);
+  }
+
+  /**
+   * Get the owner number (digits with country code, no +).
+   */
+  getOwnerNumber(): string {
+    return this.ownerNumberClean;
+  }
+
   /**
    * Get the whitelist store for management operations.
    */
@@ -168,6 +222,28 @@ export class SecurityGate {
     return this.whitelist.has(number);
   }
 
+  /**
+   * Get a sender's role.
+   *
+   * @param whatsappId - Sender's WhatsApp ID (for groups, the participant)
+   * @returns 'owner', the trusted number's role, or null if untrusted
+   */
+  roleOf(whatsappId: string): SenderRole | null {
+    if (this.isOwner(whatsappId)) return 'owner';
+    return this.whitelist?.roleOf(this.extractNumber(whatsappId)) ?? null;
+  }
+
+  /**
+   * Check if Fetch may answer in this chat. Direct chats always pass;
+   * groups must be on the group allow-list when it is turned on.
+   *
+   * @param senderId - WhatsApp chat ID (can be @c.us or @g.us)
+   */
+  isChatAllowed(senderId: string): boolean {
+    if (!senderId.endsWith('@g.us')) return true;
+    return this.whitelist?.isGroupAllowed(senderId) ?? true;
+  }
+
   /**
    * Check if message is from the owner (without @fetch requirement)
    * Used for thread replies where @fetch trigger is not needed
@@ -223,6 +299,12 @@ export class SecurityGate {
         return false;
       }
 
+      // Group allow-list applies to everyone, the owner included
+      if (!this.isChatAllowed(senderId)) {
+        logger.debug(`Ignored @fetch in a group that is not allowed: ${senderId}`);
+        return false;
+      }
+
       // Determine which ID to check
       const checkId = isGroup ? participantId : senderId;
       
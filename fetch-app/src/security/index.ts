@@ -9,6 +9,8 @@
  * @see {@link module:security/whitelist} For WhitelistStore trusted numbers
  * @see {@link module:security/rateLimiter} For RateLimiter abuse prevention
  * @see {@link module:security/validator} For input validation utilities
+ * @see {@link module:security/repos} For the repository allow-list
+ * @see {@link module:security/activity} For the per-number activity log
  * 
  * @example
  * ```typescript
@@ -24,7 +26,9 @@
  * ```
  */
 
-export { SecurityGate } from './gate.js';
-export { WhitelistStore, getWhitelistStore, getWhitelistStoreSync } from './whitelist.js';
-export { RateLimiter } from './rateLimiter.js';
+export { SecurityGate, canRunTasks, canAdminister, type SenderRole } from './gate.js';
+export { WhitelistStore, getWhitelistStore, getWhitelistStoreSync, isExpired, TRUST_ROLES, type TrustRole, type TrustedContact, type WhitelistSnapshot } from './whitelist.js';
+export { RateLimiter, type RateLimitStatus, type RateLimitKeyStatus } from './rateLimiter.js';
+export { ActivityLog, getActivityLog, type NumberActivity, type ActivityEvent } from './activity.js';
+export { checkRepoAllowed, repoFromUrl, type RepoCheck } from './repos.js';
 export { validateInput, sanitizePath, type ValidationResult } from './validator.js';
@@ -30,11 +30,36 @@
  * 
  * // Check remaining quota
  * const remaining = limiter.getRemaining(userId);
+ *
+ * // Every active key, for the manager's limits screen
+ * const { keys } = limiter.snapshot();
  * ```
  */
 
 import { logger } from '../utils/logger.js';
 
+// =============================================================================
+// TYPES
+// =============================================================================
+
+/** One key's requests within the current window */
+export interface RateLimitKeyStatus {
+  key: string;
+  /** Requests allowed in the window */
+  count: number;
+  /** Requests left before the key is refused */
+  remaining: number;
+  /** Requests refused in the window */
+  blocked: number;
+}
+
+/** The limiter's configuration and its active keys */
+export interface RateLimitStatus {
+  maxRequests: number;
+  windowMs: number;
+  keys: RateLimitKeyStatus[];
+}
+
 // =============================================================================
 // RATE LIMITER CLASS
 // =============================================================================
@@ -50,6 +75,8 @@ import { logger } from '../utils/logger.js';
 export class RateLimiter {
   /** Per-key arrays of request timestamps (epoch ms) */
   private timestamps: Map<string, number[]> = new Map();
+  /** Per-key arrays of refused request timestamps (epoch ms) */
+  private blockedTimestamps: Map<string, number[]> = new Map();
   private readonly maxRequests: number;
   private readonly windowMs: number;
   private evictionTimer: ReturnType<typeof setInterval> | null = null;
@@ -86,6 +113,12 @@ export class RateLimiter {
 
     if (ts.length >= this.maxRequests) {
       logger.warn(`Rate limit exceeded for ${key}`);
+      const blocked = this.blockedTimestamps.get(key) ?? [];
+      while (blocked.length > 0 && blocked[0] <= cutoff) {
+        blocked.shift();
+      }
+      blocked.push(now);
+      this.blockedTimestamps.set(key, blocked);
       return false;
     }
 
@@ -106,11 +139,27 @@ export class RateLimiter {
     return Math.max(0, this.maxRequests - recent);
   }
 
+  /**
+   * Get the configuration and every key with requests in the window
+   */
+  snapshot(): RateLimitStatus {
+    const cutoff = Date.now() - this.windowMs;
+    const keys: RateLimitKeyStatus[] = [];
+    for (const key of new Set([...this.timestamps.keys(), ...this.blockedTimestamps.keys()])) {
+      const count = (this.timestamps.get(key) ?? []).filter((t) => t > cutoff).length;
+      const blocked = (this.blockedTimestamps.get(key) ?? []).filter((t) => t > cutoff).length;
+      if (count === 0 && blocked === 0) continue;
+      keys.push({ key, count, remaining: Math.max(0, this.maxRequests - count), blocked });
+    }
+    return { maxRequests: this.maxRequests, windowMs: this.windowMs, keys };
+  }
+
   /**
    * Clear rate limit for a key (useful for testing)
    */
   clear(key: string): void {
     this.timestamps.delete(key);
+    this.blockedTimestamps.delete(key);
   }
 
   /**
@@ -118,6 +167,7 @@ export class RateLimiter {
    */
   clearAll(): void {
     this.timestamps.clear();
+    this.blockedTimestamps.clear();
   }
 
   // ---------------------------------------------------------------------------
@@ -127,9 +177,11 @@ export class RateLimiter {
   /** Remove keys whose newest timestamp is older than the window. */
   private evictStale(): void {
     const cutoff = Date.now() - this.windowMs;
-    for (const [key, ts] of this.timestamps) {
-      if (ts.length === 0 || ts[ts.length - 1] <= cutoff) {
-        this.timestamps.delete(key);
+    for (const map of [this.timestamps, this.blockedTimestamps]) {
+      for (const [key, ts] of map) {
+        if (ts.length === 0 || ts[ts.length - 1] <= cutoff) {
+          map.delete(key);
+        }
       }
     }
   }
@@ -0,0 +1,166 @@
+/**
+ * @fileoverview Repository Allow-List
+ *
+ * Limits which GitHub repositories the coding agents may clone and start
+ * tasks in. The list is written by the manager's Repository Allow-List
+ * screen.
+ *
+ * @module security/repos
+ * @see {@link checkRepoAllowed} - Main check
+ *
+ * ## Data Source
+ *
+ * File: data/repos.json (written by the manager, read on every check so
+ * edits apply without a restart)
+ *
+ * ## Security Model
+ *
+ * - No file: every repository is allowed (the behaviour before the list)
+ * - File present: only listed owner/name pairs are allowed
+ * - Unreadable file: everything is refused rather than silently allowed
+ * - Workspaces without a GitHub remote (local projects) are always allowed
+ *
+ * @example
+ * ```typescript
+ * const check = await checkRepoAllowed('https://github.com/octocat/hello-world');
+ * if (!check.allowed) {
+ *   return check.reason;
+ * }
+ * ```
+ */
+
+import { promises as fs } from 'fs';
+import { join, dirname } from 'path';
+import { fileURLToPath } from 'url';
+import { logger } from '../utils/logger.js';
+
+// =============================================================================
+// CONFIGURATION
+// =============================================================================
+
+const __dirname = dirname(fileURLToPath(import.meta.url));
+const DATA_DIR = join(__dirname, '..', '..', 'data');
+const REPOS_FILE = join(DATA_DIR, 'repos.json');
+
+// =============================================================================
+// TYPES
+// =============================================================================
+
+interface RepoAllowListData {
+  /** Allowed repositories as owner/name */
+  allowedRepos: string[];
+  /** Last updated timestamp */
+  updatedAt: string;
+  /** Version for future migrations */
+  version: number;
+}
+
+/** Result of an allow-list check */
+export interface RepoCheck {
+  /** Whether the agents may work on the repository */
+  allowed: boolean;
+  /** The repository as owner/name, when the URL points at GitHub */
+  repo: string | null;
+  /** Why the repository was refused */
+  reason?: string;
+}
+
+// =============================================================================
+// PARSING
+// =============================================================================
+
+/**
+ * Extract owner/name from a GitHub URL.
+ *
+ * Accepts HTTPS (`https://github.com/owner/name(.git)`), SSH
+ * (`git@github.com:owner/name.git`) and bare `owner/name`.
+ *
+ * @param url - Clone URL or remote
+ * @returns Lowercased owner/name, or null for non-GitHub URLs
+ */
+export function repoFromUrl(url: string): string | null {
+  let path = url.trim().replace(/\/+$/, '').replace(/\.git$/, '');
+
+  const ssh = path.match(/^git@github\.com:(.+)$/i);
+  const https = path.match(/github\.com\/(.+)$/i);
+  if (ssh) {
+    path = ssh[1];
+  } else if (https) {
+    path = https[1];
+  } else if (path.includes(':') || path.startsWith('/')) {
+    return null;
+  }
+
+  const parts = path.split('/');
+  if (parts.length !== 2 || !parts[0] || !parts[1]) return null;
+  return path.toLowerCase();
+}
+
+// =============================================================================
+// CHECK
+// =============================================================================
+
+/**
+ * Load the allowed repositories.
+ *
+ * @returns The lowercased owner/name set, or null when there is no list
+ * @throws When the file exists but can't be read
+ */
+async function loadAllowList(): Promise<Set<string> | null> {
+  let content: string;
+  try {
+    content = await fs.readFile(REPOS_FILE, 'utf-8');
+  } catch (error) {
+    if ((error as NodeJS.ErrnoException).code === 'ENOENT') return null;
+    throw error;
+  }
+
+  const data: RepoAllowListData = JSON.parse(content);
+  if (!Array.isArray(data.allowedRepos)) {
+    throw new Error('allowedRepos is not a list');
+  }
+  return new Set(data.allowedRepos.map((r) => r.toLowerCase()));
+}
+
+/**
+ * Check whether the coding agents may work on a repository.
+ *
+ * @param url - Clone URL or git remote; undefined for local projects
+ * @returns Whether the repository is allowed, and why not
+ */
+export async function checkRepoAllowed(url: string | undefined): Promise<RepoCheck> {
+  const repo = url ? repoFromUrl(url) : null;
+
+  let allowList: Set<string> | null;
+  try {
+    allowList = await loadAllowList();
+  } catch (error) {
+    logger.error('Failed to read repository allow-list', error);
+    return {
+      allowed: false,
+      repo,
+      reason: 'The repository allow-list (data/repos.json) could not be read. Fix or remove it in the manager.',
+    };
+  }
+
+  if (!allowList || !url) return { allowed: true, repo };
+
+  if (!repo) {
+    return {
+      allowed: false,
+      repo,
+      reason: 'Only GitHub repositories on the allow-list can be used. Add it in the manager under Git Providers → Repos.',
+    };
+  }
+
+  if (!allowList.has(repo)) {
+    logger.warn('Repository not on allow-list', { repo });
+    return {
+      allowed: false,
+      repo,
+      reason: `${repo} is not on the repository allow-list. Add it in the manager under Git Providers → Repos.`,
+    };
+  }
+
+  return { allowed: true, repo };
+}
@@ -11,6 +11,13 @@
  * 
  * 1. Environment: TRUSTED_PHONE_NUMBERS (comma-separated, loaded at startup)
  * 2. File: data/whitelist.json (runtime additions, persisted)
+ *
+ * The manager may edit the file directly; it then calls
+ * POST /api/whitelist/reload or sends SIGHUP, and {@link WhitelistStore.reload}
+ * re-reads both sources.
+ * 
+ * Numbers the manager added for a while carry an `expiresAt` in their
+ * contact details; {@link WhitelistStore.has} stops trusting them from then.
  * 
  * ## Security Model
  * 
@@ -54,13 +61,82 @@ const WHITELIST_FILE = join(DATA_DIR, 'whitelist.json');
 // TYPES
 // =============================================================================
 
+/**
+ * What a trusted number may ask Fetch to do, set in the manager:
+ * - `chat`: conversation only, no tasks or workspace changes
+ * - `tasks`: chat and run coding tasks (the default)
+ * - `admin`: tasks plus /trust and other admin commands
+ */
+export type TrustRole = 'chat' | 'tasks' | 'admin';
+
+/** Roles a contact may carry; anything else counts as the default */
+export const TRUST_ROLES: readonly TrustRole[] = ['chat', 'tasks', 'admin'];
+
+/**
+ * Details the manager keeps for a trusted number (version 2).
+ */
+export interface TrustedContact {
+  /** Display name, e.g. "Mom" */
+  label?: string;
+  /** Free-form note */
+  note?: string;
+  /** When the number was added (ISO 8601) */
+  addedAt?: string;
+  /** What the number may do; missing means `tasks` */
+  role?: TrustRole;
+  /** When the number stops being trusted (ISO 8601); missing means never */
+  expiresAt?: string;
+}
+
+/**
+ * Check if a contact's expiry has passed. Contacts without one, or with one
+ * that doesn't parse, never expire.
+ *
+ * @param contact - The number's details, if any
+ * @param now - Current time in milliseconds
+ */
+export function isExpired(contact: TrustedContact | undefined, now: number = Date.now()): boolean {
+  if (!contact?.expiresAt) return false;
+  const expiresAt = Date.parse(contact.expiresAt);
+  return !Number.isNaN(expiresAt) && now >= expiresAt;
+}
+
+/**
+ * Which groups Fetch answers in (version 3). Files without it answer in
+ * every group, as before.
+ */
+export interface GroupAccess {
+  /** Only answer in groups listed in `allowed` */
+  restricted: boolean;
+  /** Allowed group JIDs (…@g.us) → group name when it was allowed */
+  allowed?: Record<string, string>;
+}
+
+/**
+ * The list as the manager's Trusted Numbers screen reads it.
+ */
+export interface WhitelistSnapshot {
+  /** Trusted numbers, sorted */
+  trustedNumbers: string[];
+  /** Details of trusted numbers that have any */
+  contacts: Record<string, TrustedContact>;
+  /** When the list last changed (ISO 8601), null if never saved */
+  updatedAt: string | null;
+}
+
 interface WhitelistData {
   /** Trusted phone numbers (normalized, digits only) */
   trustedNumbers: string[];
+  /** Per-number details keyed by normalized number (version 2) */
+  contacts?: Record<string, TrustedContact>;
+  /** Group allow-list (version 3) */
+  groups?: GroupAccess;
   /** Last updated timestamp */
   updatedAt: string;
   /** Version for future migrations */
   version: number;
+  /** Fields written by the manager (contacts, …), kept as-is */
+  [field: string]: unknown;
 }
 
 // =============================================================================
@@ -79,6 +155,21 @@ export class WhitelistStore {
   /** In-memory set of trusted numbers */
   private trustedNumbers: Set<string> = new Set();
   
+  /** Details of trusted numbers, keyed by normalized number */
+  private contacts: Record<string, TrustedContact> = {};
+
+  /** Group allow-list; null means every group */
+  private groups: GroupAccess | null = null;
+
+  /** Other fields of the file, written back unchanged on persist */
+  private extra: Record<string, unknown> = {};
+
+  /** Schema version of the file as read */
+  private fileVersion = 1;
+
+  /** When the file was last written, as read or persisted */
+  private updatedAt: string | null = null;
+
   /** Initialization flag */
   private initialized = false;
 
@@ -132,6 +223,12 @@ export class WhitelistStore {
     try {
       const content = await fs.readFile(WHITELIST_FILE, 'utf-8');
       const data: WhitelistData = JSON.parse(content);
+      const { trustedNumbers: _numbers, contacts, groups, updatedAt, version, ...extra } = data;
+      this.extra = extra;
+      this.updatedAt = typeof updatedAt === 'string' ? updatedAt : null;
+      this.contacts = contacts && typeof contacts === 'object' ? contacts : {};
+      this.groups = groups ?? null;
+      this.fileVersion = typeof version === 'number' ? version : 1;
 
       if (data.trustedNumbers && Array.isArray(data.trustedNumbers)) {
         for (const num of data.trustedNumbers) {
@@ -151,6 +248,32 @@ export class WhitelistStore {
     }
   }
 
+  /**
+   * Re-read the environment and file, dropping numbers no longer listed.
+   * Used when another process (the manager) wrote the file directly.
+   *
+   * @returns Number of trusted numbers after the reload
+   */
+  async reload(): Promise<number> {
+    const previous = this.trustedNumbers;
+    const previousContacts = this.contacts;
+    const previousGroups = this.groups;
+    this.trustedNumbers = new Set();
+    this.contacts = {};
+    this.groups = null;
+    try {
+      await this.loadFromEnv();
+      await this.loadFromFile();
+    } catch (error) {
+      this.trustedNumbers = previous;
+      this.contacts = previousContacts;
+      this.groups = previousGroups;
+      throw error;
+    }
+    logger.info(`Whitelist reloaded: ${this.trustedNumbers.size} trusted number(s)`);
+    return this.trustedNumbers.size;
+  }
+
   /**
    * Persist current whitelist to JSON file.
    */
@@ -159,13 +282,26 @@ export class WhitelistStore {
       // Ensure data directory exists
       await fs.mkdir(DATA_DIR, { recursive: true });
 
+      // Details of numbers no longer trusted go with them
+      const contacts: Record<string, TrustedContact> = {};
+      for (const [number, contact] of Object.entries(this.contacts)) {
+        if (this.trustedNumbers.has(number)) contacts[number] = contact;
+      }
+
+      // Contacts need version 2
+      const hasContacts = Object.keys(contacts).length > 0;
       const data: WhitelistData = {
+        ...this.extra,
         trustedNumbers: Array.from(this.trustedNumbers),
+        ...(hasContacts ? { contacts } : {}),
+        ...(this.groups ? { groups: this.groups } : {}),
         updatedAt: new Date().toISOString(),
-        version: 1,
+        version: hasContacts ? Math.max(this.fileVersion, 2) : this.fileVersion,
       };
 
       await fs.writeFile(WHITELIST_FILE, JSON.stringify(data, null, 2), 'utf-8');
+      this.updatedAt = data.updatedAt;
+      this.fileVersion = data.version;
       logger.debug('Whitelist persisted to file');
     } catch (error) {
       logger.error('Failed to persist whitelist', error);
@@ -187,9 +323,10 @@ export class WhitelistStore {
    * Add a phone number to the whitelist.
    * 
    * @param phoneNumber - Phone number to add
+   * @param contact - Details to keep for it (label, role, …)
    * @returns true if added, false if already existed
    */
-  async add(phoneNumber: string): Promise<boolean> {
+  async add(phoneNumber: string, contact?: TrustedContact): Promise<boolean> {
     const normalized = this.normalizeNumber(phoneNumber);
     
     if (normalized.length < 10) {
@@ -203,6 +340,9 @@ export class WhitelistStore {
     }
 
     this.trustedNumbers.add(normalized);
+    if (contact && Object.keys(contact).length > 0) {
+      this.contacts[normalized] = contact;
+    }
     await this.persist();
     
     logger.success(`Added to whitelist: +${normalized}`);
@@ -224,6 +364,7 @@ export class WhitelistStore {
     }
 
     this.trustedNumbers.delete(normalized);
+    delete this.contacts[normalized];
     await this.persist();
     
     logger.success(`Removed from whitelist: +${normalized}`);
@@ -231,14 +372,65 @@ export class WhitelistStore {
   }
 
   /**
-   * Check if a phone number is in the whitelist.
+   * Replace the details kept for a trusted number.
+   *
+   * @param phoneNumber - Phone number to update
+   * @param contact - New details; empty clears them
+   * @returns true if updated, false if the number isn't in the list
+   */
+  async setContact(phoneNumber: string, contact: TrustedContact): Promise<boolean> {
+    const normalized = this.normalizeNumber(phoneNumber);
+
+    if (!this.trustedNumbers.has(normalized)) {
+      logger.debug(`Number not in whitelist: ${normalized}`);
+      return false;
+    }
+
+    if (Object.keys(contact).length > 0) {
+      this.contacts[normalized] = contact;
+    } else {
+      delete this.contacts[normalized];
+    }
+    await this.persist();
+
+    logger.info(`Updated whitelist contact: +${normalized}`);
+    return true;
+  }
+
+  /**
+   * Check if a phone number is in the whitelist and hasn't expired.
+   * Expired numbers stay in the file until the manager removes them.
    * 
    * @param phoneNumber - Phone number to check
    * @returns true if trusted
    */
   has(phoneNumber: string): boolean {
     const normalized = this.normalizeNumber(phoneNumber);
-    return this.trustedNumbers.has(normalized);
+    return this.trustedNumbers.has(normalized) && !isExpired(this.contacts[normalized]);
+  }
+
+  /**
+   * Get what a number may do.
+   *
+   * @param phoneNumber - Phone number to check
+   * @returns The number's role, or null if it isn't trusted
+   */
+  roleOf(phoneNumber: string): TrustRole | null {
+    const normalized = this.normalizeNumber(phoneNumber);
+    if (!this.has(normalized)) return null;
+    const role = this.contacts[normalized]?.role;
+    return role && TRUST_ROLES.includes(role) ? role : 'tasks';
+  }
+
+  /**
+   * Check if Fetch may answer in a group.
+   *
+   * @param groupId - Group JID (…@g.us)
+   * @returns true unless the group allow-list is on and omits the group
+   */
+  isGroupAllowed(groupId: string): boolean {
+    if (!this.groups?.restricted) return true;
+    return Object.prototype.hasOwnProperty.call(this.groups.allowed ?? {}, groupId);
   }
 
   /**
@@ -250,6 +442,18 @@ export class WhitelistStore {
     return Array.from(this.trustedNumbers).sort();
   }
 
+  /**
+   * Get the numbers with their details, expired ones included, for the
+   * manager.
+   */
+  snapshot(): WhitelistSnapshot {
+    const contacts: Record<string, TrustedContact> = {};
+    for (const number of this.trustedNumbers) {
+      if (this.contacts[number]) contacts[number] = this.contacts[number];
+    }
+    return { trustedNumbers: this.list(), contacts, updatedAt: this.updatedAt };
+  }
+
   /**
    * Get count of trusted numbers.
    */
@@ -263,6 +467,7 @@ export class WhitelistStore {
    */
   async clear(): Promise<void> {
     this.trustedNumbers.clear();
+    this.contacts = {};
     await this.persist();
     logger.warn('Whitelist cleared');
   }
@@ -47,7 +47,8 @@ import {
   createMessage,
   ToolCall
 } from './types.js';
-import { SessionStore, getSessionStore } from './store.js';
+import { nanoid } from 'nanoid';
+import { SessionStore, getSessionStore, type SummaryRow } from './store.js';
 import { ThreadManager, Thread } from './thread-manager.js';
 import { pipeline } from '../config/pipeline.js';
 import { logger } from '../utils/logger.js';
@@ -56,6 +57,21 @@ import { logger } from '../utils/logger.js';
 // SESSION MANAGER CLASS
 // =============================================================================
 
+/** Most recent compaction failures kept for the manager */
+const SUMMARY_FAILURE_LIMIT = 20;
+
+/**
+ * A compaction whose summary could not be generated
+ */
+export interface SummaryFailure {
+  /** Session being compacted */
+  sessionId: string;
+  /** Why generation failed */
+  error: string;
+  /** When it failed (ISO 8601) */
+  at: string;
+}
+
 /**
  * High-level manager for user sessions and conversation state.
  * 
@@ -76,6 +92,7 @@ import { logger } from '../utils/logger.js';
 export class SessionManager {
   private store: SessionStore;
   private threadManager: ThreadManager;
+  private summaryFailures: SummaryFailure[] = [];
 
   constructor(store?: SessionStore) {
     this.store = store || getSessionStore();
@@ -384,6 +401,17 @@ export class SessionManager {
     session.messages = recentMessages;
 
     await this.store.update(session);
+
+    // Keep each summary so the manager can show what the agent remembers
+    this.store.saveSummary({
+      id: nanoid(),
+      session_id: session.id,
+      thread_id: session.currentThreadId,
+      range_start_id: oldMessages[0].id,
+      range_end_id: oldMessages[oldMessages.length - 1].id,
+      content: summary,
+      created_at: session.metadata.compactedAt,
+    });
     logger.info('Compacted session', {
       sessionId: session.id,
       removed: oldMessages.length,
@@ -437,6 +465,12 @@ Keep it under ${pipeline.compactionMaxTokens} tokens.`
       return response.choices[0]?.message?.content ?? 'Unable to generate summary';
     } catch (err) {
       logger.error('Compaction summary generation failed', err);
+      this.summaryFailures.unshift({
+        sessionId: session.id,
+        error: err instanceof Error ? err.message : String(err),
+        at: new Date().toISOString(),
+      });
+      this.summaryFailures.length = Math.min(this.summaryFailures.length, SUMMARY_FAILURE_LIMIT);
       // Fallback: simple truncated transcript
       return `[Auto-summary failed — ${transcript.length} chars of conversation compacted]`;
     }
@@ -602,6 +636,24 @@ Keep it under ${pipeline.compactionMaxTokens} tokens.`
   async cleanup(): Promise<number> {
     return this.store.cleanup();
   }
+
+  // ============================================================================
+  // Summaries
+  // ============================================================================
+
+  /**
+   * Get the stored compaction summaries across every session, newest first
+   */
+  getSummaries(): SummaryRow[] {
+    return this.store.getAllSummaries();
+  }
+
+  /**
+   * Get recent compaction failures, newest first. Memory only.
+   */
+  getSummaryFailures(): SummaryFailure[] {
+    return [...this.summaryFailures];
+  }
 }
 
 // Singleton instance
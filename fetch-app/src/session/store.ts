@@ -79,7 +79,7 @@ interface MetaRow {
   updated_at: string;
 }
 
-interface SummaryRow {
+export interface SummaryRow {
   id: string;
   session_id: string;
   thread_id?: string;
@@ -144,6 +144,7 @@ export class SessionStore {
   // Summary Statements
   private stmtInsertSummary: Database.Statement | null = null;
   private stmtGetSummaries: Database.Statement | null = null;
+  private stmtGetAllSummaries: Database.Statement | null = null;
 
   constructor(dbPath: string = DEFAULT_DB_PATH) {
     this.dbPath = dbPath;
@@ -251,6 +252,9 @@ export class SessionStore {
       this.stmtGetSummaries = this.db.prepare(`
         SELECT * FROM conversation_summaries WHERE session_id = ? ORDER BY created_at DESC LIMIT ?
       `);
+      this.stmtGetAllSummaries = this.db.prepare(`
+        SELECT * FROM conversation_summaries ORDER BY created_at DESC LIMIT ?
+      `);
       
       this.initialized = true;
       
@@ -318,6 +322,14 @@ export class SessionStore {
       return this.stmtGetSummaries!.all(sessionId, limit) as SummaryRow[];
   }
 
+  /**
+   * Get the newest summaries across every session
+   */
+  public getAllSummaries(limit: number = 200): SummaryRow[] {
+      this.ensureInitialized();
+      return this.stmtGetAllSummaries!.all(limit) as SummaryRow[];
+  }
+
   /**
    * Ensure store is initialized
    */
@@ -503,6 +515,14 @@ export class SessionStore {
     return result.count;
   }
 
+  /**
+   * Write the WAL into the database file, for a shutdown that may not get
+   * to close the connection.
+   */
+  flush(): void {
+    this.db?.pragma('wal_checkpoint(TRUNCATE)');
+  }
+
   /**
    * Close the database connection
    */
@@ -167,15 +167,47 @@ export class TaskIntegration extends EventEmitter {
    * @param taskId - Task ID to cancel
    */
   async cancelExecution(taskId: TaskId): Promise<void> {
+    if (!this.initialized) {
+      await this.initialize();
+    }
+
     const abort = this.activeExecutions.get(taskId);
     if (abort) {
       abort.abort();
       logger.info(`Cancelled task execution: ${taskId}`);
     }
 
+    // Stop the harness too, or it keeps editing the workspace
+    const executor = getHarnessExecutor();
+    const execution = executor.getExecutionForTask(taskId);
+    if (execution && executor.isRunning(execution.id)) {
+      executor.kill(execution.id);
+    }
+
     await this.manager!.cancelTask(taskId);
   }
 
+  /**
+   * Retry a failed or cancelled task
+   *
+   * Queues a copy of the task and starts it in the background, the way
+   * task_create does.
+   *
+   * @param taskId - Task ID to retry
+   * @returns The new task
+   */
+  async retryTask(taskId: TaskId): Promise<Task> {
+    if (!this.initialized) {
+      await this.initialize();
+    }
+
+    const task = await this.manager!.retryTask(taskId);
+    this.executeTask(task).catch((error) => {
+      logger.error(`Retried task failed to run: ${task.id}`, { error });
+    });
+    return task;
+  }
+
   /**
    * Send a response to a waiting task
    *
@@ -183,15 +215,23 @@ export class TaskIntegration extends EventEmitter {
    * @param response - User response
    */
   async respondToTask(taskId: TaskId, response: string): Promise<void> {
+    if (!this.initialized) {
+      await this.initialize();
+    }
+
     const executor = getHarnessExecutor();
 
-    // Find the harness for this task
-    const execution = executor.getActiveExecution(taskId);
-    if (!execution) {
+    // Find the harness for this task; it is waiting_input, not running,
+    // while the question is open
+    const execution = executor.getExecutionForTask(taskId);
+    if (!execution || !executor.isRunning(execution.id)) {
       throw new Error(`No active execution for task: ${taskId}`);
     }
 
-    await executor.sendInput(execution.id, response);
+    executor.sendInput(execution.id, response);
+    if (this.manager!.getTask(taskId)?.status === 'waiting_input') {
+      await this.manager!.resumeTask(taskId);
+    }
     logger.info(`Sent response to task: ${taskId}`, { response });
   }
 
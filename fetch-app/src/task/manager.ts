@@ -206,6 +206,39 @@ export class TaskManager extends EventEmitter {
     return task;
   }
 
+  /**
+   * Queue a failed or cancelled task again
+   *
+   * Creates a new task with the same goal, workspace, agent and timeout,
+   * counting one more retry than the original.
+   *
+   * @param taskId - Task ID to retry
+   * @returns The new task
+   * @throws Error if the task is not found, didn't fail or get cancelled, or
+   *   another task is running
+   */
+  async retryTask(taskId: TaskId): Promise<Task> {
+    const original = this.getTaskOrThrow(taskId);
+    if (original.status !== 'failed' && original.status !== 'cancelled') {
+      throw new Error(`Cannot retry task: task is ${original.status}`);
+    }
+
+    const task = await this.createTask(
+      {
+        goal: original.goal,
+        agent: original.agentSelection,
+        workspace: original.workspace,
+        timeout: original.constraints.timeoutMs,
+      },
+      original.sessionId
+    );
+    task.retryCount = original.retryCount + 1;
+    await this.store.saveTask(task);
+
+    logger.info(`Task retried: ${taskId} → ${task.id}`, { retryCount: task.retryCount });
+    return task;
+  }
+
   // ==========================================================================
   // Task State Management
   // ==========================================================================
@@ -489,6 +522,22 @@ export class TaskManager extends EventEmitter {
       .slice(0, limit);
   }
 
+  /**
+   * Get the most recent task run in a workspace
+   *
+   * @param workspace - Workspace name
+   * @returns Latest task by creation time, undefined if none
+   */
+  getLastTaskForWorkspace(workspace: string): Task | undefined {
+    let last: Task | undefined;
+    for (const task of this.tasks.values()) {
+      if (task.workspace === workspace && (!last || task.createdAt > last.createdAt)) {
+        last = task;
+      }
+    }
+    return last;
+  }
+
   // ==========================================================================
   // Private Helpers
   // ==========================================================================
@@ -136,6 +136,14 @@ export class TaskStore {
     }
   }
 
+  /**
+   * Write the WAL into the database file, for a shutdown that may not get
+   * to close the connection.
+   */
+  flush(): void {
+    this.db?.pragma('wal_checkpoint(TRUNCATE)');
+  }
+
   /**
    * Close the database connection and flush the WAL.
    */
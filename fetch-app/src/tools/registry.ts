@@ -13,6 +13,8 @@ import { z } from 'zod';
 import { ToolResult, ToolContext, DangerLevel } from './types.js';
 export type { ToolContext }; // Re-export for backward compatibility
 import { logger } from '../utils/logger.js';
+import { getTrafficStats } from '../utils/traffic.js';
+import { canRunTasks } from '../security/gate.js';
 import { ToolInputSchemas, type ToolName } from '../validation/tools.js';
 
 // Import tool handlers
@@ -51,6 +53,12 @@ import { TOOLS_DIR } from '../config/paths.js';
 // Internal Types
 // ============================================================================
 
+/**
+ * Tools a chat-only number may use: they read, and custom tools (which run
+ * commands) are left out
+ */
+const CHAT_TOOLS = new Set(['workspace_list', 'workspace_status', 'task_status', 'ask_user', 'report_progress']);
+
 /**
  * Tool definition for Orchestrator
  */
@@ -262,6 +270,19 @@ export class ToolRegistry {
       };
     }
     
+    // Chat-only numbers may look around but not start, answer or cancel
+    // tasks, or change workspaces
+    if (context?.role && !canRunTasks(context.role) && !CHAT_TOOLS.has(name)) {
+      logger.warn(`Refused ${name} for a chat-only number`);
+      return {
+        success: false,
+        output: '',
+        error: 'This number is set to chat only in the Fetch manager, so it cannot run tasks or change workspaces. Ask the owner for task access.',
+        duration: 0
+      };
+    }
+
+    getTrafficStats().record('toolCalls');
     const startTime = Date.now();
     try {
       // Validate args
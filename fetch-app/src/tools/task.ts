@@ -18,6 +18,7 @@ import { getTaskManager } from '../task/manager.js';
 import { workspaceManager } from '../workspace/manager.js';
 import { getTaskIntegration } from '../task/integration.js';
 import { getHarnessExecutor } from '../harness/executor.js';
+import { checkRepoAllowed } from '../security/repos.js';
 import {
   TaskCreateInputSchema,
   TaskStatusInputSchema,
@@ -116,6 +117,17 @@ export async function handleTaskCreate(
     };
   }
 
+  // Refuse repositories the owner hasn't allowed in the manager
+  const repoCheck = await checkRepoAllowed(workspaceData.git?.remoteUrl);
+  if (!repoCheck.allowed) {
+    return {
+      success: false,
+      output: '',
+      error: repoCheck.reason,
+      duration: Date.now() - start,
+    };
+  }
+
   try {
     // Create the task via TaskManager
     const manager = await getTaskManager();
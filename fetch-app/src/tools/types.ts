@@ -3,6 +3,8 @@
  * @module tools/types
  */
 
+import type { SenderRole } from '../security/gate.js';
+
 // ============================================================================
 // Tool Context
 // ============================================================================
@@ -15,6 +17,8 @@ export interface ToolContext {
   sessionId?: string;
   /** Current autonomy level for ask_user guard */
   autonomyLevel?: string;
+  /** What the sender may do; missing means the owner */
+  role?: SenderRole;
 }
 
 // ============================================================================
@@ -0,0 +1,127 @@
+/**
+ * @fileoverview Traffic Stats - Per-Hour Message Counters
+ *
+ * Counts the messages the bridge handled, the tool calls the agent made and
+ * the messages that failed, in one-hour buckets. The manager's Message
+ * Statistics screen draws them as sparklines.
+ *
+ * @module utils/traffic
+ * @see {@link TrafficStats} - Counter class
+ *
+ * ## Data Source
+ *
+ * Memory only: the counters start over when the bridge restarts.
+ *
+ * @example
+ * ```typescript
+ * const traffic = getTrafficStats();
+ * traffic.record('messages');
+ * traffic.hours(24); // oldest first, hours without traffic as zeros
+ * ```
+ */
+
+// =============================================================================
+// CONFIGURATION
+// =============================================================================
+
+/** Length of a bucket */
+const HOUR_MS = 60 * 60 * 1000;
+
+/** Hours kept and reported by default */
+export const TRAFFIC_HOURS = 24;
+
+// =============================================================================
+// TYPES
+// =============================================================================
+
+/** What a bucket counts */
+export type TrafficCounter = 'messages' | 'toolCalls' | 'errors';
+
+/** Counters for one hour */
+export interface HourStats {
+  /** Start of the hour (ISO 8601) */
+  hour: string;
+  /** Messages handled */
+  messages: number;
+  /** Agent tool calls made */
+  toolCalls: number;
+  /** Messages whose handling failed */
+  errors: number;
+}
+
+// =============================================================================
+// TRAFFIC STATS CLASS
+// =============================================================================
+
+/**
+ * Per-hour traffic counters for the last {@link TRAFFIC_HOURS} hours.
+ *
+ * @class
+ */
+export class TrafficStats {
+  /** Buckets keyed by the start of their hour, in milliseconds */
+  private buckets = new Map<number, HourStats>();
+
+  /**
+   * Count one event in the current hour.
+   *
+   * @param counter - What happened
+   * @param now - Current time in milliseconds
+   */
+  record(counter: TrafficCounter, now: number = Date.now()): void {
+    const start = Math.floor(now / HOUR_MS) * HOUR_MS;
+    let bucket = this.buckets.get(start);
+    if (!bucket) {
+      bucket = { hour: new Date(start).toISOString(), messages: 0, toolCalls: 0, errors: 0 };
+      this.buckets.set(start, bucket);
+      this.prune(start);
+    }
+    bucket[counter]++;
+  }
+
+  /**
+   * Get the counters for the last hours, the current one included.
+   *
+   * @param count - Hours to report
+   * @param now - Current time in milliseconds
+   * @returns One bucket per hour, oldest first; quiet hours are zeros
+   */
+  hours(count: number = TRAFFIC_HOURS, now: number = Date.now()): HourStats[] {
+    const current = Math.floor(now / HOUR_MS) * HOUR_MS;
+    const hours: HourStats[] = [];
+    for (let i = count - 1; i >= 0; i--) {
+      const start = current - i * HOUR_MS;
+      const bucket = this.buckets.get(start);
+      hours.push(bucket
+        ? { ...bucket }
+        : { hour: new Date(start).toISOString(), messages: 0, toolCalls: 0, errors: 0 });
+    }
+    return hours;
+  }
+
+  /**
+   * Drop buckets older than the hours kept.
+   */
+  private prune(current: number): void {
+    const oldest = current - (TRAFFIC_HOURS - 1) * HOUR_MS;
+    for (const start of this.buckets.keys()) {
+      if (start < oldest) this.buckets.delete(start);
+    }
+  }
+}
+
+// =============================================================================
+// SINGLETON
+// =============================================================================
+
+let trafficStats: TrafficStats | null = null;
+
+/**
+ * Get the singleton traffic counters.
+ */
+export function getTrafficStats(): TrafficStats {
+  if (!trafficStats) {
+    trafficStats = new TrafficStats();
+  }
+  return trafficStats;
+}
@@ -739,6 +739,44 @@ export class WorkspaceManager extends EventEmitter {
     await dockerExec('git', ['-C', path, 'commit', '-m', 'Initial commit']);
   }
 
+  /**
+   * Get a workspace's uncommitted changes, or its last commit when the
+   * tree is clean
+   *
+   * @param workspaceId - Workspace ID
+   * @returns Unified diff text, or null if the workspace doesn't exist
+   * @throws Error if the workspace isn't a git repository
+   */
+  async getWorkspaceDiff(workspaceId: WorkspaceId): Promise<string | null> {
+    const workspace = await this.getWorkspace(workspaceId);
+    if (!workspace) {
+      return null;
+    }
+
+    const path = getWorkspacePath(workspaceId);
+    const diffResult = await dockerExec(
+      'git',
+      ['-C', path, 'diff', 'HEAD'],
+      { timeoutMs: pipeline.gitCommandTimeout }
+    );
+    if (diffResult.exitCode !== 0) {
+      throw new Error(`No git history in ${workspaceId}`);
+    }
+    if (diffResult.stdout.trim()) {
+      return diffResult.stdout;
+    }
+
+    const showResult = await dockerExec(
+      'git',
+      ['-C', path, 'show', 'HEAD'],
+      { timeoutMs: pipeline.gitCommandTimeout }
+    );
+    if (showResult.exitCode !== 0) {
+      throw new Error(`Failed to read the last commit: ${showResult.stderr}`);
+    }
+    return showResult.stdout;
+  }
+
   // ==========================================================================
   // Workspace Deletion
   // ==========================================================================
@@ -233,3 +233,29 @@ describe('Command Parser — Router', () => {
     expect(result.handled).toBe(true);
   });
 });
+
+describe('Command Parser — Roles', () => {
+  let session: Session;
+  let sm: ReturnType<typeof mockSessionManager>;
+
+  beforeEach(() => {
+    session = createMockSession();
+    sm = mockSessionManager();
+  });
+
+  it('should refuse task commands for chat-only numbers', async () => {
+    const result = await parseCommand('/stop', session, sm, 'chat');
+    expect(result.handled).toBe(true);
+    expect(result.responses?.[0]).toContain('chat only');
+  });
+
+  it('should still answer read-only commands for chat-only numbers', async () => {
+    const result = await parseCommand('/help', session, sm, 'chat');
+    expect(result.responses?.[0]).not.toContain('chat only');
+  });
+
+  it('should keep /trust for the owner and admins', async () => {
+    const result = await parseCommand('/trust add 15559876543', session, sm, 'tasks');
+    expect(result.responses?.[0]).toContain('Only the owner and admins');
+  });
+});
@@ -194,6 +194,7 @@ describe('Context Pipeline: Compaction', () => {
       clear: vi.fn(),
       delete: vi.fn(),
       cleanup: vi.fn(),
+      saveSummary: vi.fn(),
     };
     const manager = new SessionManager(mockStore as unknown as import('../../src/session/store.js').SessionStore);
 
@@ -220,6 +221,7 @@ describe('Context Pipeline: Compaction', () => {
       clear: vi.fn(),
       delete: vi.fn(),
       cleanup: vi.fn(),
+      saveSummary: vi.fn(),
     };
     const manager = new SessionManager(mockStore as unknown as import('../../src/session/store.js').SessionStore);
 
@@ -252,6 +254,10 @@ describe('Context Pipeline: Compaction', () => {
     expect(session.metadata.compactedAt).toBeDefined();
     expect(session.metadata.compactedMessageCount).toBeGreaterThan(0);
     expect(mockStore.update).toHaveBeenCalled();
+    // Each compaction is kept for the manager's summaries screen
+    expect(mockStore.saveSummary).toHaveBeenCalledTimes(1);
+    expect(mockStore.saveSummary.mock.calls[0][0].content).toBe(session.metadata.compactionSummary);
+    expect(mockStore.saveSummary.mock.calls[0][0].session_id).toBe(session.id);
   });
 });
 
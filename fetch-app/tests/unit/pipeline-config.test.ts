@@ -5,11 +5,14 @@
  * 1. Default values are sane and accessible
  * 2. Env var overrides work for int, float, string, and int-array params
  * 3. Invalid env vars fall back to defaults (NaN protection)
+ * 4. The manager's settings schema covers every FETCH_* variable, with the same defaults
  *
  * @module tests/unit/pipeline-config.test
  */
 
 import { describe, it, expect, afterEach } from 'vitest';
+import fs from 'fs';
+import path from 'path';
 
 // We need to re-import pipeline fresh for each test to pick up env changes.
 // Vitest module cache means we need dynamic import + resetModules.
@@ -144,3 +147,48 @@ describe('Pipeline Configuration', () => {
     });
   });
 });
+
+describe('Manager settings schema', () => {
+  // The manager's config editor is generated from this file
+  const schemaPath = path.resolve(__dirname, '../../../manager/internal/config/schema.json');
+  const pipelinePath = path.resolve(__dirname, '../../src/config/pipeline.ts');
+
+  interface Spec {
+    key: string;
+    default?: string;
+  }
+
+  function specs(): Map<string, Spec> {
+    const schema = JSON.parse(fs.readFileSync(schemaPath, 'utf-8')) as {
+      sections: { settings: Spec[] }[];
+    };
+    return new Map(schema.sections.flatMap((s) => s.settings).map((s) => [s.key, s]));
+  }
+
+  /** `property: int('FETCH_KEY', ...)` entries of the pipeline config */
+  function pipelineKeys(): [property: string, key: string][] {
+    const source = fs.readFileSync(pipelinePath, 'utf-8');
+    return [...source.matchAll(/^\s+(\w+): (?:int|float|str|ints)\('(FETCH_\w+)'/gm)].map(
+      (m) => [m[1], m[2]]
+    );
+  }
+
+  it.skipIf(!fs.existsSync(schemaPath))('should declare every FETCH_* variable the bridge reads', () => {
+    const declared = specs();
+    const keys = pipelineKeys();
+    expect(keys.length).toBeGreaterThan(0);
+    const missing = keys.map(([, key]) => key).filter((key) => !declared.has(key));
+    expect(missing).toEqual([]);
+  });
+
+  it.skipIf(!fs.existsSync(schemaPath))('should show the bridge defaults', async () => {
+    const { pipeline } = await import('../../src/config/pipeline.js');
+    const declared = specs();
+    const values = pipeline as Record<string, unknown>;
+    for (const [property, key] of pipelineKeys()) {
+      const value = values[property];
+      const expected = Array.isArray(value) ? value.join(',') : String(value);
+      expect(declared.get(key)?.default, key).toBe(expected);
+    }
+  });
+});
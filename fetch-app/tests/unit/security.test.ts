@@ -4,7 +4,7 @@
  * Tests for SecurityGate, RateLimiter, and input validator.
  */
 
-import { describe, it, expect, beforeEach, vi } from 'vitest';
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
 
 // ── Validator tests (pure functions, no mocks needed) ────────────────────────
 import { validateInput, sanitizePath } from '../../src/security/validator.js';
@@ -166,6 +166,89 @@ describe('RateLimiter', () => {
     expect(limiter.getRemaining('user1')).toBe(3);
     expect(limiter.getRemaining('user2')).toBe(3);
   });
+
+  it('should report active keys with refused requests', () => {
+    for (let i = 0; i < 5; i++) limiter.isAllowed('user1');
+    limiter.isAllowed('user2');
+    expect(limiter.snapshot()).toEqual({
+      maxRequests: 3,
+      windowMs: 1000,
+      keys: [
+        { key: 'user1', count: 3, remaining: 0, blocked: 2 },
+        { key: 'user2', count: 1, remaining: 2, blocked: 0 },
+      ],
+    });
+  });
+});
+
+// ── Repository allow-list tests ──────────────────────────────────────────────
+import { repoFromUrl } from '../../src/security/repos.js';
+
+describe('repoFromUrl', () => {
+  it('should parse HTTPS URLs', () => {
+    expect(repoFromUrl('https://github.com/Octocat/Hello-World.git')).toBe('octocat/hello-world');
+  });
+
+  it('should parse SSH URLs', () => {
+    expect(repoFromUrl('git@github.com:octocat/hello-world.git')).toBe('octocat/hello-world');
+  });
+
+  it('should accept bare owner/name', () => {
+    expect(repoFromUrl('octocat/hello-world')).toBe('octocat/hello-world');
+  });
+
+  it('should reject non-GitHub URLs', () => {
+    expect(repoFromUrl('git@gitlab.com:octocat/hello-world.git')).toBeNull();
+    expect(repoFromUrl('/srv/git/project')).toBeNull();
+  });
+
+  it('should reject paths deeper than owner/name', () => {
+    expect(repoFromUrl('https://github.com/octocat/hello-world/tree/main')).toBeNull();
+  });
+});
+
+// ── ActivityLog tests ────────────────────────────────────────────────────────
+import { ActivityLog } from '../../src/security/activity.js';
+
+describe('ActivityLog', () => {
+  let log: ActivityLog;
+
+  beforeEach(() => {
+    // Fake timers keep the delayed write from touching data/activity.json
+    vi.useFakeTimers();
+    log = new ActivityLog();
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+  });
+
+  it('should count messages per number across ID formats', () => {
+    log.recordMessage('15559999999@c.us', 'hello');
+    log.recordMessage('+1 555 999 9999', 'again');
+    expect(log.get('15559999999')?.messages).toBe(2);
+  });
+
+  it('should record slash commands but not message text', () => {
+    log.recordMessage('15559999999@c.us', '/Status now');
+    log.recordMessage('15559999999@c.us', 'my secret plans');
+    const activity = log.get('15559999999')!;
+    expect(activity.commands).toEqual({ '/status': 1 });
+    expect(activity.recent).toHaveLength(1);
+    expect(activity.recent[0].detail).toBe('/Status now');
+  });
+
+  it('should record tasks started', () => {
+    log.recordTask('15559999999@c.us', 'tsk_1', 'fix the build');
+    const activity = log.get('15559999999')!;
+    expect(activity.tasksStarted).toBe(1);
+    expect(activity.recent[0]).toMatchObject({ kind: 'task', detail: 'tsk_1: fix the build' });
+  });
+
+  it('should ignore IDs without a number', () => {
+    log.recordMessage('manager-console', '/status');
+    expect(log.all()).toEqual({});
+  });
 });
 
 // ── SecurityGate tests ───────────────────────────────────────────────────────
@@ -175,16 +258,18 @@ describe('RateLimiter', () => {
 // Stub whitelist module to avoid SQLite
 vi.mock('../../src/security/whitelist.js', () => ({
   getWhitelistStore: vi.fn(async () => ({
-    has: (n: string) => n === '15559999999',
-    count: () => 1,
+    has: (n: string) => n === '15559999999' || n === '15558888888',
+    roleOf: (n: string) => (n === '15559999999' ? 'tasks' : n === '15558888888' ? 'chat' : null),
+    count: () => 2,
     add: vi.fn(),
     remove: vi.fn(),
-    list: () => ['15559999999'],
+    list: () => ['15558888888', '15559999999'],
+    isGroupAllowed: (id: string) => id !== 'blocked@g.us',
   })),
 }));
 
 // Import after mock — top-level await is fine at module scope
-const { SecurityGate } = await import('../../src/security/gate.js');
+const { SecurityGate, canRunTasks, canAdminister } = await import('../../src/security/gate.js');
 
 describe('SecurityGate', () => {
   const OWNER = '15551234567';
@@ -264,6 +349,36 @@ describe('SecurityGate', () => {
     it('should reject group message without participantId', () => {
       expect(gate.isAuthorized('group@g.us', undefined, '@fetch hello')).toBe(false);
     });
+
+    it('should reject owner in a group missing from the group allow-list', () => {
+      expect(gate.isAuthorized('blocked@g.us', `${OWNER}@c.us`, '@fetch hello')).toBe(false);
+    });
+  });
+
+  describe('roles', () => {
+    let gate: InstanceType<typeof SecurityGate>;
+    beforeEach(async () => {
+      process.env.OWNER_PHONE_NUMBER = OWNER;
+      gate = await SecurityGate.create();
+    });
+
+    it('should give the owner every permission', () => {
+      expect(gate.roleOf(`${OWNER}@c.us`)).toBe('owner');
+      expect(canRunTasks('owner')).toBe(true);
+      expect(canAdminister('owner')).toBe(true);
+    });
+
+    it('should use the role set for a trusted number', () => {
+      expect(gate.roleOf('15559999999@c.us')).toBe('tasks');
+      expect(gate.roleOf('15558888888@c.us')).toBe('chat');
+      expect(gate.roleOf('999@c.us')).toBeNull();
+    });
+
+    it('should keep chat-only numbers from tasks and tasks from /trust', () => {
+      expect(canRunTasks('chat')).toBe(false);
+      expect(canAdminister('tasks')).toBe(false);
+      expect(canAdminister('admin')).toBe(true);
+    });
   });
 
   describe('isOwnerMessage', () => {
@@ -285,4 +400,51 @@ describe('SecurityGate', () => {
       expect(gate.isOwnerMessage('broadcast@c.us', undefined)).toBe(false);
     });
   });
+
+  describe('setOwner', () => {
+    let gate: InstanceType<typeof SecurityGate>;
+    beforeEach(() => {
+      process.env.OWNER_PHONE_NUMBER = OWNER;
+      gate = new SecurityGate();
+    });
+
+    it('should switch the owner without a restart', () => {
+      gate.setOwner('+44 7700 900123');
+      expect(gate.isOwnerMessage('447700900123@c.us', undefined)).toBe(true);
+      expect(gate.isOwnerMessage(`${OWNER}@c.us`, undefined)).toBe(false);
+      expect(process.env.OWNER_PHONE_NUMBER).toBe('447700900123');
+      expect(gate.getOwnerNumber()).toBe('447700900123');
+    });
+
+    it('should reject a number that is too short', () => {
+      expect(() => gate.setOwner('12345')).toThrow('Invalid owner number');
+      expect(gate.isOwnerMessage(`${OWNER}@c.us`, undefined)).toBe(true);
+    });
+  });
+});
+
+// ── Whitelist expiry ─────────────────────────────────────────────────────────
+
+// The module is mocked above for SecurityGate; isExpired is pure, so use the real one
+const { isExpired } = await vi.importActual<typeof import('../../src/security/whitelist.js')>(
+  '../../src/security/whitelist.js'
+);
+
+describe('isExpired', () => {
+  const now = Date.parse('2026-03-01T12:00:00Z');
+
+  it('should keep contacts without an expiry', () => {
+    expect(isExpired(undefined, now)).toBe(false);
+    expect(isExpired({ label: 'Sam' }, now)).toBe(false);
+  });
+
+  it('should expire a contact once expiresAt has passed', () => {
+    expect(isExpired({ expiresAt: '2026-03-01T11:59:59Z' }, now)).toBe(true);
+    expect(isExpired({ expiresAt: '2026-03-01T12:00:00Z' }, now)).toBe(true);
+    expect(isExpired({ expiresAt: '2026-03-02T00:00:00Z' }, now)).toBe(false);
+  });
+
+  it('should ignore an expiry it cannot parse', () => {
+    expect(isExpired({ expiresAt: 'soon' }, now)).toBe(false);
+  });
 });
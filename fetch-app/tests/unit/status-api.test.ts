@@ -0,0 +1,356 @@
+/**
+ * @fileoverview Status API Route Tests
+ *
+ * Serves the real status API routes on a random port and drives them the
+ * way the manager's Go client does, with stub callbacks behind them.
+ */
+
+import { describe, it, expect, beforeAll, afterAll, beforeEach } from 'vitest';
+import type { AddressInfo } from 'net';
+import type { Server } from 'http';
+
+const TOKEN = 'test-admin-token';
+process.env.ADMIN_TOKEN = TOKEN;
+
+// Import after setting the token — the module reads it at load
+const status = await import('../../src/api/status.js');
+const { StatusApiError } = status;
+
+let server: Server;
+let baseUrl: string;
+
+beforeAll(async () => {
+  server = status.createStatusServer();
+  await new Promise<void>((resolve) => server.listen(0, '127.0.0.1', resolve));
+  baseUrl = `http://127.0.0.1:${(server.address() as AddressInfo).port}`;
+});
+
+afterAll(async () => {
+  await new Promise<void>((resolve) => server.close(() => resolve()));
+});
+
+/** Calls a route with the admin token unless auth is false */
+async function call(
+  method: string,
+  path: string,
+  body?: unknown,
+  auth = true
+): Promise<{ status: number; json: Record<string, unknown> }> {
+  const headers: Record<string, string> = {};
+  if (auth) headers.Authorization = `Bearer ${TOKEN}`;
+  if (body !== undefined) headers['Content-Type'] = 'application/json';
+  const res = await fetch(baseUrl + path, {
+    method,
+    headers,
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  return { status: res.status, json: await res.json() };
+}
+
+describe('Status API — tasks', () => {
+  const tasks = [
+    { id: 'tsk_running001', goal: 'Add dark mode', status: 'running', progress: [], retryCount: 0 },
+    { id: 'tsk_failed0001', goal: 'Fix login', status: 'failed', progress: [], retryCount: 1 },
+  ];
+  let actions: Array<[string, string]>;
+
+  beforeEach(() => {
+    actions = [];
+    status.setTasksCallback(async () => tasks);
+    status.setTaskActionCallback(async (taskId, action) => {
+      const task = tasks.find((t) => t.id === taskId);
+      if (!task) throw new StatusApiError(404, `Task not found: ${taskId}`);
+      if (action === 'retry' && task.status !== 'failed') {
+        throw new StatusApiError(409, `Task is ${task.status}`);
+      }
+      actions.push([taskId, action]);
+    });
+  });
+
+  it('should list tasks', async () => {
+    const { status: code, json } = await call('GET', '/api/tasks');
+    expect(code).toBe(200);
+    expect(json.tasks).toEqual(tasks);
+  });
+
+  it('should require the admin token', async () => {
+    expect((await call('GET', '/api/tasks', undefined, false)).status).toBe(401);
+    expect((await call('POST', '/api/tasks/tsk_running001/cancel', undefined, false)).status).toBe(401);
+    expect(actions).toEqual([]);
+  });
+
+  it('should cancel and retry tasks', async () => {
+    expect((await call('POST', '/api/tasks/tsk_running001/cancel')).status).toBe(200);
+    expect((await call('POST', '/api/tasks/tsk_failed0001/retry')).status).toBe(200);
+    expect(actions).toEqual([
+      ['tsk_running001', 'cancel'],
+      ['tsk_failed0001', 'retry'],
+    ]);
+  });
+
+  it('should answer with the status a callback throws', async () => {
+    const missing = await call('POST', '/api/tasks/tsk_missing001/cancel');
+    expect(missing.status).toBe(404);
+    expect(missing.json.message).toBe('Task not found: tsk_missing001');
+
+    const conflict = await call('POST', '/api/tasks/tsk_running001/retry');
+    expect(conflict.status).toBe(409);
+    expect(conflict.json.message).toBe('Task is running');
+  });
+
+  it('should pass an answer to a waiting task', async () => {
+    const answers: Array<[string, string]> = [];
+    status.setTaskRespondCallback(async (taskId, response) => {
+      if (taskId !== 'tsk_waiting001') throw new StatusApiError(409, 'Task is running, not waiting for an answer');
+      answers.push([taskId, response]);
+    });
+
+    expect((await call('POST', '/api/tasks/tsk_waiting001/respond', { response: 'yes' })).status).toBe(200);
+    const conflict = await call('POST', '/api/tasks/tsk_running001/respond', { response: 'yes' });
+    expect(conflict.status).toBe(409);
+    expect(conflict.json.message).toBe('Task is running, not waiting for an answer');
+    expect((await call('POST', '/api/tasks/tsk_waiting001/respond', { response: ' ' })).status).toBe(400);
+    expect((await call('POST', '/api/tasks/tsk_waiting001/respond', { response: 'no' }, false)).status).toBe(401);
+    expect(answers).toEqual([['tsk_waiting001', 'yes']]);
+  });
+
+  it('should reject unknown actions and malformed IDs', async () => {
+    expect((await call('POST', '/api/tasks/tsk_running001/explode')).status).toBe(404);
+    expect((await call('POST', '/api/tasks/%E0%A4%A/cancel')).status).toBe(400);
+  });
+});
+
+describe('Status API — whitelist', () => {
+  let numbers: Map<string, Record<string, unknown>>;
+
+  beforeEach(() => {
+    numbers = new Map([['15551234567', { label: 'Sam', role: 'chat' }]]);
+    status.setWhitelistCallback(async () => ({
+      trustedNumbers: [...numbers.keys()].sort(),
+      contacts: Object.fromEntries(numbers),
+      updatedAt: '2026-03-01T12:00:00.000Z',
+    }));
+    status.setWhitelistAddCallback(async (n, contact) => {
+      if (numbers.has(n)) return false;
+      numbers.set(n, contact);
+      return true;
+    });
+    status.setWhitelistUpdateCallback(async (n, contact) => {
+      if (!numbers.has(n)) return false;
+      numbers.set(n, contact);
+      return true;
+    });
+    status.setWhitelistRemoveCallback(async (n) => numbers.delete(n));
+  });
+
+  it('should list numbers with their details', async () => {
+    const { status: code, json } = await call('GET', '/api/whitelist');
+    expect(code).toBe(200);
+    expect(json).toEqual({
+      trustedNumbers: ['15551234567'],
+      contacts: { '15551234567': { label: 'Sam', role: 'chat' } },
+      updatedAt: '2026-03-01T12:00:00.000Z',
+    });
+  });
+
+  it('should add a number with its details', async () => {
+    const body = { number: '447700900123', label: 'Alex', role: 'admin', expiresAt: '2026-04-01T00:00:00Z' };
+    expect((await call('POST', '/api/whitelist', body)).status).toBe(201);
+    expect(numbers.get('447700900123')).toEqual({ label: 'Alex', role: 'admin', expiresAt: '2026-04-01T00:00:00Z' });
+  });
+
+  it('should answer 409 for a number already trusted', async () => {
+    expect((await call('POST', '/api/whitelist', { number: '15551234567' })).status).toBe(409);
+  });
+
+  it('should update and remove a number', async () => {
+    expect((await call('PATCH', '/api/whitelist/15551234567', { label: 'Sam M', role: 'tasks' })).status).toBe(200);
+    expect(numbers.get('15551234567')).toEqual({ label: 'Sam M', role: 'tasks' });
+    expect((await call('DELETE', '/api/whitelist/15551234567')).status).toBe(200);
+    expect(numbers.has('15551234567')).toBe(false);
+  });
+
+  it('should answer 404 for a number that is not trusted', async () => {
+    expect((await call('PATCH', '/api/whitelist/15550000000', { label: 'Nobody' })).status).toBe(404);
+    expect((await call('DELETE', '/api/whitelist/15550000000')).status).toBe(404);
+  });
+
+  it('should reject bad numbers and details', async () => {
+    expect((await call('POST', '/api/whitelist', { number: '+1 555' })).status).toBe(400);
+    expect((await call('POST', '/api/whitelist', { number: '15559999999', role: 'root' })).status).toBe(400);
+    expect((await call('PATCH', '/api/whitelist/15551234567', { expiresAt: 'soon' })).status).toBe(400);
+    expect((await call('DELETE', '/api/whitelist/reload')).status).toBe(400);
+    expect(numbers.size).toBe(1);
+  });
+
+  it('should require the admin token', async () => {
+    expect((await call('GET', '/api/whitelist', undefined, false)).status).toBe(401);
+    expect((await call('DELETE', '/api/whitelist/15551234567', undefined, false)).status).toBe(401);
+    expect(numbers.size).toBe(1);
+  });
+});
+
+describe('Status API — pairing code', () => {
+  let requested: string[];
+
+  beforeEach(() => {
+    requested = [];
+    status.updateStatus({ state: 'qr_pending' });
+    status.setPairingCodeCallback(async (phoneNumber) => {
+      requested.push(phoneNumber);
+      return 'ABCD1234';
+    });
+  });
+
+  afterAll(() => {
+    status.updateStatus({ state: 'initializing' });
+  });
+
+  it('should return a code while the QR code is showing', async () => {
+    const { status: code, json } = await call('POST', '/api/pairing-code', { phoneNumber: '15551234567' });
+    expect(code).toBe(200);
+    expect(json.code).toBe('ABCD1234');
+    expect(requested).toEqual(['15551234567']);
+  });
+
+  it('should refuse once WhatsApp is linked', async () => {
+    status.updateStatus({ state: 'authenticated' });
+    const { status: code, json } = await call('POST', '/api/pairing-code', { phoneNumber: '15551234567' });
+    expect(code).toBe(409);
+    expect(json.message).toBe('WhatsApp is already linked');
+    expect(requested).toEqual([]);
+  });
+
+  it('should reject a malformed number', async () => {
+    expect((await call('POST', '/api/pairing-code', { phoneNumber: '+1 555' })).status).toBe(400);
+    expect(requested).toEqual([]);
+  });
+
+  it('should require the admin token', async () => {
+    expect((await call('POST', '/api/pairing-code', { phoneNumber: '15551234567' }, false)).status).toBe(401);
+  });
+});
+
+describe('Status API — stats', () => {
+  it('should return the hourly counters', async () => {
+    const hours = [{ hour: '2026-03-01T11:00:00.000Z', messages: 4, toolCalls: 9, errors: 1 }];
+    status.setStatsCallback(() => hours);
+    const { status: code, json } = await call('GET', '/api/stats');
+    expect(code).toBe(200);
+    expect(json).toEqual({ hours });
+  });
+
+  it('should require the admin token', async () => {
+    expect((await call('GET', '/api/stats', undefined, false)).status).toBe(401);
+  });
+});
+
+describe('Status API — shutdown', () => {
+  it('should call off a prepared stop', async () => {
+    let draining = true;
+    status.setShutdownCancelCallback(() => {
+      const was = draining;
+      draining = false;
+      return was;
+    });
+    expect((await call('POST', '/api/shutdown/cancel')).json).toEqual({ success: true, resumed: true });
+    expect((await call('POST', '/api/shutdown/cancel')).json).toEqual({ success: true, resumed: false });
+  });
+
+  it('should require the admin token', async () => {
+    expect((await call('POST', '/api/shutdown/cancel', undefined, false)).status).toBe(401);
+  });
+});
+
+describe('Status API — limits', () => {
+  it('should return the circuit breaker and rate limiter state', async () => {
+    const limits = {
+      circuitBreaker: {
+        threshold: 3,
+        backoffMs: [1000, 5000, 30000],
+        resetMs: 300000,
+        sessions: [{ sessionId: 'user_1', state: 'open', errorCount: 3, lastErrorAt: '2026-03-01T12:00:00.000Z', retryAt: '2026-03-01T12:00:30.000Z' }],
+      },
+      rateLimit: { maxRequests: 30, windowMs: 60000, keys: [{ key: '15551234567', count: 30, remaining: 0, blocked: 4 }] },
+    };
+    status.setLimitsCallback(() => limits);
+    const { status: code, json } = await call('GET', '/api/limits');
+    expect(code).toBe(200);
+    expect(json).toEqual(limits);
+  });
+
+  it('should require the admin token', async () => {
+    expect((await call('GET', '/api/limits', undefined, false)).status).toBe(401);
+  });
+});
+
+describe('Status API — summaries', () => {
+  it('should return summaries and failures', async () => {
+    const result = {
+      summaries: [{ id: 's1', sessionId: 'user_1', threadId: '', content: 'Set up CI', createdAt: '2026-03-01T12:00:00.000Z' }],
+      failures: [{ sessionId: 'user_2', error: 'rate limited', at: '2026-03-01T11:00:00.000Z' }],
+    };
+    status.setSummariesCallback(async () => result);
+    const { status: code, json } = await call('GET', '/api/summaries');
+    expect(code).toBe(200);
+    expect(json).toEqual(result);
+  });
+
+  it('should require the admin token', async () => {
+    expect((await call('GET', '/api/summaries', undefined, false)).status).toBe(401);
+  });
+});
+
+describe('Status API — workspaces', () => {
+  let workspaces: Array<Record<string, unknown>>;
+
+  beforeEach(() => {
+    workspaces = [
+      { id: 'web-app', path: '/workspace/web-app', branch: 'main', dirty: true, isActive: true, lastTask: null },
+      { id: 'old-api', path: '/workspace/old-api', branch: 'main', dirty: false, isActive: false, lastTask: null },
+    ];
+    status.setWorkspacesCallback(async () => workspaces);
+    status.setWorkspaceDiffCallback(async (id) => {
+      if (!workspaces.some((ws) => ws.id === id)) throw new StatusApiError(404, `Workspace not found: ${id}`);
+      return `diff --git a/${id} b/${id}\n`;
+    });
+    status.setWorkspaceDeleteCallback(async (id) => {
+      const ws = workspaces.find((w) => w.id === id);
+      if (!ws) throw new StatusApiError(404, `Workspace not found: ${id}`);
+      if (ws.isActive) throw new StatusApiError(409, 'Cannot delete the active workspace');
+      workspaces = workspaces.filter((w) => w !== ws);
+    });
+  });
+
+  it('should list workspaces', async () => {
+    const { status: code, json } = await call('GET', '/api/workspaces');
+    expect(code).toBe(200);
+    expect(json.workspaces).toEqual(workspaces);
+  });
+
+  it('should return a workspace diff', async () => {
+    const { status: code, json } = await call('GET', '/api/workspaces/web-app/diff');
+    expect(code).toBe(200);
+    expect(json.diff).toBe('diff --git a/web-app b/web-app\n');
+    expect((await call('GET', '/api/workspaces/missing/diff')).status).toBe(404);
+  });
+
+  it('should delete an idle workspace', async () => {
+    expect((await call('DELETE', '/api/workspaces/old-api')).status).toBe(200);
+    expect(workspaces.map((ws) => ws.id)).toEqual(['web-app']);
+  });
+
+  it('should refuse to delete the active workspace', async () => {
+    const { status: code, json } = await call('DELETE', '/api/workspaces/web-app');
+    expect(code).toBe(409);
+    expect(json.message).toBe('Cannot delete the active workspace');
+    expect(workspaces).toHaveLength(2);
+  });
+
+  it('should require the admin token', async () => {
+    expect((await call('GET', '/api/workspaces', undefined, false)).status).toBe(401);
+    expect((await call('GET', '/api/workspaces/web-app/diff', undefined, false)).status).toBe(401);
+    expect((await call('DELETE', '/api/workspaces/old-api', undefined, false)).status).toBe(401);
+    expect(workspaces).toHaveLength(2);
+  });
+});
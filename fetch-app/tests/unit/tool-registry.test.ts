@@ -88,6 +88,19 @@ describe('Tool Registry', () => {
       expect(result.success).toBe(false);
       expect(result.output).toContain('not found');
     });
+
+    it('should refuse workspace_select for a chat-only number', async () => {
+      const result = await registry.execute('workspace_select', { name: 'any' }, { role: 'chat' });
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain('chat only');
+    });
+
+    it('should let a chat-only number list workspaces', async () => {
+      const result = await registry.execute('workspace_list', {}, { role: 'chat' });
+
+      expect(result.error ?? '').not.toContain('chat only');
+    });
   });
 
   describe('Singleton', () => {
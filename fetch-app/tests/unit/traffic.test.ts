@@ -0,0 +1,41 @@
+/**
+ * @fileoverview Traffic Stats Unit Tests
+ *
+ * Tests the per-hour counters behind the manager's Message Statistics screen.
+ */
+
+import { describe, it, expect } from 'vitest';
+import { TrafficStats, TRAFFIC_HOURS } from '../../src/utils/traffic.js';
+
+const HOUR = 60 * 60 * 1000;
+const NOW = Date.parse('2026-03-01T12:30:00Z');
+
+describe('TrafficStats', () => {
+  it('should report every hour, oldest first, with quiet hours as zeros', () => {
+    const hours = new TrafficStats().hours(TRAFFIC_HOURS, NOW);
+    expect(hours).toHaveLength(TRAFFIC_HOURS);
+    expect(hours[0].hour).toBe('2026-02-28T13:00:00.000Z');
+    expect(hours[TRAFFIC_HOURS - 1]).toEqual({ hour: '2026-03-01T12:00:00.000Z', messages: 0, toolCalls: 0, errors: 0 });
+  });
+
+  it('should count events in their hour', () => {
+    const traffic = new TrafficStats();
+    traffic.record('messages', NOW);
+    traffic.record('messages', NOW + 10 * 60 * 1000);
+    traffic.record('toolCalls', NOW);
+    traffic.record('errors', NOW - HOUR);
+
+    const hours = traffic.hours(2, NOW);
+    expect(hours).toEqual([
+      { hour: '2026-03-01T11:00:00.000Z', messages: 0, toolCalls: 0, errors: 1 },
+      { hour: '2026-03-01T12:00:00.000Z', messages: 2, toolCalls: 1, errors: 0 },
+    ]);
+  });
+
+  it('should forget hours older than the window', () => {
+    const traffic = new TrafficStats();
+    traffic.record('messages', NOW - TRAFFIC_HOURS * HOUR);
+    traffic.record('messages', NOW);
+    expect(traffic.hours(TRAFFIC_HOURS + 1, NOW)[0].messages).toBe(0);
+  });
+});
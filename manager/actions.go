@@ -0,0 +1,453 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/screens"
+	configscreen "github.com/fetch/manager/internal/screens/config"
+	statusscreen "github.com/fetch/manager/internal/screens/status"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// action is a command that can be run from anywhere through the command
+// palette. Main menu entries run the same openers, so both paths stay in
+// sync. Actions with a key also run from that hotkey on every screen.
+type action struct {
+	id    string
+	title string
+	group string
+	key   string
+	run   func(m model) (model, tea.Cmd)
+}
+
+// actionRegistry lists every palette command in display order.
+var actionRegistry = []action{
+	{id: "start", title: "Start Fetch", group: "Services", key: "ctrl+s", run: model.startServices},
+	{id: "stop", title: "Stop Fetch", group: "Services", key: "ctrl+x", run: model.stopServices},
+	{id: "disconnect-whatsapp", title: "Disconnect WhatsApp", group: "Services", run: model.disconnectWhatsApp},
+	{id: "reconnect-whatsapp", title: "Reconnect WhatsApp", group: "Services", run: model.reconnectWhatsApp},
+	{id: "relink-whatsapp", title: "Re-link WhatsApp (new QR code)", group: "Services", run: model.relinkWhatsApp},
+	{id: "clear-whatsapp-auth", title: "Clear WhatsApp login", group: "Services", run: model.clearWhatsAppAuth},
+	{id: "restart-bridge", title: "Restart bridge", group: "Services", key: "ctrl+r", run: model.restartBridge},
+	{id: "backup-now", title: "Back up .env and data now", group: "Services", run: model.backupNow},
+	{id: "setup", title: "Setup WhatsApp", group: "Screens", run: model.openSetup},
+	{id: "git-providers", title: "Git Providers", group: "Screens", run: model.openGitProviders},
+	{id: "status", title: "System Status", group: "Screens", run: model.openStatus},
+	{id: "stats", title: "Statistics", group: "Screens", run: model.openStats},
+	{id: "tasks", title: "Tasks", group: "Screens", run: model.openTasks},
+	{id: "workspaces", title: "Workspaces", group: "Screens", run: model.openWorkspaces},
+	{id: "approvals", title: "Approvals", group: "Screens", run: model.openApprovals},
+	{id: "summaries", title: "Conversation summaries", group: "Screens", run: model.openSummaries},
+	{id: "limits", title: "Rate limits & circuit breakers", group: "Screens", run: model.openLimits},
+	{id: "whitelist", title: "Trusted Numbers", group: "Screens", run: model.openWhitelist},
+	{id: "repos", title: "Repository allow-list", group: "Screens", run: model.openRepos},
+	{id: "groups", title: "Group chats", group: "Screens", run: model.openGroups},
+	{id: "logs", title: "View Logs", group: "Screens", key: "ctrl+l", run: model.openLogs},
+	{id: "update", title: "Update Fetch", group: "Screens", run: model.openUpdate},
+	{id: "version", title: "Version", group: "Screens", run: model.openVersion},
+	{id: "notifications", title: "Notifications", group: "Screens", run: model.openNotifications},
+	{id: "console", title: "Test Console", group: "Screens", run: model.openConsole},
+	{id: "configure", title: "Configure", group: "Config", run: model.openConfigure},
+	{id: "edit-owner-phone", title: "Change owner phone (OWNER_PHONE_NUMBER)", group: "Config", run: configField("OWNER_PHONE_NUMBER")},
+	{id: "edit-openrouter-key", title: "Edit OpenRouter key", group: "Config", run: configField("OPENROUTER_API_KEY")},
+	{id: "select-model", title: "Select agent model", group: "Config", run: configField("AGENT_MODEL")},
+	{id: "edit-git-provider", title: "Set git provider (GIT_PROVIDER)", group: "Config", run: configField("GIT_PROVIDER")},
+	{id: "toggle-accessible", title: "Toggle accessibility mode", group: "App", run: model.toggleAccessible},
+	{id: "cycle-theme", title: "Switch color theme", group: "App", run: model.cycleTheme},
+	{id: "docs", title: "Open documentation", group: "Help", run: model.openDocs},
+	{id: "support-bundle", title: "Generate support bundle", group: "Help", run: model.generateSupportBundle},
+	{id: "quit", title: "Quit manager", group: "App", run: model.quit},
+}
+
+// paletteItems converts the registry for the palette component.
+func paletteItems() []components.PaletteItem {
+	items := make([]components.PaletteItem, len(actionRegistry))
+	for i, a := range actionRegistry {
+		items[i] = components.PaletteItem{ID: a.id, Title: a.title, Group: a.group, Key: a.key}
+	}
+	return items
+}
+
+// runAction runs the registered action with the given ID.
+func (m model) runAction(id string) (model, tea.Cmd) {
+	for _, a := range actionRegistry {
+		if a.id == id {
+			return a.run(m)
+		}
+	}
+	return m, nil
+}
+
+// hotkeyAction finds the action bound to a global hotkey.
+func hotkeyAction(key string) (action, bool) {
+	for _, a := range actionRegistry {
+		if a.key != "" && a.key == key {
+			return a, true
+		}
+	}
+	return action{}, false
+}
+
+// quickActionHints lists the hotkeys worth showing in the status bar right
+// now: start or stop depending on whether Fetch is running, and logs unless
+// they're already open.
+func (m model) quickActionHints() []string {
+	hints := make([]string, 0, 2)
+	if m.bridgeRunning || m.kennelRunning {
+		hints = append(hints, "ctrl+x Stop")
+	} else {
+		hints = append(hints, "ctrl+s Start")
+	}
+	if m.screen != screens.Logs {
+		hints = append(hints, "ctrl+l Logs")
+	}
+	return hints
+}
+
+// updatePalette routes keys to the open command palette.
+func (m model) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	chosen, done := m.palette.Update(msg)
+	if !done {
+		return m, nil
+	}
+	m.palette = nil
+	if chosen == "" {
+		return m, nil
+	}
+	return m.runAction(chosen)
+}
+
+// askConfirm opens a confirmation dialog that runs then on yes.
+func (m model) askConfirm(title, message, label string, then func(m model) (model, tea.Cmd)) (model, tea.Cmd) {
+	m.showHelp = false
+	m.confirm = components.NewConfirm(title, message, label)
+	m.confirmAction = then
+	return m, nil
+}
+
+// updateConfirmDialog routes keys to the open confirmation dialog.
+func (m model) updateConfirmDialog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := m.confirm.Update(msg)
+	if result == components.ConfirmPending {
+		return m, nil
+	}
+	then := m.confirmAction
+	m.confirm = nil
+	m.confirmAction = nil
+	if result == components.ConfirmYes && then != nil {
+		return then(m)
+	}
+	return m, nil
+}
+
+// stopFetch lets the bridge finish replies in progress and flush its
+// data, then stops Docker services. compose down runs even when the bridge
+// can't be asked, and kills containers that outlast its stop timeout. When
+// the stop is cancelled or fails, the bridge is told to take messages again.
+func stopFetch(ctx context.Context, client *status.Client) tea.Msg {
+	note := ""
+	asked := false
+	if client != nil && client.IsHealthy() {
+		asked = true
+		pending, err := client.WithContext(ctx).PrepareShutdown(envBool("FETCH_STOP_GOODBYE"))
+		switch {
+		case ctx.Err() != nil:
+			// The request may have reached the bridge before the cancel
+			client.CancelShutdown()
+			return actionResultMsg{success: false, message: fmt.Sprintf("Failed to stop: %v", ctx.Err())}
+		case err != nil:
+			note = fmt.Sprintf(" The bridge couldn't finish first (%v).", err)
+		case pending > 0:
+			note = fmt.Sprintf(" %d message(s) were still being answered.", pending)
+		}
+	}
+	err := docker.StopServices(ctx)
+	if err != nil {
+		if asked {
+			// Best effort: the bridge may be stopped already
+			client.CancelShutdown()
+		}
+		return actionResultMsg{success: false, message: fmt.Sprintf("Failed to stop: %v", err)}
+	}
+	return actionResultMsg{success: true, message: "🛑 Fetch services stopped." + note}
+}
+
+func (m model) stopServices() (model, tea.Cmd) {
+	if demoWorld != nil {
+		return m.demoServices("stop")
+	}
+	return m.askConfirm("Stop Fetch",
+		"Stop the bridge and kennel containers? Replies in progress get up to 15s to finish; running tasks are interrupted.",
+		"Stop", func(m model) (model, tea.Cmd) {
+			m.servicesStopped = true
+			client := m.statusClient
+			return m.runCancellable("Stopping Fetch", func(ctx context.Context) tea.Msg {
+				return stopFetch(ctx, client)
+			})
+		})
+}
+
+// disconnectWhatsApp logs the bridge out of WhatsApp. Linking again needs
+// a new QR scan or pairing code.
+func (m model) disconnectWhatsApp() (model, tea.Cmd) {
+	if demoWorld != nil {
+		return m.demoServices("Disconnecting WhatsApp")
+	}
+	return m.askConfirm("Disconnect WhatsApp",
+		"Log Fetch out of WhatsApp? You will need to scan a new QR code or pair again to reconnect.",
+		"Disconnect", func(m model) (model, tea.Cmd) {
+			m.whatsappLoggedOut = true
+			client := m.statusClient
+			return m, func() tea.Msg {
+				resp, err := client.Logout()
+				if err != nil {
+					return actionResultMsg{success: false, message: fmt.Sprintf("Failed to disconnect: %v", err)}
+				}
+				if !resp.Success {
+					return actionResultMsg{success: false, message: "Failed to disconnect: " + resp.Message}
+				}
+				return actionResultMsg{success: true, message: "WhatsApp disconnected."}
+			}
+		})
+}
+
+// reconnectWhatsApp restarts the bridge so it reconnects with the saved
+// login.
+func (m model) reconnectWhatsApp() (model, tea.Cmd) {
+	if demoWorld != nil {
+		return m.demoServices("Reconnecting WhatsApp")
+	}
+	return m.askConfirm("Reconnect WhatsApp",
+		"Restart the bridge so it reconnects to WhatsApp with the saved login? Messages sent while it restarts are picked up afterwards.",
+		"Reconnect", func(m model) (model, tea.Cmd) {
+			return m.runCancellable("Restarting the bridge", func(ctx context.Context) tea.Msg {
+				if err := docker.RestartBridge(ctx); err != nil {
+					return actionResultMsg{success: false, message: fmt.Sprintf("Failed to reconnect: %v", err)}
+				}
+				return actionResultMsg{success: true, message: "Bridge restarted, reconnecting to WhatsApp."}
+			})
+		})
+}
+
+// relinkWhatsApp logs out, forgets the saved login, and restarts the bridge
+// so a new QR code can be scanned, e.g. to link a different phone.
+func (m model) relinkWhatsApp() (model, tea.Cmd) {
+	if demoWorld != nil {
+		return m.demoServices("Re-linking WhatsApp")
+	}
+	return m.askConfirm("Re-link WhatsApp",
+		"Log out, forget the saved login, and show a new QR code? Fetch stops answering until the new link is scanned.",
+		"Re-link", func(m model) (model, tea.Cmd) {
+			m.whatsappLoggedOut = true
+			client := m.statusClient
+			linked := m.bridgeStatus != nil && m.bridgeStatus.State == "authenticated"
+			return m.runCancellable("Re-linking WhatsApp", func(ctx context.Context) tea.Msg {
+				// Logging out first removes Fetch from the phone's linked devices
+				if linked {
+					if _, err := client.WithContext(ctx).Logout(); err != nil {
+						return actionResultMsg{success: false, message: fmt.Sprintf("Failed to log out: %v", err)}
+					}
+				}
+				if err := docker.ClearWhatsAppSession(); err != nil {
+					return actionResultMsg{success: false, message: fmt.Sprintf("Failed to clear the WhatsApp login: %v", err)}
+				}
+				if err := docker.RestartBridge(ctx); err != nil {
+					return actionResultMsg{success: false, message: fmt.Sprintf("Failed to restart bridge: %v", err)}
+				}
+				return actionResultMsg{success: true, message: "Bridge restarted, scan the new QR code."}
+			})
+		})
+}
+
+// clearWhatsAppAuth deletes the saved login without logging out, for when
+// the session is corrupt and the bridge can't connect at all.
+func (m model) clearWhatsAppAuth() (model, tea.Cmd) {
+	if demoWorld != nil {
+		return m.demoServices("Clearing the WhatsApp login")
+	}
+	return m.askConfirm("Clear WhatsApp login",
+		"Delete the bridge's saved WhatsApp session and restart it? Use this when it can't connect; remove the old device from WhatsApp → Linked Devices yourself.",
+		"Clear", func(m model) (model, tea.Cmd) {
+			m.whatsappLoggedOut = true
+			return m.runCancellable("Clearing the WhatsApp login", func(ctx context.Context) tea.Msg {
+				if err := docker.ClearWhatsAppSession(); err != nil {
+					return actionResultMsg{success: false, message: fmt.Sprintf("Failed to clear the WhatsApp login: %v", err)}
+				}
+				if err := docker.RestartBridge(ctx); err != nil {
+					return actionResultMsg{success: false, message: fmt.Sprintf("Failed to restart bridge: %v", err)}
+				}
+				return actionResultMsg{success: true, message: "WhatsApp login cleared, scan the new QR code."}
+			})
+		})
+}
+
+func (m model) restartBridge() (model, tea.Cmd) {
+	if demoWorld != nil {
+		return m.demoServices("Restarting the bridge")
+	}
+	return m.runCancellable("Restarting the bridge", func(ctx context.Context) tea.Msg {
+		if err := docker.RestartBridge(ctx); err != nil {
+			return actionResultMsg{success: false, message: fmt.Sprintf("Failed to restart bridge: %v", err)}
+		}
+		return actionResultMsg{success: true, message: "Bridge restarted."}
+	})
+}
+
+// openSetup shows the connection screen; status polling speeds up while
+// it's open.
+func (m model) openSetup() (model, tea.Cmd) {
+	return m.enter(screens.Setup)
+}
+
+// openGitProviders shows auth status, starting on the configured provider.
+func (m model) openGitProviders() (model, tea.Cmd) {
+	return m.enter(screens.GitHub)
+}
+
+// openStatus runs diagnostics the first time; afterwards the last results
+// are kept until 'r' re-runs them.
+func (m model) openStatus() (model, tea.Cmd) {
+	return m.enter(screens.Status)
+}
+
+// backupNow is the palette action for the Status screen's b key.
+func (m model) backupNow() (model, tea.Cmd) {
+	return m.sendScreen(screens.Status, statusscreen.BackupNowMsg{})
+}
+
+// openStats loads the traffic counters again on each visit.
+func (m model) openStats() (model, tea.Cmd) {
+	return m.enter(screens.Stats)
+}
+
+// openTasks reuses the board from an earlier visit and restarts its
+// refresh loop if that stopped while tabs were closed.
+func (m model) openTasks() (model, tea.Cmd) {
+	return m.enter(screens.Tasks)
+}
+
+// openApprovals lists tasks waiting on an answer and restarts the refresh
+// loop if it stopped when the screen was last left.
+func (m model) openApprovals() (model, tea.Cmd) {
+	return m.enter(screens.Approvals)
+}
+
+// openWorkspaces lists the kennel workspaces, keeping the selection from
+// an earlier visit.
+func (m model) openWorkspaces() (model, tea.Cmd) {
+	return m.enter(screens.Workspaces)
+}
+
+// openSummaries shows the stored conversation summaries, keeping the search
+// from an earlier visit.
+func (m model) openSummaries() (model, tea.Cmd) {
+	return m.enter(screens.Summaries)
+}
+
+// openLimits shows the limits panel, keeping the history from an earlier
+// visit, and restarts sampling if it stopped when the screen was left.
+func (m model) openLimits() (model, tea.Cmd) {
+	return m.enter(screens.Limits)
+}
+
+// openConfigure goes straight to the editor, keeping unsaved edits (and an
+// open model picker) from an earlier visit.
+func (m model) openConfigure() (model, tea.Cmd) {
+	return m.enter(screens.Config)
+}
+
+// configField returns an action that opens the editor on one field.
+// AGENT_MODEL opens the model picker and OWNER_PHONE_NUMBER the owner
+// change flow instead of a text field.
+func configField(key string) func(m model) (model, tea.Cmd) {
+	return func(m model) (model, tea.Cmd) {
+		m, cmd := m.openConfigure()
+		m, focus := m.sendScreen(screens.Config, configscreen.FocusMsg{Key: key})
+		return m, tea.Batch(cmd, focus)
+	}
+}
+
+// openWhitelist shows the trusted numbers, keeping the cursor and any edit
+// in progress from an earlier visit.
+func (m model) openWhitelist() (model, tea.Cmd) {
+	return m.enter(screens.Whitelist)
+}
+
+// openRepos shows the repository allow-list.
+func (m model) openRepos() (model, tea.Cmd) {
+	return m.enter(screens.Repos)
+}
+
+// openGroups shows the group chats list.
+func (m model) openGroups() (model, tea.Cmd) {
+	return m.enter(screens.Groups)
+}
+
+// openLogs starts following the bridge logs. They keep refreshing while
+// any tab is open.
+func (m model) openLogs() (model, tea.Cmd) {
+	return m.enter(screens.Logs)
+}
+
+func (m model) openDocs() (model, tea.Cmd) {
+	return m, openDocsCmd(m.statusClient.DocsURL())
+}
+
+// openUpdate previews incoming changes before anything is pulled.
+func (m model) openUpdate() (model, tea.Cmd) {
+	return m.enter(screens.Update)
+}
+
+func (m model) openVersion() (model, tea.Cmd) {
+	return m.enter(screens.Version)
+}
+
+func (m model) openNotifications() (model, tea.Cmd) {
+	m.toasts.DismissAll()
+	return m.enter(screens.Notifications)
+}
+
+func (m model) openConsole() (model, tea.Cmd) {
+	return m.enter(screens.Console)
+}
+
+// cycleTheme switches to the next color theme and saves it as FETCH_THEME.
+func (m model) cycleTheme() (model, tea.Cmd) {
+	name := theme.Next()
+	if err := theme.Set(name); err != nil {
+		return m, m.notify(err.Error(), components.SeverityError)
+	}
+	if err := config.SetEnvValue("FETCH_THEME", name); err != nil {
+		return m, m.notify(fmt.Sprintf("Theme set to %s, but saving it failed: %v", name, err), components.SeverityWarning)
+	}
+	return m, m.notify("Theme: "+name, components.SeverityInfo)
+}
+
+// toggleAccessible switches plain-text accessibility mode and saves it as
+// FETCH_ACCESSIBLE.
+func (m model) toggleAccessible() (model, tea.Cmd) {
+	on := !theme.Plain()
+	theme.SetPlain(on)
+	m, cmd := m.broadcast(screens.SettingMsg{Key: "FETCH_ACCESSIBLE", Value: strconv.FormatBool(on)})
+	label := "off"
+	if on {
+		label = "on"
+	}
+	if err := config.SetEnvValue("FETCH_ACCESSIBLE", strconv.FormatBool(on)); err != nil {
+		return m, tea.Batch(cmd, m.notify(fmt.Sprintf("Accessibility mode %s, but saving it failed: %v", label, err), components.SeverityWarning))
+	}
+	return m, tea.Batch(cmd, m.notify("Accessibility mode "+label, components.SeverityInfo))
+}
+
+func (m model) quit() (model, tea.Cmd) {
+	m.quitting = true
+	return m, tea.Quit
+}
@@ -9,18 +9,40 @@ echo "🐕 Building Fetch Manager..."
 cd "$(dirname "$0")"
 
 # Version info for ldflags injection
-VERSION=$(git describe --tags --always --dirty 2>/dev/null || echo "v1.0.0-dev")
+VERSION=$(git describe --tags --always 2>/dev/null || echo "v1.0.0-dev")
 COMMIT=$(git rev-parse --short HEAD 2>/dev/null || echo "unknown")
 BUILD_DATE=$(date -u +"%Y-%m-%dT%H:%M:%SZ")
+MODIFIED=false
+if [ -n "$(git status --porcelain --untracked-files=no 2>/dev/null)" ]; then
+    MODIFIED=true
+fi
 
 LDFLAGS="-s -w"
 LDFLAGS="${LDFLAGS} -X 'github.com/fetch/manager/internal/components.version=${VERSION}'"
 LDFLAGS="${LDFLAGS} -X 'github.com/fetch/manager/internal/components.buildDate=${BUILD_DATE}'"
 LDFLAGS="${LDFLAGS} -X 'github.com/fetch/manager/internal/components.gitCommit=${COMMIT}'"
+LDFLAGS="${LDFLAGS} -X 'github.com/fetch/manager/internal/components.modified=${MODIFIED}'"
 
 # Tidy dependencies
 go mod tidy
 
+# Release mode: cross-compile the assets the manager's self-update expects
+# (fetch-manager-<os>-<arch>[.exe] plus checksums.txt) into dist/
+if [ "$1" = "--release" ]; then
+    rm -rf dist && mkdir -p dist
+    for target in linux/amd64 linux/arm64 darwin/amd64 darwin/arm64 windows/amd64; do
+        os="${target%/*}"
+        arch="${target#*/}"
+        out="dist/fetch-manager-${os}-${arch}"
+        [ "$os" = "windows" ] && out="${out}.exe"
+        echo "Building ${out}..."
+        GOOS=$os GOARCH=$arch go build -ldflags "${LDFLAGS}" -o "$out" .
+    done
+    (cd dist && sha256sum fetch-manager-* > checksums.txt)
+    echo "✅ Release assets in dist/ — attach them to the GitHub release for ${VERSION}"
+    exit 0
+fi
+
 # Build for current platform
 echo "Building for current platform..."
 go build -ldflags "${LDFLAGS}" -o fetch-manager .
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/health"
+	"github.com/fetch/manager/internal/models"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/support"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// command is a subcommand that runs instead of the TUI.
+type command struct {
+	name  string
+	usage string
+	help  string
+	run   func(opts options) error
+}
+
+// commands are the subcommands, in the order usage lists them.
+var commands = []command{
+	{"status", "status [--json|--short]", "Show containers and the WhatsApp connection", runStatus},
+	{"models", "models [--json] [--all]", "List OpenRouter models that support tools", runModels},
+	{"config", "config get [KEY...]", "Print settings from .env (--json, --reveal)", runConfig},
+	{"watchdog", "watchdog [--interval]", "Restart crashed services and notify, without the TUI", runWatchdog},
+	{"bundle", "bundle", "Write a support bundle to data/ for a GitHub issue", runBundle},
+}
+
+// findCommand returns the named subcommand, or nil.
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// printJSON writes v to stdout, indented for people reading it too.
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// statusReport is the output of fetch-manager status.
+type statusReport struct {
+	Version           string               `json:"version"`
+	Containers        []containerReport    `json:"containers"`
+	BridgeReachable   bool                 `json:"bridgeReachable"`
+	BridgeError       string               `json:"bridgeError,omitempty"`
+	WhatsAppConnected bool                 `json:"whatsappConnected"`
+	Bridge            *status.BridgeStatus `json:"bridge,omitempty"`
+	CheckedAt         string               `json:"checkedAt"`
+}
+
+type containerReport struct {
+	Name    string `json:"name"`
+	Running bool   `json:"running"`
+	State   string `json:"state"`            // Empty when the container doesn't exist
+	Health  string `json:"health,omitempty"` // Empty without a healthcheck
+}
+
+// runStatus prints the same checks the TUI's status polling makes.
+func runStatus(opts options) error {
+	s := health.Check(status.NewClient(opts.apiURL, opts.apiToken))
+	r := statusReport{
+		Version:           components.DefaultVersionInfo().Version,
+		BridgeReachable:   s.Bridge != nil,
+		WhatsAppConnected: s.WhatsAppState() == "authenticated",
+		Bridge:            s.Bridge,
+		CheckedAt:         s.At.Format(time.RFC3339),
+	}
+	if s.BridgeErr != nil {
+		r.BridgeError = s.BridgeErr.Error()
+	}
+	for _, c := range s.Containers {
+		r.Containers = append(r.Containers, containerReport{Name: c.Name, Running: c.Running(), State: c.State, Health: c.Health})
+	}
+	if opts.jsonOutput {
+		return printJSON(r)
+	}
+	if opts.short {
+		line, err := shortStatus(s, opts.colors)
+		if err != nil {
+			return err
+		}
+		fmt.Println(line)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, c := range r.Containers {
+		state := c.State
+		if state == "" {
+			state = "missing"
+		}
+		if c.Health != "" {
+			state += " (" + c.Health + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", c.Name, state)
+	}
+	switch {
+	case s.Bridge == nil:
+		fmt.Fprintf(w, "bridge API\tunreachable: %v\n", s.BridgeErr)
+	default:
+		fmt.Fprintf(w, "WhatsApp\t%s\n", s.Bridge.StateDescription())
+		fmt.Fprintf(w, "uptime\t%s\n", s.Bridge.FormatUptime())
+		fmt.Fprintf(w, "messages\t%d\n", s.Bridge.MessageCount)
+	}
+	return w.Flush()
+}
+
+// statusLevel is how a part of the short status is colored.
+type statusLevel int
+
+const (
+	levelOK statusLevel = iota
+	levelWarn
+	levelDown
+)
+
+// statusColors wraps text in each status bar's color markup. "auto" picks
+// ANSI on a terminal and none otherwise.
+var statusColors = map[string]func(text string, level statusLevel) string{
+	"ansi": func(text string, level statusLevel) string {
+		return fmt.Sprintf("\x1b[%dm%s\x1b[0m", [...]int{32, 33, 31}[level], text)
+	},
+	"tmux": func(text string, level statusLevel) string {
+		return fmt.Sprintf("#[fg=%s]%s#[default]", [...]string{"green", "yellow", "red"}[level], text)
+	},
+	"polybar": func(text string, level statusLevel) string {
+		return fmt.Sprintf("%%{F%s}%s%%{F-}", [...]string{"#50fa7b", "#f1fa8c", "#ff5555"}[level], text)
+	},
+	"none": func(text string, _ statusLevel) string { return text },
+}
+
+// shortStatus formats a snapshot as one line for tmux or polybar, e.g.
+// "🐕 bridge✓ kennel✓ wa:connected msgs:42".
+func shortStatus(s health.Snapshot, colors string) (string, error) {
+	if colors == "auto" || colors == "" {
+		colors = "none"
+		if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			colors = "ansi"
+		}
+	}
+	paint, ok := statusColors[colors]
+	if !ok {
+		return "", fmt.Errorf("unknown --colors %q: use auto, ansi, tmux, polybar, or none", colors)
+	}
+
+	parts := []string{theme.Cue("🐕", "fetch")}
+	for _, c := range s.Containers {
+		name := strings.TrimPrefix(c.Name, "fetch-")
+		switch {
+		case c.Unhealthy():
+			parts = append(parts, paint(name+theme.Cue("!", ":unhealthy"), levelWarn))
+		case c.Running():
+			parts = append(parts, paint(name+theme.Cue("✓", ":up"), levelOK))
+		default:
+			parts = append(parts, paint(name+theme.Cue("✗", ":down"), levelDown))
+		}
+	}
+	if s.Bridge == nil {
+		return strings.Join(append(parts, paint("wa:?", levelDown)), " "), nil
+	}
+	level := levelWarn
+	switch s.Bridge.State {
+	case "authenticated":
+		level = levelOK
+	case "disconnected", "error":
+		level = levelDown
+	}
+	parts = append(parts,
+		paint("wa:"+strings.ReplaceAll(s.Bridge.ShortState(), " ", "-"), level),
+		fmt.Sprintf("msgs:%d", s.Bridge.MessageCount))
+	return strings.Join(parts, " "), nil
+}
+
+// modelReport is one model in the output of fetch-manager models.
+type modelReport struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	ContextLength   int      `json:"contextLength"`
+	PromptPrice     string   `json:"promptPrice"`     // USD per token, as OpenRouter reports it
+	CompletionPrice string   `json:"completionPrice"` // USD per token
+	Tools           bool     `json:"tools"`
+	InputModalities []string `json:"inputModalities,omitempty"`
+	Current         bool     `json:"current"` // The AGENT_MODEL in .env
+}
+
+// runModels lists the OpenRouter models the agent can use.
+func runModels(opts options) error {
+	key := envOrDotEnv("OPENROUTER_API_KEY")
+	if key == "" {
+		return errors.New("OPENROUTER_API_KEY isn't set")
+	}
+	list, err := models.FetchModels(context.Background(), key)
+	if err != nil {
+		return err
+	}
+	if !opts.allModels {
+		list = models.FilterToolCapable(list)
+	}
+	current := models.GetCurrentModel()
+
+	reports := make([]modelReport, 0, len(list))
+	for _, m := range list {
+		reports = append(reports, modelReport{
+			ID:              m.ID,
+			Name:            m.Name,
+			ContextLength:   m.ContextLength,
+			PromptPrice:     m.Pricing.Prompt,
+			CompletionPrice: m.Pricing.Completion,
+			Tools:           models.HasTools(m),
+			InputModalities: m.Architecture.InputModalities,
+			Current:         m.ID == current,
+		})
+	}
+	if opts.jsonOutput {
+		return printJSON(reports)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tMODEL\tCONTEXT\tINPUT\tOUTPUT")
+	for _, r := range reports {
+		mark := ""
+		if r.Current {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", mark, r.ID, models.FormatContextLength(r.ContextLength),
+			models.FormatPrice(r.PromptPrice), models.FormatPrice(r.CompletionPrice))
+	}
+	return w.Flush()
+}
+
+// runBundle handles fetch-manager bundle, for when the TUI won't start.
+func runBundle(opts options) error {
+	path, err := support.Create(components.DefaultVersionInfo())
+	if err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
+
+// runConfig handles fetch-manager config get [KEY...].
+func runConfig(opts options) error {
+	if len(opts.commandArgs) == 0 || opts.commandArgs[0] != "get" {
+		return errors.New("usage: config get [KEY...]")
+	}
+	settings, err := config.Settings(opts.commandArgs[1:], opts.reveal)
+	if err != nil {
+		return err
+	}
+	if opts.jsonOutput {
+		return printJSON(settings)
+	}
+
+	// One key prints just its effective value, for $(fetch-manager config get KEY)
+	if len(opts.commandArgs) == 2 {
+		s := settings[0]
+		if s.Redacted {
+			return fmt.Errorf("%s is secret; add --reveal to print it", s.Key)
+		}
+		if s.Value == "" {
+			s.Value = s.Default
+		}
+		fmt.Println(s.Value)
+		return nil
+	}
+	for _, s := range settings {
+		line := s.Key + "=" + s.Value
+		switch {
+		case s.Redacted:
+			line += "<redacted>"
+		case s.Value == "" && s.Default != "":
+			line += "  # default " + s.Default
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
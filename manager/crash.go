@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// crashReport describes a panic caught by the error boundary.
+type crashReport struct {
+	value  string
+	stack  string
+	screen screens.ID
+	at     time.Time
+}
+
+// panicMsg carries a panic recovered inside a command back to Update.
+type panicMsg struct {
+	report *crashReport
+}
+
+// crashSlot passes a panic caught in View on to Update. Model copies share
+// it, so what the value receiver of View leaves here is seen by the next
+// Update.
+type crashSlot struct {
+	report *crashReport
+}
+
+func newCrashReport(r any, s screens.ID) *crashReport {
+	return &crashReport{
+		value:  fmt.Sprint(r),
+		stack:  string(debug.Stack()),
+		screen: s,
+		at:     time.Now(),
+	}
+}
+
+// String formats the report for pasting into an issue.
+func (c *crashReport) String() string {
+	info := components.DefaultVersionInfo()
+	screenName := screens.Keymaps[c.screen].Title
+	if screenName == "" {
+		screenName = fmt.Sprintf("screen %d", c.screen)
+	}
+	return fmt.Sprintf("Fetch Manager %s (commit %s, %s, %s/%s)\nScreen: %s\nTime: %s\nPanic: %s\n\n%s",
+		info.Label(), info.GitCommit, info.GoVersion, runtime.GOOS, runtime.GOARCH,
+		screenName, c.at.Format(time.RFC3339), c.value, c.stack)
+}
+
+// safeCmd runs cmd behind the error boundary. Batches are unwrapped so each
+// of their commands is guarded too; a panic becomes a panicMsg instead of
+// taking down the program.
+func safeCmd(cmd tea.Cmd, s screens.ID) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = panicMsg{report: newCrashReport(r, s)}
+			}
+		}()
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i, c := range batch {
+				batch[i] = safeCmd(c, s)
+			}
+		}
+		return msg
+	}
+}
+
+// updateCrash handles keys on the error screen.
+func (m model) updateCrash(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.String() {
+	case "c":
+		report := m.crash.String()
+		return m, func() tea.Msg {
+			if err := clipboard.WriteAll(report); err != nil {
+				return actionResultMsg{success: false, message: fmt.Sprintf("Failed to copy report: %v", err)}
+			}
+			return actionResultMsg{success: true, message: "📋 Copied crash report"}
+		}
+	case "esc", "enter":
+		// Drop whatever overlay was open when it happened and start over
+		m.crash = nil
+		m.palette = nil
+		m.confirm = nil
+		m.showHelp = false
+		m.screen = screens.Menu
+		return m, nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// viewCrash renders the error screen for a caught panic.
+func (m model) viewCrash(c *crashReport) string {
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+	height := m.height
+	if height == 0 {
+		height = 24
+	}
+
+	title := layout.SectionHeader("💥 Something went wrong", width-4)
+	var content strings.Builder
+	content.WriteString(theme.StatusError().Render("   The manager caught an internal error and kept running.") + "\n\n")
+	content.WriteString("   " + theme.Muted().Render("Panic:") + " " + theme.Value().Render(layout.Truncate(c.value, width-14)) + "\n\n")
+
+	// Only these keys work here, so skip the usual status line and ? hint
+	help := components.HelpBar(screens.KeyHelp(screens.Crash, "c", "Esc", "q"), width)
+	// Show as much of the stack as fits above the help bar
+	room := max(1, height-lipgloss.Height(title)-lipgloss.Height(content.String())-lipgloss.Height(help)-1)
+	lines := strings.Split(strings.TrimSpace(c.stack), "\n")
+	if len(lines) > room {
+		lines = append(lines[:room-1], fmt.Sprintf("… %d more lines (press c to copy the full report)", len(lines)-room+1))
+	}
+	for _, line := range lines {
+		content.WriteString(theme.Muted().Render("   "+layout.Truncate(strings.ReplaceAll(line, "\t", "  "), width-6)) + "\n")
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left, title, content.String())
+	return layout.Bottom(body, help, height)
+}
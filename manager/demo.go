@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/demo"
+	"github.com/fetch/manager/internal/models"
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/update"
+)
+
+// demoWorld is the made-up deployment shown in demo mode; nil otherwise.
+// Status, bridge, log, diagnostic, model, and release reads come from it
+// instead of Docker, the bridge, OpenRouter, and GitHub.
+var demoWorld *demo.World
+
+// demoAPIURL is where the status client points in demo mode: nothing
+// listens there, so screens without demo data show the bridge as
+// unreachable rather than a real bridge's data.
+const demoAPIURL = "http://127.0.0.1:9"
+
+// demoEnv is the demo project's .env.
+const demoEnv = `OWNER_PHONE_NUMBER=+15550100042
+OPENROUTER_API_KEY=sk-or-v1-0000000000000000000000000000000000000000000000000000000000000000
+AGENT_MODEL=anthropic/claude-sonnet-4
+ENABLE_CLAUDE=true
+ENABLE_COPILOT=true
+`
+
+// startDemo switches to demo mode. The manager runs in a throwaway project
+// directory, so settings, trusted numbers, and the saved screen go there
+// instead of the real project; the returned function removes it.
+func startDemo(opts *options) (cleanup func(), err error) {
+	dir, err := os.MkdirTemp("", "fetch-demo-")
+	if err != nil {
+		return nil, fmt.Errorf("creating the demo project: %w", err)
+	}
+	cleanup = func() { os.RemoveAll(dir) }
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0o755); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("creating the demo project: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(demoEnv), 0o600); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("creating the demo project: %w", err)
+	}
+
+	paths.Use(dir)
+	opts.apiURL, opts.apiToken = demoAPIURL, ""
+	demoWorld = demo.New(opts.demoSeed)
+	models.UseFixed(demoWorld.Models())
+	models.UseFixedCredits(demoWorld.Credits())
+	update.UseFixedReleases(demoWorld.Releases())
+	return cleanup, nil
+}
+
+// demoServices stands in for actions on the containers in demo mode:
+// "start" and "stop" flip the made-up containers, and any other action,
+// named as in a progress title, only says it isn't available.
+func (m model) demoServices(action string) (model, tea.Cmd) {
+	switch action {
+	case "start":
+		demoWorld.SetRunning(true)
+		m.servicesStopped = false
+		return m, tea.Batch(checkStatus, m.notify("Fetch started (demo)", components.SeveritySuccess))
+	case "stop":
+		demoWorld.SetRunning(false)
+		m.servicesStopped = true
+		return m, tea.Batch(checkStatus, m.notify("Fetch stopped (demo)", components.SeveritySuccess))
+	}
+	return m, m.notify(action+" isn't available in demo mode", components.SeverityInfo)
+}
@@ -0,0 +1,203 @@
+// Package backup archives Fetch's configuration and persistent data and
+// prunes old archives. Archives are gzipped tarballs of .env and data/ kept
+// in the project's backups/ directory.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fetch/manager/internal/paths"
+)
+
+const (
+	prefix     = "fetch-backup-"
+	suffix     = ".tar.gz"
+	timeLayout = "20060102-150405"
+)
+
+// skipDirs are directories under data/ that only hold caches and are
+// rebuilt on their own.
+var skipDirs = map[string]bool{
+	".wwebjs_cache": true,
+}
+
+// Backup is one archive on disk.
+type Backup struct {
+	Path      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+// Name returns the archive's file name.
+func (b Backup) Name() string {
+	return filepath.Base(b.Path)
+}
+
+// Dir returns the directory archives are written to.
+func Dir() string {
+	return filepath.Join(paths.ProjectDir, "backups")
+}
+
+// Create archives .env and data/ into a new file in Dir. The archive is
+// written to a temporary file first so a failed backup never looks complete.
+func Create() (*Backup, error) {
+	if err := os.MkdirAll(Dir(), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", Dir(), err)
+	}
+
+	now := time.Now()
+	path := filepath.Join(Dir(), prefix+now.Format(timeLayout)+suffix)
+	tmp, err := os.CreateTemp(Dir(), ".backup-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to save archive: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Backup{Path: path, Size: info.Size(), CreatedAt: now}, nil
+}
+
+// write streams the archive into w.
+func write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := addFile(tw, paths.EnvFile, ".env"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	dataDir := filepath.Join(paths.ProjectDir, "data")
+	err := filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == dataDir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() && skipDirs[d.Name()] {
+			return fs.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(paths.ProjectDir, path)
+		if err != nil {
+			return err
+		}
+		return addFile(tw, path, filepath.ToSlash(rel))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive data: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// addFile copies one file into the archive under name.
+func addFile(tw *tar.Writer, path, name string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+	return nil
+}
+
+// List returns the archives in Dir, newest first. A missing directory
+// means no backups yet.
+func List() ([]Backup, error) {
+	entries, err := os.ReadDir(Dir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		created, err := time.ParseInLocation(timeLayout, strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix), time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{Path: filepath.Join(Dir(), name), Size: info.Size(), CreatedAt: created})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+// Prune deletes all but the newest keep archives and returns how many were
+// removed. keep below 1 keeps everything.
+func Prune(keep int) (int, error) {
+	if keep < 1 {
+		return 0, nil
+	}
+	backups, err := List()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, b := range backups[min(keep, len(backups)):] {
+		if err := os.Remove(b.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", b.Name(), err)
+		}
+		removed++
+	}
+	return removed, nil
+}
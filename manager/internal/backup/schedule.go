@@ -0,0 +1,57 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule is how often backups are taken automatically.
+type Schedule string
+
+const (
+	Off    Schedule = "off"
+	Daily  Schedule = "daily"
+	Weekly Schedule = "weekly"
+)
+
+// DefaultKeep is how many archives are kept when FETCH_BACKUP_KEEP is unset.
+const DefaultKeep = 7
+
+// ParseSchedule parses a schedule name. Empty means Off.
+func ParseSchedule(s string) (Schedule, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "off", "false", "no", "none":
+		return Off, nil
+	case "daily", "day", "1d":
+		return Daily, nil
+	case "weekly", "week", "7d":
+		return Weekly, nil
+	}
+	return Off, fmt.Errorf("invalid backup schedule %q (use daily, weekly, or off)", s)
+}
+
+// Interval returns the time between backups, or 0 when Off.
+func (s Schedule) Interval() time.Duration {
+	switch s {
+	case Daily:
+		return 24 * time.Hour
+	case Weekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Next returns when the next backup is due after one taken at last. A zero
+// last means one is due now.
+func (s Schedule) Next(last time.Time) time.Time {
+	if last.IsZero() {
+		return time.Now()
+	}
+	return last.Add(s.Interval())
+}
+
+// Due reports whether a scheduled backup should run at now.
+func (s Schedule) Due(last, now time.Time) bool {
+	return s != Off && !now.Before(s.Next(last))
+}
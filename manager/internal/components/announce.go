@@ -0,0 +1,28 @@
+// This file provides coarse textual progress announcements.
+
+package components
+
+import "fmt"
+
+// CoarseCountdown describes the remaining time in steps large enough that the
+// text only changes a handful of times, so screen readers announce it instead
+// of a constantly redrawing bar. 20s reads as 20, then 10, then 5 seconds.
+func CoarseCountdown(seconds int) string {
+	if seconds <= 0 {
+		return "Refreshing now"
+	}
+
+	step := 5
+	switch {
+	case seconds > 60:
+		step = 30
+	case seconds > 10:
+		step = 10
+	}
+	rounded := ((seconds + step - 1) / step) * step
+
+	if rounded >= 120 {
+		return fmt.Sprintf("About %d minutes remaining", rounded/60)
+	}
+	return fmt.Sprintf("About %d seconds remaining", rounded)
+}
@@ -0,0 +1,85 @@
+// Package components provides a confirmation dialog for the Fetch TUI.
+package components
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// ConfirmResult is the outcome of a key press in a Confirm dialog.
+type ConfirmResult int
+
+const (
+	ConfirmPending ConfirmResult = iota // Still waiting for an answer
+	ConfirmYes
+	ConfirmNo
+)
+
+// Confirm is a yes/no dialog for destructive actions. Cancel has focus by
+// default so a stray Enter never destroys anything.
+type Confirm struct {
+	Title        string
+	Message      string
+	ConfirmLabel string // e.g. "Delete"; defaults to "Confirm"
+	onConfirm    bool   // Focus is on the confirm button
+}
+
+// NewConfirm creates a dialog asking before a destructive action.
+func NewConfirm(title, message, confirmLabel string) *Confirm {
+	if confirmLabel == "" {
+		confirmLabel = "Confirm"
+	}
+	return &Confirm{Title: title, Message: message, ConfirmLabel: confirmLabel}
+}
+
+// Update handles a key: y confirms, n or Esc cancels, arrows and Tab move
+// focus, and Enter picks the focused button.
+func (c *Confirm) Update(msg tea.KeyMsg) ConfirmResult {
+	switch msg.String() {
+	case "y", "Y":
+		return ConfirmYes
+	case "n", "N", "esc", "q":
+		return ConfirmNo
+	case "left", "right", "h", "l", "tab", "shift+tab":
+		c.onConfirm = !c.onConfirm
+	case "enter", " ":
+		if c.onConfirm {
+			return ConfirmYes
+		}
+		return ConfirmNo
+	}
+	return ConfirmPending
+}
+
+// View renders the dialog as a bordered box.
+func (c *Confirm) View(width int) string {
+	boxWidth := min(56, width-4)
+
+	button := func(label string, focused bool, color lipgloss.TerminalColor) string {
+		style := lipgloss.NewStyle().Padding(0, 2)
+		if focused && theme.Plain() {
+			// Brackets mark focus without relying on the background color
+			return style.Padding(0, 1).Foreground(color).Bold(true).Render("[" + label + "]")
+		}
+		if focused {
+			return style.Background(color).Foreground(theme.Active().Background).Bold(true).Render(label)
+		}
+		return style.Foreground(color).Render(label)
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(theme.Active().Warning).Bold(true).Render("⚠ "+c.Title) + "\n\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(theme.Active().TextPrimary).Width(boxWidth-4).Render(c.Message) + "\n\n")
+	b.WriteString(button("Cancel (n)", !c.onConfirm, theme.Active().TextSecondary) + "  " +
+		button(c.ConfirmLabel+" (y)", c.onConfirm, theme.Active().Error))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Active().Warning).
+		Padding(0, 1).
+		Width(boxWidth).
+		Render(b.String())
+}
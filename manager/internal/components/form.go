@@ -0,0 +1,416 @@
+// Package components provides a form of labelled, grouped fields.
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/theme"
+)
+
+// FormField is one labelled value of a Form.
+type FormField struct {
+	Key     string
+	Label   string
+	Group   string // Fields sharing a group are listed under its header
+	Value   string
+	Default string // Shown dimmed while Value is empty
+	Help    string // Shown under the field while it has focus
+	Masked  bool   // Shown as dots unless being edited
+	// Action fields aren't typed into: Enter reports FormActivated and the
+	// screen opens its own flow, such as a picker
+	Action bool
+	// Filter drops typed and pasted characters it rejects; nil keeps all
+	Filter func(r rune) bool
+	// Validate checks a value on Enter and may normalize it. An error keeps
+	// the field open with the message under it; nil accepts anything.
+	Validate func(value string) (string, error)
+}
+
+// FormEvent is what a key or click did to a Form.
+type FormEvent int
+
+const (
+	FormIgnored   FormEvent = iota // Not a key the form uses
+	FormHandled                    // Focus moved or the text being edited changed
+	FormCommitted                  // Enter stored the edited value
+	FormCancelled                  // Esc closed the field without storing it
+	FormActivated                  // Enter on an Action field
+)
+
+// Form lists fields under their group headers, moves focus between them
+// and edits the focused one in a TextInput. Long forms scroll in a
+// viewport that keeps the focused field, with its help and notes, in view.
+type Form struct {
+	fields     []FormField
+	cursor     int
+	viewport   viewport.Model
+	lineOf     []int // First rendered line of each field, header included, then the total
+	viewHeight int   // Lines the fields may fill; 0 shows all
+	input      *TextInput
+	err        string // Validation message for the field being edited
+
+	// LabelWidth pads labels into a column; 0 leaves them as they are
+	LabelWidth int
+	// Tag adds text after a field's value, such as where it came from
+	Tag func(field FormField, editing bool) string
+	// Notes adds styled lines under the focused field's help. While it's
+	// being edited, field.Value holds the text typed so far.
+	Notes func(field FormField, editing bool) []string
+}
+
+// NewForm creates a form over fields with the first one focused.
+func NewForm(fields ...FormField) *Form {
+	vp := viewport.New(0, 0)
+	vp.Style = lipgloss.NewStyle()
+	return &Form{fields: fields, viewport: vp}
+}
+
+// Styles read the active theme each time so theme changes apply at once.
+func formLabelStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextSecondary)
+}
+
+func formInputStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Primary)
+}
+
+func formFocusedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Success).Bold(true)
+}
+
+func formMutedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Italic(true)
+}
+
+func formGroupStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true)
+}
+
+// Fields returns the fields with their current values.
+func (f *Form) Fields() []FormField {
+	return f.fields
+}
+
+// Value returns the value of the field with the given key.
+func (f *Form) Value(key string) string {
+	if i := f.index(key); i >= 0 {
+		return f.fields[i].Value
+	}
+	return ""
+}
+
+// SetValue sets the value of the field with the given key.
+func (f *Form) SetValue(key, value string) {
+	if i := f.index(key); i >= 0 {
+		f.fields[i].Value = value
+	}
+}
+
+func (f *Form) index(key string) int {
+	for i, field := range f.fields {
+		if field.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Focused returns the field with focus; ok is false for an empty form.
+func (f *Form) Focused() (field FormField, ok bool) {
+	if f.cursor >= len(f.fields) {
+		return FormField{}, false
+	}
+	return f.fields[f.cursor], true
+}
+
+// Select moves focus to the field with the given key without opening it.
+// It reports whether the field exists.
+func (f *Form) Select(key string) bool {
+	i := f.index(key)
+	if i < 0 {
+		return false
+	}
+	f.cursor = i
+	f.ensureVisible()
+	return true
+}
+
+// Editing reports whether the focused field is open for typing.
+func (f *Form) Editing() bool {
+	return f.input != nil
+}
+
+// Open starts editing the focused field, or reports FormActivated for an
+// Action field.
+func (f *Form) Open() FormEvent {
+	field, ok := f.Focused()
+	switch {
+	case !ok:
+		return FormIgnored
+	case field.Action:
+		return FormActivated
+	}
+	f.input = NewTextInput(field.Value)
+	f.input.Filter = field.Filter
+	f.err = ""
+	return FormHandled
+}
+
+// SetHeight sets the lines the fields may fill, scroll hints included;
+// 0 shows them all.
+func (f *Form) SetHeight(lines int) {
+	f.viewHeight = max(0, lines)
+	f.ensureVisible()
+}
+
+// layout renders every field into the viewport and records the line
+// each one starts on. Blocks are measured as rendered, so wrapped notes
+// and the focused field's help lines are counted as drawn.
+func (f *Form) layout() {
+	var b strings.Builder
+	f.lineOf = f.lineOf[:0]
+	line := 0
+	for i := range f.fields {
+		f.lineOf = append(f.lineOf, line)
+		block := strings.Join(f.lines(i), "\n")
+		b.WriteString(block + "\n")
+		line += lipgloss.Height(block)
+	}
+	f.lineOf = append(f.lineOf, line)
+
+	height := line
+	if f.viewHeight > 0 && line > f.viewHeight {
+		height = max(1, f.viewHeight-2) // Room for the scroll hints
+	}
+	f.viewport.Height = height
+	f.viewport.SetContent(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// ensureVisible lays the form out and scrolls so the focused field's
+// block is in view, its top first when it is taller than the view.
+func (f *Form) ensureVisible() {
+	f.layout()
+	if f.cursor >= len(f.fields) {
+		return
+	}
+	top, bottom := f.lineOf[f.cursor], f.lineOf[f.cursor+1]
+	if bottom > f.viewport.YOffset+f.viewport.Height {
+		f.viewport.SetYOffset(bottom - f.viewport.Height)
+	}
+	if top < f.viewport.YOffset {
+		f.viewport.SetYOffset(top)
+	}
+}
+
+// showsHeader reports whether field i starts a group, and so is drawn
+// under the group's header.
+func (f *Form) showsHeader(i int) bool {
+	group := f.fields[i].Group
+	return group != "" && (i == 0 || f.fields[i-1].Group != group)
+}
+
+// Update applies a key. While a field is open, keys go to its input:
+// Enter validates and stores the value and Esc drops it. Otherwise ↑/↓
+// (or k/j, tab/shift+tab) move focus, PgUp/PgDn jump to the previous or
+// next group, Home/End to the first or last field, and Enter or e opens
+// the field.
+// Pasting on a closed field opens it with the pasted text.
+func (f *Form) Update(msg tea.KeyMsg) FormEvent {
+	if f.input != nil {
+		switch msg.String() {
+		case "enter":
+			return f.commit()
+		case "esc":
+			f.input, f.err = nil, ""
+			return FormCancelled
+		}
+		f.input.Update(msg)
+		return FormHandled
+	}
+
+	if msg.Paste {
+		if f.Open() != FormHandled {
+			return FormIgnored
+		}
+		f.input.Update(msg)
+		return FormHandled
+	}
+
+	switch msg.String() {
+	case "up", "k", "shift+tab":
+		if f.cursor > 0 {
+			f.cursor--
+			f.ensureVisible()
+		}
+		return FormHandled
+	case "down", "j", "tab":
+		if f.cursor < len(f.fields)-1 {
+			f.cursor++
+			f.ensureVisible()
+		}
+		return FormHandled
+	case "pgup", "pgdown", "home", "end":
+		f.jump(msg.String())
+		return FormHandled
+	case "enter", "e":
+		return f.Open()
+	}
+	return FormIgnored
+}
+
+// jump moves focus by a group for pgup/pgdown, or to either end of the
+// form for home/end. PgUp from inside a group goes to its first field.
+func (f *Form) jump(key string) {
+	if len(f.fields) == 0 {
+		return
+	}
+	switch key {
+	case "home":
+		f.cursor = 0
+	case "end":
+		f.cursor = len(f.fields) - 1
+	case "pgdown":
+		i := f.cursor + 1
+		for i < len(f.fields) && !f.showsHeader(i) {
+			i++
+		}
+		f.cursor = min(i, len(f.fields)-1)
+	case "pgup":
+		i := max(0, f.cursor-1)
+		for i > 0 && !f.showsHeader(i) {
+			i--
+		}
+		f.cursor = i
+	}
+	f.ensureVisible()
+}
+
+// commit validates the text being edited and stores it.
+func (f *Form) commit() FormEvent {
+	field := &f.fields[f.cursor]
+	value := f.input.Value()
+	if field.Validate != nil {
+		v, err := field.Validate(value)
+		if err != nil {
+			f.err = err.Error()
+			return FormHandled
+		}
+		value = v
+	}
+	field.Value = value
+	f.input, f.err = nil, ""
+	return FormCommitted
+}
+
+// ClickLine handles a click on a rendered line. Clicking a field focuses
+// it and clicking the focused field opens it, as Enter would. It returns
+// FormIgnored for lines that aren't a field.
+func (f *Form) ClickLine(line string) FormEvent {
+	if f.input != nil {
+		return FormIgnored
+	}
+	text := strings.TrimLeft(line, " ▶>")
+	for i, field := range f.fields {
+		if !strings.HasPrefix(text, field.Label+":") {
+			continue
+		}
+		if i == f.cursor {
+			return f.Open()
+		}
+		f.cursor = i
+		f.ensureVisible()
+		return FormHandled
+	}
+	return FormIgnored
+}
+
+// View renders the fields in view under their group headers, with the
+// focused field's help, validation message and notes beneath it.
+func (f *Form) View() string {
+	if len(f.fields) == 0 {
+		return ""
+	}
+	f.ensureVisible() // Notes follow the text being typed
+
+	var b strings.Builder
+	if f.viewport.YOffset > 0 {
+		b.WriteString(formMutedStyle().Render("   ▲ scroll up for more") + "\n")
+	}
+	b.WriteString(f.viewport.View() + "\n")
+	if !f.viewport.AtBottom() {
+		b.WriteString(formMutedStyle().Render("   ▼ scroll down for more") + "\n")
+	}
+	return b.String()
+}
+
+// lines renders field i and what goes with it: its group header, and for
+// the focused field its help, validation message and notes.
+func (f *Form) lines(i int) []string {
+	field := f.fields[i]
+	var lines []string
+	if f.showsHeader(i) {
+		lines = append(lines, "", formGroupStyle().Render("   ─── "+field.Group+" ───"))
+	}
+	lines = append(lines, f.row(field, i == f.cursor))
+	if i != f.cursor {
+		return lines
+	}
+	if field.Help != "" {
+		lines = append(lines, "     "+formMutedStyle().Render(field.Help))
+	}
+	if f.err != "" {
+		lines = append(lines, "     "+lipgloss.NewStyle().Foreground(theme.Active().Error).Render(theme.Cue("✗ ", "Error: ")+f.err))
+	}
+	if f.Notes != nil {
+		if f.input != nil {
+			field.Value = f.input.Value() // Notes follow the text as it's typed
+		}
+		for _, note := range f.Notes(field, f.input != nil) {
+			lines = append(lines, "     "+note)
+		}
+	}
+	return lines
+}
+
+// row renders one field: its label, then the text being edited, the value,
+// or the default in its place.
+func (f *Form) row(field FormField, focused bool) string {
+	labelStyle := formLabelStyle()
+	if f.LabelWidth > 0 {
+		labelStyle = labelStyle.Width(f.LabelWidth)
+	}
+	label := labelStyle.Render(field.Label + ":")
+	editing := focused && f.input != nil
+
+	var value string
+	switch {
+	case editing:
+		value = f.input.View(formInputStyle())
+	case field.Value == "" && field.Default != "":
+		value = field.Default
+		if focused {
+			value += " (default)"
+		}
+		value = formMutedStyle().Render(value)
+	default:
+		value = field.Value
+		if field.Masked && value != "" {
+			value = strings.Repeat("•", min(len(value), 20))
+		}
+		if focused {
+			value = formInputStyle().Render(value)
+		}
+	}
+	if f.Tag != nil {
+		value += f.Tag(field, editing)
+	}
+
+	if focused {
+		return formFocusedStyle().Render("▶ ") + label + " " + value
+	}
+	return "   " + label + " " + value
+}
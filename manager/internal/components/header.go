@@ -37,7 +37,7 @@ func Header(width, height int, status string) string {
 ⡿⠁⠀⠀⠀⠀⠀⠀⠘⣦⡀⠀⠀⠀⠀⢸⣿⠀`
 
 	logoStyle := lipgloss.NewStyle().
-		Foreground(theme.Primary).
+		Foreground(theme.Active().Primary).
 		Bold(true)
 
 	// Choose dog size based on height
@@ -51,11 +51,11 @@ func Header(width, height int, status string) string {
 func CompactHeader(title string, width int) string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(theme.Primary).
+		Foreground(theme.Active().Primary).
 		Padding(0, 1)
 
 	borderStyle := lipgloss.NewStyle().
-		Foreground(theme.Border)
+		Foreground(theme.Active().Border)
 
 	titleText := titleStyle.Render(title)
 	titleWidth := lipgloss.Width(titleText)
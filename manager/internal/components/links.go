@@ -0,0 +1,69 @@
+// This file provides numbered, keyboard-accessible links.
+
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// Link is a labelled URL that can be opened or copied by its number.
+type Link struct {
+	Label string
+	URL   string
+}
+
+// LinkList renders links as a numbered list so each one can be reached by key.
+func LinkList(links []Link) string {
+	if len(links) == 0 {
+		return ""
+	}
+
+	numStyle := lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Active().TextSecondary)
+	urlStyle := lipgloss.NewStyle().Foreground(theme.Active().Info).Underline(true)
+
+	var b strings.Builder
+	for i, link := range links {
+		if i >= 9 {
+			break
+		}
+		b.WriteString(fmt.Sprintf("   %s %s %s\n",
+			numStyle.Render(fmt.Sprintf("[%d]", i+1)),
+			labelStyle.Render(link.Label),
+			urlStyle.Render(link.URL)))
+	}
+	return b.String()
+}
+
+// LinkKey maps a key press to a link index. Digits 1-9 open the link,
+// alt+1-9 copies it to the clipboard instead.
+func LinkKey(key string, count int) (index int, copyLink bool, ok bool) {
+	if strings.HasPrefix(key, "alt+") {
+		copyLink = true
+		key = strings.TrimPrefix(key, "alt+")
+	}
+	if len(key) != 1 || key[0] < '1' || key[0] > '9' {
+		return 0, false, false
+	}
+	index = int(key[0] - '1')
+	if index >= count {
+		return 0, false, false
+	}
+	return index, copyLink, true
+}
+
+// LinkHelp returns the help bar entries for screens that show links.
+func LinkHelp(count int) []string {
+	switch {
+	case count == 0:
+		return nil
+	case count == 1:
+		return []string{"1 Open link", "alt+1 Copy"}
+	default:
+		return []string{fmt.Sprintf("1-%d Open link", min(count, 9)), "alt+# Copy"}
+	}
+}
@@ -6,13 +6,18 @@ package components
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/fetch/manager/internal/theme"
 )
 
@@ -24,8 +29,8 @@ type LogEntry struct {
 	Timestamp time.Time // When the log was generated
 	Level     string    // DEBUG, INFO, WARN, ERROR
 	Source    string    // Component that generated the log (bridge, kennel, etc.)
-	Message   string    // Full log message text (not truncated)
-	Raw       string    // Original raw log line for copying
+	Message   string    // Full log message text (not truncated), without terminal codes
+	Raw       string    // Original raw log line, colors included
 }
 
 // LogViewer is a scrollable, filterable log viewer with real-time updates.
@@ -34,6 +39,7 @@ type LogEntry struct {
 //   - Full viewport scrolling (up/down/page up/page down)
 //   - Auto-scroll to follow new logs
 //   - Filter by text (case-insensitive)
+//   - Show or hide levels with the header chips
 //   - Copy logs to clipboard
 //   - Word wrapping for long messages
 //   - Color-coded log levels
@@ -50,13 +56,82 @@ type LogViewer struct {
 	lastCopied  string
 	statusMsg   string
 	statusTimer int
+	confirm     *Confirm        // Pending clear confirmation
+	history     string          // Search shown instead of live logs; empty when live
+	footer      string          // Replaces the help line while set, e.g. a search prompt
+	hidden      map[string]bool // Levels toggled off with the chips, keyed like LogLevels
+	lineOf      []int           // First line of each entry; -1 when filtered out
+	dayBreak    []bool          // A day separator is drawn above the entry
+	offset      int             // First line in view
+	lines       int             // Lines the shown entries take, separators included
+	dropped     int             // Entries trimmed from the front so far, to follow one across trims
+	lineBase    int             // dropped as of the render lineOf came from
+	unseen      int             // Live entries that arrived below the view while scrolled up
+	picking     bool            // Choosing an entry to correlate
+	pick        int             // Index into logs of the entry being chosen
+	title       string          // Title bar text
+	focused     bool            // Highlighted as the pane taking keys in a split view
+
+	// Layout is deferred to View and only the entries in view are styled,
+	// so a frame costs the same however full the buffer is
+	dirty    bool                // Logs or settings changed since the last layout
+	cache    map[LogEntry]string // Styled line per entry drawn last frame
+	heights  map[LogEntry]int    // Lines per entry, as of the last layout
+	cacheKey renderKey           // Settings the cache and heights were made with
+	shown    int                 // Entries that pass the filter, as of the last layout
+}
+
+const (
+	// maxLogEntries caps the buffer; the oldest entries are dropped.
+	maxLogEntries = 10000
+	// renderSlack is how many heights of entries no longer in the buffer
+	// are kept before they are cleared out.
+	renderSlack = 1000
+)
+
+// renderKey holds the settings a styled line depends on.
+type renderKey struct {
+	width int
+	wrap  bool
+	raw   bool
+	theme *theme.Theme
+}
+
+// CorrelateMsg asks to follow one ID across the bridge and kennel logs. At
+// is when the chosen entry was logged.
+type CorrelateMsg struct {
+	ID string
+	At time.Time
+}
+
+// correlationIDs find the ID a log line is about, most specific first: a
+// named field such as taskId or sessionId, then Fetch's prefixed task IDs,
+// then "session <id>" in prose.
+var correlationIDs = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(?:request|req|task|session|correlation|trace)_?id"?\s*[:=]\s*"?([A-Za-z0-9_.:-]{4,})`),
+	regexp.MustCompile(`\b((?:tsk|prg)_[A-Za-z0-9_-]{8,10})`),
+	regexp.MustCompile(`(?i)\bsession ([A-Za-z0-9_.:-]{6,})`),
+}
+
+// CorrelationID returns the request, task, or session ID an entry mentions.
+func CorrelationID(entry LogEntry) (string, bool) {
+	text := entry.Message
+	if entry.Raw != "" {
+		text = ansi.Strip(entry.Raw)
+	}
+	for _, re := range correlationIDs {
+		if m := re.FindStringSubmatch(text); m != nil {
+			return strings.TrimRight(m[1], ".,:"), true
+		}
+	}
+	return "", false
 }
 
 // NewLogViewer creates a new log viewer with the specified dimensions.
 //
 // The viewer initializes with auto-scroll enabled and word wrap enabled by default.
 func NewLogViewer(width, height int) *LogViewer {
-	vp := viewport.New(width-4, height-8) // Account for frame, title, and help
+	vp := viewport.New(width-4, height-7) // Account for frame, title, chips, and help
 	vp.Style = lipgloss.NewStyle()
 	vp.MouseWheelEnabled = true
 
@@ -69,66 +144,230 @@ func NewLogViewer(width, height int) *LogViewer {
 		width:      width,
 		height:     height,
 		ready:      true,
+		hidden:     make(map[string]bool),
+		title:      "📜 Fetch Logs",
+	}
+}
+
+// SetTitle replaces the title shown for live logs, e.g. to name the
+// service in a split view.
+func (l *LogViewer) SetTitle(title string) {
+	l.title = title
+}
+
+// SetFocus highlights the viewer's border as the pane that takes keys.
+func (l *LogViewer) SetFocus(focused bool) {
+	l.focused = focused
+}
+
+// LogLevels are the levels with toggle chips, in number-key order.
+var LogLevels = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+// levelKey maps an entry's level onto LogLevels. Unrecognized levels map to
+// "" and are never hidden.
+func levelKey(level string) string {
+	switch strings.ToUpper(level) {
+	case "DEBUG":
+		return "DEBUG"
+	case "INFO", "SUCCESS", "OK":
+		return "INFO"
+	case "WARN", "WARNING":
+		return "WARN"
+	case "ERROR", "ERR":
+		return "ERROR"
+	}
+	return ""
+}
+
+// IsErrorLevel reports whether a parsed level is an error, however the
+// line spelled it.
+func IsErrorLevel(level string) bool {
+	return levelKey(level) == "ERROR"
+}
+
+// ToggleLevel shows or hides one of LogLevels.
+func (l *LogViewer) ToggleLevel(level string) {
+	l.hidden[level] = !l.hidden[level]
+	var shown []string
+	for _, lvl := range LogLevels {
+		if !l.hidden[lvl] {
+			shown = append(shown, lvl)
+		}
+	}
+	if len(shown) == 0 {
+		l.setStatus("Showing no levels")
+	} else {
+		l.setStatus("Showing " + strings.Join(shown, ", "))
+	}
+	l.invalidate()
+}
+
+// HiddenLevels returns the levels toggled off, in LogLevels order.
+func (l *LogViewer) HiddenLevels() []string {
+	var hidden []string
+	for _, lvl := range LogLevels {
+		if l.hidden[lvl] {
+			hidden = append(hidden, lvl)
+		}
+	}
+	return hidden
+}
+
+// SetHiddenLevels hides exactly the given levels.
+func (l *LogViewer) SetHiddenLevels(levels []string) {
+	l.hidden = make(map[string]bool)
+	for _, lvl := range levels {
+		if key := levelKey(lvl); key != "" {
+			l.hidden[key] = true
+		}
 	}
+	l.invalidate()
 }
 
 // SetSize updates the viewport dimensions for responsive layout.
 func (l *LogViewer) SetSize(width, height int) {
+	if width == l.width && height == l.height {
+		return
+	}
 	l.width = width
 	l.height = height
 	l.viewport.Width = width - 4
-	l.viewport.Height = height - 8
-	l.renderLogs()
+	l.viewport.Height = height - 7
+	l.invalidate()
 }
 
-// AddLog adds a new log entry and optionally scrolls to it.
+// AddLog adds a new log entry; it is drawn on the next frame.
 func (l *LogViewer) AddLog(entry LogEntry) {
-	l.logs = append(l.logs, entry)
-
-	// Keep log buffer manageable (last 1000 entries)
-	if len(l.logs) > 1000 {
-		l.logs = l.logs[len(l.logs)-1000:]
-	}
-
-	l.renderLogs()
-	if l.autoScroll {
-		l.viewport.GotoBottom()
-	}
+	l.AddLogs([]LogEntry{entry})
 }
 
 // AddLogs adds multiple log entries at once.
 func (l *LogViewer) AddLogs(entries []LogEntry) {
-	l.logs = append(l.logs, entries...)
-
-	// Keep log buffer manageable
-	if len(l.logs) > 1000 {
-		l.logs = l.logs[len(l.logs)-1000:]
+	if len(entries) == 0 {
+		return
+	}
+	l.logs = append(l.logs, plainMessages(entries)...)
+	if !l.autoScroll && l.history == "" {
+		for _, entry := range entries {
+			if l.matchesFilter(entry) {
+				l.unseen++
+			}
+		}
 	}
 
-	l.renderLogs()
-	if l.autoScroll {
-		l.viewport.GotoBottom()
+	// Keep log buffer manageable
+	if len(l.logs) > maxLogEntries {
+		l.dropped += len(l.logs) - maxLogEntries
+		l.logs = l.logs[len(l.logs)-maxLogEntries:]
+		// Indices moved; an entry being picked may be gone
+		l.picking = false
 	}
+	l.invalidate()
 }
 
 // SetLogs replaces all logs with a new set.
 func (l *LogViewer) SetLogs(entries []LogEntry) {
+	l.replaceLogs(plainMessages(entries))
+}
+
+// replaceLogs swaps in a new buffer. Nothing in it was on screen, so the
+// next render doesn't try to hold the scroll position.
+func (l *LogViewer) replaceLogs(entries []LogEntry) {
 	l.logs = entries
-	l.renderLogs()
-	if l.autoScroll {
-		l.viewport.GotoBottom()
+	l.lineOf = nil
+	l.unseen = 0
+	l.picking = false
+	l.invalidate()
+}
+
+// SyncTail merges a fresh read of the newest lines, such as the last few
+// hundred lines of docker logs, appending only the ones not already at the
+// end of the buffer. The buffer grows past one read, up to maxLogEntries.
+func (l *LogViewer) SyncTail(entries []LogEntry) {
+	entries = plainMessages(entries)
+	l.AddLogs(entries[tailOverlap(l.logs, entries):])
+}
+
+// plainMessages strips terminal codes, such as chalk's colors, from each
+// entry's Message in place. Raw keeps them for raw mode.
+func plainMessages(entries []LogEntry) []LogEntry {
+	for i := range entries {
+		if strings.IndexFunc(entries[i].Message, unicode.IsControl) >= 0 {
+			entries[i].Message = cleanLine(entries[i].Message, false)
+		}
 	}
+	return entries
+}
+
+// tailOverlap returns how many leading entries of next repeat the end of buf.
+func tailOverlap(buf, next []LogEntry) int {
+	for n := min(len(buf), len(next)); n > 0; n-- {
+		tail := buf[len(buf)-n:]
+		same := true
+		for i := range n {
+			if tail[i].Raw != next[i].Raw || tail[i].Message != next[i].Message {
+				same = false
+				break
+			}
+		}
+		if same {
+			return n
+		}
+	}
+	return 0
+}
+
+// StartHistory swaps the live logs for search results, described by title.
+// Results arrive through AppendHistory, oldest first.
+func (l *LogViewer) StartHistory(title string) {
+	l.history = title
+	l.autoScroll = false
+	l.replaceLogs(make([]LogEntry, 0))
+	l.flush()
+	l.offset = 0
+}
+
+// AppendHistory adds search results. Unlike AddLogs there is no cap; the
+// search limits itself.
+func (l *LogViewer) AppendHistory(entries []LogEntry) {
+	l.logs = append(l.logs, plainMessages(entries)...)
+	l.invalidate()
+}
+
+// EndHistory drops the search results and goes back to following live logs.
+func (l *LogViewer) EndHistory() {
+	l.history = ""
+	l.autoScroll = true
+	l.replaceLogs(make([]LogEntry, 0))
+}
+
+// InHistory reports whether search results are shown instead of live logs.
+func (l *LogViewer) InHistory() bool {
+	return l.history != ""
+}
+
+// SetFooter replaces the help line, or restores it when footer is empty.
+func (l *LogViewer) SetFooter(footer string) {
+	l.footer = footer
 }
 
 // SetFilter sets a filter string for logs (case-insensitive).
 func (l *LogViewer) SetFilter(filter string) {
 	l.filter = strings.ToLower(filter)
-	l.renderLogs()
+	l.invalidate()
+}
+
+// Filter returns the current filter string.
+func (l *LogViewer) Filter() string {
+	return l.filter
 }
 
 // ToggleAutoScroll toggles automatic scrolling to new logs.
 func (l *LogViewer) ToggleAutoScroll() {
 	l.autoScroll = !l.autoScroll
+	if l.autoScroll {
+		l.unseen = 0
+	}
 	l.setStatus("Auto-scroll: " + boolToOnOff(l.autoScroll))
 }
 
@@ -136,14 +375,14 @@ func (l *LogViewer) ToggleAutoScroll() {
 func (l *LogViewer) ToggleWordWrap() {
 	l.wordWrap = !l.wordWrap
 	l.setStatus("Word wrap: " + boolToOnOff(l.wordWrap))
-	l.renderLogs()
+	l.invalidate()
 }
 
 // ToggleRaw toggles showing raw log lines.
 func (l *LogViewer) ToggleRaw() {
 	l.showRaw = !l.showRaw
 	l.setStatus("Raw mode: " + boolToOnOff(l.showRaw))
-	l.renderLogs()
+	l.invalidate()
 }
 
 // CopyAllLogs copies all visible logs to clipboard.
@@ -152,7 +391,7 @@ func (l *LogViewer) CopyAllLogs() {
 	for _, entry := range l.logs {
 		if l.matchesFilter(entry) {
 			if l.showRaw && entry.Raw != "" {
-				b.WriteString(entry.Raw + "\n")
+				b.WriteString(ansi.Strip(entry.Raw) + "\n")
 			} else {
 				b.WriteString(fmt.Sprintf("[%s] %s [%s] %s\n",
 					entry.Timestamp.Format("15:04:05"),
@@ -173,7 +412,8 @@ func (l *LogViewer) CopyAllLogs() {
 
 // CopySelectedLog copies the currently visible portion to clipboard.
 func (l *LogViewer) CopySelectedLog() {
-	content := l.viewport.View()
+	l.flush()
+	content := l.window()
 	if err := clipboard.WriteAll(content); err != nil {
 		l.setStatus("❌ Copy failed: " + err.Error())
 	} else {
@@ -183,11 +423,185 @@ func (l *LogViewer) CopySelectedLog() {
 
 // Clear removes all logs.
 func (l *LogViewer) Clear() {
-	l.logs = make([]LogEntry, 0)
-	l.renderLogs()
+	l.replaceLogs(make([]LogEntry, 0))
 	l.setStatus("🗑️ Logs cleared")
 }
 
+// Picking reports whether an entry is being chosen to correlate.
+func (l *LogViewer) Picking() bool {
+	return l.picking
+}
+
+// startPick selects the last entry with an ID that is on screen, or the
+// last one above it.
+func (l *LogViewer) startPick() {
+	l.flush()
+	bottom := l.offset + l.viewport.Height
+	best := -1
+	for i, entry := range l.logs {
+		if l.lineOf[i] < 0 || l.lineOf[i] >= bottom {
+			continue
+		}
+		if _, ok := CorrelationID(entry); ok {
+			best = i
+		}
+	}
+	if best < 0 {
+		l.setStatus("No task, session, or request ID in view")
+		return
+	}
+	l.picking, l.pick = true, best
+	l.autoScroll = false
+	l.invalidate()
+}
+
+// movePick selects the next (dir 1) or previous (dir -1) entry with an ID.
+func (l *LogViewer) movePick(dir int) {
+	for i := l.pick + dir; i >= 0 && i < len(l.logs); i += dir {
+		if l.lineOf[i] < 0 {
+			continue
+		}
+		if _, ok := CorrelationID(l.logs[i]); ok {
+			l.pick = i
+			l.invalidate()
+			l.flush()
+			// Keep the choice on screen
+			if line := l.lineOf[i]; line < l.offset {
+				l.setOffset(line)
+			} else if line >= l.offset+l.viewport.Height {
+				l.setOffset(line - l.viewport.Height + 1)
+			}
+			return
+		}
+	}
+}
+
+// pickFooter describes the entry being chosen, for the help line.
+func (l *LogViewer) pickFooter() string {
+	if l.pick >= len(l.logs) {
+		return ""
+	}
+	id, _ := CorrelationID(l.logs[l.pick])
+	return fmt.Sprintf("Follow %s across bridge + kennel? │ Enter: Correlate │ ↑/↓: Other IDs │ Esc: Cancel", id)
+}
+
+// TopTime returns when the entry at the top of the view was logged.
+func (l *LogViewer) TopTime() (time.Time, bool) {
+	l.flush()
+	if top := l.topEntry(); top >= 0 {
+		return l.logs[top].Timestamp, true
+	}
+	return time.Time{}, false
+}
+
+// topEntry returns the index, as of the last layout, of the entry at the
+// top of the view, or -1 when nothing is shown.
+func (l *LogViewer) topEntry() int {
+	return l.entryAt(l.offset)
+}
+
+// entryAt returns the index of the last shown entry starting at or above
+// line, the first shown one when none does, or -1 when nothing is shown.
+func (l *LogViewer) entryAt(line int) int {
+	at := -1
+	for i, start := range l.lineOf {
+		if start < 0 {
+			continue
+		}
+		if start > line && at >= 0 {
+			break
+		}
+		at = i
+	}
+	return at
+}
+
+// scrollToEntry puts entry i, or the first shown entry after it, at the top
+// of the view, within lines into the entry.
+func (l *LogViewer) scrollToEntry(i, within int) {
+	i = max(i, 0)
+	for i < len(l.logs) && l.lineOf[i] < 0 {
+		i++
+	}
+	if i >= len(l.logs) {
+		l.gotoBottom()
+		return
+	}
+	offset := l.lineOf[i] + max(within, 0)
+	for j := i + 1; j < len(l.logs); j++ {
+		if l.lineOf[j] >= 0 {
+			// The entry wraps to fewer lines than before
+			if offset >= l.lineOf[j] {
+				offset = l.lineOf[i]
+			}
+			break
+		}
+	}
+	l.setOffset(offset)
+}
+
+// ScrollToTime scrolls to the first entry logged at or after t, or to the
+// bottom when every entry is older, and stops following new logs.
+func (l *LogViewer) ScrollToTime(t time.Time) {
+	l.flush()
+	l.autoScroll = false
+	for i, entry := range l.logs {
+		if l.lineOf[i] >= 0 && !entry.Timestamp.Before(t) {
+			l.setOffset(l.lineOf[i])
+			return
+		}
+	}
+	l.gotoBottom()
+}
+
+// JumpToNew scrolls to the bottom and follows new logs again.
+func (l *LogViewer) JumpToNew() {
+	l.gotoBottom()
+	l.autoScroll = true
+	l.unseen = 0
+}
+
+// newPill is the note of how many entries arrived below the view, or ""
+// when there are none.
+func (l *LogViewer) newPill() string {
+	switch l.unseen {
+	case 0:
+		return ""
+	case 1:
+		return theme.Cue("▼", "v") + " 1 new line"
+	}
+	return fmt.Sprintf("%s %d new lines", theme.Cue("▼", "v"), l.unseen)
+}
+
+// ClickLine handles a click on a rendered line of the viewer. Clicking the
+// new lines note jumps to them. It reports whether the click was used.
+func (l *LogViewer) ClickLine(line string) bool {
+	pill := l.newPill()
+	if pill == "" || !strings.Contains(line, pill) {
+		return false
+	}
+	l.JumpToNew()
+	return true
+}
+
+// lastVisible counts the shown entries that start above the bottom of the
+// view, i.e. the position of the last one on screen.
+func (l *LogViewer) lastVisible() int {
+	bottom := l.offset + l.viewport.Height
+	n := 0
+	for _, line := range l.lineOf {
+		if line >= 0 && line < bottom {
+			n++
+		}
+	}
+	return n
+}
+
+// Confirming reports whether a confirmation dialog is waiting for an answer.
+func (l *LogViewer) Confirming() bool {
+	return l.confirm != nil
+}
+
 // setStatus sets a temporary status message.
 func (l *LogViewer) setStatus(msg string) {
 	l.statusMsg = msg
@@ -196,6 +610,9 @@ func (l *LogViewer) setStatus(msg string) {
 
 // matchesFilter checks if an entry matches the current filter.
 func (l *LogViewer) matchesFilter(entry LogEntry) bool {
+	if l.hidden[levelKey(entry.Level)] {
+		return false
+	}
 	if l.filter == "" {
 		return true
 	}
@@ -203,118 +620,326 @@ func (l *LogViewer) matchesFilter(entry LogEntry) bool {
 	return strings.Contains(combined, l.filter)
 }
 
-// renderLogs renders all logs to the viewport with full formatting.
-//
-// This method handles:
-//   - Filtering by search term
-//   - Color-coding by log level
-//   - Word wrapping for long messages
-//   - Timestamp and source formatting
-func (l *LogViewer) renderLogs() {
-	var b strings.Builder
-	maxMsgWidth := l.width - 30 // Account for timestamp, level, source
+// invalidate marks the layout stale; the next View redoes it.
+func (l *LogViewer) invalidate() {
+	l.dirty = true
+}
 
-	for _, entry := range l.logs {
-		// Apply filter
+// maxOffset is the offset that puts the last line at the bottom of the view.
+func (l *LogViewer) maxOffset() int {
+	return max(0, l.lines-l.viewport.Height)
+}
+
+// setOffset scrolls so line n is at the top, as far as the lines allow.
+func (l *LogViewer) setOffset(n int) {
+	l.offset = max(0, min(n, l.maxOffset()))
+}
+
+// gotoBottom scrolls to the last line.
+func (l *LogViewer) gotoBottom() {
+	l.offset = l.maxOffset()
+}
+
+// atBottom reports whether the last line is in view.
+func (l *LogViewer) atBottom() bool {
+	return l.offset >= l.maxOffset()
+}
+
+// flush lays the entries out again if anything changed since the last
+// frame, following new logs when auto-scroll is on.
+func (l *LogViewer) flush() {
+	key := renderKey{width: l.width, wrap: l.wordWrap, raw: l.showRaw, theme: theme.Active()}
+	if !l.dirty && key == l.cacheKey {
+		return
+	}
+	// Keep the entry at the top in place while not following: lines shift
+	// as old entries are trimmed or wrapping changes
+	anchor, within := -1, 0
+	if !l.autoScroll {
+		if top := l.topEntry(); top >= 0 {
+			anchor = l.lineBase + top
+			within = l.offset - l.lineOf[top]
+		}
+	}
+	l.layout(key)
+	l.lineBase = l.dropped
+	if l.autoScroll {
+		l.gotoBottom()
+	} else if anchor >= 0 {
+		l.scrollToEntry(anchor-l.dropped, within)
+	}
+}
+
+// layout works out which entries pass the filter and the line each one
+// starts on, with day separators between search results that span days.
+// Nothing is styled here: an entry's height is its wrapped message's, kept
+// from the last layout while the settings are the same.
+func (l *LogViewer) layout(key renderKey) {
+	if key != l.cacheKey || len(l.heights) > len(l.logs)+renderSlack {
+		// Settings changed, or enough entries were trimmed to be worth
+		// forgetting
+		l.cache, l.heights, l.cacheKey = nil, make(map[LogEntry]int, len(l.logs)), key
+	}
+	l.dirty = false
+
+	var lastDay time.Time
+	lines := 0
+	l.shown = 0
+	l.lineOf = make([]int, len(l.logs))
+	l.dayBreak = make([]bool, len(l.logs))
+
+	for i, entry := range l.logs {
+		l.lineOf[i] = -1
 		if !l.matchesFilter(entry) {
 			continue
 		}
+		l.shown++
+
+		// Search results span days; mark where each one starts
+		if l.history != "" {
+			day := time.Date(entry.Timestamp.Year(), entry.Timestamp.Month(), entry.Timestamp.Day(), 0, 0, 0, 0, time.Local)
+			if !day.Equal(lastDay) {
+				lastDay = day
+				l.dayBreak[i] = true
+				lines++
+			}
+		}
+		l.lineOf[i] = lines
+
+		height, ok := l.heights[entry]
+		if !ok {
+			height = l.entryHeight(entry)
+			l.heights[entry] = height
+		}
+		lines += height
+	}
+
+	l.lines = lines
+	l.setOffset(l.offset)
+}
+
+// entryHeight returns how many lines entry is drawn on.
+func (l *LogViewer) entryHeight(entry LogEntry) int {
+	if l.showRaw && entry.Raw != "" {
+		return 1 // Raw lines aren't wrapped
+	}
+	return 1 + strings.Count(l.wrapMessage(entry.Message), "\n")
+}
 
-		// Raw mode - show original line
-		if l.showRaw && entry.Raw != "" {
-			b.WriteString(entry.Raw + "\n")
+// window draws the lines in view: the entries from the one at the top of
+// the view down to the bottom, styled, and cut to the view.
+func (l *LogViewer) window() string {
+	first := l.entryAt(l.offset)
+	if first < 0 {
+		return ""
+	}
+	bottom := l.offset + l.viewport.Height
+	next := make(map[LogEntry]string, l.viewport.Height)
+
+	// from is the line out starts on
+	from := l.lineOf[first]
+	if l.dayBreak[first] {
+		from--
+	}
+	var out []string
+	for i := first; i < len(l.logs) && l.lineOf[i] < bottom; i++ {
+		if l.lineOf[i] < 0 {
 			continue
 		}
+		if l.dayBreak[i] {
+			out = append(out, l.daySeparator(l.logs[i].Timestamp))
+		}
+		out = append(out, strings.Split(l.entryLine(i, next), "\n")...)
+	}
+	l.cache = next
 
-		// Format timestamp
-		ts := entry.Timestamp.Format("15:04:05")
-
-		// Style based on level
-		var levelStyle lipgloss.Style
-		var levelIcon string
-		switch strings.ToUpper(entry.Level) {
-		case "DEBUG":
-			levelStyle = theme.LogDebug
-			levelIcon = "🔍"
-		case "INFO":
-			levelStyle = theme.LogInfo
-			levelIcon = "📘"
-		case "WARN", "WARNING":
-			levelStyle = theme.LogWarn
-			levelIcon = "⚠️ "
-		case "ERROR", "ERR":
-			levelStyle = theme.LogError
-			levelIcon = "❌"
-		case "SUCCESS", "OK":
-			levelStyle = lipgloss.NewStyle().Foreground(theme.Success)
-			levelIcon = "✅"
-		default:
-			levelStyle = lipgloss.NewStyle().Foreground(theme.TextPrimary)
-			levelIcon = "  "
-		}
-
-		// Format source
-		source := entry.Source
-		if len(source) > 8 {
-			source = source[:8]
-		}
-		sourceText := lipgloss.NewStyle().
-			Foreground(theme.Secondary).
-			Width(8).
-			Render(source)
-
-		// Build timestamp
-		timestampText := lipgloss.NewStyle().
-			Foreground(theme.TextMuted).
-			Render(ts)
+	top := min(max(0, l.offset-from), len(out))
+	return strings.Join(out[top:min(len(out), bottom-from)], "\n")
+}
+
+// entryLine returns entry i styled, from last frame's cache when it was
+// drawn then, and keeps it in next for the following frame.
+func (l *LogViewer) entryLine(i int, next map[LogEntry]string) string {
+	entry := l.logs[i]
+	switch {
+	case l.showRaw && entry.Raw != "":
+		// Raw mode - show original line in its own colors
+		return cleanLine(entry.Raw, true)
+	case l.picking && i == l.pick:
+		return l.styleEntry(entry, true)
+	}
+	line, ok := l.cache[entry]
+	if !ok {
+		line = l.styleEntry(entry, false)
+	}
+	next[entry] = line
+	return line
+}
+
+// daySeparator marks where search results for the day of t start.
+func (l *LogViewer) daySeparator(t time.Time) string {
+	return lipgloss.NewStyle().
+		Foreground(theme.Active().Secondary).
+		Bold(true).
+		Render("── " + t.Format("Monday, January 2, 2006") + " ──")
+}
+
+// wrapMessage wraps a message to fit beside the timestamp, level, and
+// source columns when word wrap is on.
+func (l *LogViewer) wrapMessage(message string) string {
+	// What the viewport leaves after the timestamp, level, source and bar
+	maxMsgWidth := l.viewport.Width - logPrefixWidth - 2
+	if l.wordWrap && ansi.StringWidth(message) > maxMsgWidth && maxMsgWidth >= 10 {
+		return wrapText(message, maxMsgWidth)
+	}
+	return message
+}
+
+// styleEntry renders one entry with colors; picked highlights its
+// timestamp for correlation.
+func (l *LogViewer) styleEntry(entry LogEntry, picked bool) string {
+	// Format timestamp
+	ts := entry.Timestamp.Format("15:04:05")
+
+	// Style based on level
+	levelStyle, levelIcon := levelStyle(entry.Level)
 
-		// Build level with icon
-		levelText := levelStyle.
+	// Format source
+	source := entry.Source
+	if len(source) > 8 {
+		source = source[:8]
+	}
+	sourceText := lipgloss.NewStyle().
+		Foreground(theme.Active().Secondary).
+		Width(8).
+		Render(source)
+
+	// Build timestamp
+	timestampText := lipgloss.NewStyle().
+		Foreground(theme.Active().TextMuted).
+		Render(ts)
+	if picked {
+		timestampText = lipgloss.NewStyle().
+			Foreground(theme.Active().Primary).
+			Reverse(true).
 			Bold(true).
-			Render(levelIcon)
+			Render(ts)
+	}
 
-		// Format message with word wrap if enabled
-		message := entry.Message
-		if l.wordWrap && len(message) > maxMsgWidth && maxMsgWidth > 20 {
-			message = wrapText(message, maxMsgWidth)
-		}
+	// Build level with icon
+	levelText := levelStyle.
+		Bold(true).
+		Render(levelIcon)
 
-		messageText := lipgloss.NewStyle().
-			Foreground(theme.TextPrimary).
-			Render(message)
+	messageText := lipgloss.NewStyle().
+		Foreground(theme.Active().TextPrimary).
+		Render(l.wrapMessage(entry.Message))
+
+	// Build full line
+	return fmt.Sprintf("%s %s %s │ %s", timestampText, levelText, sourceText, messageText)
+}
 
-		// Build full line
-		line := fmt.Sprintf("%s %s %s │ %s", timestampText, levelText, sourceText, messageText)
-		b.WriteString(line + "\n")
+// renderChips renders the level legend: one chip per level with its count
+// in the buffer, dimmed and marked off when the level is hidden.
+func (l *LogViewer) renderChips() string {
+	counts := make(map[string]int, len(LogLevels))
+	for _, entry := range l.logs {
+		counts[levelKey(entry.Level)]++
+	}
+	chips := make([]string, len(LogLevels))
+	for i, lvl := range LogLevels {
+		text := fmt.Sprintf("%d %s %d", i+1, lvl, counts[lvl])
+		if l.hidden[lvl] {
+			chips[i] = lipgloss.NewStyle().
+				Foreground(theme.Active().TextMuted).
+				Strikethrough(true).
+				Render(theme.Cue("○", "[off]") + " " + text)
+			continue
+		}
+		style, _ := levelStyle(lvl)
+		chips[i] = style.Bold(true).Render(theme.Cue("●", "[on]") + " " + text)
 	}
+	return " " + strings.Join(chips, "   ")
+}
 
-	l.viewport.SetContent(b.String())
+// levelStyle returns the color and icon for a log level.
+func levelStyle(level string) (lipgloss.Style, string) {
+	switch strings.ToUpper(level) {
+	case "DEBUG":
+		return theme.LogDebug(), "🔍"
+	case "INFO":
+		return theme.LogInfo(), "📘"
+	case "WARN", "WARNING":
+		return theme.LogWarn(), "⚠️ "
+	case "ERROR", "ERR":
+		return theme.LogError(), "❌"
+	case "SUCCESS", "OK":
+		return lipgloss.NewStyle().Foreground(theme.Active().Success), "✅"
+	}
+	return lipgloss.NewStyle().Foreground(theme.Active().TextPrimary), "  "
 }
 
-// wrapText wraps text to the specified width at word boundaries.
+// cleanLine drops the control codes in a log line that would corrupt the
+// viewport, such as cursor moves or carriage returns, and expands tabs.
+// Colors (SGR sequences) are kept when colors is set, with a reset at the
+// end so they can't bleed into the next line.
+func cleanLine(raw string, colors bool) string {
+	var b strings.Builder
+	colored := false
+	var state byte
+	for len(raw) > 0 {
+		seq, _, n, next := ansi.DecodeSequence(raw, state, nil)
+		state = next
+		raw = raw[n:]
+		first, _ := utf8.DecodeRuneInString(seq)
+		switch {
+		case seq == "\t":
+			b.WriteString("    ")
+		case ansi.HasCsiPrefix(seq) && strings.HasSuffix(seq, "m"):
+			if colors {
+				b.WriteString(seq)
+				colored = true
+			}
+		case !unicode.IsControl(first):
+			b.WriteString(seq)
+		}
+	}
+	if colored {
+		b.WriteString(ansi.ResetStyle)
+	}
+	return b.String()
+}
+
+// logPrefixWidth is the columns before the bar on an entry's first line:
+// timestamp, level icon and source, each followed by a space.
+const logPrefixWidth = len("15:04:05") + 1 + 2 + 1 + 8 + 1
+
+// wrapText wraps text to the specified width at word boundaries, indenting
+// continuation lines to the message column.
 func wrapText(text string, width int) string {
-	if width <= 0 || len(text) <= width {
+	if width <= 0 || ansi.StringWidth(text) <= width {
 		return text
 	}
 
 	var result strings.Builder
 	words := strings.Fields(text)
 	lineLen := 0
-	indent := "                         │ " // Match the log prefix width
+	indent := strings.Repeat(" ", logPrefixWidth) + "│ "
 
 	for i, word := range words {
+		wordLen := ansi.StringWidth(word)
 		if i == 0 {
 			result.WriteString(word)
-			lineLen = len(word)
+			lineLen = wordLen
 			continue
 		}
 
-		if lineLen+1+len(word) > width {
+		if lineLen+1+wordLen > width {
 			result.WriteString("\n" + indent + word)
-			lineLen = len(word)
+			lineLen = wordLen
 		} else {
 			result.WriteString(" " + word)
-			lineLen += 1 + len(word)
+			lineLen += 1 + wordLen
 		}
 	}
 
@@ -338,13 +963,13 @@ func boolToOnOff(b bool) string {
 //   - a: Toggle auto-scroll
 //   - w: Toggle word wrap
 //   - r: Toggle raw mode
+//   - 1-4: Show or hide DEBUG, INFO, WARN, ERROR
+//   - i: Pick an entry with an ID to correlate (↑/↓ to choose, Enter to follow)
 //   - c: Copy visible logs
 //   - C: Copy all logs
-//   - x: Clear logs
+//   - x: Clear logs (asks first)
 //   - Esc: Exit viewer
 func (l *LogViewer) Update(msg tea.Msg) (*LogViewer, tea.Cmd) {
-	var cmd tea.Cmd
-
 	// Decrement status timer
 	if l.statusTimer > 0 {
 		l.statusTimer--
@@ -355,7 +980,40 @@ func (l *LogViewer) Update(msg tea.Msg) (*LogViewer, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if l.confirm != nil {
+			if result := l.confirm.Update(msg); result != ConfirmPending {
+				l.confirm = nil
+				if result == ConfirmYes {
+					l.Clear()
+				}
+			}
+			return l, nil
+		}
+		if l.picking {
+			switch msg.String() {
+			case "up", "k":
+				l.movePick(-1)
+			case "down", "j":
+				l.movePick(1)
+			case "enter":
+				l.picking = false
+				if l.pick >= len(l.logs) {
+					return l, nil
+				}
+				entry := l.logs[l.pick]
+				l.invalidate()
+				id, _ := CorrelationID(entry)
+				return l, func() tea.Msg { return CorrelateMsg{ID: id, At: entry.Timestamp} }
+			case "esc", "i":
+				l.picking = false
+				l.invalidate()
+			}
+			return l, nil
+		}
 		switch msg.String() {
+		case "i":
+			l.startPick()
+			return l, nil
 		case "a":
 			l.ToggleAutoScroll()
 			return l, nil
@@ -365,6 +1023,9 @@ func (l *LogViewer) Update(msg tea.Msg) (*LogViewer, tea.Cmd) {
 		case "r":
 			l.ToggleRaw()
 			return l, nil
+		case "1", "2", "3", "4":
+			l.ToggleLevel(LogLevels[msg.String()[0]-'1'])
+			return l, nil
 		case "c":
 			l.CopySelectedLog()
 			return l, nil
@@ -372,37 +1033,66 @@ func (l *LogViewer) Update(msg tea.Msg) (*LogViewer, tea.Cmd) {
 			l.CopyAllLogs()
 			return l, nil
 		case "x":
-			l.Clear()
+			l.confirm = NewConfirm("Clear logs", fmt.Sprintf("Clear all %d log entries from the viewer? Container logs are not affected.", len(l.logs)), "Clear")
 			return l, nil
 		case "g":
-			l.viewport.GotoTop()
+			l.offset = 0
 			l.autoScroll = false
 			return l, nil
 		case "G":
-			l.viewport.GotoBottom()
-			l.autoScroll = true
+			l.JumpToNew()
 			return l, nil
 		case "j", "down":
-			l.viewport.LineDown(1)
+			l.scroll(1)
 			l.autoScroll = false
 			return l, nil
 		case "k", "up":
-			l.viewport.LineUp(1)
-			l.autoScroll = false
+			l.scroll(-1)
 			return l, nil
 		case "pgdown", "ctrl+d":
-			l.viewport.HalfViewDown()
+			l.scroll(l.viewport.Height / 2)
 			l.autoScroll = false
 			return l, nil
 		case "pgup", "ctrl+u":
-			l.viewport.HalfViewUp()
-			l.autoScroll = false
+			l.scroll(-l.viewport.Height / 2)
 			return l, nil
 		}
+		// The rest of the viewport's keys
+		keys := l.viewport.KeyMap
+		switch {
+		case key.Matches(msg, keys.PageDown):
+			l.scroll(l.viewport.Height)
+		case key.Matches(msg, keys.PageUp):
+			l.scroll(-l.viewport.Height)
+		case key.Matches(msg, keys.HalfPageDown):
+			l.scroll(l.viewport.Height / 2)
+		case key.Matches(msg, keys.HalfPageUp):
+			l.scroll(-l.viewport.Height / 2)
+		}
+
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress {
+			break
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			l.scroll(-l.viewport.MouseWheelDelta)
+		case tea.MouseButtonWheelDown:
+			l.scroll(l.viewport.MouseWheelDelta)
+		}
 	}
 
-	l.viewport, cmd = l.viewport.Update(msg)
-	return l, cmd
+	return l, nil
+}
+
+// scroll moves the view by n lines, down when n is positive. Scrolling
+// back stops following new logs.
+func (l *LogViewer) scroll(n int) {
+	l.flush()
+	l.setOffset(l.offset + n)
+	if n < 0 {
+		l.autoScroll = false
+	}
 }
 
 // View renders the log viewer with title, viewport, and help bar.
@@ -416,22 +1106,32 @@ func (l *LogViewer) View() string {
 	if !l.ready {
 		return "Initializing log viewer..."
 	}
+	l.flush()
+	l.viewport.SetContent(l.window())
+	if l.atBottom() {
+		// Scrolled down to them by hand
+		l.unseen = 0
+	}
 
 	// Title bar with status indicators
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(theme.Primary).
+		Foreground(theme.Active().Primary).
 		Padding(0, 1)
 
 	// Auto-scroll indicator
 	scrollIndicator := ""
-	if l.autoScroll {
+	if l.history != "" {
 		scrollIndicator = lipgloss.NewStyle().
-			Foreground(theme.Success).
+			Foreground(theme.Active().Info).
+			Render(" ◆ HISTORY")
+	} else if l.autoScroll {
+		scrollIndicator = lipgloss.NewStyle().
+			Foreground(theme.Active().Success).
 			Render(" ● LIVE")
 	} else {
 		scrollIndicator = lipgloss.NewStyle().
-			Foreground(theme.TextMuted).
+			Foreground(theme.Active().TextMuted).
 			Render(" ○ PAUSED")
 	}
 
@@ -439,7 +1139,7 @@ func (l *LogViewer) View() string {
 	wrapIndicator := ""
 	if l.wordWrap {
 		wrapIndicator = lipgloss.NewStyle().
-			Foreground(theme.Info).
+			Foreground(theme.Active().Info).
 			Render(" [wrap]")
 	}
 
@@ -447,78 +1147,92 @@ func (l *LogViewer) View() string {
 	rawIndicator := ""
 	if l.showRaw {
 		rawIndicator = lipgloss.NewStyle().
-			Foreground(theme.Warning).
+			Foreground(theme.Active().Warning).
 			Render(" [raw]")
 	}
 
-	title := titleStyle.Render("📜 Fetch Logs") + scrollIndicator + wrapIndicator + rawIndicator
-
-	// Log count and scroll position
-	filteredCount := 0
-	for _, entry := range l.logs {
-		if l.matchesFilter(entry) {
-			filteredCount++
-		}
+	title := titleStyle.Render(l.title) + scrollIndicator + wrapIndicator + rawIndicator
+	if l.history != "" {
+		title = titleStyle.Render("🔎 "+l.history) + scrollIndicator + wrapIndicator + rawIndicator
 	}
 
+	// Log count and scroll position
 	countText := lipgloss.NewStyle().
-		Foreground(theme.TextMuted).
-		Render(fmt.Sprintf("  %d entries", filteredCount))
+		Foreground(theme.Active().TextMuted).
+		Render(fmt.Sprintf("  %d entries", l.shown))
 
 	if l.filter != "" {
 		countText += lipgloss.NewStyle().
-			Foreground(theme.Secondary).
+			Foreground(theme.Active().Secondary).
 			Render(fmt.Sprintf(" (filter: %s)", l.filter))
 	}
 
-	// Scroll position
+	// Scroll position: the last entry that starts on screen
 	scrollPos := ""
-	if l.viewport.TotalLineCount() > 0 {
-		pct := int(float64(l.viewport.YOffset) / float64(l.viewport.TotalLineCount()-l.viewport.Height) * 100)
-		if pct < 0 {
-			pct = 0
-		}
-		if pct > 100 {
-			pct = 100
-		}
+	if l.shown > 0 {
 		scrollPos = lipgloss.NewStyle().
-			Foreground(theme.TextMuted).
-			Render(fmt.Sprintf(" │ %d%%", pct))
+			Foreground(theme.Active().TextMuted).
+			Render(fmt.Sprintf(" │ entry %d/%d", l.lastVisible(), l.shown))
 	}
 
 	// Status message (temporary feedback)
 	statusLine := ""
 	if l.statusMsg != "" {
 		statusLine = lipgloss.NewStyle().
-			Foreground(theme.Success).
+			Foreground(theme.Active().Success).
 			Bold(true).
 			Render("  " + l.statusMsg)
 	}
 
 	// Viewport with border
+	borderColor := theme.Active().Border
+	if l.focused {
+		borderColor = theme.Active().Primary
+	}
 	viewportStyle := lipgloss.NewStyle().
 		Border(theme.PanelBorder).
-		BorderForeground(theme.Border).
+		BorderForeground(borderColor).
 		Padding(0, 1).
 		Width(l.width - 2).
-		Height(l.height - 6)
+		Height(l.height - 7)
 
-	// Help bar - comprehensive keybindings
+	// Help bar - the common keys, short enough for 80 columns; ? lists
+	// the rest
 	helpStyle := lipgloss.NewStyle().
-		Foreground(theme.TextMuted).
+		Foreground(theme.Active().TextMuted).
 		Padding(0, 1)
 
 	helpText := helpStyle.Render(
-		"↑/↓/j/k: Scroll │ g/G: Top/Bottom │ a: Auto-scroll │ w: Wrap │ c/C: Copy │ x: Clear │ Esc: Back")
+		"↑/↓: Scroll │ 1-4: Levels │ w: Wrap │ /: Search history │ ?: Help │ Esc: Back")
+	switch {
+	case l.picking:
+		helpText = helpStyle.Render(l.pickFooter())
+	case l.footer != "":
+		helpText = helpStyle.Render(l.footer)
+	}
+	if pill := l.newPill(); pill != "" {
+		helpText = lipgloss.NewStyle().
+			Foreground(theme.Active().Background).
+			Background(theme.Active().Info).
+			Bold(true).
+			Padding(0, 1).
+			Render(pill+" · G") + helpText
+	}
 
-	// Combine all elements
+	// Combine all elements; lines are cut to width so split panes line up
 	header := lipgloss.JoinHorizontal(lipgloss.Left, title, countText, scrollPos, statusLine)
+	fit := lipgloss.NewStyle().MaxWidth(l.width)
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
-		header,
+		fit.Render(header),
+		fit.Render(l.renderChips()),
 		viewportStyle.Render(l.viewport.View()),
-		helpText,
+		fit.Render(helpText),
 	)
 
+	if l.confirm != nil {
+		content = PlaceOverlay(content, l.confirm.View(l.width), l.width, lipgloss.Height(content))
+	}
+
 	return content
 }
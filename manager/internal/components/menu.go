@@ -14,6 +14,7 @@ type MenuItem struct {
 	Label    string
 	Key      string // Optional hotkey
 	Disabled bool
+	Hint     string // Why a disabled item can't be chosen
 }
 
 // Menu is a keyboard-navigable menu component with customizable styling.
@@ -56,6 +57,32 @@ func (m *Menu) Down() {
 	}
 }
 
+// MoveTo moves the cursor to item i, clamped to the list. A disabled item
+// gives way to the nearest enabled one in the direction of travel, then
+// the other way.
+func (m *Menu) MoveTo(i int) {
+	if len(m.Items) == 0 {
+		return
+	}
+	i = min(max(i, 0), len(m.Items)-1)
+	forward := i >= m.Cursor
+	m.Cursor = i
+	if !m.Items[i].Disabled {
+		return
+	}
+	if forward {
+		m.Down()
+		if m.Cursor == i {
+			m.Up()
+		}
+	} else {
+		m.Up()
+		if m.Cursor == i {
+			m.Down()
+		}
+	}
+}
+
 // Selected returns the currently selected item index
 func (m *Menu) Selected() int {
 	return m.Cursor
@@ -77,7 +104,7 @@ func (m *Menu) View() string {
 	if m.Title != "" {
 		titleStyle := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(theme.Primary).
+			Foreground(theme.Active().Primary).
 			MarginBottom(1)
 		b.WriteString(titleStyle.Render(m.Title))
 		b.WriteString("\n\n")
@@ -90,17 +117,17 @@ func (m *Menu) View() string {
 		if item.Disabled {
 			// Disabled item
 			disabledStyle := lipgloss.NewStyle().
-				Foreground(theme.TextMuted).
+				Foreground(theme.Active().TextMuted).
 				PaddingLeft(4)
 			line = disabledStyle.Render(item.Icon + " " + item.Label)
 		} else if i == m.Cursor {
 			// Selected item
 			selectedStyle := lipgloss.NewStyle().
-				Foreground(theme.Primary).
+				Foreground(theme.Active().Primary).
 				Bold(true)
 
 			cursorStyle := lipgloss.NewStyle().
-				Foreground(theme.Primary).
+				Foreground(theme.Active().Primary).
 				Bold(true)
 
 			cursor := cursorStyle.Render("▸ ")
@@ -108,7 +135,7 @@ func (m *Menu) View() string {
 		} else {
 			// Normal item
 			normalStyle := lipgloss.NewStyle().
-				Foreground(theme.TextPrimary).
+				Foreground(theme.Active().TextPrimary).
 				PaddingLeft(2)
 			line = normalStyle.Render("  " + item.Icon + " " + item.Label)
 		}
@@ -116,7 +143,7 @@ func (m *Menu) View() string {
 		// Add hotkey if showing
 		if m.ShowKeys && item.Key != "" {
 			keyStyle := lipgloss.NewStyle().
-				Foreground(theme.TextMuted).
+				Foreground(theme.Active().TextMuted).
 				PaddingLeft(2)
 			keyWidth := m.Width - lipgloss.Width(line) - 4
 			if keyWidth > 0 {
@@ -131,7 +158,7 @@ func (m *Menu) View() string {
 	// Frame the menu
 	frameStyle := lipgloss.NewStyle().
 		Border(theme.PanelBorder).
-		BorderForeground(theme.Border).
+		BorderForeground(theme.Active().Border).
 		Padding(1, 2).
 		Width(m.Width)
 
@@ -147,17 +174,17 @@ func (m *Menu) ViewCompact() string {
 
 		if item.Disabled {
 			disabledStyle := lipgloss.NewStyle().
-				Foreground(theme.TextMuted).
+				Foreground(theme.Active().TextMuted).
 				PaddingLeft(4)
 			line = disabledStyle.Render(item.Icon + " " + item.Label)
 		} else if i == m.Cursor {
 			selectedStyle := lipgloss.NewStyle().
-				Foreground(theme.Primary).
+				Foreground(theme.Active().Primary).
 				Bold(true)
 			line = selectedStyle.Render("▸ " + item.Icon + " " + item.Label)
 		} else {
 			normalStyle := lipgloss.NewStyle().
-				Foreground(theme.TextPrimary).
+				Foreground(theme.Active().TextPrimary).
 				PaddingLeft(2)
 			line = normalStyle.Render("  " + item.Icon + " " + item.Label)
 		}
@@ -0,0 +1,43 @@
+// Package components provides modal overlay rendering for the Fetch TUI.
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PlaceOverlay draws fg centered over base, which is assumed to fill a
+// width×height terminal. Lines of base outside fg are left untouched, so
+// the screen behind a modal stays visible around it.
+func PlaceOverlay(base, fg string, width, height int) string {
+	lines := strings.Split(base, "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	fgLines := strings.Split(fg, "\n")
+	fgWidth := lipgloss.Width(fg)
+	top := max(0, (height-len(fgLines))/2)
+	left := max(0, (width-fgWidth)/2)
+
+	for i, fgLine := range fgLines {
+		row := top + i
+		if row >= len(lines) {
+			break
+		}
+		bg := lines[row]
+		before := ansi.Truncate(bg, left, "")
+		if pad := left - lipgloss.Width(before); pad > 0 {
+			before += strings.Repeat(" ", pad)
+		}
+		after := ""
+		if lipgloss.Width(bg) > left+fgWidth {
+			after = ansi.TruncateLeft(bg, left+fgWidth, "")
+		}
+		lineWidth := lipgloss.Width(fgLine)
+		lines[row] = before + fgLine + strings.Repeat(" ", max(0, fgWidth-lineWidth)) + after
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,181 @@
+// Package components provides a fuzzy command palette for the Fetch TUI.
+package components
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// PaletteItem is one command listed in the palette.
+type PaletteItem struct {
+	ID    string
+	Title string
+	Group string // Shown dimmed after the title, e.g. "Services"
+	Key   string // Optional global hotkey shown before the group
+}
+
+// Palette is a filterable list of commands opened with ctrl+p.
+type Palette struct {
+	items   []PaletteItem
+	matches []PaletteItem
+	query   string
+	cursor  int
+}
+
+const paletteRows = 10
+
+// NewPalette creates a palette listing items in the given order.
+func NewPalette(items []PaletteItem) *Palette {
+	p := &Palette{items: items}
+	p.filter()
+	return p
+}
+
+// Update handles a key. It returns the chosen item's ID when the user
+// presses Enter, and done when the palette should close.
+func (p *Palette) Update(msg tea.KeyMsg) (chosen string, done bool) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC, tea.KeyCtrlP:
+		return "", true
+	case tea.KeyEnter:
+		if len(p.matches) == 0 {
+			return "", false
+		}
+		return p.matches[p.cursor].ID, true
+	case tea.KeyUp, tea.KeyCtrlK:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case tea.KeyDown, tea.KeyCtrlJ, tea.KeyTab:
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+	case tea.KeyBackspace:
+		if r := []rune(p.query); len(r) > 0 {
+			p.query = string(r[:len(r)-1])
+			p.filter()
+		}
+	case tea.KeyCtrlU:
+		p.query = ""
+		p.filter()
+	case tea.KeyRunes, tea.KeySpace:
+		p.query += string(msg.Runes)
+		p.filter()
+	}
+	return "", false
+}
+
+// filter re-ranks items against the query.
+func (p *Palette) filter() {
+	p.cursor = 0
+	if p.query == "" {
+		p.matches = p.items
+		return
+	}
+
+	type scored struct {
+		item  PaletteItem
+		score int
+	}
+	var hits []scored
+	for _, item := range p.items {
+		if score, ok := FuzzyScore(p.query, item.Title+" "+item.Group); ok {
+			hits = append(hits, scored{item, score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+	p.matches = make([]PaletteItem, len(hits))
+	for i, h := range hits {
+		p.matches[i] = h.item
+	}
+}
+
+// FuzzyScore reports whether every rune of query appears in target in
+// order (case-insensitive), and scores the match: consecutive runes and
+// runes at word starts score higher, so "rb" ranks "Restart Bridge" above
+// "Rebuild".
+func FuzzyScore(query, target string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(target))
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	score, qi, prev := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == prev+1 {
+			score += 3
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 5
+		}
+		prev = ti
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	// Prefer shorter titles among equal matches
+	return score*100 - len(t), true
+}
+
+// View renders the palette as a bordered box.
+func (p *Palette) View(width int) string {
+	boxWidth := min(64, width-4)
+	inner := boxWidth - 4
+
+	var b strings.Builder
+	prompt := lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render("› ")
+	query := p.query
+	if query == "" {
+		query = lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Render("Type a command…")
+	}
+	b.WriteString(prompt + query + "\n\n")
+
+	// Scroll so the cursor stays in the window
+	start := 0
+	if p.cursor >= paletteRows {
+		start = p.cursor - paletteRows + 1
+	}
+	end := min(len(p.matches), start+paletteRows)
+
+	if len(p.matches) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Render("No matching commands") + "\n")
+	}
+	for i := start; i < end; i++ {
+		item := p.matches[i]
+		label := item.Group
+		if item.Key != "" {
+			label = item.Key + "  " + label
+		}
+		group := lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Render(label)
+		title := item.Title
+		gap := max(1, inner-2-lipgloss.Width(title)-lipgloss.Width(label))
+		if i == p.cursor {
+			title = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render("▸ " + title)
+		} else {
+			title = lipgloss.NewStyle().Foreground(theme.Active().TextPrimary).Render("  " + title)
+		}
+		b.WriteString(title + strings.Repeat(" ", gap) + group + "\n")
+	}
+
+	hint := lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Render("↑/↓ Select │ Enter Run │ Esc Close")
+	b.WriteString("\n" + hint)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Active().Primary).
+		Padding(0, 1).
+		Width(boxWidth).
+		Render(b.String())
+}
@@ -0,0 +1,88 @@
+// Package components provides plain-text rendering for accessibility mode.
+package components
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// plainSymbols maps decorative symbols to ASCII of the same width, so
+// replacing them never breaks column alignment.
+var plainSymbols = map[rune]string{
+	'✓': "+", '✔': "+", '✗': "x", '✘': "x",
+	'●': "*", '○': "o", '•': "*", '◆': "*",
+	'▸': ">", '▶': ">", '›': ">", '»': ">",
+	'▲': "^", '▼': "v", '↑': "^", '↓': "v", '←': "<", '→': ">",
+	'…': ".", '—': "-", '–': "-", '⚠': "!", '·': ".",
+}
+
+// PlainText rewrites rendered output for accessibility mode. Emoji and
+// other pictographs are dropped along with the spaces after them, Braille
+// art is blanked, and box-drawing and arrow symbols become ASCII. ANSI
+// escape sequences are ASCII and pass through untouched.
+func PlainText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	skipSpaces := false
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+
+		if r == 0xFE0F || r == 0x200D || r == 0x20E3 { // Emoji modifiers
+			continue
+		}
+		if skipSpaces && r == ' ' {
+			continue
+		}
+		skipSpaces = false
+
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+			continue
+		}
+		if sub, ok := plainSymbols[r]; ok {
+			b.WriteString(sub)
+			continue
+		}
+		switch {
+		case r >= 0x2800 && r <= 0x28FF: // Braille patterns
+			b.WriteByte(' ')
+		case r >= 0x2500 && r <= 0x257F: // Box drawing
+			b.WriteString(boxASCII(r))
+		case isPictograph(r):
+			skipSpaces = true
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// boxASCII maps a box-drawing rune to -, |, or + for corners and joints.
+func boxASCII(r rune) string {
+	switch r {
+	case '─', '━', '═', '╌', '╍', '┄', '┅', '┈', '┉':
+		return "-"
+	case '│', '┃', '║', '╎', '╏', '┆', '┇', '┊', '┋':
+		return "|"
+	}
+	return "+"
+}
+
+// isPictograph reports whether r is an emoji or pictographic symbol that a
+// screen reader would read out by name.
+func isPictograph(r rune) bool {
+	switch {
+	case r >= 0x1F000: // Emoji blocks
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols, dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // Arrows like ⬆
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // ⏳ ⏸ and friends
+		return true
+	case r == 0x2139: // ℹ
+		return true
+	}
+	return false
+}
@@ -69,23 +69,23 @@ func (p *ProgressBar) View() string {
 	empty := barWidth - filled
 
 	// Color selection based on progress
-	var fillColor lipgloss.Color
+	var fillColor lipgloss.TerminalColor
 	if p.gradient {
 		if p.percent < 0.3 {
-			fillColor = theme.Error
+			fillColor = theme.Active().Error
 		} else if p.percent < 0.7 {
-			fillColor = theme.Warning
+			fillColor = theme.Active().Warning
 		} else {
-			fillColor = theme.Success
+			fillColor = theme.Active().Success
 		}
 	} else {
-		fillColor = theme.Primary
+		fillColor = theme.Active().Primary
 	}
 
 	// Build the bar
 	filledStyle := lipgloss.NewStyle().Foreground(fillColor)
-	emptyStyle := lipgloss.NewStyle().Foreground(theme.TextMuted)
-	bracketStyle := lipgloss.NewStyle().Foreground(theme.Border)
+	emptyStyle := lipgloss.NewStyle().Foreground(theme.Active().TextMuted)
+	bracketStyle := lipgloss.NewStyle().Foreground(theme.Active().Border)
 
 	bar := bracketStyle.Render("[") +
 		filledStyle.Render(strings.Repeat("█", filled)) +
@@ -93,13 +93,13 @@ func (p *ProgressBar) View() string {
 		bracketStyle.Render("]")
 
 	if p.showPct {
-		pctStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+		pctStyle := lipgloss.NewStyle().Foreground(theme.Active().TextSecondary)
 		bar += pctStyle.Render(fmt.Sprintf(" %3.0f%%", p.percent*100))
 	}
 
 	// Add label if present
 	if p.label != "" {
-		labelStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+		labelStyle := lipgloss.NewStyle().Foreground(theme.Active().TextSecondary)
 		return labelStyle.Render(p.label) + "\n" + bar
 	}
 
@@ -118,8 +118,8 @@ func SimpleProgress(percent float64, width int) string {
 	filled := int(float64(width) * percent)
 	empty := width - filled
 
-	filledStyle := lipgloss.NewStyle().Foreground(theme.Primary)
-	emptyStyle := lipgloss.NewStyle().Foreground(theme.TextMuted)
+	filledStyle := lipgloss.NewStyle().Foreground(theme.Active().Primary)
+	emptyStyle := lipgloss.NewStyle().Foreground(theme.Active().TextMuted)
 
 	return filledStyle.Render(strings.Repeat("▓", filled)) +
 		emptyStyle.Render(strings.Repeat("░", empty))
@@ -131,8 +131,8 @@ func DownloadProgress(percent float64, downloaded, total string, speed string, w
 	pb.SetPercent(percent)
 	pb.SetShowPercent(false)
 
-	infoStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-	speedStyle := lipgloss.NewStyle().Foreground(theme.Info)
+	infoStyle := lipgloss.NewStyle().Foreground(theme.Active().TextSecondary)
+	speedStyle := lipgloss.NewStyle().Foreground(theme.Active().Info)
 
 	return fmt.Sprintf("%s %s/%s %s",
 		pb.View(),
@@ -0,0 +1,42 @@
+// Package components provides a sparkline for small trend charts.
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkBlocks are the eight bar heights used by Sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of bar glyphs scaled to the
+// series maximum. When there are more values than width, the most recent
+// width values are shown. Zero renders as a space so idle hours stand out.
+func Sparkline(values []int, width int, color lipgloss.TerminalColor) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	peak := 0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if v <= 0 || peak == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		idx := (v*len(sparkBlocks) - 1) / peak
+		if idx >= len(sparkBlocks) {
+			idx = len(sparkBlocks) - 1
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+
+	return lipgloss.NewStyle().Foreground(color).Render(b.String())
+}
@@ -56,13 +56,13 @@ func NewSpinner(spinnerType SpinnerType, label string) *Spinner {
 		s.Spinner = spinner.Dot
 	}
 
-	s.Style = lipgloss.NewStyle().Foreground(theme.Primary)
+	s.Style = lipgloss.NewStyle().Foreground(theme.Active().Primary)
 
 	return &Spinner{
 		spinner: s,
 		label:   label,
 		style: lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
+			Foreground(theme.Active().TextSecondary).
 			MarginLeft(1),
 	}
 }
@@ -91,8 +91,8 @@ func (s *Spinner) SetLabel(label string) {
 
 // Loading renders a simple loading indicator (static, no state needed)
 func Loading(message string) string {
-	spinnerStyle := lipgloss.NewStyle().Foreground(theme.Primary)
-	labelStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary).MarginLeft(1)
+	spinnerStyle := lipgloss.NewStyle().Foreground(theme.Active().Primary)
+	labelStyle := lipgloss.NewStyle().Foreground(theme.Active().TextSecondary).MarginLeft(1)
 
 	frames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 	// Use first frame for static display
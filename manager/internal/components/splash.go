@@ -33,11 +33,12 @@ const compactSplash = `
 │                                 │
 ╰─────────────────────────────────╯`
 
-// Splash renders the splash screen centered in the terminal.
-func Splash(width, height int) string {
+// Splash renders the splash screen centered in the terminal, with footer
+// (e.g. startup checks) centered below the art.
+func Splash(width, height int, footer string) string {
 	// Style the ASCII art
 	artStyle := lipgloss.NewStyle().
-		Foreground(theme.Primary)
+		Foreground(theme.Active().Primary)
 
 	// Choose art based on width
 	var art string
@@ -48,6 +49,11 @@ func Splash(width, height int) string {
 	}
 
 	rendered := artStyle.Render(art)
+	if footer != "" {
+		// Pad the footer to one width so its lines stay left-aligned
+		footer = lipgloss.NewStyle().Width(lipgloss.Width(footer)).Render(footer)
+		rendered = lipgloss.JoinVertical(lipgloss.Center, rendered, "", footer)
+	}
 
 	// Center in terminal
 	return lipgloss.Place(
@@ -60,13 +66,13 @@ func Splash(width, height int) string {
 // SplashCompact renders just the compact splash without centering.
 func SplashCompact() string {
 	return lipgloss.NewStyle().
-		Foreground(theme.Primary).
+		Foreground(theme.Active().Primary).
 		Render(compactSplash)
 }
 
 // SplashFull renders the full splash without centering.
 func SplashFull() string {
 	return lipgloss.NewStyle().
-		Foreground(theme.Primary).
+		Foreground(theme.Active().Primary).
 		Render(splashArt)
 }
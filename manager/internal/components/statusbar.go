@@ -2,9 +2,12 @@
 package components
 
 import (
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fetch/manager/internal/layout"
 	"github.com/fetch/manager/internal/theme"
 )
 
@@ -13,7 +16,19 @@ type StatusBarState struct {
 	BridgeRunning bool
 	KennelRunning bool
 	MessageCount  int
-	CurrentScreen string
+	// Uptime is the bridge's formatted uptime; empty hides it
+	Uptime string
+	// WhatsApp is the short WhatsApp connection state; empty hides it
+	WhatsApp       string
+	WhatsAppLinked bool
+	CurrentScreen  string
+	// Hints are hotkeys shown at the right edge when there is room
+	Hints []string
+	// UpdateAvailable shows a badge when a background check found updates
+	UpdateAvailable bool
+	// Running is a long action in progress and how to cancel it; empty
+	// hides it
+	Running string
 }
 
 // StatusBar renders the bottom status bar
@@ -24,35 +39,70 @@ func StatusBar(state StatusBarState, width int) string {
 	// Bridge status
 	if state.BridgeRunning {
 		statusParts = append(statusParts,
-			lipgloss.NewStyle().Foreground(theme.Success).Render("● Bridge"))
+			lipgloss.NewStyle().Foreground(theme.Active().Success).Render(theme.Cue("● Bridge", "Bridge running")))
 	} else {
 		statusParts = append(statusParts,
-			lipgloss.NewStyle().Foreground(theme.Error).Render("○ Bridge"))
+			lipgloss.NewStyle().Foreground(theme.Active().Error).Render(theme.Cue("○ Bridge", "Bridge stopped")))
 	}
 
 	// Kennel status
 	if state.KennelRunning {
 		statusParts = append(statusParts,
-			lipgloss.NewStyle().Foreground(theme.Success).Render("● Kennel"))
+			lipgloss.NewStyle().Foreground(theme.Active().Success).Render(theme.Cue("● Kennel", "Kennel running")))
 	} else {
 		statusParts = append(statusParts,
-			lipgloss.NewStyle().Foreground(theme.Error).Render("○ Kennel"))
+			lipgloss.NewStyle().Foreground(theme.Active().Error).Render(theme.Cue("○ Kennel", "Kennel stopped")))
+	}
+
+	// WhatsApp connection, once the bridge has reported it
+	if state.WhatsApp != "" {
+		color := theme.Active().Warning
+		if state.WhatsAppLinked {
+			color = theme.Active().Success
+		}
+		statusParts = append(statusParts,
+			lipgloss.NewStyle().Foreground(color).Render("WhatsApp "+state.WhatsApp))
 	}
 
 	// Message count if any
 	if state.MessageCount > 0 {
+		count := strconv.Itoa(state.MessageCount)
 		statusParts = append(statusParts,
 			lipgloss.NewStyle().
-				Foreground(theme.Info).
-				Render("📩 "+string(rune('0'+state.MessageCount%10))))
+				Foreground(theme.Active().Info).
+				Render(theme.Cue("📩 "+count, count+" messages")))
+	}
+
+	if state.Uptime != "" {
+		statusParts = append(statusParts,
+			lipgloss.NewStyle().Foreground(theme.Active().TextSecondary).Render("Up "+state.Uptime))
+	}
+
+	if state.UpdateAvailable {
+		statusParts = append(statusParts,
+			lipgloss.NewStyle().Foreground(theme.Active().Warning).Render(theme.Cue("Update available ●", "Update available")))
+	}
+
+	if state.Running != "" {
+		statusParts = append(statusParts,
+			lipgloss.NewStyle().Foreground(theme.Active().Warning).Render(theme.Cue("⏳ ", "")+state.Running))
 	}
 
 	statusText := strings.Join(statusParts, " │ ")
 
+	// Right-align the hotkey hints, dropping them on narrow terminals
+	if len(state.Hints) > 0 {
+		hints := lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Render(strings.Join(state.Hints, " │ "))
+		gap := width - 4 - lipgloss.Width(statusText) - lipgloss.Width(hints)
+		if gap >= 2 {
+			statusText += strings.Repeat(" ", gap) + hints
+		}
+	}
+
 	// Build the bar
 	barStyle := lipgloss.NewStyle().
-		Foreground(theme.TextSecondary).
-		Background(theme.Surface).
+		Foreground(theme.Active().TextSecondary).
+		Background(theme.Active().Surface).
 		Padding(0, 2).
 		Width(width)
 
@@ -62,14 +112,39 @@ func StatusBar(state StatusBarState, width int) string {
 // HelpBar renders keyboard shortcuts
 func HelpBar(shortcuts []string, width int) string {
 	helpStyle := lipgloss.NewStyle().
-		Foreground(theme.TextMuted).
-		Background(theme.Surface).
+		Foreground(theme.Active().TextMuted).
+		Background(theme.Active().Surface).
 		Padding(0, 2).
 		Width(width)
 
+	if layout.IsCompact(width) {
+		shortcuts = fitShortcuts(shortcuts, width-4)
+	}
 	return helpStyle.Render(strings.Join(shortcuts, " │ "))
 }
 
+// fitShortcuts drops entries from the middle of the help bar until it fits
+// on one line. The last entry is kept because it is "? Help", which lists
+// everything that was dropped.
+func fitShortcuts(shortcuts []string, width int) []string {
+	fits := func(s []string) bool {
+		return lipgloss.Width(strings.Join(s, " │ ")) <= width
+	}
+	if len(shortcuts) < 2 || fits(shortcuts) {
+		return shortcuts
+	}
+	last := shortcuts[len(shortcuts)-1]
+	kept := []string{"…", last}
+	for i := len(shortcuts) - 2; i > 0; i-- {
+		candidate := append(append(slices.Clone(shortcuts[:i]), "…"), last)
+		if fits(candidate) {
+			kept = candidate
+			break
+		}
+	}
+	return kept
+}
+
 // CombinedStatusBar renders both status and help
 func CombinedStatusBar(state StatusBarState, shortcuts []string, width int) string {
 	// Top line: status
@@ -80,7 +155,7 @@ func CombinedStatusBar(state StatusBarState, shortcuts []string, width int) stri
 
 	// Separator
 	separator := lipgloss.NewStyle().
-		Foreground(theme.Border).
+		Foreground(theme.Active().Border).
 		Width(width).
 		Render(strings.Repeat("─", width))
 
@@ -90,17 +165,3 @@ func CombinedStatusBar(state StatusBarState, shortcuts []string, width int) stri
 		help,
 	)
 }
-
-// ActionMessage renders a success/error message
-func ActionMessage(message string, success bool) string {
-	if message == "" {
-		return ""
-	}
-
-	style := lipgloss.NewStyle().Bold(true).Padding(0, 1)
-
-	if success {
-		return style.Foreground(theme.Success).Render("✓ " + message)
-	}
-	return style.Foreground(theme.Error).Render("✗ " + message)
-}
@@ -0,0 +1,72 @@
+// Package components provides a tab bar for the Fetch TUI.
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// TabBar renders titles as a single row of numbered tabs with active
+// highlighted. Titles are dropped to their numbers when the row would not
+// fit in width.
+func TabBar(titles []string, active, width int) string {
+	sep := lipgloss.NewStyle().Foreground(theme.Active().Border).Render("│")
+	return " " + strings.Join(tabCells(titles, active, width), sep)
+}
+
+// TabAt returns the index of the tab under column x of a row rendered by
+// TabBar with the same arguments, or -1 when x falls outside every tab.
+func TabAt(titles []string, active, width, x int) int {
+	col := 1 // Leading space
+	for i, cell := range tabCells(titles, active, width) {
+		w := lipgloss.Width(cell)
+		if x >= col && x < col+w {
+			return i
+		}
+		col += w + 1 // Separator
+	}
+	return -1
+}
+
+func tabCells(titles []string, active, width int) []string {
+	activeStyle := lipgloss.NewStyle().
+		Foreground(theme.Active().Background).
+		Background(theme.Active().Primary).
+		Bold(true).
+		Padding(0, 1)
+	inactiveStyle := lipgloss.NewStyle().
+		Foreground(theme.Active().TextSecondary).
+		Padding(0, 1)
+
+	render := func(short bool) []string {
+		cells := make([]string, len(titles))
+		for i, title := range titles {
+			label := fmt.Sprintf("%d %s", i+1, title)
+			if short && i != active {
+				label = fmt.Sprintf("%d", i+1)
+			}
+			if i == active && theme.Plain() {
+				// Brackets mark the active tab without relying on color
+				cells[i] = activeStyle.Padding(0).Render("[" + label + "]")
+			} else if i == active {
+				cells[i] = activeStyle.Render(label)
+			} else {
+				cells[i] = inactiveStyle.Render(label)
+			}
+		}
+		return cells
+	}
+
+	cells := render(false)
+	total := 1 + len(cells) - 1
+	for _, cell := range cells {
+		total += lipgloss.Width(cell)
+	}
+	if total > width {
+		cells = render(true)
+	}
+	return cells
+}
@@ -0,0 +1,261 @@
+// Package components provides a sortable, selectable table.
+package components
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/theme"
+)
+
+// TableColumn is one column of a Table.
+type TableColumn struct {
+	Title string
+	Width int
+	// Less orders two rows when the table is sorted by this column; nil
+	// compares the column's cells as text
+	Less func(a, b TableRow) bool
+}
+
+// TableRow is one line of a Table. Key identifies the row across sorts
+// and refreshes, such as a phone number or task ID.
+type TableRow struct {
+	Key   string
+	Cells []string
+}
+
+// Table lists rows under column headers with one row selected. s sorts by
+// the next column and S reverses the order; the selection follows its row
+// through sorts and refreshes. It wraps bubbles/table, styled from the
+// theme's table styles.
+type Table struct {
+	model    table.Model
+	columns  []TableColumn
+	rows     []TableRow // As given to SetRows
+	shown    []TableRow // In display order
+	sortCol  int        // -1 keeps the given order
+	sortDesc bool
+	height   int // Lines the table may fill, header included; 0 fits every row
+}
+
+// NewTable creates an empty table with the given columns, in the order
+// rows are given.
+func NewTable(columns ...TableColumn) *Table {
+	t := &Table{
+		model: table.New(
+			table.WithFocused(true),
+			table.WithKeyMap(tableKeyMap()),
+		),
+		columns: columns,
+		sortCol: -1,
+	}
+	t.model.SetColumns(t.headers())
+	return t
+}
+
+// tableKeyMap keeps to keys screens don't use for their own actions; the
+// bubbles defaults also take letters like b, d and g.
+func tableKeyMap() table.KeyMap {
+	return table.KeyMap{
+		LineUp:     key.NewBinding(key.WithKeys("up", "k")),
+		LineDown:   key.NewBinding(key.WithKeys("down", "j")),
+		PageUp:     key.NewBinding(key.WithKeys("pgup")),
+		PageDown:   key.NewBinding(key.WithKeys("pgdown")),
+		GotoTop:    key.NewBinding(key.WithKeys("home")),
+		GotoBottom: key.NewBinding(key.WithKeys("end")),
+	}
+}
+
+// Styles read the active theme each time so theme changes apply at once.
+func tableStyles() table.Styles {
+	return table.Styles{
+		Header:   theme.TableHeader(),
+		Cell:     theme.TableCell(),
+		Selected: theme.TableRowSelected().UnsetPadding(), // Cells carry the padding
+	}
+}
+
+// SetRows replaces the rows, keeping them sorted and the selection on the
+// same key. When that row is gone the selection stays at its position.
+func (t *Table) SetRows(rows []TableRow) {
+	selected := t.SelectedKey()
+	t.rows = rows
+	t.sort()
+	if !t.Select(selected) {
+		t.model.SetCursor(t.model.Cursor())
+	}
+}
+
+// SetColumnWidth sets the width of column i, such as a description that
+// takes what the screen has left.
+func (t *Table) SetColumnWidth(i, width int) {
+	if i < 0 || i >= len(t.columns) {
+		return
+	}
+	t.columns[i].Width = width
+	t.model.SetColumns(t.headers())
+}
+
+// SetHeight sets the lines the table may fill, header included; 0 fits
+// every row.
+func (t *Table) SetHeight(lines int) {
+	t.height = max(0, lines)
+	t.resize()
+}
+
+func (t *Table) resize() {
+	t.model.SetStyles(tableStyles()) // The header's height depends on them
+	lines := t.height
+	if lines == 0 {
+		lines = lipgloss.Height(theme.TableHeader().Render("")) + max(1, len(t.shown))
+	}
+	t.model.SetHeight(lines)
+}
+
+// Len returns the number of rows.
+func (t *Table) Len() int {
+	return len(t.shown)
+}
+
+// Selected returns the selected row; ok is false for an empty table.
+func (t *Table) Selected() (row TableRow, ok bool) {
+	i := t.model.Cursor()
+	if i < 0 || i >= len(t.shown) {
+		return TableRow{}, false
+	}
+	return t.shown[i], true
+}
+
+// SelectedKey returns the key of the selected row, or "" for an empty
+// table.
+func (t *Table) SelectedKey() string {
+	row, _ := t.Selected()
+	return row.Key
+}
+
+// Select moves the selection to the row with the given key and reports
+// whether there is one.
+func (t *Table) Select(key string) bool {
+	for i, row := range t.shown {
+		if row.Key == key {
+			t.model.SetCursor(i)
+			return true
+		}
+	}
+	return false
+}
+
+// SortBy orders the rows by column col, or as given for -1.
+func (t *Table) SortBy(col int, desc bool) {
+	if col >= len(t.columns) {
+		col = -1
+	}
+	selected := t.SelectedKey()
+	t.sortCol, t.sortDesc = col, desc
+	t.sort()
+	t.Select(selected)
+}
+
+func (t *Table) sort() {
+	t.shown = slices.Clone(t.rows)
+	if t.sortCol >= 0 {
+		less := t.columns[t.sortCol].Less
+		if less == nil {
+			col := t.sortCol
+			less = func(a, b TableRow) bool { return cell(a, col) < cell(b, col) }
+		}
+		slices.SortStableFunc(t.shown, func(a, b TableRow) int {
+			if t.sortDesc {
+				a, b = b, a
+			}
+			switch {
+			case less(a, b):
+				return -1
+			case less(b, a):
+				return 1
+			}
+			return 0
+		})
+	}
+
+	rows := make([]table.Row, len(t.shown))
+	for i, row := range t.shown {
+		rows[i] = make(table.Row, len(t.columns))
+		for c := range t.columns {
+			rows[i][c] = cell(row, c)
+		}
+	}
+	t.model.SetColumns(t.headers())
+	t.model.SetRows(rows)
+	t.resize()
+}
+
+// cell returns column i of row, or "" for a short row.
+func cell(row TableRow, i int) string {
+	if i < len(row.Cells) {
+		return row.Cells[i]
+	}
+	return ""
+}
+
+// headers returns the bubbles columns, marking the sort column.
+func (t *Table) headers() []table.Column {
+	cols := make([]table.Column, len(t.columns))
+	for i, c := range t.columns {
+		title := c.Title
+		if i == t.sortCol {
+			title += theme.Cue(" ▲", " (asc)")
+			if t.sortDesc {
+				title = c.Title + theme.Cue(" ▼", " (desc)")
+			}
+		}
+		cols[i] = table.Column{Title: title, Width: c.Width}
+	}
+	return cols
+}
+
+// Update applies a key and reports whether the table used it: ↑/↓ (or
+// k/j), PgUp/PgDn and Home/End move the selection, s sorts by the next
+// column and S reverses the order.
+func (t *Table) Update(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "s":
+		next := t.sortCol + 1
+		if next >= len(t.columns) {
+			next = -1
+		}
+		t.SortBy(next, false)
+		return true
+	case "S":
+		if t.sortCol >= 0 {
+			t.SortBy(t.sortCol, !t.sortDesc)
+		}
+		return true
+	}
+	km := t.model.KeyMap
+	if !key.Matches(msg, km.LineUp, km.LineDown, km.PageUp, km.PageDown, km.GotoTop, km.GotoBottom) {
+		return false
+	}
+	t.model, _ = t.model.Update(msg)
+	return true
+}
+
+// SortHint describes the sort order for a help line, e.g. "sorted by Role ▼".
+func (t *Table) SortHint() string {
+	if t.sortCol < 0 {
+		return "unsorted"
+	}
+	return "sorted by " + strings.TrimSpace(t.headers()[t.sortCol].Title)
+}
+
+// View renders the header and the rows in view, the selected one
+// highlighted.
+func (t *Table) View() string {
+	t.model.SetStyles(tableStyles())
+	return t.model.View()
+}
@@ -0,0 +1,104 @@
+// Package components provides a single-line text field for the editors.
+package components
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TextInput wraps bubbles textinput with the editing keys the manager's
+// fields share: ←/→, home/end, alt+←/→ by word, ctrl+w and ctrl+u to
+// delete, and paste from the terminal or ctrl+v. The value a field opens
+// with starts selected, so typing replaces it while an arrow key keeps it;
+// ctrl+a selects it all again.
+//
+// A terminal paste arrives as one bracketed paste message and is inserted
+// whole, without the line break copied along with it.
+type TextInput struct {
+	input    textinput.Model
+	selected bool
+	// Filter drops typed and pasted characters it rejects; nil keeps all
+	Filter func(r rune) bool
+}
+
+// NewTextInput opens a field on value with the cursor at the end.
+func NewTextInput(value string) *TextInput {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.KeyMap.LineStart.SetKeys("home") // ctrl+a selects all instead
+	ti.SetValue(value)
+	ti.Focus()
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.CursorEnd()
+	return &TextInput{input: ti, selected: value != ""}
+}
+
+// Value returns the text entered.
+func (t *TextInput) Value() string {
+	return t.input.Value()
+}
+
+// Update applies a key to the field.
+func (t *TextInput) Update(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "ctrl+a":
+		t.selected = t.input.Value() != ""
+		return
+	case "ctrl+v":
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			return
+		}
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text), Paste: true}
+	}
+
+	switch msg.Type {
+	case tea.KeySpace, tea.KeyRunes:
+		runes := msg.Runes
+		if msg.Type == tea.KeySpace {
+			runes = []rune{' '}
+		}
+		if msg.Paste {
+			runes = []rune(strings.TrimSpace(string(runes)))
+		}
+		if t.Filter != nil {
+			kept := runes[:0:0]
+			for _, r := range runes {
+				if t.Filter(r) {
+					kept = append(kept, r)
+				}
+			}
+			runes = kept
+		}
+		if len(runes) == 0 {
+			return
+		}
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Paste: msg.Paste}
+		if t.selected {
+			t.input.SetValue("")
+		}
+	case tea.KeyBackspace, tea.KeyDelete, tea.KeyCtrlW, tea.KeyCtrlU:
+		if t.selected {
+			t.input.SetValue("")
+			t.selected = false
+			return
+		}
+	}
+	t.selected = false
+	t.input, _ = t.input.Update(msg)
+}
+
+// View renders the field in style, with the cursor shown in reverse.
+func (t *TextInput) View(style lipgloss.Style) string {
+	if t.selected {
+		return style.Reverse(true).Render(t.input.Value())
+	}
+	t.input.TextStyle = style
+	t.input.Cursor.Style = style
+	return t.input.View()
+}
@@ -0,0 +1,167 @@
+// Package components provides toast notifications for the Fetch TUI.
+package components
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// Severity controls a toast's color, icon, and how long it stays up.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeveritySuccess
+	SeverityWarning
+	SeverityError
+)
+
+// Icon returns the marker shown before a toast of this severity.
+func (s Severity) Icon() string {
+	switch s {
+	case SeveritySuccess:
+		return theme.Cue("✓", "Done:")
+	case SeverityWarning:
+		return theme.Cue("⚠", "Warning:")
+	case SeverityError:
+		return theme.Cue("✗", "Error:")
+	default:
+		return theme.Cue("ℹ", "Info:")
+	}
+}
+
+// Color returns the theme color for this severity.
+func (s Severity) Color() lipgloss.TerminalColor {
+	switch s {
+	case SeveritySuccess:
+		return theme.Active().Success
+	case SeverityWarning:
+		return theme.Active().Warning
+	case SeverityError:
+		return theme.Active().Error
+	default:
+		return theme.Active().Info
+	}
+}
+
+// Timeout is how long a toast stays visible. Errors linger so they can be read.
+func (s Severity) Timeout() time.Duration {
+	switch s {
+	case SeverityError:
+		return 10 * time.Second
+	case SeverityWarning:
+		return 6 * time.Second
+	default:
+		return 4 * time.Second
+	}
+}
+
+// Toast is a single notification.
+type Toast struct {
+	ID       int
+	Message  string
+	Severity Severity
+	At       time.Time
+}
+
+const (
+	maxVisibleToasts = 3
+	maxToastHistory  = 100
+	maxToastWidth    = 60
+)
+
+// Toasts is a queue of active notifications plus a bounded history of
+// everything shown this session.
+type Toasts struct {
+	active  []Toast
+	history []Toast
+	nextID  int
+}
+
+// NewToasts creates an empty notification queue.
+func NewToasts() *Toasts {
+	return &Toasts{}
+}
+
+// Push adds a toast and returns its ID for dismissal.
+func (t *Toasts) Push(message string, severity Severity) int {
+	t.nextID++
+	toast := Toast{ID: t.nextID, Message: message, Severity: severity, At: time.Now()}
+	t.active = append(t.active, toast)
+	t.history = append(t.history, toast)
+	if len(t.history) > maxToastHistory {
+		t.history = t.history[len(t.history)-maxToastHistory:]
+	}
+	return toast.ID
+}
+
+// Dismiss removes the toast with the given ID, if it is still showing.
+func (t *Toasts) Dismiss(id int) {
+	for i, toast := range t.active {
+		if toast.ID == id {
+			t.active = append(t.active[:i], t.active[i+1:]...)
+			return
+		}
+	}
+}
+
+// DismissAll hides every active toast. History is kept.
+func (t *Toasts) DismissAll() {
+	t.active = nil
+}
+
+// Active returns the toasts currently showing, oldest first.
+func (t *Toasts) Active() []Toast {
+	return t.active
+}
+
+// History returns every toast shown this session, oldest first.
+func (t *Toasts) History() []Toast {
+	return t.history
+}
+
+// ClearHistory forgets past notifications.
+func (t *Toasts) ClearHistory() {
+	t.history = nil
+}
+
+// renderToast renders one toast as a bordered box line.
+func renderToast(toast Toast, width int) string {
+	style := lipgloss.NewStyle().
+		Foreground(toast.Severity.Color()).
+		Background(theme.Active().Surface).
+		Bold(true).
+		Padding(0, 1)
+	msg := ansi.Truncate(toast.Message, width-4, "…")
+	return style.Render(toast.Severity.Icon() + " " + msg)
+}
+
+// OverlayToasts draws the newest active toasts over the top-right corner of
+// base. Screens push their content to the bottom, so the top rows are
+// usually blank.
+func OverlayToasts(base string, toasts []Toast, width int) string {
+	if len(toasts) == 0 {
+		return base
+	}
+	if len(toasts) > maxVisibleToasts {
+		toasts = toasts[len(toasts)-maxVisibleToasts:]
+	}
+	toastWidth := min(maxToastWidth, width-4)
+
+	lines := strings.Split(base, "\n")
+	for len(lines) < len(toasts) {
+		lines = append(lines, "")
+	}
+	for i, toast := range toasts {
+		box := renderToast(toast, toastWidth)
+		boxWidth := lipgloss.Width(box)
+		left := ansi.Truncate(lines[i], width-boxWidth-2, "")
+		pad := width - boxWidth - 2 - lipgloss.Width(left)
+		lines[i] = left + strings.Repeat(" ", max(0, pad)) + box
+	}
+	return strings.Join(lines, "\n")
+}
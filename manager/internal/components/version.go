@@ -3,18 +3,29 @@ package components
 
 import (
 	"fmt"
+	"regexp"
 	"runtime"
+	"runtime/debug"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fetch/manager/internal/layout"
 	"github.com/fetch/manager/internal/theme"
 )
 
-// Build-time variables set via -ldflags.
+// devVersion is the version of builds that weren't stamped with one.
+const devVersion = "v1.0.0-dev"
+
+// releaseVersion matches release tags like v1.2.0 or v1.3.0-rc1, not
+// git describe output (v1.2.0-3-gabc1234) or Go pseudo-versions.
+var releaseVersion = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?$`)
+
+// Build-time variables set via -ldflags by build.sh.
 var (
-	version   = "v1.0.0-dev"
+	version   = devVersion
 	buildDate = "unknown"
 	gitCommit = "unknown"
+	modified  = "false" // "true" when built from a tree with uncommitted changes
 )
 
 // VersionInfo holds version information for the application.
@@ -23,18 +34,62 @@ type VersionInfo struct {
 	BuildDate string
 	GitCommit string
 	GoVersion string
+	Modified  bool // Built from a tree with uncommitted changes
+	Dev       bool // No release version: a plain go build or go run
 }
 
-// DefaultVersionInfo returns version info populated from ldflags (or defaults).
+// DefaultVersionInfo returns version info populated from ldflags. Values
+// build.sh didn't set come from the build info the go command embeds, so
+// go install and go build from a checkout still report their commit.
 func DefaultVersionInfo() VersionInfo {
-	return VersionInfo{
+	info := VersionInfo{
 		Version:   version,
 		BuildDate: buildDate,
 		GitCommit: gitCommit,
 		GoVersion: runtime.Version(),
+		Modified:  modified == "true",
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		applyBuildInfo(&info, bi)
+	}
+	info.Dev = info.Version == devVersion || !releaseVersion.MatchString(info.Version)
+	return info
+}
+
+// applyBuildInfo fills in what ldflags left at its defaults. vcs.time is
+// the commit's time, the nearest the go command records to a build date.
+func applyBuildInfo(info *VersionInfo, bi *debug.BuildInfo) {
+	if info.Version == devVersion && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = strings.TrimSuffix(bi.Main.Version, "+dirty")
+	}
+	for _, s := range bi.Settings {
+		switch {
+		case s.Key == "vcs.revision" && info.GitCommit == "unknown":
+			info.GitCommit = s.Value
+		case s.Key == "vcs.time" && info.BuildDate == "unknown":
+			info.BuildDate = s.Value
+		case s.Key == "vcs.modified" && s.Value == "true":
+			info.Modified = true
+		}
 	}
 }
 
+// Label returns the version marked as a dev or modified build, e.g.
+// "v1.2.0" or "v1.0.0-dev (dev build, modified)".
+func (info VersionInfo) Label() string {
+	var marks []string
+	if info.Dev {
+		marks = append(marks, "dev build")
+	}
+	if info.Modified {
+		marks = append(marks, "modified")
+	}
+	if len(marks) == 0 {
+		return info.Version
+	}
+	return info.Version + " (" + strings.Join(marks, ", ") + ")"
+}
+
 // Version renders a Linux neofetch-style version screen with dog on left and info on right.
 func Version(info VersionInfo, width int) string {
 	// ASCII dog art (same as header but standalone) - 14 lines
@@ -55,28 +110,28 @@ func Version(info VersionInfo, width int) string {
 
 	// Styles
 	dogStyle := lipgloss.NewStyle().
-		Foreground(theme.Primary)
+		Foreground(theme.Active().Primary)
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(theme.Primary)
+		Foreground(theme.Active().Primary)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(theme.Primary).
+		Foreground(theme.Active().Primary).
 		Bold(true)
 
 	valueStyle := lipgloss.NewStyle().
-		Foreground(theme.TextPrimary)
+		Foreground(theme.Active().TextPrimary)
 
 	sectionStyle := lipgloss.NewStyle().
-		Foreground(theme.Secondary).
+		Foreground(theme.Active().Secondary).
 		Bold(true)
 
 	treeStyle := lipgloss.NewStyle().
-		Foreground(theme.Border)
+		Foreground(theme.Active().Border)
 
 	linkStyle := lipgloss.NewStyle().
-		Foreground(theme.Info).
+		Foreground(theme.Active().Info).
 		Underline(true)
 
 	// Build right-side info (needs to be 14 lines to match dog height)
@@ -85,11 +140,15 @@ func Version(info VersionInfo, width int) string {
 	// Line 1: Title
 	lines = append(lines, titleStyle.Render("FETCH"))
 	// Line 2: Separator
-	lines = append(lines, lipgloss.NewStyle().Foreground(theme.Border).Render(strings.Repeat("─", 40)))
+	lines = append(lines, lipgloss.NewStyle().Foreground(theme.Active().Border).Render(strings.Repeat("─", min(40, width-4))))
 	// Line 3: Empty
 	lines = append(lines, "")
 	// Line 4: Version
-	lines = append(lines, labelStyle.Render("Version")+"  "+valueStyle.Render(info.Version))
+	versionLine := labelStyle.Render("Version") + "  " + valueStyle.Render(info.Version)
+	if info.Dev || info.Modified {
+		versionLine += " " + lipgloss.NewStyle().Foreground(theme.Active().Warning).Render(strings.TrimPrefix(info.Label(), info.Version+" "))
+	}
+	lines = append(lines, versionLine)
 	// Line 5: Build
 	buildDate := info.BuildDate
 	if buildDate == "unknown" {
@@ -124,6 +183,11 @@ func Version(info VersionInfo, width int) string {
 	// Join info lines
 	infoPanel := strings.Join(lines, "\n")
 
+	// The dog only fits beside the info on wide terminals
+	if !layout.IsWide(width) {
+		return lipgloss.NewStyle().PaddingLeft(2).Render(infoPanel)
+	}
+
 	// Render dog art
 	dog := dogStyle.Render(dogArt)
 
@@ -0,0 +1,79 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file checks settings that only make sense together.
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// effective returns the value the bridge will use for key: the field's
+// value, or its default when unset.
+func (e *Editor) effective(key string) string {
+	for _, field := range e.fields() {
+		if field.Key == key {
+			if field.Value == "" {
+				return field.Default
+			}
+			return field.Value
+		}
+	}
+	return ""
+}
+
+// effectiveInt returns the effective value of key as a number, and false
+// when it isn't one (the bridge then falls back to its default).
+func (e *Editor) effectiveInt(key string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(e.effective(key)))
+	return n, err == nil
+}
+
+// dependencyWarnings explains combinations of settings that contradict
+// each other. Each setting may be fine alone; together they don't do what
+// they seem to.
+func (e *Editor) dependencyWarnings() []string {
+	var warnings []string
+
+	window, okWindow := e.effectiveInt("FETCH_HISTORY_WINDOW")
+	compaction, okCompaction := e.effectiveInt("FETCH_COMPACTION_THRESHOLD")
+	if okWindow && okCompaction && compaction <= window {
+		warnings = append(warnings, fmt.Sprintf(
+			"Compaction Threshold (%d) is not above History Window (%d): messages are summarized before they leave the window, so summaries repeat what the agent already sees.",
+			compaction, window))
+	}
+
+	truncation, okTruncation := e.effectiveInt("FETCH_TRUNCATION_LIMIT")
+	if okCompaction && okTruncation && truncation <= compaction {
+		warnings = append(warnings, fmt.Sprintf(
+			"Truncation Limit (%d) is not above Compaction Threshold (%d): history is cut before it is ever summarized.",
+			truncation, compaction))
+	}
+
+	retries, okRetries := e.effectiveInt("FETCH_MAX_RETRIES")
+	backoff := strings.Split(e.effective("FETCH_RETRY_BACKOFF"), ",")
+	// The first entry is the initial attempt, so retries need one more
+	if okRetries && len(backoff) < retries+1 {
+		warnings = append(warnings, fmt.Sprintf(
+			"Retry Backoff lists %d delays but Max Retries is %d: it needs %d (the first is the initial attempt); later retries wait 10s.",
+			len(backoff), retries, retries+1))
+	}
+
+	rateWindow, okRate := e.effectiveInt("FETCH_RATE_LIMIT_WINDOW")
+	throttle, okThrottle := e.effectiveInt("FETCH_PROGRESS_THROTTLE")
+	if okRate && okThrottle && rateWindow < throttle {
+		warnings = append(warnings, fmt.Sprintf(
+			"Rate Limit Window (%s) is shorter than Progress Throttle (%s): the limit resets between progress updates, so it never holds back a busy sender.",
+			humanMillis(strconv.Itoa(rateWindow)), humanMillis(strconv.Itoa(throttle))))
+	}
+
+	base, okBase := e.effectiveInt("FETCH_RECONNECT_BASE_DELAY")
+	maxDelay, okMax := e.effectiveInt("FETCH_RECONNECT_MAX_DELAY")
+	if okBase && okMax && maxDelay < base {
+		warnings = append(warnings, fmt.Sprintf(
+			"Reconnect Max (%s) is below Reconnect Base (%s): every reconnect waits the maximum.",
+			humanMillis(strconv.Itoa(maxDelay)), humanMillis(strconv.Itoa(base))))
+	}
+
+	return warnings
+}
@@ -5,61 +5,84 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/fetch/manager/internal/components"
 	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/theme"
 )
 
-var (
-	labelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#888888")).
-			Width(25)
+// Styles read the active theme each time so theme changes apply at once.
+func labelStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextSecondary).Width(25)
+}
 
-	inputStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF6B35"))
+func inputStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Primary)
+}
 
-	focusedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#00ff00")).
-			Bold(true)
+func focusedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Success).Bold(true)
+}
 
-	helpTextStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
-			Italic(true)
+func helpTextStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Italic(true)
+}
 
-	separatorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF6B35")).
-			Bold(true)
+func separatorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true)
+}
 
-	defaultStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#555555")).
-			Italic(true)
-)
+func sourceStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Info)
+}
+
+func overrideStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Warning)
+}
 
 // ConfigField represents a single configuration field
 type ConfigField struct {
-	Key         string
-	Value       string
-	Default     string // Default value shown when empty
-	Label       string
-	Help        string
-	Masked      bool
-	IsSeparator bool // Renders as section header, not editable
+	Key     string
+	Value   string
+	Default string // Default value shown when empty
+	Label   string
+	Help    string
+	Masked  bool
+	Millis  bool // Milliseconds; also accepts durations like 5m
 }
 
 // Editor handles the configuration editing UI
 type Editor struct {
-	fields              []ConfigField
-	cursor              int
-	editing             bool
-	editBuffer          string
-	saved               bool
-	errorMessage        string
-	scrollOffset        int // viewport scroll offset
-	viewHeight          int // max visible rows
-	modelPickerRequested bool // signals parent to open model picker
+	form                 *components.Form // one field per setting, grouped by section
+	saved                bool
+	errorMessage         string
+	modelPickerRequested bool                  // signals parent to open model picker
+	ownerChangeRequested bool                  // signals parent to open the owner change flow
+	provenance           map[string]Provenance // where each value comes from (nil until resolved)
+	provenanceErr        string
+	confirm              *components.Confirm // asks before overwriting .env
+	presetOpen           bool                // preset picker is showing
+	presetCursor         int
+	docOpen              bool      // detail pane for the focused field is showing
+	problems             []Problem // unknown and deprecated keys found in .env
+	notice               string    // success message, e.g. after applying a preset
+}
+
+// IsEditing returns true while a field's value is being typed, or a
+// confirmation, the preset picker or a field's detail pane is open
+func (e *Editor) IsEditing() bool {
+	return e.form.Editing() || e.confirm != nil || e.presetOpen || e.docOpen
+}
+
+// DocOpen reports whether the detail pane of a field is showing.
+func (e *Editor) DocOpen() bool {
+	return e.docOpen
 }
 
 // ModelPickerRequested returns true if the user pressed Enter on the Agent Model field
@@ -72,98 +95,162 @@ func (e *Editor) ClearModelPickerRequest() {
 	e.modelPickerRequested = false
 }
 
+// OwnerChangeRequested returns true if the user pressed Enter on the Owner
+// Phone field
+func (e *Editor) OwnerChangeRequested() bool {
+	return e.ownerChangeRequested
+}
+
+// ClearOwnerChangeRequest resets the owner change flag
+func (e *Editor) ClearOwnerChangeRequest() {
+	e.ownerChangeRequested = false
+}
+
 // SetFieldValue sets the value of a field by key
 func (e *Editor) SetFieldValue(key, value string) {
-	for i := range e.fields {
-		if e.fields[i].Key == key {
-			e.fields[i].Value = value
-			return
+	e.form.SetValue(key, value)
+}
+
+// fields returns the settings with their current values, in editor order.
+func (e *Editor) fields() []ConfigField {
+	form := e.form.Fields()
+	fields := make([]ConfigField, len(form))
+	for i, f := range form {
+		spec, _ := SpecFor(f.Key)
+		fields[i] = ConfigField{
+			Key:     f.Key,
+			Value:   f.Value,
+			Default: f.Default,
+			Label:   f.Label,
+			Help:    f.Help,
+			Masked:  f.Masked,
+			Millis:  spec.Millis,
 		}
 	}
+	return fields
 }
 
-// NewEditor creates a new configuration editor
-func NewEditor() *Editor {
-	editor := &Editor{
-		fields: []ConfigField{
-			// ─── Core Settings ───────────────────────────────────────
-			{IsSeparator: true, Label: "─── Core Settings ───"},
-			{Key: "OWNER_PHONE_NUMBER", Label: "Owner Phone", Help: "Your WhatsApp number (e.g., 15551234567)"},
-			{Key: "OPENROUTER_API_KEY", Label: "OpenRouter Key", Help: "API key from openrouter.ai", Masked: true},
-			{Key: "ENABLE_COPILOT", Label: "Enable Copilot", Help: "Enable GitHub Copilot harness", Default: "false"},
-			{Key: "ENABLE_CLAUDE", Label: "Enable Claude", Help: "Enable Claude Code harness", Default: "false"},
-			{Key: "ENABLE_GEMINI", Label: "Enable Gemini", Help: "Enable Gemini harness", Default: "false"},
-			{Key: "AGENT_MODEL", Label: "Agent Model", Help: "OpenRouter model ID", Default: "openai/gpt-4o-mini"},
-			{Key: "LOG_LEVEL", Label: "Log Level", Help: "debug, info, warn, error", Default: "info"},
-			{Key: "TZ", Label: "Timezone", Help: "IANA timezone", Default: "UTC"},
-			// ─── Context Window ──────────────────────────────────────
-			{IsSeparator: true, Label: "─── Context Window ───"},
-			{Key: "FETCH_HISTORY_WINDOW", Label: "History Window", Help: "Messages in sliding window", Default: "20"},
-			{Key: "FETCH_COMPACTION_THRESHOLD", Label: "Compaction Threshold", Help: "Compact when messages exceed this", Default: "40"},
-			{Key: "FETCH_COMPACTION_MAX_TOKENS", Label: "Compaction Max Tokens", Help: "Max tokens for compaction summary", Default: "500"},
-			{Key: "FETCH_COMPACTION_MODEL", Label: "Compaction Model", Help: "Model for summaries", Default: "openai/gpt-4o-mini"},
-			// ─── Agent LLM ───────────────────────────────────────────
-			{IsSeparator: true, Label: "─── Agent LLM ───"},
-			{Key: "FETCH_MAX_TOOL_CALLS", Label: "Max Tool Calls", Help: "Tool call rounds per message", Default: "5"},
-			{Key: "FETCH_CHAT_MAX_TOKENS", Label: "Chat Max Tokens", Help: "Token budget for chat responses", Default: "300"},
-			{Key: "FETCH_CHAT_TEMPERATURE", Label: "Chat Temperature", Help: "LLM creativity 0.0-1.0", Default: "0.7"},
-			{Key: "FETCH_TOOL_MAX_TOKENS", Label: "Tool Max Tokens", Help: "Token budget for tool responses", Default: "500"},
-			{Key: "FETCH_TOOL_TEMPERATURE", Label: "Tool Temperature", Help: "LLM precision 0.0-1.0", Default: "0.3"},
-			{Key: "FETCH_FRAME_MAX_TOKENS", Label: "Frame Max Tokens", Help: "Token budget for task framing", Default: "200"},
-			// ─── Circuit Breaker ─────────────────────────────────────
-			{IsSeparator: true, Label: "─── Circuit Breaker ───"},
-			{Key: "FETCH_CB_THRESHOLD", Label: "CB Threshold", Help: "Errors before circuit opens", Default: "3"},
-			{Key: "FETCH_CB_BACKOFF", Label: "CB Backoff (ms)", Help: "Backoff schedule, comma-separated", Default: "1000,5000,30000"},
-			{Key: "FETCH_MAX_RETRIES", Label: "Max Retries", Help: "Max retries for retriable errors", Default: "3"},
-			{Key: "FETCH_RETRY_BACKOFF", Label: "Retry Backoff (ms)", Help: "Retry schedule, comma-separated", Default: "0,1000,3000,10000"},
-			{Key: "FETCH_CB_RESET_MS", Label: "CB Reset (ms)", Help: "Reset error count after quiet period", Default: "300000"},
-			// ─── Task Execution ──────────────────────────────────────
-			{IsSeparator: true, Label: "─── Task Execution ───"},
-			{Key: "FETCH_TASK_TIMEOUT", Label: "Task Timeout (ms)", Help: "Task execution timeout", Default: "300000"},
-			{Key: "FETCH_HARNESS_TIMEOUT", Label: "Harness Timeout (ms)", Help: "AI harness timeout", Default: "300000"},
-			{Key: "FETCH_TASK_MAX_RETRIES", Label: "Task Max Retries", Help: "Max task retries", Default: "1"},
-			// ─── WhatsApp Formatting ─────────────────────────────────
-			{IsSeparator: true, Label: "─── WhatsApp Formatting ───"},
-			{Key: "FETCH_WA_MAX_LENGTH", Label: "WA Max Length", Help: "Max chars per WhatsApp message", Default: "4000"},
-			{Key: "FETCH_WA_LINE_WIDTH", Label: "WA Line Width", Help: "Max chars per line for readability", Default: "40"},
-			// ─── Rate Limiting ───────────────────────────────────────
-			{IsSeparator: true, Label: "─── Rate Limiting ───"},
-			{Key: "FETCH_RATE_LIMIT_MAX", Label: "Rate Limit Max", Help: "Requests per window", Default: "30"},
-			{Key: "FETCH_RATE_LIMIT_WINDOW", Label: "Rate Limit Window (ms)", Help: "Rate limit window duration", Default: "60000"},
-			// ─── Bridge / Reconnection ───────────────────────────────
-			{IsSeparator: true, Label: "─── Bridge / Reconnection ───"},
-			{Key: "FETCH_MAX_RECONNECT", Label: "Max Reconnect", Help: "Max reconnect attempts", Default: "10"},
-			{Key: "FETCH_RECONNECT_BASE_DELAY", Label: "Reconnect Base (ms)", Help: "Base delay for exponential backoff", Default: "5000"},
-			{Key: "FETCH_RECONNECT_MAX_DELAY", Label: "Reconnect Max (ms)", Help: "Max delay cap for reconnect", Default: "300000"},
-			{Key: "FETCH_RECONNECT_JITTER", Label: "Reconnect Jitter (ms)", Help: "Max jitter added to delay", Default: "2000"},
-			{Key: "FETCH_DEDUP_TTL", Label: "Dedup TTL (ms)", Help: "Message deduplication cache TTL", Default: "30000"},
-			{Key: "FETCH_PROGRESS_THROTTLE", Label: "Progress Throttle (ms)", Help: "Throttle interval for progress updates", Default: "3000"},
-			// ─── Session / Memory ────────────────────────────────────
-			{IsSeparator: true, Label: "─── Session / Memory ───"},
-			{Key: "FETCH_RECENT_MSG_LIMIT", Label: "Recent Msg Limit", Help: "Default recent messages limit", Default: "50"},
-			{Key: "FETCH_TRUNCATION_LIMIT", Label: "Truncation Limit", Help: "Max messages before hard truncation", Default: "100"},
-			{Key: "FETCH_REPO_MAP_TTL", Label: "Repo Map TTL (ms)", Help: "Repo map staleness check interval", Default: "300000"},
-			// ─── Workspace ───────────────────────────────────────────
-			{IsSeparator: true, Label: "─── Workspace ───"},
-			{Key: "FETCH_WORKSPACE_CACHE_TTL", Label: "Workspace Cache (ms)", Help: "Workspace info cache TTL", Default: "30000"},
-			{Key: "FETCH_GIT_TIMEOUT", Label: "Git Timeout (ms)", Help: "Git command execution timeout", Default: "5000"},
-			// ─── BM25 Memory ─────────────────────────────────────────
-			{IsSeparator: true, Label: "─── BM25 Memory ───"},
-			{Key: "FETCH_RECALL_LIMIT", Label: "Recall Limit", Help: "Max recalled results injected into context", Default: "5"},
-			{Key: "FETCH_RECALL_SNIPPET_TOKENS", Label: "Recall Snippet Tokens", Help: "Max tokens per recalled snippet", Default: "300"},
-			{Key: "FETCH_RECALL_DECAY", Label: "Recall Decay", Help: "Recency decay factor, higher=faster", Default: "0.1"},
-		},
+// focused returns the field under the cursor.
+func (e *Editor) focused() ConfigField {
+	key := e.FocusedKey()
+	for _, f := range e.fields() {
+		if f.Key == key {
+			return f
+		}
+	}
+	return ConfigField{}
+}
+
+// Focus moves the cursor to the field with the given key and opens it for
+// editing, as if the user had pressed Enter on it. It reports whether the
+// field exists.
+func (e *Editor) Focus(key string) bool {
+	if !e.form.Select(key) {
+		return false
+	}
+	e.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return true
+}
+
+// FocusedKey returns the key of the field under the cursor.
+func (e *Editor) FocusedKey() string {
+	f, _ := e.form.Focused()
+	return f.Key
+}
+
+// Select moves the cursor to the field with the given key without opening
+// it. It reports whether the field exists.
+func (e *Editor) Select(key string) bool {
+	return e.form.Select(key)
+}
+
+// ClickLine handles a click on a rendered line of the editor. Clicking a
+// field focuses it; clicking the focused field opens it for editing. It
+// reports whether the line belonged to a field.
+func (e *Editor) ClickLine(line string) bool {
+	if e.confirm != nil {
+		return false
+	}
+	ev := e.form.ClickLine(line)
+	e.formEvent(ev)
+	return ev != components.FormIgnored
+}
+
+// SetProvenance records where each field's effective value comes from.
+func (e *Editor) SetProvenance(msg ProvenanceMsg) {
+	if msg.Err != nil {
+		e.provenanceErr = msg.Err.Error()
+		return
+	}
+	e.provenance = msg.Fields
+	e.provenanceErr = ""
+}
+
+// fieldTag renders what follows a field's value: the duration a
+// millisecond value stands for, and where the value comes from.
+func (e *Editor) fieldTag(field components.FormField, editing bool) string {
+	tag := e.sourceTag(field.Key)
+	value := field.Value
+	if value == "" {
+		value = field.Default
+	}
+	if spec, _ := SpecFor(field.Key); spec.Millis && !editing {
+		if human := humanMillis(value); human != "" {
+			tag = " " + helpTextStyle().Render("("+human+")") + tag
+		}
+	}
+	return tag
+}
+
+// fieldNotes adds to the focused field's help: the other ways to type a
+// duration, and a running value that differs from the saved one.
+func (e *Editor) fieldNotes(field components.FormField, editing bool) []string {
+	var notes []string
+	if spec, _ := SpecFor(field.Key); spec.Millis && editing {
+		notes = append(notes, helpTextStyle().Render("Also accepts 5m, 300s, 1h30m"))
 	}
+	// Explain when the running container disagrees with what's saved
+	if p, ok := e.provenance[field.Key]; ok && p.HasRunning && p.Running != field.Value {
+		running := p.Running
+		if field.Masked && running != "" {
+			running = strings.Repeat("•", min(len(running), 20))
+		}
+		notes = append(notes, overrideStyle().Render("Running value: "+running+" (restart Fetch to apply saved value)"))
+	}
+	return notes
+}
+
+// sourceTag renders the provenance tag for a field, or "" if unknown.
+func (e *Editor) sourceTag(key string) string {
+	if e.provenance == nil {
+		return ""
+	}
+	p, ok := e.provenance[key]
+	if !ok {
+		return " " + sourceStyle().Render("[default]")
+	}
+	if p.Source == SourceContainer {
+		return " " + overrideStyle().Render("["+p.Source.Tag()+"]")
+	}
+	return " " + sourceStyle().Render("["+p.Source.Tag()+"]")
+}
+
+// NewEditor creates a new configuration editor with the fields declared
+// in schema.json
+func NewEditor() *Editor {
+	editor := &Editor{form: components.NewForm(schemaFields()...)}
+	editor.form.LabelWidth = 25
+	editor.form.Tag = editor.fieldTag
+	editor.form.Notes = editor.fieldNotes
 	editor.loadFromFile()
 	return editor
 }
 
-// loadFromFile loads current values from .env file.
-func (e *Editor) loadFromFile() {
+// readEnvFile parses KEY=VALUE pairs from the .env file.
+func readEnvFile() (map[string]string, error) {
 	file, err := os.Open(paths.EnvFile)
 	if err != nil {
-		// File doesn't exist, that's okay
-		return
+		return nil, err
 	}
 	defer file.Close()
 
@@ -179,10 +266,30 @@ func (e *Editor) loadFromFile() {
 			envMap[parts[0]] = parts[1]
 		}
 	}
+	return envMap, scanner.Err()
+}
+
+// EnvValue returns the value of key from the .env file, or "" if unset.
+func EnvValue(key string) string {
+	envMap, err := readEnvFile()
+	if err != nil {
+		return ""
+	}
+	return envMap[key]
+}
+
+// loadFromFile loads current values from .env file.
+func (e *Editor) loadFromFile() {
+	envMap, err := readEnvFile()
+	if err != nil {
+		// File doesn't exist, that's okay
+		return
+	}
+	e.problems = envProblems(envMap)
 
-	for i := range e.fields {
-		if val, ok := envMap[e.fields[i].Key]; ok {
-			e.fields[i].Value = val
+	for _, field := range e.form.Fields() {
+		if val, ok := envMap[field.Key]; ok {
+			e.form.SetValue(field.Key, val)
 		}
 	}
 }
@@ -192,10 +299,24 @@ func (e *Editor) loadFromFile() {
 func (e *Editor) saveToFile() error {
 	// Build map of editor-managed keys
 	editorValues := make(map[string]string)
-	for _, field := range e.fields {
+	var order []string
+	for _, field := range e.form.Fields() {
 		editorValues[field.Key] = field.Value
+		order = append(order, field.Key)
 	}
+	return writeEnvValues(editorValues, order)
+}
+
+// SetEnvValue sets a single key in the .env file, leaving everything else
+// untouched.
+func SetEnvValue(key, value string) error {
+	return writeEnvValues(map[string]string{key: value}, []string{key})
+}
 
+// writeEnvValues updates the given keys in the .env file, preserving
+// unknown keys, comments, and blank lines. Keys not yet present are
+// appended in order, skipping empty values.
+func writeEnvValues(values map[string]string, order []string) error {
 	// Read existing file content
 	existingContent, readErr := os.ReadFile(paths.EnvFile)
 
@@ -222,7 +343,7 @@ func (e *Editor) saveToFile() error {
 			}
 
 			key := strings.TrimSpace(parts[0])
-			if val, managed := editorValues[key]; managed {
+			if val, managed := values[key]; managed {
 				// Editor-managed key — write updated value
 				outputLines = append(outputLines, key+"="+val)
 				writtenKeys[key] = true
@@ -238,13 +359,10 @@ func (e *Editor) saveToFile() error {
 		outputLines = append(outputLines, "")
 	}
 
-	// Append any editor-managed keys not already in the file
-	for _, field := range e.fields {
-		if field.IsSeparator {
-			continue
-		}
-		if !writtenKeys[field.Key] && field.Value != "" {
-			outputLines = append(outputLines, field.Key+"="+field.Value)
+	// Append any managed keys not already in the file
+	for _, key := range order {
+		if !writtenKeys[key] && values[key] != "" {
+			outputLines = append(outputLines, key+"="+values[key])
 		}
 	}
 
@@ -252,180 +370,194 @@ func (e *Editor) saveToFile() error {
 	output := strings.Join(outputLines, "\n")
 	output = strings.TrimRight(output, "\n") + "\n"
 
-	return os.WriteFile(paths.EnvFile, []byte(output), 0644)
+	return writeFileAtomic(paths.EnvFile, []byte(output))
 }
 
-// SetSize sets the available viewport height for scrolling
-func (e *Editor) SetSize(height int) {
-	// Reserve lines for status messages, padding
-	e.viewHeight = height - 6
-	if e.viewHeight < 10 {
-		e.viewHeight = 10
+// writeFileAtomic replaces path through a temporary file and a rename, so
+// readers never see a half-written .env. The file keeps its permissions.
+func writeFileAtomic(path string, data []byte) error {
+	// Replace the target of a symlinked .env, not the link
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
 	}
-}
-
-// ensureVisible adjusts scroll offset so cursor is visible
-func (e *Editor) ensureVisible() {
-	if e.viewHeight <= 0 {
-		return
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
 	}
-	// Each field takes ~1 line, separators ~2, focused field ~2 (with help)
-	// Use a simple line-per-field estimate
-	if e.cursor < e.scrollOffset {
-		e.scrollOffset = e.cursor
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
 	}
-	if e.cursor >= e.scrollOffset+e.viewHeight {
-		e.scrollOffset = e.cursor - e.viewHeight + 1
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
 	}
-	if e.scrollOffset < 0 {
-		e.scrollOffset = 0
+	if err := tmp.Close(); err != nil {
+		return err
 	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// SetSize sets the available viewport height for scrolling
+func (e *Editor) SetSize(height int) {
+	// Reserve lines for status messages, padding
+	e.form.SetHeight(max(10, height-6))
 }
 
 // Update handles keyboard input
 func (e *Editor) Update(msg tea.KeyMsg) {
-	if e.editing {
-		switch msg.String() {
-		case "enter":
-			e.fields[e.cursor].Value = e.editBuffer
-			e.editing = false
-		case "esc":
-			e.editing = false
-		case "backspace":
-			if len(e.editBuffer) > 0 {
-				e.editBuffer = e.editBuffer[:len(e.editBuffer)-1]
-			}
-		default:
-			if len(msg.String()) == 1 {
-				e.editBuffer += msg.String()
-			}
+	if e.confirm != nil {
+		result := e.confirm.Update(msg)
+		if result == components.ConfirmPending {
+			return
+		}
+		e.confirm = nil
+		if result == components.ConfirmYes {
+			e.save()
 		}
 		return
 	}
 
-	switch msg.String() {
-	case "up", "k":
-		for i := e.cursor - 1; i >= 0; i-- {
-			if !e.fields[i].IsSeparator {
-				e.cursor = i
-				break
-			}
-		}
-		e.ensureVisible()
-	case "down", "j":
-		for i := e.cursor + 1; i < len(e.fields); i++ {
-			if !e.fields[i].IsSeparator {
-				e.cursor = i
-				break
-			}
-		}
-		e.ensureVisible()
-	case "enter", "e":
-		if !e.fields[e.cursor].IsSeparator {
-			// AGENT_MODEL opens the model picker overlay
-			if e.fields[e.cursor].Key == "AGENT_MODEL" {
-				e.modelPickerRequested = true
-				return
-			}
-			e.editing = true
-			e.editBuffer = e.fields[e.cursor].Value
-		}
-	case "s":
-		err := e.saveToFile()
-		if err != nil {
-			e.errorMessage = "Failed to save: " + err.Error()
-		} else {
-			e.saved = true
-			e.errorMessage = ""
-		}
+	if e.presetOpen {
+		e.updatePresets(msg.String())
+		return
 	}
-}
 
-// View renders the configuration editor
-func (e *Editor) View() string {
-	s := ""
-
-	// Determine visible range
-	startIdx := 0
-	endIdx := len(e.fields)
-	if e.viewHeight > 0 && len(e.fields) > e.viewHeight {
-		startIdx = e.scrollOffset
-		endIdx = e.scrollOffset + e.viewHeight
-		if endIdx > len(e.fields) {
-			endIdx = len(e.fields)
+	if e.docOpen {
+		switch msg.String() {
+		case "esc", "q", "i", "f1", "enter":
+			e.docOpen = false
 		}
+		return
 	}
 
-	// Scroll indicator at top
-	if startIdx > 0 {
-		s += helpTextStyle.Render("   ▲ scroll up for more") + "\n"
+	if e.form.Editing() {
+		e.formEvent(e.form.Update(msg))
+		return
 	}
 
-	for i := startIdx; i < endIdx; i++ {
-		field := e.fields[i]
-		// Render separator as section header
-		if field.IsSeparator {
-			s += "\n" + separatorStyle.Render("   "+field.Label) + "\n"
-			continue
+	switch msg.String() {
+	case "p":
+		e.presetOpen = true
+	case "i", "f1":
+		e.docOpen = true
+	case "s":
+		changed := e.changedKeys()
+		if len(changed) == 0 {
+			e.errorMessage = "No changes to save"
+			return
 		}
-
-		label := labelStyle.Render(field.Label + ":")
-
-		value := field.Value
-		if field.Masked && value != "" && !e.editing {
-			value = strings.Repeat("•", min(len(value), 20))
+		if len(changed) > 5 {
+			changed = append(changed[:5], fmt.Sprintf("and %d more", len(changed)-5))
+		}
+		message := "Save changes to " + strings.Join(changed, ", ") + "? The previous values in .env will be replaced."
+		if n := len(e.dependencyWarnings()); n > 0 {
+			message += fmt.Sprintf(" %d settings conflict; see the warnings above.", n)
 		}
+		e.confirm = components.NewConfirm("Overwrite .env", message, "Save")
+	default:
+		e.formEvent(e.form.Update(msg))
+	}
+}
 
-		// Show default when value is empty
-		displayValue := value
-		showingDefault := false
-		if displayValue == "" && field.Default != "" {
-			displayValue = field.Default
-			showingDefault = true
+// formEvent follows up on what a key or click did to the form: a stored
+// value clears the last error, and Agent Model and Owner Phone open their
+// own flows instead of a text field.
+func (e *Editor) formEvent(ev components.FormEvent) {
+	switch ev {
+	case components.FormCommitted:
+		e.errorMessage = ""
+	case components.FormActivated:
+		switch e.FocusedKey() {
+		case "AGENT_MODEL":
+			e.modelPickerRequested = true
+		case "OWNER_PHONE_NUMBER":
+			e.ownerChangeRequested = true
 		}
+	}
+}
 
-		if i == e.cursor {
-			if e.editing {
-				// Show edit buffer with cursor
-				s += focusedStyle.Render("▶ ") + label + " " + inputStyle.Render(e.editBuffer+"█") + "\n"
-			} else if showingDefault {
-				s += focusedStyle.Render("▶ ") + label + " " + defaultStyle.Render(displayValue+" (default)") + "\n"
-			} else {
-				s += focusedStyle.Render("▶ ") + label + " " + inputStyle.Render(displayValue) + "\n"
-			}
-			// Show help text for focused field
-			s += "     " + helpTextStyle.Render(field.Help) + "\n"
-		} else {
-			if showingDefault {
-				s += "   " + label + " " + defaultStyle.Render(displayValue) + "\n"
-			} else {
-				s += "   " + label + " " + value + "\n"
+// save writes the fields to .env and records the outcome
+func (e *Editor) save() {
+	if err := e.saveToFile(); err != nil {
+		e.errorMessage = "Failed to save: " + err.Error()
+		return
+	}
+	e.saved = true
+	e.errorMessage = ""
+	e.notice = ""
+	// Display settings are the ones the manager itself can apply right away
+	for _, field := range e.form.Fields() {
+		switch field.Key {
+		case "FETCH_THEME":
+			if err := theme.Set(field.Value); err != nil {
+				e.errorMessage = "Saved, but " + err.Error()
 			}
+		case "FETCH_ACCESSIBLE":
+			on, _ := strconv.ParseBool(field.Value)
+			theme.SetPlain(on)
 		}
 	}
+}
 
-	s += "\n"
+// changedKeys lists fields whose value differs from .env
+func (e *Editor) changedKeys() []string {
+	envMap, _ := readEnvFile()
+	var changed []string
+	for _, field := range e.form.Fields() {
+		if field.Value != envMap[field.Key] {
+			changed = append(changed, field.Key)
+		}
+	}
+	return changed
+}
 
-	// Scroll indicator at bottom
-	if endIdx < len(e.fields) {
-		s += helpTextStyle.Render("   ▼ scroll down for more") + "\n"
+// View renders the configuration editor
+func (e *Editor) View() string {
+	if e.presetOpen {
+		return e.viewPresets()
 	}
+	if e.docOpen {
+		return e.viewDoc()
+	}
+
+	s := e.form.View() + "\n"
 
 	// Field counter
-	editableCount := 0
-	for _, f := range e.fields {
-		if !f.IsSeparator {
-			editableCount++
+	editableCount := len(e.form.Fields())
+	s += helpTextStyle().Render(fmt.Sprintf("   %d configurable parameters", editableCount)) + "\n"
+	for _, w := range e.dependencyWarnings() {
+		s += lipgloss.NewStyle().Foreground(theme.Active().Warning).Width(100).Render("   ⚠ "+w) + "\n"
+	}
+	if len(e.problems) > 0 {
+		s += "\n" + separatorStyle().Render("   ─── Problems in .env ───") + "\n"
+		for _, p := range e.problems {
+			s += lipgloss.NewStyle().Width(100).Render("   "+overrideStyle().Render("✗ "+p.Key)+" "+p.Message) + "\n"
 		}
+		s += helpTextStyle().Render("   The editor only writes the keys above; fix or remove these in .env by hand.") + "\n"
+	}
+	if e.provenanceErr != "" {
+		s += helpTextStyle().Render("   Value sources unavailable: "+e.provenanceErr) + "\n"
 	}
-	s += helpTextStyle.Render(fmt.Sprintf("   %d configurable parameters", editableCount)) + "\n"
 
 	if e.saved {
-		s += lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00")).Render("   ✅ Configuration saved!") + "\n"
+		s += lipgloss.NewStyle().Foreground(theme.Active().Success).Render("   ✅ Configuration saved!") + "\n"
+	}
+
+	if e.notice != "" && !e.saved {
+		s += lipgloss.NewStyle().Foreground(theme.Active().Success).Render("   ✅ "+e.notice) + "\n"
 	}
 
 	if e.errorMessage != "" {
-		s += lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000")).Render("   ❌ "+e.errorMessage) + "\n"
+		s += lipgloss.NewStyle().Foreground(theme.Active().Error).Render("   ❌ "+e.errorMessage) + "\n"
+	}
+
+	if e.confirm != nil {
+		s += "\n" + lipgloss.NewStyle().MarginLeft(3).Render(e.confirm.View(64)) + "\n"
 	}
 
 	return s
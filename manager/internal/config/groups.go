@@ -0,0 +1,334 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file handles the WhatsApp group allow-list.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// GroupAccess is the groups entry of whitelist.json (version 3). Without
+// Restricted, Fetch answers in every group, as before version 3.
+type GroupAccess struct {
+	Restricted bool              `json:"restricted"`
+	Allowed    map[string]string `json:"allowed,omitempty"` // Group JID → name when allowed
+}
+
+// GroupsLoadedMsg carries the groups listed by the bridge.
+type GroupsLoadedMsg struct {
+	Groups []status.Group
+	Err    error
+}
+
+// ListGroupsCmd asks the bridge for the WhatsApp groups the linked account
+// is in.
+func ListGroupsCmd(client *status.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return GroupsLoadedMsg{Err: errors.New("bridge client not available")}
+		}
+		groups, err := client.GetGroups()
+		return GroupsLoadedMsg{Groups: groups, Err: err}
+	}
+}
+
+// GroupManager handles the group allow-list UI. Like the repository list,
+// selections are kept in memory until saved. Saving rewrites only the
+// groups entry of whitelist.json, so trusted numbers edited meanwhile (on
+// the Trusted Numbers screen or with /trust) are kept.
+type GroupManager struct {
+	groups     []status.Group    // From the bridge, plus allowed groups it didn't list
+	allowed    map[string]string // Group JID → name
+	restricted bool              // Only answer in allowed groups
+	dirty      bool              // Selections differ from the file
+	loading    bool
+	loadErr    error
+	cursor     int
+	offset     int
+	viewHeight int
+	width      int // Columns the view must fit in; 0 for no limit
+
+	client       *status.Client
+	message      string
+	messageIsErr bool
+	fileVersion  int
+	bridgeAck    string // How the bridge took the last save
+	bridgeAcked  bool
+}
+
+// NewGroupManager reads the group list from whitelist.json. Run the command
+// from ListGroupsCmd to fill in the groups.
+func NewGroupManager(client *status.Client) *GroupManager {
+	gm := &GroupManager{client: client, loading: true, viewHeight: 15}
+	gm.loadFromFile()
+	return gm
+}
+
+// loadFromFile replaces the selections with the file contents.
+func (gm *GroupManager) loadFromFile() {
+	gm.allowed = map[string]string{}
+	gm.restricted, gm.dirty = false, false
+	whitelist, err := readWhitelistFile()
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		gm.message, gm.messageIsErr = "Failed to read whitelist.json: "+err.Error(), true
+		return
+	}
+	gm.fileVersion = whitelist.Version
+	if whitelist.Groups != nil {
+		gm.restricted = whitelist.Groups.Restricted
+		for id, name := range whitelist.Groups.Allowed {
+			gm.allowed[id] = name
+		}
+	}
+	gm.mergeAllowed()
+}
+
+// mergeAllowed lists allowed groups the bridge didn't return, such as ones
+// the account has left, so they can still be unticked.
+func (gm *GroupManager) mergeAllowed() {
+	listed := make(map[string]bool, len(gm.groups))
+	for _, g := range gm.groups {
+		listed[g.ID] = true
+	}
+	var extra []status.Group
+	for id, name := range gm.allowed {
+		if !listed[id] {
+			extra = append(extra, status.Group{ID: id, Name: name})
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return strings.ToLower(extra[i].Name) < strings.ToLower(extra[j].Name) })
+	gm.groups = append(gm.groups, extra...)
+}
+
+// Save writes the group list into whitelist.json and tells the bridge.
+func (gm *GroupManager) Save() error {
+	whitelist, err := readWhitelistFile()
+	if errors.Is(err, os.ErrNotExist) {
+		whitelist, err = WhitelistData{TrustedNumbers: []string{}}, nil
+	}
+	if err != nil {
+		return err
+	}
+	if whitelist.Version > whitelistVersion {
+		return fmt.Errorf("whitelist.json uses schema version %d; update the manager to edit it", whitelist.Version)
+	}
+
+	whitelist.Groups = &GroupAccess{Restricted: gm.restricted, Allowed: gm.allowed}
+	whitelist.UpdatedAt = time.Now().Format(time.RFC3339)
+	whitelist.Version = whitelistVersion
+	data, err := json.MarshalIndent(whitelist, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(whitelistPath()), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(whitelistPath(), data, 0644); err != nil {
+		return err
+	}
+	gm.dirty = false
+	gm.fileVersion = whitelistVersion
+	gm.bridgeAck, gm.bridgeAcked = reloadBridgeWhitelist(gm.client)
+	if gm.restricted {
+		gm.message = fmt.Sprintf("Saved: Fetch answers in %d group(s)", len(gm.allowed))
+	} else {
+		gm.message = "Saved: Fetch answers in every group"
+	}
+	gm.messageIsErr = false
+	return nil
+}
+
+// Dirty reports whether there are unsaved selections.
+func (gm *GroupManager) Dirty() bool {
+	return gm.dirty
+}
+
+// SetSize sets the lines available for the group list.
+func (gm *GroupManager) SetSize(height int) {
+	gm.viewHeight = max(5, height-12)
+	gm.ensureVisible()
+}
+
+// SetWidth sets the columns the view must fit in.
+func (gm *GroupManager) SetWidth(width int) {
+	gm.width = width
+}
+
+// helpLine renders muted help text indented under the title, wrapped to
+// the width.
+func (gm *GroupManager) helpLine(text string) string {
+	style := whitelistHelpStyle().PaddingLeft(3)
+	if gm.width > 0 {
+		style = style.Width(gm.width)
+	}
+	return style.Render(text)
+}
+
+func (gm *GroupManager) ensureVisible() {
+	gm.cursor = min(gm.cursor, max(0, len(gm.groups)-1))
+	if gm.cursor < gm.offset {
+		gm.offset = gm.cursor
+	}
+	if gm.cursor >= gm.offset+gm.viewHeight {
+		gm.offset = gm.cursor - gm.viewHeight + 1
+	}
+}
+
+// Update handles the groups loaded by ListGroupsCmd and key presses.
+func (gm *GroupManager) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case GroupsLoadedMsg:
+		gm.loading = false
+		gm.loadErr = msg.Err
+		if msg.Err == nil {
+			groups := msg.Groups
+			sort.SliceStable(groups, func(i, j int) bool {
+				return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+			})
+			gm.groups = groups
+			gm.mergeAllowed()
+			gm.ensureVisible()
+		}
+	case tea.KeyMsg:
+		return gm.handleKey(msg)
+	}
+	return nil
+}
+
+func (gm *GroupManager) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if gm.cursor > 0 {
+			gm.cursor--
+		}
+	case "down", "j":
+		if gm.cursor < len(gm.groups)-1 {
+			gm.cursor++
+		}
+	case " ", "enter":
+		if gm.cursor < len(gm.groups) {
+			g := gm.groups[gm.cursor]
+			if _, ok := gm.allowed[g.ID]; ok {
+				delete(gm.allowed, g.ID)
+			} else {
+				gm.allowed[g.ID] = g.Name
+			}
+			gm.dirty = true
+		}
+	case "t":
+		gm.restricted = !gm.restricted
+		gm.dirty = true
+	case "s":
+		if err := gm.Save(); err != nil {
+			gm.message, gm.messageIsErr = "Failed to save: "+err.Error(), true
+		}
+	case "r":
+		gm.loading = true
+		gm.groups = nil
+		gm.loadFromFile()
+		return ListGroupsCmd(gm.client)
+	}
+	gm.ensureVisible()
+	return nil
+}
+
+// View renders the group allow-list.
+func (gm *GroupManager) View() string {
+	var s strings.Builder
+
+	s.WriteString("👥 ")
+	s.WriteString(lipgloss.NewStyle().Bold(true).Render("Groups Fetch answers in"))
+	s.WriteString("\n")
+	if gm.restricted {
+		s.WriteString(gm.helpLine(fmt.Sprintf("Only ticked groups: %d allowed · data/whitelist.json", len(gm.allowed))))
+	} else {
+		s.WriteString(gm.helpLine("Every group: ticks take effect once you press t to restrict Fetch to them"))
+	}
+	s.WriteString("\n")
+	s.WriteString(gm.helpLine("Trusted numbers still need @fetch in an allowed group; other groups are ignored, even for the owner"))
+	s.WriteString("\n\n")
+
+	switch {
+	case gm.loading && len(gm.groups) == 0:
+		s.WriteString(gm.helpLine("Asking the bridge for your groups..."))
+		s.WriteString("\n")
+	case gm.loadErr != nil && len(gm.groups) == 0:
+		s.WriteString(whitelistErrorStyle().Render("   ❌ " + gm.loadErr.Error()))
+		s.WriteString("\n")
+		s.WriteString(gm.helpLine("Start Fetch and link WhatsApp, then press r to list your groups."))
+		s.WriteString("\n")
+	case len(gm.groups) == 0:
+		s.WriteString(gm.helpLine("The linked account isn't in any groups."))
+		s.WriteString("\n")
+	}
+	if gm.loadErr != nil && len(gm.groups) > 0 {
+		s.WriteString(whitelistWarningStyle().Render("   Showing saved groups only: " + gm.loadErr.Error()))
+		s.WriteString("\n")
+	}
+
+	end := min(len(gm.groups), gm.offset+gm.viewHeight)
+	for row := gm.offset; row < end; row++ {
+		g := gm.groups[row]
+		prefix := "   "
+		if row == gm.cursor {
+			prefix = whitelistFocusedStyle().Render(" ▶ ")
+		}
+		box := whitelistHelpStyle().Render(theme.Cue("[ ]", "[no] "))
+		if _, ok := gm.allowed[g.ID]; ok {
+			box = repoCheckStyle().Render(theme.Cue("[✓]", "[yes]"))
+		}
+		name := g.Name
+		if name == "" {
+			name = g.ID
+		}
+		s.WriteString(prefix + box + " " + repoNameStyle().Render(name))
+		if g.Participants > 0 {
+			s.WriteString(whitelistHelpStyle().Render(fmt.Sprintf("  %d members", g.Participants)))
+		} else {
+			s.WriteString(whitelistHelpStyle().Render("  not listed by the bridge"))
+		}
+		s.WriteString("\n")
+	}
+	if len(gm.groups) > gm.viewHeight {
+		s.WriteString(whitelistHelpStyle().Render(fmt.Sprintf("   %d–%d of %d", gm.offset+1, end, len(gm.groups))))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	if gm.message != "" {
+		if gm.messageIsErr {
+			s.WriteString(whitelistErrorStyle().Render("   ❌ " + gm.message))
+		} else {
+			s.WriteString(whitelistSuccessStyle().Render("   ✅ " + gm.message))
+		}
+		s.WriteString("\n")
+	}
+	if gm.bridgeAck != "" && !gm.messageIsErr {
+		if gm.bridgeAcked {
+			s.WriteString(whitelistSuccessStyle().Render("   ● " + gm.bridgeAck))
+		} else {
+			s.WriteString(whitelistWarningStyle().Render("   ○ " + gm.bridgeAck))
+		}
+		s.WriteString("\n")
+	}
+	if gm.dirty {
+		s.WriteString(whitelistExpiryStyle().Render("   ● Unsaved changes — press s to save"))
+		s.WriteString("\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,70 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file converts between millisecond settings and durations like "5m".
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseMillis turns an entry for a millisecond field into the digits the
+// bridge reads. Each comma-separated item may be plain milliseconds
+// ("300000") or a duration ("5m", "1h30m", "1.5s"); lists such as backoff
+// schedules keep their order.
+func parseMillis(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", nil
+	}
+	items := strings.Split(input, ",")
+	for i, item := range items {
+		item = strings.TrimSpace(item)
+		if _, err := strconv.ParseUint(item, 10, 64); err == nil {
+			items[i] = item
+			continue
+		}
+		d, err := time.ParseDuration(item)
+		if err != nil || d < 0 {
+			return "", fmt.Errorf("%q is not milliseconds or a duration like 5m or 1h30m", item)
+		}
+		if d%time.Millisecond != 0 {
+			return "", fmt.Errorf("%q is finer than a millisecond", item)
+		}
+		items[i] = strconv.FormatInt(d.Milliseconds(), 10)
+	}
+	return strings.Join(items, ","), nil
+}
+
+// humanMillis renders a millisecond value as durations ("300000" → "5m",
+// "1000,5000" → "1s, 5s"). It returns "" when the value isn't milliseconds.
+func humanMillis(value string) string {
+	if value == "" {
+		return ""
+	}
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		ms, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil || ms < 0 {
+			return ""
+		}
+		items[i] = shortDuration(time.Duration(ms) * time.Millisecond)
+	}
+	return strings.Join(items, ", ")
+}
+
+// shortDuration formats d without zero units, e.g. "5m" instead of "5m0s".
+func shortDuration(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
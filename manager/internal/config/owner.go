@@ -0,0 +1,367 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file handles changing the owner number.
+package config
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/phone"
+	"github.com/fetch/manager/internal/status"
+)
+
+// ownerCodeTTL is how long a verification code stays valid.
+const ownerCodeTTL = 10 * time.Minute
+
+// ownerCodeAttempts is how many wrong codes end the verification.
+const ownerCodeAttempts = 3
+
+// ownerStep is where the owner change flow is.
+type ownerStep int
+
+const (
+	ownerStepNumber  ownerStep = iota // Typing the new number
+	ownerStepConfirm                  // Choosing to verify or apply
+	ownerStepCode                     // Typing the code sent over WhatsApp
+	ownerStepDone                     // Showing the outcome
+)
+
+// OwnerCodeSentMsg reports whether the bridge sent the verification code.
+type OwnerCodeSentMsg struct {
+	Err error
+}
+
+// OwnerChangedMsg reports the outcome of applying the new owner.
+type OwnerChangedMsg struct {
+	Live bool // The running bridge switched too; otherwise .env only
+	Err  error
+}
+
+// OwnerChange is the guided flow for changing OWNER_PHONE_NUMBER: the
+// number is parsed as E.164, optionally proven with a code sent over
+// WhatsApp, then written to .env and applied to the running bridge. If the
+// bridge is running and refuses the number, .env is put back, so the two
+// never disagree.
+type OwnerChange struct {
+	client  *status.Client
+	current string // Owner digits in .env when the flow opened
+
+	step      ownerStep
+	input     string
+	number    phone.Number
+	code      string // Code sent to the new number
+	codeInput string
+	sentAt    time.Time
+	attempts  int
+	busy      bool // Waiting on the bridge
+	finished  bool
+
+	message      string
+	messageIsErr bool
+
+	width int // Columns the view must fit in; 0 for no limit
+}
+
+// NewOwnerChange starts the flow from the owner number in .env.
+func NewOwnerChange(client *status.Client) *OwnerChange {
+	current := EnvValue("OWNER_PHONE_NUMBER")
+	return &OwnerChange{client: client, current: normalizeNumber(current), input: current}
+}
+
+// Number returns the new owner digits once the change has been applied.
+func (oc *OwnerChange) Number() string {
+	if oc.step != ownerStepDone {
+		return ""
+	}
+	return oc.number.Digits()
+}
+
+// Finished reports whether the user left the flow.
+func (oc *OwnerChange) Finished() bool {
+	return oc.finished
+}
+
+// IsEditing returns true while a number or code is being typed.
+func (oc *OwnerChange) IsEditing() bool {
+	return oc.step == ownerStepNumber || oc.step == ownerStepCode
+}
+
+// Update handles key presses and the bridge's replies.
+func (oc *OwnerChange) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case OwnerCodeSentMsg:
+		oc.busy = false
+		if msg.Err != nil {
+			oc.setError(codeSendError(msg.Err))
+			return nil
+		}
+		oc.step = ownerStepCode
+		oc.codeInput, oc.attempts = "", 0
+		oc.sentAt = time.Now()
+		oc.message, oc.messageIsErr = "Code sent to "+oc.number.Format()+" on WhatsApp", false
+	case OwnerChangedMsg:
+		oc.busy = false
+		if msg.Err != nil {
+			oc.step = ownerStepConfirm
+			oc.setError(msg.Err.Error())
+			return nil
+		}
+		oc.step = ownerStepDone
+		oc.current = oc.number.Digits()
+		switch {
+		case msg.Live:
+			oc.message, oc.messageIsErr = "Owner is now "+oc.number.Format()+", on the running bridge and in .env", false
+		default:
+			oc.message, oc.messageIsErr = "Owner saved to .env as "+oc.number.Format()+"; Fetch isn't running, so it applies when Fetch starts", false
+		}
+	case tea.KeyMsg:
+		if oc.busy {
+			return nil
+		}
+		return oc.handleKey(msg)
+	}
+	return nil
+}
+
+func (oc *OwnerChange) setError(message string) {
+	oc.message, oc.messageIsErr = message, true
+}
+
+func (oc *OwnerChange) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch oc.step {
+	case ownerStepNumber:
+		switch msg.String() {
+		case "esc":
+			oc.finished = true
+		case "enter":
+			n, _, err := phone.Lenient(oc.input)
+			switch {
+			case err != nil:
+				oc.setError("Enter the number in international form, e.g. +1 415 555 0132 (" + err.Error() + ")")
+			case n.Digits() == oc.current:
+				oc.setError(n.Format() + " is already the owner")
+			default:
+				oc.number = n
+				oc.step = ownerStepConfirm
+				oc.message = ""
+			}
+		case "backspace":
+			if len(oc.input) > 0 {
+				oc.input = oc.input[:len(oc.input)-1]
+			}
+		default:
+			for _, r := range msg.String() {
+				if (r >= '0' && r <= '9') || r == '+' || r == ' ' || r == '-' {
+					oc.input += string(r)
+				}
+			}
+		}
+	case ownerStepConfirm:
+		switch msg.String() {
+		case "esc":
+			oc.step = ownerStepNumber
+			oc.message = ""
+		case "v":
+			return oc.sendCode()
+		case "a":
+			oc.busy = true
+			oc.message, oc.messageIsErr = "Applying...", false
+			return applyOwnerCmd(oc.client, oc.current, oc.number.Digits())
+		}
+	case ownerStepCode:
+		switch msg.String() {
+		case "esc":
+			oc.step = ownerStepConfirm
+			oc.code, oc.message = "", ""
+		case "r":
+			return oc.sendCode()
+		case "enter":
+			return oc.checkCode()
+		case "backspace":
+			if len(oc.codeInput) > 0 {
+				oc.codeInput = oc.codeInput[:len(oc.codeInput)-1]
+			}
+		default:
+			for _, r := range msg.String() {
+				if r >= '0' && r <= '9' && len(oc.codeInput) < 6 {
+					oc.codeInput += string(r)
+				}
+			}
+		}
+	case ownerStepDone:
+		switch msg.String() {
+		case "esc", "enter", "q":
+			oc.finished = true
+		}
+	}
+	return nil
+}
+
+// sendCode makes a new code and asks the bridge to send it.
+func (oc *OwnerChange) sendCode() tea.Cmd {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		oc.setError("Failed to make a code: " + err.Error())
+		return nil
+	}
+	oc.code = fmt.Sprintf("%06d", n.Int64())
+	oc.busy = true
+	oc.message, oc.messageIsErr = "Sending a code to "+oc.number.Format()+"...", false
+	client, digits, code := oc.client, oc.number.Digits(), oc.code
+	return func() tea.Msg {
+		if client == nil {
+			return OwnerCodeSentMsg{Err: errors.New("bridge client not available")}
+		}
+		return OwnerCodeSentMsg{Err: client.SendOwnerCode(digits, code)}
+	}
+}
+
+// checkCode compares the typed code and applies the change when it matches.
+func (oc *OwnerChange) checkCode() tea.Cmd {
+	if time.Since(oc.sentAt) > ownerCodeTTL {
+		oc.setError("The code expired. Press r to send a new one")
+		return nil
+	}
+	if oc.codeInput != oc.code {
+		oc.attempts++
+		oc.codeInput = ""
+		if oc.attempts >= ownerCodeAttempts {
+			oc.step = ownerStepNumber
+			oc.code = ""
+			oc.setError("Too many wrong codes. Check the number and start again")
+			return nil
+		}
+		oc.setError(fmt.Sprintf("Wrong code; %d attempt(s) left", ownerCodeAttempts-oc.attempts))
+		return nil
+	}
+	oc.busy = true
+	oc.message, oc.messageIsErr = "Verified. Applying...", false
+	return applyOwnerCmd(oc.client, oc.current, oc.number.Digits())
+}
+
+// codeSendError explains why the bridge couldn't send the code.
+func codeSendError(err error) string {
+	switch {
+	case errors.Is(err, status.ErrNotOnWhatsApp):
+		return "That number has no WhatsApp account. Check it, or press a to apply without verifying"
+	case errors.Is(err, status.ErrWhatsAppOffline):
+		return "WhatsApp isn't connected, so no code can be sent. Press a to apply without verifying"
+	}
+	return "Couldn't send the code: " + err.Error()
+}
+
+// applyOwnerCmd writes the new owner to .env, then switches the running
+// bridge. When the bridge is up but refuses, .env gets the old value back.
+func applyOwnerCmd(client *status.Client, previous, digits string) tea.Cmd {
+	return func() tea.Msg {
+		if err := SetEnvValue("OWNER_PHONE_NUMBER", digits); err != nil {
+			return OwnerChangedMsg{Err: fmt.Errorf("failed to update .env: %w", err)}
+		}
+		if client == nil || !client.IsHealthy() {
+			return OwnerChangedMsg{}
+		}
+		if err := client.SetOwner(digits); err != nil {
+			if restoreErr := SetEnvValue("OWNER_PHONE_NUMBER", previous); restoreErr != nil {
+				return OwnerChangedMsg{Err: fmt.Errorf("bridge refused the new owner (%v) and restoring .env failed: %w; set OWNER_PHONE_NUMBER back to %s by hand", err, restoreErr, previous)}
+			}
+			return OwnerChangedMsg{Err: fmt.Errorf("bridge refused the new owner, so .env was left unchanged: %w", err)}
+		}
+		return OwnerChangedMsg{Live: true}
+	}
+}
+
+// SetWidth sets the columns the view must fit in.
+func (oc *OwnerChange) SetWidth(width int) {
+	oc.width = width
+}
+
+// line renders text in style, indented under the title and wrapped to the
+// width.
+func (oc *OwnerChange) line(style lipgloss.Style, text string) string {
+	style = style.PaddingLeft(3)
+	if oc.width > 0 {
+		style = style.Width(oc.width)
+	}
+	return style.Render(text)
+}
+
+// View renders the owner change flow.
+func (oc *OwnerChange) View() string {
+	var s strings.Builder
+
+	s.WriteString("📱 ")
+	s.WriteString(lipgloss.NewStyle().Bold(true).Render("Change owner number"))
+	s.WriteString("\n")
+	current := "(not set)"
+	if oc.current != "" {
+		current = prettyOwner(oc.current)
+	}
+	s.WriteString(oc.line(whitelistHelpStyle(), "Current owner: "+current))
+	s.WriteString("\n")
+	s.WriteString(oc.line(whitelistHelpStyle(), "The owner always has full access to Fetch, in direct chats and allowed groups."))
+	s.WriteString("\n\n")
+
+	switch oc.step {
+	case ownerStepNumber:
+		s.WriteString(whitelistFocusedStyle().Render("New number: "))
+		s.WriteString(whitelistNumberStyle().Render(oc.input + "█"))
+		s.WriteString("\n")
+		if n, unknown, err := phone.Lenient(oc.input); err == nil && unknown {
+			s.WriteString(oc.line(whitelistWarningStyle(), n.Format()+" · country code not recognised; check the number"))
+		} else if err == nil {
+			s.WriteString(oc.line(whitelistHelpStyle(), strings.TrimSpace(n.Flag()+" "+n.Country)+" · "+n.Format()))
+		} else if strings.TrimSpace(oc.input) != "" {
+			s.WriteString(oc.line(whitelistHelpStyle(), err.Error()))
+		}
+		s.WriteString("\n")
+	case ownerStepConfirm:
+		s.WriteString("   " + current + " → " + whitelistNumberStyle().Render(oc.number.Flag()+" "+oc.number.Format()))
+		s.WriteString("\n\n")
+		if oc.number.CountryCode == "" {
+			s.WriteString(oc.line(whitelistWarningStyle(), "The country code isn't one the manager knows. Check the number; verifying it is safest."))
+			s.WriteString("\n\n")
+		}
+		if oc.current != "" {
+			s.WriteString(oc.line(whitelistWarningStyle(), current+" stops being the owner. Add it on Trusted Numbers to keep its access."))
+			s.WriteString("\n\n")
+		}
+		s.WriteString("   " + whitelistFocusedStyle().Render("v") + "  Send a verification code over WhatsApp (recommended)\n")
+		s.WriteString("   " + whitelistFocusedStyle().Render("a") + "  Apply without verifying\n")
+	case ownerStepCode:
+		s.WriteString(whitelistFocusedStyle().Render("Code: "))
+		s.WriteString(whitelistNumberStyle().Render(oc.codeInput + "█"))
+		s.WriteString("\n")
+		s.WriteString(oc.line(whitelistHelpStyle(), fmt.Sprintf("Sent to %s · valid for %d minutes · r sends a new one",
+			oc.number.Format(), int(ownerCodeTTL.Minutes()))))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	if oc.message != "" {
+		switch {
+		case oc.busy:
+			s.WriteString(oc.line(whitelistHelpStyle(), "⏳ "+oc.message))
+		case oc.messageIsErr:
+			s.WriteString(oc.line(whitelistErrorStyle(), "❌ "+oc.message))
+		default:
+			s.WriteString(oc.line(whitelistSuccessStyle(), "✅ "+oc.message))
+		}
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// prettyOwner formats stored owner digits with the region flag.
+func prettyOwner(digits string) string {
+	if n, err := phone.Parse(digits); err == nil {
+		return strings.TrimSpace(n.Flag() + " " + n.Format())
+	}
+	return "+" + digits
+}
@@ -0,0 +1,173 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file holds the presets that set groups of tuning values at once.
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Preset is a named set of FETCH_* values for a common deployment profile.
+// Applying one only changes the editor's fields; the user still saves.
+type Preset struct {
+	Name        string
+	Description string
+	Values      map[string]string
+}
+
+// Presets lists the presets in the order the picker shows them. Values
+// keep the relationships the bridge expects: the compaction threshold
+// stays above the history window and below the truncation limit.
+var Presets = []Preset{
+	{
+		Name:        "Budget",
+		Description: "Fewer tokens per message: a shorter history, smaller replies, fewer tool rounds.",
+		Values: map[string]string{
+			"FETCH_HISTORY_WINDOW":        "12",
+			"FETCH_COMPACTION_THRESHOLD":  "30",
+			"FETCH_COMPACTION_MAX_TOKENS": "300",
+			"FETCH_COMPACTION_MODEL":      "openai/gpt-4o-mini",
+			"FETCH_MAX_TOOL_CALLS":        "3",
+			"FETCH_CHAT_MAX_TOKENS":       "200",
+			"FETCH_TOOL_MAX_TOKENS":       "350",
+			"FETCH_FRAME_MAX_TOKENS":      "150",
+			"FETCH_MAX_RETRIES":           "2",
+			"FETCH_RECALL_LIMIT":          "3",
+			"FETCH_RECALL_SNIPPET_TOKENS": "200",
+		},
+	},
+	{
+		Name:        "Fast responses",
+		Description: "Lower latency: less context to send, quicker retries, more frequent progress updates.",
+		Values: map[string]string{
+			"FETCH_HISTORY_WINDOW":      "12",
+			"FETCH_MAX_TOOL_CALLS":      "3",
+			"FETCH_CHAT_MAX_TOKENS":     "250",
+			"FETCH_RETRY_BACKOFF":       "0,500,1500,5000",
+			"FETCH_CB_BACKOFF":          "500,2000,10000",
+			"FETCH_PROGRESS_THROTTLE":   "1500",
+			"FETCH_RECALL_LIMIT":        "3",
+			"FETCH_WORKSPACE_CACHE_TTL": "60000",
+		},
+	},
+	{
+		Name:        "Long memory",
+		Description: "Remembers more: a longer history, fuller summaries, more recalled snippets.",
+		Values: map[string]string{
+			"FETCH_HISTORY_WINDOW":        "40",
+			"FETCH_COMPACTION_THRESHOLD":  "80",
+			"FETCH_COMPACTION_MAX_TOKENS": "1000",
+			"FETCH_RECENT_MSG_LIMIT":      "100",
+			"FETCH_TRUNCATION_LIMIT":      "200",
+			"FETCH_RECALL_LIMIT":          "10",
+			"FETCH_RECALL_SNIPPET_TOKENS": "400",
+			"FETCH_RECALL_DECAY":          "0.05",
+		},
+	},
+	{
+		Name:        "Paranoid security",
+		Description: "Tighter limits: strict rate limiting, a quick circuit breaker, shorter tasks without retries.",
+		Values: map[string]string{
+			"FETCH_RATE_LIMIT_MAX":    "10",
+			"FETCH_RATE_LIMIT_WINDOW": "60000",
+			"FETCH_CB_THRESHOLD":      "2",
+			"FETCH_CB_BACKOFF":        "5000,30000,120000",
+			"FETCH_CB_RESET_MS":       "600000",
+			"FETCH_MAX_TOOL_CALLS":    "3",
+			"FETCH_TOOL_TEMPERATURE":  "0.2",
+			"FETCH_TASK_TIMEOUT":      "180000",
+			"FETCH_HARNESS_TIMEOUT":   "180000",
+			"FETCH_TASK_MAX_RETRIES":  "0",
+		},
+	},
+}
+
+// PresetChange is one value a preset would change.
+type PresetChange struct {
+	Key   string
+	Label string
+	From  string // Effective value now, the default when unset
+	To    string
+}
+
+// presetChanges compares a preset with the editor's fields, in field order.
+func (e *Editor) presetChanges(p Preset) []PresetChange {
+	var changes []PresetChange
+	for _, field := range e.fields() {
+		to, ok := p.Values[field.Key]
+		if !ok {
+			continue
+		}
+		from := field.Value
+		if from == "" {
+			from = field.Default
+		}
+		if from != to {
+			changes = append(changes, PresetChange{Key: field.Key, Label: field.Label, From: from, To: to})
+		}
+	}
+	return changes
+}
+
+// applyPreset sets the preset's values in the editor, unsaved.
+func (e *Editor) applyPreset(p Preset) int {
+	changes := e.presetChanges(p)
+	for _, c := range changes {
+		e.SetFieldValue(c.Key, c.To)
+	}
+	return len(changes)
+}
+
+// PresetsOpen reports whether the preset picker is showing.
+func (e *Editor) PresetsOpen() bool {
+	return e.presetOpen
+}
+
+// updatePresets handles keys while the preset picker is open.
+func (e *Editor) updatePresets(key string) {
+	switch key {
+	case "up", "k", "left", "h", "shift+tab":
+		e.presetCursor = (e.presetCursor + len(Presets) - 1) % len(Presets)
+	case "down", "j", "right", "l", "tab":
+		e.presetCursor = (e.presetCursor + 1) % len(Presets)
+	case "enter":
+		p := Presets[e.presetCursor]
+		e.presetOpen = false
+		e.saved = false
+		e.errorMessage = ""
+		if n := e.applyPreset(p); n == 0 {
+			e.notice = "Your configuration already matches " + p.Name
+		} else {
+			e.notice = fmt.Sprintf("Applied %s: %d values changed. Press s to save.", p.Name, n)
+		}
+	case "esc", "q":
+		e.presetOpen = false
+	}
+}
+
+// viewPresets renders the preset picker with a preview of its changes.
+func (e *Editor) viewPresets() string {
+	var s strings.Builder
+	s.WriteString(separatorStyle().Render("   ─── Presets ───") + "\n\n")
+	for i, p := range Presets {
+		if i == e.presetCursor {
+			s.WriteString(focusedStyle().Render("▶ "+p.Name) + "\n")
+			s.WriteString("     " + helpTextStyle().Render(p.Description) + "\n")
+		} else {
+			s.WriteString("   " + p.Name + "\n")
+		}
+	}
+
+	p := Presets[e.presetCursor]
+	changes := e.presetChanges(p)
+	s.WriteString("\n" + separatorStyle().Render(fmt.Sprintf("   ─── %s would change %d of %d values ───", p.Name, len(changes), len(p.Values))) + "\n")
+	if len(changes) == 0 {
+		s.WriteString("   " + helpTextStyle().Render("Your configuration already matches this preset.") + "\n")
+	}
+	for _, c := range changes {
+		s.WriteString("   " + labelStyle().Render(c.Label+":") + " " +
+			overrideStyle().Render(c.From) + helpTextStyle().Render(" → ") + inputStyle().Render(c.To) + "\n")
+	}
+	s.WriteString("\n   " + helpTextStyle().Render("Applying only fills in the fields; nothing is written until you save.") + "\n")
+	return s.String()
+}
@@ -0,0 +1,96 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file finds .env keys the schema doesn't know or has retired.
+package config
+
+import (
+	"sort"
+)
+
+// Problem is a key in .env that Fetch won't read as written.
+type Problem struct {
+	Key        string
+	Message    string
+	Suggestion string // Key to use instead, if there is a likely one
+}
+
+// maxTypoDistance is how many edits apart a key may be from a known one to
+// be suggested as a typo of it.
+const maxTypoDistance = 2
+
+// envProblems compares the keys of .env with the schema, in key order.
+// Unknown keys close to a known one are reported as likely typos.
+func envProblems(envMap map[string]string) []Problem {
+	known := map[string]bool{}
+	for _, section := range schema.Sections {
+		for _, spec := range section.Settings {
+			known[spec.Key] = true
+		}
+	}
+	for _, other := range schema.Unmanaged {
+		known[other.Key] = true
+	}
+	deprecated := map[string]DeprecatedKey{}
+	for _, d := range schema.Deprecated {
+		deprecated[d.Key] = d
+	}
+
+	var problems []Problem
+	for key := range envMap {
+		if known[key] {
+			continue
+		}
+		if d, ok := deprecated[key]; ok {
+			p := Problem{Key: key, Message: "Deprecated. " + d.Reason, Suggestion: d.Replacement}
+			if d.Replacement != "" {
+				p.Message += " Use " + d.Replacement + " instead."
+			}
+			problems = append(problems, p)
+			continue
+		}
+		p := Problem{Key: key, Message: "Not a Fetch setting. Ignore this if a coding agent in the kennel needs it."}
+		if guess := closestKey(key, known); guess != "" {
+			p.Suggestion = guess
+			p.Message = "Unknown key. Did you mean " + guess + "?"
+		}
+		problems = append(problems, p)
+	}
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems
+}
+
+// closestKey returns the known key fewest edits from key, or "" when none
+// is within maxTypoDistance.
+func closestKey(key string, known map[string]bool) string {
+	best, bestDistance := "", maxTypoDistance+1
+	for candidate := range known {
+		d := editDistance(key, candidate)
+		if d < bestDistance || (d == bestDistance && candidate < best) {
+			best, bestDistance = candidate, d
+		}
+	}
+	if bestDistance > maxTypoDistance {
+		return ""
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
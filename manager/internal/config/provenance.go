@@ -0,0 +1,108 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file resolves where each configuration value actually comes from.
+package config
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/docker"
+)
+
+// bridgeService is the compose service (and container name) that consumes .env.
+const bridgeService = "fetch-bridge"
+
+// Source identifies which layer a configuration value is taken from.
+type Source int
+
+const (
+	// SourceDefault means the key is unset everywhere and the bridge default applies.
+	SourceDefault Source = iota
+	// SourceEnvFile means the value comes from .env.
+	SourceEnvFile
+	// SourceCompose means docker-compose.yml sets or overrides the value.
+	SourceCompose
+	// SourceContainer means the running container has a different value than
+	// compose would produce (stale container or manual override).
+	SourceContainer
+)
+
+// Tag returns a short label for display next to a field value.
+func (s Source) Tag() string {
+	switch s {
+	case SourceEnvFile:
+		return ".env"
+	case SourceCompose:
+		return "compose"
+	case SourceContainer:
+		return "container"
+	default:
+		return "default"
+	}
+}
+
+// Provenance describes where a field's effective value comes from.
+type Provenance struct {
+	Source     Source
+	Running    string // Value inside the running container
+	HasRunning bool   // Whether the container defines the key at all
+}
+
+// ProvenanceMsg carries resolved provenance for every known key.
+type ProvenanceMsg struct {
+	Fields map[string]Provenance
+	Err    error
+}
+
+// LoadProvenanceCmd inspects .env, the compose config, and the running bridge
+// container to work out which layer each value comes from.
+func LoadProvenanceCmd() tea.Msg {
+	fields, err := LoadProvenance()
+	return ProvenanceMsg{Fields: fields, Err: err}
+}
+
+// LoadProvenance is LoadProvenanceCmd outside the TUI.
+func LoadProvenance() (map[string]Provenance, error) {
+	envFile, _ := readEnvFile()
+
+	compose, err := docker.ComposeEnv(bridgeService)
+	if err != nil {
+		return nil, err
+	}
+
+	// A stopped container is fine — provenance falls back to compose/.env
+	running, _ := docker.ContainerEnv(bridgeService)
+
+	return ResolveProvenance(envFile, compose, running), nil
+}
+
+// ResolveProvenance determines the source of every key seen in any layer.
+// Later layers win: container over compose over .env over default.
+func ResolveProvenance(envFile, compose, running map[string]string) map[string]Provenance {
+	keys := make(map[string]bool)
+	for _, layer := range []map[string]string{envFile, compose, running} {
+		for k := range layer {
+			keys[k] = true
+		}
+	}
+
+	result := make(map[string]Provenance, len(keys))
+	for key := range keys {
+		fileVal, inFile := envFile[key]
+		composeVal, inCompose := compose[key]
+		runVal, inRunning := running[key]
+
+		p := Provenance{Running: runVal, HasRunning: inRunning}
+		switch {
+		case running != nil && inRunning && (!inCompose || runVal != composeVal):
+			p.Source = SourceContainer
+		case inCompose && (!inFile || composeVal != fileVal):
+			p.Source = SourceCompose
+		case inFile:
+			p.Source = SourceEnvFile
+		default:
+			p.Source = SourceDefault
+		}
+		result[key] = p
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+// This file builds the redactor for logs and exports from the settings.
+package config
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/redact"
+)
+
+// personalKeys are settings that aren't secret in the editor but identify
+// people, so exports leave them out like secrets.
+var personalKeys = map[string]bool{
+	"OWNER_PHONE_NUMBER":    true,
+	"TRUSTED_PHONE_NUMBERS": true,
+}
+
+// IsPersonal reports whether a setting identifies a person, such as the
+// owner's phone number.
+func IsPersonal(key string) bool {
+	return personalKeys[key]
+}
+
+var redactorCache struct {
+	sync.Mutex
+	r       *redact.Redactor
+	err     error
+	envMod  time.Time
+	listMod time.Time
+}
+
+// Redactor returns the redactor for logs and exports: the built-in
+// patterns, FETCH_REDACT_PATTERNS, and the values of secret and personal
+// settings and trusted numbers. It is rebuilt when .env or the whitelist
+// changes, so a new API key is scrubbed as soon as it is saved. The error
+// reports a FETCH_REDACT_PATTERNS that doesn't compile; the redactor is
+// usable without it.
+func Redactor() (*redact.Redactor, error) {
+	envMod, listMod := modTime(paths.EnvFile), modTime(whitelistPath())
+
+	c := &redactorCache
+	c.Lock()
+	defer c.Unlock()
+	if c.r != nil && envMod.Equal(c.envMod) && listMod.Equal(c.listMod) {
+		return c.r, c.err
+	}
+
+	env, _ := readEnvFile()
+	var values []string
+	for key, value := range env {
+		value = strings.Trim(value, `"'`)
+		if value == "" || !secretKey(key) && !personalKeys[key] {
+			continue
+		}
+		values = append(values, strings.Split(value, ",")...)
+	}
+	if whitelist, err := readWhitelistFile(); err == nil {
+		values = append(values, whitelist.TrustedNumbers...)
+	}
+
+	pattern := os.Getenv("FETCH_REDACT_PATTERNS")
+	if pattern == "" {
+		pattern = env["FETCH_REDACT_PATTERNS"]
+	}
+	c.r, c.err = redact.New(pattern, values)
+	c.envMod, c.listMod = envMod, listMod
+	return c.r, c.err
+}
+
+// secretKey reports whether a .env key holds a secret: masked in the editor,
+// or named like a credential, as the coding agents' keys are.
+func secretKey(key string) bool {
+	if spec, ok := SpecFor(key); ok && spec.Masked {
+		return true
+	}
+	for _, suffix := range []string{"_KEY", "_TOKEN", "_SECRET", "_PASSWORD"} {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// modTime returns when a file last changed, or the zero time if it can't
+// be read.
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
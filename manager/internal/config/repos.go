@@ -0,0 +1,446 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file handles the repository allow-list for the coding agents.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/github"
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// reposVersion is the repos file schema written by this manager. Files
+// with a newer version are left untouched.
+const reposVersion = 1
+
+// repoListLimit bounds how many repositories are asked of gh.
+const repoListLimit = 1000
+
+// RepoAllowListData represents the JSON structure of data/repos.json. The
+// bridge reads it before cloning a repository or starting a task; without
+// the file every repository is allowed.
+type RepoAllowListData struct {
+	AllowedRepos []string `json:"allowedRepos"` // owner/name
+	UpdatedAt    string   `json:"updatedAt"`
+	Version      int      `json:"version"`
+}
+
+// ReposLoadedMsg carries the repositories listed by gh.
+type ReposLoadedMsg struct {
+	Repos []github.Repo
+	Err   error
+}
+
+// ListReposCmd lists the active GitHub account's repositories.
+func ListReposCmd() tea.Msg {
+	repos, err := github.ListRepos(repoListLimit)
+	return ReposLoadedMsg{Repos: repos, Err: err}
+}
+
+// RepoManager handles the repository allow-list UI. Selections are kept
+// in memory until saved, so several repositories can be picked at once.
+type RepoManager struct {
+	repos      []github.Repo   // From gh, plus allowed repos it didn't list
+	allowed    map[string]bool // Lowercased owner/name
+	enforced   bool            // The file exists, so the list applies
+	dirty      bool            // Selections differ from the file
+	loading    bool
+	loadErr    error
+	cursor     int
+	offset     int
+	viewHeight int
+
+	filter    string
+	filtering bool
+	adding    bool
+	addBuffer string
+
+	message      string
+	messageIsErr bool
+	fileVersion  int
+}
+
+// Styles read the active theme each time so theme changes apply at once.
+func repoNameStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Info)
+}
+
+func repoCheckStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Success).Bold(true)
+}
+
+// NewRepoManager reads the allow-list file. Call ListReposCmd to fill in
+// the repositories.
+func NewRepoManager() *RepoManager {
+	rm := &RepoManager{loading: true, viewHeight: 15}
+	rm.loadFromFile()
+	return rm
+}
+
+// reposPath returns the path to the repos JSON file, next to whitelist.json
+// in the directory the bridge mounts as /app/data.
+func reposPath() string {
+	return filepath.Join(paths.ProjectDir, "data", "repos.json")
+}
+
+// loadFromFile replaces the selections with the file contents.
+func (rm *RepoManager) loadFromFile() {
+	rm.allowed = map[string]bool{}
+	rm.enforced, rm.dirty = false, false
+	data, err := os.ReadFile(reposPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	var list RepoAllowListData
+	if err == nil {
+		err = json.Unmarshal(data, &list)
+	}
+	if err != nil {
+		rm.message, rm.messageIsErr = "Failed to read repos.json: "+err.Error(), true
+		return
+	}
+	rm.enforced = true
+	rm.fileVersion = list.Version
+	for _, r := range list.AllowedRepos {
+		rm.allowed[strings.ToLower(r)] = true
+	}
+	rm.mergeAllowed()
+}
+
+// mergeAllowed lists allowed repositories that gh didn't return, such as
+// ones in organizations, so they can still be unticked.
+func (rm *RepoManager) mergeAllowed() {
+	listed := make(map[string]bool, len(rm.repos))
+	for _, r := range rm.repos {
+		listed[strings.ToLower(r.NameWithOwner)] = true
+	}
+	var extra []string
+	for name, ok := range rm.allowed {
+		if ok && !listed[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		rm.repos = append(rm.repos, github.Repo{NameWithOwner: name})
+	}
+}
+
+// Save writes the selected repositories, which turns the list on.
+func (rm *RepoManager) Save() error {
+	if rm.fileVersion > reposVersion {
+		return fmt.Errorf("repos.json uses schema version %d; update the manager to edit it", rm.fileVersion)
+	}
+	list := RepoAllowListData{
+		AllowedRepos: rm.Selected(),
+		UpdatedAt:    time.Now().Format(time.RFC3339),
+		Version:      reposVersion,
+	}
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(reposPath()), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(reposPath(), data, 0644); err != nil {
+		return err
+	}
+	rm.enforced, rm.dirty = true, false
+	rm.fileVersion = reposVersion
+	rm.message, rm.messageIsErr = fmt.Sprintf("Saved: agents may work on %d repositories", len(list.AllowedRepos)), false
+	return nil
+}
+
+// Disable removes the file so agents may work on any repository again.
+func (rm *RepoManager) Disable() error {
+	if err := os.Remove(reposPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	rm.loadFromFile()
+	rm.message, rm.messageIsErr = "Allow-list removed: agents may work on any repository", false
+	return nil
+}
+
+// Selected returns the allowed repositories, sorted.
+func (rm *RepoManager) Selected() []string {
+	names := make([]string, 0, len(rm.allowed))
+	for name, ok := range rm.allowed {
+		if ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dirty reports whether there are unsaved selections.
+func (rm *RepoManager) Dirty() bool {
+	return rm.dirty
+}
+
+// Enforced reports whether an allow-list file exists.
+func (rm *RepoManager) Enforced() bool {
+	return rm.enforced
+}
+
+// IsEditing returns true while the filter or the add prompt takes keys.
+func (rm *RepoManager) IsEditing() bool {
+	return rm.filtering || rm.adding
+}
+
+// SetSize sets the lines available for the repository list.
+func (rm *RepoManager) SetSize(height int) {
+	rm.viewHeight = max(5, height-10)
+	rm.ensureVisible()
+}
+
+// visible returns the indexes of the repositories matching the filter.
+func (rm *RepoManager) visible() []int {
+	needle := strings.ToLower(rm.filter)
+	idx := make([]int, 0, len(rm.repos))
+	for i, r := range rm.repos {
+		if needle == "" || strings.Contains(strings.ToLower(r.NameWithOwner), needle) ||
+			strings.Contains(strings.ToLower(r.Description), needle) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+func (rm *RepoManager) ensureVisible() {
+	n := len(rm.visible())
+	rm.cursor = min(rm.cursor, max(0, n-1))
+	if rm.cursor < rm.offset {
+		rm.offset = rm.cursor
+	}
+	if rm.cursor >= rm.offset+rm.viewHeight {
+		rm.offset = rm.cursor - rm.viewHeight + 1
+	}
+}
+
+// Update handles the repositories loaded by ListReposCmd.
+func (rm *RepoManager) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case ReposLoadedMsg:
+		rm.loading = false
+		rm.loadErr = msg.Err
+		if msg.Err == nil {
+			rm.repos = msg.Repos
+			rm.mergeAllowed()
+			rm.ensureVisible()
+		}
+	case tea.KeyMsg:
+		return rm.handleKey(msg)
+	}
+	return nil
+}
+
+func (rm *RepoManager) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if rm.filtering || rm.adding {
+		rm.updateInput(msg)
+		return nil
+	}
+
+	visible := rm.visible()
+	switch msg.String() {
+	case "up", "k":
+		if rm.cursor > 0 {
+			rm.cursor--
+		}
+	case "down", "j":
+		if rm.cursor < len(visible)-1 {
+			rm.cursor++
+		}
+	case " ", "enter":
+		if rm.cursor < len(visible) {
+			name := strings.ToLower(rm.repos[visible[rm.cursor]].NameWithOwner)
+			rm.allowed[name] = !rm.allowed[name]
+			rm.dirty = true
+		}
+	case "A":
+		// Tick every shown repository, or untick them if all are ticked
+		all := true
+		for _, i := range visible {
+			all = all && rm.allowed[strings.ToLower(rm.repos[i].NameWithOwner)]
+		}
+		for _, i := range visible {
+			rm.allowed[strings.ToLower(rm.repos[i].NameWithOwner)] = !all
+		}
+		rm.dirty = len(visible) > 0
+	case "/":
+		rm.filtering = true
+	case "m":
+		rm.adding, rm.addBuffer = true, ""
+	case "s":
+		if err := rm.Save(); err != nil {
+			rm.message, rm.messageIsErr = "Failed to save: "+err.Error(), true
+		}
+	case "r":
+		rm.loading = true
+		rm.loadFromFile()
+		return ListReposCmd
+	}
+	rm.ensureVisible()
+	return nil
+}
+
+// updateInput edits the filter or the repository being added by hand.
+func (rm *RepoManager) updateInput(msg tea.KeyMsg) {
+	buf := &rm.filter
+	if rm.adding {
+		buf = &rm.addBuffer
+	}
+	switch msg.String() {
+	case "esc":
+		if rm.filtering {
+			rm.filter = ""
+		}
+		rm.filtering, rm.adding = false, false
+	case "enter":
+		if rm.adding {
+			rm.addRepo(rm.addBuffer)
+		}
+		rm.filtering, rm.adding = false, false
+	case "backspace":
+		if r := []rune(*buf); len(r) > 0 {
+			*buf = string(r[:len(r)-1])
+		}
+	default:
+		if msg.Type == tea.KeyRunes {
+			*buf += string(msg.Runes)
+		}
+	}
+	rm.cursor = 0
+	rm.ensureVisible()
+}
+
+// addRepo ticks a repository typed as owner/name or pasted as a URL.
+func (rm *RepoManager) addRepo(input string) {
+	name := strings.TrimSpace(input)
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "/"), ".git")
+	name = strings.TrimPrefix(name, "git@github.com:")
+	if i := strings.Index(name, "github.com/"); i >= 0 {
+		name = name[i+len("github.com/"):]
+	}
+	owner, repo, ok := strings.Cut(name, "/")
+	if !ok || owner == "" || repo == "" || strings.ContainsAny(repo, "/ ") {
+		rm.message, rm.messageIsErr = "Enter a repository as owner/name", true
+		return
+	}
+	name = strings.ToLower(name)
+	rm.allowed[name] = true
+	rm.dirty = true
+	rm.mergeAllowed()
+	rm.message, rm.messageIsErr = "Added "+name, false
+}
+
+// View renders the repository allow-list.
+func (rm *RepoManager) View() string {
+	var s strings.Builder
+
+	s.WriteString("📦 ")
+	s.WriteString(lipgloss.NewStyle().Bold(true).Render("Repositories the coding agents may work on"))
+	s.WriteString("\n")
+	switch {
+	case !rm.enforced:
+		s.WriteString(whitelistHelpStyle().Render("   No allow-list yet: agents may clone and change any repository gh can reach"))
+	default:
+		s.WriteString(whitelistHelpStyle().Render(fmt.Sprintf("   %d allowed · data/repos.json · applies to the next clone or task", len(rm.Selected()))))
+	}
+	s.WriteString("\n\n")
+
+	switch {
+	case rm.adding:
+		s.WriteString(whitelistFocusedStyle().Render("Add repository (owner/name or URL): "))
+		s.WriteString(repoNameStyle().Render(rm.addBuffer + "█"))
+		s.WriteString("\n\n")
+	case rm.filtering || rm.filter != "":
+		s.WriteString(whitelistFocusedStyle().Render("Filter: "))
+		cursor := ""
+		if rm.filtering {
+			cursor = "█"
+		}
+		s.WriteString(repoNameStyle().Render(rm.filter + cursor))
+		s.WriteString("\n\n")
+	}
+
+	visible := rm.visible()
+	switch {
+	case rm.loading && len(rm.repos) == 0:
+		s.WriteString(whitelistHelpStyle().Render("   Listing your repositories with gh..."))
+		s.WriteString("\n")
+	case rm.loadErr != nil && len(rm.repos) == 0:
+		s.WriteString(whitelistErrorStyle().Render("   ❌ " + rm.loadErr.Error()))
+		s.WriteString("\n")
+		s.WriteString(whitelistHelpStyle().Render("   Press m to add repositories by name instead."))
+		s.WriteString("\n")
+	case len(visible) == 0:
+		s.WriteString(whitelistHelpStyle().Render("   No repositories match."))
+		s.WriteString("\n")
+	}
+	end := min(len(visible), rm.offset+rm.viewHeight)
+	for row := rm.offset; row < end; row++ {
+		r := rm.repos[visible[row]]
+		prefix := "   "
+		if row == rm.cursor && !rm.IsEditing() {
+			prefix = whitelistFocusedStyle().Render(" ▶ ")
+		}
+		box := whitelistHelpStyle().Render(theme.Cue("[ ]", "[no] "))
+		if rm.allowed[strings.ToLower(r.NameWithOwner)] {
+			box = repoCheckStyle().Render(theme.Cue("[✓]", "[yes]"))
+		}
+		s.WriteString(prefix + box + " " + repoNameStyle().Render(r.NameWithOwner))
+		var tags []string
+		if r.Private() {
+			tags = append(tags, "private")
+		}
+		if r.IsFork {
+			tags = append(tags, "fork")
+		}
+		if r.IsArchived {
+			tags = append(tags, "archived")
+		}
+		if len(tags) > 0 {
+			s.WriteString(whitelistHelpStyle().Render("  " + strings.Join(tags, " · ")))
+		}
+		if r.Description != "" {
+			desc := r.Description
+			if len([]rune(desc)) > 50 {
+				desc = string([]rune(desc)[:49]) + "…"
+			}
+			s.WriteString(whitelistHelpStyle().Render("  " + desc))
+		}
+		s.WriteString("\n")
+	}
+	if len(visible) > rm.viewHeight {
+		s.WriteString(whitelistHelpStyle().Render(fmt.Sprintf("   %d–%d of %d", rm.offset+1, end, len(visible))))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	if rm.message != "" {
+		if rm.messageIsErr {
+			s.WriteString(whitelistErrorStyle().Render("   ❌ " + rm.message))
+		} else {
+			s.WriteString(whitelistSuccessStyle().Render("   ✅ " + rm.message))
+		}
+		s.WriteString("\n")
+	}
+	if rm.dirty {
+		s.WriteString(whitelistExpiryStyle().Render("   ● Unsaved changes — press s to save"))
+		s.WriteString("\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,259 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file loads the settings schema: the one place a setting is declared.
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+)
+
+// schemaJSON declares every setting the editor manages, grouped in the
+// sections the editor shows. The bridge's tests read the same file to check
+// it covers every FETCH_* variable with the bridge's defaults, so a new
+// setting is added here and nowhere else in the manager.
+//
+//go:embed schema.json
+var schemaJSON []byte
+
+// Service names what reads a setting.
+type Service string
+
+const (
+	ServiceBridge  Service = "bridge"
+	ServiceKennel  Service = "kennel"
+	ServiceManager Service = "manager"
+)
+
+// String describes the service for the detail pane.
+func (s Service) String() string {
+	switch s {
+	case ServiceBridge:
+		return "Bridge (fetch-app)"
+	case ServiceKennel:
+		return "Kennel (coding agents)"
+	case ServiceManager:
+		return "Manager (this app)"
+	}
+	return string(s)
+}
+
+// Restart says what has to restart before a saved value takes effect.
+type Restart string
+
+const (
+	RestartNone    Restart = "none"    // Applies when saved
+	RestartManager Restart = "manager" // Read when the manager starts
+	RestartBridge  Restart = "bridge"  // Read when the bridge starts
+	RestartRebuild Restart = "rebuild" // Baked into the kennel image
+)
+
+// String describes the restart for the detail pane.
+func (r Restart) String() string {
+	switch r {
+	case RestartNone:
+		return "No; applies as soon as it is saved"
+	case RestartManager:
+		return "Restart the manager"
+	case RestartBridge:
+		return "Restart Fetch (Stop, then Start)"
+	case RestartRebuild:
+		return "Rebuild the kennel image (Update Fetch, or docker compose build)"
+	}
+	return string(r)
+}
+
+// Kinds of value a setting accepts. Text, the empty kind, accepts anything.
+const (
+	KindInt   = "int"
+	KindFloat = "float"
+	KindBool  = "bool"
+	KindEnum  = "enum"  // One of Options
+	KindRegex = "regex" // A Go regular expression
+)
+
+// Spec declares one setting.
+type Spec struct {
+	Key         string    `json:"key"`
+	Label       string    `json:"label"`
+	Help        string    `json:"help"` // One line, shown under the focused field
+	Default     string    `json:"default,omitempty"`
+	Masked      bool      `json:"masked,omitempty"`
+	Millis      bool      `json:"millis,omitempty"` // Milliseconds; durations like 5m are converted
+	Kind        string    `json:"kind,omitempty"`
+	Options     []string  `json:"options,omitempty"`
+	Description string    `json:"description"` // Long form, for the detail pane
+	Range       string    `json:"range"`       // Accepted values, in words
+	Services    []Service `json:"services"`
+	Restart     Restart   `json:"restart"`
+}
+
+// Section is a titled group of settings.
+type Section struct {
+	Title    string `json:"title"`
+	Settings []Spec `json:"settings"`
+}
+
+// OtherKey is a valid .env key the editor doesn't manage, such as a path
+// override or a credential for a coding agent.
+type OtherKey struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+// DeprecatedKey is a key Fetch no longer reads.
+type DeprecatedKey struct {
+	Key         string `json:"key"`
+	Replacement string `json:"replacement,omitempty"` // Key to set instead, if any
+	Reason      string `json:"reason"`
+}
+
+// schemaDoc is the layout of schema.json.
+type schemaDoc struct {
+	Sections   []Section       `json:"sections"`
+	Unmanaged  []OtherKey      `json:"unmanaged"`
+	Deprecated []DeprecatedKey `json:"deprecated"`
+}
+
+// schema is the parsed schemaJSON. The file is embedded, so a parse error
+// is a bug in the build and fails at startup.
+var schema = mustParseSchema(schemaJSON)
+
+func mustParseSchema(data []byte) schemaDoc {
+	var doc schemaDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		panic("config: invalid schema.json: " + err.Error())
+	}
+	return doc
+}
+
+// Schema returns the sections of settings in editor order.
+func Schema() []Section {
+	return schema.Sections
+}
+
+// SpecFor returns the declaration of the setting with the given key.
+func SpecFor(key string) (Spec, bool) {
+	for _, section := range schema.Sections {
+		for _, spec := range section.Settings {
+			if spec.Key == key {
+				return spec, true
+			}
+		}
+	}
+	return Spec{}, false
+}
+
+// schemaFields lays the schema out as editor fields, grouped by section.
+// Agent Model and Owner Phone open their own flows rather than a text field.
+func schemaFields() []components.FormField {
+	var fields []components.FormField
+	for _, section := range schema.Sections {
+		for _, spec := range section.Settings {
+			fields = append(fields, components.FormField{
+				Key:      spec.Key,
+				Label:    spec.Label,
+				Group:    section.Title,
+				Help:     spec.Help,
+				Default:  spec.Default,
+				Masked:   spec.Masked,
+				Action:   spec.Key == "AGENT_MODEL" || spec.Key == "OWNER_PHONE_NUMBER",
+				Validate: spec.parse,
+			})
+		}
+	}
+	return fields
+}
+
+// parse checks a typed value, converting durations such as 5m to
+// milliseconds for Millis settings.
+func (s Spec) parse(value string) (string, error) {
+	if s.Millis {
+		ms, err := parseMillis(value)
+		if err != nil {
+			return "", err
+		}
+		value = ms
+	}
+	if err := s.Validate(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// Validate checks a value against the setting's kind. An empty value is
+// always valid: the default applies.
+func (s Spec) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	switch s.Kind {
+	case KindInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s must be a whole number", s.Label)
+		}
+	case KindFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s must be a number such as 0.5", s.Label)
+		}
+	case KindBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s must be true or false", s.Label)
+		}
+	case KindEnum:
+		if !slices.Contains(s.Options, value) {
+			return fmt.Errorf("%s must be one of %s", s.Label, strings.Join(s.Options, ", "))
+		}
+	case KindRegex:
+		if _, err := regexp.Compile(value); err != nil {
+			return fmt.Errorf("%s is not a valid regular expression: %v", s.Label, err)
+		}
+	}
+	return nil
+}
+
+// viewDoc renders the detail pane for the focused field.
+func (e *Editor) viewDoc() string {
+	field := e.focused()
+	var s strings.Builder
+	s.WriteString(separatorStyle().Render("   ─── "+field.Label+" ───") + "\n\n")
+	s.WriteString("   " + labelStyle().Render("Variable:") + " " + inputStyle().Render(field.Key) + "\n")
+
+	spec, ok := SpecFor(field.Key)
+	if !ok {
+		s.WriteString("\n   " + helpTextStyle().Render(field.Help) + "\n")
+		return s.String()
+	}
+
+	wrap := lipgloss.NewStyle().Width(72).PaddingLeft(3)
+	s.WriteString("\n" + wrap.Render(spec.Description) + "\n\n")
+
+	def := field.Default
+	if def == "" {
+		def = "(none)"
+	} else if human := humanMillis(def); field.Millis && human != "" {
+		def += " (" + human + ")"
+	}
+	services := make([]string, len(spec.Services))
+	for i, svc := range spec.Services {
+		services[i] = svc.String()
+	}
+	rows := [][2]string{
+		{"Accepts:", spec.Range},
+		{"Default:", def},
+		{"Used by:", strings.Join(services, ", ")},
+		{"Restart needed:", spec.Restart.String()},
+	}
+	for _, row := range rows {
+		s.WriteString("   " + labelStyle().Render(row[0]) + " " + row[1] + "\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"testing"
+)
+
+// exampleKey matches a key .env.example sets or shows commented out.
+var exampleKey = regexp.MustCompile(`(?m)^#? ?([A-Z][A-Z0-9_]*)=`)
+
+// exampleKeys reads the keys in the repository's .env.example.
+func exampleKeys(t *testing.T) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile("../../../.env.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]string{}
+	for _, m := range exampleKey.FindAllStringSubmatch(string(data), -1) {
+		keys[m[1]] = ""
+	}
+	if len(keys) == 0 {
+		t.Fatal("no keys in .env.example")
+	}
+	return keys
+}
+
+// TestEnvExampleMatchesSchema checks that a .env copied from the example
+// raises no problems in the editor, and that the example mentions every
+// setting that has no default, which a new install has to fill in.
+func TestEnvExampleMatchesSchema(t *testing.T) {
+	keys := exampleKeys(t)
+	for _, p := range envProblems(keys) {
+		t.Errorf(".env.example has %s: %s", p.Key, p.Message)
+	}
+	for _, section := range Schema() {
+		for _, spec := range section.Settings {
+			if _, ok := keys[spec.Key]; !ok && spec.Default == "" {
+				t.Errorf("%s has no default and isn't in .env.example", spec.Key)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// This file lists settings for scripts (fetch-manager config get).
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Setting is one configuration value as reported to scripts.
+type Setting struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`             // From .env; empty when unset or redacted
+	Default  string `json:"default,omitempty"` // Applies when Value is empty
+	Source   string `json:"source,omitempty"`  // ".env", "compose", "container", or "default"
+	Secret   bool   `json:"secret,omitempty"`
+	Redacted bool   `json:"redacted,omitempty"` // A secret is set but Value was withheld
+}
+
+// Settings returns the settings the editor knows about, in editor order, or
+// only keys when any are given. Secret values are withheld unless reveal.
+// Source is left empty when Docker can't be asked.
+func Settings(keys []string, reveal bool) ([]Setting, error) {
+	e := NewEditor()
+	fields := e.fields()
+	byKey := make(map[string]ConfigField, len(fields))
+	var order []string
+	for _, f := range fields {
+		byKey[f.Key] = f
+		order = append(order, f.Key)
+	}
+	if len(keys) > 0 {
+		order = nil
+		for _, k := range keys {
+			k = strings.ToUpper(strings.TrimSpace(k))
+			if _, ok := byKey[k]; !ok {
+				return nil, fmt.Errorf("unknown setting %q", k)
+			}
+			order = append(order, k)
+		}
+	}
+
+	provenance, _ := LoadProvenance()
+	settings := make([]Setting, 0, len(order))
+	for _, k := range order {
+		f := byKey[k]
+		s := Setting{Key: k, Value: f.Value, Default: f.Default, Secret: f.Masked}
+		if p, ok := provenance[k]; ok {
+			s.Source = p.Source.Tag()
+		} else if provenance != nil {
+			s.Source = SourceDefault.Tag()
+		}
+		if s.Secret && s.Value != "" && !reveal {
+			s.Value, s.Redacted = "", true
+		}
+		settings = append(settings, s)
+	}
+	return settings, nil
+}
@@ -4,65 +4,437 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/layout"
 	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/phone"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
 )
 
-// WhitelistData represents the JSON structure of the whitelist file
+// whitelistVersion is the whitelist file schema written by this manager.
+// Version 2 added per-number contacts (label, note, role, expiresAt);
+// version 3 added the group allow-list. Files with a newer version are left
+// untouched.
+const whitelistVersion = 3
+
+// WhitelistData represents the JSON structure of the whitelist file.
+// Contacts is keyed by normalized number and is optional, so older files
+// and bridges that only know trustedNumbers keep working.
 type WhitelistData struct {
-	TrustedNumbers []string `json:"trustedNumbers"`
-	UpdatedAt      string   `json:"updatedAt"`
-	Version        int      `json:"version"`
+	TrustedNumbers []string                         `json:"trustedNumbers"`
+	Contacts       map[string]status.TrustedContact `json:"contacts,omitempty"`
+	Groups         *GroupAccess                     `json:"groups,omitempty"`
+	UpdatedAt      string                           `json:"updatedAt"`
+	Version        int                              `json:"version"`
 }
 
-// WhitelistManager handles the trusted numbers management UI
+// inputMode identifies which field the whitelist manager is editing
+type inputMode int
+
+const (
+	inputNone inputMode = iota
+	inputNumber
+	inputLabel
+	inputNote
+	inputRole
+	inputBulk // Typed into inputBuffer rather than inputForm, to keep newlines
+	inputImport
+	inputExport
+	inputExpiry
+)
+
+// WhitelistManager handles the trusted numbers management UI.
+//
+// When the bridge is running, changes go through its whitelist API so they
+// take effect immediately and can't race with WhatsApp /trust commands.
+// Otherwise the manager edits data/whitelist.json directly, re-reading the
+// file before each write to detect changes made behind its back.
 type WhitelistManager struct {
 	numbers      []string
-	cursor       int
-	adding       bool
-	addBuffer    string
+	contacts     map[string]status.TrustedContact
+	groups       *GroupAccess // Kept as read; edited on the Group Chats screen
+	table        *components.Table
+	input        inputMode
+	inputForm    *components.Form
+	inputBuffer  string
+	roleCursor   int // Highlighted entry in the role picker
 	message      string
 	messageIsErr bool
+	client       *status.Client
+	useAPI       bool   // Bridge API reachable; file is the fallback
+	loadedAt     string // UpdatedAt of the file when last read (file mode)
+	fileVersion  int    // Version of the file when last read (file mode)
+	bridgeAck    string // How the bridge took the last file write
+	bridgeAcked  bool   // The bridge confirmed it reloaded the file
+	// What each number did, from the bridge's activity log
+	activity    map[string]status.NumberActivity
+	activityErr error
+	// Removal waiting for confirmation
+	confirm       *components.Confirm
+	pendingRemove string
+	// Loading from the bridge runs as a command; load queues one and
+	// Update returns it
+	loading     bool
+	loadQueued  bool
+	announceSrc bool // Say where the list came from once it arrives
+	width       int  // Columns to fit; 0 doesn't wrap
 }
 
-var (
-	whitelistLabelStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#888888")).
-				Width(3)
+// WhitelistLoadedMsg is sent when the list and activity have been fetched
+// from the bridge. Err means the bridge didn't answer and the manager falls
+// back to the file.
+type WhitelistLoadedMsg struct {
+	Whitelist   *status.WhitelistResponse
+	Err         error
+	Activity    map[string]status.NumberActivity
+	ActivityErr error
+	Pruned      int   // Expired numbers removed on the bridge
+	PruneErr    error // Why removing an expired number failed
+}
 
-	whitelistNumberStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#00BFFF"))
+// Styles read the active theme each time so theme changes apply at once.
+func whitelistNumberStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Info)
+}
 
-	whitelistFocusedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#00ff00")).
-				Bold(true)
+func whitelistRoleStyle(role status.Role) lipgloss.Style {
+	switch role {
+	case status.RoleTasks:
+		return lipgloss.NewStyle().Foreground(theme.Active().Info)
+	case status.RoleAdmin:
+		return lipgloss.NewStyle().Foreground(theme.Active().Warning).Bold(true)
+	default:
+		return lipgloss.NewStyle().Foreground(theme.Active().TextSecondary)
+	}
+}
 
-	whitelistHelpStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#626262")).
-				Italic(true)
+func whitelistExpiryStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Warning)
+}
 
-	whitelistSuccessStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#00ff00"))
+func whitelistWarningStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Warning)
+}
 
-	whitelistErrorStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#ff0000"))
-)
+func whitelistContactStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextPrimary).Bold(true)
+}
 
-// NewWhitelistManager creates a new whitelist manager
-func NewWhitelistManager() *WhitelistManager {
-	wm := &WhitelistManager{}
-	wm.loadFromFile()
+func whitelistFocusedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Success).Bold(true)
+}
+
+func whitelistHelpStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Italic(true)
+}
+
+func whitelistSuccessStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Success)
+}
+
+func whitelistErrorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Error)
+}
+
+// NewWhitelistManager creates a new whitelist manager. A nil client
+// always uses the whitelist file.
+func NewWhitelistManager(client *status.Client) *WhitelistManager {
+	wm := &WhitelistManager{client: client}
+	wm.table = components.NewTable(
+		components.TableColumn{Title: "Number", Width: 18},
+		components.TableColumn{Title: "Role", Width: 7, Less: wm.lessRole},
+		components.TableColumn{Title: "Label", Width: 16},
+		components.TableColumn{Title: "Added", Width: 12, Less: wm.lessAdded},
+		components.TableColumn{Title: "Expires", Width: 12, Less: wm.lessExpiry},
+	)
 	return wm
 }
 
+// Init fetches the list and activity from the bridge.
+func (wm *WhitelistManager) Init() tea.Cmd {
+	wm.load()
+	return wm.takeLoad()
+}
+
+// Refresh reloads the list and activity for a return to the screen,
+// keeping the cursor. An edit in progress is left alone.
+func (wm *WhitelistManager) Refresh() tea.Cmd {
+	if wm.IsEditing() {
+		return nil
+	}
+	wm.load()
+	return wm.takeLoad()
+}
+
+// load queues a fetch of the whitelist from the bridge API; the file is
+// the fallback when the bridge is unreachable. The fetch runs as the
+// command Update returns, so a slow bridge doesn't freeze the screen.
+func (wm *WhitelistManager) load() {
+	wm.loading = true
+	wm.loadQueued = true
+}
+
+// takeLoad returns the queued fetch, if any.
+func (wm *WhitelistManager) takeLoad() tea.Cmd {
+	if !wm.loadQueued {
+		return nil
+	}
+	wm.loadQueued = false
+	return loadWhitelistCmd(wm.client)
+}
+
+// loadWhitelistCmd fetches the list and activity and removes expired
+// numbers on the bridge. Activity is only kept by the bridge, so there is
+// no file fallback for it.
+func loadWhitelistCmd(client *status.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return WhitelistLoadedMsg{Err: errors.New("no bridge client")}
+		}
+		var msg WhitelistLoadedMsg
+		msg.Activity, msg.ActivityErr = client.GetActivity()
+		msg.Whitelist, msg.Err = client.GetWhitelist()
+		if msg.Err != nil {
+			return msg
+		}
+
+		// The bridge already stops trusting expired numbers at expiresAt;
+		// this only tidies them out of the list
+		now := time.Now()
+		for _, n := range msg.Whitelist.TrustedNumbers {
+			if !msg.Whitelist.Contacts[n].Expired(now) {
+				continue
+			}
+			if err := client.RemoveTrustedNumber(n); err != nil && !errors.Is(err, status.ErrWhitelistConflict) {
+				msg.PruneErr = fmt.Errorf("failed to remove expired +%s: %w", n, err)
+				break
+			}
+			msg.Pruned++
+		}
+		if msg.Pruned > 0 {
+			if resp, err := client.GetWhitelist(); err == nil {
+				msg.Whitelist = resp
+			}
+		}
+		return msg
+	}
+}
+
+// loaded applies a fetched list, or reads the file when the bridge didn't
+// answer.
+func (wm *WhitelistManager) loaded(msg WhitelistLoadedMsg) {
+	wm.loading = false
+	if wm.client != nil {
+		wm.activity, wm.activityErr = msg.Activity, msg.ActivityErr
+	}
+
+	if msg.Err == nil {
+		wm.useAPI = true
+		wm.numbers = msg.Whitelist.TrustedNumbers
+		if wm.numbers == nil {
+			wm.numbers = []string{}
+		}
+		wm.contacts = msg.Whitelist.Contacts
+		if wm.contacts == nil {
+			wm.contacts = map[string]status.TrustedContact{}
+		}
+		sort.Strings(wm.numbers)
+		switch {
+		case msg.PruneErr != nil:
+			wm.message = "Failed to remove expired number: " + msg.PruneErr.Error()
+			wm.messageIsErr = true
+		case msg.Pruned > 0:
+			wm.message = fmt.Sprintf("Removed %d expired number(s)", msg.Pruned)
+			wm.messageIsErr = false
+		case wm.announceSrc:
+			wm.message = "Refreshed from bridge"
+			wm.messageIsErr = false
+		}
+	} else {
+		wm.useAPI = false
+		wm.loadFromFile()
+		if !wm.pruneExpiredFile() && wm.announceSrc {
+			wm.message = "Refreshed from file"
+			wm.messageIsErr = false
+		}
+	}
+	wm.announceSrc = false
+	wm.syncTable()
+}
+
+// pruneExpiredFile removes numbers whose expiry has passed from the file,
+// reporting whether it changed anything. Like the bridge-side prune in
+// loadWhitelistCmd, this is cleanup only.
+func (wm *WhitelistManager) pruneExpiredFile() bool {
+	now := time.Now()
+	var expired []string
+	for _, n := range wm.numbers {
+		if wm.contacts[n].Expired(now) {
+			expired = append(expired, n)
+		}
+	}
+	if len(expired) == 0 {
+		return false
+	}
+
+	wm.numbers = slices.DeleteFunc(wm.numbers, func(n string) bool {
+		return slices.Contains(expired, n)
+	})
+	for _, n := range expired {
+		delete(wm.contacts, n)
+	}
+	if err := wm.saveToFile(); err != nil {
+		wm.message = "Failed to remove expired numbers: " + err.Error()
+		wm.messageIsErr = true
+		return true
+	}
+
+	wm.message = fmt.Sprintf("Removed %d expired number(s)", len(expired))
+	wm.messageIsErr = false
+	return true
+}
+
+// parseExpiry parses a duration such as "30m", "24h", "7d", or "2w".
+func parseExpiry(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit != 0 {
+		n, err := strconv.Atoi(strings.TrimSpace(s[:len(s)-1]))
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q (try 24h or 7d)", s)
+	}
+	return d, nil
+}
+
+// formatAgo renders how long ago t was, e.g. "3h ago".
+func formatAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// formatRemaining renders the time left before an expiry, e.g. "2d 4h left"
+func formatRemaining(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh left", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm left", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm left", max(1, int(d.Minutes())))
+	}
+}
+
+// syncTable rebuilds the table rows from the numbers and their contacts,
+// keeping the selection on the same number.
+func (wm *WhitelistManager) syncTable() {
+	rows := make([]components.TableRow, 0, len(wm.numbers))
+	for _, number := range wm.numbers {
+		contact := wm.contacts[number]
+		added := ""
+		if t, err := time.Parse(time.RFC3339, contact.AddedAt); err == nil {
+			added = t.Format("Jan 2, 2006")
+		}
+		expires := ""
+		if expiry, ok := contact.Expiry(); ok {
+			expires = formatRemaining(time.Until(expiry))
+		}
+		rows = append(rows, components.TableRow{Key: number, Cells: []string{
+			phone.Pretty(number), string(contact.EffectiveRole()), contact.Label, added, expires,
+		}})
+	}
+	wm.table.SetRows(rows)
+}
+
+// selectNumber moves the selection to number once it is in the list.
+func (wm *WhitelistManager) selectNumber(number string) {
+	wm.syncTable()
+	wm.table.Select(number)
+}
+
+// lessRole orders rows by increasing privilege.
+func (wm *WhitelistManager) lessRole(a, b components.TableRow) bool {
+	return slices.Index(status.Roles, wm.contacts[a.Key].EffectiveRole()) <
+		slices.Index(status.Roles, wm.contacts[b.Key].EffectiveRole())
+}
+
+// lessAdded orders rows oldest first. RFC 3339 times in one zone sort as
+// text.
+func (wm *WhitelistManager) lessAdded(a, b components.TableRow) bool {
+	return wm.contacts[a.Key].AddedAt < wm.contacts[b.Key].AddedAt
+}
+
+// lessExpiry orders rows by which expires first, permanent ones last.
+func (wm *WhitelistManager) lessExpiry(a, b components.TableRow) bool {
+	ea, okA := wm.contacts[a.Key].Expiry()
+	eb, okB := wm.contacts[b.Key].Expiry()
+	if okA != okB {
+		return okA
+	}
+	return ea.Before(eb)
+}
+
+// readWhitelistFile parses the whitelist JSON file.
+func readWhitelistFile() (WhitelistData, error) {
+	var whitelist WhitelistData
+	data, err := os.ReadFile(whitelistPath())
+	if err != nil {
+		return whitelist, err
+	}
+	if err = json.Unmarshal(data, &whitelist); err == nil {
+		migrateWhitelist(&whitelist)
+	}
+	return whitelist, err
+}
+
+// migrateWhitelist upgrades a file read from an older manager or bridge to
+// whitelistVersion. Newer files are left as they are.
+func migrateWhitelist(whitelist *WhitelistData) {
+	if whitelist.Version >= whitelistVersion {
+		return
+	}
+	// Version 3: files without a group list answered in every group
+	if whitelist.Groups == nil {
+		whitelist.Groups = &GroupAccess{}
+	}
+	whitelist.Version = whitelistVersion
+}
+
 // whitelistPath returns the path to the whitelist JSON file.
 // This must match the Docker volume mount: ./data:/app/data
 // The bridge reads from /app/data/whitelist.json inside the container.
@@ -73,36 +445,77 @@ func whitelistPath() string {
 // loadFromFile loads trusted numbers from the JSON file
 func (wm *WhitelistManager) loadFromFile() {
 	wm.numbers = []string{}
+	wm.contacts = map[string]status.TrustedContact{}
+	wm.loadedAt = ""
 
-	data, err := os.ReadFile(whitelistPath())
-	if err != nil {
+	whitelist, err := readWhitelistFile()
+	if errors.Is(err, os.ErrNotExist) {
 		// File doesn't exist yet, that's okay
 		return
 	}
-
-	var whitelist WhitelistData
-	if err := json.Unmarshal(data, &whitelist); err != nil {
+	if err != nil {
 		wm.message = "Failed to parse whitelist file"
 		wm.messageIsErr = true
 		return
 	}
 
+	wm.setFromFile(whitelist)
+}
+
+// setFromFile replaces the in-memory list with the file contents
+func (wm *WhitelistManager) setFromFile(whitelist WhitelistData) {
 	wm.numbers = whitelist.TrustedNumbers
+	if wm.numbers == nil {
+		wm.numbers = []string{}
+	}
+	wm.contacts = whitelist.Contacts
+	if wm.contacts == nil {
+		wm.contacts = map[string]status.TrustedContact{}
+	}
+	wm.groups = whitelist.Groups
+	wm.loadedAt = whitelist.UpdatedAt
+	wm.fileVersion = whitelist.Version
 	sort.Strings(wm.numbers)
 }
 
+// reloadIfChanged re-reads the file and reports whether someone else (the
+// bridge handling /trust, another manager) wrote it since we loaded it.
+func (wm *WhitelistManager) reloadIfChanged() bool {
+	whitelist, err := readWhitelistFile()
+	if err != nil || whitelist.UpdatedAt == wm.loadedAt {
+		return false
+	}
+	wm.setFromFile(whitelist)
+	wm.syncTable()
+	return true
+}
+
 // saveToFile writes the whitelist to JSON file
 func (wm *WhitelistManager) saveToFile() error {
+	if wm.fileVersion > whitelistVersion {
+		return fmt.Errorf("whitelist.json uses schema version %d; update the manager to edit it", wm.fileVersion)
+	}
+
 	// Ensure directory exists
 	dir := filepath.Dir(whitelistPath())
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
+	// Drop details for numbers that are no longer trusted
+	contacts := make(map[string]status.TrustedContact)
+	for _, n := range wm.numbers {
+		if c, ok := wm.contacts[n]; ok && c != (status.TrustedContact{}) {
+			contacts[n] = c
+		}
+	}
+
 	whitelist := WhitelistData{
 		TrustedNumbers: wm.numbers,
+		Contacts:       contacts,
+		Groups:         wm.groups,
 		UpdatedAt:      time.Now().Format(time.RFC3339),
-		Version:        1,
+		Version:        whitelistVersion,
 	}
 
 	data, err := json.MarshalIndent(whitelist, "", "  ")
@@ -110,7 +523,45 @@ func (wm *WhitelistManager) saveToFile() error {
 		return err
 	}
 
-	return os.WriteFile(whitelistPath(), data, 0644)
+	if err := os.WriteFile(whitelistPath(), data, 0644); err != nil {
+		return err
+	}
+	wm.loadedAt = whitelist.UpdatedAt
+	wm.fileVersion = whitelist.Version
+	wm.bridgeAck, wm.bridgeAcked = reloadBridgeWhitelist(wm.client)
+	return nil
+}
+
+// reloadBridgeWhitelist tells the bridge whitelist.json changed: through
+// the API when it answers, otherwise with SIGHUP, which it can't
+// acknowledge. It returns a line describing the outcome and whether the
+// bridge confirmed it.
+func reloadBridgeWhitelist(client *status.Client) (string, bool) {
+	if client != nil {
+		if n, err := client.ReloadWhitelist(); err == nil {
+			return fmt.Sprintf("Bridge reloaded the list and now trusts %d number(s)", n), true
+		}
+	}
+	if err := docker.SignalContainer("fetch-bridge", "HUP"); err != nil {
+		return "Bridge isn't running; it reads the list when it starts", false
+	}
+	return "Sent the bridge a reload signal; it didn't confirm (check the logs if the change doesn't apply)", false
+}
+
+// apiFailed handles an error from the bridge API. Conflicts reload the list
+// from the bridge; anything else means the bridge went away, so the manager
+// switches to file mode. It returns true if the caller should retry against
+// the file.
+func (wm *WhitelistManager) apiFailed(err error) bool {
+	if errors.Is(err, status.ErrWhitelistConflict) {
+		wm.load()
+		wm.message = "Whitelist changed on the bridge (e.g. via /trust) — list reloaded"
+		wm.messageIsErr = true
+		return false
+	}
+	wm.useAPI = false
+	wm.loadFromFile()
+	return true
 }
 
 // normalizeNumber removes non-digit characters from a phone number
@@ -124,15 +575,64 @@ func normalizeNumber(number string) string {
 	return result.String()
 }
 
+// parseNumber validates an international number and returns the digits
+// to store, or a user-facing reason when it can't be trusted. A country
+// code the manager doesn't know is accepted; unknown reports it so the
+// caller can ask for the number to be checked.
+func parseNumber(input string) (digits, reason string, unknown bool) {
+	n, unknown, err := phone.Lenient(input)
+	switch {
+	case errors.Is(err, phone.ErrEmpty):
+		return "", "Enter a number including its country code, e.g. +1 415 555 0132", false
+	case errors.Is(err, phone.ErrUnknownCountry):
+		return "", "Unknown country code — include it, e.g. +44 for the UK", false
+	case err != nil:
+		return "", "Invalid number: " + err.Error(), false
+	}
+	return n.Digits(), "", unknown
+}
+
+// unknownCountryNote is appended when a number with an unrecognised
+// country code is added.
+const unknownCountryNote = " (country code not recognised; check the number)"
+
 // addNumber adds a phone number to the whitelist
 func (wm *WhitelistManager) addNumber(number string) bool {
-	normalized := normalizeNumber(number)
-	if len(normalized) < 10 {
-		wm.message = "Number too short (need at least 10 digits)"
+	normalized, reason, unknown := parseNumber(number)
+	if reason != "" {
+		wm.message = reason
 		wm.messageIsErr = true
 		return false
 	}
 
+	contact := status.TrustedContact{AddedAt: time.Now().Format(time.RFC3339)}
+
+	if wm.useAPI {
+		err := wm.client.AddTrustedNumber(normalized, contact)
+		if err == nil {
+			// Show it at once; the reload brings the bridge's copy
+			if !slices.Contains(wm.numbers, normalized) {
+				wm.numbers = append(wm.numbers, normalized)
+				sort.Strings(wm.numbers)
+			}
+			wm.contacts[normalized] = contact
+			wm.load()
+			wm.selectNumber(normalized)
+			wm.message = "Added " + phone.Pretty(normalized)
+			if unknown {
+				wm.message += unknownCountryNote
+			}
+			wm.messageIsErr = false
+			return true
+		}
+		if !wm.apiFailed(err) {
+			return false
+		}
+	}
+
+	// File mode: pick up external edits before applying ours
+	conflict := wm.reloadIfChanged()
+
 	// Check if already exists
 	for _, n := range wm.numbers {
 		if n == normalized {
@@ -143,7 +643,9 @@ func (wm *WhitelistManager) addNumber(number string) bool {
 	}
 
 	wm.numbers = append(wm.numbers, normalized)
+	wm.contacts[normalized] = contact
 	sort.Strings(wm.numbers)
+	wm.selectNumber(normalized)
 
 	if err := wm.saveToFile(); err != nil {
 		wm.message = "Failed to save: " + err.Error()
@@ -151,23 +653,179 @@ func (wm *WhitelistManager) addNumber(number string) bool {
 		return false
 	}
 
-	wm.message = "Added +" + normalized
+	wm.message = "Added " + phone.Pretty(normalized)
+	if unknown {
+		wm.message += unknownCountryNote
+	}
+	if conflict {
+		wm.message += " (merged with changes made outside the manager)"
+	}
 	wm.messageIsErr = false
 	return true
 }
 
-// removeNumber removes the currently selected number
-func (wm *WhitelistManager) removeNumber() bool {
-	if len(wm.numbers) == 0 || wm.cursor >= len(wm.numbers) {
+// addEntries adds many numbers at once, e.g. from a paste or an import.
+// Numbers already trusted are skipped and invalid ones are counted. In file
+// mode everything is written in a single save.
+func (wm *WhitelistManager) addEntries(entries []contactEntry) {
+	var added, existing, invalid int
+	now := time.Now().Format(time.RFC3339)
+
+	if wm.useAPI {
+		for i, e := range entries {
+			normalized, reason, _ := parseNumber(e.Number)
+			if reason != "" {
+				invalid++
+				continue
+			}
+			if slices.Contains(wm.numbers, normalized) {
+				existing++
+				continue
+			}
+			if e.Contact.AddedAt == "" {
+				e.Contact.AddedAt = now
+			}
+			err := wm.client.AddTrustedNumber(normalized, e.Contact)
+			if errors.Is(err, status.ErrWhitelistConflict) {
+				existing++
+				continue
+			}
+			if err != nil {
+				// Bridge went away mid-import; finish the rest via the file
+				wm.useAPI = false
+				wm.loadFromFile()
+				entries = entries[i:]
+				break
+			}
+			wm.numbers = append(wm.numbers, normalized)
+			added++
+		}
+		if wm.useAPI {
+			wm.load()
+			wm.reportBulk(added, existing, invalid)
+			return
+		}
+	}
+
+	wm.reloadIfChanged()
+	for _, e := range entries {
+		normalized, reason, _ := parseNumber(e.Number)
+		if reason != "" {
+			invalid++
+			continue
+		}
+		if slices.Contains(wm.numbers, normalized) {
+			existing++
+			continue
+		}
+		if e.Contact.AddedAt == "" {
+			e.Contact.AddedAt = now
+		}
+		wm.numbers = append(wm.numbers, normalized)
+		wm.contacts[normalized] = e.Contact
+		added++
+	}
+	sort.Strings(wm.numbers)
+	wm.syncTable()
+
+	if added > 0 {
+		if err := wm.saveToFile(); err != nil {
+			wm.message = "Failed to save: " + err.Error()
+			wm.messageIsErr = true
+			return
+		}
+	}
+	wm.reportBulk(added, existing, invalid)
+}
+
+// reportBulk summarises the outcome of addEntries
+func (wm *WhitelistManager) reportBulk(added, existing, invalid int) {
+	wm.message = fmt.Sprintf("Added %d number(s)", added)
+	if existing > 0 {
+		wm.message += fmt.Sprintf(", %d already trusted", existing)
+	}
+	if invalid > 0 {
+		wm.message += fmt.Sprintf(", %d invalid", invalid)
+	}
+	wm.messageIsErr = added == 0 && (existing > 0 || invalid > 0)
+}
+
+// importFrom adds the numbers in a CSV or vCard file
+func (wm *WhitelistManager) importFrom(path string) {
+	entries, err := importContacts(resolvePath(path))
+	if err != nil {
+		wm.message = "Import failed: " + err.Error()
+		wm.messageIsErr = true
+		return
+	}
+	if len(entries) == 0 {
+		wm.message = "No phone numbers found in " + path
+		wm.messageIsErr = true
+		return
+	}
+	wm.addEntries(entries)
+}
+
+// exportTo writes the current list to a CSV or vCard file
+func (wm *WhitelistManager) exportTo(path string) {
+	resolved := resolvePath(path)
+	if err := exportContacts(resolved, wm.numbers, wm.contacts); err != nil {
+		wm.message = "Export failed: " + err.Error()
+		wm.messageIsErr = true
+		return
+	}
+	wm.message = fmt.Sprintf("Exported %d number(s) to %s", len(wm.numbers), resolved)
+	wm.messageIsErr = false
+}
+
+// confirmRemove asks before removing the selected number
+func (wm *WhitelistManager) confirmRemove() {
+	number := wm.selected()
+	if number == "" {
+		return
+	}
+	who := phone.Pretty(number)
+	if label := wm.contacts[number].Label; label != "" {
+		who = label + " (" + who + ")"
+	}
+	wm.confirm = components.NewConfirm("Remove trusted number",
+		"Remove "+who+"? They will no longer be able to use @fetch.", "Remove")
+	wm.pendingRemove = number
+}
+
+// removeNumber removes a trusted number
+func (wm *WhitelistManager) removeNumber(removed string) bool {
+	if removed == "" {
 		return false
 	}
 
-	removed := wm.numbers[wm.cursor]
-	wm.numbers = append(wm.numbers[:wm.cursor], wm.numbers[wm.cursor+1:]...)
+	if wm.useAPI {
+		err := wm.client.RemoveTrustedNumber(removed)
+		if err == nil {
+			wm.numbers = slices.DeleteFunc(wm.numbers, func(n string) bool { return n == removed })
+			delete(wm.contacts, removed)
+			wm.syncTable()
+			wm.load()
+			wm.message = "Removed " + phone.Pretty(removed)
+			wm.messageIsErr = false
+			return true
+		}
+		if !wm.apiFailed(err) {
+			return false
+		}
+	}
 
-	if wm.cursor >= len(wm.numbers) && wm.cursor > 0 {
-		wm.cursor--
+	// File mode: pick up external edits, then remove by value
+	conflict := wm.reloadIfChanged()
+	idx := sort.SearchStrings(wm.numbers, removed)
+	if idx >= len(wm.numbers) || wm.numbers[idx] != removed {
+		wm.message = "+" + removed + " was already removed outside the manager"
+		wm.messageIsErr = true
+		return false
 	}
+	wm.numbers = append(wm.numbers[:idx], wm.numbers[idx+1:]...)
+	delete(wm.contacts, removed)
+	wm.syncTable()
 
 	if err := wm.saveToFile(); err != nil {
 		wm.message = "Failed to save: " + err.Error()
@@ -175,59 +833,373 @@ func (wm *WhitelistManager) removeNumber() bool {
 		return false
 	}
 
-	wm.message = "Removed +" + removed
+	wm.message = "Removed " + phone.Pretty(removed)
+	if conflict {
+		wm.message += " (merged with changes made outside the manager)"
+	}
 	wm.messageIsErr = false
 	return true
 }
 
-// Update handles keyboard input
-func (wm *WhitelistManager) Update(msg tea.KeyMsg) {
-	if wm.adding {
-		switch msg.String() {
-		case "enter":
-			if wm.addBuffer != "" {
-				wm.addNumber(wm.addBuffer)
-			}
-			wm.adding = false
-			wm.addBuffer = ""
-		case "esc":
-			wm.adding = false
-			wm.addBuffer = ""
-		case "backspace":
-			if len(wm.addBuffer) > 0 {
-				wm.addBuffer = wm.addBuffer[:len(wm.addBuffer)-1]
-			}
-		default:
-			// Only accept digits and common phone characters
-			for _, r := range msg.String() {
-				if (r >= '0' && r <= '9') || r == '+' || r == '-' || r == ' ' || r == '(' || r == ')' {
-					wm.addBuffer += string(r)
-				}
+// selected returns the selected number, or "" if the list is empty
+func (wm *WhitelistManager) selected() string {
+	return wm.table.SelectedKey()
+}
+
+// setField applies an edited label or note to the selected number
+func (wm *WhitelistManager) setField(field inputMode, value string) bool {
+	value = strings.TrimSpace(value)
+	if field == inputLabel {
+		return wm.updateContact("Label", func(c status.TrustedContact) status.TrustedContact {
+			c.Label = value
+			return c
+		})
+	}
+	return wm.updateContact("Note", func(c status.TrustedContact) status.TrustedContact {
+		c.Note = value
+		return c
+	})
+}
+
+// setExpiry makes the selected number temporary. A blank duration removes
+// the expiry.
+func (wm *WhitelistManager) setExpiry(value string) bool {
+	expiresAt := ""
+	if strings.TrimSpace(value) != "" {
+		d, err := parseExpiry(value)
+		if err != nil {
+			wm.message = err.Error()
+			wm.messageIsErr = true
+			return false
+		}
+		expiresAt = time.Now().Add(d).Format(time.RFC3339)
+	}
+	return wm.updateContact("Expiry", func(c status.TrustedContact) status.TrustedContact {
+		c.ExpiresAt = expiresAt
+		return c
+	})
+}
+
+// setRole changes the permission level of the selected number
+func (wm *WhitelistManager) setRole(role status.Role) bool {
+	return wm.updateContact("Role", func(c status.TrustedContact) status.TrustedContact {
+		c.Role = role
+		return c
+	})
+}
+
+// updateContact applies a change to the selected number's details
+func (wm *WhitelistManager) updateContact(what string, apply func(status.TrustedContact) status.TrustedContact) bool {
+	number := wm.selected()
+	if number == "" {
+		return false
+	}
+
+	if wm.useAPI {
+		updated := apply(wm.contacts[number])
+		err := wm.client.UpdateTrustedContact(number, updated)
+		if err == nil {
+			wm.contacts[number] = updated
+			wm.syncTable()
+			wm.load()
+			wm.message = what + " updated for +" + number
+			wm.messageIsErr = false
+			return true
+		}
+		if !wm.apiFailed(err) {
+			return false
+		}
+	}
+
+	wm.reloadIfChanged()
+	idx := sort.SearchStrings(wm.numbers, number)
+	if idx >= len(wm.numbers) || wm.numbers[idx] != number {
+		wm.message = "+" + number + " was removed outside the manager"
+		wm.messageIsErr = true
+		return false
+	}
+	wm.contacts[number] = apply(wm.contacts[number])
+	wm.syncTable()
+
+	if err := wm.saveToFile(); err != nil {
+		wm.message = "Failed to save: " + err.Error()
+		wm.messageIsErr = true
+		return false
+	}
+
+	wm.message = what + " updated for +" + number
+	wm.messageIsErr = false
+	return true
+}
+
+// startInput enters an editing mode, pre-filling the buffer when editing
+// an existing field
+func (wm *WhitelistManager) startInput(mode inputMode) {
+	wm.input = mode
+	wm.inputBuffer = ""
+	wm.message = ""
+	c := wm.contacts[wm.selected()]
+	field := components.FormField{Key: "value", Help: "Enter to confirm, Esc to cancel"}
+	switch mode {
+	case inputRole:
+		wm.roleCursor = 0
+		for i, r := range status.Roles {
+			if r == c.EffectiveRole() {
+				wm.roleCursor = i
 			}
 		}
 		return
+	case inputBulk:
+		return
+	case inputNumber:
+		field.Label = "Add number"
+		// Only accept digits and common phone characters
+		field.Filter = func(r rune) bool {
+			return (r >= '0' && r <= '9') || r == '+' || r == '-' || r == ' ' || r == '(' || r == ')'
+		}
+	case inputLabel:
+		field.Label, field.Value = "Label for +"+wm.selected(), c.Label
+	case inputNote:
+		field.Label, field.Value = "Note for +"+wm.selected(), c.Note
+	case inputImport:
+		field.Label, field.Value = "Import from (.csv or .vcf)", defaultExportPath
+	case inputExport:
+		field.Label, field.Value = "Export to (.csv or .vcf)", defaultExportPath
+	case inputExpiry:
+		field.Label = "Trust +" + wm.selected() + " for (e.g. 24h, 7d; blank = forever)"
+	}
+	wm.inputForm = components.NewForm(field)
+	if mode == inputNumber {
+		wm.inputForm.Notes = numberFeedback
 	}
+	wm.inputForm.Open()
+}
 
+// updateRolePicker handles keys while the role picker is open
+func (wm *WhitelistManager) updateRolePicker(msg tea.KeyMsg) {
 	switch msg.String() {
 	case "up", "k":
-		if wm.cursor > 0 {
-			wm.cursor--
+		if wm.roleCursor > 0 {
+			wm.roleCursor--
 		}
 	case "down", "j":
-		if wm.cursor < len(wm.numbers)-1 {
-			wm.cursor++
+		if wm.roleCursor < len(status.Roles)-1 {
+			wm.roleCursor++
+		}
+	case "enter":
+		wm.setRole(status.Roles[wm.roleCursor])
+		wm.input = inputNone
+	case "esc":
+		wm.input = inputNone
+	}
+}
+
+// updateInput handles keys while a field is being edited
+func (wm *WhitelistManager) updateInput(msg tea.KeyMsg) {
+	switch wm.input {
+	case inputRole:
+		wm.updateRolePicker(msg)
+		return
+	case inputBulk:
+		wm.updateBulk(msg)
+		return
+	}
+
+	if wm.input == inputNumber && msg.Paste && len(parseNumberBlock(string(msg.Runes))) > 1 {
+		// Several numbers pasted into the add prompt go to bulk add
+		pasted := wm.inputForm.Value("value") + string(msg.Runes)
+		wm.startInput(inputBulk)
+		wm.inputBuffer = pasted
+		return
+	}
+
+	switch wm.inputForm.Update(msg) {
+	case components.FormCancelled:
+		wm.input = inputNone
+	case components.FormCommitted:
+		value := wm.inputForm.Value("value")
+		switch wm.input {
+		case inputNumber:
+			if value != "" && wm.addNumber(value) {
+				// Offer a label straight away; Esc skips it
+				wm.startInput(inputLabel)
+				wm.message = "Added " + phone.Pretty(wm.selected()) + " — enter a label or press Esc"
+				return
+			}
+		case inputLabel, inputNote:
+			wm.setField(wm.input, value)
+		case inputImport:
+			wm.importFrom(value)
+		case inputExport:
+			wm.exportTo(value)
+		case inputExpiry:
+			wm.setExpiry(value)
 		}
+		wm.input = inputNone
+	}
+}
+
+// updateBulk handles keys in the bulk paste box, which keeps line breaks
+// and so isn't a form field.
+func (wm *WhitelistManager) updateBulk(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "enter":
+		wm.addEntries(parseNumberBlock(wm.inputBuffer))
+		wm.input = inputNone
+		wm.inputBuffer = ""
+	case "esc":
+		wm.input = inputNone
+		wm.inputBuffer = ""
+	default:
+		switch msg.Type {
+		case tea.KeyBackspace:
+			if r := []rune(wm.inputBuffer); len(r) > 0 {
+				wm.inputBuffer = string(r[:len(r)-1])
+			}
+		case tea.KeySpace:
+			wm.inputBuffer += " "
+		case tea.KeyRunes:
+			// Pasted text arrives as a single message, newlines included
+			wm.inputBuffer += string(msg.Runes)
+		}
+	}
+}
+
+// numberFeedback gives live feedback on the country and validity of the
+// number being added.
+func numberFeedback(field components.FormField, _ bool) []string {
+	if field.Value == "" {
+		return nil
+	}
+	n, err := phone.Parse(field.Value)
+	switch {
+	case err == nil:
+		return []string{whitelistSuccessStyle().Render(n.Flag() + " " + n.Country + " · " + n.Format())}
+	case errors.Is(err, phone.ErrUnknownCountry):
+		if n, unknown, err := phone.Lenient(field.Value); err == nil && unknown {
+			return []string{whitelistWarningStyle().Render(n.Format() + " · country code not recognised; check the number")}
+		}
+	case n.Country != "":
+		return []string{whitelistHelpStyle().Render(n.Flag() + " " + n.Country + " · " + err.Error())}
+	}
+	return []string{whitelistHelpStyle().Render("Include the country code, e.g. +1 or +44")}
+}
+
+// Update handles a fetched list and keyboard input, returning the fetch a
+// change queued, if any.
+func (wm *WhitelistManager) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case WhitelistLoadedMsg:
+		wm.loaded(msg)
+	case tea.KeyMsg:
+		wm.handleKey(msg)
+	}
+	return wm.takeLoad()
+}
+
+// handleKey handles keyboard input
+func (wm *WhitelistManager) handleKey(msg tea.KeyMsg) {
+	if wm.confirm != nil {
+		switch wm.confirm.Update(msg) {
+		case components.ConfirmYes:
+			wm.removeNumber(wm.pendingRemove)
+		case components.ConfirmPending:
+			return
+		}
+		wm.confirm = nil
+		wm.pendingRemove = ""
+		return
+	}
+
+	if wm.input != inputNone {
+		wm.updateInput(msg)
+		return
+	}
+
+	// Pasting on the list adds what was pasted: one number opens it in
+	// the add prompt, several open bulk add
+	if msg.Paste {
+		if len(parseNumberBlock(string(msg.Runes))) > 1 {
+			wm.startInput(inputBulk)
+			wm.inputBuffer = string(msg.Runes)
+			return
+		}
+		wm.startInput(inputNumber)
+		wm.inputForm.Update(msg)
+		return
+	}
+
+	if wm.table.Update(msg) {
+		return
+	}
+
+	switch msg.String() {
 	case "a":
-		wm.adding = true
-		wm.addBuffer = ""
-		wm.message = ""
+		wm.startInput(inputNumber)
+	case "l", "e":
+		if wm.selected() != "" {
+			wm.startInput(inputLabel)
+		}
+	case "n":
+		if wm.selected() != "" {
+			wm.startInput(inputNote)
+		}
+	case "p":
+		if wm.selected() != "" {
+			wm.startInput(inputRole)
+		}
+	case "t":
+		if wm.selected() != "" {
+			wm.startInput(inputExpiry)
+		}
+	case "b":
+		wm.startInput(inputBulk)
+	case "i":
+		wm.startInput(inputImport)
+	case "x":
+		wm.startInput(inputExport)
 	case "d", "delete", "backspace":
-		wm.removeNumber()
+		wm.confirmRemove()
 	case "r":
-		wm.loadFromFile()
-		wm.message = "Refreshed from file"
-		wm.messageIsErr = false
+		wm.announceSrc = true
+		wm.load()
+	}
+}
+
+// SetWidth sets the columns the view must fit in.
+func (wm *WhitelistManager) SetWidth(width int) {
+	wm.width = width
+}
+
+// helpLine renders muted help text indented under the title, wrapped to
+// the width.
+func (wm *WhitelistManager) helpLine(text string) string {
+	style := whitelistHelpStyle().PaddingLeft(3)
+	if wm.width > 0 {
+		style = style.Width(wm.width)
+	}
+	return style.Render(text)
+}
+
+// keyLines packs key hints onto as few lines as fit the width.
+func (wm *WhitelistManager) keyLines(hints ...string) string {
+	var lines []string
+	line := ""
+	for _, hint := range hints {
+		if line != "" && wm.width > 0 && lipgloss.Width("   "+line+"  "+hint) > wm.width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += "  "
+		}
+		line += hint
+	}
+	lines = append(lines, line)
+	for i, line := range lines {
+		lines[i] = whitelistHelpStyle().Render("   " + line)
 	}
+	return strings.Join(lines, "\n")
 }
 
 // View renders the whitelist manager
@@ -236,56 +1208,169 @@ func (wm *WhitelistManager) View() string {
 
 	s.WriteString("🔐 ")
 	s.WriteString(lipgloss.NewStyle().Bold(true).Render("Zero Trust Bonding - Trusted Numbers"))
+	s.WriteString("\n")
+	switch {
+	case wm.loading && wm.numbers == nil:
+		s.WriteString(wm.helpLine("Loading from the bridge…"))
+	case wm.useAPI:
+		s.WriteString(wm.helpLine("Source: bridge API (changes apply immediately)"))
+	case wm.bridgeAcked:
+		s.WriteString(wm.helpLine("Source: data/whitelist.json"))
+		s.WriteString("\n" + whitelistSuccessStyle().Render("   ● "+wm.bridgeAck))
+	case wm.bridgeAck != "":
+		s.WriteString(wm.helpLine("Source: data/whitelist.json"))
+		s.WriteString("\n" + whitelistWarningStyle().Render("   ○ "+wm.bridgeAck))
+	default:
+		s.WriteString(wm.helpLine("Source: data/whitelist.json (the bridge is told to reload after each change)"))
+	}
 	s.WriteString("\n\n")
 
-	if wm.adding {
-		s.WriteString(whitelistFocusedStyle.Render("Add number: "))
-		s.WriteString(whitelistNumberStyle.Render(wm.addBuffer + "█"))
+	if wm.input == inputRole {
+		s.WriteString(whitelistFocusedStyle().Render("Permissions for +" + wm.selected() + ":"))
 		s.WriteString("\n")
-		s.WriteString(whitelistHelpStyle.Render("Enter to confirm, Esc to cancel"))
+		for i, r := range status.Roles {
+			marker := "   "
+			if i == wm.roleCursor {
+				marker = whitelistFocusedStyle().Render(" ▶ ")
+			}
+			s.WriteString(marker)
+			s.WriteString(whitelistRoleStyle(r).Width(7).Render(string(r)))
+			s.WriteString(whitelistHelpStyle().Render(r.Description()))
+			s.WriteString("\n")
+		}
+		s.WriteString(whitelistHelpStyle().Render("↑/↓ to choose, Enter to apply, Esc to cancel"))
 		s.WriteString("\n\n")
+	} else if wm.input != inputNone {
+		if wm.input == inputBulk {
+			s.WriteString(whitelistFocusedStyle().Render("Paste numbers (one per line or comma-separated):"))
+			s.WriteString("\n")
+			s.WriteString(whitelistNumberStyle().Render(wm.inputBuffer + "█"))
+			s.WriteString("\n")
+			s.WriteString(whitelistHelpStyle().Render(fmt.Sprintf("%d number(s) detected", len(parseNumberBlock(wm.inputBuffer)))))
+			s.WriteString("\n")
+			s.WriteString(whitelistHelpStyle().Render("Enter to confirm, Esc to cancel"))
+			s.WriteString("\n")
+		} else {
+			s.WriteString(wm.inputForm.View())
+		}
+		s.WriteString("\n")
 	}
 
 	if len(wm.numbers) == 0 {
-		s.WriteString(whitelistHelpStyle.Render("   No trusted numbers configured."))
+		s.WriteString(whitelistHelpStyle().Render("   No trusted numbers configured."))
 		s.WriteString("\n")
-		s.WriteString(whitelistHelpStyle.Render("   Only the owner can use @fetch."))
+		s.WriteString(whitelistHelpStyle().Render("   Only the owner can use @fetch."))
 		s.WriteString("\n\n")
 	} else {
-		for i, number := range wm.numbers {
-			prefix := "   "
-			if i == wm.cursor && !wm.adding {
-				prefix = whitelistFocusedStyle.Render("▶ ")
-			}
-			s.WriteString(prefix)
-			s.WriteString(whitelistLabelStyle.Render(string(rune('1'+i)) + "."))
-			s.WriteString(" ")
-			s.WriteString(whitelistNumberStyle.Render("+" + number))
-			s.WriteString("\n")
-		}
+		s.WriteString(lipgloss.NewStyle().MarginLeft(3).Render(wm.table.View()))
 		s.WriteString("\n")
+		s.WriteString(wm.viewSelected())
+		s.WriteString("\n")
+		if wm.input == inputNone && wm.confirm == nil {
+			s.WriteString(wm.viewActivity(wm.selected()))
+		}
 	}
 
 	// Message area
 	if wm.message != "" {
 		if wm.messageIsErr {
-			s.WriteString(whitelistErrorStyle.Render("   ❌ " + wm.message))
+			s.WriteString(whitelistErrorStyle().Render("   ❌ " + wm.message))
 		} else {
-			s.WriteString(whitelistSuccessStyle.Render("   ✅ " + wm.message))
+			s.WriteString(whitelistSuccessStyle().Render("   ✅ " + wm.message))
 		}
 		s.WriteString("\n")
 	}
 
-	// Help
+	if wm.confirm != nil {
+		s.WriteString("\n" + lipgloss.NewStyle().MarginLeft(3).Render(wm.confirm.View(60)))
+		return s.String()
+	}
+
+	// Help; compact terminals leave the keys to the help bar and overlay
 	s.WriteString("\n")
-	s.WriteString(whitelistHelpStyle.Render("   [a] Add  [d] Delete  [r] Refresh  [esc] Back"))
+	if wm.width == 0 || !layout.IsCompact(wm.width) {
+		s.WriteString(wm.keyLines("[a] Add", "[l] Label", "[n] Note", "[p] Permissions", "[d] Delete", "[r] Refresh", "[esc] Back"))
+		s.WriteString("\n")
+		s.WriteString(wm.keyLines("[t] Temporary", "[b] Bulk paste", "[i] Import", "[x] Export", "[g] Groups"))
+		s.WriteString("\n")
+		s.WriteString(wm.keyLines("[s] Sort by next column", "[S] Reverse", "("+wm.table.SortHint()+")"))
+		s.WriteString("\n")
+	}
+	s.WriteString(wm.helpLine("Changes sync with WhatsApp /trust commands"))
+
+	return s.String()
+}
+
+// viewSelected renders what the table leaves out for the selected
+// number: its country and note.
+func (wm *WhitelistManager) viewSelected() string {
+	number := wm.selected()
+	if number == "" {
+		return ""
+	}
+	var s strings.Builder
+	if n, err := phone.Parse(number); err == nil {
+		s.WriteString("   " + n.Flag() + " " + whitelistHelpStyle().Render(n.Country+" · "+n.Format()))
+		s.WriteString("\n")
+	}
+	if note := wm.contacts[number].Note; note != "" {
+		s.WriteString(wm.helpLine("Note: " + note))
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// activityRecent is how many of a number's latest commands and tasks are
+// shown under the list.
+const activityRecent = 3
+
+// viewActivity renders what the selected number did, so the owner can
+// audit the access they granted.
+func (wm *WhitelistManager) viewActivity(number string) string {
+	var s strings.Builder
+	s.WriteString(whitelistHelpStyle().Render("   ─── Activity of " + phone.Pretty(number) + " ───"))
 	s.WriteString("\n")
-	s.WriteString(whitelistHelpStyle.Render("   Changes sync with WhatsApp /trust commands"))
 
+	a, ok := wm.activity[number]
+	switch {
+	case wm.client == nil || wm.activityErr != nil:
+		s.WriteString(wm.helpLine("Unavailable: the bridge keeps the activity log and isn't reachable"))
+		s.WriteString("\n\n")
+		return s.String()
+	case !ok || a.Messages == 0:
+		s.WriteString(whitelistHelpStyle().Render("   No messages from this number yet"))
+		s.WriteString("\n\n")
+		return s.String()
+	}
+
+	s.WriteString("   " + whitelistContactStyle().Render("Last seen "+formatAgo(a.LastSeen)))
+	s.WriteString(whitelistHelpStyle().Render(fmt.Sprintf(" · %d message(s) · %d task(s) started · since %s",
+		a.Messages, a.TasksStarted, a.FirstSeen.Local().Format("Jan 2, 2006"))))
+	s.WriteString("\n")
+	if commands := a.TopCommands(); len(commands) > 0 {
+		parts := make([]string, 0, len(commands))
+		for _, c := range commands {
+			parts = append(parts, fmt.Sprintf("%s ×%d", c.Name, c.Count))
+		}
+		s.WriteString(wm.helpLine("Commands: " + strings.Join(parts, ", ")))
+		s.WriteString("\n")
+	}
+	for i := len(a.Recent) - 1; i >= 0 && i >= len(a.Recent)-activityRecent; i-- {
+		e := a.Recent[i]
+		icon := "›"
+		if e.Kind == "task" {
+			icon = "⚙"
+		}
+		s.WriteString(whitelistHelpStyle().Render(fmt.Sprintf("   %s %s  ", icon, e.At.Local().Format("Jan 2 15:04"))))
+		s.WriteString(whitelistNumberStyle().Render(e.Detail))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
 	return s.String()
 }
 
-// IsAdding returns true if currently in add mode
-func (wm *WhitelistManager) IsAdding() bool {
-	return wm.adding
+// IsEditing returns true while a field is being edited or the role
+// picker is open
+func (wm *WhitelistManager) IsEditing() bool {
+	return wm.input != inputNone || wm.confirm != nil
 }
@@ -0,0 +1,230 @@
+// Package config provides a TUI-based configuration editor for Fetch.
+// This file handles importing and exporting trusted numbers as CSV and vCard.
+package config
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/phone"
+	"github.com/fetch/manager/internal/status"
+)
+
+// defaultExportPath is offered when importing or exporting, relative to
+// the project directory.
+const defaultExportPath = "data/trusted-numbers.csv"
+
+// csvHeader is the column layout used for CSV export and recognised on import.
+var csvHeader = []string{"number", "label", "note", "role", "addedAt", "expiresAt"}
+
+// contactEntry is a number with its details, as read from an import source.
+type contactEntry struct {
+	Number  string
+	Contact status.TrustedContact
+}
+
+// resolvePath makes relative paths relative to the project directory and
+// expands a leading ~.
+func resolvePath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(paths.ProjectDir, path)
+	}
+	return path
+}
+
+// isVCard reports whether the path should be treated as a vCard file.
+func isVCard(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".vcf" || ext == ".vcard"
+}
+
+// parseNumberBlock extracts phone numbers from free-form text such as a
+// pasted list. Numbers may be separated by newlines, commas, or semicolons.
+func parseNumberBlock(text string) []contactEntry {
+	var entries []contactEntry
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == ',' || r == ';' || r == '\t'
+	})
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			entries = append(entries, contactEntry{Number: f})
+		}
+	}
+	return entries
+}
+
+// importContacts reads numbers from a CSV or vCard file.
+func importContacts(path string) ([]contactEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if isVCard(path) {
+		return parseVCard(f)
+	}
+	return parseCSV(f)
+}
+
+// parseCSV reads entries from CSV. A header row matching csvHeader names is
+// optional; without one the columns are assumed to be in csvHeader order.
+func parseCSV(r io.Reader) ([]contactEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range csvHeader {
+		columns[strings.ToLower(name)] = i
+	}
+	if normalizeNumber(records[0][0]) == "" {
+		columns = map[string]int{}
+		for i, name := range records[0] {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		records = records[1:]
+	}
+
+	get := func(rec []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var entries []contactEntry
+	for _, rec := range records {
+		number := get(rec, "number")
+		if number == "" {
+			number = get(rec, "phone")
+		}
+		if number == "" {
+			continue
+		}
+		entries = append(entries, contactEntry{
+			Number: number,
+			Contact: status.TrustedContact{
+				Label:     get(rec, "label"),
+				Note:      get(rec, "note"),
+				Role:      status.Role(get(rec, "role")),
+				AddedAt:   get(rec, "addedat"),
+				ExpiresAt: get(rec, "expiresat"),
+			},
+		})
+	}
+	return entries, nil
+}
+
+// parseVCard reads entries from a vCard file. Every TEL line becomes an
+// entry labelled with the card's FN; NOTE lines are kept as the note.
+func parseVCard(r io.Reader) ([]contactEntry, error) {
+	var entries []contactEntry
+	var name, note string
+	var tels []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Strip parameters such as TEL;TYPE=CELL
+		prop, _, _ := strings.Cut(strings.ToUpper(key), ";")
+
+		switch prop {
+		case "BEGIN":
+			name, note, tels = "", "", nil
+		case "FN":
+			name = vcardUnescape(value)
+		case "NOTE":
+			note = vcardUnescape(value)
+		case "TEL":
+			tels = append(tels, strings.TrimPrefix(value, "tel:"))
+		case "END":
+			for _, tel := range tels {
+				entries = append(entries, contactEntry{
+					Number:  tel,
+					Contact: status.TrustedContact{Label: name, Note: note},
+				})
+			}
+			tels = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid vCard: %w", err)
+	}
+	return entries, nil
+}
+
+var (
+	vcardEscaper   = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`)
+	vcardUnescaper = strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, "\n", `\N`, "\n")
+)
+
+func vcardUnescape(s string) string {
+	return vcardUnescaper.Replace(s)
+}
+
+// exportContacts writes the numbers and their details to a CSV or vCard file.
+func exportContacts(path string, numbers []string, contacts map[string]status.TrustedContact) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	sorted := append([]string(nil), numbers...)
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	if isVCard(path) {
+		for _, n := range sorted {
+			c := contacts[n]
+			name := c.Label
+			if name == "" {
+				name = phone.Pretty(n)
+			}
+			b.WriteString("BEGIN:VCARD\r\nVERSION:3.0\r\n")
+			b.WriteString("FN:" + vcardEscaper.Replace(name) + "\r\n")
+			b.WriteString("TEL;TYPE=CELL:+" + n + "\r\n")
+			if c.Note != "" {
+				b.WriteString("NOTE:" + vcardEscaper.Replace(c.Note) + "\r\n")
+			}
+			b.WriteString("END:VCARD\r\n")
+		}
+	} else {
+		w := csv.NewWriter(&b)
+		_ = w.Write(csvHeader)
+		for _, n := range sorted {
+			c := contacts[n]
+			_ = w.Write([]string{phone.Pretty(n), c.Label, c.Note, string(c.Role), c.AddedAt, c.ExpiresAt})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
@@ -0,0 +1,80 @@
+// This file holds the demo's canned log lines and models.
+package demo
+
+import "github.com/fetch/manager/internal/models"
+
+var bridgeMessages = []string{
+	"Message from Owner: \"what's failing in CI on fetch-web?\"",
+	"Message from Owner: \"add a dark mode toggle to the settings page\"",
+	"Message from Sam: \"@fetch summarize yesterday's PRs\"",
+	"Message from Owner: \"run the tests in api-server\"",
+	"Message from Priya: \"@fetch is the deploy done?\"",
+	"Message from Owner: \"open a PR for the login fix\"",
+}
+
+var bridgeInfo = []string{
+	"Task tsk_7QmX2a started (claude) in fetch-web",
+	"Task tsk_7QmX2a completed in 48s",
+	"Task tsk_9LpR4c started (copilot) in api-server",
+	"Task tsk_9LpR4c completed in 1m12s",
+	"Reply sent to Owner (312 chars)",
+	"Reply sent to Sam (1,024 chars)",
+	"Compacted conversation history: 41 → 12 messages",
+	"Repo map refreshed for fetch-web (214 files)",
+}
+
+var bridgeDebug = []string{
+	"Routing intent: task (confidence 0.92)",
+	"Routing intent: chat (confidence 0.87)",
+	"Tool call: read_file src/settings/Theme.tsx",
+	"Tool call: run_tests api-server",
+	"Progress update throttled (2 pending)",
+}
+
+var bridgeWarnings = []string{
+	"OpenRouter responded slowly (6.2s); retrying with backoff",
+	"Rate limit: Sam sent 5 messages in 60s, slowing down",
+}
+
+var bridgeErrors = []string{
+	"Harness copilot exited with code 1: authentication required",
+}
+
+var kennelInfo = []string{
+	"claude: editing src/settings/Theme.tsx",
+	"claude: running npm test (38 passed)",
+	"copilot: git checkout -b fix/login-redirect",
+	"copilot: git push origin fix/login-redirect",
+	"gemini: reading docs/ARCHITECTURE.md",
+}
+
+var toolsAndMore = []string{"tools", "tool_choice", "temperature", "max_tokens"}
+
+var demoModels = []models.Model{
+	demoModel("anthropic/claude-sonnet-4", "Anthropic: Claude Sonnet 4", 200000, "0.000003", "0.000015", true, "text", "image"),
+	demoModel("anthropic/claude-3.5-haiku", "Anthropic: Claude 3.5 Haiku", 200000, "0.0000008", "0.000004", true, "text", "image"),
+	demoModel("google/gemini-2.5-flash", "Google: Gemini 2.5 Flash", 1048576, "0.0000003", "0.0000025", true, "text", "image", "audio"),
+	demoModel("google/gemini-2.5-pro", "Google: Gemini 2.5 Pro", 1048576, "0.00000125", "0.00001", true, "text", "image"),
+	demoModel("meta-llama/llama-3.3-70b-instruct", "Meta: Llama 3.3 70B Instruct", 131072, "0.00000013", "0.0000004", true, "text"),
+	demoModel("mistralai/mistral-small-3.2-24b-instruct", "Mistral: Mistral Small 3.2 24B", 131072, "0.00000005", "0.0000001", true, "text", "image"),
+	demoModel("openai/gpt-4o-mini", "OpenAI: GPT-4o-mini", 128000, "0.00000015", "0.0000006", true, "text", "image"),
+	demoModel("openai/gpt-4.1", "OpenAI: GPT-4.1", 1047576, "0.000002", "0.000008", true, "text", "image"),
+	demoModel("qwen/qwen3-coder:free", "Qwen: Qwen3 Coder (free)", 262144, "0", "0", true, "text"),
+	demoModel("sao10k/l3-euryale-70b", "Sao10K: Llama 3 Euryale 70B", 8192, "0.00000148", "0.00000148", false, "text"),
+}
+
+func demoModel(id, name string, context int, prompt, completion string, tools bool, inputs ...string) models.Model {
+	m := models.Model{
+		ID:            id,
+		Name:          name,
+		ContextLength: context,
+		Pricing:       models.Pricing{Prompt: prompt, Completion: completion},
+		Architecture:  models.Architecture{Modality: "text->text", InputModalities: inputs, OutputModalities: []string{"text"}},
+	}
+	if tools {
+		m.SupportedParameters = toolsAndMore
+	} else {
+		m.SupportedParameters = []string{"temperature", "max_tokens"}
+	}
+	return m
+}
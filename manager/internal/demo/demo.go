@@ -0,0 +1,272 @@
+// Package demo makes up a plausible Fetch deployment: containers, the
+// bridge's status, logs, diagnostics, models, and releases. The manager
+// shows it instead of real data in demo mode, for screenshots, recordings,
+// and tests without Docker or WhatsApp. The same seed gives the same world,
+// and its clock starts at Epoch and moves one step per read rather than
+// with the wall clock, so output doesn't depend on when or how fast it
+// runs.
+package demo
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/fetch/manager/internal/doctor"
+	"github.com/fetch/manager/internal/health"
+	"github.com/fetch/manager/internal/models"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/update"
+)
+
+// Epoch is when the demo's containers started.
+var Epoch = time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC)
+
+// step is how far the demo clock moves per read, matching the fastest
+// status poll.
+const step = 2 * time.Second
+
+// maxLines caps each container's log like Docker's tail would.
+const maxLines = 500
+
+// World is one made-up deployment. It is safe for concurrent use.
+type World struct {
+	mu      sync.Mutex
+	running bool
+	steps   int // Reads so far; the clock is Epoch plus steps
+	started int // Step the containers last started at
+	logs    map[string]*containerLog
+}
+
+// containerLog is one container's log and the generator that grows it.
+// Each container has its own generator so the order the manager reads them
+// in doesn't change what they say.
+type containerLog struct {
+	rng      *rand.Rand
+	lines    []string // As docker logs --timestamps prints them
+	messages int      // WhatsApp messages handled, for the bridge
+}
+
+// New creates a running deployment with a few minutes of logs.
+func New(seed int64) *World {
+	w := &World{
+		running: true,
+		logs: map[string]*containerLog{
+			"fetch-bridge": {rng: rand.New(rand.NewSource(seed))},
+			"fetch-kennel": {rng: rand.New(rand.NewSource(seed + 1))},
+		},
+	}
+	w.logStart()
+	for range 90 {
+		w.advance()
+	}
+	return w
+}
+
+// now is the demo clock.
+func (w *World) now() time.Time {
+	return Epoch.Add(time.Duration(w.steps) * step)
+}
+
+// advance moves the clock one step and lets each running container log.
+func (w *World) advance() {
+	w.steps++
+	if !w.running {
+		return
+	}
+	bridge := w.logs["fetch-bridge"]
+	switch n := bridge.rng.Intn(10); {
+	case n < 3:
+		bridge.messages++
+		w.logf("fetch-bridge", "message", pick(bridge.rng, bridgeMessages))
+	case n < 5:
+		w.logf("fetch-bridge", "info", pick(bridge.rng, bridgeInfo))
+	case n == 5:
+		w.logf("fetch-bridge", "debug", pick(bridge.rng, bridgeDebug))
+	case n == 6 && bridge.rng.Intn(4) == 0:
+		w.logf("fetch-bridge", "warn", pick(bridge.rng, bridgeWarnings))
+	case n == 7 && bridge.rng.Intn(8) == 0:
+		w.logf("fetch-bridge", "error", pick(bridge.rng, bridgeErrors))
+	}
+	kennel := w.logs["fetch-kennel"]
+	if kennel.rng.Intn(4) == 0 {
+		w.logf("fetch-kennel", "info", pick(kennel.rng, kennelInfo))
+	}
+}
+
+// logStart writes the lines each container logs as it comes up.
+func (w *World) logStart() {
+	w.started = w.steps
+	w.logf("fetch-bridge", "info", "Starting Fetch bridge v3.5.0")
+	w.logf("fetch-bridge", "info", "Status API listening on :8765")
+	w.logf("fetch-bridge", "success", "WhatsApp client ready")
+	w.logf("fetch-kennel", "info", "Kennel ready: copilot, claude, gemini")
+}
+
+// levelStyles are the bridge logger's color and icon for each level.
+var levelStyles = map[string]string{
+	"debug":   "\x1b[90m🔍",
+	"info":    "\x1b[34m📘",
+	"warn":    "\x1b[33m⚠️ ",
+	"error":   "\x1b[31m❌",
+	"success": "\x1b[32m✅",
+	"message": "\x1b[36m💬",
+}
+
+// logf appends a line in the bridge logger's format, stamped like
+// docker logs --timestamps.
+func (w *World) logf(container, level, message string) {
+	l := w.logs[container]
+	at := w.now().Add(time.Duration(l.rng.Intn(int(step/time.Millisecond))) * time.Millisecond)
+	line := fmt.Sprintf("%s \x1b[2m%s\x1b[0m %s\x1b[0m %s",
+		at.Format(time.RFC3339Nano), at.Format("15:04:05"), levelStyles[level], message)
+	l.lines = append(l.lines, line)
+	if len(l.lines) > maxLines {
+		l.lines = l.lines[len(l.lines)-maxLines:]
+	}
+}
+
+// Running reports whether the containers are up.
+func (w *World) Running() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.running
+}
+
+// SetRunning starts or stops both containers.
+func (w *World) SetRunning(running bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if running == w.running {
+		return
+	}
+	w.running = running
+	if running {
+		w.logStart()
+		return
+	}
+	w.logf("fetch-bridge", "info", "Shutting down: replies finished, data flushed")
+}
+
+// Containers returns both containers' state, healthy while running.
+func (w *World) Containers() []health.Container {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]health.Container, len(health.Containers))
+	for i, name := range health.Containers {
+		out[i] = health.Container{Name: name, State: "exited"}
+		if w.running {
+			out[i].State, out[i].Health = "running", "healthy"
+		}
+	}
+	return out
+}
+
+// BridgeStatus returns the bridge's status, linked to a made-up account,
+// and moves the clock a step. It fails like an unreachable bridge while
+// the containers are stopped.
+func (w *World) BridgeStatus() (*status.BridgeStatus, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance()
+	if !w.running {
+		return nil, fmt.Errorf("failed to connect to bridge: demo containers are stopped")
+	}
+	name, phone := "Fetch Demo", "15550100042"
+	return &status.BridgeStatus{
+		State:        "authenticated",
+		Uptime:       int(time.Duration(w.steps-w.started) * step / time.Second),
+		MessageCount: w.logs["fetch-bridge"].messages,
+		Device:       &status.LinkedDevice{Name: &name, Phone: &phone},
+	}, nil
+}
+
+// Checks returns the diagnostics System Status runs: all passing while the
+// containers are up, and the containers and bridge failing once stopped.
+func (w *World) Checks() []doctor.Check {
+	w.mu.Lock()
+	running := w.running
+	w.mu.Unlock()
+
+	checks := []doctor.Check{{Name: "Docker daemon", Result: doctor.Pass, Detail: "reachable (v27.3.1)"}}
+	if running {
+		checks = append(checks,
+			doctor.Check{Name: "Bridge container", Result: doctor.Pass, Detail: "running (healthy)"},
+			doctor.Check{Name: "Kennel container", Result: doctor.Pass, Detail: "running (healthy)"},
+			doctor.Check{Name: "Copilot CLI", Result: doctor.Pass, Detail: "version 1.0.5, authenticated [ENABLE_COPILOT=true]"},
+			doctor.Check{Name: "Claude CLI", Result: doctor.Pass, Detail: "1.0.17 (Claude Code), authenticated [ENABLE_CLAUDE=true]"},
+			doctor.Check{Name: "Gemini CLI", Result: doctor.Skip, Detail: "not installed in kennel [ENABLE_GEMINI=false]"},
+			doctor.Check{Name: "Bridge API", Result: doctor.Pass, Detail: "reachable at http://localhost:8765"},
+			doctor.Check{Name: "WhatsApp", Result: doctor.Pass, Detail: "Connected to WhatsApp"},
+		)
+	} else {
+		checks = append(checks,
+			doctor.Check{Name: "Bridge container", Result: doctor.Fail, Detail: "exited", Fix: "Choose Start Fetch, then check View Logs for startup errors"},
+			doctor.Check{Name: "Kennel container", Result: doctor.Fail, Detail: "exited", Fix: "Choose Start Fetch, then check View Logs for startup errors"},
+			doctor.Check{Name: "Bridge API", Result: doctor.Fail, Detail: "unreachable at http://localhost:8765",
+				Fix: "Start Fetch, or set FETCH_API_URL / --api-url if the bridge runs elsewhere"},
+			doctor.Check{Name: "WhatsApp", Result: doctor.Warn, Detail: "unknown (bridge unreachable)"},
+		)
+	}
+	return append(checks,
+		doctor.Check{Name: "GitHub auth", Result: doctor.Pass, Detail: "logged in"},
+		doctor.Check{Name: "OpenRouter key", Result: doctor.Pass, Detail: "valid (" + w.Credits().Label + ")"},
+		doctor.Check{Name: "OpenRouter credits", Result: doctor.Pass, Detail: w.Credits().Summary()},
+		doctor.Check{Name: "Data disk", Result: doctor.Pass, Detail: "41.7 GiB free in /opt/fetch/data"},
+	)
+}
+
+// Logs returns the last n lines of a container's log as docker logs
+// --timestamps prints them.
+func (w *World) Logs(container string, n int) ([]string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	l, ok := w.logs[container]
+	if !ok {
+		return nil, fmt.Errorf("no such container: %s", container)
+	}
+	lines := l.lines[max(0, len(l.lines)-n):]
+	return append([]string(nil), lines...), nil
+}
+
+// Models returns a short OpenRouter catalogue across a few providers.
+func (w *World) Models() []models.Model {
+	out := make([]models.Model, len(demoModels))
+	copy(out, demoModels)
+	return out
+}
+
+// Credits returns a paid key with a spending cap and a healthy balance.
+func (w *World) Credits() models.Credits {
+	limit, left := 25.0, 18.42
+	return models.Credits{
+		Label:     "sk-or-v1-demo…",
+		Usage:     limit - left,
+		Limit:     &limit,
+		Left:      &left,
+		RateLimit: &models.RateLimit{Requests: 20, Interval: "10s"},
+	}
+}
+
+// Releases returns the manager releases on GitHub, newest first.
+func (w *World) Releases() []update.Release {
+	return []update.Release{
+		{
+			Version: "v3.5.0",
+			Name:    "Fetch v3.5.0",
+			Notes:   "- Task approvals from the manager\n- Conversation summaries screen",
+			URL:     "https://github.com/Traves-Theberge/Fetch/releases/tag/v3.5.0",
+		},
+		{
+			Version: "v3.4.2",
+			Name:    "Fetch v3.4.2",
+			Notes:   "- Fix QR codes drawn too wide for 80-column terminals",
+			URL:     "https://github.com/Traves-Theberge/Fetch/releases/tag/v3.4.2",
+		},
+	}
+}
+
+func pick(rng *rand.Rand, options []string) string {
+	return options[rng.Intn(len(options))]
+}
@@ -2,64 +2,331 @@
 package docker
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"os/exec"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/runner"
 )
 
+// Docker commands give up after these, so a hung daemon can't freeze the
+// caller. Compose gets longer since it may pull images and recreate
+// containers.
+const (
+	queryTimeout   = 15 * time.Second
+	composeTimeout = 5 * time.Minute
+)
+
+// run runs a docker command, stopping when ctx is done or after timeout. A
+// timeout or cancellation is reported as such rather than as the killed
+// process's exit status.
+func run(ctx context.Context, timeout time.Duration, c runner.Command) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	c.Name = "docker"
+	out, err := runner.Run(ctx, c)
+	if err == nil {
+		return out, nil
+	}
+	name := c.Args[0]
+	if name == "compose" && len(c.Args) > 1 {
+		name += " " + c.Args[1]
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return out, fmt.Errorf("docker %s timed out after %s", name, timeout)
+	case ctx.Err() != nil:
+		return out, fmt.Errorf("docker %s: %w", name, ctx.Err())
+	}
+	return out, err
+}
+
+// query runs a quick docker command and returns its stdout.
+func query(args ...string) ([]byte, error) {
+	return run(context.Background(), queryTimeout, runner.Command{Args: args})
+}
+
+// queryCombined runs a quick docker command and returns its stdout and
+// stderr interleaved.
+func queryCombined(args ...string) ([]byte, error) {
+	return run(context.Background(), queryTimeout, runner.Command{Args: args, Combined: true})
+}
+
+// compose runs a docker compose subcommand in the project directory and
+// returns its combined output in the error when it fails.
+func compose(ctx context.Context, args ...string) error {
+	output, err := run(ctx, composeTimeout, runner.Command{
+		Args:     append([]string{"compose"}, args...),
+		Dir:      paths.ProjectDir,
+		Combined: true,
+	})
+	if err != nil {
+		if len(output) == 0 || errors.Is(err, context.Canceled) {
+			return err
+		}
+		return fmt.Errorf("%v: %s", err, string(output))
+	}
+	return nil
+}
+
 // IsContainerRunning checks if a Docker container is running.
 func IsContainerRunning(name string) bool {
-	cmd := exec.Command("docker", "inspect", "-f", "{{.State.Running}}", name)
-	out, err := cmd.Output()
+	out, err := query("inspect", "-f", "{{.State.Running}}", name)
 	if err != nil {
 		return false
 	}
 	return strings.TrimSpace(string(out)) == "true"
 }
 
-// StartServices starts all Fetch Docker services.
-func StartServices() error {
-	cmd := exec.Command("docker", "compose", "up", "-d")
-	cmd.Dir = paths.ProjectDir
-	output, err := cmd.CombinedOutput()
+// DaemonVersion returns the Docker server version, or an error if the
+// daemon is not reachable.
+func DaemonVersion() (string, error) {
+	out, err := queryCombined("info", "--format", "{{.ServerVersion}}")
 	if err != nil {
-		return fmt.Errorf("%v: %s", err, string(output))
+		return "", fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
 	}
-	return nil
+	return strings.TrimSpace(string(out)), nil
 }
 
-// StopServices stops all Fetch Docker services.
-func StopServices() error {
-	cmd := exec.Command("docker", "compose", "down")
-	cmd.Dir = paths.ProjectDir
-	output, err := cmd.CombinedOutput()
+// RevisionLabel is the image label holding the commit an image was built
+// from, set from the FETCH_COMMIT build argument.
+const RevisionLabel = "org.opencontainers.image.revision"
+
+// ImageRevision returns the commit a container's image was built from, or
+// "" for images built without one.
+func ImageRevision(name string) (string, error) {
+	out, err := query("inspect", "-f", fmt.Sprintf("{{index .Config.Labels %q}}", RevisionLabel), name)
 	if err != nil {
-		return fmt.Errorf("%v: %s", err, string(output))
+		return "", fmt.Errorf("inspect %s failed: %v", name, err)
 	}
-	return nil
+	rev := strings.TrimSpace(string(out))
+	if rev == "unknown" || rev == "<no value>" {
+		rev = ""
+	}
+	return rev, nil
+}
+
+// ContainerHealth returns the container state ("running", "exited", ...) and
+// its healthcheck status ("healthy", "unhealthy", or "" without a healthcheck).
+func ContainerHealth(name string) (state, health string, err error) {
+	out, err := query("inspect", "-f", "{{.State.Status}} {{if .State.Health}}{{.State.Health.Status}}{{end}}", name)
+	if err != nil {
+		var exit *runner.ExitError
+		if !errors.As(err, &exit) {
+			return "", "", err
+		}
+		return "", "", fmt.Errorf("container %s not found", name)
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) > 0 {
+		state = fields[0]
+	}
+	if len(fields) > 1 {
+		health = fields[1]
+	}
+	return state, health, nil
+}
+
+// StartServices starts all Fetch Docker services. Cancelling ctx stops
+// waiting on compose; containers it already started keep running.
+func StartServices(ctx context.Context) error {
+	return compose(ctx, "up", "-d")
+}
+
+// StartService starts one compose service, creating its container if it
+// is gone.
+func StartService(ctx context.Context, service string) error {
+	return compose(ctx, "up", "-d", service)
+}
+
+// RestartService restarts one compose service in place.
+func RestartService(ctx context.Context, service string) error {
+	return compose(ctx, "restart", service)
+}
+
+// StreamLogs follows `docker logs --timestamps` for a container until its
+// existing output is read, returning stdout and stderr interleaved. since
+// limits how far back it goes (e.g. "24h"); empty reads the whole history
+// Docker keeps. Cancel ctx to stop early; a failed command surfaces as the
+// reader's error.
+func StreamLogs(ctx context.Context, container, since string) io.ReadCloser {
+	args := []string{"logs", "--timestamps"}
+	if since != "" {
+		args = append(args, "--since", since)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := runner.Run(ctx, runner.Command{
+			Name:     "docker",
+			Args:     append(args, container),
+			Combined: true,
+			Stdout:   pw,
+		})
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// TailLogs returns the last n lines of `docker logs --timestamps` for a
+// container, stdout and stderr interleaved. Split each with SplitTimestamp.
+func TailLogs(container string, n int) ([]string, error) {
+	out, err := queryCombined("logs", "--timestamps", "--tail", fmt.Sprint(n), container)
+	if err != nil {
+		if len(out) == 0 {
+			return nil, err
+		}
+		return nil, fmt.Errorf("docker logs %s: %s", container, strings.TrimSpace(string(out)))
+	}
+	text := strings.TrimRight(string(out), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// SplitTimestamp splits the RFC3339Nano time Docker puts before a line read
+// with --timestamps from the line itself. ok is false for lines without
+// one, such as Docker's own errors.
+func SplitTimestamp(raw string) (t time.Time, line string, ok bool) {
+	stamp, line, found := strings.Cut(raw, " ")
+	if !found {
+		return time.Time{}, raw, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return time.Time{}, raw, false
+	}
+	return t.Local(), line, true
+}
+
+// StopServices stops all Fetch Docker services.
+func StopServices(ctx context.Context) error {
+	return compose(ctx, "down")
+}
+
+// whatsappSessionDir is where the bridge keeps its WhatsApp login, inside
+// the container and under the project's data directory on the host.
+const whatsappSessionDir = ".wwebjs_auth"
+
+// ClearWhatsAppSession deletes the bridge's saved WhatsApp login so the
+// next start shows a fresh QR code. The files are removed from inside the
+// container when it runs, since they are usually owned by its user.
+func ClearWhatsAppSession() error {
+	if IsContainerRunning("fetch-bridge") {
+		return ExecShell("fetch-bridge", "rm -rf /app/data/"+whatsappSessionDir)
+	}
+	return os.RemoveAll(filepath.Join(paths.ProjectDir, "data", whatsappSessionDir))
 }
 
 // RestartBridge restarts only the bridge container with fresh auth.
-func RestartBridge() error {
+// Cancelling ctx between steps can leave the bridge stopped.
+func RestartBridge(ctx context.Context) error {
 	// Stop bridge
-	stop := exec.Command("docker", "compose", "stop", "fetch-bridge")
-	stop.Dir = paths.ProjectDir
-	if output, err := stop.CombinedOutput(); err != nil {
-		return fmt.Errorf("stop failed: %v: %s", err, string(output))
+	if err := compose(ctx, "stop", "fetch-bridge"); err != nil {
+		return fmt.Errorf("stop failed: %w", err)
 	}
 
 	// Remove bridge container
-	rm := exec.Command("docker", "compose", "rm", "-f", "fetch-bridge")
-	rm.Dir = paths.ProjectDir
-	rm.CombinedOutput() // Ignore errors
+	compose(ctx, "rm", "-f", "fetch-bridge") // Ignore errors
 
 	// Start bridge
-	start := exec.Command("docker", "compose", "up", "-d", "fetch-bridge")
-	start.Dir = paths.ProjectDir
-	if output, err := start.CombinedOutput(); err != nil {
-		return fmt.Errorf("start failed: %v: %s", err, string(output))
+	if err := compose(ctx, "up", "-d", "fetch-bridge"); err != nil {
+		return fmt.Errorf("start failed: %w", err)
 	}
 	return nil
 }
+
+// SignalContainer sends a signal such as HUP to a container's main
+// process. Docker doesn't report whether the process handled it.
+func SignalContainer(name, signal string) error {
+	output, err := queryCombined("kill", "--signal="+signal, name)
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ComposeEnv returns the environment docker compose resolves for a service,
+// including values merged in from env_file and the environment block.
+func ComposeEnv(service string) (map[string]string, error) {
+	out, err := run(context.Background(), queryTimeout, runner.Command{
+		Args: []string{"compose", "config", "--format", "json"},
+		Dir:  paths.ProjectDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("compose config failed: %v", err)
+	}
+
+	var cfg struct {
+		Services map[string]struct {
+			Environment map[string]*string `json:"environment"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(out, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing compose config: %w", err)
+	}
+
+	env := make(map[string]string)
+	for key, val := range cfg.Services[service].Environment {
+		if val != nil {
+			env[key] = *val
+		}
+	}
+	return env, nil
+}
+
+// ComposePS returns the output of `docker compose ps --all` for the
+// project: every container with its image, state, and ports.
+func ComposePS() (string, error) {
+	out, err := run(context.Background(), queryTimeout, runner.Command{
+		Args:     []string{"compose", "ps", "--all"},
+		Dir:      paths.ProjectDir,
+		Combined: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// ContainerEnv returns the environment of a container as it is currently running.
+func ContainerEnv(name string) (map[string]string, error) {
+	out, err := query("inspect", "-f", "{{json .Config.Env}}", name)
+	if err != nil {
+		return nil, fmt.Errorf("inspect %s failed: %v", name, err)
+	}
+
+	var vars []string
+	if err := json.Unmarshal(out, &vars); err != nil {
+		return nil, fmt.Errorf("parsing container env: %w", err)
+	}
+
+	env := make(map[string]string, len(vars))
+	for _, kv := range vars {
+		if key, val, ok := strings.Cut(kv, "="); ok {
+			env[key] = val
+		}
+	}
+	return env, nil
+}
+
+// Exec runs a command inside a running container and returns its combined
+// output. A hung CLI is given up on after the query timeout.
+func Exec(container string, args ...string) (string, error) {
+	out, err := queryCombined(append([]string{"exec", container}, args...)...)
+	return string(out), err
+}
+
+// ExecShell runs a shell snippet inside a container, returning an error if
+// it exits non-zero.
+func ExecShell(container, script string) error {
+	_, err := Exec(container, "sh", "-c", script)
+	return err
+}
@@ -0,0 +1,186 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/runner"
+	"github.com/fetch/manager/internal/runner/runnertest"
+)
+
+// useProject points paths at an empty project directory until the test
+// ends.
+func useProject(t *testing.T) {
+	prev := paths.ProjectDir
+	paths.Use(t.TempDir())
+	t.Cleanup(func() { paths.Use(prev) })
+}
+
+func TestContainerHealth(t *testing.T) {
+	fake := runnertest.Install(t)
+	fake.Reply("docker inspect -f {{.State.Status}}", "running healthy\n")
+
+	state, health, err := ContainerHealth("fetch-bridge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != "running" || health != "healthy" {
+		t.Errorf("got %q %q, want running healthy", state, health)
+	}
+}
+
+func TestContainerHealthMissing(t *testing.T) {
+	fake := runnertest.Install(t)
+	fake.Fail("docker inspect", 1, "Error: No such object: fetch-bridge\n")
+
+	_, _, err := ContainerHealth("fetch-bridge")
+	if err == nil || err.Error() != "container fetch-bridge not found" {
+		t.Errorf("err = %v, want container fetch-bridge not found", err)
+	}
+}
+
+func TestContainerHealthDaemonDown(t *testing.T) {
+	fake := runnertest.Install(t)
+	notInstalled := errors.New(`exec: "docker": executable file not found in $PATH`)
+	fake.Error("docker", notInstalled)
+
+	// Only a non-zero exit means the container is missing
+	if _, _, err := ContainerHealth("fetch-bridge"); !errors.Is(err, notInstalled) {
+		t.Errorf("err = %v, want %v", err, notInstalled)
+	}
+}
+
+func TestIsContainerRunning(t *testing.T) {
+	fake := runnertest.Install(t)
+	fake.Reply("docker inspect -f {{.State.Running}} fetch-bridge", "true\n")
+	fake.Reply("docker inspect -f {{.State.Running}} fetch-kennel", "false\n")
+	fake.Fail("docker inspect -f {{.State.Running}} fetch-gone", 1, "Error: No such object\n")
+
+	for name, want := range map[string]bool{"fetch-bridge": true, "fetch-kennel": false, "fetch-gone": false} {
+		if got := IsContainerRunning(name); got != want {
+			t.Errorf("IsContainerRunning(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestComposeFailureIncludesOutput(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Fail("docker compose up -d", 1, "no such service: fetch-bridge\n")
+
+	err := StartService(context.Background(), "fetch-bridge")
+	if err == nil || !strings.Contains(err.Error(), "no such service: fetch-bridge") {
+		t.Fatalf("err = %v, want compose's output", err)
+	}
+	var exit *runner.ExitError
+	if errors.As(err, &exit) {
+		t.Error("compose's error should be flattened into its message")
+	}
+
+	cmds := fake.Commands()
+	if len(cmds) != 1 || cmds[0].Dir != paths.ProjectDir {
+		t.Errorf("commands = %+v, want one run in %s", cmds, paths.ProjectDir)
+	}
+}
+
+func TestRestartBridgeStopsAtFailedStop(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Fail("docker compose stop", 1, "daemon busy\n")
+
+	err := RestartBridge(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "stop failed:") {
+		t.Fatalf("err = %v, want stop failed", err)
+	}
+	if calls := fake.Calls(); len(calls) != 1 {
+		t.Errorf("ran %q after the stop failed", calls[1:])
+	}
+}
+
+func TestTailLogs(t *testing.T) {
+	fake := runnertest.Install(t)
+	fake.Reply("docker logs --timestamps --tail 2 fetch-bridge",
+		"2026-01-02T03:04:05.000000000Z first\n2026-01-02T03:04:06.000000000Z second\n")
+
+	lines, err := TailLogs("fetch-bridge", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if _, line, ok := SplitTimestamp(lines[1]); !ok || line != "second" {
+		t.Errorf("second line = %q (ok %v), want second", line, ok)
+	}
+}
+
+func TestTailLogsFailure(t *testing.T) {
+	fake := runnertest.Install(t)
+	fake.Fail("docker logs", 1, "Error: No such container: fetch-bridge\n")
+
+	_, err := TailLogs("fetch-bridge", 10)
+	if err == nil || err.Error() != "docker logs fetch-bridge: Error: No such container: fetch-bridge" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	fake := runnertest.Install(t)
+	fake.Reply("docker logs --timestamps --since 24h fetch-bridge", "one\ntwo\n")
+
+	r := StreamLogs(context.Background(), "fetch-bridge", "24h")
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "one\ntwo\n" {
+		t.Errorf("read %q", out)
+	}
+}
+
+func TestStreamLogsFailure(t *testing.T) {
+	fake := runnertest.Install(t)
+	fake.Fail("docker logs", 1, "")
+
+	r := StreamLogs(context.Background(), "fetch-bridge", "")
+	defer r.Close()
+	_, err := io.ReadAll(r)
+	var exit *runner.ExitError
+	if !errors.As(err, &exit) || exit.Code != 1 {
+		t.Errorf("err = %v, want exit status 1", err)
+	}
+}
+
+func TestComposeEnv(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Reply("docker compose config --format json",
+		`{"services":{"fetch-bridge":{"environment":{"OWNER_PHONE_NUMBER":"15551234567","EMPTY":null}}}}`)
+
+	env, err := ComposeEnv("fetch-bridge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env["OWNER_PHONE_NUMBER"] != "15551234567" {
+		t.Errorf("OWNER_PHONE_NUMBER = %q", env["OWNER_PHONE_NUMBER"])
+	}
+	if _, ok := env["EMPTY"]; ok {
+		t.Error("unset variables should be left out")
+	}
+}
+
+func TestCancelledCompose(t *testing.T) {
+	useProject(t)
+	runnertest.Install(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := StopServices(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
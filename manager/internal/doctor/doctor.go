@@ -0,0 +1,204 @@
+// Package doctor runs diagnostic checks against a Fetch installation.
+//
+// Each check reports pass/warn/fail with a short detail and, when something
+// is wrong, a suggested fix — a built-in "fetch doctor".
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/models"
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/runner"
+	"github.com/fetch/manager/internal/status"
+)
+
+// Result is the outcome of a single check.
+type Result int
+
+const (
+	// Pass means the check succeeded.
+	Pass Result = iota
+	// Warn means Fetch can run but something needs attention.
+	Warn
+	// Fail means Fetch will not work correctly until this is fixed.
+	Fail
+	// Skip means the check doesn't apply (e.g. a disabled harness) and is
+	// shown for information only.
+	Skip
+)
+
+// minFreeBytes is the free space below which the data dir check warns.
+const minFreeBytes = 1 << 30 // 1 GiB
+
+// Check is the outcome of one diagnostic.
+type Check struct {
+	Name   string
+	Result Result
+	Detail string
+	Fix    string // Suggested fix (empty when passing)
+}
+
+// Run executes every diagnostic in order. It blocks on network and docker
+// calls, so call it from a tea.Cmd.
+func Run(client *status.Client) []Check {
+	checks := []Check{checkDocker()}
+
+	// Container checks are meaningless without a daemon
+	if checks[0].Result == Pass {
+		kennel := checkContainer(kennelContainer, "Kennel container")
+		checks = append(checks,
+			checkContainer("fetch-bridge", "Bridge container"),
+			kennel,
+		)
+		if kennel.Result != Fail {
+			checks = append(checks, checkHarnesses()...)
+		}
+	}
+
+	checks = append(checks, checkBridge(client)...)
+	checks = append(checks, checkGitHub())
+	checks = append(checks, checkOpenRouter()...)
+	checks = append(checks, checkDisk(filepath.Join(paths.ProjectDir, "data")))
+	return checks
+}
+
+// Summary counts the checks by result.
+func Summary(checks []Check) (pass, warn, fail int) {
+	for _, c := range checks {
+		switch c.Result {
+		case Pass:
+			pass++
+		case Warn:
+			warn++
+		case Fail:
+			fail++
+		}
+	}
+	return pass, warn, fail
+}
+
+func checkDocker() Check {
+	version, err := docker.DaemonVersion()
+	if err != nil {
+		return Check{
+			Name:   "Docker daemon",
+			Result: Fail,
+			Detail: "not reachable",
+			Fix:    "Start Docker (sudo systemctl start docker) and make sure your user is in the docker group",
+		}
+	}
+	return Check{Name: "Docker daemon", Result: Pass, Detail: "reachable (v" + version + ")"}
+}
+
+func checkContainer(name, label string) Check {
+	state, health, err := docker.ContainerHealth(name)
+	switch {
+	case err != nil:
+		return Check{Name: label, Result: Fail, Detail: "not created", Fix: "Choose Start Fetch from the menu (docker compose up -d)"}
+	case state != "running":
+		return Check{Name: label, Result: Fail, Detail: state, Fix: "Choose Start Fetch, then check View Logs for startup errors"}
+	case health == "unhealthy":
+		return Check{Name: label, Result: Warn, Detail: "running but unhealthy", Fix: "Check View Logs; restart with Stop then Start Fetch"}
+	case health != "":
+		return Check{Name: label, Result: Pass, Detail: "running (" + health + ")"}
+	default:
+		return Check{Name: label, Result: Pass, Detail: "running"}
+	}
+}
+
+// checkBridge reports API reachability and, if reachable, WhatsApp auth state.
+func checkBridge(client *status.Client) []Check {
+	st, err := client.GetStatus()
+	if err != nil {
+		return []Check{
+			{Name: "Bridge API", Result: Fail, Detail: "unreachable at " + client.BaseURL(),
+				Fix: "Start Fetch, or set FETCH_API_URL / --api-url if the bridge runs elsewhere"},
+			{Name: "WhatsApp", Result: Warn, Detail: "unknown (bridge unreachable)"},
+		}
+	}
+
+	api := Check{Name: "Bridge API", Result: Pass, Detail: "reachable at " + client.BaseURL()}
+	wa := Check{Name: "WhatsApp", Detail: st.StateDescription()}
+	switch st.State {
+	case "authenticated":
+		wa.Result = Pass
+	case "qr_pending":
+		wa.Result = Warn
+		wa.Fix = "Open Setup WhatsApp and scan the QR code (or pair with your phone number)"
+	case "initializing":
+		wa.Result = Warn
+		wa.Fix = "Wait a few seconds for the bridge to finish starting"
+	case "authenticating":
+		wa.Result = Warn
+		wa.Fix = "Wait for WhatsApp to finish loading chats"
+	default:
+		wa.Result = Fail
+		wa.Fix = "Restart Fetch; if it persists, re-link the device from Setup WhatsApp"
+	}
+	return []Check{api, wa}
+}
+
+func checkGitHub() Check {
+	if _, err := runner.LookPath("gh"); err != nil {
+		return Check{Name: "GitHub auth", Result: Warn, Detail: "gh CLI not installed", Fix: "Install the GitHub CLI: https://cli.github.com"}
+	}
+	if _, err := runner.Output("gh", "auth", "status"); err != nil {
+		return Check{Name: "GitHub auth", Result: Warn, Detail: "not logged in", Fix: "Open Git Providers from the menu and add an account"}
+	}
+	return Check{Name: "GitHub auth", Result: Pass, Detail: "logged in"}
+}
+
+// checkOpenRouter checks the key and what it has left to spend, since
+// tasks fail partway through once the credits run out.
+func checkOpenRouter() []Check {
+	key := models.GetAPIKey()
+	if key == "" {
+		return []Check{{Name: "OpenRouter key", Result: Fail, Detail: "not configured", Fix: "Set OPENROUTER_API_KEY in Configure (get one at openrouter.ai/keys)"}}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	credits, err := models.FetchCredits(ctx, key)
+	if err != nil {
+		return []Check{{Name: "OpenRouter key", Result: Fail, Detail: err.Error(), Fix: "Check OPENROUTER_API_KEY in Configure or create a new key"}}
+	}
+
+	checks := []Check{{Name: "OpenRouter key", Result: Pass, Detail: "valid"}}
+	if credits.Label != "" {
+		checks[0].Detail = "valid (" + credits.Label + ")"
+	}
+	if credits.Low() {
+		return append(checks, Check{Name: "OpenRouter credits", Result: Warn, Detail: credits.Summary(), Fix: "Add credits at openrouter.ai/credits or raise the key's limit before a task runs dry"})
+	}
+	return append(checks, Check{Name: "OpenRouter credits", Result: Pass, Detail: credits.Summary()})
+}
+
+func checkDisk(dir string) Check {
+	free, err := freeBytes(dir)
+	if err != nil {
+		return Check{Name: "Data disk", Result: Warn, Detail: "could not read free space: " + err.Error()}
+	}
+	detail := fmt.Sprintf("%s free in %s", formatBytes(free), dir)
+	if free < minFreeBytes {
+		return Check{Name: "Data disk", Result: Warn, Detail: detail, Fix: "Free up disk space; WhatsApp session and task data live here"}
+	}
+	return Check{Name: "Data disk", Result: Pass, Detail: detail}
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,34 @@
+package doctor
+
+import (
+	"testing"
+
+	"github.com/fetch/manager/internal/runner/runnertest"
+)
+
+func TestCheckGitHub(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(f *runnertest.Fake)
+		result Result
+		detail string
+	}{
+		{"not installed", func(f *runnertest.Fake) {}, Warn, "gh CLI not installed"},
+		{"logged out", func(f *runnertest.Fake) {
+			f.Fail("gh auth status", 1, "You are not logged into any GitHub hosts.\n")
+		}, Warn, "not logged in"},
+		{"logged in", func(f *runnertest.Fake) {
+			f.Reply("gh auth status", "github.com\n  ✓ Logged in to github.com account octocat (keyring)\n")
+		}, Pass, "logged in"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := runnertest.Install(t)
+			tt.setup(fake)
+			got := checkGitHub()
+			if got.Result != tt.result || got.Detail != tt.detail {
+				t.Errorf("got %v %q, want %v %q", got.Result, got.Detail, tt.result, tt.detail)
+			}
+		})
+	}
+}
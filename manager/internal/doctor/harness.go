@@ -0,0 +1,92 @@
+package doctor
+
+import (
+	"strings"
+
+	"github.com/fetch/manager/internal/docker"
+)
+
+// kennelContainer is where the coding harnesses run.
+const kennelContainer = "fetch-kennel"
+
+// harness describes how to probe one AI CLI inside the kennel.
+type harness struct {
+	name      string
+	enableKey string   // ENABLE_* toggle in .env
+	version   []string // Prints a version if installed
+	auth      string   // Shell test that succeeds when credentials exist
+	login     string   // How to fix missing credentials on the host
+}
+
+var harnesses = []harness{
+	{
+		name:      "Copilot CLI",
+		enableKey: "ENABLE_COPILOT",
+		version:   []string{"gh", "copilot", "--version"},
+		auth:      "gh auth status >/dev/null 2>&1",
+		login:     "Run gh auth login on the host (mounted from ~/.config/gh)",
+	},
+	{
+		name:      "Claude CLI",
+		enableKey: "ENABLE_CLAUDE",
+		version:   []string{"claude", "--version"},
+		auth:      `test -n "$ANTHROPIC_API_KEY" || test -n "$(ls -A /root/.config/claude-code 2>/dev/null)"`,
+		login:     "Run claude on the host and complete the browser login (mounted from ~/.config/claude-code)",
+	},
+	{
+		name:      "Gemini CLI",
+		enableKey: "ENABLE_GEMINI",
+		version:   []string{"gemini", "--version"},
+		auth:      `test -n "$GEMINI_API_KEY" || test -s /root/.gemini/oauth_creds.json`,
+		login:     "Run gemini on the host and log in, or set GEMINI_API_KEY in Configure",
+	},
+}
+
+// checkHarnesses reports whether each AI CLI is installed and authenticated
+// inside the kennel, alongside its ENABLE_* toggle. Only enabled harnesses
+// can fail; disabled ones are reported for information.
+func checkHarnesses() []Check {
+	env, _ := docker.ContainerEnv(kennelContainer)
+
+	var checks []Check
+	for _, h := range harnesses {
+		enabled := strings.EqualFold(env[h.enableKey], "true")
+		toggle := h.enableKey + "=false"
+		if enabled {
+			toggle = h.enableKey + "=true"
+		}
+
+		c := Check{Name: h.name}
+		out, err := docker.Exec(kennelContainer, h.version...)
+		switch {
+		case err != nil:
+			c.Detail = "not installed in kennel [" + toggle + "]"
+			c.Fix = "Rebuild the kennel image: docker compose build fetch-kennel"
+		case docker.ExecShell(kennelContainer, h.auth) != nil:
+			c.Detail = firstLine(out) + ", not authenticated [" + toggle + "]"
+			c.Fix = h.login
+		default:
+			c.Result = Pass
+			c.Detail = firstLine(out) + ", authenticated [" + toggle + "]"
+		}
+
+		if c.Result != Pass {
+			if enabled {
+				c.Result = Fail
+			} else {
+				c.Result = Skip
+				c.Fix = ""
+			}
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
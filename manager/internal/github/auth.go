@@ -0,0 +1,126 @@
+// Package github inspects GitHub CLI credentials used by the coding harnesses.
+package github
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fetch/manager/internal/runner"
+)
+
+// RequiredScopes are the classic OAuth scopes the coding harnesses need:
+// repo to clone and push, workflow to push changes under .github/workflows.
+var RequiredScopes = []string{"repo", "workflow"}
+
+// TokenInfo describes what a token can do, as reported by the GitHub API.
+type TokenInfo struct {
+	Kind      string    // "oauth", "classic PAT", "fine-grained PAT", ...
+	Scopes    []string  // OAuth scopes; empty for fine-grained tokens
+	ExpiresAt time.Time // Zero if the token does not expire
+	// FineGrained tokens use per-repository permissions instead of scopes,
+	// which the API does not expose, so scope checks are skipped.
+	FineGrained bool
+}
+
+// Missing returns the required scopes the token lacks.
+func (t TokenInfo) Missing() []string {
+	if t.FineGrained {
+		return nil
+	}
+	var missing []string
+	for _, s := range RequiredScopes {
+		if !hasScope(t.Scopes, s) {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// hasScope reports whether scopes grant want, treating "admin:org" and
+// "write:org" as implying "read:org".
+func hasScope(scopes []string, want string) bool {
+	if slices.Contains(scopes, want) {
+		return true
+	}
+	if _, name, ok := strings.Cut(want, ":"); ok {
+		return slices.Contains(scopes, "admin:"+name) || slices.Contains(scopes, "write:"+name)
+	}
+	return false
+}
+
+// tokenKind identifies a token from its prefix.
+func tokenKind(token string) string {
+	switch {
+	case strings.HasPrefix(token, "gho_"):
+		return "OAuth"
+	case strings.HasPrefix(token, "ghp_"):
+		return "classic PAT"
+	case strings.HasPrefix(token, "github_pat_"):
+		return "fine-grained PAT"
+	case strings.HasPrefix(token, "ghu_"), strings.HasPrefix(token, "ghs_"):
+		return "GitHub App"
+	default:
+		return "token"
+	}
+}
+
+// Inspect queries the GitHub API with the given token via `gh api -i` and
+// parses the scope and expiry headers. The token is passed through the
+// environment only and never logged.
+func Inspect(token string) (TokenInfo, error) {
+	info := TokenInfo{
+		Kind:        tokenKind(token),
+		FineGrained: strings.HasPrefix(token, "github_pat_"),
+	}
+
+	cmd := runner.New("gh", "api", "-i", "user")
+	cmd.Env = []string{"GH_TOKEN=" + token}
+	out, err := runner.Run(context.Background(), cmd)
+	if err != nil {
+		return info, fmt.Errorf("gh api user failed: %w", err)
+	}
+
+	// Headers come first, terminated by a blank line
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(name) {
+		case "x-oauth-scopes":
+			info.Scopes = splitScopes(value)
+		case "github-authentication-token-expiration":
+			// e.g. "2026-01-31 12:00:00 UTC"
+			if t, err := time.Parse("2006-01-02 15:04:05 MST", value); err == nil {
+				info.ExpiresAt = t
+			}
+		}
+	}
+	return info, nil
+}
+
+// ParseScopes parses the "Token scopes:" value printed by `gh auth status`,
+// e.g. "'gist', 'read:org', 'repo'".
+func ParseScopes(s string) []string {
+	return splitScopes(strings.ReplaceAll(s, "'", ""))
+}
+
+func splitScopes(s string) []string {
+	var scopes []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" && part != "none" {
+			scopes = append(scopes, part)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fetch/manager/internal/runner"
+)
+
+// Repo is a repository as listed by `gh repo list`.
+type Repo struct {
+	NameWithOwner string `json:"nameWithOwner"` // e.g. "octocat/hello-world"
+	Description   string `json:"description"`
+	Visibility    string `json:"visibility"` // PUBLIC, PRIVATE or INTERNAL
+	IsFork        bool   `json:"isFork"`
+	IsArchived    bool   `json:"isArchived"`
+}
+
+// Private reports whether only invited people can see the repository.
+func (r Repo) Private() bool {
+	return r.Visibility != "" && r.Visibility != "PUBLIC"
+}
+
+// ListRepos returns up to limit repositories owned by the active gh
+// account, most recently pushed first.
+func ListRepos(limit int) ([]Repo, error) {
+	out, err := runner.Output("gh", "repo", "list", "--limit", strconv.Itoa(limit),
+		"--json", "nameWithOwner,description,visibility,isFork,isArchived")
+	if err != nil {
+		if ee, ok := err.(*runner.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("gh repo list: %s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("gh repo list: %w", err)
+	}
+	var repos []Repo
+	if err := json.Unmarshal(out, &repos); err != nil {
+		return nil, fmt.Errorf("gh repo list: %w", err)
+	}
+	return repos, nil
+}
@@ -0,0 +1,150 @@
+// Package gitprovider detects authentication for the git hosting providers
+// Fetch can work with. GitHub, GitLab, and Gitea are checked through their
+// CLIs (gh, glab, tea); Bitbucket has no official CLI, so its app password
+// is read from .env.
+package gitprovider
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/fetch/manager/internal/runner"
+)
+
+// Provider describes a supported git host.
+type Provider struct {
+	ID       string // Value written to GIT_PROVIDER
+	Name     string
+	CLI      string // Binary used for auth; empty if none
+	LoginURL string // Where to create credentials manually
+}
+
+// Account is a logged-in identity on a provider.
+type Account struct {
+	Host   string
+	User   string
+	Active bool
+	Detail string // Extra status such as protocol or token source
+}
+
+// Providers lists the supported providers in display order.
+var Providers = []Provider{
+	{ID: "github", Name: "GitHub", CLI: "gh", LoginURL: "https://github.com/login/device"},
+	{ID: "gitlab", Name: "GitLab", CLI: "glab", LoginURL: "https://gitlab.com/-/user_settings/personal_access_tokens"},
+	{ID: "gitea", Name: "Gitea", CLI: "tea", LoginURL: "https://gitea.com/user/settings/applications"},
+	{ID: "bitbucket", Name: "Bitbucket", LoginURL: "https://bitbucket.org/account/settings/app-passwords/"},
+}
+
+// Bitbucket credentials are read from these .env keys.
+const (
+	BitbucketUserKey     = "BITBUCKET_USERNAME"
+	BitbucketPasswordKey = "BITBUCKET_APP_PASSWORD"
+)
+
+// ByID returns the provider with the given ID, defaulting to GitHub.
+func ByID(id string) Provider {
+	for _, p := range Providers {
+		if p.ID == strings.ToLower(strings.TrimSpace(id)) {
+			return p
+		}
+	}
+	return Providers[0]
+}
+
+// Installed reports whether the provider's CLI is on PATH. Providers without
+// a CLI are always considered installed.
+func (p Provider) Installed() bool {
+	if p.CLI == "" {
+		return true
+	}
+	_, err := runner.LookPath(p.CLI)
+	return err == nil
+}
+
+// LoginCommand returns the interactive login command, or nil if the
+// provider has no CLI login flow.
+func (p Provider) LoginCommand() *exec.Cmd {
+	switch p.ID {
+	case "github":
+		return exec.Command("gh", "auth", "login")
+	case "gitlab":
+		return exec.Command("glab", "auth", "login")
+	case "gitea":
+		return exec.Command("tea", "login", "add")
+	}
+	return nil
+}
+
+// Status returns the accounts logged in on a CLI-backed provider. envValue
+// looks up .env keys for providers configured there.
+func (p Provider) Status(envValue func(string) string) ([]Account, error) {
+	switch p.ID {
+	case "gitlab":
+		status := runner.New("glab", "auth", "status")
+		status.Combined = true
+		out, err := runner.Run(context.Background(), status)
+		if err != nil && len(out) == 0 {
+			return nil, err
+		}
+		return parseGlabStatus(string(out)), nil
+	case "gitea":
+		out, err := runner.Output("tea", "login", "list", "--output", "simple")
+		if err != nil {
+			return nil, err
+		}
+		return parseTeaLogins(string(out)), nil
+	case "bitbucket":
+		user := envValue(BitbucketUserKey)
+		if user == "" || envValue(BitbucketPasswordKey) == "" {
+			return nil, nil
+		}
+		return []Account{{Host: "bitbucket.org", User: user, Active: true, Detail: "app password in .env"}}, nil
+	}
+	return nil, nil
+}
+
+// parseGlabStatus parses `glab auth status`, which prints one block per host:
+//
+//	gitlab.com
+//	  ✓ Logged in to gitlab.com as USER (/home/me/.config/glab-cli/config.yml)
+//	  ✓ Git operations for gitlab.com configured to use https protocol.
+func parseGlabStatus(out string) []Account {
+	var accounts []Account
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if i := strings.Index(line, "Logged in to "); i >= 0 {
+			rest := line[i+len("Logged in to "):]
+			host, user, ok := strings.Cut(rest, " as ")
+			if !ok {
+				continue
+			}
+			user, _, _ = strings.Cut(user, " ")
+			accounts = append(accounts, Account{Host: host, User: user, Active: true})
+		} else if strings.Contains(line, "configured to use") && len(accounts) > 0 {
+			if _, proto, ok := strings.Cut(line, "configured to use "); ok {
+				accounts[len(accounts)-1].Detail = strings.TrimSuffix(proto, ".")
+			}
+		}
+	}
+	return accounts
+}
+
+// parseTeaLogins parses `tea login list --output simple`, which prints
+// whitespace-separated NAME URL SSHHOST USER DEFAULT columns.
+func parseTeaLogins(out string) []Account {
+	var accounts []Account
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || strings.EqualFold(fields[0], "name") {
+			continue
+		}
+		host := strings.TrimPrefix(strings.TrimPrefix(fields[1], "https://"), "http://")
+		acct := Account{Host: host, User: fields[3], Detail: "login " + fields[0]}
+		if len(fields) >= 5 {
+			acct.Active = fields[4] == "true"
+		}
+		accounts = append(accounts, acct)
+	}
+	return accounts
+}
@@ -0,0 +1,108 @@
+package gitprovider
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/fetch/manager/internal/runner"
+)
+
+// packageManager is a way to install software on one OS.
+type packageManager struct {
+	goos    string // runtime.GOOS it applies to
+	binary  string // Must be on PATH to be offered
+	command []string
+	sudo    bool // Installs system-wide and needs root
+}
+
+// packageManagers are tried in order; the first one found is offered.
+var packageManagers = []packageManager{
+	{"darwin", "brew", []string{"brew", "install"}, false},
+	{"darwin", "port", []string{"port", "install"}, true},
+	{"windows", "winget", []string{"winget", "install", "--id"}, false},
+	{"windows", "scoop", []string{"scoop", "install"}, false},
+	{"windows", "choco", []string{"choco", "install"}, false},
+	{"linux", "brew", []string{"brew", "install"}, false},
+	{"linux", "apt-get", []string{"apt-get", "install", "-y"}, true},
+	{"linux", "dnf", []string{"dnf", "install", "-y"}, true},
+	{"linux", "pacman", []string{"pacman", "-S", "--noconfirm"}, true},
+	{"linux", "zypper", []string{"zypper", "install", "-y"}, true},
+	{"linux", "apk", []string{"apk", "add"}, true},
+}
+
+// cliPackages names each CLI's package per package manager. A manager
+// missing from the map doesn't package that CLI.
+var cliPackages = map[string]map[string]string{
+	"gh": {
+		"brew":    "gh",
+		"port":    "gh",
+		"winget":  "GitHub.cli",
+		"scoop":   "gh",
+		"choco":   "gh",
+		"apt-get": "gh",
+		"dnf":     "gh",
+		"pacman":  "github-cli",
+		"zypper":  "gh",
+		"apk":     "github-cli",
+	},
+	"glab": {
+		"brew":   "glab",
+		"winget": "GLab.GLab",
+		"scoop":  "glab",
+		"dnf":    "glab",
+		"pacman": "glab",
+		"apk":    "glab",
+	},
+	"tea": {
+		"brew":  "tea",
+		"scoop": "tea",
+	},
+}
+
+// downloadPages are the CLIs' own install instructions.
+var downloadPages = map[string]string{
+	"gh":   "https://cli.github.com/",
+	"glab": "https://gitlab.com/gitlab-org/cli#installation",
+	"tea":  "https://gitea.com/gitea/tea#installation",
+}
+
+// Install describes how to get a provider's CLI onto this machine.
+type Install struct {
+	URL     string   // Download page with instructions for every OS
+	Command []string // Package-manager command; nil when none was found
+}
+
+// String returns the command as it would be typed in a shell.
+func (i Install) String() string {
+	return strings.Join(i.Command, " ")
+}
+
+// InstallHelp returns how to install the provider's CLI, using the first
+// package manager found on PATH that packages it.
+func (p Provider) InstallHelp() Install {
+	inst := Install{URL: downloadPages[p.CLI]}
+	packages := cliPackages[p.CLI]
+	for _, pm := range packageManagers {
+		pkg, ok := packages[pm.binary]
+		if !ok || pm.goos != runtime.GOOS {
+			continue
+		}
+		if _, err := runner.LookPath(pm.binary); err != nil {
+			continue
+		}
+		cmd := append(append([]string{}, pm.command...), pkg)
+		if pm.sudo && !isRoot() {
+			cmd = append([]string{"sudo"}, cmd...)
+		}
+		inst.Command = cmd
+		break
+	}
+	return inst
+}
+
+// isRoot reports whether the manager already runs as root, where sudo
+// would be redundant or missing altogether.
+func isRoot() bool {
+	return os.Geteuid() == 0
+}
@@ -0,0 +1,75 @@
+// Package health checks Fetch's containers and bridge. The TUI's status
+// polling, the metrics exporter, and the watchdog all read state through it
+// so they agree on what "running" and "connected" mean.
+package health
+
+import (
+	"time"
+
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/status"
+)
+
+// Containers are the containers Fetch runs; each is also its compose service.
+var Containers = []string{"fetch-bridge", "fetch-kennel"}
+
+// Container is one container's state.
+type Container struct {
+	Name   string
+	State  string // Docker state ("running", "exited", ...); empty if missing
+	Health string // "healthy", "unhealthy", "starting", or "" without a healthcheck
+}
+
+// Running reports whether the container is up.
+func (c Container) Running() bool {
+	return c.State == "running"
+}
+
+// Unhealthy reports whether the container runs but fails its healthcheck.
+func (c Container) Unhealthy() bool {
+	return c.Running() && c.Health == "unhealthy"
+}
+
+// Snapshot is the state of everything at one moment.
+type Snapshot struct {
+	Containers []Container
+	Bridge     *status.BridgeStatus // Nil when the bridge API didn't answer
+	BridgeErr  error
+	At         time.Time
+}
+
+// CheckContainers inspects every Fetch container.
+func CheckContainers() []Container {
+	out := make([]Container, len(Containers))
+	for i, name := range Containers {
+		state, health, _ := docker.ContainerHealth(name)
+		out[i] = Container{Name: name, State: state, Health: health}
+	}
+	return out
+}
+
+// Check inspects the containers and asks the bridge for its status.
+func Check(client *status.Client) Snapshot {
+	s := Snapshot{Containers: CheckContainers(), At: time.Now()}
+	s.Bridge, s.BridgeErr = client.GetStatus()
+	return s
+}
+
+// Running reports whether the named container is up.
+func (s Snapshot) Running(name string) bool {
+	for _, c := range s.Containers {
+		if c.Name == name {
+			return c.Running()
+		}
+	}
+	return false
+}
+
+// WhatsAppState returns the bridge's WhatsApp state, or "" when the bridge
+// didn't answer.
+func (s Snapshot) WhatsAppState() string {
+	if s.Bridge == nil {
+		return ""
+	}
+	return s.Bridge.State
+}
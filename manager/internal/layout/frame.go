@@ -12,12 +12,12 @@ import (
 func Frame(content string, width int, focused bool) string {
 	style := lipgloss.NewStyle().
 		Border(theme.PanelBorder).
-		BorderForeground(theme.Border).
+		BorderForeground(theme.Active().Border).
 		Padding(1, 2).
 		Width(width)
 
 	if focused {
-		style = style.BorderForeground(theme.Primary)
+		style = style.BorderForeground(theme.Active().Primary)
 	}
 
 	return style.Render(content)
@@ -25,15 +25,15 @@ func Frame(content string, width int, focused bool) string {
 
 // FrameWithTitle wraps content in a border with a title
 func FrameWithTitle(title, content string, width int, focused bool) string {
-	borderColor := theme.Border
+	borderColor := theme.Active().Border
 	if focused {
-		borderColor = theme.Primary
+		borderColor = theme.Active().Primary
 	}
 
 	// Create the title bar
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(theme.Primary).
+		Foreground(theme.Active().Primary).
 		Padding(0, 1)
 
 	titleText := titleStyle.Render(title)
@@ -67,7 +67,7 @@ func FrameWithTitle(title, content string, width int, focused bool) string {
 func AppFrame(content string, width, height int) string {
 	style := lipgloss.NewStyle().
 		Border(theme.AppBorder).
-		BorderForeground(theme.Primary).
+		BorderForeground(theme.Active().Primary).
 		Padding(0, 1).
 		Width(width).
 		Height(height)
@@ -79,8 +79,8 @@ func AppFrame(content string, width, height int) string {
 func HeaderBar(title string, width int) string {
 	style := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(theme.TextPrimary).
-		Background(theme.Surface).
+		Foreground(theme.Active().TextPrimary).
+		Background(theme.Active().Surface).
 		Padding(0, 2).
 		Width(width).
 		Align(lipgloss.Center)
@@ -92,11 +92,11 @@ func HeaderBar(title string, width int) string {
 func TitleBar(title string, status string, width int) string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(theme.Primary).
+		Foreground(theme.Active().Primary).
 		Padding(0, 1)
 
 	statusStyle := lipgloss.NewStyle().
-		Foreground(theme.TextSecondary).
+		Foreground(theme.Active().TextSecondary).
 		Padding(0, 1)
 
 	titleText := titleStyle.Render(title)
@@ -116,7 +116,7 @@ func TitleBar(title string, status string, width int) string {
 	}
 
 	barStyle := lipgloss.NewStyle().
-		Background(theme.Surface).
+		Background(theme.Active().Surface).
 		Width(width).
 		Padding(0, 1)
 
@@ -126,10 +126,10 @@ func TitleBar(title string, status string, width int) string {
 // StatusBar creates a bottom status bar
 func StatusBar(left, right string, width int) string {
 	leftStyle := lipgloss.NewStyle().
-		Foreground(theme.TextSecondary)
+		Foreground(theme.Active().TextSecondary)
 
 	rightStyle := lipgloss.NewStyle().
-		Foreground(theme.TextMuted)
+		Foreground(theme.Active().TextMuted)
 
 	leftText := leftStyle.Render(left)
 	rightText := rightStyle.Render(right)
@@ -145,7 +145,7 @@ func StatusBar(left, right string, width int) string {
 	}
 
 	barStyle := lipgloss.NewStyle().
-		Background(theme.Surface).
+		Background(theme.Active().Surface).
 		Width(width).
 		Padding(0, 1)
 
@@ -155,14 +155,14 @@ func StatusBar(left, right string, width int) string {
 // Separator creates a horizontal line separator
 func Separator(width int) string {
 	return lipgloss.NewStyle().
-		Foreground(theme.Border).
+		Foreground(theme.Active().Border).
 		Render(strings.Repeat("─", width))
 }
 
 // ThickSeparator creates a thicker horizontal line
 func ThickSeparator(width int) string {
 	return lipgloss.NewStyle().
-		Foreground(theme.Border).
+		Foreground(theme.Active().Border).
 		Render(strings.Repeat("━", width))
 }
 
@@ -170,7 +170,7 @@ func ThickSeparator(width int) string {
 func SectionHeader(title string, width int) string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(theme.Secondary)
+		Foreground(theme.Active().Secondary)
 
 	titleText := titleStyle.Render(" " + title + " ")
 	titleWidth := lipgloss.Width(titleText)
@@ -180,7 +180,7 @@ func SectionHeader(title string, width int) string {
 		return titleText
 	}
 
-	lineStyle := lipgloss.NewStyle().Foreground(theme.Border)
+	lineStyle := lipgloss.NewStyle().Foreground(theme.Active().Border)
 	line := lineStyle.Render(strings.Repeat("─", lineWidth))
 
 	return line + titleText + line
@@ -194,22 +194,22 @@ func Card(title, content string, width int) string {
 	if title != "" {
 		titleBar := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(theme.TextPrimary).
-			Background(theme.Surface).
+			Foreground(theme.Active().TextPrimary).
+			Background(theme.Active().Surface).
 			Padding(0, 2).
 			Width(width - 2).
 			Render(title)
 
 		return lipgloss.NewStyle().
 			Border(theme.PanelBorder).
-			BorderForeground(theme.Border).
+			BorderForeground(theme.Active().Border).
 			Width(width).
 			Render(titleBar + "\n" + contentStyle.Render(content))
 	}
 
 	return lipgloss.NewStyle().
 		Border(theme.PanelBorder).
-		BorderForeground(theme.Border).
+		BorderForeground(theme.Active().Border).
 		Padding(1, 2).
 		Width(width).
 		Render(content)
@@ -218,10 +218,10 @@ func Card(title, content string, width int) string {
 // HelpBar creates a keyboard shortcuts bar
 func HelpBar(shortcuts map[string]string, width int) string {
 	style := lipgloss.NewStyle().
-		Foreground(theme.TextMuted)
+		Foreground(theme.Active().TextMuted)
 
 	keyStyle := lipgloss.NewStyle().
-		Foreground(theme.TextSecondary).
+		Foreground(theme.Active().TextSecondary).
 		Bold(true)
 
 	var parts []string
@@ -12,6 +12,18 @@ const BreakpointStandard = 100
 // BreakpointWide is for terminals over 100 chars wide
 const BreakpointWide = 140
 
+// MinWidth and MinHeight are the smallest terminal the TUI renders in.
+// Widths between MinWidth and BreakpointCompact use the compact layouts.
+const (
+	MinWidth  = 40
+	MinHeight = 20
+)
+
+// TooSmall reports whether the terminal is below the minimum size
+func TooSmall(width, height int) bool {
+	return width < MinWidth || height < MinHeight
+}
+
 // Breakpoint returns the current layout breakpoint
 func Breakpoint(width int) string {
 	switch {
@@ -93,6 +105,16 @@ func CenterVertical(content string, height int) string {
 	return lipgloss.PlaceVertical(height, lipgloss.Center, content)
 }
 
+// Bottom pins footer to the last line of height and pushes content down
+// onto it. Content taller than the room left is returned as is.
+func Bottom(content, footer string, height int) string {
+	room := height - lipgloss.Height(footer)
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.PlaceVertical(room, lipgloss.Bottom, content),
+		footer,
+	)
+}
+
 // CenterBoth centers content both horizontally and vertically
 func CenterBoth(content string, width, height int) string {
 	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
@@ -133,6 +155,15 @@ func Rows(gap int, rows ...string) string {
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
+// Truncate shortens s to at most width runes, adding an ellipsis
+func Truncate(s string, width int) string {
+	r := []rune(s)
+	if width <= 1 || len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
+
 // max returns the larger of two ints
 func max(a, b int) int {
 	if a > b {
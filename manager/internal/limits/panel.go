@@ -0,0 +1,297 @@
+// Package limits provides the live circuit breaker and rate limit panel.
+package limits
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// refreshInterval is how often the panel samples the bridge while open.
+const refreshInterval = 2 * time.Second
+
+// historySize is how many samples are kept: ten minutes at the refresh
+// interval.
+const historySize = 300
+
+// LoadedMsg is sent when the limits have been fetched.
+type LoadedMsg struct {
+	Limits *status.LimitsStatus
+	Err    error
+}
+
+// TickMsg triggers a sample while the panel is open.
+type TickMsg time.Time
+
+// sample is one point of the panel's history.
+type sample struct {
+	open      int // Sessions with an open breaker
+	errors    int // Errors counted across sessions
+	peakUsage int // Highest sender usage of the rate limit, in percent
+	blocked   int // Requests refused by the rate limiter
+}
+
+// Panel shows the breaker of each session and the rate limit use of each
+// sender, with a history of both kept while the manager runs.
+type Panel struct {
+	client  *status.Client
+	limits  *status.LimitsStatus
+	history []sample
+	trips   int // Breakers seen going from closed to open
+	open    map[string]bool
+	since   time.Time
+	err     error
+}
+
+// NewPanel creates a limits panel backed by the given bridge client.
+func NewPanel(client *status.Client) *Panel {
+	return &Panel{client: client, open: map[string]bool{}, since: time.Now()}
+}
+
+// Init fetches the limits and starts the refresh loop.
+func (p *Panel) Init() tea.Cmd {
+	return tea.Batch(p.fetchCmd(), tickCmd())
+}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
+		return TickMsg(t)
+	})
+}
+
+func (p *Panel) fetchCmd() tea.Cmd {
+	client := p.client
+	return func() tea.Msg {
+		limits, err := client.GetLimits()
+		return LoadedMsg{Limits: limits, Err: err}
+	}
+}
+
+// Update handles limits messages and keyboard input.
+func (p *Panel) Update(msg tea.Msg) (*Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		p.err = msg.Err
+		if msg.Err == nil {
+			p.record(msg.Limits)
+		}
+		return p, nil
+
+	case TickMsg:
+		return p, tea.Batch(p.fetchCmd(), tickCmd())
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return p, p.fetchCmd()
+		case "c":
+			// Start the history over, e.g. after changing a setting
+			p.history, p.trips, p.since = nil, 0, time.Now()
+		}
+	}
+	return p, nil
+}
+
+// record stores the latest limits and appends them to the history.
+func (p *Panel) record(l *status.LimitsStatus) {
+	p.limits = l
+
+	var s sample
+	open := map[string]bool{}
+	for _, b := range l.CircuitBreaker.Sessions {
+		s.errors += b.ErrorCount
+		if b.State == status.BreakerOpen {
+			s.open++
+			open[b.SessionID] = true
+			if !p.open[b.SessionID] {
+				p.trips++
+			}
+		}
+	}
+	p.open = open
+
+	for _, k := range l.RateLimit.Keys {
+		if l.RateLimit.MaxRequests > 0 {
+			s.peakUsage = max(s.peakUsage, k.Count*100/l.RateLimit.MaxRequests)
+		}
+		s.blocked += k.Blocked
+	}
+
+	p.history = append(p.history, s)
+	if len(p.history) > historySize {
+		p.history = p.history[len(p.history)-historySize:]
+	}
+}
+
+// HelpKeys returns the help bar entries.
+func (p *Panel) HelpKeys() []string {
+	return []string{"r Refresh", "c Clear history", "Esc Back"}
+}
+
+// breakerBadge returns the label and style for a breaker state.
+func breakerBadge(state string) (string, lipgloss.Style) {
+	switch state {
+	case status.BreakerOpen:
+		return "● open", theme.StatusError()
+	case status.BreakerHalfOpen:
+		return "◐ half-open", theme.StatusWarning()
+	default:
+		return "○ closed", theme.StatusSuccess()
+	}
+}
+
+// formatMs renders milliseconds as a short duration ("1s", "5m").
+func formatMs(ms int) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
+
+// usageBar draws count out of limit as a fixed-width bar.
+func usageBar(count, limit, width int) string {
+	if limit <= 0 {
+		return ""
+	}
+	filled := min(width, count*width/limit)
+	style := theme.StatusSuccess()
+	switch {
+	case count >= limit:
+		style = theme.StatusError()
+	case count*100 >= limit*80:
+		style = theme.StatusWarning()
+	}
+	return style.Render(strings.Repeat("█", filled)) + theme.Muted().Render(strings.Repeat("░", width-filled))
+}
+
+// series extracts one field of the history.
+func (p *Panel) series(field func(sample) int) []int {
+	values := make([]int, len(p.history))
+	for i, s := range p.history {
+		values[i] = field(s)
+	}
+	return values
+}
+
+// View renders the panel for the given width.
+func (p *Panel) View(width int) string {
+	var s strings.Builder
+
+	switch {
+	case p.limits == nil && p.err == nil:
+		s.WriteString(theme.StatusInfo().Render("   Loading limits...") + "\n")
+		return s.String()
+	case p.limits == nil:
+		s.WriteString(theme.StatusError().Render("   ● Limits API unavailable") + "\n")
+		s.WriteString(theme.Subtitle().Render("   "+p.err.Error()) + "\n")
+		return s.String()
+	}
+	cb, rl := p.limits.CircuitBreaker, p.limits.RateLimit
+	now := time.Now()
+
+	// Circuit breakers
+	backoff := make([]string, len(cb.BackoffMs))
+	for i, ms := range cb.BackoffMs {
+		backoff[i] = formatMs(ms)
+	}
+	s.WriteString(heading("Circuit breakers", fmt.Sprintf("opens after %d errors · backoff %s · resets after %s quiet",
+		cb.Threshold, strings.Join(backoff, "/"), formatMs(cb.ResetMs)), width))
+	sessions := append([]status.SessionBreaker(nil), cb.Sessions...)
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].ErrorCount > sessions[j].ErrorCount
+	})
+	if len(sessions) == 0 {
+		s.WriteString(theme.Subtitle().Render("   No session has recorded errors.") + "\n")
+	}
+	for _, b := range sessions {
+		label, style := breakerBadge(b.State)
+		detail := ""
+		if b.LastErrorAt != nil {
+			detail = "last error " + now.Sub(*b.LastErrorAt).Round(time.Second).String() + " ago"
+		}
+		if b.State == status.BreakerOpen && b.RetryAt != nil {
+			detail += fmt.Sprintf(" · retry in %s", max(0, b.RetryAt.Sub(now)).Round(time.Second))
+		}
+		s.WriteString(fmt.Sprintf("   %s %s %s %s\n",
+			style.Width(12).Render(label),
+			theme.Value().Width(18).Render(truncate(b.SessionID, 17)),
+			theme.Muted().Width(10).Render(fmt.Sprintf("%d/%d errors", b.ErrorCount, cb.Threshold)),
+			theme.Muted().Render(detail)))
+	}
+	s.WriteString("\n")
+
+	// Rate limits
+	s.WriteString(heading("Rate limits", fmt.Sprintf("%d requests per %s per number", rl.MaxRequests, formatMs(rl.WindowMs)), width))
+	keys := append([]status.RateLimitKey(nil), rl.Keys...)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].Count > keys[j].Count
+	})
+	if len(keys) == 0 {
+		s.WriteString(theme.Subtitle().Render("   No requests in the current window.") + "\n")
+	}
+	for _, k := range keys {
+		line := fmt.Sprintf("   %s %s %s",
+			theme.Value().Width(18).Render(truncate("+"+k.Key, 17)),
+			usageBar(k.Count, rl.MaxRequests, 20),
+			theme.Muted().Render(fmt.Sprintf("%d/%d", k.Count, rl.MaxRequests)))
+		if k.Blocked > 0 {
+			line += theme.StatusError().Render(fmt.Sprintf("  %d blocked", k.Blocked))
+		}
+		s.WriteString(line + "\n")
+	}
+	s.WriteString("\n")
+
+	// History
+	sparkWidth := max(10, min(historySize, width-30))
+	s.WriteString(heading("History", fmt.Sprintf("since %s · %d breaker trips", p.since.Format("15:04:05"), p.trips), width))
+	rows := []struct {
+		label string
+		field func(sample) int
+		color lipgloss.TerminalColor
+	}{
+		{"Open breakers", func(s sample) int { return s.open }, theme.Active().Error},
+		{"Session errors", func(s sample) int { return s.errors }, theme.Active().Warning},
+		{"Peak rate use %", func(s sample) int { return s.peakUsage }, theme.Active().Info},
+		{"Blocked requests", func(s sample) int { return s.blocked }, theme.Active().Error},
+	}
+	for _, row := range rows {
+		values := p.series(row.field)
+		latest := 0
+		if n := len(values); n > 0 {
+			latest = values[n-1]
+		}
+		s.WriteString(fmt.Sprintf("   %s %s %s\n",
+			theme.Muted().Width(17).Render(row.label),
+			components.Sparkline(values, sparkWidth, row.color),
+			theme.Value().Render(fmt.Sprint(latest))))
+	}
+
+	if p.err != nil {
+		s.WriteString("\n" + theme.StatusError().Render("   ❌ "+p.err.Error()) + "\n")
+	}
+	return s.String()
+}
+
+// heading renders a section title with its settings beside it, or under it
+// when they don't fit the width.
+func heading(title, detail string, width int) string {
+	line := "   " + theme.Value().Bold(true).Render(title)
+	if lipgloss.Width(line)+2+lipgloss.Width(detail) > width {
+		return line + "\n" + theme.Muted().Render("   "+detail) + "\n"
+	}
+	return line + theme.Muted().Render("  "+detail) + "\n"
+}
+
+// truncate shortens s to at most n runes, adding an ellipsis.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if n <= 1 || len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
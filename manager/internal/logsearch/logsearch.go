@@ -0,0 +1,181 @@
+// Package logsearch greps the log history Docker keeps for Fetch's
+// containers, which reaches back days further than the live log buffer.
+// Matches from every container are merged into time order and streamed in
+// batches so results appear while older logs are still being read.
+package logsearch
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fetch/manager/internal/docker"
+)
+
+const (
+	// MaxMatches stops a search that matches too much to be useful.
+	MaxMatches = 5000
+	// flushEvery bounds how long found matches wait before being sent.
+	flushEvery = 250 * time.Millisecond
+	// maxLine is the longest log line read; longer lines end the search.
+	maxLine = 1 << 20
+)
+
+// Window is how far back a search reads.
+type Window struct {
+	Label string
+	Since string // Passed to docker logs --since; empty for everything
+}
+
+// Windows are the choices offered, narrowest first.
+var Windows = []Window{
+	{"24 hours", "24h"},
+	{"7 days", "168h"},
+	{"30 days", "720h"},
+	{"all history", ""},
+}
+
+// Query describes one search.
+type Query struct {
+	Text       string // Case-insensitive substring
+	Window     Window
+	Containers []string // Searched together, e.g. fetch-bridge and fetch-kennel
+}
+
+// Match is one matching log line.
+type Match struct {
+	Container string
+	Time      time.Time // From Docker, so it carries the date
+	Line      string    // Without Docker's timestamp or color codes
+}
+
+// Batch is the next part of a search's results. The last batch has Done
+// set; Err reports a container whose logs couldn't be read.
+type Batch struct {
+	Matches   []Match
+	Scanned   int // Lines read so far across all containers
+	Truncated bool
+	Done      bool
+	Err       error
+}
+
+var ansi = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// StripANSI removes terminal color codes from a log line.
+func StripANSI(s string) string {
+	return ansi.ReplaceAllString(s, "")
+}
+
+// source reads one container's logs a matching line at a time.
+type source struct {
+	container string
+	scanner   *bufio.Scanner
+	head      *Match // Next match, nil when exhausted
+	err       error
+}
+
+// Search starts a search and returns its batches. The channel is closed
+// after the Done batch, or early once ctx is cancelled.
+func Search(ctx context.Context, q Query) <-chan Batch {
+	out := make(chan Batch)
+	go func() {
+		defer close(out)
+		send := func(b Batch) bool {
+			select {
+			case out <- b:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		needle := strings.ToLower(q.Text)
+		scanned := 0
+		sources := make([]*source, 0, len(q.Containers))
+		for _, name := range q.Containers {
+			r := docker.StreamLogs(ctx, name, q.Window.Since)
+			defer r.Close()
+			sc := bufio.NewScanner(r)
+			sc.Buffer(make([]byte, 64*1024), maxLine)
+			sources = append(sources, &source{container: name, scanner: sc})
+		}
+
+		var batch Batch
+		var errs []string
+		lastFlush := time.Now()
+		total := 0
+		for _, s := range sources {
+			s.advance(needle, &scanned)
+		}
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			// Emit the earliest head so containers interleave by time
+			var next *source
+			for _, s := range sources {
+				if s.head != nil && (next == nil || s.head.Time.Before(next.head.Time)) {
+					next = s
+				}
+			}
+			if next == nil {
+				break
+			}
+			batch.Matches = append(batch.Matches, *next.head)
+			total++
+			if total >= MaxMatches {
+				batch.Truncated = true
+				break
+			}
+			next.advance(needle, &scanned)
+
+			if time.Since(lastFlush) >= flushEvery {
+				batch.Scanned = scanned
+				if !send(batch) {
+					return
+				}
+				batch, lastFlush = Batch{}, time.Now()
+			}
+		}
+
+		for _, s := range sources {
+			if s.err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", s.container, s.err))
+			}
+		}
+		batch.Scanned, batch.Done = scanned, true
+		if len(errs) > 0 {
+			batch.Err = fmt.Errorf("couldn't read logs for %s", strings.Join(errs, "; "))
+		}
+		send(batch)
+	}()
+	return out
+}
+
+// advance reads lines until the next match or the end of the logs.
+func (s *source) advance(needle string, scanned *int) {
+	s.head = nil
+	for s.scanner.Scan() {
+		*scanned++
+		raw := s.scanner.Text()
+		t, line, ok := docker.SplitTimestamp(raw)
+		if !ok {
+			// Docker's own errors ("No such container") have no timestamp
+			s.err = errors.New(strings.TrimSpace(StripANSI(raw)))
+			continue
+		}
+		line = StripANSI(line)
+		if needle != "" && !strings.Contains(strings.ToLower(line), needle) {
+			continue
+		}
+		s.head = &Match{Container: s.container, Time: t, Line: line}
+		return
+	}
+	if err := s.scanner.Err(); err != nil && s.err == nil {
+		s.err = err
+	}
+}
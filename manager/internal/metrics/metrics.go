@@ -0,0 +1,134 @@
+// Package metrics exposes Fetch's health as Prometheus gauges in the text
+// exposition format, so homelab setups can alert on it from Grafana.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fetch/manager/internal/status"
+)
+
+// BridgeStates lists every state the bridge reports. Each gets a series in
+// fetch_whatsapp_state so a state that stops being current drops to 0
+// instead of disappearing.
+var BridgeStates = []string{"initializing", "qr_pending", "authenticating", "authenticated", "disconnected", "error"}
+
+// Snapshot is everything exported on one scrape.
+type Snapshot struct {
+	Containers map[string]bool      // Container name → running
+	Bridge     *status.BridgeStatus // Nil when the bridge API didn't answer
+	// Update availability; omitted while UpdatesChecked is false
+	UpdatesChecked  bool
+	UpdatesPending  int  // Commits waiting to be pulled
+	ManagerOutdated bool // A newer manager release exists
+	Version         string
+}
+
+// Write renders s in the Prometheus text exposition format.
+func Write(w io.Writer, s Snapshot) {
+	gauge(w, "fetch_manager_info", "Manager build information.")
+	fmt.Fprintf(w, "fetch_manager_info{version=%q} 1\n", s.Version)
+
+	gauge(w, "fetch_container_up", "Whether a Fetch container is running (1) or not (0).")
+	names := make([]string, 0, len(s.Containers))
+	for name := range s.Containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "fetch_container_up{container=%q} %d\n", name, boolValue(s.Containers[name]))
+	}
+
+	gauge(w, "fetch_bridge_up", "Whether the bridge status API answered (1) or not (0).")
+	fmt.Fprintf(w, "fetch_bridge_up %d\n", boolValue(s.Bridge != nil))
+
+	// Always present so "disconnected for 5m" alerts also fire when the
+	// bridge itself is down
+	gauge(w, "fetch_whatsapp_connected", "Whether WhatsApp is linked and connected (1) or not (0).")
+	fmt.Fprintf(w, "fetch_whatsapp_connected %d\n", boolValue(s.Bridge != nil && s.Bridge.State == "authenticated"))
+
+	if s.Bridge != nil {
+		gauge(w, "fetch_whatsapp_state", "The bridge's WhatsApp state; 1 for the current state.")
+		for _, state := range BridgeStates {
+			fmt.Fprintf(w, "fetch_whatsapp_state{state=%q} %d\n", state, boolValue(s.Bridge.State == state))
+		}
+
+		gauge(w, "fetch_bridge_uptime_seconds", "Seconds since the bridge started.")
+		fmt.Fprintf(w, "fetch_bridge_uptime_seconds %d\n", s.Bridge.Uptime)
+
+		fmt.Fprintf(w, "# HELP fetch_messages_processed_total Messages processed since the bridge started.\n")
+		fmt.Fprintf(w, "# TYPE fetch_messages_processed_total counter\n")
+		fmt.Fprintf(w, "fetch_messages_processed_total %d\n", s.Bridge.MessageCount)
+	}
+
+	if s.UpdatesChecked {
+		gauge(w, "fetch_updates_pending", "Commits available to pull with Update Fetch.")
+		fmt.Fprintf(w, "fetch_updates_pending %d\n", s.UpdatesPending)
+
+		gauge(w, "fetch_manager_update_available", "Whether a newer manager release exists (1) or not (0).")
+		fmt.Fprintf(w, "fetch_manager_update_available %d\n", boolValue(s.ManagerOutdated))
+	}
+}
+
+func gauge(w io.Writer, name, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+func boolValue(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Handler serves /metrics, calling collect on every scrape.
+func Handler(collect func() Snapshot) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		Write(w, collect())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, "Fetch Manager metrics are at /metrics\n")
+	})
+	return mux
+}
+
+// Serve listens on addr until ctx is cancelled. A bare port (":9091" or
+// "9091") listens on every interface.
+func Serve(ctx context.Context, addr string, collect func() Snapshot) error {
+	if !strings.Contains(addr, ":") {
+		addr = ":" + addr
+	}
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           Handler(collect),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
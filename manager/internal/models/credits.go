@@ -0,0 +1,164 @@
+// This file reads what an OpenRouter key has left to spend and how fast it
+// may make requests.
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LowBalance is the balance in dollars below which credits are shown as
+// running low: a long task can spend this much, and OpenRouter fails the
+// task's requests once it runs out.
+const LowBalance = 1.0
+
+// Credits is what an OpenRouter key may still spend.
+type Credits struct {
+	Label    string
+	Usage    float64  // Dollars spent with this key
+	Limit    *float64 // The key's spending cap; nil when it has none
+	Left     *float64 // What the cap leaves; nil when there's no cap
+	FreeTier bool     // The account has never bought credits
+	// RateLimit is nil when OpenRouter doesn't report one
+	RateLimit *RateLimit
+
+	// The account's purchases and spending across keys, nil when the key
+	// may not read them
+	TotalCredits *float64
+	TotalUsage   *float64
+}
+
+// RateLimit is how many requests a key may make per interval.
+type RateLimit struct {
+	Requests int    `json:"requests"`
+	Interval string `json:"interval"` // e.g. "10s"
+}
+
+// Balance returns the dollars the key can still spend: the account's
+// balance or the key's cap, whichever is lower. ok is false when neither is
+// known, as for a key without a cap that may not read the account.
+func (c Credits) Balance() (balance float64, ok bool) {
+	if c.TotalCredits != nil && c.TotalUsage != nil {
+		balance, ok = *c.TotalCredits-*c.TotalUsage, true
+	}
+	if c.Left != nil && (!ok || *c.Left < balance) {
+		balance, ok = *c.Left, true
+	}
+	return max(balance, 0), ok
+}
+
+// Low reports whether the balance is known and under LowBalance. Free tier
+// keys only use free models, so they are never low.
+func (c Credits) Low() bool {
+	balance, ok := c.Balance()
+	return ok && !c.FreeTier && balance < LowBalance
+}
+
+// Summary describes the balance and rate limit in a few words, e.g.
+// "$12.34 left · 20 requests/10s".
+func (c Credits) Summary() string {
+	s := "balance unknown"
+	if balance, ok := c.Balance(); ok {
+		s = fmt.Sprintf("$%.2f left", balance)
+	}
+	if c.FreeTier {
+		s = "free tier"
+	}
+	if c.RateLimit != nil && c.RateLimit.Requests > 0 {
+		s += fmt.Sprintf(" · %d requests/%s", c.RateLimit.Requests, c.RateLimit.Interval)
+	}
+	return s
+}
+
+// CreditsLoadedMsg is sent when the key's credits are fetched.
+type CreditsLoadedMsg struct {
+	Credits *Credits
+	Err     error
+}
+
+// fixedCredits replaces OpenRouter's answer when set by UseFixedCredits.
+var fixedCredits *Credits
+
+// UseFixedCredits makes FetchCredits return c instead of asking
+// OpenRouter, for demo mode.
+func UseFixedCredits(c Credits) {
+	fixedCredits = &c
+}
+
+// FetchCredits reads the key's limits from OpenRouter's key info endpoint
+// and, when the key may, the account balance from the credits endpoint.
+// Each request gives up after 10 seconds or when ctx is cancelled.
+func FetchCredits(ctx context.Context, apiKey string) (*Credits, error) {
+	if fixedCredits != nil {
+		c := *fixedCredits
+		return &c, nil
+	}
+
+	var key struct {
+		Data struct {
+			Label          string     `json:"label"`
+			Usage          float64    `json:"usage"`
+			Limit          *float64   `json:"limit"`
+			LimitRemaining *float64   `json:"limit_remaining"`
+			IsFreeTier     bool       `json:"is_free_tier"`
+			RateLimit      *RateLimit `json:"rate_limit"`
+		} `json:"data"`
+	}
+	if err := getOpenRouter(ctx, apiKey, "/auth/key", &key); err != nil {
+		return nil, err
+	}
+	c := &Credits{
+		Label:     key.Data.Label,
+		Usage:     key.Data.Usage,
+		Limit:     key.Data.Limit,
+		Left:      key.Data.LimitRemaining,
+		FreeTier:  key.Data.IsFreeTier,
+		RateLimit: key.Data.RateLimit,
+	}
+
+	// Only some keys may read the account; the key's own limits are still
+	// worth showing without it
+	var account struct {
+		Data struct {
+			TotalCredits float64 `json:"total_credits"`
+			TotalUsage   float64 `json:"total_usage"`
+		} `json:"data"`
+	}
+	if err := getOpenRouter(ctx, apiKey, "/credits", &account); err == nil {
+		c.TotalCredits = &account.Data.TotalCredits
+		c.TotalUsage = &account.Data.TotalUsage
+	}
+	return c, nil
+}
+
+// getOpenRouter decodes the JSON an OpenRouter API path returns into v.
+func getOpenRouter(ctx context.Context, apiKey, path string, v any) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://openrouter.ai/api/v1"+path, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("contacting OpenRouter: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("key rejected by OpenRouter (%d)", resp.StatusCode)
+	default:
+		return fmt.Errorf("API error %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package models
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -51,11 +52,12 @@ type Category struct {
 	Models []Model
 }
 
-// FetchModels retrieves available models from OpenRouter
-func FetchModels(apiKey string) ([]Model, error) {
+// FetchModels retrieves available models from OpenRouter. The request
+// gives up after 10 seconds or when ctx is cancelled.
+func FetchModels(ctx context.Context, apiKey string) ([]Model, error) {
 	client := &http.Client{Timeout: 10 * time.Second}
 
-	req, err := http.NewRequest("GET", "https://openrouter.ai/api/v1/models", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://openrouter.ai/api/v1/models", nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -82,6 +84,12 @@ func FetchModels(apiKey string) ([]Model, error) {
 	return modelsResp.Data, nil
 }
 
+// ValidateAPIKey checks an OpenRouter API key against the key info endpoint.
+func ValidateAPIKey(apiKey string) error {
+	var info struct{}
+	return getOpenRouter(context.Background(), apiKey, "/auth/key", &info)
+}
+
 // FilterToolCapable returns only models that support function calling (tools).
 func FilterToolCapable(models []Model) []Model {
 	var filtered []Model
@@ -2,50 +2,56 @@
 package models
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/theme"
 )
 
-var (
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FF6B35"))
+// Styles read the active theme each time so theme changes apply at once.
+func titleStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true)
+}
 
-	selectedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#00ff00")).
-			Bold(true)
+func selectedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Success).Bold(true)
+}
 
-	normalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#cccccc"))
+func normalStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextPrimary)
+}
 
-	dimStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666"))
+func dimStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextMuted)
+}
 
-	categoryStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#00bfff")).
-			Bold(true).
-			MarginTop(1)
+func categoryStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Info).Bold(true).MarginTop(1)
+}
 
-	priceStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#888888"))
+func priceStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().TextSecondary)
+}
 
-	currentStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFD700")).
-			Bold(true)
+func currentStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Warning).Bold(true)
+}
 
-	ctxStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#9B59B6"))
+func ctxStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Secondary)
+}
 
-	modalityStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#E67E22"))
+func modalityStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Primary)
+}
 
-	toolsBadgeStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#2ECC71")).
-			Bold(true)
-)
+func toolsBadgeStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Active().Success).Bold(true)
+}
 
 // SelectionState represents the current state of the model selection UI.
 type SelectionState int
@@ -75,9 +81,29 @@ type Selector struct {
 	errorMessage string
 	width        int
 	height       int
-	showAll      bool // Show all models or just recommended
+	showAll      bool     // Show all models or just recommended
+	credits      *Credits // nil until fetched, or when that failed
+
+	// Only the rows in view are drawn. offset is the first of them and
+	// viewHeight the lines they may fill.
+	offset     int
+	viewHeight int
+
+	// lists holds the grouped list for each showAll setting so Tab doesn't
+	// regroup hundreds of models; rows memoizes styled rows of flatList.
+	lists     map[bool][]listItem
+	rows      []string
+	rowsTheme *theme.Theme
+	rowsModel string // currentModel when rows were styled
+
+	// cancel stops the model list request when the picker closes first
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
+// defaultViewHeight is used until SetSize is called.
+const defaultViewHeight = 20
+
 // listItem represents an item in the flattened model list.
 type listItem struct {
 	isCategory bool
@@ -98,22 +124,77 @@ type ModelSavedMsg struct {
 
 // NewSelector creates a new model selector
 func NewSelector() *Selector {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Selector{
 		state:        StateLoading,
 		currentModel: GetCurrentModel(),
 		showAll:      false,
+		ctx:          ctx,
+		cancel:       cancel,
 	}
 }
 
-// FetchModelsCmd fetches models from OpenRouter
-func FetchModelsCmd() tea.Msg {
-	apiKey := GetAPIKey()
-	if apiKey == "" {
-		return ModelsLoadedMsg{Err: fmt.Errorf("OPENROUTER_API_KEY not configured")}
+// Close stops a model list request still running. Call it when the picker
+// is dismissed.
+func (s *Selector) Close() {
+	s.cancel()
+}
+
+// ShowAll reports whether every model is listed rather than just the
+// recommended ones.
+func (s *Selector) ShowAll() bool {
+	return s.showAll
+}
+
+// SetShowAll switches between all and recommended models.
+func (s *Selector) SetShowAll(all bool) {
+	s.showAll = all
+	s.rebuildList()
+}
+
+// SetSize sets the lines available to the selector, including its header.
+func (s *Selector) SetSize(height int) {
+	// Title, current model, credits, hints and the model count take eight
+	// lines
+	s.viewHeight = max(5, height-8)
+	s.ensureVisible()
+}
+
+// fixedModels replaces OpenRouter's list when set by UseFixed.
+var fixedModels []Model
+
+// UseFixed makes selectors list models instead of asking OpenRouter, for
+// demo mode.
+func UseFixed(models []Model) {
+	fixedModels = models
+}
+
+// fetchModelsCmd fetches models from OpenRouter until ctx is cancelled.
+func fetchModelsCmd(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		if fixedModels != nil {
+			return ModelsLoadedMsg{Models: fixedModels}
+		}
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return ModelsLoadedMsg{Err: fmt.Errorf("OPENROUTER_API_KEY not configured")}
+		}
+
+		models, err := FetchModels(ctx, apiKey)
+		return ModelsLoadedMsg{Models: models, Err: err}
 	}
+}
 
-	models, err := FetchModels(apiKey)
-	return ModelsLoadedMsg{Models: models, Err: err}
+// fetchCreditsCmd fetches the key's credits until ctx is cancelled.
+func fetchCreditsCmd(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		apiKey := GetAPIKey()
+		if apiKey == "" && fixedCredits == nil {
+			return CreditsLoadedMsg{Err: fmt.Errorf("OPENROUTER_API_KEY not configured")}
+		}
+		credits, err := FetchCredits(ctx, apiKey)
+		return CreditsLoadedMsg{Credits: credits, Err: err}
+	}
 }
 
 // SaveModelCmd saves the selected model
@@ -126,7 +207,7 @@ func SaveModelCmd(modelID string) tea.Cmd {
 
 // Init initializes the selector
 func (s *Selector) Init() tea.Cmd {
-	return FetchModelsCmd
+	return tea.Batch(fetchModelsCmd(s.ctx), fetchCreditsCmd(s.ctx))
 }
 
 // Update handles messages
@@ -139,12 +220,18 @@ func (s *Selector) Update(msg tea.Msg) (*Selector, tea.Cmd) {
 			return s, nil
 		}
 		s.models = msg.Models
+		s.lists = nil
 		s.rebuildList()
 		s.state = StateLoaded
 		// Move cursor to current model
 		s.moveToCurrent()
 		return s, nil
 
+	case CreditsLoadedMsg:
+		// The header just goes without credits when they can't be read
+		s.credits = msg.Credits
+		return s, nil
+
 	case ModelSavedMsg:
 		if msg.Err != nil {
 			s.state = StateError
@@ -156,6 +243,14 @@ func (s *Selector) Update(msg tea.Msg) (*Selector, tea.Cmd) {
 
 	case tea.KeyMsg:
 		return s.handleKey(msg)
+
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			s.moveCursor(-1)
+		case tea.MouseButtonWheelDown:
+			s.moveCursor(1)
+		}
 	}
 
 	return s, nil
@@ -167,10 +262,26 @@ func (s *Selector) handleKey(msg tea.KeyMsg) (*Selector, tea.Cmd) {
 		s.moveCursor(-1)
 	case "down", "j":
 		s.moveCursor(1)
+	case "pgup":
+		s.jump(-s.pageSize())
+	case "pgdown":
+		s.jump(s.pageSize())
+	case "home":
+		s.jump(-len(s.flatList))
+	case "end":
+		s.jump(len(s.flatList))
 	case "tab":
-		// Toggle between recommended and all models
+		// Toggle between recommended and all models, staying on the same
+		// model when it's in both
+		var id string
+		if s.cursor < len(s.flatList) {
+			id = s.flatList[s.cursor].model.ID
+		}
 		s.showAll = !s.showAll
 		s.rebuildList()
+		if id != "" {
+			s.moveTo(id)
+		}
 	case "enter", " ":
 		if s.state == StateLoaded && s.cursor < len(s.flatList) {
 			item := s.flatList[s.cursor]
@@ -198,43 +309,117 @@ func (s *Selector) moveCursor(delta int) {
 	if newCursor >= 0 && newCursor < len(s.flatList) {
 		s.cursor = newCursor
 	}
+	s.ensureVisible()
+}
+
+// jump moves the cursor by up to delta rows, stopping on the first or
+// last model instead of staying put when fewer rows are left.
+func (s *Selector) jump(delta int) {
+	if len(s.flatList) == 0 {
+		return
+	}
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	// Walk back from the target towards the cursor to land on a model
+	for i := min(max(s.cursor+delta, 0), len(s.flatList)-1); i != s.cursor-step; i -= step {
+		if !s.flatList[i].isCategory {
+			s.cursor = i
+			break
+		}
+	}
+	s.ensureVisible()
+}
+
+// pageSize is how many rows PgUp and PgDn move.
+func (s *Selector) pageSize() int {
+	if s.viewHeight <= 0 {
+		return defaultViewHeight
+	}
+	return max(1, s.viewHeight-1)
 }
 
 func (s *Selector) moveToCurrent() {
+	s.moveTo(s.currentModel)
+}
+
+// moveTo puts the cursor on the model with the given ID, if it's listed.
+func (s *Selector) moveTo(id string) {
 	for i, item := range s.flatList {
-		if !item.isCategory && item.model.ID == s.currentModel {
+		if !item.isCategory && item.model.ID == id {
 			s.cursor = i
+			s.ensureVisible()
 			return
 		}
 	}
 }
 
+// lineCount is how many lines row i takes; headers have a blank line above.
+func (s *Selector) lineCount(i int) int {
+	if s.flatList[i].isCategory {
+		return 2
+	}
+	return 1
+}
+
+// ensureVisible scrolls the least needed to show the cursor, along with
+// the header of its category when that's just above it.
+func (s *Selector) ensureVisible() {
+	height := s.viewHeight
+	if height <= 0 {
+		height = defaultViewHeight
+	}
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.offset > 0 && s.offset == s.cursor && s.flatList[s.offset-1].isCategory {
+		s.offset--
+	}
+	for s.offset < s.cursor {
+		lines := 0
+		for i := s.offset; i <= s.cursor; i++ {
+			lines += s.lineCount(i)
+		}
+		if lines <= height {
+			break
+		}
+		s.offset++
+	}
+}
+
 func (s *Selector) rebuildList() {
-	s.flatList = nil
-
-	var modelsToShow []Model
-	if s.showAll {
-		modelsToShow = s.models
-	} else {
-		modelsToShow = FilterToolCapable(s.models)
-	}
-
-	categories := GroupByProvider(modelsToShow)
-
-	for _, cat := range categories {
-		// Add category header
-		s.flatList = append(s.flatList, listItem{
-			isCategory: true,
-			category:   cat.Name,
-		})
-		// Add models
-		for _, m := range cat.Models {
-			s.flatList = append(s.flatList, listItem{
-				isCategory: false,
-				model:      m,
+	if s.lists == nil {
+		s.lists = make(map[bool][]listItem)
+	}
+	list, ok := s.lists[s.showAll]
+	if !ok {
+		var modelsToShow []Model
+		if s.showAll {
+			modelsToShow = s.models
+		} else {
+			modelsToShow = FilterToolCapable(s.models)
+		}
+
+		for _, cat := range GroupByProvider(modelsToShow) {
+			// Add category header
+			list = append(list, listItem{
+				isCategory: true,
+				category:   cat.Name,
 			})
+			// Add models
+			for _, m := range cat.Models {
+				list = append(list, listItem{
+					isCategory: false,
+					model:      m,
+				})
+			}
 		}
+		s.lists[s.showAll] = list
 	}
+	s.flatList = list
+	s.rows = nil
+	s.offset = 0
 
 	// Ensure cursor is valid
 	if s.cursor >= len(s.flatList) {
@@ -244,6 +429,84 @@ func (s *Selector) rebuildList() {
 	if len(s.flatList) > 0 && s.flatList[s.cursor].isCategory {
 		s.moveCursor(1)
 	}
+	s.ensureVisible()
+}
+
+// row returns row i styled. Rows other than the cursor's are memoized
+// until the list, the theme or the current model changes.
+func (s *Selector) row(i int) string {
+	if i == s.cursor {
+		return s.renderRow(i)
+	}
+	if t := theme.Active(); s.rows == nil || s.rowsTheme != t || s.rowsModel != s.currentModel {
+		s.rows = make([]string, len(s.flatList))
+		s.rowsTheme, s.rowsModel = t, s.currentModel
+	}
+	if s.rows[i] == "" {
+		s.rows[i] = s.renderRow(i)
+	}
+	return s.rows[i]
+}
+
+func (s *Selector) renderRow(i int) string {
+	item := s.flatList[i]
+	if item.isCategory {
+		return categoryStyle().Render("─── " + item.category + " ───")
+	}
+
+	// Model line
+	prefix := "  "
+	style := normalStyle()
+	if i == s.cursor {
+		prefix = "▸ "
+		style = selectedStyle()
+	}
+
+	isCurrent := item.model.ID == s.currentModel
+	modelName := item.model.ID
+	if isCurrent {
+		modelName += " ★"
+		if i != s.cursor {
+			style = currentStyle()
+		}
+	}
+
+	// Context window
+	ctx := ctxStyle().Render(FormatContextLength(item.model.ContextLength))
+
+	// Format pricing (per million tokens)
+	promptPrice := FormatPrice(item.model.Pricing.Prompt)
+	price := priceStyle().Render(promptPrice)
+
+	// Modality badges
+	modality := FormatModality(item.model)
+	if modality != "" {
+		modality = modalityStyle().Render(modality)
+	}
+
+	// Tools badge
+	tools := ""
+	if HasTools(item.model) {
+		tools = toolsBadgeStyle().Render("🔧")
+	}
+
+	// Build the line: prefix modelName | ctx | price | modalities | tools
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteString(style.Render(modelName))
+	b.WriteString(dimStyle().Render(" │ "))
+	b.WriteString(ctx)
+	b.WriteString(dimStyle().Render(" │ "))
+	b.WriteString(price)
+	if modality != "" {
+		b.WriteString(dimStyle().Render(" │ "))
+		b.WriteString(modality)
+	}
+	if tools != "" {
+		b.WriteString(" ")
+		b.WriteString(tools)
+	}
+	return b.String()
 }
 
 // View renders the selector
@@ -251,111 +514,55 @@ func (s *Selector) View() string {
 	var b strings.Builder
 
 	// Title
-	b.WriteString(titleStyle.Render("🤖 Select AI Model"))
+	b.WriteString(titleStyle().Render("🤖 Select AI Model"))
+	b.WriteString("\n")
+	b.WriteString(dimStyle().Render(fmt.Sprintf("Current: %s", s.currentModel)))
+	b.WriteString("\n")
+	if s.credits != nil {
+		b.WriteString(s.creditsLine())
+		b.WriteString("\n")
+	}
 	b.WriteString("\n")
-	b.WriteString(dimStyle.Render(fmt.Sprintf("Current: %s", s.currentModel)))
-	b.WriteString("\n\n")
 
 	switch s.state {
 	case StateLoading:
-		b.WriteString("⏳ Loading models from OpenRouter...")
+		b.WriteString("⏳ Loading models from OpenRouter... (Esc to cancel)")
 
 	case StateError:
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000")).Render("❌ " + s.errorMessage))
+		b.WriteString(lipgloss.NewStyle().Foreground(theme.Active().Error).Render("❌ " + s.errorMessage))
 
 	case StateSaving:
 		b.WriteString("💾 Saving model selection...")
 
 	case StateSaved:
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00")).Render("✅ Model saved! Restart Fetch to apply."))
+		b.WriteString(lipgloss.NewStyle().Foreground(theme.Active().Success).Render("✅ Model saved! Restart Fetch to apply."))
 
 	case StateLoaded:
 		// Show toggle hint
 		if s.showAll {
-			b.WriteString(dimStyle.Render("Showing all models • Tab: show tool-capable only"))
+			b.WriteString(dimStyle().Render("Showing all models • Tab: show tool-capable only"))
 		} else {
-			b.WriteString(dimStyle.Render("Showing tool-capable (🔧) • Tab: show all"))
+			b.WriteString(dimStyle().Render("Showing tool-capable (🔧) • Tab: show all"))
 		}
 		b.WriteString("\n")
-		b.WriteString(dimStyle.Render("↑/↓ navigate • Enter select • Esc back"))
+		b.WriteString(dimStyle().Render("↑/↓ navigate • Enter select • Esc back"))
 		b.WriteString("\n\n")
 
-		// Calculate visible range (simple scrolling)
-		visibleStart := 0
-		visibleEnd := len(s.flatList)
-		maxVisible := 20
-		if len(s.flatList) > maxVisible {
-			visibleStart = s.cursor - maxVisible/2
-			if visibleStart < 0 {
-				visibleStart = 0
-			}
-			visibleEnd = visibleStart + maxVisible
-			if visibleEnd > len(s.flatList) {
-				visibleEnd = len(s.flatList)
-				visibleStart = visibleEnd - maxVisible
-			}
+		// Draw only the rows that fit from offset
+		height := s.viewHeight
+		if height <= 0 {
+			height = defaultViewHeight
 		}
-
-		for i := visibleStart; i < visibleEnd; i++ {
-			item := s.flatList[i]
-
-			if item.isCategory {
-				b.WriteString(categoryStyle.Render("─── " + item.category + " ───"))
-				b.WriteString("\n")
-				continue
-			}
-
-			// Model line
-			prefix := "  "
-			style := normalStyle
-			if i == s.cursor {
-				prefix = "▸ "
-				style = selectedStyle
-			}
-
-			isCurrent := item.model.ID == s.currentModel
-			modelName := item.model.ID
-			if isCurrent {
-				modelName += " ★"
-				if i != s.cursor {
-					style = currentStyle
-				}
-			}
-
-			// Context window
-			ctx := ctxStyle.Render(FormatContextLength(item.model.ContextLength))
-
-			// Format pricing (per million tokens)
-			promptPrice := FormatPrice(item.model.Pricing.Prompt)
-			price := priceStyle.Render(promptPrice)
-
-			// Modality badges
-			modality := FormatModality(item.model)
-			if modality != "" {
-				modality = modalityStyle.Render(modality)
-			}
-
-			// Tools badge
-			tools := ""
-			if HasTools(item.model) {
-				tools = toolsBadgeStyle.Render("🔧")
-			}
-
-			// Build the line: prefix modelName | ctx | price | modalities | tools
-			b.WriteString(prefix)
-			b.WriteString(style.Render(modelName))
-			b.WriteString(dimStyle.Render(" │ "))
-			b.WriteString(ctx)
-			b.WriteString(dimStyle.Render(" │ "))
-			b.WriteString(price)
-			if modality != "" {
-				b.WriteString(dimStyle.Render(" │ "))
-				b.WriteString(modality)
-			}
-			if tools != "" {
-				b.WriteString(" ")
-				b.WriteString(tools)
+		for i, lines := s.offset, 0; i < len(s.flatList); i++ {
+			lines += s.lineCount(i)
+			if lines > height {
+				break
 			}
+			b.WriteString(s.row(i))
+			b.WriteString("\n")
+		}
+		if len(s.flatList) > 0 {
+			b.WriteString(dimStyle().Render(fmt.Sprintf("%d models", s.modelCount())))
 			b.WriteString("\n")
 		}
 	}
@@ -363,6 +570,27 @@ func (s *Selector) View() string {
 	return b.String()
 }
 
+// creditsLine shows the key's balance and rate limit, warning when the
+// balance is low since tasks fail once it runs out.
+func (s *Selector) creditsLine() string {
+	line := "Credits: " + s.credits.Summary()
+	if s.credits.Low() {
+		return currentStyle().Render("⚠ " + line + " — top up at openrouter.ai/credits")
+	}
+	return dimStyle().Render(line)
+}
+
+// modelCount is the number of models listed, without headers.
+func (s *Selector) modelCount() int {
+	n := 0
+	for _, item := range s.flatList {
+		if !item.isCategory {
+			n++
+		}
+	}
+	return n
+}
+
 // IsDone returns true if selection is complete
 func (s *Selector) IsDone() bool {
 	return s.state == StateSaved
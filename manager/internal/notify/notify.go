@@ -0,0 +1,210 @@
+// Package notify sends Fetch state changes to outside services: a generic
+// JSON webhook, ntfy, Discord, Slack, or a Telegram bot.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SendTimeout bounds each delivery.
+const SendTimeout = 10 * time.Second
+
+// Kind identifies what happened.
+type Kind string
+
+const (
+	KindDisconnected    Kind = "whatsapp_disconnected"
+	KindContainerDown   Kind = "container_down"
+	KindUpdateAvailable Kind = "update_available"
+	KindRepeatedFailure Kind = "repeated_failure" // From the watchdog
+	KindTest            Kind = "test"
+)
+
+// Event is one notification.
+type Event struct {
+	Kind    Kind
+	Title   string
+	Message string
+	At      time.Time
+}
+
+// Urgent reports whether the event needs attention soon.
+func (e Event) Urgent() bool {
+	return e.Kind == KindDisconnected || e.Kind == KindContainerDown || e.Kind == KindRepeatedFailure
+}
+
+// Target delivers events to one service.
+type Target struct {
+	Name string // Shown on the Notifications screen, e.g. "ntfy"
+	send func(c *http.Client, e Event) error
+}
+
+// Notifier delivers events to every configured target.
+type Notifier struct {
+	targets []Target
+	client  *http.Client
+}
+
+// Config holds the notification settings from .env. Empty fields are
+// skipped.
+type Config struct {
+	Webhook       string // FETCH_NOTIFY_WEBHOOK: POSTed a JSON body
+	Ntfy          string // FETCH_NOTIFY_NTFY: topic URL, e.g. https://ntfy.sh/my-fetch
+	Discord       string // FETCH_NOTIFY_DISCORD: webhook URL
+	Slack         string // FETCH_NOTIFY_SLACK: incoming webhook URL
+	TelegramToken string // FETCH_NOTIFY_TELEGRAM_TOKEN: bot token
+	TelegramChat  string // FETCH_NOTIFY_TELEGRAM_CHAT: chat ID
+}
+
+// ConfigFromEnv reads Config through get, which looks up a setting.
+func ConfigFromEnv(get func(string) string) Config {
+	return Config{
+		Webhook:       get("FETCH_NOTIFY_WEBHOOK"),
+		Ntfy:          get("FETCH_NOTIFY_NTFY"),
+		Discord:       get("FETCH_NOTIFY_DISCORD"),
+		Slack:         get("FETCH_NOTIFY_SLACK"),
+		TelegramToken: get("FETCH_NOTIFY_TELEGRAM_TOKEN"),
+		TelegramChat:  get("FETCH_NOTIFY_TELEGRAM_CHAT"),
+	}
+}
+
+// New builds a notifier for the targets set in cfg.
+func New(cfg Config) *Notifier {
+	n := &Notifier{client: &http.Client{Timeout: SendTimeout}}
+	if cfg.Webhook != "" {
+		n.targets = append(n.targets, Target{Name: "Webhook", send: webhook(cfg.Webhook)})
+	}
+	if cfg.Ntfy != "" {
+		n.targets = append(n.targets, Target{Name: "ntfy", send: ntfy(cfg.Ntfy)})
+	}
+	if cfg.Discord != "" {
+		n.targets = append(n.targets, Target{Name: "Discord", send: discord(cfg.Discord)})
+	}
+	if cfg.Slack != "" {
+		n.targets = append(n.targets, Target{Name: "Slack", send: slack(cfg.Slack)})
+	}
+	if cfg.TelegramToken != "" && cfg.TelegramChat != "" {
+		n.targets = append(n.targets, Target{Name: "Telegram", send: telegram(cfg.TelegramToken, cfg.TelegramChat)})
+	}
+	return n
+}
+
+// Enabled reports whether any target is configured.
+func (n *Notifier) Enabled() bool {
+	return n != nil && len(n.targets) > 0
+}
+
+// Targets returns the names of the configured targets.
+func (n *Notifier) Targets() []string {
+	if n == nil {
+		return nil
+	}
+	names := make([]string, len(n.targets))
+	for i, t := range n.targets {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Send delivers e to every target, returning the failures joined.
+func (n *Notifier) Send(e Event) error {
+	if !n.Enabled() {
+		return nil
+	}
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	var errs []error
+	for _, t := range n.targets {
+		if err := t.send(n.client, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", t.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// post sends a request and treats any non-2xx answer as an error.
+func post(c *http.Client, url, contentType string, body []byte, header map[string]string) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		if s := strings.TrimSpace(string(detail)); s != "" {
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, s)
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func postJSON(c *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return post(c, url, "application/json", body, nil)
+}
+
+func webhook(url string) func(*http.Client, Event) error {
+	return func(c *http.Client, e Event) error {
+		return postJSON(c, url, map[string]string{
+			"event":   string(e.Kind),
+			"title":   e.Title,
+			"message": e.Message,
+			"time":    e.At.Format(time.RFC3339),
+		})
+	}
+}
+
+func ntfy(url string) func(*http.Client, Event) error {
+	return func(c *http.Client, e Event) error {
+		header := map[string]string{"Title": e.Title, "Tags": "dog"}
+		if e.Urgent() {
+			header["Priority"] = "high"
+			header["Tags"] = "warning,dog"
+		}
+		return post(c, url, "text/plain; charset=utf-8", []byte(e.Message), header)
+	}
+}
+
+func discord(url string) func(*http.Client, Event) error {
+	return func(c *http.Client, e Event) error {
+		return postJSON(c, url, map[string]string{"content": "**" + e.Title + "**\n" + e.Message})
+	}
+}
+
+func slack(url string) func(*http.Client, Event) error {
+	return func(c *http.Client, e Event) error {
+		return postJSON(c, url, map[string]string{"text": "*" + e.Title + "*\n" + e.Message})
+	}
+}
+
+func telegram(token, chat string) func(*http.Client, Event) error {
+	url := "https://api.telegram.org/bot" + token + "/sendMessage"
+	return func(c *http.Client, e Event) error {
+		err := postJSON(c, url, map[string]string{"chat_id": chat, "text": e.Title + "\n" + e.Message})
+		if err != nil {
+			// The URL carries the bot token; keep it out of error messages
+			return errors.New(strings.ReplaceAll(err.Error(), token, "<token>"))
+		}
+		return nil
+	}
+}
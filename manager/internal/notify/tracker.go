@@ -0,0 +1,124 @@
+package notify
+
+import "fmt"
+
+// confirmations is how many observations in a row a failure must last
+// before it is reported, so restarts and brief reconnects stay quiet.
+const confirmations = 2
+
+// Tracker turns successive observations of Fetch's state into events. Only
+// changes are reported: a container that was running and stopped, WhatsApp
+// dropping after it was connected, or an update appearing. The first
+// observation of each kind only records the starting point.
+type Tracker struct {
+	containers map[string]*observed
+	whatsapp   observed
+	updates    int
+	release    string
+	seenUpdate bool
+}
+
+// observed follows one up/down signal.
+type observed struct {
+	seen      bool
+	up        bool // Last confirmed state
+	downCount int  // Down observations in a row
+	reported  bool // A down event was sent for the current outage
+}
+
+// NewTracker returns a tracker with no observations yet.
+func NewTracker() *Tracker {
+	return &Tracker{containers: make(map[string]*observed)}
+}
+
+// observe records one up/down reading and reports whether it confirms a
+// new outage.
+func (o *observed) observe(up bool) bool {
+	if !o.seen {
+		o.seen, o.up = true, up
+		return false
+	}
+	if up {
+		o.up, o.downCount, o.reported = true, 0, false
+		return false
+	}
+	o.downCount++
+	if o.up && !o.reported && o.downCount >= confirmations {
+		o.up, o.reported = false, true
+		return true
+	}
+	return false
+}
+
+// Container records whether a container is running. expected marks a stop
+// the user asked for, which is never reported.
+func (t *Tracker) Container(name string, running, expected bool) (Event, bool) {
+	o := t.containers[name]
+	if o == nil {
+		o = &observed{}
+		t.containers[name] = o
+	}
+	if expected && !running {
+		// Forget the outage entirely so a later start starts clean
+		*o = observed{seen: true}
+		return Event{}, false
+	}
+	if !o.observe(running) {
+		return Event{}, false
+	}
+	return Event{
+		Kind:    KindContainerDown,
+		Title:   "Fetch: " + name + " stopped",
+		Message: fmt.Sprintf("The %s container is no longer running and wasn't stopped from the manager.", name),
+	}, true
+}
+
+// WhatsApp records the bridge's WhatsApp state; an empty state means the
+// bridge didn't answer. expected marks an outage the user caused, such as
+// stopping Fetch, which is never reported.
+func (t *Tracker) WhatsApp(state string, expected bool) (Event, bool) {
+	up := state == "authenticated"
+	if expected && !up {
+		t.whatsapp = observed{seen: true}
+		return Event{}, false
+	}
+	if !t.whatsapp.observe(up) {
+		return Event{}, false
+	}
+	detail := "The bridge reports " + state + "."
+	if state == "" {
+		detail = "The bridge isn't answering."
+	}
+	return Event{
+		Kind:    KindDisconnected,
+		Title:   "Fetch: WhatsApp disconnected",
+		Message: detail + " Fetch can't receive messages until it reconnects.",
+	}, true
+}
+
+// Updates records pending commits and the newest manager release that is
+// newer than the running one ("" when up to date).
+func (t *Tracker) Updates(pending int, release string) (Event, bool) {
+	first := !t.seenUpdate
+	newCommits := pending > 0 && t.updates == 0
+	newRelease := release != "" && release != t.release
+	t.seenUpdate, t.updates, t.release = true, pending, release
+	if first || !newCommits && !newRelease {
+		return Event{}, false
+	}
+
+	var msg string
+	switch {
+	case newRelease && pending > 0:
+		msg = fmt.Sprintf("Manager %s is out and %d new commits are ready.", release, pending)
+	case newRelease:
+		msg = fmt.Sprintf("Manager %s is out.", release)
+	default:
+		msg = fmt.Sprintf("%d new commits are ready.", pending)
+	}
+	return Event{
+		Kind:    KindUpdateAvailable,
+		Title:   "Fetch: update available",
+		Message: msg + " Install it from Update Fetch in the manager.",
+	}, true
+}
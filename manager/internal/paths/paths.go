@@ -6,9 +6,10 @@ package paths
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/fetch/manager/internal/runner"
 )
 
 var (
@@ -19,6 +20,13 @@ var (
 	EnvFile = filepath.Join(ProjectDir, ".env")
 )
 
+// Use points ProjectDir and EnvFile at dir, such as the throwaway project
+// demo mode runs in.
+func Use(dir string) {
+	ProjectDir = dir
+	EnvFile = filepath.Join(dir, ".env")
+}
+
 // isFetchProject returns true if the given directory looks like the Fetch project root.
 func isFetchProject(dir string) bool {
 	// Must contain docker-compose.yml or .env — the two files Fetch always has
@@ -72,8 +80,7 @@ func resolveProjectDir() string {
 
 // gitRepoRoot returns the git repository root for a given directory, or "".
 func gitRepoRoot(dir string) string {
-	cmd := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel")
-	out, err := cmd.Output()
+	out, err := runner.Output("git", "-C", dir, "rev-parse", "--show-toplevel")
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,316 @@
+// Package phone parses and formats international phone numbers.
+// It is a small port of the libphonenumber rules the manager needs:
+// country calling code detection, national number length checks,
+// trunk-prefix handling, and display grouping. Numbers are always expected
+// in international form, since WhatsApp identifies users by E.164 number.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Number is a parsed international phone number.
+type Number struct {
+	CountryCode string // Calling code without "+", e.g. "44"
+	National    string // National significant number, e.g. "7700900123"
+	Region      string // ISO 3166-1 alpha-2 code, e.g. "GB"
+	Country     string // Display name, e.g. "United Kingdom"
+}
+
+// country holds the numbering rules for one calling code.
+type country struct {
+	region string
+	name   string
+	min    int   // Minimum national number length
+	max    int   // Maximum national number length
+	groups []int // Display grouping of the national number; nil uses the default
+	// keepZero is set for plans where a leading 0 is part of the national
+	// number rather than a trunk prefix (Italy)
+	keepZero bool
+}
+
+// countries maps calling codes to numbering rules. Lengths follow the
+// libphonenumber metadata for mobile and fixed lines.
+var countries = map[string]country{
+	"1":   {region: "US", name: "United States / Canada", min: 10, max: 10, groups: []int{3, 3, 4}},
+	"7":   {region: "RU", name: "Russia / Kazakhstan", min: 10, max: 10, groups: []int{3, 3, 2, 2}},
+	"20":  {region: "EG", name: "Egypt", min: 8, max: 10},
+	"27":  {region: "ZA", name: "South Africa", min: 9, max: 9, groups: []int{2, 3, 4}},
+	"30":  {region: "GR", name: "Greece", min: 10, max: 10},
+	"31":  {region: "NL", name: "Netherlands", min: 9, max: 9, groups: []int{1, 8}},
+	"32":  {region: "BE", name: "Belgium", min: 8, max: 9},
+	"33":  {region: "FR", name: "France", min: 9, max: 9, groups: []int{1, 2, 2, 2, 2}},
+	"34":  {region: "ES", name: "Spain", min: 9, max: 9, groups: []int{3, 3, 3}},
+	"36":  {region: "HU", name: "Hungary", min: 8, max: 9},
+	"39":  {region: "IT", name: "Italy", min: 6, max: 11, keepZero: true},
+	"40":  {region: "RO", name: "Romania", min: 9, max: 9},
+	"41":  {region: "CH", name: "Switzerland", min: 9, max: 9, groups: []int{2, 3, 2, 2}},
+	"43":  {region: "AT", name: "Austria", min: 4, max: 13},
+	"44":  {region: "GB", name: "United Kingdom", min: 9, max: 10, groups: []int{4, 6}},
+	"45":  {region: "DK", name: "Denmark", min: 8, max: 8, groups: []int{2, 2, 2, 2}},
+	"46":  {region: "SE", name: "Sweden", min: 7, max: 10},
+	"47":  {region: "NO", name: "Norway", min: 8, max: 8, groups: []int{3, 2, 3}},
+	"48":  {region: "PL", name: "Poland", min: 9, max: 9, groups: []int{3, 3, 3}},
+	"49":  {region: "DE", name: "Germany", min: 6, max: 13},
+	"51":  {region: "PE", name: "Peru", min: 8, max: 9},
+	"52":  {region: "MX", name: "Mexico", min: 10, max: 10, groups: []int{2, 4, 4}},
+	"53":  {region: "CU", name: "Cuba", min: 6, max: 8},
+	"54":  {region: "AR", name: "Argentina", min: 10, max: 11},
+	"55":  {region: "BR", name: "Brazil", min: 10, max: 11, groups: []int{2, 5, 4}},
+	"56":  {region: "CL", name: "Chile", min: 9, max: 9},
+	"57":  {region: "CO", name: "Colombia", min: 8, max: 10, groups: []int{3, 3, 4}},
+	"58":  {region: "VE", name: "Venezuela", min: 10, max: 10},
+	"60":  {region: "MY", name: "Malaysia", min: 8, max: 10},
+	"61":  {region: "AU", name: "Australia", min: 9, max: 9, groups: []int{1, 4, 4}},
+	"62":  {region: "ID", name: "Indonesia", min: 8, max: 12},
+	"63":  {region: "PH", name: "Philippines", min: 8, max: 10},
+	"64":  {region: "NZ", name: "New Zealand", min: 8, max: 10},
+	"65":  {region: "SG", name: "Singapore", min: 8, max: 8, groups: []int{4, 4}},
+	"66":  {region: "TH", name: "Thailand", min: 8, max: 9},
+	"81":  {region: "JP", name: "Japan", min: 9, max: 10, groups: []int{2, 4, 4}},
+	"82":  {region: "KR", name: "South Korea", min: 8, max: 10},
+	"84":  {region: "VN", name: "Vietnam", min: 9, max: 10},
+	"86":  {region: "CN", name: "China", min: 10, max: 11, groups: []int{3, 4, 4}},
+	"90":  {region: "TR", name: "Turkey", min: 10, max: 10, groups: []int{3, 3, 4}},
+	"91":  {region: "IN", name: "India", min: 10, max: 10, groups: []int{5, 5}},
+	"92":  {region: "PK", name: "Pakistan", min: 9, max: 10},
+	"93":  {region: "AF", name: "Afghanistan", min: 9, max: 9},
+	"94":  {region: "LK", name: "Sri Lanka", min: 9, max: 9},
+	"95":  {region: "MM", name: "Myanmar", min: 7, max: 10},
+	"98":  {region: "IR", name: "Iran", min: 10, max: 10},
+	"211": {region: "SS", name: "South Sudan", min: 9, max: 9},
+	"212": {region: "MA", name: "Morocco", min: 9, max: 9},
+	"213": {region: "DZ", name: "Algeria", min: 8, max: 9},
+	"216": {region: "TN", name: "Tunisia", min: 8, max: 8},
+	"218": {region: "LY", name: "Libya", min: 8, max: 9},
+	"220": {region: "GM", name: "Gambia", min: 7, max: 7},
+	"221": {region: "SN", name: "Senegal", min: 9, max: 9},
+	"225": {region: "CI", name: "Côte d'Ivoire", min: 10, max: 10},
+	"233": {region: "GH", name: "Ghana", min: 9, max: 9},
+	"234": {region: "NG", name: "Nigeria", min: 8, max: 10, groups: []int{3, 3, 4}},
+	"237": {region: "CM", name: "Cameroon", min: 9, max: 9},
+	"251": {region: "ET", name: "Ethiopia", min: 9, max: 9},
+	"254": {region: "KE", name: "Kenya", min: 9, max: 9, groups: []int{3, 6}},
+	"255": {region: "TZ", name: "Tanzania", min: 9, max: 9},
+	"256": {region: "UG", name: "Uganda", min: 9, max: 9},
+	"260": {region: "ZM", name: "Zambia", min: 9, max: 9},
+	"263": {region: "ZW", name: "Zimbabwe", min: 9, max: 9},
+	"351": {region: "PT", name: "Portugal", min: 9, max: 9, groups: []int{3, 3, 3}},
+	"352": {region: "LU", name: "Luxembourg", min: 4, max: 11},
+	"353": {region: "IE", name: "Ireland", min: 7, max: 9},
+	"354": {region: "IS", name: "Iceland", min: 7, max: 9},
+	"355": {region: "AL", name: "Albania", min: 8, max: 9},
+	"356": {region: "MT", name: "Malta", min: 8, max: 8},
+	"357": {region: "CY", name: "Cyprus", min: 8, max: 8},
+	"358": {region: "FI", name: "Finland", min: 5, max: 12},
+	"359": {region: "BG", name: "Bulgaria", min: 8, max: 9},
+	"370": {region: "LT", name: "Lithuania", min: 8, max: 8},
+	"371": {region: "LV", name: "Latvia", min: 8, max: 8},
+	"372": {region: "EE", name: "Estonia", min: 7, max: 8},
+	"380": {region: "UA", name: "Ukraine", min: 9, max: 9, groups: []int{2, 3, 2, 2}},
+	"381": {region: "RS", name: "Serbia", min: 8, max: 9},
+	"385": {region: "HR", name: "Croatia", min: 8, max: 9},
+	"386": {region: "SI", name: "Slovenia", min: 8, max: 8},
+	"420": {region: "CZ", name: "Czechia", min: 9, max: 9, groups: []int{3, 3, 3}},
+	"421": {region: "SK", name: "Slovakia", min: 9, max: 9, groups: []int{3, 3, 3}},
+	"502": {region: "GT", name: "Guatemala", min: 8, max: 8},
+	"503": {region: "SV", name: "El Salvador", min: 8, max: 8},
+	"504": {region: "HN", name: "Honduras", min: 8, max: 8},
+	"505": {region: "NI", name: "Nicaragua", min: 8, max: 8},
+	"506": {region: "CR", name: "Costa Rica", min: 8, max: 8},
+	"507": {region: "PA", name: "Panama", min: 7, max: 8},
+	"591": {region: "BO", name: "Bolivia", min: 8, max: 8},
+	"593": {region: "EC", name: "Ecuador", min: 8, max: 9},
+	"595": {region: "PY", name: "Paraguay", min: 9, max: 9},
+	"598": {region: "UY", name: "Uruguay", min: 8, max: 8},
+	"852": {region: "HK", name: "Hong Kong", min: 8, max: 8, groups: []int{4, 4}},
+	"853": {region: "MO", name: "Macau", min: 8, max: 8, groups: []int{4, 4}},
+	"855": {region: "KH", name: "Cambodia", min: 8, max: 9},
+	"880": {region: "BD", name: "Bangladesh", min: 10, max: 10},
+	"886": {region: "TW", name: "Taiwan", min: 9, max: 9},
+	"960": {region: "MV", name: "Maldives", min: 7, max: 7},
+	"961": {region: "LB", name: "Lebanon", min: 7, max: 8},
+	"962": {region: "JO", name: "Jordan", min: 8, max: 9},
+	"963": {region: "SY", name: "Syria", min: 9, max: 9},
+	"964": {region: "IQ", name: "Iraq", min: 10, max: 10},
+	"965": {region: "KW", name: "Kuwait", min: 8, max: 8},
+	"966": {region: "SA", name: "Saudi Arabia", min: 9, max: 9, groups: []int{2, 3, 4}},
+	"967": {region: "YE", name: "Yemen", min: 9, max: 9},
+	"968": {region: "OM", name: "Oman", min: 8, max: 8},
+	"970": {region: "PS", name: "Palestine", min: 9, max: 9},
+	"971": {region: "AE", name: "United Arab Emirates", min: 8, max: 9, groups: []int{2, 3, 4}},
+	"972": {region: "IL", name: "Israel", min: 8, max: 9, groups: []int{2, 3, 4}},
+	"973": {region: "BH", name: "Bahrain", min: 8, max: 8},
+	"974": {region: "QA", name: "Qatar", min: 8, max: 8},
+	"977": {region: "NP", name: "Nepal", min: 8, max: 10},
+	"992": {region: "TJ", name: "Tajikistan", min: 9, max: 9},
+	"993": {region: "TM", name: "Turkmenistan", min: 8, max: 8},
+	"994": {region: "AZ", name: "Azerbaijan", min: 9, max: 9},
+	"995": {region: "GE", name: "Georgia", min: 9, max: 9},
+	"996": {region: "KG", name: "Kyrgyzstan", min: 9, max: 9},
+	"998": {region: "UZ", name: "Uzbekistan", min: 9, max: 9},
+}
+
+// Parse errors
+var (
+	ErrEmpty          = errors.New("no digits in number")
+	ErrUnknownCountry = errors.New("unknown country calling code")
+)
+
+// Parse reads a number in international form. Formatting characters are
+// ignored, and a leading "+" or "00" international prefix is accepted.
+func Parse(input string) (Number, error) {
+	digits := digitsOnly(input)
+	if strings.HasPrefix(digits, "00") {
+		digits = digits[2:]
+	}
+	if digits == "" {
+		return Number{}, ErrEmpty
+	}
+
+	// Calling codes are prefix-free, so at most one of these matches
+	for l := 1; l <= 3 && l < len(digits); l++ {
+		cc := digits[:l]
+		c, ok := countries[cc]
+		if !ok {
+			continue
+		}
+
+		national := digits[l:]
+		if !c.keepZero && strings.HasPrefix(national, "0") {
+			// Trunk prefix written after the country code, e.g. +44 07700...
+			national = national[1:]
+		}
+
+		n := Number{CountryCode: cc, National: national, Region: c.region, Country: c.name}
+		switch {
+		case len(national) < c.min:
+			return n, fmt.Errorf("too short for %s (need %s digits after +%s)", c.name, lengthRange(c), cc)
+		case len(national) > c.max:
+			return n, fmt.Errorf("too long for %s (need %s digits after +%s)", c.name, lengthRange(c), cc)
+		}
+		if cc == "1" && (national[0] < '2' || national[3] < '2') {
+			// NANP area codes and exchanges never start with 0 or 1
+			return n, fmt.Errorf("not a valid North American number")
+		}
+		return n, nil
+	}
+
+	return Number{}, ErrUnknownCountry
+}
+
+// Lenient is Parse, except that a calling code missing from the table is
+// not an error: the table can't list every country, and WhatsApp numbers
+// are E.164 either way. Such a number is accepted when it has 8 to 15
+// digits and no leading zero, with unknown set since its length can't be
+// checked. Its CountryCode is empty and National holds every digit.
+func Lenient(input string) (n Number, unknown bool, err error) {
+	n, err = Parse(input)
+	if !errors.Is(err, ErrUnknownCountry) {
+		return n, false, err
+	}
+	digits := strings.TrimPrefix(digitsOnly(input), "00")
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return Number{}, false, err
+	}
+	return Number{National: digits}, true, nil
+}
+
+// E164 returns the canonical "+<country><national>" form.
+func (n Number) E164() string {
+	return "+" + n.Digits()
+}
+
+// Digits returns the number without "+", as stored in the whitelist.
+func (n Number) Digits() string {
+	return n.CountryCode + n.National
+}
+
+// Format returns a human-friendly international form, e.g. "+1 415 555 0132".
+// Numbers with an unknown calling code are not grouped.
+func (n Number) Format() string {
+	if n.CountryCode == "" {
+		return "+" + n.National
+	}
+	groups := countries[n.CountryCode].groups
+	if sum(groups) != len(n.National) {
+		groups = defaultGroups(len(n.National))
+	}
+
+	parts := []string{"+" + n.CountryCode}
+	rest := n.National
+	for _, g := range groups {
+		parts = append(parts, rest[:g])
+		rest = rest[g:]
+	}
+	return strings.Join(parts, " ")
+}
+
+// Flag returns the regional indicator emoji for the number's region.
+func (n Number) Flag() string {
+	if len(n.Region) != 2 {
+		return ""
+	}
+	var b strings.Builder
+	for _, r := range n.Region {
+		b.WriteRune(0x1F1E6 + (r - 'A'))
+	}
+	return b.String()
+}
+
+// Pretty formats a stored digit string for display, falling back to
+// "+<digits>" when it doesn't parse.
+func Pretty(digits string) string {
+	if n, err := Parse(digits); err == nil {
+		return n.Format()
+	}
+	return "+" + digits
+}
+
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func lengthRange(c country) string {
+	if c.min == c.max {
+		return fmt.Sprint(c.min)
+	}
+	return fmt.Sprintf("%d-%d", c.min, c.max)
+}
+
+// defaultGroups splits a national number into leading groups of three and
+// a tail of two groups of three or four digits.
+func defaultGroups(length int) []int {
+	var groups []int
+	for length > 8 {
+		groups = append(groups, 3)
+		length -= 3
+	}
+	switch length {
+	case 8:
+		return append(groups, 4, 4)
+	case 7:
+		return append(groups, 3, 4)
+	case 6:
+		return append(groups, 3, 3)
+	case 5:
+		return append(groups, 2, 3)
+	}
+	return append(groups, length)
+}
+
+func sum(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		total += x
+	}
+	return total
+}
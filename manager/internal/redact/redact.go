@@ -0,0 +1,100 @@
+// Package redact scrubs API keys, tokens, and phone numbers from text that
+// is shown, copied, or exported, such as container logs. The bridge can
+// echo secrets at debug level, and logs are what people paste into issues.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Mask replaces each redacted value.
+const Mask = "<redacted>"
+
+// rule replaces a pattern's matches with repl, which may keep submatches
+// such as a "Bearer " prefix or a WhatsApp ID's domain.
+type rule struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// builtin covers the secrets Fetch handles and the usual shapes of phone
+// numbers in its logs. Bare digit runs aren't matched: they are more often
+// timestamps or IDs than phone numbers.
+var builtin = []rule{
+	{regexp.MustCompile(`\bsk-or-v1-[0-9a-f]{16,}`), Mask},                                      // OpenRouter
+	{regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`), Mask},                                       // OpenAI, Anthropic
+	{regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})`), Mask}, // GitHub
+	{regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`), Mask},                                    // GitLab
+	{regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`), Mask},                                // Slack
+	{regexp.MustCompile(`\b\d{6,12}:[A-Za-z0-9_-]{30,}`), Mask},                                 // Telegram bots
+	{regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9._~+/=-]{16,}`), "${1}" + Mask},
+	{regexp.MustCompile(`(?i)(\b(?:api[_-]?key|token|secret|password)["']?\s*[:=]\s*["']?)[^\s"'&,;]{8,}`), "${1}" + Mask},
+	{regexp.MustCompile(`\b\d{8,15}(@(?:c\.us|s\.whatsapp\.net|lid))\b`), Mask + "${1}"}, // WhatsApp IDs
+	{regexp.MustCompile(`\+\d{10,15}\b`), Mask},
+}
+
+// minValueLen keeps short values from matching unrelated text.
+const minValueLen = 6
+
+// Redactor replaces known secret values and pattern matches in text. The
+// zero value redacts nothing; use New.
+type Redactor struct {
+	values *strings.Replacer // Nil without values
+	rules  []rule
+}
+
+// New creates a redactor for the built-in patterns, an optional extra
+// regular expression (several can be joined with |), and exact values such
+// as the API key in .env. A value is also matched without a leading +, the
+// way phone numbers often appear in logs. When extra doesn't compile the
+// redactor is still returned, without it, along with the error.
+func New(extra string, values []string) (*Redactor, error) {
+	r := &Redactor{rules: builtin}
+
+	var err error
+	if extra != "" {
+		re, compileErr := regexp.Compile(extra)
+		if compileErr != nil {
+			err = fmt.Errorf("redaction pattern: %w", compileErr)
+		} else {
+			r.rules = append(append([]rule(nil), builtin...), rule{re, Mask})
+		}
+	}
+
+	var exact []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		for _, form := range []string{v, strings.TrimPrefix(v, "+")} {
+			if len(form) >= minValueLen {
+				exact = append(exact, form)
+			}
+		}
+	}
+	if len(exact) > 0 {
+		// Longest first, so a value containing another is replaced whole
+		sort.Slice(exact, func(i, j int) bool { return len(exact[i]) > len(exact[j]) })
+		pairs := make([]string, 0, 2*len(exact))
+		for _, v := range exact {
+			pairs = append(pairs, v, Mask)
+		}
+		r.values = strings.NewReplacer(pairs...)
+	}
+	return r, err
+}
+
+// String returns s with every secret replaced by Mask.
+func (r *Redactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	if r.values != nil {
+		s = r.values.Replace(s)
+	}
+	for _, rl := range r.rules {
+		s = rl.re.ReplaceAllString(s, rl.repl)
+	}
+	return s
+}
@@ -0,0 +1,173 @@
+// Package runner runs the command-line tools the manager drives, such as
+// docker, git, and gh, behind one interface. Tests swap in a fake, such as
+// runnertest.Fake, with Use, and Observe sees every call with how long it
+// took.
+package runner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Command is one run of an external program.
+type Command struct {
+	Name string
+	Args []string
+	Dir  string   // Working directory; empty for the manager's
+	Env  []string // KEY=VALUE pairs added to the manager's environment
+	// Combined interleaves stderr with stdout instead of dropping it
+	Combined bool
+	// Stdout receives the output as it is written instead of it being
+	// returned, e.g. to stream it; stderr too when Combined is set
+	Stdout io.Writer
+}
+
+// New returns a command for name with args.
+func New(name string, args ...string) Command {
+	return Command{Name: name, Args: args}
+}
+
+// String returns the command line, e.g. for logs. Env is left out since it
+// can hold tokens.
+func (c Command) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// ExitError reports a command that ran and exited non-zero.
+type ExitError struct {
+	Code   int    // -1 when killed by a signal
+	Stderr []byte // When only stdout was returned
+	msg    string
+}
+
+func (e *ExitError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return "exit status " + strconv.Itoa(e.Code)
+}
+
+// Runner runs commands.
+type Runner interface {
+	// Run runs c until it exits or ctx is done and returns its output. A
+	// command that exits non-zero returns an *ExitError.
+	Run(ctx context.Context, c Command) ([]byte, error)
+}
+
+// Finder is implemented by runners that decide which programs are
+// installed, such as a fake that only knows some of them.
+type Finder interface {
+	// LookPath returns where name is installed, like exec.LookPath.
+	LookPath(name string) (string, error)
+}
+
+// Exec runs commands with os/exec.
+type Exec struct{}
+
+// LookPath implements Finder.
+func (Exec) LookPath(name string) (string, error) {
+	return exec.LookPath(name)
+}
+
+// Run implements Runner.
+func (Exec) Run(ctx context.Context, c Command) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, c.Name, c.Args...)
+	cmd.Dir = c.Dir
+	if len(c.Env) > 0 {
+		cmd.Env = append(os.Environ(), c.Env...)
+	}
+	// A child such as compose can hold the output open after the CLI is
+	// killed
+	cmd.WaitDelay = time.Second
+
+	var out []byte
+	var err error
+	switch {
+	case c.Stdout != nil:
+		cmd.Stdout = c.Stdout
+		if c.Combined {
+			cmd.Stderr = c.Stdout
+		}
+		err = cmd.Run()
+	case c.Combined:
+		out, err = cmd.CombinedOutput()
+	default:
+		out, err = cmd.Output()
+	}
+
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		err = &ExitError{Code: exit.ExitCode(), Stderr: exit.Stderr, msg: exit.Error()}
+	}
+	return out, err
+}
+
+// Call is a finished command, as Observe reports it.
+type Call struct {
+	Command Command
+	Took    time.Duration
+	Err     error
+}
+
+var state = struct {
+	sync.RWMutex
+	runner  Runner
+	observe func(Call)
+}{runner: Exec{}}
+
+// Use makes every package run commands with r and returns the runner it
+// replaces, so a test can put it back.
+func Use(r Runner) Runner {
+	state.Lock()
+	defer state.Unlock()
+	prev := state.runner
+	state.runner = r
+	return prev
+}
+
+// Observe calls observe after every command finishes, or stops when it is
+// nil. It is called on the goroutine that ran the command.
+func Observe(observe func(Call)) {
+	state.Lock()
+	state.observe = observe
+	state.Unlock()
+}
+
+// Run runs c with the current runner.
+func Run(ctx context.Context, c Command) ([]byte, error) {
+	state.RLock()
+	r, observe := state.runner, state.observe
+	state.RUnlock()
+
+	started := time.Now()
+	out, err := r.Run(ctx, c)
+	if observe != nil {
+		observe(Call{Command: c, Took: time.Since(started), Err: err})
+	}
+	return out, err
+}
+
+// LookPath returns where name is installed, asking the current runner
+// when it is a Finder and searching PATH otherwise.
+func LookPath(name string) (string, error) {
+	state.RLock()
+	r := state.runner
+	state.RUnlock()
+	if f, ok := r.(Finder); ok {
+		return f.LookPath(name)
+	}
+	return exec.LookPath(name)
+}
+
+// Output runs name with args in the background context and returns its
+// stdout.
+func Output(name string, args ...string) ([]byte, error) {
+	return Run(context.Background(), New(name, args...))
+}
@@ -0,0 +1,130 @@
+// Package runnertest provides a fake runner.Runner for tests. It answers
+// commands with canned output or exit codes, matched by their command
+// line, and records every command it was asked to run.
+package runnertest
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fetch/manager/internal/runner"
+)
+
+// reply is the answer to commands whose line starts with prefix.
+type reply struct {
+	prefix string
+	out    []byte
+	err    error
+}
+
+// Fake is a runner.Runner that runs nothing. A command with no matching
+// reply fails, so a test notices commands it didn't expect, and a program
+// counts as installed once it has a reply.
+type Fake struct {
+	mu      sync.Mutex
+	replies []reply
+	calls   []runner.Command
+}
+
+// Install makes every package run commands with a new Fake until the test
+// ends.
+func Install(t testing.TB) *Fake {
+	t.Helper()
+	f := &Fake{}
+	prev := runner.Use(f)
+	t.Cleanup(func() { runner.Use(prev) })
+	return f
+}
+
+// Reply answers commands whose line starts with prefix, e.g. "docker
+// inspect", with output. The longest matching prefix wins.
+func (f *Fake) Reply(prefix, output string) {
+	f.add(reply{prefix: prefix, out: []byte(output)})
+}
+
+// Fail makes commands whose line starts with prefix exit with code,
+// writing output to stderr. It is returned as the output too when the
+// command combines stderr with stdout.
+func (f *Fake) Fail(prefix string, code int, output string) {
+	f.add(reply{prefix: prefix, out: []byte(output), err: &runner.ExitError{Code: code, Stderr: []byte(output)}})
+}
+
+// Error makes commands whose line starts with prefix fail without running,
+// as when the program isn't installed.
+func (f *Fake) Error(prefix string, err error) {
+	f.add(reply{prefix: prefix, err: err})
+}
+
+func (f *Fake) add(r reply) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies = append(f.replies, r)
+}
+
+// Calls returns the line of every command run so far, in order.
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lines := make([]string, len(f.calls))
+	for i, c := range f.calls {
+		lines[i] = c.String()
+	}
+	return lines
+}
+
+// Commands returns every command run so far, in order.
+func (f *Fake) Commands() []runner.Command {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]runner.Command(nil), f.calls...)
+}
+
+// LookPath implements runner.Finder.
+func (f *Fake) LookPath(name string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.replies {
+		if program, _, _ := strings.Cut(r.prefix, " "); program == name {
+			return "/usr/bin/" + name, nil
+		}
+	}
+	return "", &exec.Error{Name: name, Err: exec.ErrNotFound}
+}
+
+// Run implements runner.Runner.
+func (f *Fake) Run(ctx context.Context, c runner.Command) ([]byte, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, c)
+	var match *reply
+	line := c.String()
+	for i, r := range f.replies {
+		if strings.HasPrefix(line, r.prefix) && (match == nil || len(r.prefix) > len(match.prefix)) {
+			match = &f.replies[i]
+		}
+	}
+	f.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return nil, fmt.Errorf("runnertest: no reply for %q", line)
+	}
+
+	out := match.out
+	if _, failed := match.err.(*runner.ExitError); failed && !c.Combined {
+		// Stderr is only in the error unless it is combined
+		out = nil
+	}
+	if c.Stdout != nil {
+		if _, err := c.Stdout.Write(out); err != nil {
+			return nil, err
+		}
+		out = nil
+	}
+	return out, match.err
+}
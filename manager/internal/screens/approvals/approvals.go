@@ -0,0 +1,88 @@
+// Package approvals is the Approvals screen: kennel tasks waiting on an
+// approve or deny answer.
+package approvals
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/tasks"
+)
+
+// Model lists the tasks waiting on an approve or deny answer, refreshing
+// while it is shown.
+type Model struct {
+	screens.Room
+	client    *status.Client
+	approvals *tasks.Approvals
+	shown     bool
+	polling   bool // The refresh loop is running
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		return s, nil
+	case screens.ShownMsg:
+		s.shown = true
+		if s.approvals == nil {
+			s.approvals = tasks.NewApprovals(s.client)
+		} else if s.polling {
+			return s, nil
+		}
+		s.polling = true
+		return s, s.approvals.Init()
+	case screens.HiddenMsg:
+		s.shown = false
+		return s, nil
+	case tasks.ApprovalsTickMsg:
+		// Stop refreshing once the screen is left; the next visit restarts it
+		if !s.shown {
+			s.polling = false
+			return s, nil
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return s, screens.Show(screens.Menu)
+		}
+	}
+	if s.approvals == nil {
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.approvals, cmd = s.approvals.Update(msg)
+	return s, cmd
+}
+
+func (s Model) HelpKeys() []string {
+	if s.approvals == nil {
+		return screens.KeyHelp(screens.Approvals, "Esc")
+	}
+	return s.approvals.HelpKeys()
+}
+
+func (s Model) View() string {
+	width, _ := s.Size()
+
+	title := layout.SectionHeader("🛂 Approvals", width-4)
+
+	var content strings.Builder
+	if s.approvals != nil {
+		// Columns that don't fit a narrow terminal are cut rather than wrapped
+		content.WriteString(lipgloss.NewStyle().MaxWidth(width).Render(s.approvals.View(width)))
+	}
+	return title + "\n\n" + content.String()
+}
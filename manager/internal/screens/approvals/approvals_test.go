@@ -0,0 +1,51 @@
+package approvals
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/tasks"
+)
+
+var waiting = tasks.ApprovalsLoadedMsg{Tasks: []status.Task{
+	{ID: "t1", Goal: "Deploy the site", Agent: "claude", Status: "waiting_input",
+		Question: "Run npm publish?", CreatedAt: time.Now()},
+}}
+
+func TestViewListsWaitingTasks(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{}, waiting)
+	v := s.View()
+	for _, want := range []string{"Approvals", "Run npm publish?"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestPollsOnlyWhileShown(t *testing.T) {
+	s, cmd := screenstest.Send(New(nil), screens.ShownMsg{})
+	if cmd == nil {
+		t.Fatal("the first visit didn't start polling")
+	}
+	if _, cmd = screenstest.Send(s, screens.ShownMsg{}); cmd != nil {
+		t.Error("a second visit started another refresh loop")
+	}
+	s, cmd = screenstest.Send(s, screens.HiddenMsg{}, tasks.ApprovalsTickMsg{})
+	if cmd != nil {
+		t.Error("polling went on after the screen was left")
+	}
+	if _, cmd = screenstest.Send(s, screens.ShownMsg{}); cmd == nil {
+		t.Error("coming back didn't restart polling")
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	_, cmd := screenstest.Send(New(nil), screens.ShownMsg{}, waiting, screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
@@ -0,0 +1,194 @@
+// Package config is the configuration editor for .env, with the model
+// picker opened from its Agent Model field.
+package config
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/models"
+	"github.com/fetch/manager/internal/screens"
+	modelsscreen "github.com/fetch/manager/internal/screens/models"
+)
+
+// FocusMsg opens the editor on one field, as the palette's field actions
+// do. AGENT_MODEL opens the model picker and OWNER_PHONE_NUMBER the owner
+// change instead of a text field.
+type FocusMsg struct {
+	Key string
+}
+
+// Model is the config screen. The editor is created on the first visit and
+// kept after that, so unsaved edits and an open model picker survive
+// leaving the screen.
+type Model struct {
+	screens.Room
+	editor   *config.Editor
+	picker   modelsscreen.Model
+	picking  bool   // The model picker is showing over the editor
+	restored string // Field the editor first opens on, from the last run
+}
+
+// New returns the config screen, selecting field once the editor is
+// created and listing every model in the picker when showAllModels is set.
+func New(field string, showAllModels bool) Model {
+	return Model{picker: modelsscreen.New(showAllModels), restored: field}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+// Picking reports whether the model picker is showing.
+func (s Model) Picking() bool {
+	return s.picking
+}
+
+// CapturingText is true while a field is being edited.
+func (s Model) CapturingText() bool {
+	return !s.picking && s.editor != nil && s.editor.IsEditing()
+}
+
+// FocusedKey returns the field under the cursor, for the next run.
+func (s Model) FocusedKey() string {
+	if s.editor == nil {
+		return s.restored
+	}
+	return s.editor.FocusedKey()
+}
+
+// ShowAllModels reports whether the picker lists every model.
+func (s Model) ShowAllModels() bool {
+	return s.picker.ShowAll()
+}
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		s.picker = s.updatePicker(msg)
+
+	case screens.ShownMsg:
+		if s.editor != nil {
+			return s, nil
+		}
+		s.editor = config.NewEditor()
+		s.editor.Select(s.restored)
+		return s, config.LoadProvenanceCmd
+
+	case config.ProvenanceMsg:
+		if s.editor != nil {
+			s.editor.SetProvenance(msg)
+		}
+
+	case models.ModelsLoadedMsg, models.CreditsLoadedMsg:
+		s.picker = s.updatePicker(msg)
+
+	case models.ModelSavedMsg:
+		s.picker = s.updatePicker(msg)
+		// The picker shows "Saved!" and the editor the new model
+		if s.picking && msg.Err == nil && s.editor != nil {
+			s.editor.SetFieldValue("AGENT_MODEL", s.picker.Selected())
+		}
+
+	case screens.SettingMsg:
+		if s.editor != nil {
+			s.editor.SetFieldValue(msg.Key, msg.Value)
+		}
+
+	case FocusMsg:
+		if s.editor == nil {
+			return s, nil
+		}
+		s.picking = false
+		s.picker = s.picker.Close()
+		s.editor.Focus(msg.Key)
+		return s.openRequested()
+
+	case screens.ClickMsg:
+		if !s.picking && s.editor != nil && s.editor.ClickLine(msg.Line) {
+			return s.openRequested()
+		}
+
+	case tea.MouseMsg:
+		if s.picking {
+			s.picker = s.updatePicker(msg)
+		}
+
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if s.picking {
+		if msg.String() == "esc" {
+			s.picking = false
+			s.picker = s.picker.Close()
+			return s, nil
+		}
+		next, cmd := s.picker.Update(msg)
+		s.picker = next.(modelsscreen.Model)
+		return s, cmd
+	}
+	if s.editor == nil {
+		return s, nil
+	}
+	if msg.String() == "esc" && !s.editor.ModelPickerRequested() && !s.editor.IsEditing() {
+		return s, screens.Show(screens.Menu)
+	}
+	s.editor.Update(msg)
+	return s.openRequested()
+}
+
+// updatePicker hands the picker a message whose command, if any, isn't
+// needed.
+func (s Model) updatePicker(msg tea.Msg) modelsscreen.Model {
+	next, _ := s.picker.Update(msg)
+	return next.(modelsscreen.Model)
+}
+
+// openRequested opens what the editor asked for: the owner change (Enter
+// on Owner Phone) or the model picker (Enter on Agent Model).
+func (s Model) openRequested() (tea.Model, tea.Cmd) {
+	if s.editor.OwnerChangeRequested() {
+		s.editor.ClearOwnerChangeRequest()
+		return s, screens.Show(screens.Owner)
+	}
+	if !s.editor.ModelPickerRequested() {
+		return s, nil
+	}
+	s.editor.ClearModelPickerRequest()
+	s.picking = true
+	var cmd tea.Cmd
+	s.picker, cmd = s.picker.Open()
+	return s, cmd
+}
+
+func (s Model) HelpKeys() []string {
+	switch {
+	case s.picking:
+		return s.picker.HelpKeys()
+	case s.editor != nil && s.editor.DocOpen():
+		return []string{"Esc Close"}
+	case s.editor != nil && s.editor.PresetsOpen():
+		return []string{"↑/↓ Choose", "Enter Apply", "Esc Cancel"}
+	}
+	return screens.KeyHelp(screens.Config, "↑/↓", "Enter", "i", "p", "s", "Esc")
+}
+
+func (s Model) View() string {
+	if s.picking {
+		return s.picker.View()
+	}
+	width, height := s.Size()
+	title := layout.SectionHeader("⚙️  Configuration", width-4)
+	if s.editor == nil {
+		return title + "\n\n"
+	}
+	// Rows wider than the terminal are cut rather than wrapped, so the
+	// editor's scrolling still matches what is drawn
+	s.editor.SetSize(height - 6)
+	return title + "\n\n" + lipgloss.NewStyle().MaxWidth(width).Render(s.editor.View())
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+)
+
+func shown(t *testing.T, field string) Model {
+	t.Helper()
+	screenstest.Project(t, "AGENT_MODEL=openai/gpt-4o-mini\nOWNER_PHONE_NUMBER=15551234567\n")
+	s, _ := screenstest.Send(New(field, false), screenstest.Room, screens.ShownMsg{})
+	return s
+}
+
+func TestOpensOnRestoredField(t *testing.T) {
+	s := New("FETCH_THEME", false)
+	if s.FocusedKey() != "FETCH_THEME" {
+		t.Errorf("before the first visit FocusedKey is %q, want the restored field", s.FocusedKey())
+	}
+	s = shown(t, "FETCH_THEME")
+	if s.FocusedKey() != "FETCH_THEME" {
+		t.Errorf("editor opened on %q, want FETCH_THEME", s.FocusedKey())
+	}
+	if v := s.View(); !strings.Contains(v, "Configuration") || !strings.Contains(v, "Manager Theme") {
+		t.Errorf("view is missing the title or the restored field:\n%s", v)
+	}
+}
+
+func TestFocusOpensFlows(t *testing.T) {
+	s, cmd := screenstest.Send(shown(t, ""), FocusMsg{Key: "OWNER_PHONE_NUMBER"})
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Owner {
+		t.Errorf("Owner Phone showed %v, want the owner change", show.Screen)
+	}
+	if s.Picking() {
+		t.Error("Owner Phone opened the model picker")
+	}
+
+	s, _ = screenstest.Send(s, FocusMsg{Key: "AGENT_MODEL"})
+	if !s.Picking() {
+		t.Fatal("Agent Model didn't open the model picker")
+	}
+	if v := s.View(); !strings.Contains(v, "Select Model") {
+		t.Errorf("view while picking:\n%s", v)
+	}
+}
+
+func TestEscBacksOutOneLevel(t *testing.T) {
+	s, _ := screenstest.Send(shown(t, ""), FocusMsg{Key: "AGENT_MODEL"})
+	s, cmd := screenstest.Send(s, screenstest.Key("esc"))
+	if s.Picking() || cmd != nil {
+		t.Fatal("first Esc should close the picker and stay on the editor")
+	}
+	_, cmd = screenstest.Send(s, screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("second Esc showed %v, want the menu", show.Screen)
+	}
+}
+
+func TestSettingUpdatesField(t *testing.T) {
+	s, _ := screenstest.Send(shown(t, ""), screens.SettingMsg{Key: "OWNER_PHONE_NUMBER", Value: "15559876543"})
+	if v := s.View(); !strings.Contains(v, "15559876543") {
+		t.Errorf("view doesn't show the new setting:\n%s", v)
+	}
+}
+
+func TestHelpKeysFollowMode(t *testing.T) {
+	s := shown(t, "")
+	if got := strings.Join(s.HelpKeys(), " "); !strings.Contains(got, "Esc") {
+		t.Errorf("editor help %q", got)
+	}
+	s, _ = screenstest.Send(s, screenstest.Key("i"))
+	if got := s.HelpKeys(); len(got) != 1 || got[0] != "Esc Close" {
+		t.Errorf("help with the field doc open is %q", got)
+	}
+}
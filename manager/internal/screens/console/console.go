@@ -0,0 +1,223 @@
+// Package console is the Test Console screen, which talks to the agent
+// without going through WhatsApp.
+package console
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// consoleExchange is one message sent from the test console and its answer.
+type consoleExchange struct {
+	prompt string
+	sentAt time.Time
+	result *status.TestMessageResult // Nil while waiting or on error
+	err    error
+}
+
+// consoleReplyMsg carries the bridge's answer to a test console message.
+type consoleReplyMsg struct {
+	result *status.TestMessageResult
+	err    error
+}
+
+// sendTestMessageCmd runs text through the bridge's agent pipeline.
+func sendTestMessageCmd(client *status.Client, text string) tea.Cmd {
+	return func() tea.Msg {
+		r, err := client.SendTestMessage(text)
+		return consoleReplyMsg{result: r, err: err}
+	}
+}
+
+// Model sends messages through the bridge's agent pipeline and shows the
+// replies, keeping the transcript between visits.
+type Model struct {
+	screens.Room
+	client   *status.Client
+	input    string            // Message being typed
+	history  []consoleExchange // Sent messages and replies, oldest first
+	sending  bool              // Waiting for the bridge to answer
+	viewport viewport.Model    // Scrollable transcript
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client, viewport: viewport.New(76, 10)}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		s.layout()
+	case consoleReplyMsg:
+		s.sending = false
+		if n := len(s.history); n > 0 {
+			s.history[n-1].result = msg.result
+			s.history[n-1].err = msg.err
+		}
+		s.layout()
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+// updateKeys handles keys on the test console. Printable keys always go
+// to the input, so only Esc leaves the screen.
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return s, screens.Show(screens.Menu)
+	case "enter":
+		text := strings.TrimSpace(s.input)
+		if text == "" || s.sending {
+			return s, nil
+		}
+		s.input = ""
+		s.sending = true
+		s.history = append(s.history, consoleExchange{prompt: text, sentAt: time.Now()})
+		s.layout()
+		return s, sendTestMessageCmd(s.client, text)
+	case "backspace":
+		if r := []rune(s.input); len(r) > 0 {
+			s.input = string(r[:len(r)-1])
+		}
+		return s, nil
+	case "ctrl+u":
+		s.input = ""
+		return s, nil
+	case "ctrl+k":
+		if !s.sending {
+			s.history = nil
+			s.layout()
+		}
+		return s, nil
+	case "up", "down", "pgup", "pgdown":
+		var cmd tea.Cmd
+		s.viewport, cmd = s.viewport.Update(msg)
+		return s, cmd
+	}
+	if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+		s.input += string(msg.Runes)
+	}
+	return s, nil
+}
+
+// CapturingText is always true: every key is typed into the message.
+func (s Model) CapturingText() bool { return true }
+
+func (s Model) HelpKeys() []string {
+	return screens.KeyHelp(screens.Console, "Enter", "↑/↓", "ctrl+k", "Esc")
+}
+
+// layout sizes the transcript viewport to the room and refills it,
+// keeping the newest exchange in view.
+func (s *Model) layout() {
+	width, height := s.Size()
+	// Title and its gap, and the input line with a blank above it
+	s.viewport.Width = width - 4
+	s.viewport.Height = max(3, height-4)
+	s.viewport.SetContent(s.renderTranscript(width - 4))
+	s.viewport.GotoBottom()
+}
+
+// renderTranscript renders every exchange, oldest first.
+func (s Model) renderTranscript(width int) string {
+	if len(s.history) == 0 {
+		return theme.Muted().Render(lipgloss.NewStyle().Width(width).Render(
+			"Messages typed here go through Fetch's agent exactly as if they came from WhatsApp, " +
+				"in a session of their own. Replies, tool calls, and token usage show up below."))
+	}
+
+	wrap := lipgloss.NewStyle().Width(width - 3).PaddingLeft(3)
+	var b strings.Builder
+	for i, ex := range s.history {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(theme.StatusInfo().Render("You") + " " + theme.Muted().Render(ex.sentAt.Format("15:04:05")) + "\n")
+		b.WriteString(theme.Value().Render(wrap.Render(ex.prompt)) + "\n")
+
+		switch {
+		case ex.err != nil:
+			b.WriteString(theme.StatusError().Render(wrap.Render(theme.Cue("✗", "ERROR")+" "+ex.err.Error())) + "\n")
+		case ex.result == nil:
+			b.WriteString(theme.Muted().Render("   Waiting for Fetch...") + "\n")
+		default:
+			b.WriteString(renderConsoleResult(ex.result, wrap))
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderConsoleResult renders the reply header, progress, tool calls, and
+// the replies themselves.
+func renderConsoleResult(r *status.TestMessageResult, wrap lipgloss.Style) string {
+	var b strings.Builder
+
+	meta := []string{fmt.Sprintf("%.1fs", float64(r.DurationMs)/1000)}
+	if r.Usage != nil {
+		meta = append(meta, fmt.Sprintf("%s tokens (%s in, %s out)",
+			strconv.Itoa(r.Usage.TotalTokens), strconv.Itoa(r.Usage.PromptTokens), strconv.Itoa(r.Usage.CompletionTokens)))
+	}
+	if r.Mode != nil {
+		meta = append(meta, "mode "+*r.Mode)
+	}
+	b.WriteString(theme.StatusSuccess().Render("Fetch") + " " + theme.Muted().Render(strings.Join(meta, " · ")) + "\n")
+
+	for _, p := range r.Progress {
+		b.WriteString(theme.Muted().Render(wrap.Render("… "+p)) + "\n")
+	}
+	for _, tc := range r.ToolCalls {
+		mark := theme.StatusSuccess().Render(theme.Cue("✓", "OK"))
+		if !tc.Succeeded() {
+			mark = theme.StatusError().Render(theme.Cue("✗", "FAILED"))
+		}
+		b.WriteString("   " + theme.Muted().Render(theme.Cue("🔧", "Tool")+" "+tc.Name) + " " + mark + "\n")
+	}
+	if len(r.Responses) == 0 {
+		b.WriteString(theme.Muted().Render("   (no reply)") + "\n")
+	}
+	for _, reply := range r.Responses {
+		b.WriteString(wrap.Render(strings.TrimSpace(reply)) + "\n")
+	}
+	return b.String()
+}
+
+func (s Model) View() string {
+	width, _ := s.Size()
+
+	title := layout.SectionHeader("🧪 Test Console", width-4)
+
+	// Keep the end of a long message in view, where the cursor is
+	typed := []rune(s.input)
+	if room := width - 8; len(typed) > room {
+		typed = append([]rune("…"), typed[len(typed)-room+1:]...)
+	}
+	input := theme.Value().Render("› " + string(typed) + "█")
+	if s.sending {
+		input = theme.Muted().Render("› Waiting for Fetch to answer...")
+	}
+
+	body := lipgloss.NewStyle().PaddingLeft(2).Render(s.viewport.View())
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		body,
+		"",
+		"  "+input,
+	)
+}
@@ -0,0 +1,66 @@
+package console
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+)
+
+func TestSendShowsReply(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), append([]tea.Msg{screenstest.Room}, screenstest.Keys("h", "i", "q")...)...)
+	if v := s.View(); !strings.Contains(v, "› hiq") {
+		t.Errorf("typed message isn't shown:\n%s", v)
+	}
+	s, cmd := screenstest.Send(s, screenstest.Key("enter"))
+	if cmd == nil || !s.sending {
+		t.Fatal("Enter didn't send the message")
+	}
+	if v := s.View(); !strings.Contains(v, "Waiting for Fetch") {
+		t.Errorf("view while sending:\n%s", v)
+	}
+	if _, cmd = screenstest.Send(s, screenstest.Key("x"), screenstest.Key("enter")); cmd != nil {
+		t.Error("a second message went out before the first was answered")
+	}
+
+	s, _ = screenstest.Send(s, consoleReplyMsg{result: &status.TestMessageResult{
+		Responses:  []string{"Hello there!"},
+		ToolCalls:  []status.ToolCall{{Name: "read_file"}},
+		Usage:      &status.TokenUsage{PromptTokens: 90, CompletionTokens: 30, TotalTokens: 120},
+		DurationMs: 1500,
+	}})
+	v := s.View()
+	for _, want := range []string{"hiq", "Hello there!", "read_file", "1.5s", "120 tokens"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("transcript is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestFailedSendAndClear(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), append([]tea.Msg{screenstest.Room}, screenstest.Keys("h", "i", "enter")...)...)
+	s, _ = screenstest.Send(s, consoleReplyMsg{err: errors.New("admin token missing")})
+	if v := s.View(); !strings.Contains(v, "admin token missing") {
+		t.Errorf("transcript is missing the error:\n%s", v)
+	}
+	s, _ = screenstest.Send(s, screenstest.Key("ctrl+k"))
+	if v := s.View(); strings.Contains(v, "admin token missing") {
+		t.Errorf("ctrl+k didn't clear the transcript:\n%s", v)
+	}
+}
+
+func TestOnlyEscLeaves(t *testing.T) {
+	_, cmd := screenstest.Send(New(nil), screenstest.Key("q"))
+	if cmd != nil {
+		t.Error("q left the console instead of being typed")
+	}
+	_, cmd = screenstest.Send(New(nil), screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
@@ -0,0 +1,52 @@
+package gitproviders
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/gitprovider"
+)
+
+// ghRefreshInterval is how often GitHub auth is re-checked in the
+// background. Expired tokens break the kennel's pull requests without any
+// error the user would see, so the main menu flags them.
+const ghRefreshInterval = 15 * time.Minute
+
+// RefreshMsg starts a background GitHub auth check.
+type RefreshMsg struct{}
+
+func ghRefreshCmd() tea.Cmd {
+	return tea.Tick(ghRefreshInterval, func(time.Time) tea.Msg {
+		return RefreshMsg{}
+	})
+}
+
+// ghAuthProblem describes what stops the kennel from using GitHub, or
+// returns "" when auth is fine or another git provider is configured.
+func ghAuthProblem(accounts []ghAccount, missing bool) string {
+	if gitprovider.ByID(config.EnvValue("GIT_PROVIDER")).ID != "github" {
+		return ""
+	}
+	if missing {
+		return "CLI not installed"
+	}
+	if len(accounts) == 0 {
+		return "not signed in"
+	}
+	acct := accounts[0]
+	for _, a := range accounts {
+		if a.active {
+			acct = a
+			break
+		}
+	}
+	switch {
+	case acct.invalid:
+		return "token invalid"
+	case !acct.token.ExpiresAt.IsZero() && time.Now().After(acct.token.ExpiresAt):
+		return "token expired"
+	}
+	return ""
+}
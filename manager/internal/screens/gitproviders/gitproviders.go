@@ -0,0 +1,658 @@
+// Package gitproviders is the Git Providers screen: who is logged in to
+// each git provider, and logging in, switching, and removing accounts.
+package gitproviders
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/github"
+	"github.com/fetch/manager/internal/gitprovider"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/runner"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// ghDeviceLoginURL is where gh's device flow asks for the one-time code
+const ghDeviceLoginURL = "https://github.com/login/device"
+
+// ghAuthResultMsg carries the result of gh auth login
+type ghAuthResultMsg struct {
+	err error
+}
+
+// ghAccount represents a single GitHub account from gh auth status
+type ghAccount struct {
+	user     string
+	active   bool
+	protocol string
+	scopes   string
+	invalid  bool             // gh failed to log in with the stored token
+	token    github.TokenInfo // Scopes and expiry from the GitHub API
+	tokenErr error            // Set if the token could not be inspected
+}
+
+// ghStatusMsg carries the result of checking gh auth status
+type ghStatusMsg struct {
+	accounts []ghAccount
+	install  *gitprovider.Install // Set when gh isn't installed
+	err      error
+}
+
+// gitProviderStatusMsg carries the accounts for a non-GitHub provider
+type gitProviderStatusMsg struct {
+	id       string
+	accounts []gitprovider.Account
+	install  *gitprovider.Install // Set when the provider's CLI isn't installed
+	err      error
+}
+
+// cliInstallMsg carries the result of installing a provider's CLI
+type cliInstallMsg struct {
+	cli string
+	err error
+}
+
+// ghSwitchMsg carries the result of gh auth switch or gh auth logout
+type ghSwitchMsg struct {
+	err error
+}
+
+// cliInstallConfirmedMsg runs a provider's CLI install once confirmed
+type cliInstallConfirmedMsg struct {
+	cli     string
+	install gitprovider.Install
+}
+
+// Model shows who is logged in to each git provider and logs in,
+// switches, and removes accounts with the provider's CLI. GitHub auth is
+// also checked in the background for the menu.
+type Model struct {
+	screens.Room
+	shown    bool
+	provider int         // Index into gitprovider.Providers
+	selected string      // GIT_PROVIDER from .env
+	checking bool        // Whether we're currently checking status
+	accounts []ghAccount // All GitHub accounts from gh auth status
+	cursor   int         // Cursor for account selection
+	problem  string      // Why GitHub auth is unusable, from the last check
+	// Other git providers (GitLab, Gitea, Bitbucket)
+	providerAccounts []gitprovider.Account // Accounts for the shown provider
+	providerErr      error                 // Error from the provider's CLI
+	cliInstall       *gitprovider.Install  // How to install the shown provider's missing CLI
+}
+
+func New() Model {
+	return Model{}
+}
+
+// current returns the provider shown
+func (s Model) current() gitprovider.Provider {
+	return gitprovider.Providers[s.provider]
+}
+
+// refresh marks the screen as checking and starts a status check for the
+// shown provider. It must be called on the screen being returned.
+func (s *Model) refresh() tea.Cmd {
+	s.checking = true
+	p := s.current()
+	if p.ID == "github" {
+		return checkGhStatusCmd()
+	}
+	s.providerAccounts = nil
+	s.providerErr = nil
+	s.cliInstall = nil
+	return checkGitProviderCmd(p)
+}
+
+// checkGhStatusCmd checks current GitHub auth status via gh CLI
+func checkGhStatusCmd() tea.Cmd {
+	return func() tea.Msg {
+		if p := gitprovider.ByID("github"); !p.Installed() {
+			install := p.InstallHelp()
+			return ghStatusMsg{install: &install}
+		}
+		status := runner.New("gh", "auth", "status", "--show-token")
+		status.Combined = true
+		out, err := runner.Run(context.Background(), status)
+		if err != nil && len(out) == 0 {
+			// gh not installed or no accounts
+			return ghStatusMsg{accounts: nil, err: nil}
+		}
+		// Parse all accounts from output
+		// Format:
+		//   ✓ Logged in to github.com account USERNAME (keyring)
+		//   - Active account: true/false
+		//   - Git operations protocol: https
+		//   - Token: gho_************************************
+		//   - Token scopes: 'gist', 'read:org', 'repo', 'workflow'
+		var accounts []ghAccount
+		var tokens []string // Parallel to accounts; never stored in the model
+		var current *ghAccount
+		var token string
+		for _, line := range strings.Split(string(out), "\n") {
+			line = strings.TrimSpace(line)
+			loggedIn := strings.Contains(line, "Logged in to")
+			// An expired or revoked token shows as "X Failed to log in to
+			// github.com account USERNAME (keyring)"
+			if (loggedIn || strings.Contains(line, "Failed to log in to")) && strings.Contains(line, "account") {
+				// Start a new account
+				if current != nil {
+					accounts = append(accounts, *current)
+					tokens = append(tokens, token)
+				}
+				current = &ghAccount{invalid: !loggedIn}
+				token = ""
+				parts := strings.Split(line, "account ")
+				if len(parts) >= 2 {
+					user := strings.TrimSpace(parts[1])
+					if idx := strings.Index(user, " "); idx > 0 {
+						user = user[:idx]
+					}
+					current.user = user
+				}
+			} else if current != nil {
+				if strings.HasPrefix(line, "- Active account:") {
+					current.active = strings.Contains(line, "true")
+				} else if strings.HasPrefix(line, "- Git operations protocol:") {
+					current.protocol = strings.TrimPrefix(line, "- Git operations protocol: ")
+					current.protocol = strings.TrimSpace(current.protocol)
+				} else if strings.HasPrefix(line, "- Token scopes:") {
+					current.scopes = strings.TrimPrefix(line, "- Token scopes: ")
+					current.scopes = strings.TrimSpace(current.scopes)
+				} else if strings.HasPrefix(line, "- Token:") {
+					token = strings.TrimSpace(strings.TrimPrefix(line, "- Token:"))
+				}
+			}
+		}
+		if current != nil {
+			accounts = append(accounts, *current)
+			tokens = append(tokens, token)
+		}
+
+		// Ask the API what each token can actually do
+		for i := range accounts {
+			if tokens[i] == "" {
+				continue
+			}
+			accounts[i].token, accounts[i].tokenErr = github.Inspect(tokens[i])
+			if accounts[i].token.Scopes == nil && accounts[i].scopes != "" {
+				accounts[i].token.Scopes = github.ParseScopes(accounts[i].scopes)
+			}
+		}
+		return ghStatusMsg{accounts: accounts, err: nil}
+	}
+}
+
+// checkGitProviderCmd checks auth status for a non-GitHub provider
+func checkGitProviderCmd(p gitprovider.Provider) tea.Cmd {
+	return func() tea.Msg {
+		if !p.Installed() {
+			install := p.InstallHelp()
+			return gitProviderStatusMsg{id: p.ID, install: &install}
+		}
+		accounts, err := p.Status(config.EnvValue)
+		return gitProviderStatusMsg{id: p.ID, accounts: accounts, err: err}
+	}
+}
+
+// switchGhAccountCmd switches the active GitHub account
+func switchGhAccountCmd(user string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := runner.Output("gh", "auth", "switch", "-u", user)
+		return ghSwitchMsg{err: err}
+	}
+}
+
+// logoutGhAccountCmd removes a GitHub account
+func logoutGhAccountCmd(user string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := runner.Output("gh", "auth", "logout", "-u", user)
+		return ghSwitchMsg{err: err}
+	}
+}
+
+// Init starts the background GitHub auth checks.
+func (s Model) Init() tea.Cmd {
+	return tea.Batch(checkGhStatusCmd(), ghRefreshCmd())
+}
+
+// Problem says why GitHub auth is unusable, from the last check, or
+// returns "" when it is fine.
+func (s Model) Problem() string {
+	return s.problem
+}
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case screens.ShownMsg:
+		// Start on the configured provider
+		s.shown = true
+		s.selected = gitprovider.ByID(config.EnvValue("GIT_PROVIDER")).ID
+		for i, p := range gitprovider.Providers {
+			if p.ID == s.selected {
+				s.provider = i
+			}
+		}
+		cmd := s.refresh()
+		return s, cmd
+	case screens.HiddenMsg:
+		s.shown = false
+	case ghAuthResultMsg:
+		var notice tea.Cmd
+		if msg.err != nil {
+			notice = screens.Toast(fmt.Sprintf("GitHub auth failed: %v", msg.err), components.SeverityError)
+		} else {
+			notice = screens.Toast("GitHub authenticated! Restart Fetch to apply.", components.SeveritySuccess)
+		}
+		// Re-check status after login attempt
+		if s.shown {
+			cmd := s.refresh()
+			return s, tea.Batch(notice, cmd)
+		}
+		return s, notice
+	case gitProviderStatusMsg:
+		if msg.id == s.current().ID {
+			s.checking = false
+			s.providerAccounts = msg.accounts
+			s.providerErr = msg.err
+			s.cliInstall = msg.install
+		}
+	case cliInstallConfirmedMsg:
+		c := exec.Command(msg.install.Command[0], msg.install.Command[1:]...)
+		return s, tea.ExecProcess(c, func(err error) tea.Msg {
+			return cliInstallMsg{cli: msg.cli, err: err}
+		})
+	case cliInstallMsg:
+		var notice tea.Cmd
+		if msg.err != nil {
+			notice = screens.Toast(fmt.Sprintf("Installing %s failed: %v", msg.cli, msg.err), components.SeverityError)
+		} else {
+			notice = screens.Toast(fmt.Sprintf("%s installed. Press 'a' to log in.", msg.cli), components.SeveritySuccess)
+		}
+		if s.shown {
+			cmd := s.refresh()
+			return s, tea.Batch(notice, cmd)
+		}
+		return s, notice
+	case RefreshMsg:
+		return s, tea.Batch(checkGhStatusCmd(), ghRefreshCmd())
+	case ghStatusMsg:
+		// Background checks arrive while other providers are shown too
+		if s.current().ID == "github" {
+			s.checking = false
+			s.cliInstall = msg.install
+		}
+		s.accounts = msg.accounts
+		s.problem = ghAuthProblem(msg.accounts, msg.install != nil)
+		// Clamp cursor
+		if s.cursor >= len(s.accounts) {
+			s.cursor = 0
+		}
+	case ghSwitchMsg:
+		var notice tea.Cmd
+		if msg.err != nil {
+			notice = screens.Toast(fmt.Sprintf("GitHub operation failed: %v", msg.err), components.SeverityError)
+		}
+		// Re-check status after switch/logout
+		s.checking = true
+		return s, tea.Batch(notice, checkGhStatusCmd())
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	provider := s.current()
+
+	switch msg.String() {
+	case "esc", "q":
+		return s, screens.Show(screens.Menu)
+	case "tab", "right", "l":
+		s.provider = (s.provider + 1) % len(gitprovider.Providers)
+		cmd := s.refresh()
+		return s, cmd
+	case "shift+tab", "left", "h":
+		s.provider = (s.provider + len(gitprovider.Providers) - 1) % len(gitprovider.Providers)
+		cmd := s.refresh()
+		return s, cmd
+	case "u":
+		// Use this provider for Fetch's repositories
+		if err := config.SetEnvValue("GIT_PROVIDER", provider.ID); err != nil {
+			return s, screens.Toast(fmt.Sprintf("Failed to update .env: %v", err), components.SeverityError)
+		}
+		s.selected = provider.ID
+		return s, screens.Toast(fmt.Sprintf("GIT_PROVIDER=%s saved. Restart Fetch to apply.", provider.ID), components.SeveritySuccess)
+	}
+
+	if msg.String() == "p" && provider.ID == "github" && s.cliInstall == nil {
+		return s, screens.Show(screens.Repos)
+	}
+
+	// Without the CLI there is nothing to log in with yet
+	if s.cliInstall != nil {
+		switch msg.String() {
+		case "i", "a":
+			return s, installCLI(provider, *s.cliInstall)
+		case "r":
+			cmd := s.refresh()
+			return s, cmd
+		}
+		return s, screens.LinkKey(s.links(), msg)
+	}
+
+	if provider.ID != "github" {
+		return s.updateProviderKeys(msg, provider)
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+		return s, nil
+	case "down", "j":
+		if s.cursor < len(s.accounts)-1 {
+			s.cursor++
+		}
+		return s, nil
+	case "a":
+		// Add new account via gh auth login
+		c := exec.Command("gh", "auth", "login")
+		return s, tea.ExecProcess(c, func(err error) tea.Msg {
+			return ghAuthResultMsg{err: err}
+		})
+	case "s":
+		// Switch active account to selected
+		if len(s.accounts) > 0 && s.cursor < len(s.accounts) {
+			acct := s.accounts[s.cursor]
+			if !acct.active {
+				return s, switchGhAccountCmd(acct.user)
+			}
+		}
+		return s, nil
+	case "d":
+		// Remove selected account
+		if len(s.accounts) > 0 && s.cursor < len(s.accounts) {
+			acct := s.accounts[s.cursor]
+			return s, logoutGhAccountCmd(acct.user)
+		}
+		return s, nil
+	case "f":
+		// Request the scopes the harnesses need for the active account
+		c := exec.Command("gh", "auth", "refresh", "-h", "github.com", "-s", strings.Join(github.RequiredScopes, ","))
+		return s, tea.ExecProcess(c, func(err error) tea.Msg {
+			return ghAuthResultMsg{err: err}
+		})
+	case "r":
+		// Manual refresh
+		s.checking = true
+		return s, checkGhStatusCmd()
+	}
+	return s, screens.LinkKey(s.links(), msg)
+}
+
+// updateProviderKeys handles keys for GitLab, Gitea, and Bitbucket, which
+// only support logging in and refreshing from the manager
+func (s Model) updateProviderKeys(msg tea.KeyMsg, provider gitprovider.Provider) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "a":
+		if c := provider.LoginCommand(); c != nil && provider.Installed() {
+			return s, tea.ExecProcess(c, func(err error) tea.Msg {
+				return ghAuthResultMsg{err: err}
+			})
+		}
+		// No CLI: send the user to the provider's credential page
+		return s, screens.OpenLink(components.Link{Label: provider.Name, URL: provider.LoginURL})
+	case "r":
+		cmd := s.refresh()
+		return s, cmd
+	}
+	return s, screens.LinkKey(s.links(), msg)
+}
+
+// installCLI installs a provider's missing CLI with the package manager
+// found, after asking, or opens its download page when there is none.
+func installCLI(p gitprovider.Provider, install gitprovider.Install) tea.Cmd {
+	if install.Command == nil {
+		return screens.OpenLink(components.Link{Label: p.CLI + " download page", URL: install.URL})
+	}
+	return screens.Confirm("Install "+p.CLI,
+		fmt.Sprintf("Run `%s`? It runs in this terminal and may ask for your password.", install),
+		"Install", cliInstallConfirmedMsg{cli: p.CLI, install: install})
+}
+
+// links returns the device login page and the selected account's profile,
+// or where to get the shown provider's CLI or credentials.
+func (s Model) links() []components.Link {
+	p := s.current()
+	if s.cliInstall != nil {
+		return []components.Link{{Label: "Install " + p.CLI, URL: s.cliInstall.URL}}
+	}
+	if p.ID != "github" {
+		return []components.Link{{Label: p.Name + " credentials", URL: p.LoginURL}}
+	}
+	links := []components.Link{{Label: "Device login", URL: ghDeviceLoginURL}}
+	if len(s.accounts) > 0 && s.cursor < len(s.accounts) {
+		user := s.accounts[s.cursor].user
+		links = append(links, components.Link{Label: "Profile", URL: "https://github.com/" + user})
+	}
+	return links
+}
+
+// renderGhTokenDetail shows the token type, expiry, and whether the scopes
+// the coding harnesses need are present
+func renderGhTokenDetail(acct ghAccount, indent string) string {
+	var b strings.Builder
+	if acct.tokenErr != nil {
+		b.WriteString(indent + theme.StatusWarning().Render("⚠ Could not verify token: "+acct.tokenErr.Error()) + "\n")
+		return b.String()
+	}
+	if acct.token.Kind == "" {
+		return ""
+	}
+
+	b.WriteString(fmt.Sprintf("%sToken:    %s\n", indent, theme.Subtitle().Render(acct.token.Kind)))
+
+	if exp := acct.token.ExpiresAt; !exp.IsZero() {
+		left := time.Until(exp)
+		line := fmt.Sprintf("%s (%s)", exp.Local().Format("Jan 2, 2006"), formatDays(left))
+		style := theme.Subtitle()
+		switch {
+		case left <= 0:
+			style, line = theme.StatusError(), "expired "+exp.Local().Format("Jan 2, 2006")
+		case left < 7*24*time.Hour:
+			style = theme.StatusWarning()
+		}
+		b.WriteString(fmt.Sprintf("%sExpires:  %s\n", indent, style.Render(line)))
+	} else {
+		b.WriteString(fmt.Sprintf("%sExpires:  %s\n", indent, theme.Subtitle().Render("never")))
+	}
+
+	if acct.token.FineGrained {
+		b.WriteString(indent + theme.StatusInfo().Render("ℹ Fine-grained token — make sure it grants Contents and Workflows write access") + "\n")
+	} else if missing := acct.token.Missing(); len(missing) > 0 {
+		b.WriteString(indent + theme.StatusWarning().Render("⚠ Missing scopes needed by the coding agents: "+strings.Join(missing, ", ")) + "\n")
+		b.WriteString(indent + theme.Subtitle().Render("  Press 'f' to run gh auth refresh -s "+strings.Join(github.RequiredScopes, ",")) + "\n")
+	} else {
+		b.WriteString(indent + theme.StatusSuccess().Render("✓ Has repo and workflow scopes") + "\n")
+	}
+	return b.String()
+}
+
+// formatDays renders a duration as "in N days" for token expiry
+func formatDays(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days > 1:
+		return fmt.Sprintf("in %d days", days)
+	case days == 1:
+		return "in 1 day"
+	default:
+		return "today"
+	}
+}
+
+func (s Model) View() string {
+	width, _ := s.Size()
+
+	// Title
+	title := layout.SectionHeader("🔑 Git Providers", width-4)
+
+	var content strings.Builder
+	content.WriteString(s.renderGitProviderTabs() + "\n\n")
+
+	provider := s.current()
+	if s.checking {
+		content.WriteString(theme.StatusInfo().Render(fmt.Sprintf("   Checking %s auth status...", provider.Name)) + "\n")
+	} else if s.cliInstall != nil {
+		content.WriteString(renderCLIInstall(provider, *s.cliInstall))
+	} else if provider.ID != "github" {
+		content.WriteString(s.renderGitProviderAccounts(provider))
+	} else if len(s.accounts) == 0 {
+		content.WriteString(theme.StatusError().Render("   ● No Accounts") + "\n\n")
+		content.WriteString(theme.Subtitle().Render("   GitHub auth is required for Fetch to access repositories") + "\n")
+		content.WriteString(theme.Subtitle().Render("   and manage pull requests via the coding agents.") + "\n\n")
+		content.WriteString(theme.StatusInfo().Render("   Press 'a' to add a GitHub account.") + "\n")
+	} else {
+		content.WriteString(fmt.Sprintf("   %s\n\n", theme.Subtitle().Render(fmt.Sprintf("%d account(s) on github.com", len(s.accounts)))))
+		for i, acct := range s.accounts {
+			// Cursor indicator
+			prefix := "   "
+			if i == s.cursor {
+				prefix = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render(" ▸ ")
+			}
+
+			// Active badge
+			var badge string
+			if acct.invalid {
+				badge = theme.StatusError().Render(theme.Cue("✗", "[invalid]") + " Token invalid — press 'a' to sign in again")
+			} else if acct.active {
+				badge = theme.StatusSuccess().Render("● Active")
+			} else {
+				badge = lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Render("○ Inactive")
+			}
+
+			// Username styling
+			var userStyle lipgloss.Style
+			if i == s.cursor {
+				userStyle = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true)
+			} else {
+				userStyle = theme.Value()
+			}
+
+			content.WriteString(fmt.Sprintf("%s%s  %s\n", prefix, userStyle.Render(acct.user), badge))
+
+			// Show details for selected account
+			if i == s.cursor {
+				detailIndent := "      "
+				if acct.protocol != "" {
+					content.WriteString(fmt.Sprintf("%sProtocol: %s\n", detailIndent, theme.Subtitle().Render(acct.protocol)))
+				}
+				if acct.scopes != "" {
+					content.WriteString(fmt.Sprintf("%sScopes:   %s\n", detailIndent, theme.Subtitle().Render(acct.scopes)))
+				}
+				content.WriteString(renderGhTokenDetail(acct, detailIndent))
+			} else if missing := acct.token.Missing(); len(missing) > 0 && acct.tokenErr == nil && acct.token.Kind != "" {
+				content.WriteString(theme.StatusWarning().Render(fmt.Sprintf("      ⚠ Missing scopes: %s", strings.Join(missing, ", "))) + "\n")
+			}
+			content.WriteString("\n")
+		}
+	}
+
+	// Links (device login page, selected account profile)
+	if !s.checking {
+		content.WriteString(components.LinkList(s.links()))
+	}
+
+	// Lines that don't fit a narrow terminal are cut rather than wrapped
+	return title + "\n\n" + lipgloss.NewStyle().MaxWidth(width).Render(content.String())
+}
+
+func (s Model) HelpKeys() []string {
+	helpKeys := screens.KeyHelp(screens.GitHub, "Tab", "u", "a", "r")
+	if s.cliInstall != nil {
+		helpKeys = screens.KeyHelp(screens.GitHub, "Tab", "u", "i", "r")
+	} else if s.current().ID == "github" {
+		helpKeys = screens.KeyHelp(screens.GitHub, "Tab", "u", "↑/↓", "s", "a", "d", "f", "p", "r")
+	}
+	helpKeys = append(helpKeys, components.LinkHelp(len(s.links()))...)
+	return append(helpKeys, screens.KeyHelp(screens.GitHub, "Esc")...)
+}
+
+// renderGitProviderTabs draws the provider tab row, starring the provider
+// selected in .env
+func (s Model) renderGitProviderTabs() string {
+	var tabs []string
+	for i, p := range gitprovider.Providers {
+		label := p.Name
+		if p.ID == s.selected {
+			label += " ★"
+		}
+		if i == s.provider {
+			tabs = append(tabs, lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Underline(true).Render(label))
+		} else {
+			tabs = append(tabs, lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Render(label))
+		}
+	}
+	return "   " + strings.Join(tabs, "   ")
+}
+
+// renderGitProviderAccounts shows the accounts for GitLab, Gitea, or Bitbucket
+// renderCLIInstall explains that the provider's CLI is missing and how to
+// install it
+func renderCLIInstall(p gitprovider.Provider, install gitprovider.Install) string {
+	var b strings.Builder
+	b.WriteString(theme.StatusError().Render(fmt.Sprintf("   ● %s CLI not installed", p.Name)) + "\n\n")
+	b.WriteString(theme.Subtitle().Render(fmt.Sprintf("   Fetch uses %s to log in to %s and to push branches.", p.CLI, p.Name)) + "\n\n")
+	if install.Command != nil {
+		b.WriteString(theme.Subtitle().Render("   Install it with:") + "\n")
+		b.WriteString("      " + theme.Value().Render(install.String()) + "\n\n")
+		b.WriteString(theme.StatusInfo().Render("   Press 'i' to run this now, or open the download page below.") + "\n")
+	} else {
+		b.WriteString(theme.Subtitle().Render("   No package manager that ships it was found on this machine.") + "\n")
+		b.WriteString(theme.StatusInfo().Render("   Press 'i' to open the download page with instructions for your OS.") + "\n")
+	}
+	return b.String()
+}
+
+func (s Model) renderGitProviderAccounts(p gitprovider.Provider) string {
+	var b strings.Builder
+	switch {
+	case s.providerErr != nil:
+		b.WriteString(theme.StatusError().Render("   ● "+s.providerErr.Error()) + "\n\n")
+	case len(s.providerAccounts) == 0:
+		b.WriteString(theme.StatusError().Render("   ● No Accounts") + "\n\n")
+		if p.ID == "bitbucket" {
+			b.WriteString(theme.Subtitle().Render(fmt.Sprintf("   Set %s and %s in .env.", gitprovider.BitbucketUserKey, gitprovider.BitbucketPasswordKey)) + "\n")
+			b.WriteString(theme.StatusInfo().Render("   Press 'a' to create an app password.") + "\n")
+		} else {
+			b.WriteString(theme.StatusInfo().Render(fmt.Sprintf("   Press 'a' to log in with %s.", p.CLI)) + "\n")
+		}
+	default:
+		for _, acct := range s.providerAccounts {
+			badge := lipgloss.NewStyle().Foreground(theme.Active().TextMuted).Render(theme.Cue("○", "inactive"))
+			if acct.Active {
+				badge = theme.StatusSuccess().Render(theme.Cue("●", "active"))
+			}
+			b.WriteString(fmt.Sprintf("   %s %s %s\n", badge, theme.Value().Render(acct.User), theme.Subtitle().Render("@ "+acct.Host)))
+			if acct.Detail != "" {
+				b.WriteString(fmt.Sprintf("      %s\n", theme.Subtitle().Render(acct.Detail)))
+			}
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package gitproviders
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/github"
+	"github.com/fetch/manager/internal/gitprovider"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+)
+
+var accounts = []ghAccount{
+	{user: "octocat", active: true, protocol: "https", scopes: "'repo', 'workflow'"},
+	{user: "hubot"},
+}
+
+func checked(t *testing.T) Model {
+	t.Helper()
+	screenstest.Project(t, "GIT_PROVIDER=github\n")
+	s, _ := screenstest.Send(New(), screenstest.Room, screens.ShownMsg{}, ghStatusMsg{accounts: accounts})
+	return s
+}
+
+func TestViewListsAccounts(t *testing.T) {
+	screenstest.Project(t, "GIT_PROVIDER=github\n")
+	s, _ := screenstest.Send(New(), screenstest.Room, screens.ShownMsg{})
+	if v := s.View(); !strings.Contains(v, "Checking GitHub auth status") {
+		t.Errorf("view while checking:\n%s", v)
+	}
+	s, _ = screenstest.Send(s, ghStatusMsg{accounts: accounts})
+	v := s.View()
+	for _, want := range []string{"GitHub ★", "2 account(s)", "octocat", "● Active", "hubot", "Protocol: https"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestProblem(t *testing.T) {
+	expired := []ghAccount{{user: "octocat", active: true, token: github.TokenInfo{ExpiresAt: time.Now().Add(-time.Hour)}}}
+	tests := []struct {
+		name     string
+		provider string
+		msg      ghStatusMsg
+		want     string
+	}{
+		{"signed in", "github", ghStatusMsg{accounts: accounts}, ""},
+		{"no accounts", "github", ghStatusMsg{}, "not signed in"},
+		{"no gh", "github", ghStatusMsg{install: &gitprovider.Install{}}, "CLI not installed"},
+		{"invalid token", "github", ghStatusMsg{accounts: []ghAccount{{user: "octocat", active: true, invalid: true}}}, "token invalid"},
+		{"expired token", "github", ghStatusMsg{accounts: expired}, "token expired"},
+		{"another provider", "gitlab", ghStatusMsg{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screenstest.Project(t, "GIT_PROVIDER="+tt.provider+"\n")
+			s, _ := screenstest.Send(New(), tt.msg)
+			if s.Problem() != tt.want {
+				t.Errorf("problem %q, want %q", s.Problem(), tt.want)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		key  string
+		want screens.ID
+	}{
+		{"esc", screens.Menu},
+		{"q", screens.Menu},
+		{"p", screens.Repos},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			_, cmd := screenstest.Send(checked(t), screenstest.Key(tt.key))
+			if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != tt.want {
+				t.Errorf("%s showed %v, want %v", tt.key, show.Screen, tt.want)
+			}
+		})
+	}
+}
+
+func TestTabThenUseProvider(t *testing.T) {
+	s, _ := screenstest.Send(checked(t), screenstest.Key("tab"))
+	if s.current().ID != "gitlab" {
+		t.Fatalf("Tab showed %s, want gitlab", s.current().ID)
+	}
+	s, cmd := screenstest.Send(s, gitProviderStatusMsg{id: "gitlab"}, screenstest.Key("u"))
+	if toast, ok := screenstest.Find[screens.ToastMsg](cmd); !ok || !strings.Contains(toast.Message, "GIT_PROVIDER=gitlab") {
+		t.Errorf("got toast %q after u", toast.Message)
+	}
+	if got := config.EnvValue("GIT_PROVIDER"); got != "gitlab" {
+		t.Errorf(".env has GIT_PROVIDER=%q, want gitlab", got)
+	}
+	if v := s.View(); !strings.Contains(v, "GitLab ★") || !strings.Contains(v, "No Accounts") {
+		t.Errorf("view after switching:\n%s", v)
+	}
+}
+
+func TestCursorPicksAccount(t *testing.T) {
+	s, _ := screenstest.Send(checked(t), screenstest.Key("down"))
+	if s.cursor != 1 {
+		t.Fatalf("cursor %d after down, want 1", s.cursor)
+	}
+	if links := s.links(); links[len(links)-1].URL != "https://github.com/hubot" {
+		t.Errorf("profile link is %q, want hubot's", links[len(links)-1].URL)
+	}
+	if _, cmd := screenstest.Send(s, screenstest.Key("up"), screenstest.Key("s")); cmd != nil {
+		t.Error("s tried to switch to the account already active")
+	}
+}
@@ -0,0 +1,85 @@
+// Package groups is the Group chats screen: the WhatsApp groups Fetch
+// answers in.
+package groups
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+)
+
+// groupsDiscardMsg leaves the list once discarding the changes is confirmed
+type groupsDiscardMsg struct{}
+
+// Model shows which WhatsApp groups Fetch answers in and asks the bridge
+// for the groups to pick from. It is opened from the Trusted Numbers screen
+// and returns there.
+type Model struct {
+	screens.Room
+	client  *status.Client
+	manager *config.GroupManager
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case screens.ShownMsg:
+		s.manager = config.NewGroupManager(s.client)
+		return s, config.ListGroupsCmd(s.client)
+	case screens.HiddenMsg:
+		s.manager = nil
+	case config.GroupsLoadedMsg:
+		if s.manager != nil {
+			return s, s.manager.Update(msg)
+		}
+	case groupsDiscardMsg:
+		return s, screens.Show(screens.Whitelist)
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	gm := s.manager
+	if gm == nil {
+		return s, screens.Show(screens.Whitelist)
+	}
+	switch msg.String() {
+	case "esc", "q":
+		if gm.Dirty() {
+			return s, screens.Confirm("Discard changes",
+				"Leave without saving the groups you ticked or unticked?",
+				"Discard", groupsDiscardMsg{})
+		}
+		return s, screens.Show(screens.Whitelist)
+	}
+	return s, gm.Update(msg)
+}
+
+func (s Model) HelpKeys() []string {
+	return screens.KeyHelp(screens.Groups, "Space", "t", "s", "r", "Esc")
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	title := layout.SectionHeader("👥 Group Chats", width-4)
+	if s.manager == nil {
+		return title + "\n\n"
+	}
+	s.manager.SetSize(height - 6)
+	s.manager.SetWidth(width)
+	return title + "\n\n" + lipgloss.NewStyle().MaxWidth(width).Render(s.manager.View())
+}
@@ -0,0 +1,54 @@
+package groups
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+)
+
+func loaded(t *testing.T) Model {
+	t.Helper()
+	screenstest.Project(t, "")
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{}, config.GroupsLoadedMsg{Groups: []status.Group{
+		{ID: "1203@g.us", Name: "Family", Participants: 5},
+		{ID: "4506@g.us", Name: "Book club", Participants: 12},
+	}})
+	return s
+}
+
+func TestViewListsGroups(t *testing.T) {
+	v := loaded(t).View()
+	for _, want := range []string{"Group Chats", "Family", "Book club"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestEscAsksBeforeDiscarding(t *testing.T) {
+	_, cmd := screenstest.Send(loaded(t), screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Whitelist {
+		t.Errorf("Esc without changes showed %v, want Trusted Numbers", show.Screen)
+	}
+
+	s, cmd := screenstest.Send(loaded(t), screenstest.Key(" "), screenstest.Key("esc"))
+	confirm, ok := screenstest.Find[screens.ConfirmMsg](cmd)
+	if !ok {
+		t.Fatal("Esc with a ticked group didn't ask first")
+	}
+	_, cmd = screenstest.Send(s, confirm.Then)
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Whitelist {
+		t.Errorf("discarding showed %v, want Trusted Numbers", show.Screen)
+	}
+}
+
+func TestHiddenDropsChanges(t *testing.T) {
+	s, _ := screenstest.Send(loaded(t), screenstest.Key(" "), screens.HiddenMsg{})
+	if v := s.View(); strings.Contains(v, "Family") {
+		t.Errorf("the list outlived the screen:\n%s", v)
+	}
+}
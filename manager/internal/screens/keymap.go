@@ -0,0 +1,351 @@
+package screens
+
+// Binding documents one key on a screen.
+type Binding struct {
+	Key  string // As displayed, e.g. "↑/↓" or "Enter"
+	Help string // Short label for the help bar
+	Desc string // Full description for the ? overlay
+}
+
+// Keymap describes a screen's keys. Help bars and the ? overlay are
+// both generated from it, so a key documented here is documented everywhere.
+type Keymap struct {
+	Title    string
+	Summary  string
+	Bindings []Binding
+}
+
+// GlobalBindings work on every screen.
+var GlobalBindings = []Binding{
+	{"ctrl+p", "Commands", "Open the command palette"},
+	{"?", "Help", "Show keys for the current screen"},
+	{"ctrl+s", "Start", "Start Fetch from any screen"},
+	{"ctrl+x", "Stop", "Stop Fetch from any screen (asks for confirmation)"},
+	{"ctrl+r", "Restart bridge", "Restart the bridge container from any screen"},
+	{"Esc", "Cancel", "Cancel the action running in the status bar, such as Stop Fetch"},
+	{"ctrl+l", "Logs", "Jump to the logs from any screen"},
+	{"ctrl+z", "Suspend", "Suspend to the shell and pause status polling; fg resumes"},
+	{"click", "Select", "Select tabs, menu items, and config fields; click again to open"},
+	{"wheel", "Scroll", "Scroll lists and logs (--no-mouse keeps terminal text selection)"},
+}
+
+// TabBindings work on the tab screens (Status, Logs, Config, Tasks, Setup).
+var TabBindings = []Binding{
+	{"Tab", "Next tab", "Switch to the next tab (shift+tab for previous)"},
+	{"1-5", "Go to tab", "Jump to a tab by its number (not on Logs, or on Setup while it shows a link; use Tab there)"},
+}
+
+var (
+	bindBack    = Binding{"Esc", "Back", "Return to the main menu"}
+	bindLinks   = Binding{"1-9", "Open link", "Open a numbered link in the browser"}
+	bindCopy    = Binding{"alt+1-9", "Copy link", "Copy a numbered link to the clipboard"}
+	bindRefresh = Binding{"r", "Refresh", "Reload from the bridge"}
+)
+
+// Keymaps holds every screen's keys.
+var Keymaps = map[ID]Keymap{
+	Menu: {
+		Title:   "Main Menu",
+		Summary: "Start here to manage Fetch's services, configuration, and updates.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Move through the menu"},
+			{"PgUp/PgDn", "Page", "Move five items at a time (Home/End for the first or last)"},
+			{"Enter", "Select", "Open the highlighted item"},
+			{"1-0", "Open #", "Open an item by the number beside it (0 for the tenth)"},
+			{"s", "Start", "Start Fetch"},
+			{"x", "Stop", "Stop Fetch"},
+			{"l", "Logs", "View the logs"},
+			{"n", "Notifications", "Show notification history"},
+			{"q", "Quit", "Exit the manager (Fetch keeps running)"},
+		},
+	},
+	Setup: {
+		Title:   "Setup WhatsApp",
+		Summary: "Link Fetch to WhatsApp by scanning the QR code or with a pairing code.",
+		Bindings: []Binding{
+			{"o", "Open QR", "Open the QR code in the browser"},
+			{"s", "Save PNG", "Save the QR code to data/whatsapp-qr.png, kept current until linked"},
+			{"h", "Serve QR", "Serve the QR code on a local URL for two minutes (h again stops)"},
+			{"p", "Pair with phone", "Link with an 8-character code instead of scanning"},
+			{"Enter", "Request code", "Request a pairing code for the typed number"},
+			{"x", "Disconnect", "Log out of WhatsApp (asks for confirmation)"},
+			{"r", "Reconnect", "Restart the bridge with the saved login (asks for confirmation)"},
+			{"l", "Re-link", "Log out and show a new QR code (asks for confirmation)"},
+			{"c", "Clear login", "Delete the saved session when it can't connect (asks for confirmation)"},
+			bindLinks,
+			bindCopy,
+			bindBack,
+		},
+	},
+	GitHub: {
+		Title:   "Git Providers",
+		Summary: "Check which git hosts the coding harnesses can push to and log in to them.",
+		Bindings: []Binding{
+			{"Tab", "Provider", "Next provider (shift+tab for previous)"},
+			{"u", "Use", "Save this provider as GIT_PROVIDER"},
+			{"↑/↓", "Navigate", "Select a GitHub account"},
+			{"s", "Switch", "Make the selected GitHub account active"},
+			{"a", "Add", "Log in to another account"},
+			{"i", "Install", "Install the provider's missing CLI (asks first)"},
+			{"d", "Remove", "Log out the selected GitHub account"},
+			{"f", "Fix Scopes", "Request the repo and workflow scopes for the active account"},
+			{"p", "Repos", "Choose which repositories the coding agents may work on"},
+			{"r", "Refresh", "Re-check authentication"},
+			bindLinks,
+			bindCopy,
+			bindBack,
+		},
+	},
+	Status: {
+		Title:   "System Status",
+		Summary: "Diagnostics for Docker, the containers, the bridge, and the coding harnesses.",
+		Bindings: []Binding{
+			{"r", "Re-run", "Run the diagnostics again"},
+			{"b", "Back up now", "Archive .env and data/ into backups/ now"},
+			bindBack,
+		},
+	},
+	Stats: {
+		Title:    "Statistics",
+		Summary:  "Message, task, and token usage reported by the bridge.",
+		Bindings: []Binding{bindRefresh, bindBack},
+	},
+	Tasks: {
+		Title:   "Tasks",
+		Summary: "Coding tasks Fetch is running or has run recently.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Select a task"},
+			{"s", "Sort", "Sort by the next column: status, agent, run time, goal"},
+			{"S", "Reverse", "Reverse the sort order"},
+			{"c", "Cancel", "Cancel the selected running task"},
+			{"R", "Retry", "Retry the selected failed task"},
+			{"a", "Approvals", "Answer tasks waiting for approval"},
+			bindRefresh,
+			bindBack,
+		},
+	},
+	Approvals: {
+		Title:   "Approvals",
+		Summary: "Tasks paused on an agent asking to go ahead, such as running a command or pushing. Answering here is the same as replying on WhatsApp.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Select a waiting task"},
+			{"y", "Approve", "Reply yes so the agent goes ahead"},
+			{"n", "Deny", "Reply no so the agent doesn't"},
+			bindRefresh,
+			bindBack,
+		},
+	},
+	Limits: {
+		Title:   "Rate Limits & Circuit Breakers",
+		Summary: "Live breaker state per session and rate limit use per number, sampled every 2 seconds while open, to tune the FETCH_CB_* and FETCH_RATE_LIMIT_* settings.",
+		Bindings: []Binding{
+			{"r", "Refresh", "Sample the bridge now"},
+			{"c", "Clear", "Start the history over, e.g. after changing a setting"},
+			bindBack,
+		},
+	},
+	Summaries: {
+		Title:   "Conversation Summaries",
+		Summary: "What the agent remembers of earlier conversation: the summaries the bridge stores per session in place of older messages. Read-only.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Select a summary to read in full"},
+			{"/", "Search", "Show only summaries containing every typed word"},
+			bindRefresh,
+			bindBack,
+		},
+	},
+	Workspaces: {
+		Title:   "Workspaces",
+		Summary: "Repositories and projects in the kennel that the coding agents work in.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Select a workspace, or scroll the diff"},
+			{"Enter", "Diff", "Show uncommitted changes, or the last commit when clean"},
+			{"d", "Delete", "Delete the selected workspace from disk (asks first)"},
+			bindRefresh,
+			{"Esc", "Back", "Close the diff, or return to the main menu"},
+		},
+	},
+	Config: {
+		Title:   "Configuration",
+		Summary: "Edit the settings in .env. Restart Fetch for changes to take effect.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Move between fields"},
+			{"PgUp/PgDn", "Section", "Jump to the previous or next section (Home/End for the first or last field)"},
+			{"Enter", "Edit", "Edit the selected field (Agent Model opens the model picker)"},
+			{"p", "Presets", "Fill in tuning values for a profile such as Budget or Long memory, with a preview"},
+			{"i", "Details", "Explain the selected field: accepted values, default, what uses it, and whether a restart is needed (also F1)"},
+			{"s", "Save", "Write changes to .env (asks for confirmation)"},
+			bindBack,
+		},
+	},
+	Models: {
+		Title:   "Select Model",
+		Summary: "Choose the OpenRouter model Fetch's agent uses.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Move through the models"},
+			{"PgUp/PgDn", "Page", "Move a screenful at a time (Home/End for the first or last model)"},
+			{"Enter", "Select", "Use the highlighted model"},
+			{"Tab", "Toggle", "Switch between recommended and all models"},
+			{"Esc", "Back", "Return to the configuration editor"},
+		},
+	},
+	Whitelist: {
+		Title:   "Trusted Numbers",
+		Summary: "Numbers allowed to use @fetch besides the owner.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Select a number"},
+			{"PgUp/PgDn", "Page", "Move a page at a time (Home/End for the first or last number)"},
+			{"s", "Sort", "Sort by the next column: number, role, label, date added, expiry"},
+			{"S", "Reverse", "Reverse the sort order"},
+			{"a", "Add", "Add a trusted number"},
+			{"l", "Label", "Name the selected number"},
+			{"n", "Note", "Attach a note to the selected number"},
+			{"p", "Permissions", "Choose what the selected number may do"},
+			{"t", "Temporary", "Make the selected number expire after a while"},
+			{"b", "Bulk paste", "Add several numbers at once"},
+			{"i", "Import", "Import numbers from CSV or vCard"},
+			{"x", "Export", "Export numbers to CSV or vCard"},
+			{"d", "Delete", "Remove the selected number (asks for confirmation)"},
+			{"g", "Groups", "Choose which group chats Fetch answers in"},
+			bindRefresh,
+			bindBack,
+		},
+	},
+	Logs: {
+		Title:   "Logs",
+		Summary: "Live output from the bridge container, and a search across the days of logs Docker keeps.",
+		Bindings: []Binding{
+			{"↑/↓", "Scroll", "Scroll one line"},
+			{"PgUp/PgDn", "Page", "Scroll half a page"},
+			{"g/G", "Top/Bottom", "Jump to the first or last line"},
+			{"a", "Auto-scroll", "Follow new lines"},
+			{"w", "Wrap", "Toggle word wrap"},
+			{"r", "Raw", "Toggle raw output"},
+			{"1-4", "Levels", "Show or hide DEBUG, INFO, WARN, ERROR"},
+			{"i", "Correlate", "Pick a line with a task, session, or request ID and follow it across bridge and kennel"},
+			{"c", "Copy", "Copy the visible lines"},
+			{"C", "Copy all", "Copy every line"},
+			{"x", "Clear", "Clear the buffer (asks for confirmation)"},
+			{"/", "Search history", "Search days of bridge and kennel logs; Tab picks how far back"},
+			{"v", "Split view", "Show kennel logs beside the bridge's; Tab moves between panes, t matches the other pane's time"},
+			{"Esc", "Back", "Leave search results, or go back"},
+		},
+	},
+	Version: {
+		Title:   "Version",
+		Summary: "Build information, component versions, and manager self-update.",
+		Bindings: []Binding{
+			{"r", "Refresh", "Read the bridge, kennel, and checkout versions again"},
+			{"c", "Check for Updates", "Look up the latest manager release"},
+			{"u", "Update Manager", "Download, verify, and install the newer release"},
+			bindLinks,
+			bindCopy,
+			bindBack,
+		},
+	},
+	Update: {
+		Title:   "Update Fetch",
+		Summary: "Review what's new on origin/main, then pull and rebuild.",
+		Bindings: []Binding{
+			{"↑/↓", "Scroll", "Scroll the changelog"},
+			{"Enter", "Update", "Pull and rebuild (asks for confirmation)"},
+			{"y", "Confirm", "Confirm the pending update or rollback"},
+			{"n", "Cancel", "Cancel the pending update or rollback"},
+			{"b", "Rollback", "Return to the commit before the last update"},
+			{"r", "Re-check", "Fetch origin/main again"},
+			{"Esc", "Back", "Return to the menu; a running update continues"},
+		},
+	},
+	Notifications: {
+		Title:   "Notifications",
+		Summary: "Everything shown as a toast this session, newest first, and where state changes are sent.",
+		Bindings: []Binding{
+			{"t", "Send test", "Send a test notification to the FETCH_NOTIFY_* targets"},
+			{"c", "Clear", "Forget past notifications"},
+			bindBack,
+		},
+	},
+	Console: {
+		Title:   "Test Console",
+		Summary: "Send messages through Fetch's agent as if they came from WhatsApp, without a phone.",
+		Bindings: []Binding{
+			{"Enter", "Send", "Send the typed message to the agent"},
+			{"↑/↓", "Scroll", "Scroll the transcript (PgUp/PgDn for a page)"},
+			{"ctrl+u", "Clear input", "Erase the message being typed"},
+			{"ctrl+k", "Clear", "Clear the transcript (the agent's session keeps its history)"},
+			bindBack,
+		},
+	},
+	Repos: {
+		Title:   "Repository Allow-List",
+		Summary: "Repositories the coding agents may clone and start tasks in. Saved to data/repos.json, which the bridge checks before every clone and task.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Select a repository"},
+			{"Space", "Toggle", "Allow or disallow the selected repository"},
+			{"A", "All", "Tick every shown repository, or untick them all"},
+			{"/", "Filter", "Show only repositories matching the typed text"},
+			{"m", "Add", "Add a repository gh didn't list, as owner/name"},
+			{"s", "Save", "Write the list; only ticked repositories are allowed"},
+			{"x", "Remove list", "Delete the list so every repository is allowed (asks first)"},
+			{"r", "Reload", "Re-read the saved list and the repositories from gh"},
+			{"Enter", "Confirm", "Apply the filter or add the typed repository"},
+			{"Esc", "Back", "Return to Git Providers (asks about unsaved changes)"},
+		},
+	},
+	Groups: {
+		Title:   "Group Chats",
+		Summary: "WhatsApp groups Fetch answers in. Saved to data/whitelist.json; the bridge reloads it on save.",
+		Bindings: []Binding{
+			{"↑/↓", "Navigate", "Select a group"},
+			{"Space", "Toggle", "Allow or disallow the selected group"},
+			{"t", "Restrict", "Switch between every group and only ticked groups"},
+			{"s", "Save", "Write the list and tell the bridge"},
+			{"r", "Reload", "Re-read the saved list and the groups from the bridge"},
+			{"Esc", "Back", "Return to Trusted Numbers (asks about unsaved changes)"},
+		},
+	},
+	Owner: {
+		Title:   "Change Owner",
+		Summary: "Switch OWNER_PHONE_NUMBER to a new number, optionally proven with a code sent over WhatsApp. Updates .env and the running bridge together.",
+		Bindings: []Binding{
+			{"Enter", "Next", "Check the number, or the typed code"},
+			{"v", "Verify", "Send a verification code to the new number"},
+			{"a", "Apply", "Switch without verifying"},
+			{"r", "Resend", "Send a new code"},
+			{"Esc", "Back", "Go back a step, or return to Configure"},
+		},
+	},
+	Crash: {
+		Title:   "Error",
+		Summary: "The manager caught an internal error instead of exiting.",
+		Bindings: []Binding{
+			{"c", "Copy report", "Copy the error and stack trace to the clipboard"},
+			{"Esc", "Menu", "Dismiss the error and return to the main menu"},
+			{"q", "Quit", "Exit the manager"},
+		},
+	},
+}
+
+// KeyHelp builds help bar entries for a screen from its keymap, in the
+// given order. Keys are matched against the displayed key.
+func KeyHelp(id ID, keys ...string) []string {
+	return helpEntries(Keymaps[id].Bindings, keys)
+}
+
+// GlobalHelp builds help bar entries for keys that work on every screen.
+func GlobalHelp(keys ...string) []string {
+	return helpEntries(GlobalBindings, keys)
+}
+
+func helpEntries(bindings []Binding, keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, b := range bindings {
+			if b.Key == key {
+				out = append(out, b.Key+" "+b.Help)
+				break
+			}
+		}
+	}
+	return out
+}
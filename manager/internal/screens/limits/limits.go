@@ -0,0 +1,88 @@
+// Package limits is the rate limit and circuit breaker screen.
+package limits
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/limits"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+)
+
+// Model is the circuit breaker and rate limit panel. It samples while
+// shown and keeps the history from earlier visits.
+type Model struct {
+	screens.Room
+	client  *status.Client
+	panel   *limits.Panel
+	shown   bool
+	polling bool // The sampling loop is running
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		return s, nil
+	case screens.ShownMsg:
+		s.shown = true
+		if s.panel == nil {
+			s.panel = limits.NewPanel(s.client)
+		} else if s.polling {
+			return s, nil
+		}
+		s.polling = true
+		return s, s.panel.Init()
+	case screens.HiddenMsg:
+		s.shown = false
+		return s, nil
+	case limits.TickMsg:
+		// Stop sampling once the screen is left; the next visit restarts it
+		if !s.shown {
+			s.polling = false
+			return s, nil
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return s, screens.Show(screens.Menu)
+		}
+	}
+	if s.panel == nil {
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.panel, cmd = s.panel.Update(msg)
+	return s, cmd
+}
+
+func (s Model) HelpKeys() []string {
+	if s.panel == nil {
+		return screens.KeyHelp(screens.Limits, "Esc")
+	}
+	return s.panel.HelpKeys()
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	title := layout.SectionHeader("🚦 Rate Limits & Circuit Breakers", width-4)
+
+	var content strings.Builder
+	if s.panel != nil {
+		// Rows that don't fit a small terminal are cut rather than wrapped
+		fit := lipgloss.NewStyle().MaxWidth(width).MaxHeight(height - lipgloss.Height(title) - 1)
+		content.WriteString(fit.Render(s.panel.View(width)))
+	}
+	return title + "\n\n" + content.String()
+}
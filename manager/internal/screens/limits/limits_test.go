@@ -0,0 +1,52 @@
+package limits
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/limits"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+)
+
+var loaded = limits.LoadedMsg{Limits: &status.LimitsStatus{
+	CircuitBreaker: status.CircuitBreakerStatus{Threshold: 3, Sessions: []status.SessionBreaker{
+		{SessionID: "15551234567", State: status.BreakerOpen, ErrorCount: 3},
+	}},
+	RateLimit: status.RateLimitStatus{MaxRequests: 30, WindowMs: 60000},
+}}
+
+func TestViewShowsBreakers(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{}, loaded)
+	v := s.View()
+	for _, want := range []string{"Rate Limits & Circuit Breakers", "15551234567"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestSamplesOnlyWhileShown(t *testing.T) {
+	s, cmd := screenstest.Send(New(nil), screens.ShownMsg{})
+	if cmd == nil {
+		t.Fatal("the first visit didn't start sampling")
+	}
+	if _, cmd = screenstest.Send(s, screens.ShownMsg{}); cmd != nil {
+		t.Error("a second visit started another sampling loop")
+	}
+	s, cmd = screenstest.Send(s, screens.HiddenMsg{}, limits.TickMsg{})
+	if cmd != nil {
+		t.Error("sampling went on after the screen was left")
+	}
+	if _, cmd = screenstest.Send(s, screens.ShownMsg{}); cmd == nil {
+		t.Error("coming back didn't restart sampling")
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	_, cmd := screenstest.Send(New(nil), screens.ShownMsg{}, loaded, screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
@@ -0,0 +1,44 @@
+package screens
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/browser"
+	"github.com/fetch/manager/internal/components"
+)
+
+// LinkKey opens or copies the link a number key picks from links, or
+// returns nil when the key picks none.
+func LinkKey(links []components.Link, msg tea.KeyMsg) tea.Cmd {
+	idx, copyLink, ok := components.LinkKey(msg.String(), len(links))
+	if !ok {
+		return nil
+	}
+	if copyLink {
+		return CopyLink(links[idx])
+	}
+	return OpenLink(links[idx])
+}
+
+// OpenLink opens a link with the platform's default browser
+func OpenLink(link components.Link) tea.Cmd {
+	return func() tea.Msg {
+		if err := browser.Open(link.URL); err != nil {
+			return ToastMsg{Message: fmt.Sprintf("Failed to open %s: %v", link.Label, err), Severity: components.SeverityError}
+		}
+		return ToastMsg{Message: fmt.Sprintf("🔗 Opened %s in browser", link.Label), Severity: components.SeveritySuccess}
+	}
+}
+
+// CopyLink copies a link's URL to the clipboard
+func CopyLink(link components.Link) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(link.URL); err != nil {
+			return ToastMsg{Message: fmt.Sprintf("Failed to copy %s: %v", link.Label, err), Severity: components.SeverityError}
+		}
+		return ToastMsg{Message: fmt.Sprintf("📋 Copied %s link", link.Label), Severity: components.SeveritySuccess}
+	}
+}
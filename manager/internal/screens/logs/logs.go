@@ -0,0 +1,256 @@
+// Package logs is the Logs screen: the bridge logs as they are written,
+// the kennel's beside them in the split view, and a search through their
+// history.
+package logs
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/logs"
+	"github.com/fetch/manager/internal/screens"
+)
+
+// refreshInterval is how often the screen re-reads the bridge logs.
+const refreshInterval = 2 * time.Second
+
+// resizeDebounce is how long the terminal must keep one size before the
+// viewers are resized
+const resizeDebounce = 100 * time.Millisecond
+
+// Tail reads the newest n lines of a container's logs, each starting with
+// Docker's timestamp.
+type Tail func(container string, n int) ([]string, error)
+
+// Msg carries the bridge's newest log lines, parsed.
+type Msg struct {
+	Entries []components.LogEntry // Timed by Docker
+}
+
+// ErrorsMsg counts the bridge's recent errors logged after Since.
+type ErrorsMsg struct {
+	Since time.Time
+	Count int
+}
+
+// TickMsg re-reads the logs. The root model answers it with PausedMsg
+// once the tabs are closed, and the next visit starts the loop again.
+type (
+	TickMsg   struct{}
+	PausedMsg struct{}
+)
+
+// SettledMsg fires once the terminal has stopped resizing; seq matches the
+// last resize seen when it was scheduled
+type SettledMsg struct {
+	seq int
+}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+		return TickMsg{}
+	})
+}
+
+// Model is the Logs screen. Its viewers live as long as the manager, so
+// the scroll position, filter and search survive leaving the screen.
+type Model struct {
+	screens.Room
+	tail        Tail
+	viewer      *components.LogViewer
+	kennel      *components.LogViewer // Right pane of the split view
+	split       bool                  // Kennel logs shown beside the bridge's
+	kennelFocus bool                  // Keys go to the kennel pane
+	search      search
+	streaming   bool // The refresh loop is running
+	// Re-wrapping the logs is expensive, so a drag-resize must settle
+	// before the viewers are resized
+	resizeSeq int
+	pending   tea.WindowSizeMsg
+	sized     bool // A size was applied since the screen was opened
+}
+
+// New returns the Logs screen reading logs with tail, filtered as the last
+// run left them.
+func New(tail Tail, filter string, hiddenLevels []string) Model {
+	viewer := components.NewLogViewer(80, 24)
+	viewer.SetFilter(filter)
+	viewer.SetHiddenLevels(hiddenLevels)
+	return Model{tail: tail, viewer: viewer}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+// Filter and HiddenLevels are the viewer's filters, for the next run.
+func (s Model) Filter() string         { return s.viewer.Filter() }
+func (s Model) HiddenLevels() []string { return s.viewer.HiddenLevels() }
+
+// CapturingText is true while a search is typed.
+func (s Model) CapturingText() bool {
+	return s.search.editing
+}
+
+// UsesNumberKeys is true: the number keys toggle log levels.
+func (s Model) UsesNumberKeys() bool { return true }
+
+// CountErrors counts errors among the bridge's latest log lines that were
+// written after since, for the View Logs badge on the menu.
+func (s Model) CountErrors(since time.Time) tea.Cmd {
+	tail := s.tail
+	return func() tea.Msg {
+		_, entries := recentLogs(tail, "fetch-bridge", "bridge")
+		count := 0
+		for _, e := range entries {
+			if e.Timestamp.After(since) && components.IsErrorLevel(e.Level) {
+				count++
+			}
+		}
+		return ErrorsMsg{Since: since, Count: count}
+	}
+}
+
+// fetch reads the bridge logs.
+func (s Model) fetch() tea.Msg {
+	_, entries := recentLogs(s.tail, "fetch-bridge", "bridge")
+	return Msg{Entries: entries}
+}
+
+// recentLogs reads the newest lines of a container's logs. Each entry takes
+// its time from Docker, so it is right however long ago the line was
+// written. Secrets are redacted before the lines are shown or copied.
+func recentLogs(tail Tail, container, source string) ([]string, []components.LogEntry) {
+	raw, err := tail(container, 200)
+	if err != nil {
+		return nil, nil
+	}
+	redactor, _ := config.Redactor()
+	lines := make([]string, 0, len(raw))
+	entries := make([]components.LogEntry, 0, len(raw))
+	for _, r := range raw {
+		at, line, ok := docker.SplitTimestamp(r)
+		line = redactor.String(line)
+		entry := logs.ParseLogLine(line, source)
+		if ok {
+			entry.Timestamp = at
+		}
+		lines = append(lines, line)
+		entries = append(entries, entry)
+	}
+	return lines, entries
+}
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.resizeSeq++
+		if !s.sized {
+			s.sized = true
+			s.Resize(msg)
+			return s, nil
+		}
+		s.pending = msg
+		seq := s.resizeSeq
+		return s, tea.Tick(resizeDebounce, func(time.Time) tea.Msg {
+			return SettledMsg{seq: seq}
+		})
+
+	case SettledMsg:
+		if msg.seq == s.resizeSeq {
+			s.Resize(s.pending)
+		}
+
+	case screens.ShownMsg:
+		// The screen opens at the size it's drawn at, not after a delay
+		s.sized = false
+		if s.streaming {
+			return s, nil
+		}
+		s.streaming = true
+		return s, tea.Batch(s.fetch, tickCmd())
+
+	case TickMsg:
+		if s.split {
+			return s, tea.Batch(s.fetch, s.fetchKennel, tickCmd())
+		}
+		return s, tea.Batch(s.fetch, tickCmd())
+
+	case PausedMsg:
+		s.streaming = false
+
+	case Msg:
+		// Search results stay put until the search is closed, and the
+		// entries being picked from until one is chosen
+		if !s.viewer.InHistory() && !s.viewer.Picking() {
+			s.viewer.SyncTail(msg.Entries)
+		}
+
+	case kennelMsg:
+		if s.kennel != nil && !s.kennel.Picking() {
+			s.kennel.SyncTail(msg.entries)
+		}
+
+	case searchMsg:
+		return s.updateSearch(msg)
+
+	case components.CorrelateMsg:
+		return s.correlate(msg)
+
+	case screens.ClickMsg:
+		s.viewerAt(msg.X).ClickLine(msg.Line)
+
+	case tea.MouseMsg:
+		viewer := s.viewerAt(msg.X)
+		if !viewer.Confirming() {
+			_, cmd := viewer.Update(msg)
+			return s, cmd
+		}
+
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if s.search.editing {
+		return s.updateSearchInput(msg)
+	}
+	viewer := s.focused()
+	if !viewer.Confirming() && !viewer.Picking() {
+		if s.split {
+			if next, cmd, handled := s.updateSplit(msg); handled {
+				return next, cmd
+			}
+		}
+		switch msg.String() {
+		case "/":
+			return s.openSearch(), nil
+		case "v":
+			return s.toggleSplit()
+		case "esc", "q":
+			if s.viewer.InHistory() {
+				return s.closeSearch()
+			}
+			return s, screens.Show(screens.Menu)
+		}
+	}
+	// Delegate all other keys to the focused viewer (scroll, copy, wrap, etc.)
+	_, cmd := viewer.Update(msg)
+	return s, cmd
+}
+
+// HelpKeys is nil: the viewers draw their own help line.
+func (s Model) HelpKeys() []string { return nil }
+
+func (s Model) View() string {
+	width, height := s.Size()
+	if s.split {
+		return s.viewSplit(width, height)
+	}
+	s.viewer.SetSize(width, height)
+	return s.viewer.View()
+}
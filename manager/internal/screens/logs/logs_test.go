@@ -0,0 +1,124 @@
+package logs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+)
+
+// tail returns a line for each container, as docker logs --timestamps
+// writes them.
+func tail(container string, n int) ([]string, error) {
+	return []string{"2026-01-02T15:04:05.000000000Z hello from " + container}, nil
+}
+
+// shown opens the screen as the root model does: shown, then sized.
+func shown(t *testing.T) Model {
+	t.Helper()
+	screenstest.Project(t, "")
+	s, _ := screenstest.Send(New(tail, "", nil), screens.ShownMsg{}, screenstest.Room)
+	return s
+}
+
+func TestShownStreamsLogs(t *testing.T) {
+	screenstest.Project(t, "")
+	s, cmd := screenstest.Send(New(tail, "", nil), screens.ShownMsg{})
+	got, ok := screenstest.Find[Msg](cmd)
+	if !ok || len(got.Entries) != 1 {
+		t.Fatalf("showing the screen read %+v", got)
+	}
+	if got.Entries[0].Timestamp.IsZero() {
+		t.Error("entry didn't take Docker's timestamp")
+	}
+	s, _ = screenstest.Send(s, got)
+	if v := s.View(); !strings.Contains(v, "hello from fetch-bridge") {
+		t.Errorf("view is missing the log line:\n%s", v)
+	}
+
+	if _, cmd := screenstest.Send(s, screens.ShownMsg{}); cmd != nil {
+		t.Error("a second visit started another refresh loop")
+	}
+	s, _ = screenstest.Send(s, PausedMsg{})
+	if _, cmd := screenstest.Send(s, screens.ShownMsg{}); cmd == nil {
+		t.Error("the visit after a pause didn't restart the refresh loop")
+	}
+}
+
+func TestSplitShowsKennel(t *testing.T) {
+	s := shown(t)
+	s, cmd := screenstest.Send(s, screenstest.Key("v"))
+	got, ok := screenstest.Find[kennelMsg](cmd)
+	if !ok {
+		t.Fatal("splitting didn't read the kennel logs")
+	}
+	s, _ = screenstest.Send(s, got)
+	v := s.View()
+	for _, want := range []string{"Bridge", "Kennel", "fetch-kennel"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("split view is missing %q:\n%s", want, v)
+		}
+	}
+	s, _ = screenstest.Send(s, screenstest.Key("v"))
+	if v := s.View(); strings.Contains(v, "Kennel") {
+		t.Errorf("v didn't close the split:\n%s", v)
+	}
+}
+
+func TestSearchTakesTyping(t *testing.T) {
+	s := shown(t)
+	s, _ = screenstest.Send(s, screenstest.Key("/"))
+	if !s.CapturingText() {
+		t.Fatal("/ didn't open the search")
+	}
+	_, cmd := screenstest.Send(s, screenstest.Key("q"))
+	if _, ok := screenstest.Find[screens.ShowMsg](cmd); ok {
+		t.Error("typing q in the search left the screen")
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	s := shown(t)
+	_, cmd := screenstest.Send(s, screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
+
+func TestResizeSettles(t *testing.T) {
+	s := shown(t)
+	// shown's own size applied at once; later ones wait to settle
+	s, first := screenstest.Send(s, tea.WindowSizeMsg{Width: 100, Height: 30})
+	s, second := screenstest.Send(s, tea.WindowSizeMsg{Width: 120, Height: 40})
+	stale, _ := screenstest.Find[SettledMsg](first)
+	latest, _ := screenstest.Find[SettledMsg](second)
+	if width(s) != 80 {
+		t.Fatal("a resize applied before it settled")
+	}
+	if s, _ = screenstest.Send(s, stale); width(s) == 100 {
+		t.Error("a resize applied after the terminal had moved on")
+	}
+	s, _ = screenstest.Send(s, latest)
+	if w, h := s.Size(); w != 120 || h != 40 {
+		t.Errorf("settled at %dx%d, want 120x40", w, h)
+	}
+}
+
+func TestCountErrorsWithoutLogs(t *testing.T) {
+	s := New(func(string, int) ([]string, error) { return nil, errors.New("no docker") }, "", nil)
+	since := time.Now()
+	got, ok := screenstest.Find[ErrorsMsg](s.CountErrors(since))
+	if !ok || got.Count != 0 || !got.Since.Equal(since) {
+		t.Errorf("counted %+v", got)
+	}
+}
+
+func width(s Model) int {
+	w, _ := s.Size()
+	return w
+}
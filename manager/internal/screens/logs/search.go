@@ -0,0 +1,227 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/health"
+	"github.com/fetch/manager/internal/logs"
+	"github.com/fetch/manager/internal/logsearch"
+	"github.com/fetch/manager/internal/screens"
+)
+
+// searchMsg delivers the next batch of a history search. id ties it to
+// the search that produced it so batches from a replaced search are dropped.
+type searchMsg struct {
+	id    int
+	batch logsearch.Batch
+}
+
+// waitSearchCmd delivers the next batch from a running search.
+func waitSearchCmd(id int, batches <-chan logsearch.Batch) tea.Cmd {
+	return func() tea.Msg {
+		b, ok := <-batches
+		if !ok {
+			// Cancelled; nothing is waiting on the result
+			return nil
+		}
+		return searchMsg{id: id, batch: b}
+	}
+}
+
+// search is the state of the Logs screen's history search.
+type search struct {
+	editing bool   // The query prompt is open
+	input   string // Query being typed
+	window  int    // Index into logsearch.Windows
+
+	id        int                // Current search, for matching batches
+	query     string             // Query of the results shown
+	running   bool               // Batches are still arriving
+	cancel    context.CancelFunc // Stops the running search
+	batches   <-chan logsearch.Batch
+	matches   int
+	scanned   int
+	truncated bool
+	err       error
+}
+
+// openSearch opens the query prompt, keeping the last query.
+func (s Model) openSearch() Model {
+	s.search.editing = true
+	if s.search.input == "" {
+		s.search.input = s.search.query
+	}
+	s.viewer.SetFooter(s.searchPrompt())
+	return s
+}
+
+// updateSearchInput handles keys while the query prompt is open.
+func (s Model) updateSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	q := &s.search
+	switch msg.String() {
+	case "esc":
+		q.editing = false
+		s.viewer.SetFooter(s.searchStatus())
+		return s, nil
+	case "enter":
+		if strings.TrimSpace(q.input) == "" {
+			return s, screens.Toast("Type something to search for", components.SeverityWarning)
+		}
+		return s.startSearch()
+	case "tab":
+		q.window = (q.window + 1) % len(logsearch.Windows)
+	case "shift+tab":
+		q.window = (q.window + len(logsearch.Windows) - 1) % len(logsearch.Windows)
+	case "backspace":
+		if len(q.input) > 0 {
+			runes := []rune(q.input)
+			q.input = string(runes[:len(runes)-1])
+		}
+	case "ctrl+u":
+		q.input = ""
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			q.input += string(msg.Runes)
+		}
+	}
+	s.viewer.SetFooter(s.searchPrompt())
+	return s, nil
+}
+
+// startSearch replaces any running search with the typed query.
+func (s Model) startSearch() (tea.Model, tea.Cmd) {
+	query := strings.TrimSpace(s.search.input)
+	window := logsearch.Windows[s.search.window]
+	title := fmt.Sprintf("History: %q in the last %s", query, window.Label)
+	if window.Since == "" {
+		title = fmt.Sprintf("History: %q in all logs", query)
+	}
+	s.search.input = ""
+	return s.runSearch(query, window, title)
+}
+
+// correlate follows one ID through the bridge and kennel logs, reaching
+// back far enough to cover the entry it was picked from.
+func (s Model) correlate(msg components.CorrelateMsg) (tea.Model, tea.Cmd) {
+	if s.split {
+		// The results cover both services, so they take the whole screen
+		s, _ = s.toggleSplit()
+	}
+	window := logsearch.Windows[0]
+	if !msg.At.IsZero() {
+		age := time.Since(msg.At) + time.Hour
+		for _, w := range logsearch.Windows {
+			window = w
+			if d, err := time.ParseDuration(w.Since); err == nil && d > age {
+				break
+			}
+		}
+	}
+	return s.runSearch(msg.ID, window, "Correlate "+msg.ID+" across bridge + kennel")
+}
+
+// runSearch replaces any running search and shows its results as they
+// arrive.
+func (s Model) runSearch(query string, window logsearch.Window, title string) (tea.Model, tea.Cmd) {
+	q := &s.search
+	if q.cancel != nil {
+		q.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q.editing = false
+	q.id++
+	q.query = query
+	q.running, q.cancel = true, cancel
+	q.matches, q.scanned, q.truncated, q.err = 0, 0, false, nil
+
+	q.batches = logsearch.Search(ctx, logsearch.Query{
+		Text:       query,
+		Window:     window,
+		Containers: health.Containers,
+	})
+	s.viewer.StartHistory(title)
+	s.viewer.SetFooter(s.searchStatus())
+	return s, waitSearchCmd(q.id, q.batches)
+}
+
+// updateSearch adds a batch of results to the viewer.
+func (s Model) updateSearch(msg searchMsg) (tea.Model, tea.Cmd) {
+	q := &s.search
+	if msg.id != q.id || !s.viewer.InHistory() {
+		return s, nil
+	}
+	redactor, _ := config.Redactor()
+	entries := make([]components.LogEntry, 0, len(msg.batch.Matches))
+	for _, match := range msg.batch.Matches {
+		entry := logs.ParseLogLine(redactor.String(match.Line), strings.TrimPrefix(match.Container, "fetch-"))
+		entry.Timestamp = match.Time
+		entries = append(entries, entry)
+	}
+	s.viewer.AppendHistory(entries)
+	q.matches += len(entries)
+	q.scanned = msg.batch.Scanned
+	q.truncated = q.truncated || msg.batch.Truncated
+	if msg.batch.Err != nil {
+		q.err = msg.batch.Err
+	}
+
+	var cmd tea.Cmd
+	if msg.batch.Done {
+		q.cancel()
+		q.running, q.cancel, q.batches = false, nil, nil
+		if q.err != nil {
+			cmd = screens.Toast(q.err.Error(), components.SeverityWarning)
+		}
+	} else {
+		cmd = waitSearchCmd(q.id, q.batches)
+	}
+	if !q.editing {
+		s.viewer.SetFooter(s.searchStatus())
+	}
+	return s, cmd
+}
+
+// closeSearch stops any search and goes back to the live logs.
+func (s Model) closeSearch() (tea.Model, tea.Cmd) {
+	q := &s.search
+	if q.cancel != nil {
+		q.cancel()
+	}
+	q.running, q.cancel, q.batches = false, nil, nil
+	q.id++
+	s.viewer.EndHistory()
+	s.viewer.SetFooter("")
+	return s, s.fetch
+}
+
+// searchPrompt is the footer while a query is typed.
+func (s Model) searchPrompt() string {
+	q := s.search
+	return fmt.Sprintf("Search history: %s▌   range: %s   │ Enter: Search │ Tab: Range │ Esc: Cancel",
+		q.input, logsearch.Windows[q.window].Label)
+}
+
+// searchStatus is the footer while results are shown.
+func (s Model) searchStatus() string {
+	q := s.search
+	if !s.viewer.InHistory() {
+		return ""
+	}
+	var status string
+	switch {
+	case q.running:
+		status = fmt.Sprintf("Searching… %d matches in %d lines", q.matches, q.scanned)
+	case q.truncated:
+		status = fmt.Sprintf("Stopped at %d matches; narrow the search", q.matches)
+	default:
+		status = fmt.Sprintf("%d matches in %d lines", q.matches, q.scanned)
+	}
+	return status + " │ /: New search │ Esc: Live logs"
+}
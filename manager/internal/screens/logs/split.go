@@ -0,0 +1,113 @@
+package logs
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+)
+
+// The split view shows the bridge on the left and the kennel on the right,
+// for following a message from one service into the other.
+
+// kennelMsg carries parsed log lines from the kennel container.
+type kennelMsg struct {
+	entries []components.LogEntry
+}
+
+func (s Model) fetchKennel() tea.Msg {
+	_, entries := recentLogs(s.tail, "fetch-kennel", "kennel")
+	return kennelMsg{entries: entries}
+}
+
+// toggleSplit switches between the bridge logs alone and the split view.
+// Search results show both services already, so they stay single.
+func (s Model) toggleSplit() (Model, tea.Cmd) {
+	if s.split {
+		s.split = false
+		s.viewer.SetTitle("📜 Fetch Logs")
+		s.viewer.SetFocus(false)
+		s.viewer.SetFooter("")
+		return s, nil
+	}
+	if s.viewer.InHistory() {
+		return s, screens.Toast("Close the search (Esc) to split the view", components.SeverityInfo)
+	}
+	if s.kennel == nil {
+		s.kennel = components.NewLogViewer(80, 24)
+		s.kennel.SetTitle("🐕 Kennel")
+		s.kennel.SetHiddenLevels(s.viewer.HiddenLevels())
+	}
+	s.split, s.kennelFocus = true, false
+	s.viewer.SetTitle("🌉 Bridge")
+	s.focusPane()
+	return s, s.fetchKennel
+}
+
+// focusPane highlights the pane that takes keys and shows the split view's
+// keys under it.
+func (s Model) focusPane() {
+	focused, other := s.viewer, s.kennel
+	if s.kennelFocus {
+		focused, other = other, focused
+	}
+	focused.SetFocus(true)
+	focused.SetFooter("Tab: Other pane │ t: Match time │ v: Single view │ Esc: Back")
+	other.SetFocus(false)
+	other.SetFooter("Tab: Focus this pane")
+}
+
+// focused is the pane keys go to.
+func (s Model) focused() *components.LogViewer {
+	if s.split && s.kennelFocus {
+		return s.kennel
+	}
+	return s.viewer
+}
+
+// updateSplit handles the split view's own keys. Everything else goes to
+// the focused pane, so each scrolls on its own.
+func (s Model) updateSplit(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "tab", "shift+tab":
+		s.kennelFocus = !s.kennelFocus
+		s.focusPane()
+		return s, nil, true
+	case "t":
+		// Bring the other pane to the moment at the top of this one
+		focused, other := s.viewer, s.kennel
+		if s.kennelFocus {
+			focused, other = other, focused
+		}
+		at, ok := focused.TopTime()
+		if !ok {
+			return s, screens.Toast("No logs in this pane to match", components.SeverityInfo), true
+		}
+		other.ScrollToTime(at)
+		return s, nil, true
+	case "/":
+		// Search covers both services in one list
+		s, _ = s.toggleSplit()
+		return s.openSearch(), nil, true
+	}
+	return s, nil, false
+}
+
+// viewerAt is the pane under column x.
+func (s Model) viewerAt(x int) *components.LogViewer {
+	width, _ := s.Size()
+	if left, _ := layout.SplitHorizontal(width, 0.5); s.split && x > left {
+		return s.kennel
+	}
+	return s.viewer
+}
+
+// viewSplit renders the two panes side by side.
+func (s Model) viewSplit(width, height int) string {
+	left, right := layout.SplitHorizontal(width, 0.5)
+	s.viewer.SetSize(left, height)
+	s.kennel.SetSize(right, height)
+	return lipgloss.JoinHorizontal(lipgloss.Top, s.viewer.View(), " ", s.kennel.View())
+}
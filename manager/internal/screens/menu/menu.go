@@ -0,0 +1,399 @@
+// Package menu is the main menu: every screen and service action, with the
+// health of Fetch beside the items it concerns.
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// choices are the menu items in order, each with the palette action it
+// runs, so the menu and the palette stay in sync.
+var choices = []struct {
+	label  string
+	action string
+}{
+	{"📱 Setup WhatsApp", "setup"},
+	{"🔑 Git Providers", "git-providers"},
+	{"🚀 Start Fetch", "start"},
+	{"🛑 Stop Fetch", "stop"},
+	{"🩺 System Status", "status"},
+	{"📊 Statistics", "stats"},
+	{"📋 Tasks", "tasks"},
+	{"⚙️  Configure", "configure"},
+	{"🔐 Trusted Numbers", "whitelist"},
+	{"📜 View Logs", "logs"},
+	{"📚 Documentation", "docs"},
+	{"⬆️  Update Fetch", "update"},
+	{"ℹ️  Version", "version"},
+	{"❌ Exit", "quit"},
+}
+
+// menuPage is how many items PgUp and PgDn move through the menu.
+const menuPage = 5
+
+// menuLetters opens frequent menu items by letter: Start Fetch, Stop
+// Fetch and View Logs. 1-9 and 0 open the first ten items by number.
+var menuLetters = map[string]int{"s": 2, "x": 3, "l": 9}
+
+// menuNumber returns the item a digit key opens, or -1: 1-9 are the
+// first nine items and 0 the tenth.
+func menuNumber(key string) int {
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' {
+		return -1
+	}
+	if key == "0" {
+		return 9
+	}
+	return int(key[0] - '1')
+}
+
+// menuKey is the digit shown beside item i, or "" past the tenth.
+func menuKey(i int) string {
+	switch {
+	case i < 9:
+		return string(rune('1' + i))
+	case i == 9:
+		return "0"
+	}
+	return ""
+}
+
+// Health is what the menu shows about Fetch beside its items. The root
+// model sends it whenever it changes.
+type Health struct {
+	Loaded         bool // A container check has come back
+	BridgeRunning  bool
+	KennelRunning  bool
+	DockerDown     bool // The Docker daemon didn't answer the last check
+	Bridge         *status.BridgeStatus
+	LogErrors      int    // Bridge errors logged since the logs were last open
+	UpdatesPending bool   // Commits to pull
+	NewRelease     bool   // A newer manager release
+	GitHubProblem  string // Why GitHub auth is unusable, or ""
+}
+
+// Model is the main menu.
+type Model struct {
+	screens.Room
+	cursor int
+	health Health
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case Health:
+		s.health = msg
+	case screens.ClickMsg:
+		// Clicking the item that is already selected opens it, like Enter
+		for i, choice := range choices {
+			if !strings.Contains(msg.Line, plain(choice.label)) {
+				continue
+			}
+			if i == s.cursor {
+				return s.activate()
+			}
+			s.cursor = i
+			return s, nil
+		}
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return s, screens.Run("quit")
+
+	case "up", "k":
+		menu := s.menu()
+		menu.Up()
+		s.cursor = menu.Cursor
+
+	case "down", "j":
+		menu := s.menu()
+		menu.Down()
+		s.cursor = menu.Cursor
+
+	case "pgup":
+		s.cursor = s.moveTo(s.cursor - menuPage)
+
+	case "pgdown":
+		s.cursor = s.moveTo(s.cursor + menuPage)
+
+	case "home":
+		s.cursor = s.moveTo(0)
+
+	case "end":
+		s.cursor = s.moveTo(len(choices) - 1)
+
+	case "n":
+		return s, screens.Run("notifications")
+
+	case "enter", " ":
+		return s.activate()
+
+	default:
+		i, ok := menuLetters[msg.String()]
+		if !ok {
+			i = menuNumber(msg.String())
+		}
+		if i >= 0 && i < len(choices) {
+			s.cursor = i
+			return s.activate()
+		}
+	}
+	return s, nil
+}
+
+// menu returns the menu items with the ones whose prerequisites are
+// missing disabled, each with a hint saying why. Until the first status
+// check nothing is disabled.
+func (s Model) menu() *components.Menu {
+	h := s.health
+	items := make([]components.MenuItem, len(choices))
+	for i, choice := range choices {
+		items[i] = components.MenuItem{Label: choice.label}
+	}
+	if h.DockerDown {
+		items[2].Disabled, items[2].Hint = true, "Docker isn't reachable: start Docker first"
+	}
+	if h.Loaded && !h.BridgeRunning {
+		items[0].Disabled, items[0].Hint = true, "The bridge isn't running: start Fetch to link WhatsApp"
+	}
+	if h.Loaded && !h.BridgeRunning && !h.KennelRunning {
+		items[9].Disabled, items[9].Hint = true, "No containers running: start Fetch to see logs"
+	}
+	return &components.Menu{Items: items, Cursor: s.cursor}
+}
+
+// moveTo returns the cursor for moving to item i, passing over disabled
+// items.
+func (s Model) moveTo(i int) int {
+	menu := s.menu()
+	menu.MoveTo(i)
+	return menu.Cursor
+}
+
+// activate opens the item under the cursor. A disabled item only explains
+// why it can't be opened.
+func (s Model) activate() (tea.Model, tea.Cmd) {
+	if item := s.menu().SelectedItem(); item.Disabled {
+		return s, screens.Toast(item.Hint, components.SeverityInfo)
+	}
+	return s, screens.Run(choices[s.cursor].action)
+}
+
+// HelpKeys goes on the status bar's line, which the menu shares.
+func (s Model) HelpKeys() []string {
+	return append(screens.KeyHelp(screens.Menu, "↑/↓", "Enter", "1-0", "n", "q"), screens.GlobalHelp("ctrl+p")...)
+}
+
+// InlineHelp puts the menu's keys beside the status rather than below it.
+func (s Model) InlineHelp() bool { return true }
+
+// UsesNumberKeys is true: 1-9 and 0 open the first ten items.
+func (s Model) UsesNumberKeys() bool { return true }
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	menuPanel := strings.TrimSuffix(s.renderPanel(), "\n")
+	compact := lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render("  FETCH")
+	if theme.Plain() {
+		// Screen readers get the menu without the decorative art
+		compact = "  Fetch Manager"
+	}
+
+	// Drop the banner, then the title, until the menu fits above the
+	// status bar
+	var content string
+	if !theme.Plain() && !layout.IsCompact(width) {
+		content = s.art(width, height, menuPanel)
+	}
+	if content == "" || lipgloss.Height(content) > height {
+		content = lipgloss.JoinVertical(lipgloss.Left, compact, "", menuPanel)
+	}
+	if lipgloss.Height(content) > height {
+		content = menuPanel
+	}
+	return content
+}
+
+// art lays out the dog and FETCH banner around the menu panel.
+func (s Model) art(width, height int, menuPanel string) string {
+	// Right side: FETCH title + menu
+	fetchTitle := lipgloss.NewStyle().
+		Foreground(theme.Active().Primary).
+		Bold(true).
+		Render(`███████╗███████╗████████╗ ██████╗██╗  ██╗
+██╔════╝██╔════╝╚══██╔══╝██╔════╝██║  ██║
+█████╗  █████╗     ██║   ██║     ███████║
+██╔══╝  ██╔══╝     ██║   ██║     ██╔══██║
+██║     ███████╗   ██║   ╚██████╗██║  ██║
+╚═╝     ╚══════╝   ╚═╝    ╚═════╝╚═╝  ╚═╝`)
+
+	tagline := lipgloss.NewStyle().
+		Foreground(theme.Active().TextSecondary).
+		Italic(true).
+		Render("Your Faithful Code Companion")
+
+	rightContent := lipgloss.JoinVertical(lipgloss.Left,
+		fetchTitle,
+		tagline,
+		"",
+		menuPanel,
+	)
+
+	// Below the wide breakpoint the dog no longer fits beside the banner
+	if !layout.IsWide(width) {
+		return rightContent
+	}
+
+	// Get ASCII dog art (left side)
+	dogArt := components.Header(width, height, s.statusString())
+
+	// Join horizontally: dog on left, menu on right
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		dogArt,
+		"    ", // gap between dog and menu
+		rightContent,
+	)
+}
+
+func (s Model) statusString() string {
+	if s.health.BridgeRunning && s.health.KennelRunning {
+		return "running"
+	} else if s.health.BridgeRunning || s.health.KennelRunning {
+		return "partial"
+	}
+	return "stopped"
+}
+
+// badge is the live status shown after item i, from what the status
+// poller last saw, so the menu doubles as a health overview.
+func (s Model) badge(i int) string {
+	h := s.health
+	switch i {
+	case 0: // Setup WhatsApp
+		if !h.BridgeRunning || h.Bridge == nil {
+			return ""
+		}
+		switch h.Bridge.State {
+		case "authenticated":
+			return theme.StatusSuccess().Render(theme.Cue(" ✅ ", " (") + "connected" + theme.Cue("", ")"))
+		case "qr_pending":
+			return theme.StatusWarning().Render(theme.Cue(" ⏳ ", " (") + "waiting for scan" + theme.Cue("", ")"))
+		case "disconnected", "error":
+			return theme.StatusError().Render(theme.Cue(" ✗ ", " (") + h.Bridge.State + theme.Cue("", ")"))
+		}
+	case 2: // Start Fetch
+		if h.BridgeRunning && h.KennelRunning {
+			return theme.StatusSuccess().Render(theme.Cue(" ● ", " (") + "running" + theme.Cue("", ")"))
+		}
+	case 9: // View Logs
+		switch {
+		case h.LogErrors == 1:
+			return theme.StatusError().Render(" (1 error)")
+		case h.LogErrors > 1:
+			return theme.StatusError().Render(fmt.Sprintf(" (%d errors)", h.LogErrors))
+		}
+	}
+	return ""
+}
+
+func (s Model) renderPanel() string {
+	var b strings.Builder
+	width, _ := s.Size()
+
+	// Menu title with visible styling (aligned with status bar padding)
+	menuTitle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Active().Secondary).
+		Background(theme.Active().Surface).
+		Padding(0, 1).
+		Render("✨ Main Menu ✨")
+
+	b.WriteString("  " + menuTitle + "\n")
+
+	// Menu items (aligned with status bar's 2-space padding)
+	update := lipgloss.NewStyle().Foreground(theme.Active().Warning).Render(theme.Cue(" ●", " (update available)"))
+	for i, entry := range s.menu().Items {
+		choice := entry.Label
+		if entry.Disabled {
+			// Greyed out, with the reason underneath
+			prefix := "   "
+			if s.cursor == i {
+				prefix = " " + theme.Muted().Render("▸ ")
+			}
+			b.WriteString(prefix + theme.Muted().Render(fmt.Sprintf("%-2s", menuKey(i))+choice+theme.Cue("", " (unavailable)")) + "\n")
+			b.WriteString("       " + theme.Muted().Render(layout.Truncate(entry.Hint, width-7)) + "\n")
+			continue
+		}
+		var suffix string
+		switch {
+		case i == 11 && s.health.UpdatesPending: // Update Fetch
+			suffix = update
+		case i == 12 && s.health.NewRelease: // Version
+			suffix = update
+		case i == 1 && s.health.GitHubProblem != "": // Git Providers
+			suffix = theme.StatusError().Render(theme.Cue(" ✗ ", " (") + "GitHub " + s.health.GitHubProblem + theme.Cue("", ")"))
+		default:
+			suffix = s.badge(i)
+		}
+		for letter, item := range menuLetters {
+			if item == i {
+				suffix = theme.Muted().Render(" ["+letter+"]") + suffix
+			}
+		}
+		number := theme.Muted().Render(fmt.Sprintf("%-2s", menuKey(i)))
+		if s.cursor == i {
+			// Selected item
+			cursor := lipgloss.NewStyle().
+				Foreground(theme.Active().Primary).
+				Bold(true).
+				Render("▸ ")
+			item := lipgloss.NewStyle().
+				Foreground(theme.Active().Primary).
+				Bold(true).
+				Render(choice)
+			b.WriteString(" " + cursor + number + item + suffix + "\n")
+		} else {
+			// Normal item
+			item := lipgloss.NewStyle().
+				Foreground(theme.Active().TextPrimary).
+				Render(choice)
+			b.WriteString("   " + number + item + suffix + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// plain is a label as drawn in accessibility mode, for matching a clicked
+// line.
+func plain(s string) string {
+	if theme.Plain() {
+		return components.PlainText(s)
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+)
+
+func TestKeysRunActions(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []string
+		action string
+	}{
+		{"enter opens the first item", []string{"enter"}, "setup"},
+		{"down then enter", []string{"down", "down", "enter"}, "start"},
+		{"number", []string{"5"}, "status"},
+		{"zero is the tenth item", []string{"0"}, "logs"},
+		{"letter", []string{"x"}, "stop"},
+		{"end", []string{"end", "enter"}, "quit"},
+		{"notifications", []string{"n"}, "notifications"},
+		{"quit", []string{"q"}, "quit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, cmd := screenstest.Send(New(), screenstest.Keys(tt.keys...)...)
+			run, ok := screenstest.Find[screens.RunMsg](cmd)
+			if !ok || run.Action != tt.action {
+				t.Errorf("ran %q, want %q", run.Action, tt.action)
+			}
+		})
+	}
+}
+
+func TestDisabledItemExplains(t *testing.T) {
+	s, _ := screenstest.Send(New(), Health{Loaded: true})
+	_, cmd := screenstest.Send(s, screenstest.Key("1"))
+	if _, ok := screenstest.Find[screens.RunMsg](cmd); ok {
+		t.Fatal("opened setup without a running bridge")
+	}
+	toast, ok := screenstest.Find[screens.ToastMsg](cmd)
+	if !ok || !strings.Contains(toast.Message, "start Fetch") {
+		t.Errorf("got toast %q, want the hint to start Fetch", toast.Message)
+	}
+}
+
+func TestClickSelectsThenOpens(t *testing.T) {
+	click := screens.ClickMsg{Line: "  6 📊 Statistics"}
+	s, cmd := screenstest.Send(New(), click)
+	if cmd != nil || s.cursor != 5 {
+		t.Fatalf("first click: cursor %d, want 5 and nothing run", s.cursor)
+	}
+	_, cmd = screenstest.Send(s, click)
+	if run, ok := screenstest.Find[screens.RunMsg](cmd); !ok || run.Action != "stats" {
+		t.Errorf("second click ran %q, want stats", run.Action)
+	}
+}
+
+func TestViewShowsHealth(t *testing.T) {
+	qr := &status.BridgeStatus{State: "qr_pending"}
+	s, _ := screenstest.Send(New(), screenstest.Room, Health{
+		Loaded:        true,
+		BridgeRunning: true,
+		KennelRunning: true,
+		Bridge:        qr,
+		LogErrors:     3,
+		GitHubProblem: "token expired",
+	})
+	v := s.View()
+	for _, want := range []string{"Setup WhatsApp", "View Logs", "Exit", "token expired"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("menu is missing %q:\n%s", want, v)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Package models is the model picker: the OpenRouter models to choose the
+// agent's from. The config screen opens it for the Agent Model field.
+package models
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/models"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// Model is the model picker. The selector exists only while the picker is
+// open; the all/recommended toggle outlives it.
+type Model struct {
+	screens.Room
+	selector *models.Selector
+	showAll  bool
+}
+
+// New returns a closed picker listing every model when showAll is set.
+func New(showAll bool) Model {
+	return Model{showAll: showAll}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+// Open starts a fresh picker and its model list request.
+func (s Model) Open() (Model, tea.Cmd) {
+	s = s.Close()
+	s.selector = models.NewSelector()
+	s.selector.SetShowAll(s.showAll)
+	return s, s.selector.Init()
+}
+
+// Close dismisses the picker, stopping a model list request still running.
+func (s Model) Close() Model {
+	if s.selector != nil {
+		s.showAll = s.selector.ShowAll()
+		s.selector.Close()
+	}
+	s.selector = nil
+	return s
+}
+
+// IsOpen reports whether the picker is showing.
+func (s Model) IsOpen() bool {
+	return s.selector != nil
+}
+
+// ShowAll reports whether every model is listed rather than just the
+// recommended ones.
+func (s Model) ShowAll() bool {
+	if s.selector != nil {
+		return s.selector.ShowAll()
+	}
+	return s.showAll
+}
+
+// Selected returns the model under the cursor, or "" when closed.
+func (s Model) Selected() string {
+	if s.selector == nil {
+		return ""
+	}
+	return s.selector.SelectedModel()
+}
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		return s, nil
+	case screens.ShownMsg:
+		return s.Open()
+	case screens.HiddenMsg:
+		return s.Close(), nil
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			return s.Close(), screens.Show(screens.Menu)
+		}
+	case models.ModelsLoadedMsg, models.CreditsLoadedMsg, models.ModelSavedMsg, tea.MouseMsg:
+	default:
+		return s, nil
+	}
+	if s.selector == nil {
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.selector, cmd = s.selector.Update(msg)
+	return s, cmd
+}
+
+func (s Model) HelpKeys() []string {
+	return screens.KeyHelp(screens.Models, "↑/↓", "Enter", "Tab", "Esc")
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+	title := layout.SectionHeader("🤖 Select Model", width-4)
+	if s.selector == nil {
+		return title + "\n\n" + theme.StatusInfo().Render("   Loading models...") + "\n"
+	}
+	// Rows wider than the terminal are cut rather than wrapped, so the
+	// selector's scrolling still matches what is drawn
+	s.selector.SetSize(height - 6)
+	return title + "\n\n" + lipgloss.NewStyle().MaxWidth(width).Render(s.selector.View())
+}
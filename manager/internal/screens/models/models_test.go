@@ -0,0 +1,65 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/models"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+)
+
+var listed = models.ModelsLoadedMsg{Models: []models.Model{
+	{ID: "openai/gpt-4o-mini", Name: "GPT-4o mini", SupportedParameters: []string{"tools"}},
+	{ID: "anthropic/claude-3.5-sonnet", Name: "Claude 3.5 Sonnet", SupportedParameters: []string{"tools"}},
+}}
+
+func TestShownOpensPicker(t *testing.T) {
+	screenstest.Project(t, "AGENT_MODEL=openai/gpt-4o-mini\n")
+	s, _ := screenstest.Send(New(true), screenstest.Room, screens.ShownMsg{}, listed)
+	if !s.IsOpen() {
+		t.Fatal("picker is closed after being shown")
+	}
+	if s.Selected() != "openai/gpt-4o-mini" {
+		t.Errorf("selected %q, want the model in .env", s.Selected())
+	}
+	v := s.View()
+	for _, want := range []string{"Select Model", "Current: openai/gpt-4o-mini", "anthropic/claude-3.5-sonnet"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("picker is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestEscClosesToMenu(t *testing.T) {
+	screenstest.Project(t, "")
+	s, _ := screenstest.Send(New(false), screenstest.Room, screens.ShownMsg{}, listed)
+	s, cmd := screenstest.Send(s, screenstest.Key("esc"))
+	if s.IsOpen() {
+		t.Error("picker is still open after Esc")
+	}
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+	if !strings.Contains(s.View(), "Loading models...") {
+		t.Errorf("closed picker view:\n%s", s.View())
+	}
+}
+
+func TestTabKeepsShowAllAcrossVisits(t *testing.T) {
+	screenstest.Project(t, "")
+	s, _ := screenstest.Send(New(false), screens.ShownMsg{}, listed, screenstest.Key("tab"), screens.HiddenMsg{})
+	if !s.ShowAll() {
+		t.Error("Tab's all-models toggle was lost when the picker closed")
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	screenstest.Project(t, "")
+	s, _ := screenstest.Send(New(false), screenstest.Room, screens.ShownMsg{},
+		models.ModelsLoadedMsg{Err: errors.New("openrouter is down")})
+	if v := s.View(); !strings.Contains(v, "openrouter is down") {
+		t.Errorf("view is missing the error:\n%s", v)
+	}
+}
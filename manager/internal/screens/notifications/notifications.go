@@ -0,0 +1,146 @@
+// Package notifications is the notification history: this session's
+// toasts, the webhook targets state changes are sent to, and the last
+// delivery.
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/notify"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// SentMsg reports a finished webhook delivery.
+type SentMsg struct {
+	Event notify.Event
+	Err   error
+	At    time.Time
+}
+
+// Send delivers e to every configured target.
+func Send(n *notify.Notifier, e notify.Event) tea.Cmd {
+	if !n.Enabled() {
+		return nil
+	}
+	return func() tea.Msg {
+		return SentMsg{Event: e, Err: n.Send(e), At: time.Now()}
+	}
+}
+
+// Model is the notifications screen. The toasts are the root model's; the
+// screen lists their history.
+type Model struct {
+	screens.Room
+	toasts       *components.Toasts
+	notifier     *notify.Notifier
+	lastDelivery *SentMsg
+}
+
+func New(toasts *components.Toasts, notifier *notify.Notifier) Model {
+	return Model{toasts: toasts, notifier: notifier}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case SentMsg:
+		return s.sent(msg)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return s, screens.Show(screens.Menu)
+		case "c":
+			s.toasts.ClearHistory()
+		case "t":
+			return s, s.sendTest()
+		}
+	}
+	return s, nil
+}
+
+// sendTest is the t key.
+func (s Model) sendTest() tea.Cmd {
+	if !s.notifier.Enabled() {
+		return screens.Toast("No notification targets: set a FETCH_NOTIFY_* key in Configure", components.SeverityWarning)
+	}
+	return Send(s.notifier, notify.Event{
+		Kind:    notify.KindTest,
+		Title:   "Fetch: test notification",
+		Message: "Notifications from the Fetch manager reach you here.",
+	})
+}
+
+// sent records a delivery. Failures always toast; tests toast either way
+// since someone is waiting on the result.
+func (s Model) sent(msg SentMsg) (tea.Model, tea.Cmd) {
+	s.lastDelivery = &msg
+	switch {
+	case msg.Err != nil:
+		return s, screens.Toast(fmt.Sprintf("Couldn't send notification: %v", msg.Err), components.SeverityWarning)
+	case msg.Event.Kind == notify.KindTest:
+		return s, screens.Toast("Test notification sent to "+strings.Join(s.notifier.Targets(), ", "), components.SeveritySuccess)
+	}
+	return s, nil
+}
+
+func (s Model) HelpKeys() []string {
+	return screens.KeyHelp(screens.Notifications, "t", "c", "Esc")
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	title := layout.SectionHeader("🔔 Notifications", width-4)
+
+	var content strings.Builder
+	content.WriteString(s.renderTargets(width) + "\n")
+	history := s.toasts.History()
+	if len(history) == 0 {
+		content.WriteString(theme.Muted().Render("   No notifications this session.") + "\n")
+	}
+
+	// Newest first, as many as fit
+	shown := 0
+	for i := len(history) - 1; i >= 0 && shown < max(3, height-9); i-- {
+		t := history[i]
+		icon := lipgloss.NewStyle().Foreground(t.Severity.Color()).Render(t.Severity.Icon())
+		content.WriteString(fmt.Sprintf("   %s %s %s\n",
+			theme.Muted().Render(t.At.Format("15:04:05")), icon, theme.Value().Render(layout.Truncate(t.Message, width-18))))
+		shown++
+	}
+	if more := len(history) - shown; more > 0 {
+		content.WriteString(theme.Muted().Render(fmt.Sprintf("   … and %d older", more)) + "\n")
+	}
+
+	return title + "\n\n" + content.String()
+}
+
+// renderTargets renders the webhook targets and the last delivery.
+func (s Model) renderTargets(width int) string {
+	var b strings.Builder
+	if !s.notifier.Enabled() {
+		b.WriteString("   " + theme.Label().Render("Sent to") + theme.Muted().Render(layout.Truncate("nowhere (set FETCH_NOTIFY_* in Configure)", width-23)) + "\n")
+		return b.String()
+	}
+	b.WriteString("   " + theme.Label().Render("Sent to") + theme.Value().Render(layout.Truncate(strings.Join(s.notifier.Targets(), ", "), width-23)) + "\n")
+	if d := s.lastDelivery; d != nil {
+		result := theme.StatusSuccess().Render(theme.Cue("✓", "OK"))
+		if d.Err != nil {
+			result = theme.StatusError().Render(theme.Cue("✗", "FAILED") + " " + layout.Truncate(d.Err.Error(), width-50))
+		}
+		b.WriteString("   " + theme.Label().Render("Last sent") +
+			theme.Value().Render(d.At.Format("15:04:05")+" "+d.Event.Title) + " " + result + "\n")
+	}
+	return b.String()
+}
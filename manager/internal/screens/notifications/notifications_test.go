@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/notify"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+)
+
+func TestViewListsHistoryNewestFirst(t *testing.T) {
+	toasts := components.NewToasts()
+	toasts.Push("Fetch started", components.SeveritySuccess)
+	toasts.Push("Bridge disconnected", components.SeverityWarning)
+	s, _ := screenstest.Send(New(toasts, notify.New(notify.Config{})), screenstest.Room)
+	v := s.View()
+	if !strings.Contains(v, "nowhere") {
+		t.Errorf("view doesn't say there are no targets:\n%s", v)
+	}
+	first, second := strings.Index(v, "Bridge disconnected"), strings.Index(v, "Fetch started")
+	if first < 0 || second < 0 || first > second {
+		t.Errorf("history isn't listed newest first:\n%s", v)
+	}
+
+	s, _ = screenstest.Send(s, screenstest.Key("c"))
+	if v := s.View(); !strings.Contains(v, "No notifications this session") {
+		t.Errorf("c didn't clear the history:\n%s", v)
+	}
+}
+
+func TestTestWithoutTargetsWarns(t *testing.T) {
+	s := New(components.NewToasts(), notify.New(notify.Config{}))
+	_, cmd := screenstest.Send(s, screenstest.Key("t"))
+	toast, ok := screenstest.Find[screens.ToastMsg](cmd)
+	if !ok || !strings.Contains(toast.Message, "FETCH_NOTIFY_") {
+		t.Errorf("got toast %q, want the hint to set a target", toast.Message)
+	}
+}
+
+func TestTestSendsAndRecords(t *testing.T) {
+	var got int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { got++ }))
+	defer srv.Close()
+	s := New(components.NewToasts(), notify.New(notify.Config{Webhook: srv.URL}))
+
+	_, cmd := screenstest.Send(s, screenstest.Key("t"))
+	sent, ok := screenstest.Find[SentMsg](cmd)
+	if !ok || sent.Err != nil || got != 1 {
+		t.Fatalf("t sent %+v, webhook called %d times", sent, got)
+	}
+	s, cmd = screenstest.Send(s, screenstest.Room, sent)
+	if toast, ok := screenstest.Find[screens.ToastMsg](cmd); !ok || toast.Severity != components.SeveritySuccess {
+		t.Errorf("got toast %+v after a test send", toast)
+	}
+	if v := s.View(); !strings.Contains(v, "Fetch: test notification") {
+		t.Errorf("view is missing the last delivery:\n%s", v)
+	}
+}
+
+func TestFailedDeliveryToasts(t *testing.T) {
+	s := New(components.NewToasts(), notify.New(notify.Config{Webhook: "http://example.invalid"}))
+	failed := SentMsg{Event: notify.Event{Title: "Bridge down"}, Err: errors.New("timeout"), At: time.Now()}
+	s, cmd := screenstest.Send(s, screenstest.Room, failed)
+	if toast, ok := screenstest.Find[screens.ToastMsg](cmd); !ok || !strings.Contains(toast.Message, "timeout") {
+		t.Errorf("got toast %q for a failed delivery", toast.Message)
+	}
+	if v := s.View(); !strings.Contains(v, "Bridge down") || !strings.Contains(v, "timeout") {
+		t.Errorf("view doesn't show the failure:\n%s", v)
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	_, cmd := screenstest.Send(New(components.NewToasts(), notify.New(notify.Config{})), screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
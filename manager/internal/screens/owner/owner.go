@@ -0,0 +1,87 @@
+// Package owner is the guided owner number change: a code sent to the new
+// number, checked, then written to .env and the whitelist.
+package owner
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+)
+
+// Model is the owner change screen. Each visit starts a fresh change.
+type Model struct {
+	screens.Room
+	client *status.Client
+	change *config.OwnerChange
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+// CapturingText is true while a number or code is being typed.
+func (s Model) CapturingText() bool {
+	return s.change != nil && s.change.IsEditing()
+}
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case screens.ShownMsg:
+		s.change = config.NewOwnerChange(s.client)
+	case screens.HiddenMsg:
+		s.change = nil
+	case config.OwnerCodeSentMsg, config.OwnerChangedMsg:
+		if s.change != nil {
+			return s, s.change.Update(msg)
+		}
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if s.change == nil {
+		return s, screens.Show(screens.Config)
+	}
+	cmd := s.change.Update(msg)
+	if !s.change.Finished() {
+		return s, cmd
+	}
+
+	// Back to the editor, showing the number now in .env
+	number := s.change.Number()
+	s.change = nil
+	if number == "" {
+		return s, tea.Batch(cmd, screens.Show(screens.Config))
+	}
+	setting := func() tea.Msg {
+		return screens.SettingMsg{Key: "OWNER_PHONE_NUMBER", Value: number}
+	}
+	return s, tea.Batch(cmd, setting, screens.Show(screens.Config), screens.Toast("Owner number changed", components.SeveritySuccess))
+}
+
+func (s Model) HelpKeys() []string {
+	if s.change != nil && !s.change.IsEditing() {
+		return screens.KeyHelp(screens.Owner, "v", "a", "Esc")
+	}
+	return screens.KeyHelp(screens.Owner, "Enter", "Esc")
+}
+
+func (s Model) View() string {
+	width, _ := s.Size()
+	title := layout.SectionHeader("📱 Change Owner", width-4)
+	if s.change == nil {
+		return title + "\n\n"
+	}
+	s.change.SetWidth(width)
+	return title + "\n\n" + s.change.View()
+}
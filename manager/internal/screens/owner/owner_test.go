@@ -0,0 +1,63 @@
+package owner
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+)
+
+func shown(t *testing.T) Model {
+	t.Helper()
+	screenstest.Project(t, "OWNER_PHONE_NUMBER=15551234567\n")
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{})
+	return s
+}
+
+func TestEscReturnsToConfig(t *testing.T) {
+	s, cmd := screenstest.Send(shown(t), screenstest.Key("esc"))
+	msgs := screenstest.Msgs(cmd)
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Config {
+		t.Errorf("Esc showed %v, want the config screen", show.Screen)
+	}
+	for _, msg := range msgs {
+		if _, ok := msg.(screens.SettingMsg); ok {
+			t.Error("cancelling changed the owner setting")
+		}
+	}
+	if s.CapturingText() {
+		t.Error("screen still takes typing after the change ended")
+	}
+}
+
+func TestAppliedChangeUpdatesConfig(t *testing.T) {
+	s := shown(t)
+	if !s.CapturingText() {
+		t.Fatal("the number field isn't taking typing")
+	}
+	for range "15551234567" {
+		s, _ = screenstest.Send(s, screenstest.Key("backspace"))
+	}
+	s, _ = screenstest.Send(s, screenstest.Keys("+", "4", "4", "2", "0", "7", "9", "4", "6", "0", "9", "5", "8", "enter", "a")...)
+	s, _ = screenstest.Send(s, config.OwnerChangedMsg{})
+	if v := s.View(); !strings.Contains(v, "Owner saved to .env") {
+		t.Errorf("view after applying:\n%s", v)
+	}
+	_, cmd := screenstest.Send(s, screenstest.Key("enter"))
+	setting, ok := screenstest.Find[screens.SettingMsg](cmd)
+	if !ok || setting.Key != "OWNER_PHONE_NUMBER" || setting.Value != "442079460958" {
+		t.Errorf("got setting %+v, want the new owner", setting)
+	}
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Config {
+		t.Errorf("finishing showed %v, want the config screen", show.Screen)
+	}
+}
+
+func TestViewBeforeShown(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screenstest.Room)
+	if v := s.View(); !strings.Contains(v, "Change Owner") {
+		t.Errorf("view:\n%s", v)
+	}
+}
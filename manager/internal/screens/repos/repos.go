@@ -0,0 +1,111 @@
+// Package repos is the repository allow-list screen for the coding
+// agents.
+package repos
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+)
+
+// reposDiscardMsg leaves the list once discarding the changes is confirmed
+type reposDiscardMsg struct{}
+
+// reposDisableMsg removes the allow-list once confirmed
+type reposDisableMsg struct{}
+
+// Model shows the repositories the coding agents may work on and lists the
+// GitHub account's repositories to pick from. It is opened from the Git
+// Providers screen and returns there.
+type Model struct {
+	screens.Room
+	manager *config.RepoManager
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case screens.ShownMsg:
+		s.manager = config.NewRepoManager()
+		return s, config.ListReposCmd
+	case screens.HiddenMsg:
+		s.manager = nil
+	case config.ReposLoadedMsg:
+		if s.manager != nil {
+			return s, s.manager.Update(msg)
+		}
+	case reposDiscardMsg:
+		return s, screens.Show(screens.GitHub)
+	case reposDisableMsg:
+		if s.manager == nil {
+			return s, nil
+		}
+		if err := s.manager.Disable(); err != nil {
+			return s, screens.Toast(fmt.Sprintf("Failed to remove repos.json: %v", err), components.SeverityError)
+		}
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rm := s.manager
+	if rm == nil {
+		return s, screens.Show(screens.GitHub)
+	}
+	if !rm.IsEditing() {
+		switch msg.String() {
+		case "esc", "q":
+			if rm.Dirty() {
+				return s, screens.Confirm("Discard changes",
+					"Leave without saving the repositories you ticked or unticked?",
+					"Discard", reposDiscardMsg{})
+			}
+			return s, screens.Show(screens.GitHub)
+		case "x":
+			if !rm.Enforced() {
+				return s, nil
+			}
+			return s, screens.Confirm("Remove allow-list",
+				"Delete data/repos.json? The coding agents may then clone and change any repository gh can reach.",
+				"Remove", reposDisableMsg{})
+		}
+	}
+	return s, rm.Update(msg)
+}
+
+// CapturingText reports whether the repository filter is being typed.
+func (s Model) CapturingText() bool {
+	return s.manager != nil && s.manager.IsEditing()
+}
+
+func (s Model) HelpKeys() []string {
+	if s.CapturingText() {
+		return screens.KeyHelp(screens.Repos, "Enter", "Esc")
+	}
+	return screens.KeyHelp(screens.Repos, "Space", "/", "m", "s", "Esc")
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	title := layout.SectionHeader("📦 Repository Allow-List", width-4)
+	if s.manager == nil {
+		return title + "\n\n"
+	}
+	s.manager.SetSize(height - 6)
+	return title + "\n\n" + s.manager.View()
+}
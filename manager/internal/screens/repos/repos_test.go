@@ -0,0 +1,83 @@
+package repos
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/github"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+)
+
+func loaded(t *testing.T) Model {
+	t.Helper()
+	s, _ := screenstest.Send(New(), screenstest.Room, screens.ShownMsg{}, config.ReposLoadedMsg{Repos: []github.Repo{
+		{NameWithOwner: "octocat/hello-world", Visibility: "PUBLIC"},
+		{NameWithOwner: "octocat/secret", Visibility: "PRIVATE"},
+	}})
+	return s
+}
+
+func TestViewListsRepos(t *testing.T) {
+	screenstest.Project(t, "")
+	v := loaded(t).View()
+	for _, want := range []string{"Repository Allow-List", "octocat/hello-world", "octocat/secret"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestEscAsksBeforeDiscarding(t *testing.T) {
+	screenstest.Project(t, "")
+	_, cmd := screenstest.Send(loaded(t), screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.GitHub {
+		t.Errorf("Esc without changes showed %v, want Git Providers", show.Screen)
+	}
+
+	s, cmd := screenstest.Send(loaded(t), screenstest.Key(" "), screenstest.Key("esc"))
+	confirm, ok := screenstest.Find[screens.ConfirmMsg](cmd)
+	if !ok {
+		t.Fatal("Esc with a ticked repository didn't ask first")
+	}
+	_, cmd = screenstest.Send(s, confirm.Then)
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.GitHub {
+		t.Errorf("discarding showed %v, want Git Providers", show.Screen)
+	}
+}
+
+func TestRemoveAllowList(t *testing.T) {
+	dir := screenstest.Project(t, "")
+	if _, cmd := screenstest.Send(loaded(t), screenstest.Key("x")); cmd != nil {
+		t.Error("x offered to remove an allow-list that isn't there")
+	}
+
+	file := filepath.Join(dir, "data", "repos.json")
+	if err := os.WriteFile(file, []byte(`{"version":1,"allowedRepos":["octocat/hello-world"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, cmd := screenstest.Send(loaded(t), screenstest.Key("x"))
+	confirm, ok := screenstest.Find[screens.ConfirmMsg](cmd)
+	if !ok {
+		t.Fatal("x didn't ask before removing the allow-list")
+	}
+	screenstest.Send(s, confirm.Then)
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("repos.json is still there: %v", err)
+	}
+}
+
+func TestFilterTakesTyping(t *testing.T) {
+	screenstest.Project(t, "")
+	s, _ := screenstest.Send(loaded(t), screenstest.Key("/"))
+	if !s.CapturingText() {
+		t.Fatal("/ didn't open the filter")
+	}
+	_, cmd := screenstest.Send(s, screenstest.Key("q"))
+	if _, ok := screenstest.Find[screens.ShowMsg](cmd); ok {
+		t.Error("typing q in the filter left the screen")
+	}
+}
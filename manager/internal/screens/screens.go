@@ -0,0 +1,177 @@
+// Package screens holds what the manager's screens share: their IDs and
+// keymaps, and the messages a screen sends the root model to ask for a
+// toast, a confirmation, or another screen. Each screen is a tea.Model in
+// a package of its own below this one, which the root model routes to.
+package screens
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/status"
+)
+
+// ID names a screen.
+type ID int
+
+// Screen IDs for navigation
+const (
+	Splash        ID = iota // Initial splash screen
+	Menu                    // Main menu
+	Config                  // Configuration editor
+	Logs                    // Log viewer
+	Status                  // System status
+	Setup                   // WhatsApp setup wizard
+	Models                  // AI model selector
+	Version                 // Version information
+	Whitelist               // Trusted numbers manager
+	GitHub                  // Git provider authentication screen
+	Stats                   // Message traffic statistics
+	Tasks                   // Kennel task queue dashboard
+	Update                  // Update Fetch (git pull + rebuild)
+	Notifications           // Notification history
+	Crash                   // Caught panic (error boundary)
+	Console                 // Conversation test console
+	Repos                   // Repository allow-list for the coding agents
+	Workspaces              // Kennel workspace browser
+	Approvals               // Tasks waiting on an approve/deny answer
+	Summaries               // Stored conversation summaries
+	Limits                  // Circuit breaker and rate limit panel
+	Groups                  // WhatsApp groups Fetch answers in
+	Owner                   // Guided owner number change
+)
+
+// Model is a screen that owns its state. The root model sends it the
+// screen's keys, its size, and every message the root doesn't handle, and
+// draws its View above the status and help bars. A screen model never
+// reaches into the root model: it asks for a toast, a confirmation, or
+// another screen with the commands below.
+type Model interface {
+	tea.Model
+	// HelpKeys lists the keys for the help bar drawn below the screen, or
+	// nil for a screen that draws its own help line.
+	HelpKeys() []string
+}
+
+// TextInput is implemented by screen models with a text field, so "?" and
+// the tab keys are typed into it while it has the focus.
+type TextInput interface {
+	CapturingText() bool
+}
+
+// NumberKeys is implemented by tab screens with number keys of their own,
+// such as numbered links, which then don't switch tabs.
+type NumberKeys interface {
+	UsesNumberKeys() bool
+}
+
+// InlineHelp is implemented by screen models whose help keys go on the
+// status bar's line rather than a line of their own, like the menu's.
+type InlineHelp interface {
+	InlineHelp() bool
+}
+
+// ShownMsg tells a screen model it was opened, and HiddenMsg that it was
+// left, by any route: the menu, a tab, the palette, or Esc.
+type (
+	ShownMsg  struct{}
+	HiddenMsg struct{}
+)
+
+// ShowMsg asks the root model to open a screen.
+type ShowMsg struct {
+	Screen ID
+}
+
+// ToastMsg asks the root model to show a toast.
+type ToastMsg struct {
+	Message  string
+	Severity components.Severity
+}
+
+// ConfirmMsg asks the root model for a confirmation dialog. On yes, Then
+// is delivered back to the screen that asked.
+type ConfirmMsg struct {
+	Title, Message, Label string
+	Then                  tea.Msg
+}
+
+// RunMsg asks the root model to run a command palette action by its ID,
+// for keys that do what an action does, such as Start Fetch on the menu.
+type RunMsg struct {
+	Action string
+}
+
+// RefreshMsg asks the root model to check the containers and the bridge
+// now rather than on the next poll.
+type RefreshMsg struct{}
+
+// BridgeMsg carries a bridge status, polled by the root model or streamed
+// by the setup screen. The root model hands every one to all screens.
+type BridgeMsg struct {
+	Status *status.BridgeStatus
+	Err    error
+}
+
+// SettingMsg tells the screens that a setting in .env was changed from
+// outside the config editor, such as by the owner change.
+type SettingMsg struct {
+	Key, Value string
+}
+
+// ClickMsg is a left click on the screen. Line is the clicked row as
+// plain text.
+type ClickMsg struct {
+	X    int
+	Line string
+}
+
+// Show opens another screen from a screen model.
+func Show(id ID) tea.Cmd {
+	return func() tea.Msg { return ShowMsg{Screen: id} }
+}
+
+// Toast shows a toast from a screen model.
+func Toast(message string, severity components.Severity) tea.Cmd {
+	return func() tea.Msg { return ToastMsg{Message: message, Severity: severity} }
+}
+
+// Confirm asks before a screen model goes ahead with then.
+func Confirm(title, message, label string, then tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return ConfirmMsg{Title: title, Message: message, Label: label, Then: then}
+	}
+}
+
+// Run runs a command palette action from a screen model.
+func Run(action string) tea.Cmd {
+	return func() tea.Msg { return RunMsg{Action: action} }
+}
+
+// Refresh asks for the containers and the bridge to be checked now.
+func Refresh() tea.Msg {
+	return RefreshMsg{}
+}
+
+// Room is the space a screen model draws in, from the last
+// tea.WindowSizeMsg the root model sent it. Screen models embed it.
+type Room struct {
+	width, height int
+}
+
+// Resize records the room a tea.WindowSizeMsg gives.
+func (r *Room) Resize(msg tea.WindowSizeMsg) {
+	r.width, r.height = msg.Width, msg.Height
+}
+
+// Size returns the room, taking 80x22 until the first size arrives.
+func (r Room) Size() (width, height int) {
+	width, height = r.width, r.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 22
+	}
+	return width, height
+}
@@ -0,0 +1,123 @@
+// Package screenstest drives screen models in tests: it presses keys on
+// them and collects what they ask the root model for.
+package screenstest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/paths"
+)
+
+// Room is the size the tests draw screens at.
+var Room = tea.WindowSizeMsg{Width: 80, Height: 22}
+
+// keys are the named keys Key understands; anything else is typed as
+// runes.
+var keys = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	"ctrl+c":    tea.KeyCtrlC,
+	"ctrl+k":    tea.KeyCtrlK,
+	"backspace": tea.KeyBackspace,
+}
+
+// Key returns the message for pressing key, named as tea.KeyMsg.String
+// names it.
+func Key(key string) tea.KeyMsg {
+	if t, ok := keys[key]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// Keys returns the messages for pressing keys in turn.
+func Keys(keys ...string) []tea.Msg {
+	msgs := make([]tea.Msg, len(keys))
+	for i, key := range keys {
+		msgs[i] = Key(key)
+	}
+	return msgs
+}
+
+// Send delivers msgs to s in order and returns the screen and the last
+// message's command.
+func Send[S tea.Model](s S, msgs ...tea.Msg) (S, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, msg := range msgs {
+		var next tea.Model
+		next, cmd = s.Update(msg)
+		s = next.(S)
+	}
+	return s, cmd
+}
+
+// settle is how long Msgs waits for a command. Ticks and requests that
+// outlast it are left running and their messages dropped.
+const settle = 100 * time.Millisecond
+
+// Msgs runs cmd and the commands batched in it, returning the messages of
+// those that finish promptly.
+func Msgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(settle):
+		return nil
+	}
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var out []tea.Msg
+	for _, c := range batch {
+		out = append(out, Msgs(c)...)
+	}
+	return out
+}
+
+// Find returns the first message of type T among those cmd delivers.
+func Find[T tea.Msg](cmd tea.Cmd) (T, bool) {
+	for _, msg := range Msgs(cmd) {
+		if t, ok := msg.(T); ok {
+			return t, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Project points paths at a new project directory with env as its .env
+// until the test ends, and returns the directory.
+func Project(t testing.TB, env string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	prev := paths.ProjectDir
+	paths.Use(dir)
+	t.Cleanup(func() { paths.Use(prev) })
+	return dir
+}
@@ -0,0 +1,301 @@
+package setup
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	qrcode "github.com/skip2/go-qrcode"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// QRStyle selects how the WhatsApp QR code is drawn. Half blocks are the
+// most compact but garble on fonts without them; the other styles trade
+// size for compatibility.
+type QRStyle string
+
+const (
+	qrStyleAuto    QRStyle = "auto"    // half unless the locale isn't UTF-8
+	qrStyleHalf    QRStyle = "half"    // ▀ ▄ █, two rows per line
+	qrStyleBlock   QRStyle = "block"   // full blocks only, one row per line
+	qrStyleASCII   QRStyle = "ascii"   // ## and spaces, no Unicode at all
+	qrStyleInverse QRStyle = "inverse" // reverse-video spaces
+	qrStylePNG     QRStyle = "png"     // written to data/whatsapp-qr.png
+)
+
+var qrStyles = []QRStyle{qrStyleAuto, qrStyleHalf, qrStyleBlock, qrStyleASCII, qrStyleInverse, qrStylePNG}
+
+// ParseQRStyle validates a --qr-style value and resolves auto.
+func ParseQRStyle(s string) (QRStyle, error) {
+	style := QRStyle(strings.ToLower(strings.TrimSpace(s)))
+	if style == "" {
+		style = qrStyleAuto
+	}
+	for _, known := range qrStyles {
+		if style == known {
+			if style == qrStyleAuto {
+				return detectQRStyle(), nil
+			}
+			return style, nil
+		}
+	}
+	names := make([]string, len(qrStyles))
+	for i, known := range qrStyles {
+		names[i] = string(known)
+	}
+	return "", fmt.Errorf("unknown QR style %q (want %s)", s, strings.Join(names, ", "))
+}
+
+// detectQRStyle falls back to ASCII when the terminal is unlikely to have
+// block characters: a dumb terminal or a locale that isn't UTF-8.
+func detectQRStyle() QRStyle {
+	if os.Getenv("TERM") == "dumb" {
+		return qrStyleASCII
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			v = strings.ToLower(v)
+			if strings.Contains(v, "utf-8") || strings.Contains(v, "utf8") {
+				return qrStyleHalf
+			}
+			return qrStyleASCII
+		}
+	}
+	return qrStyleHalf
+}
+
+// qrModules draws a QR bitmap one row per line, each module two cells wide
+// so it stays roughly square.
+func qrModules(bitmap [][]bool, dark, light string) string {
+	var b strings.Builder
+	for _, row := range bitmap {
+		for _, on := range row {
+			if on {
+				b.WriteString(dark)
+			} else {
+				b.WriteString(light)
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// qrInverse draws dark modules as reverse-video spaces, which works on any
+// terminal that supports basic SGR attributes.
+func qrInverse(bitmap [][]bool) string {
+	on := lipgloss.NewStyle().Reverse(true).Render("  ")
+	return qrModules(bitmap, on, "  ")
+}
+
+// qrPNGPath is where the png style writes the current QR code.
+func qrPNGPath() string {
+	return filepath.Join(paths.ProjectDir, "data", "whatsapp-qr.png")
+}
+
+// qrPNGMsg reports the result of writing the QR code image.
+type qrPNGMsg struct {
+	path string
+	err  error
+}
+
+// writeQRPNGCmd saves the QR code as an image for terminals that can't
+// draw it at all.
+func writeQRPNGCmd(data string) tea.Cmd {
+	return func() tea.Msg {
+		path := qrPNGPath()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return qrPNGMsg{err: err}
+		}
+		if err := qrcode.WriteFile(data, qrcode.Medium, 320, path); err != nil {
+			return qrPNGMsg{err: err}
+		}
+		return qrPNGMsg{path: path}
+	}
+}
+
+// qrServeDuration is how long the QR code stays available over HTTP.
+const qrServeDuration = 2 * time.Minute
+
+// qrServer serves the current QR code as a PNG on a loopback port, for
+// terminals whose fonts mangle it. The URL carries a random token since the
+// code logs in to the owner's WhatsApp.
+type qrServer struct {
+	srv *http.Server
+	url string
+
+	mu   sync.Mutex
+	data string
+}
+
+// ServeStopMsg ends serving once qrServeDuration has passed.
+type ServeStopMsg struct {
+	server *qrServer
+}
+
+// startQRServer listens on a free loopback port and serves data until stop
+// is called.
+func startQRServer(data string) (*qrServer, error) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &qrServer{data: data}
+	base := "/" + hex.EncodeToString(token)
+	mux := http.NewServeMux()
+	mux.HandleFunc(base+"/qr.png", func(w http.ResponseWriter, r *http.Request) {
+		png, err := qrcode.Encode(s.current(), qrcode.Medium, 320)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write(png)
+	})
+	mux.HandleFunc(base, func(w http.ResponseWriter, r *http.Request) {
+		// The page reloads so a refreshed code replaces the expired one
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintf(w, `<!doctype html><meta http-equiv="refresh" content="5"><title>Fetch WhatsApp QR</title>`+
+			`<body style="text-align:center;font-family:sans-serif"><p>Scan with WhatsApp → Linked devices</p>`+
+			`<img src="%s/qr.png?%d" width="320" height="320" alt="WhatsApp QR code"></body>`,
+			html.EscapeString(base), time.Now().Unix())
+	})
+
+	s.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	s.url = "http://" + ln.Addr().String() + base
+	go s.srv.Serve(ln)
+	return s, nil
+}
+
+func (s *qrServer) current() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data
+}
+
+// update swaps in a refreshed QR code.
+func (s *qrServer) update(data string) {
+	s.mu.Lock()
+	s.data = data
+	s.mu.Unlock()
+}
+
+// stop closes the listener and any open connections.
+func (s *qrServer) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.srv.Shutdown(ctx)
+}
+
+// qrServeStopCmd stops server after qrServeDuration.
+func qrServeStopCmd(server *qrServer) tea.Cmd {
+	return tea.Tick(qrServeDuration, func(time.Time) tea.Msg {
+		return ServeStopMsg{server: server}
+	})
+}
+
+// toggleServer starts serving the pending QR code over HTTP, or stops it.
+func (s Model) toggleServer() (tea.Model, tea.Cmd) {
+	if s.server != nil {
+		return s.stopServer(), nil
+	}
+	if s.bridge == nil || s.bridge.State != "qr_pending" || s.bridge.QRCode == nil {
+		return s, nil
+	}
+	server, err := startQRServer(*s.bridge.QRCode)
+	if err != nil {
+		return s, screens.Toast(fmt.Sprintf("Couldn't serve the QR code: %v", err), components.SeverityError)
+	}
+	s.server = server
+	return s, qrServeStopCmd(server)
+}
+
+// stopServer stops serving the QR code, if it is being served.
+func (s Model) stopServer() Model {
+	if s.server != nil {
+		s.server.stop()
+		s.server = nil
+	}
+	return s
+}
+
+// renderQRCodeCompact renders a smaller QR code using Low error correction
+// and skipping every other pixel for a more compact display. Styles other
+// than half trade that compactness for terminals without half blocks.
+func renderQRCodeCompact(data string, style QRStyle) string {
+	// Use Low error correction for smaller QR code
+	qr, err := qrcode.New(data, qrcode.Low)
+	if err != nil {
+		return "   Error generating QR code"
+	}
+
+	// Get the QR code as a bitmap
+	bitmap := qr.Bitmap()
+
+	// Style for the QR code box
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Active().Primary).
+		Padding(0, 1)
+
+	switch style {
+	case qrStyleBlock:
+		return boxStyle.Render(qrModules(bitmap, "██", "  "))
+	case qrStyleASCII:
+		return boxStyle.BorderStyle(lipgloss.ASCIIBorder()).Render(qrModules(bitmap, "##", "  "))
+	case qrStyleInverse:
+		return boxStyle.Render(qrInverse(bitmap))
+	case qrStylePNG:
+		return ""
+	}
+
+	var qrContent strings.Builder
+
+	// Use unicode block characters - combine 2 rows into 1 line
+	for y := 0; y < len(bitmap)-1; y += 2 {
+		for x := 0; x < len(bitmap[y]); x++ {
+			top := bitmap[y][x]
+			bottom := false
+			if y+1 < len(bitmap) {
+				bottom = bitmap[y+1][x]
+			}
+
+			// Use half-block characters for 2:1 aspect ratio
+			if top && bottom {
+				qrContent.WriteString("█")
+			} else if top {
+				qrContent.WriteString("▀")
+			} else if bottom {
+				qrContent.WriteString("▄")
+			} else {
+				qrContent.WriteString(" ")
+			}
+		}
+		qrContent.WriteString("\n")
+	}
+
+	// Wrap in a styled box
+	return boxStyle.Render(qrContent.String())
+}
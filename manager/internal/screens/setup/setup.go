@@ -0,0 +1,685 @@
+// Package setup is the WhatsApp setup screen: the QR code or pairing code
+// that links the bridge to a phone, and the steps of linking as the bridge
+// reports them.
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/phone"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// steps are the stages of linking WhatsApp, in the order the bridge
+// reports them.
+var steps = []struct {
+	state string
+	label string
+}{
+	{"qr_pending", "Waiting for your phone"},
+	{"authenticating", "Linked, loading chats"},
+	{"authenticated", "Ready"},
+}
+
+// returnDelay is how long a new link stays on screen before the setup
+// screen returns to the menu.
+const returnDelay = 2 * time.Second
+
+// QR code refresh interval (WhatsApp QR codes expire after ~20 seconds)
+const refreshInterval = 20 * time.Second
+
+// timeline records when the setup screen first saw each step.
+type timeline struct {
+	opened time.Time
+	at     map[string]time.Time // State → first seen since the screen opened
+}
+
+// pairing reports whether linking happened while the screen was open, as
+// opposed to opening it on an account that was already linked.
+func (t timeline) pairing() bool {
+	_, qr := t.at["qr_pending"]
+	_, scanned := t.at["authenticating"]
+	return qr || scanned
+}
+
+// streamMsg carries the opened status stream, or why it couldn't open.
+type streamMsg struct {
+	events <-chan *status.BridgeStatus
+	cancel context.CancelFunc
+	err    error
+}
+
+// eventMsg is one status from the stream.
+type eventMsg struct {
+	status *status.BridgeStatus
+	events <-chan *status.BridgeStatus
+}
+
+// streamEndMsg reports that the stream closed; polling carries on.
+type streamEndMsg struct {
+	events <-chan *status.BridgeStatus
+}
+
+// DoneMsg returns to the menu once the new link has been shown.
+type DoneMsg struct{}
+
+// TickMsg counts down to the QR code's refresh, every second.
+type TickMsg time.Time
+
+// pairingCodeMsg carries the result of requesting a WhatsApp pairing code
+type pairingCodeMsg struct {
+	code string
+	err  error
+}
+
+// watchCmd opens the bridge's status stream, so the screen moves on the
+// moment the phone scans instead of on the next poll.
+func watchCmd(client *status.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := client.WatchStatus(ctx)
+		if err != nil {
+			cancel()
+			return streamMsg{err: err}
+		}
+		return streamMsg{events: events, cancel: cancel}
+	}
+}
+
+func waitEventCmd(events <-chan *status.BridgeStatus) tea.Cmd {
+	return func() tea.Msg {
+		s, ok := <-events
+		if !ok {
+			return streamEndMsg{events: events}
+		}
+		return eventMsg{status: s, events: events}
+	}
+}
+
+// requestPairingCodeCmd asks the bridge for a phone-number pairing code
+func requestPairingCodeCmd(client *status.Client, phone string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.RequestPairingCode(phone)
+		if err != nil {
+			return pairingCodeMsg{err: err}
+		}
+		return pairingCodeMsg{code: resp.Code}
+	}
+}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return TickMsg(t)
+	})
+}
+
+// Model is the setup screen. It keeps the last bridge status it was sent,
+// shown or not, so it opens on the current state.
+type Model struct {
+	screens.Room
+	client *status.Client
+	bridge *status.BridgeStatus
+	shown  bool
+	// QR code refresh countdown
+	progress     progress.Model
+	countdown    int // Seconds remaining until refresh
+	maxCountdown int
+	ticking      bool // The countdown loop is running
+	announce     bool // Coarse text announcements instead of animated bars
+	// Pairing-code login (alternative to QR for headless servers)
+	pairingRequesting bool   // Waiting for the bridge to return a code
+	pairingCode       string // Code returned by the bridge
+	pairingErr        string // Last pairing error
+	// Phone number prompt for pairing-code login, kept once opened so a
+	// number typed earlier is still there
+	pairingForm *components.Form
+
+	style   QRStyle // How the QR code is drawn
+	pngData string  // QR data last written to disk (png style, or after 's')
+	pngPath string
+	pngErr  error
+	server  *qrServer // Serving the QR code over HTTP, after 'h'
+
+	// Pairing progress, driven by the bridge's status stream
+	timeline timeline
+	events   <-chan *status.BridgeStatus
+	cancel   context.CancelFunc
+}
+
+// New returns the setup screen, drawing the QR code in style and counting
+// down in text rather than with a bar when announce is set.
+func New(client *status.Client, style QRStyle, announce bool) Model {
+	countdown := int(refreshInterval.Seconds())
+	return Model{
+		client: client,
+		progress: progress.New(
+			progress.WithDefaultGradient(),
+			progress.WithWidth(30),
+			progress.WithoutPercentage(),
+		),
+		countdown:    countdown,
+		maxCountdown: countdown,
+		announce:     announce,
+		style:        style,
+	}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+// CapturingText is true while the phone number is typed.
+func (s Model) CapturingText() bool {
+	return s.pairingEntry()
+}
+
+// UsesNumberKeys is true while the QR code's link is numbered.
+func (s Model) UsesNumberKeys() bool {
+	return len(s.links()) > 0
+}
+
+// links returns the numbered links on the screen: the QR code's URL while
+// waiting for a scan.
+func (s Model) links() []components.Link {
+	if s.bridge != nil && s.bridge.State == "qr_pending" && s.bridge.QRUrl != nil {
+		return []components.Link{{Label: "QR code", URL: *s.bridge.QRUrl}}
+	}
+	return nil
+}
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+
+	case screens.ShownMsg:
+		// The QR countdown starts once a status with a pending code arrives
+		s.shown = true
+		s.countdown = s.maxCountdown
+		s.timeline = timeline{opened: time.Now(), at: map[string]time.Time{}}
+		if s.events != nil {
+			return s, screens.Refresh
+		}
+		return s, tea.Batch(screens.Refresh, watchCmd(s.client))
+
+	case screens.HiddenMsg:
+		// The QR server and status stream only serve this screen
+		s.shown = false
+		return s.stopServer().stopStream(), nil
+
+	case screens.BridgeMsg:
+		return s.updateBridge(msg)
+
+	case screens.SettingMsg:
+		if msg.Key == "FETCH_ACCESSIBLE" {
+			s.announce = msg.Value == "true"
+		}
+
+	case streamMsg:
+		if msg.err != nil {
+			return s, nil
+		}
+		if !s.shown || s.events != nil {
+			msg.cancel()
+			return s, nil
+		}
+		s.events, s.cancel = msg.events, msg.cancel
+		return s, waitEventCmd(msg.events)
+
+	case eventMsg:
+		if msg.events != s.events {
+			return s, nil
+		}
+		if !s.shown {
+			return s.stopStream(), nil
+		}
+		// Stream events go through the same path as polled statuses
+		bridge := func() tea.Msg { return screens.BridgeMsg{Status: msg.status} }
+		return s, tea.Batch(bridge, waitEventCmd(msg.events))
+
+	case streamEndMsg:
+		if msg.events == s.events {
+			s = s.stopStream()
+		}
+
+	case DoneMsg:
+		if s.shown && s.bridge != nil && s.bridge.State == "authenticated" {
+			return s, screens.Show(screens.Menu)
+		}
+
+	case pairingCodeMsg:
+		s.pairingRequesting = false
+		if msg.err != nil {
+			s.pairingErr = msg.err.Error()
+			s.pairingCode = ""
+		} else {
+			s.pairingErr = ""
+			s.pairingCode = msg.code
+		}
+
+	case qrPNGMsg:
+		s.pngPath, s.pngErr = msg.path, msg.err
+
+	case ServeStopMsg:
+		if msg.server == s.server {
+			s = s.stopServer()
+		}
+
+	case progress.FrameMsg:
+		next, cmd := s.progress.Update(msg)
+		s.progress = next.(progress.Model)
+		return s, cmd
+
+	case TickMsg:
+		// Only count down while the screen shows a pending QR code
+		if !s.shown || s.bridge == nil || s.bridge.State != "qr_pending" {
+			s.ticking = false
+			return s, nil
+		}
+		s.countdown--
+		if s.countdown <= 0 {
+			// Auto-refresh: fetch new status
+			s.countdown = s.maxCountdown
+			return s, tea.Batch(screens.Refresh, tickCmd())
+		}
+		// Text announcements need no animation frames
+		if s.announce {
+			return s, tickCmd()
+		}
+		percent := float64(s.countdown) / float64(s.maxCountdown)
+		return s, tea.Batch(s.progress.SetPercent(percent), tickCmd())
+
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+// updateBridge takes a new bridge status: the pairing step it reached,
+// and a QR code that replaces the last one.
+func (s Model) updateBridge(msg screens.BridgeMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return s, nil
+	}
+	s, done := s.observe(msg.Status)
+	oldQRCode := ""
+	if s.bridge != nil && s.bridge.QRCode != nil {
+		oldQRCode = *s.bridge.QRCode
+	}
+	s.bridge = msg.Status
+	if s.bridge == nil {
+		return s, done
+	}
+	// Pairing is finished once WhatsApp reports the link
+	if s.bridge.State == "authenticated" {
+		s.pairingCode = ""
+		s.pairingErr = ""
+		s = s.stopServer()
+	}
+	if s.bridge.State != "qr_pending" || s.bridge.QRCode == nil {
+		return s, done
+	}
+	code := *s.bridge.QRCode
+	// Only reset the countdown for a NEW QR code
+	if oldQRCode != code {
+		s.countdown = s.maxCountdown
+	}
+	// Start the countdown whenever a QR code appears on the screen
+	var countdown tea.Cmd
+	if s.shown && !s.ticking {
+		s.ticking = true
+		countdown = tickCmd()
+	}
+	if s.server != nil {
+		s.server.update(code)
+	}
+	// A saved image (png style or 's') is rewritten for every new code
+	if (s.style == qrStylePNG || s.pngData != "") && code != s.pngData {
+		s.pngData = code
+		return s, tea.Batch(countdown, writeQRPNGCmd(s.pngData), done)
+	}
+	return s, tea.Batch(countdown, done)
+}
+
+// observe records the pairing step b reached. Once WhatsApp is ready after
+// linking on this screen, it says so and schedules the return to the menu.
+func (s Model) observe(b *status.BridgeStatus) (Model, tea.Cmd) {
+	if !s.shown || b == nil || s.timeline.at == nil {
+		return s, nil
+	}
+	if b.State == "qr_pending" {
+		// A fresh code after a failed link starts over
+		delete(s.timeline.at, "authenticating")
+	}
+	if _, seen := s.timeline.at[b.State]; seen {
+		return s, nil
+	}
+	for _, step := range steps {
+		if step.state != b.State {
+			continue
+		}
+		s.timeline.at[b.State] = time.Now()
+		if b.State == "authenticated" && s.timeline.pairing() {
+			return s, tea.Batch(
+				screens.Toast("WhatsApp linked in "+formatElapsed(time.Since(s.timeline.opened)), components.SeveritySuccess),
+				tea.Tick(returnDelay, func(time.Time) tea.Msg { return DoneMsg{} }),
+			)
+		}
+	}
+	return s, nil
+}
+
+// stopStream closes the status stream, if one is open.
+func (s Model) stopStream() Model {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.events, s.cancel = nil, nil
+	return s
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if s.pairingEntry() {
+		return s.updatePairingEntry(msg)
+	}
+
+	linked := s.bridge != nil && s.bridge.State == "authenticated"
+	switch msg.String() {
+	case "esc", "q":
+		return s, screens.Show(screens.Menu)
+	case "s":
+		// Save the QR code as an image; it is kept current from then on
+		if s.bridge != nil && s.bridge.State == "qr_pending" && s.bridge.QRCode != nil {
+			s.pngData = *s.bridge.QRCode
+			s.pngPath, s.pngErr = "", nil
+			return s, writeQRPNGCmd(s.pngData)
+		}
+		return s, nil
+	case "h":
+		return s.toggleServer()
+	case "p":
+		// Link with phone number instead of scanning the QR code
+		if linked {
+			return s, nil
+		}
+		if s.pairingForm == nil {
+			s.pairingForm = newPairingForm()
+		}
+		s.pairingForm.Open()
+		s.pairingErr = ""
+		return s, nil
+	case "x":
+		if linked {
+			return s, screens.Run("disconnect-whatsapp")
+		}
+		return s, nil
+	case "r":
+		return s, screens.Run("reconnect-whatsapp")
+	case "l":
+		return s, screens.Run("relink-whatsapp")
+	case "c":
+		return s, screens.Run("clear-whatsapp-auth")
+	case "o":
+		// Open QR URL in browser
+		if s.bridge != nil && s.bridge.QRUrl != nil {
+			return s, screens.OpenLink(components.Link{Label: "QR code", URL: *s.bridge.QRUrl})
+		}
+		return s, nil
+	}
+	return s, screens.LinkKey(s.links(), msg)
+}
+
+// newPairingForm creates the phone number prompt for pairing-code login,
+// prefilled with the owner's number.
+func newPairingForm() *components.Form {
+	return components.NewForm(components.FormField{
+		Key:   "phone",
+		Label: "Phone number",
+		Value: config.EnvValue("OWNER_PHONE_NUMBER"),
+		Help:  "Country code + number, digits only (e.g. 15551234567)",
+		Filter: func(r rune) bool {
+			return (r >= '0' && r <= '9') || r == '+'
+		},
+		Validate: func(value string) (string, error) {
+			number := strings.TrimPrefix(strings.ReplaceAll(value, " ", ""), "+")
+			if len(number) < 8 {
+				return "", errors.New("Phone number must include the country code (e.g. 15551234567)")
+			}
+			return number, nil
+		},
+	})
+}
+
+// pairingEntry reports whether the phone number prompt is open.
+func (s Model) pairingEntry() bool {
+	return s.pairingForm != nil && s.pairingForm.Editing()
+}
+
+// updatePairingEntry handles typing the phone number for pairing-code login
+func (s Model) updatePairingEntry(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if s.pairingForm.Update(msg) != components.FormCommitted {
+		return s, nil
+	}
+	s.pairingRequesting = true
+	s.pairingCode = ""
+	s.pairingErr = ""
+	return s, requestPairingCodeCmd(s.client, s.pairingForm.Value("phone"))
+}
+
+func (s Model) HelpKeys() []string {
+	switch {
+	case s.pairingEntry():
+		return append(screens.KeyHelp(screens.Setup, "Enter"), "Esc Cancel")
+	case s.bridge != nil && s.bridge.State == "qr_pending":
+		return screens.KeyHelp(screens.Setup, "o", "s", "h", "p", "alt+1-9", "Esc")
+	case s.bridge != nil && s.bridge.State == "authenticated":
+		return screens.KeyHelp(screens.Setup, "x", "r", "l", "Esc")
+	case s.bridge != nil:
+		return screens.KeyHelp(screens.Setup, "p", "r", "c", "Esc")
+	}
+	return screens.KeyHelp(screens.Setup, "Esc")
+}
+
+func (s Model) View() string {
+	width, _ := s.Size()
+
+	// Title
+	title := layout.SectionHeader("📱 WhatsApp Setup", width-4)
+
+	var content strings.Builder
+
+	if s.bridge == nil {
+		content.WriteString(theme.StatusInfo().Render("Connecting to Fetch Bridge...") + "\n")
+		content.WriteString(theme.Subtitle().Render("Make sure Fetch is running (Start Fetch from menu)") + "\n")
+	} else {
+		// Show status
+		content.WriteString(fmt.Sprintf("Status: %s %s\n\n", s.bridge.StateEmoji(), s.bridge.StateDescription()))
+		content.WriteString(s.renderTimeline())
+
+		// Pairing-code flow replaces the QR code while active
+		pairing := s.pairingEntry() || s.pairingRequesting || s.pairingCode != ""
+		if pairing && s.bridge.State != "authenticated" {
+			content.WriteString(s.renderPairing())
+		}
+
+		switch s.bridge.State {
+		case "qr_pending":
+			if pairing {
+				break
+			}
+			content.WriteString(theme.StatusInfo().Render("📱 Scan this QR code with WhatsApp:") + "\n\n")
+
+			if s.bridge.QRCode != nil {
+				content.WriteString(s.renderQR(width))
+			} else if s.bridge.QRUrl != nil {
+				content.WriteString(theme.QRBox().Render(
+					"Press 'o' or '1' to open QR in browser:\n\n"+*s.bridge.QRUrl,
+				) + "\n\n")
+			} else {
+				content.WriteString(theme.Subtitle().Render("QR code generating... wait a moment.") + "\n")
+			}
+
+		case "authenticating":
+			content.WriteString(theme.StatusInfo().Render("🔐 Scanned. WhatsApp is loading your chats...") + "\n")
+			content.WriteString(theme.Subtitle().Render("Keep the phone online; this takes a few seconds.") + "\n")
+
+		case "authenticated":
+			content.WriteString(theme.StatusSuccess().Render("✅ WhatsApp is connected and ready!") + "\n\n")
+			if d := s.bridge.Device; d != nil {
+				if d.Name != nil {
+					content.WriteString(fmt.Sprintf("Account: %s\n", *d.Name))
+				}
+				if d.Phone != nil {
+					content.WriteString(fmt.Sprintf("Phone: %s\n", phone.Pretty(*d.Phone)))
+				}
+			}
+			content.WriteString(fmt.Sprintf("Uptime: %s\n", s.bridge.FormatUptime()))
+			content.WriteString(fmt.Sprintf("Messages: %d\n", s.bridge.MessageCount))
+			if s.timeline.pairing() {
+				content.WriteString("\n" + theme.Subtitle().Render("Returning to the menu...") + "\n")
+			}
+
+		case "disconnected":
+			content.WriteString(theme.StatusError().Render("WhatsApp disconnected.") + "\n")
+			if s.bridge.LastError != nil {
+				content.WriteString(theme.Subtitle().Render(fmt.Sprintf("Reason: %s", *s.bridge.LastError)) + "\n")
+			}
+			content.WriteString("\nPress r to reconnect, or l to link again with a new QR code.\n")
+
+		case "error":
+			content.WriteString(theme.StatusError().Render("An error occurred.") + "\n")
+			if s.bridge.LastError != nil {
+				content.WriteString(theme.Subtitle().Render(fmt.Sprintf("Error: %s", *s.bridge.LastError)) + "\n")
+			}
+			content.WriteString("\nPress r to reconnect. If it keeps failing, press c to clear the saved login.\n")
+
+		default:
+			content.WriteString(theme.Subtitle().Render("Starting up...") + "\n")
+		}
+	}
+
+	// Numbered links (QR URL while pairing)
+	if links := s.links(); len(links) > 0 {
+		content.WriteString("\n" + components.LinkList(links))
+	}
+
+	if s.pairingErr != "" {
+		content.WriteString("\n" + theme.StatusError().Render("Pairing failed: "+s.pairingErr) + "\n")
+	}
+
+	return title + "\n\n" + content.String()
+}
+
+// renderQR draws the pending QR code in the chosen style, with the saved
+// image, the HTTP address and the refresh countdown under it.
+func (s Model) renderQR(width int) string {
+	var b strings.Builder
+	qrText := renderQRCodeCompact(*s.bridge.QRCode, s.style)
+	switch {
+	case s.style == qrStylePNG:
+		switch {
+		case s.pngErr != nil:
+			b.WriteString(theme.StatusError().Render(fmt.Sprintf("Couldn't save the QR code image: %v", s.pngErr)) + "\n")
+		case s.pngPath == "":
+			b.WriteString(theme.Subtitle().Render("Saving the QR code image...") + "\n")
+		default:
+			b.WriteString("QR code saved to:\n" + theme.Value().Render(s.pngPath) + "\n")
+			b.WriteString(theme.Subtitle().Render("Open it in an image viewer and scan it from the screen.") + "\n")
+		}
+	case lipgloss.Width(qrText) > width:
+		b.WriteString(theme.StatusWarning().Render("The terminal is too narrow to show the QR code.") + "\n")
+		b.WriteString(theme.Subtitle().Render("Press 'o' to open it in the browser, or widen the window.") + "\n")
+	default:
+		b.WriteString(qrText + "\n")
+	}
+	if s.style != qrStylePNG && s.pngData != "" {
+		switch {
+		case s.pngErr != nil:
+			b.WriteString(theme.StatusError().Render(fmt.Sprintf("Couldn't save the QR code image: %v", s.pngErr)) + "\n")
+		case s.pngPath != "":
+			b.WriteString("Image: " + theme.Value().Render(s.pngPath) + "\n")
+		}
+	}
+	if s.server != nil {
+		b.WriteString("Serving at " + theme.Value().Render(s.server.url) + "\n")
+		b.WriteString(theme.Subtitle().Render(fmt.Sprintf("This machine only, for %d minutes; over SSH forward the port with ssh -L. 'h' stops.",
+			int(qrServeDuration.Minutes()))) + "\n")
+	}
+
+	// Show countdown (coarse text for screen readers and narrow terminals,
+	// otherwise a progress bar)
+	if s.announce || layout.IsCompact(width) {
+		b.WriteString("\nQR code refresh: " + components.CoarseCountdown(s.countdown) + "\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("\n⏱️  Auto-refresh in %ds ", s.countdown))
+		b.WriteString(s.progress.View() + "\n\n")
+	}
+	b.WriteString(theme.Subtitle().Render("'o' open in browser | 's' save as PNG | 'h' serve over HTTP | Esc go back") + "\n")
+	return b.String()
+}
+
+// renderTimeline shows the pairing steps with a check and the time each
+// was reached, or nothing when no linking happened on this screen.
+func (s Model) renderTimeline() string {
+	t := s.timeline
+	if !t.pairing() {
+		return ""
+	}
+	var b strings.Builder
+	for i, step := range steps {
+		at, done := t.at[step.state]
+		switch {
+		case done:
+			b.WriteString(theme.StatusSuccess().Render(fmt.Sprintf("✓ %-24s", step.label)) +
+				theme.Subtitle().Render("+"+formatElapsed(at.Sub(t.opened))) + "\n")
+		case i > 0 && hasStep(t, steps[i-1].state):
+			b.WriteString(theme.StatusInfo().Render("⏳ "+step.label) + "\n")
+		default:
+			b.WriteString(theme.Subtitle().Render("· "+step.label) + "\n")
+		}
+	}
+	return b.String() + "\n"
+}
+
+func hasStep(t timeline, state string) bool {
+	_, ok := t.at[state]
+	return ok
+}
+
+// formatElapsed formats a pairing duration, e.g. "14s" or "1m05s".
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// renderPairing renders the phone-number prompt or the pairing code
+func (s Model) renderPairing() string {
+	var b strings.Builder
+	switch {
+	case s.pairingEntry():
+		b.WriteString(theme.StatusInfo().Render("📞 Link with phone number") + "\n\n")
+		b.WriteString(s.pairingForm.View())
+	case s.pairingRequesting:
+		b.WriteString(theme.StatusInfo().Render("Requesting pairing code from the bridge...") + "\n")
+	case s.pairingCode != "":
+		b.WriteString(theme.StatusInfo().Render("📞 Enter this code in WhatsApp:") + "\n\n")
+		b.WriteString(theme.QRBox().Render(theme.Title().UnsetMarginBottom().Render(status.FormatPairingCode(s.pairingCode))) + "\n\n")
+		b.WriteString(theme.Subtitle().Render("WhatsApp → Linked devices → Link a device → Link with phone number instead") + "\n")
+		b.WriteString(theme.Subtitle().Render("Press 'p' to request a new code") + "\n")
+	}
+	return b.String()
+}
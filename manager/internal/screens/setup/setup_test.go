@@ -0,0 +1,129 @@
+package setup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+)
+
+func bridge(state string) screens.BridgeMsg {
+	b := &status.BridgeStatus{State: state}
+	if state == "qr_pending" {
+		code, url := "2@fetch-test-code", "http://localhost:8765/qr"
+		b.QRCode, b.QRUrl = &code, &url
+	}
+	return screens.BridgeMsg{Status: b}
+}
+
+// shown opens the screen with the countdown in text, so no animation
+// frames are scheduled.
+func shown(t *testing.T) Model {
+	t.Helper()
+	screenstest.Project(t, "OWNER_PHONE_NUMBER=15551234567\n")
+	s, _ := screenstest.Send(New(nil, qrStyleHalf, true), screenstest.Room, screens.ShownMsg{})
+	return s
+}
+
+func TestViewBeforeBridge(t *testing.T) {
+	s, _ := screenstest.Send(New(nil, qrStyleHalf, true), screenstest.Room)
+	if v := s.View(); !strings.Contains(v, "Connecting to Fetch Bridge") {
+		t.Errorf("view:\n%s", v)
+	}
+}
+
+func TestKeysRunActions(t *testing.T) {
+	tests := []struct {
+		state, key, action string
+	}{
+		{"authenticated", "x", "disconnect-whatsapp"},
+		{"authenticated", "r", "reconnect-whatsapp"},
+		{"disconnected", "r", "reconnect-whatsapp"},
+		{"disconnected", "l", "relink-whatsapp"},
+		{"error", "c", "clear-whatsapp-auth"},
+		{"qr_pending", "x", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.state+" "+tt.key, func(t *testing.T) {
+			s, _ := screenstest.Send(shown(t), bridge(tt.state))
+			_, cmd := screenstest.Send(s, screenstest.Key(tt.key))
+			run, _ := screenstest.Find[screens.RunMsg](cmd)
+			if run.Action != tt.action {
+				t.Errorf("ran %q, want %q", run.Action, tt.action)
+			}
+		})
+	}
+}
+
+func TestPendingCodeCountsDown(t *testing.T) {
+	s, cmd := screenstest.Send(shown(t), bridge("qr_pending"))
+	if cmd == nil {
+		t.Fatal("a pending code didn't start the countdown")
+	}
+	if !s.UsesNumberKeys() {
+		t.Error("the QR code's link isn't numbered")
+	}
+	v := s.View()
+	for _, want := range []string{"Scan this QR code", "QR code refresh", "http://localhost:8765/qr"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+
+	// The countdown stops once the code is scanned
+	s, _ = screenstest.Send(s, bridge("authenticating"))
+	if _, cmd = screenstest.Send(s, TickMsg{}); cmd != nil {
+		t.Error("the countdown kept ticking after the scan")
+	}
+}
+
+func TestLinkingReturnsToMenu(t *testing.T) {
+	s, _ := screenstest.Send(shown(t), bridge("qr_pending"), bridge("authenticating"))
+	s, cmd := screenstest.Send(s, bridge("authenticated"))
+	if toast, ok := screenstest.Find[screens.ToastMsg](cmd); !ok || !strings.Contains(toast.Message, "WhatsApp linked in") {
+		t.Errorf("got toast %q after linking", toast.Message)
+	}
+	v := s.View()
+	for _, want := range []string{"✓ ", "connected and ready", "Returning to the menu..."} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+	_, cmd = screenstest.Send(s, DoneMsg{})
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("finishing showed %v, want the menu", show.Screen)
+	}
+}
+
+func TestAlreadyLinkedStays(t *testing.T) {
+	s, cmd := screenstest.Send(shown(t), bridge("authenticated"))
+	if _, ok := screenstest.Find[screens.ToastMsg](cmd); ok {
+		t.Error("opening an already linked bridge announced a link")
+	}
+	if v := s.View(); strings.Contains(v, "Returning to the menu") {
+		t.Errorf("an already linked bridge is leaving the screen:\n%s", v)
+	}
+}
+
+func TestPairingPromptTakesTyping(t *testing.T) {
+	s, _ := screenstest.Send(shown(t), bridge("qr_pending"), screenstest.Key("p"))
+	if !s.CapturingText() {
+		t.Fatal("p didn't open the phone number prompt")
+	}
+	if v := s.View(); !strings.Contains(v, "Link with phone number") || !strings.Contains(v, "15551234567") {
+		t.Errorf("prompt isn't prefilled with the owner:\n%s", v)
+	}
+	_, cmd := screenstest.Send(s, screenstest.Key("q"))
+	if _, ok := screenstest.Find[screens.ShowMsg](cmd); ok {
+		t.Error("typing q in the prompt left the screen")
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	_, cmd := screenstest.Send(shown(t), screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
@@ -0,0 +1,154 @@
+// Package stats is the Statistics screen: message traffic per hour.
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// statsMsg carries per-hour traffic statistics from the bridge
+type statsMsg struct {
+	stats *status.TrafficStats
+	err   error
+}
+
+// fetchStatsCmd fetches traffic statistics from the bridge
+func fetchStatsCmd(client *status.Client) tea.Cmd {
+	return func() tea.Msg {
+		s, err := client.GetStats()
+		return statsMsg{stats: s, err: err}
+	}
+}
+
+// Model draws per-hour message traffic as sparklines, loading it again
+// each time it is opened.
+type Model struct {
+	screens.Room
+	client  *status.Client
+	stats   *status.TrafficStats
+	err     error
+	loading bool
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case screens.ShownMsg:
+		s.loading = true
+		return s, fetchStatsCmd(s.client)
+	case statsMsg:
+		s.loading = false
+		s.err = msg.err
+		if msg.err == nil {
+			s.stats = msg.stats
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return s, screens.Show(screens.Menu)
+		case "r":
+			s.loading = true
+			return s, fetchStatsCmd(s.client)
+		}
+	}
+	return s, nil
+}
+
+func (s Model) HelpKeys() []string {
+	return screens.KeyHelp(screens.Stats, "r", "Esc")
+}
+
+func (s Model) View() string {
+	width, _ := s.Size()
+
+	// Title
+	title := layout.SectionHeader("📊 Message Statistics", width-4)
+
+	var content strings.Builder
+
+	switch {
+	case s.loading && s.stats == nil:
+		content.WriteString(theme.StatusInfo().Render("   Loading statistics from the bridge...") + "\n")
+	case s.stats == nil:
+		content.WriteString(theme.StatusError().Render("   ● Statistics unavailable") + "\n")
+		if s.err != nil {
+			content.WriteString(theme.Subtitle().Render("   "+s.err.Error()) + "\n")
+		}
+		content.WriteString(theme.Subtitle().Render("   Make sure Fetch is running (Start Fetch from menu)") + "\n")
+	case len(s.stats.Hours) == 0:
+		content.WriteString(theme.Subtitle().Render("   No traffic recorded yet.") + "\n")
+	default:
+		hours := s.stats.Hours
+		sparkWidth := max(10, width-44)
+		if len(hours) > sparkWidth {
+			hours = hours[len(hours)-sparkWidth:]
+		}
+		messages := make([]int, len(hours))
+		toolCalls := make([]int, len(hours))
+		errors := make([]int, len(hours))
+		for i, h := range hours {
+			messages[i] = h.Messages
+			toolCalls[i] = h.ToolCalls
+			errors[i] = h.Errors
+		}
+
+		span := fmt.Sprintf("Last %d hours (%s – %s)", len(hours),
+			hours[0].Hour.Local().Format("Jan 2 15:04"),
+			hours[len(hours)-1].Hour.Local().Add(time.Hour).Format("15:04"))
+		content.WriteString("   " + theme.Subtitle().Render(span) + "\n\n")
+
+		row := func(label string, values []int, color lipgloss.TerminalColor) {
+			total, peak, peakIdx := 0, 0, 0
+			for i, v := range values {
+				total += v
+				if v > peak {
+					peak, peakIdx = v, i
+				}
+			}
+			line := fmt.Sprintf("   %s %s  %s", theme.Label().Render(label),
+				components.Sparkline(values, sparkWidth, color),
+				theme.Value().Render(fmt.Sprintf("%d total", total)))
+			if peak > 0 {
+				line += theme.Muted().Render(fmt.Sprintf("  peak %d @ %s", peak, hours[peakIdx].Hour.Local().Format("15:04")))
+			}
+			content.WriteString(line + "\n")
+		}
+		row("Messages", messages, theme.Active().Info)
+		row("Tool calls", toolCalls, theme.Active().Secondary)
+		row("Errors", errors, theme.Active().Error)
+
+		rate := s.stats.ErrorRate()
+		rateStyle := theme.StatusSuccess()
+		switch {
+		case rate >= 0.1:
+			rateStyle = theme.StatusError()
+		case rate >= 0.02:
+			rateStyle = theme.StatusWarning()
+		}
+		content.WriteString(fmt.Sprintf("\n   %s %s\n", theme.Label().Render("Error rate"),
+			rateStyle.Render(fmt.Sprintf("%.1f%%", rate*100))))
+		if s.err != nil {
+			content.WriteString(theme.Subtitle().Render("   Refresh failed: "+s.err.Error()) + "\n")
+		}
+	}
+
+	// Rows that don't fit a narrow terminal are cut rather than wrapped
+	return title + "\n\n" + lipgloss.NewStyle().MaxWidth(width).Render(content.String())
+}
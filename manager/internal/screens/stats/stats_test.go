@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+)
+
+func traffic() *status.TrafficStats {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.Local)
+	return &status.TrafficStats{Hours: []status.HourStats{
+		{Hour: start, Messages: 4, ToolCalls: 2},
+		{Hour: start.Add(time.Hour), Messages: 16, ToolCalls: 9, Errors: 1},
+	}}
+}
+
+func TestViewDrawsTraffic(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{})
+	if v := s.View(); !strings.Contains(v, "Loading statistics") {
+		t.Errorf("view while loading:\n%s", v)
+	}
+	s, _ = screenstest.Send(s, statsMsg{stats: traffic()})
+	v := s.View()
+	for _, want := range []string{"Last 2 hours", "20 total", "peak 16 @ 11:00", "Error rate"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestFailedRefreshKeepsStats(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screenstest.Room, statsMsg{stats: traffic()}, statsMsg{err: errors.New("bridge down")})
+	v := s.View()
+	if !strings.Contains(v, "20 total") || !strings.Contains(v, "Refresh failed: bridge down") {
+		t.Errorf("view after a failed refresh:\n%s", v)
+	}
+
+	s, _ = screenstest.Send(New(nil), screenstest.Room, statsMsg{err: errors.New("bridge down")})
+	if v := s.View(); !strings.Contains(v, "Statistics unavailable") {
+		t.Errorf("view without stats:\n%s", v)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	_, cmd := screenstest.Send(New(nil), screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+	s, cmd := screenstest.Send(New(nil), statsMsg{stats: traffic()}, screenstest.Key("r"))
+	if cmd == nil || !s.loading {
+		t.Error("r didn't reload the stats")
+	}
+}
@@ -0,0 +1,195 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/backup"
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// backupCheckInterval is how often the scheduler looks for a due backup.
+// Due times come from the newest archive on disk, so a manager that was
+// closed over the due time catches up on the next check.
+const backupCheckInterval = time.Hour
+
+// backupMsg reports the archives on disk after a check or a backup.
+type backupMsg struct {
+	backups []backup.Backup // Newest first
+	created *backup.Backup  // Set when a backup was taken
+	pruned  int
+	err     error
+	// From the scheduler rather than the b key or palette
+	scheduled bool
+}
+
+// BackupTickMsg schedules the next backup check
+type BackupTickMsg struct{}
+
+// BackupNowMsg takes a backup regardless of the schedule, for the palette
+// action.
+type BackupNowMsg struct{}
+
+// backups is the scheduler's state and the archives on disk.
+type backups struct {
+	schedule backup.Schedule
+	keep     int
+	list     []backup.Backup // On disk, newest first
+	running  bool
+	err      error // From the last check or backup
+}
+
+// backupCheckCmd lists the archives and takes a backup if the schedule says
+// one is due.
+func backupCheckCmd(schedule backup.Schedule, keep int) tea.Cmd {
+	return func() tea.Msg {
+		backups, err := backup.List()
+		if err != nil {
+			return backupMsg{err: err, scheduled: true}
+		}
+		var last time.Time
+		if len(backups) > 0 {
+			last = backups[0].CreatedAt
+		}
+		if !schedule.Due(last, time.Now()) {
+			return backupMsg{backups: backups, scheduled: true}
+		}
+		msg := takeBackup(keep)
+		msg.scheduled = true
+		return msg
+	}
+}
+
+// backupNowCmd takes a backup regardless of the schedule.
+func backupNowCmd(keep int) tea.Cmd {
+	return func() tea.Msg {
+		return takeBackup(keep)
+	}
+}
+
+// takeBackup creates an archive and applies the retention policy.
+func takeBackup(keep int) backupMsg {
+	created, err := backup.Create()
+	if err != nil {
+		return backupMsg{err: err}
+	}
+	pruned, err := backup.Prune(keep)
+	backups, listErr := backup.List()
+	if err == nil {
+		err = listErr
+	}
+	return backupMsg{backups: backups, created: created, pruned: pruned, err: err}
+}
+
+// backupTickCmd waits for the next scheduled check; nil when backups are off.
+func backupTickCmd(schedule backup.Schedule) tea.Cmd {
+	if schedule == backup.Off {
+		return nil
+	}
+	return tea.Tick(backupCheckInterval, func(time.Time) tea.Msg { return BackupTickMsg{} })
+}
+
+// now takes a backup unless one is running: the palette action and the b
+// key.
+func (b backups) now() (backups, tea.Cmd) {
+	if b.running {
+		return b, nil
+	}
+	b.running = true
+	return b, tea.Batch(screens.Toast("Backing up .env and data/...", components.SeverityInfo), backupNowCmd(b.keep))
+}
+
+// update records the result of a check or backup.
+func (b backups) update(msg backupMsg) (backups, tea.Cmd) {
+	if !msg.scheduled {
+		b.running = false
+	}
+	b.err = msg.err
+	if msg.backups != nil || msg.err == nil {
+		b.list = msg.backups
+	}
+
+	var cmd tea.Cmd
+	switch {
+	case msg.err != nil:
+		cmd = screens.Toast(fmt.Sprintf("Backup failed: %v", msg.err), components.SeverityError)
+	case msg.created != nil:
+		text := "💾 Backed up to backups/" + msg.created.Name()
+		if msg.pruned > 0 {
+			text += fmt.Sprintf(" (removed %d old)", msg.pruned)
+		}
+		cmd = screens.Toast(text, components.SeveritySuccess)
+	}
+	return b, cmd
+}
+
+// view renders the Backups section of the screen.
+func (b backups) view() string {
+	var s strings.Builder
+	s.WriteString("   " + theme.Subtitle().Render("Backups") + "\n")
+
+	schedule := string(b.schedule)
+	if b.schedule != backup.Off {
+		schedule += fmt.Sprintf(", keeping the last %d", b.keep)
+	}
+	s.WriteString("   " + theme.Label().Render("Schedule") + theme.Value().Render(schedule) + "\n")
+
+	switch {
+	case b.running:
+		s.WriteString("   " + theme.Label().Render("Last backup") + theme.StatusInfo().Render("backing up now...") + "\n")
+	case len(b.list) == 0:
+		s.WriteString("   " + theme.Label().Render("Last backup") + theme.Muted().Render("none yet") + "\n")
+	default:
+		last := b.list[0]
+		s.WriteString("   " + theme.Label().Render("Last backup") + theme.Value().Render(fmt.Sprintf("%s (%s, %s)",
+			last.CreatedAt.Format("Jan 2 15:04"), formatAge(time.Since(last.CreatedAt)), formatSize(last.Size))) + "\n")
+		s.WriteString("   " + theme.Label().Render("Stored") + theme.Value().Render(fmt.Sprintf("%d in backups/", len(b.list))) + "\n")
+	}
+	if b.schedule != backup.Off && !b.running {
+		var last time.Time
+		if len(b.list) > 0 {
+			last = b.list[0].CreatedAt
+		}
+		next := "on the next check"
+		if n := b.schedule.Next(last); n.After(time.Now()) {
+			next = n.Format("Mon Jan 2 15:04")
+		}
+		s.WriteString("   " + theme.Label().Render("Next backup") + theme.Value().Render(next) + "\n")
+	}
+	if b.err != nil {
+		s.WriteString("   " + theme.StatusError().Render(theme.Cue("✗", "ERROR")+" "+b.err.Error()) + "\n")
+	}
+	return s.String()
+}
+
+// formatAge renders a duration as a coarse "3h ago".
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+}
+
+// formatSize renders a byte count with a binary unit suffix.
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
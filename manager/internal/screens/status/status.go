@@ -0,0 +1,150 @@
+// Package status is the System Status screen: the diagnostics from
+// internal/doctor and the scheduled backups of .env and data/.
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/backup"
+	"github.com/fetch/manager/internal/doctor"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// doctorMsg carries the results of the system diagnostics
+type doctorMsg struct {
+	checks []doctor.Check
+}
+
+// Model is the System Status screen. Diagnostics run on the first visit;
+// afterwards the last results are kept until r re-runs them. The backup
+// scheduler runs from Init whether or not the screen is shown.
+type Model struct {
+	screens.Room
+	doctor  func() []doctor.Check
+	checks  []doctor.Check
+	running bool // Diagnostics in progress
+	backups backups
+}
+
+// New returns the status screen, running diagnostics with doctor and
+// backing up on schedule, keeping the last keep archives.
+func New(doctor func() []doctor.Check, schedule backup.Schedule, keep int) Model {
+	return Model{doctor: doctor, backups: backups{schedule: schedule, keep: keep}}
+}
+
+// Init starts the backup scheduler.
+func (s Model) Init() tea.Cmd {
+	return backupCheckCmd(s.backups.schedule, s.backups.keep)
+}
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case screens.ShownMsg:
+		if s.running || len(s.checks) > 0 {
+			return s, nil
+		}
+		return s.runDoctor()
+	case doctorMsg:
+		s.running = false
+		s.checks = msg.checks
+	case backupMsg:
+		var cmd tea.Cmd
+		s.backups, cmd = s.backups.update(msg)
+		// Only the scheduler's own checks continue the schedule
+		if msg.scheduled {
+			cmd = tea.Batch(cmd, backupTickCmd(s.backups.schedule))
+		}
+		return s, cmd
+	case BackupTickMsg:
+		return s, backupCheckCmd(s.backups.schedule, s.backups.keep)
+	case BackupNowMsg:
+		var cmd tea.Cmd
+		s.backups, cmd = s.backups.now()
+		return s, cmd
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return s, screens.Show(screens.Menu)
+		case "r":
+			return s.runDoctor()
+		case "b":
+			var cmd tea.Cmd
+			s.backups, cmd = s.backups.now()
+			return s, cmd
+		}
+	}
+	return s, nil
+}
+
+// runDoctor runs the diagnostics in the background, with a fresh container
+// check for the status bar.
+func (s Model) runDoctor() (tea.Model, tea.Cmd) {
+	s.running = true
+	run := s.doctor
+	return s, tea.Batch(screens.Refresh, func() tea.Msg {
+		return doctorMsg{checks: run()}
+	})
+}
+
+func (s Model) HelpKeys() []string {
+	return screens.KeyHelp(screens.Status, "r", "b", "Esc")
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	// Title
+	title := layout.SectionHeader("🩺 System Status", width-4)
+
+	var content strings.Builder
+
+	switch {
+	case s.running && len(s.checks) == 0:
+		content.WriteString(theme.StatusInfo().Render("   Running diagnostics...") + "\n")
+	case len(s.checks) == 0:
+		content.WriteString(theme.Subtitle().Render("   Press 'r' to run diagnostics.") + "\n")
+	default:
+		for _, check := range s.checks {
+			var icon string
+			var style lipgloss.Style
+			switch check.Result {
+			case doctor.Pass:
+				icon, style = theme.Cue("✓", "PASS"), theme.StatusSuccess()
+			case doctor.Warn:
+				icon, style = theme.Cue("!", "WARN"), theme.StatusWarning()
+			case doctor.Skip:
+				icon, style = theme.Cue("·", "SKIP"), theme.Muted()
+			default:
+				icon, style = theme.Cue("✗", "FAIL"), theme.StatusError()
+			}
+			content.WriteString(fmt.Sprintf("   %s %s %s\n",
+				style.Render(icon),
+				theme.Label().Render(check.Name),
+				theme.Value().Render(check.Detail)))
+			if check.Fix != "" {
+				content.WriteString("       " + theme.Subtitle().Render("→ "+check.Fix) + "\n")
+			}
+		}
+
+		pass, warn, fail := doctor.Summary(s.checks)
+		summary := fmt.Sprintf("%d passed, %d warnings, %d failed", pass, warn, fail)
+		if s.running {
+			summary += " (re-checking...)"
+		}
+		content.WriteString("\n   " + theme.Muted().Render(summary) + "\n")
+	}
+	content.WriteString("\n" + s.backups.view())
+
+	// Checks that don't fit are cut rather than wrapped, so the help bar
+	// stays on screen
+	fit := lipgloss.NewStyle().MaxWidth(width).MaxHeight(height)
+	return fit.Render(title + "\n\n" + content.String())
+}
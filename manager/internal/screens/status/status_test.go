@@ -0,0 +1,93 @@
+package status
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/backup"
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/doctor"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+)
+
+func checks() []doctor.Check {
+	return []doctor.Check{
+		{Name: "Docker", Result: doctor.Pass, Detail: "running"},
+		{Name: "Bridge", Result: doctor.Fail, Detail: "unreachable", Fix: "start Fetch"},
+	}
+}
+
+func TestShownRunsDiagnosticsOnce(t *testing.T) {
+	runs := 0
+	s := New(func() []doctor.Check { runs++; return checks() }, backup.Off, 7)
+	s, cmd := screenstest.Send(s, screenstest.Room, screens.ShownMsg{})
+	if v := s.View(); !strings.Contains(v, "Running diagnostics...") {
+		t.Errorf("view while running:\n%s", v)
+	}
+	if _, ok := screenstest.Find[screens.RefreshMsg](cmd); !ok {
+		t.Error("diagnostics didn't refresh the container check")
+	}
+	done, ok := screenstest.Find[doctorMsg](cmd)
+	if !ok {
+		t.Fatal("diagnostics didn't report")
+	}
+	s, _ = screenstest.Send(s, done)
+	v := s.View()
+	for _, want := range []string{"Docker", "unreachable", "→ start Fetch", "1 passed, 0 warnings, 1 failed"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+
+	if _, cmd = screenstest.Send(s, screens.ShownMsg{}); cmd != nil {
+		t.Error("a second visit re-ran the diagnostics")
+	}
+	before := runs
+	_, cmd = screenstest.Send(s, screenstest.Key("r"))
+	if _, ok := screenstest.Find[doctorMsg](cmd); !ok || runs != before+1 {
+		t.Errorf("r ran the diagnostics %d times, want once", runs-before)
+	}
+}
+
+func TestBackupNow(t *testing.T) {
+	screenstest.Project(t, "LOG_LEVEL=info\n")
+	s := New(checks, backup.Daily, 3)
+	s, cmd := screenstest.Send(s, screenstest.Room, screenstest.Key("b"))
+	if v := s.View(); !strings.Contains(v, "backing up now...") {
+		t.Errorf("view while backing up:\n%s", v)
+	}
+	if _, next := screenstest.Send(s, BackupNowMsg{}); next != nil {
+		t.Error("a second backup started while one was running")
+	}
+	done, ok := screenstest.Find[backupMsg](cmd)
+	if !ok || done.err != nil || done.created == nil {
+		t.Fatalf("backup reported %+v", done)
+	}
+	s, cmd = screenstest.Send(s, done)
+	if toast, ok := screenstest.Find[screens.ToastMsg](cmd); !ok || toast.Severity != components.SeveritySuccess {
+		t.Errorf("got toast %+v after the backup", toast)
+	}
+	if v := s.View(); !strings.Contains(v, "1 in backups/") || !strings.Contains(v, "keeping the last 3") {
+		t.Errorf("view after the backup:\n%s", v)
+	}
+}
+
+func TestSchedulerStopsWhenOff(t *testing.T) {
+	screenstest.Project(t, "")
+	s := New(checks, backup.Off, 7)
+	checked, ok := screenstest.Find[backupMsg](s.Init())
+	if !ok || !checked.scheduled || checked.created != nil {
+		t.Fatalf("scheduler check reported %+v", checked)
+	}
+	if _, cmd := screenstest.Send(s, checked); cmd != nil {
+		t.Error("the scheduler kept ticking with backups off")
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	_, cmd := screenstest.Send(New(checks, backup.Off, 7), screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
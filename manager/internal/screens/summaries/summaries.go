@@ -0,0 +1,79 @@
+// Package summaries is the Conversation summaries screen.
+package summaries
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/summaries"
+)
+
+// Model lists the stored conversation summaries, reloading them on each
+// visit and keeping the search from the last one.
+type Model struct {
+	screens.Room
+	client *status.Client
+	viewer *summaries.Viewer
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		return s, nil
+	case screens.ShownMsg:
+		if s.viewer == nil {
+			s.viewer = summaries.NewViewer(s.client)
+		}
+		return s, s.viewer.Init()
+	case tea.KeyMsg:
+		if s.viewer == nil || !s.viewer.IsEditing() {
+			switch msg.String() {
+			case "esc", "q":
+				return s, screens.Show(screens.Menu)
+			}
+		}
+	}
+	if s.viewer == nil {
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.viewer, cmd = s.viewer.Update(msg)
+	return s, cmd
+}
+
+func (s Model) CapturingText() bool {
+	return s.viewer != nil && s.viewer.IsEditing()
+}
+
+func (s Model) HelpKeys() []string {
+	if s.viewer == nil {
+		return screens.KeyHelp(screens.Summaries, "Esc")
+	}
+	return s.viewer.HelpKeys()
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	title := layout.SectionHeader("🧠 Conversation Summaries", width-4)
+
+	var content strings.Builder
+	if s.viewer != nil {
+		s.viewer.SetHeight(height - 4)
+		// Columns that don't fit a narrow terminal are cut rather than wrapped
+		content.WriteString(lipgloss.NewStyle().MaxWidth(width).Render(s.viewer.View(width)))
+	}
+	return title + "\n\n" + content.String()
+}
@@ -0,0 +1,44 @@
+package summaries
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/summaries"
+)
+
+var loaded = summaries.LoadedMsg{Response: &status.SummariesResponse{Summaries: []status.ConversationSummary{
+	{ID: "s1", SessionID: "15551234567", Content: "Planned the trip to Lisbon", CreatedAt: time.Now()},
+}}}
+
+func TestViewListsSummaries(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{}, loaded)
+	v := s.View()
+	for _, want := range []string{"Conversation Summaries", "Planned the trip to Lisbon"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestSearchTakesTyping(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screens.ShownMsg{}, loaded, screenstest.Key("/"))
+	if !s.CapturingText() {
+		t.Fatal("/ didn't open the search")
+	}
+	_, cmd := screenstest.Send(s, screenstest.Key("q"))
+	if _, ok := screenstest.Find[screens.ShowMsg](cmd); ok {
+		t.Error("typing q in the search left the screen")
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	_, cmd := screenstest.Send(New(nil), screens.ShownMsg{}, loaded, screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
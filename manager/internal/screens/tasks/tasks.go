@@ -0,0 +1,106 @@
+// Package tasks is the Tasks screen, the kennel's task queue dashboard.
+package tasks
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/tasks"
+)
+
+// PausedMsg tells the task queue its refresh loop ended because the tabs
+// were closed; the next visit starts it again.
+type PausedMsg struct{}
+
+// Model is the task queue dashboard. The board is created on the first
+// visit and kept, refreshing while any tab is open.
+type Model struct {
+	screens.Room
+	client  *status.Client
+	board   *tasks.Board
+	polling bool // The refresh loop is running
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		return s, nil
+	case screens.ShownMsg:
+		if s.board == nil {
+			s.board = tasks.NewBoard(s.client)
+		} else if s.polling {
+			return s, nil
+		}
+		s.polling = true
+		return s, s.board.Init()
+	case PausedMsg:
+		s.polling = false
+		return s, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return s, screens.Show(screens.Menu)
+		case "a":
+			return s, screens.Show(screens.Approvals)
+		}
+	}
+	if s.board == nil {
+		return s, nil
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if cmd := screens.LinkKey(s.links(), msg); cmd != nil {
+			return s, cmd
+		}
+	}
+	var cmd tea.Cmd
+	s.board, cmd = s.board.Update(msg)
+	return s, cmd
+}
+
+// links returns the numbered pull request links below the task list.
+func (s Model) links() []components.Link {
+	if s.board == nil {
+		return nil
+	}
+	return s.board.Links()
+}
+
+// UsesNumberKeys reports whether pull requests are linked, so the number
+// keys open them rather than switch tabs.
+func (s Model) UsesNumberKeys() bool {
+	return len(s.links()) > 0
+}
+
+func (s Model) HelpKeys() []string {
+	if s.board == nil {
+		return screens.KeyHelp(screens.Tasks, "Esc")
+	}
+	return s.board.HelpKeys()
+}
+
+func (s Model) View() string {
+	width, _ := s.Size()
+
+	// Title
+	title := layout.SectionHeader("📋 Task Queue", width-4)
+
+	var content strings.Builder
+	if s.board != nil {
+		// Columns that don't fit a narrow terminal are cut rather than wrapped
+		content.WriteString(lipgloss.NewStyle().MaxWidth(width).Render(s.board.View(width)))
+	}
+	return title + "\n\n" + content.String()
+}
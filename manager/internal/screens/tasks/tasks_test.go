@@ -0,0 +1,63 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/tasks"
+)
+
+var loaded = tasks.LoadedMsg{Tasks: []status.Task{
+	{ID: "t1", Goal: "Fix the login bug", Agent: "claude", Status: "running", CreatedAt: time.Now()},
+	{ID: "t2", Goal: "Bump dependencies", Agent: "gemini", Status: "completed", CreatedAt: time.Now(),
+		Result: &status.TaskResult{Success: true, Summary: "Opened https://github.com/octocat/hello-world/pull/7"}},
+}}
+
+func TestViewListsTasks(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{}, loaded)
+	v := s.View()
+	for _, want := range []string{"Task Queue", "Fix the login bug", "Bump dependencies"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+	if !s.UsesNumberKeys() {
+		t.Error("the pull request isn't numbered")
+	}
+}
+
+func TestPollsWhileOpen(t *testing.T) {
+	s, cmd := screenstest.Send(New(nil), screens.ShownMsg{})
+	if cmd == nil {
+		t.Fatal("the first visit didn't start polling")
+	}
+	if _, cmd = screenstest.Send(s, screens.ShownMsg{}); cmd != nil {
+		t.Error("a second visit started another refresh loop")
+	}
+	if _, cmd = screenstest.Send(s, PausedMsg{}, screens.ShownMsg{}); cmd == nil {
+		t.Error("the visit after a pause didn't restart polling")
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		key  string
+		want screens.ID
+	}{
+		{"esc", screens.Menu},
+		{"q", screens.Menu},
+		{"a", screens.Approvals},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			_, cmd := screenstest.Send(New(nil), screens.ShownMsg{}, loaded, screenstest.Key(tt.key))
+			if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != tt.want {
+				t.Errorf("%s showed %v, want %v", tt.key, show.Screen, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+// Package updates is the Update Fetch screen: the commits and release
+// notes an update pulls in, the update itself, and a rollback.
+package updates
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+	"github.com/fetch/manager/internal/update"
+)
+
+// updatePreviewMsg carries the commits an update would pull in and the
+// rollback point left by the previous update
+type updatePreviewMsg struct {
+	changes    []update.Change
+	notes      []update.Release
+	checkpoint *update.Checkpoint
+	err        error
+}
+
+// updateEventMsg carries one event from a running update
+type updateEventMsg struct {
+	event update.Event
+}
+
+// PendingMsg tells the root model how many commits are waiting, after a
+// preview or once an update has pulled them in.
+type PendingMsg struct {
+	Commits int
+}
+
+// maxUpdateLines bounds the command output kept for the update screen
+const maxUpdateLines = 200
+
+// fetchUpdatePreviewCmd lists the commits an update would pull in
+func fetchUpdatePreviewCmd() tea.Cmd {
+	return func() tea.Msg {
+		changes, err := update.PendingChanges()
+		checkpoint, _ := update.LoadCheckpoint()
+		// Release notes are a bonus; the commit list is enough without them
+		var notes []update.Release
+		if err == nil && len(changes) > 0 {
+			notes, _ = update.ReleasesSince(update.LocalVersion())
+		}
+		return updatePreviewMsg{changes: changes, notes: notes, checkpoint: checkpoint, err: err}
+	}
+}
+
+// pendingCmd reports the commits waiting to the root model
+func pendingCmd(commits int) tea.Cmd {
+	return func() tea.Msg { return PendingMsg{Commits: commits} }
+}
+
+// waitUpdateEventCmd delivers the next event from a running update
+func waitUpdateEventCmd(events <-chan update.Event) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return updateEventMsg{event: update.Event{Done: true}}
+		}
+		return updateEventMsg{event: ev}
+	}
+}
+
+// Model previews the commits and release notes an update pulls in,
+// then runs the update or a rollback to the point the last one recorded.
+// An update keeps running in the background when the screen is left.
+type Model struct {
+	screens.Room
+	changes  []update.Change // Commits between HEAD and origin/main
+	notes    []update.Release
+	viewport viewport.Model // Changelog and release notes
+	loading  bool           // Fetching the changelog
+	confirm  bool           // Asking before pulling
+	running  bool           // Pull/rebuild in progress
+	finished bool           // Pull/rebuild completed (see err)
+	err      error          // Preview or update failure
+	step     int            // Current index into steps
+	lines    []string       // Tail of command output
+	job      *update.Job    // Running update or rollback
+	steps    []update.Step  // Steps of the current or last job
+	rollback bool           // job is a rollback
+	// Rollback point recorded before the last update
+	checkpoint      *update.Checkpoint
+	confirmRollback bool
+}
+
+func New() Model {
+	return Model{viewport: viewport.New(74, 8)}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		s.layout()
+	case screens.ShownMsg:
+		// Preview again on each visit, unless an update is running
+		if s.running {
+			return s, nil
+		}
+		s.loading = true
+		s.finished = false
+		s.rollback = false
+		s.confirm = false
+		s.err = nil
+		s.lines = nil
+		return s, fetchUpdatePreviewCmd()
+	case updatePreviewMsg:
+		s.loading = false
+		s.changes = msg.changes
+		s.notes = msg.notes
+		s.checkpoint = msg.checkpoint
+		s.err = msg.err
+		s.layout()
+		if msg.err == nil {
+			return s, pendingCmd(len(msg.changes))
+		}
+	case updateEventMsg:
+		ev := msg.event
+		s.step = ev.Step
+		if ev.Line != "" {
+			s.lines = append(s.lines, ev.Line)
+			if len(s.lines) > maxUpdateLines {
+				s.lines = s.lines[len(s.lines)-maxUpdateLines:]
+			}
+		}
+		if ev.Done {
+			s.running = false
+			s.finished = true
+			s.err = ev.Err
+			s.job = nil
+			if ev.Err == nil && !s.rollback {
+				return s, pendingCmd(0)
+			}
+			return s, nil
+		}
+		return s, waitUpdateEventCmd(s.job.Events)
+	case tea.MouseMsg:
+		// Scroll the changelog
+		if s.pending() {
+			var cmd tea.Cmd
+			s.viewport, cmd = s.viewport.Update(msg)
+			return s, cmd
+		}
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if s.confirm {
+		switch msg.String() {
+		case "y":
+			s.confirm = false
+			job, err := update.Start()
+			if err != nil {
+				s.err = err
+				return s, nil
+			}
+			s.rollback = false
+			s.running = true
+			s.step = 0
+			s.lines = nil
+			s.job = job
+			s.steps = job.Steps
+			return s, waitUpdateEventCmd(job.Events)
+		case "n", "esc":
+			s.confirm = false
+		}
+		return s, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		// The update keeps running in the background; its events are
+		// still handled when returning to this screen
+		return s, screens.Show(screens.Menu)
+	}
+
+	if s.confirmRollback {
+		switch msg.String() {
+		case "y", "enter":
+			s.confirmRollback = false
+			s.rollback = true
+			s.running = true
+			s.finished = false
+			s.err = nil
+			s.step = 0
+			s.lines = nil
+			s.job = update.StartRollback(s.checkpoint)
+			s.steps = s.job.Steps
+			return s, waitUpdateEventCmd(s.job.Events)
+		case "n":
+			s.confirmRollback = false
+		}
+		return s, nil
+	}
+
+	switch msg.String() {
+	case "enter":
+		if !s.pending() {
+			return s, nil
+		}
+		s.confirm = true
+		return s, nil
+	case "b":
+		if s.checkpoint != nil && !s.running && !s.loading {
+			s.confirmRollback = true
+		}
+		return s, nil
+	case "r":
+		if !s.running {
+			s.loading = true
+			s.finished = false
+			s.err = nil
+			return s, fetchUpdatePreviewCmd()
+		}
+	}
+
+	// Scroll the changelog
+	if s.pending() {
+		var cmd tea.Cmd
+		s.viewport, cmd = s.viewport.Update(msg)
+		return s, cmd
+	}
+	return s, nil
+}
+
+// pending reports whether the changelog is showing and an update can be
+// started from it.
+func (s Model) pending() bool {
+	return !s.running && !s.loading && !s.finished && s.err == nil && len(s.changes) > 0
+}
+
+// layout sizes the changelog viewport to the room and fills it with the
+// pending commits and release notes.
+func (s *Model) layout() {
+	width, height := s.Size()
+	s.viewport.Width = width - 6
+	s.viewport.Height = max(3, height-14)
+
+	var b strings.Builder
+	b.WriteString(theme.Label().Render("Commits") + "\n")
+	for _, c := range s.changes {
+		b.WriteString(fmt.Sprintf("%s %s\n", theme.Muted().Render(c.Hash), theme.Value().Render(layout.Truncate(c.Subject, width-16))))
+	}
+	wrap := lipgloss.NewStyle().Width(width - 8)
+	for _, rel := range s.notes {
+		heading := "Release " + rel.Version
+		if rel.Name != "" && rel.Name != rel.Version {
+			heading += " — " + rel.Name
+		}
+		b.WriteString("\n" + theme.Label().Render(heading) + "\n")
+		notes := strings.TrimSpace(strings.ReplaceAll(rel.Notes, "\r", ""))
+		if notes == "" {
+			notes = "No release notes."
+		}
+		b.WriteString(theme.Muted().Render(wrap.Render(notes)) + "\n")
+	}
+	s.viewport.SetContent(strings.TrimSuffix(b.String(), "\n"))
+	s.viewport.GotoTop()
+}
+
+func (s Model) HelpKeys() []string {
+	var keys []string
+	switch {
+	case s.loading:
+		keys = screens.KeyHelp(screens.Update, "Esc")
+	case s.running || s.finished:
+		keys = screens.KeyHelp(screens.Update, "Esc")
+		if s.finished && s.checkpoint != nil && !s.rollback {
+			keys = screens.KeyHelp(screens.Update, "b", "Esc")
+		}
+	case s.err != nil || len(s.changes) == 0:
+		keys = screens.KeyHelp(screens.Update, "r", "Esc")
+	case s.confirm:
+		keys = screens.KeyHelp(screens.Update, "y", "n")
+	default:
+		keys = screens.KeyHelp(screens.Update, "↑/↓", "Enter", "r", "Esc")
+	}
+
+	// Rollback point from the previous update
+	if s.checkpoint != nil && !s.running && !s.rollback && !s.confirm {
+		if s.confirmRollback {
+			return screens.KeyHelp(screens.Update, "y", "n")
+		}
+		if rollback := screens.KeyHelp(screens.Update, "b"); !slices.Contains(keys, rollback[0]) {
+			keys = append(rollback, keys...)
+		}
+	}
+	return keys
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	title := layout.SectionHeader("⬆️  Update Fetch", width-4)
+
+	var content strings.Builder
+
+	switch {
+	case s.loading:
+		content.WriteString(theme.StatusInfo().Render("   Checking origin/main for changes...") + "\n")
+
+	case s.running || s.finished:
+		// Step list with progress markers
+		steps := s.steps
+		for i, step := range steps {
+			var marker string
+			switch {
+			case s.finished && s.err != nil && i == s.step:
+				marker = theme.StatusError().Render("✗")
+			case i < s.step || s.finished && s.err == nil:
+				marker = theme.StatusSuccess().Render("✓")
+			case i == s.step:
+				marker = theme.StatusInfo().Render("▸")
+			default:
+				marker = theme.Muted().Render("·")
+			}
+			content.WriteString(fmt.Sprintf("   %s %s\n", marker, theme.Label().Render(fmt.Sprintf("Step %d/%d: %s", i+1, len(steps), step.Name))))
+		}
+		content.WriteString("\n")
+
+		// Tail of the command output, sized to the remaining space
+		tail := max(3, height-len(steps)-10)
+		lines := s.lines
+		if len(lines) > tail {
+			lines = lines[len(lines)-tail:]
+		}
+		for _, line := range lines {
+			content.WriteString("   " + theme.Muted().Render(layout.Truncate(line, width-6)) + "\n")
+		}
+
+		if s.finished {
+			content.WriteString("\n")
+			switch {
+			case s.err != nil && s.rollback:
+				content.WriteString(theme.StatusError().Render("   ✗ Rollback failed: "+s.err.Error()) + "\n")
+			case s.err != nil:
+				content.WriteString(theme.StatusError().Render("   ✗ Update failed: "+s.err.Error()) + "\n")
+				if s.checkpoint != nil {
+					content.WriteString(theme.Subtitle().Render("   Press 'b' to roll back to "+s.checkpoint.ShortCommit()) + "\n")
+				}
+			case s.rollback:
+				content.WriteString(theme.StatusSuccess().Render("   ✓ Rolled back to "+s.checkpoint.ShortCommit()+". Stop and Start Fetch to run it.") + "\n")
+			default:
+				content.WriteString(theme.StatusSuccess().Render("   ✓ Update complete. Stop and Start Fetch to run the new version.") + "\n")
+				content.WriteString(theme.Subtitle().Render("   If something breaks, come back here and press 'b' to roll back.") + "\n")
+			}
+		}
+
+	case s.err != nil:
+		content.WriteString(theme.StatusError().Render("   ✗ "+s.err.Error()) + "\n")
+
+	case len(s.changes) == 0:
+		content.WriteString(theme.StatusSuccess().Render("   ✓ Fetch is up to date with origin/main.") + "\n")
+
+	default:
+		summary := fmt.Sprintf("   %d new commit(s) on origin/main", len(s.changes))
+		if len(s.notes) > 0 {
+			summary += fmt.Sprintf(", %d release(s)", len(s.notes))
+		}
+		content.WriteString(theme.Subtitle().Render(summary+":") + "\n\n")
+		vp := s.viewport
+		content.WriteString(lipgloss.NewStyle().PaddingLeft(3).Render(vp.View()) + "\n")
+		if !vp.AtTop() || !vp.AtBottom() {
+			content.WriteString(theme.Muted().Render(fmt.Sprintf("   %3.f%% ↑/↓ to scroll", vp.ScrollPercent()*100)) + "\n")
+		}
+		content.WriteString("\n")
+		if s.confirm {
+			content.WriteString(theme.StatusWarning().Render(fmt.Sprintf("   Pull %d commit(s) and rebuild the containers? [y/n]", len(s.changes))) + "\n")
+		} else {
+			content.WriteString(theme.StatusInfo().Render("   Press Enter to pull and rebuild.") + "\n")
+		}
+	}
+
+	// Rollback point from the previous update
+	if cp := s.checkpoint; cp != nil && !s.running && !s.rollback && !s.confirm {
+		content.WriteString("\n")
+		if s.confirmRollback {
+			content.WriteString(theme.StatusWarning().Render(fmt.Sprintf("   Roll back to %s (%s)? This checks out the old code and restores its images. [y/n]",
+				cp.ShortCommit(), layout.Truncate(cp.Subject, 40))) + "\n")
+		} else {
+			content.WriteString(theme.Muted().Render(fmt.Sprintf("   Rollback point: %s %s (recorded %s)",
+				cp.ShortCommit(), layout.Truncate(cp.Subject, 40), cp.RecordedAt.Local().Format("Jan 2 15:04"))) + "\n")
+		}
+	}
+
+	// Lines that don't fit a narrow terminal are cut rather than wrapped
+	return title + "\n\n" + lipgloss.NewStyle().MaxWidth(width).Render(content.String())
+}
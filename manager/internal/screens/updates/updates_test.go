@@ -0,0 +1,118 @@
+package updates
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/update"
+)
+
+var preview = updatePreviewMsg{
+	changes: []update.Change{{Hash: "abc1234", Subject: "Add task board"}, {Hash: "def5678", Subject: "Fix login"}},
+	notes:   []update.Release{{Version: "v1.4.0", Notes: "Faster startup."}},
+}
+
+func previewed(t *testing.T, msg updatePreviewMsg) (Model, PendingMsg) {
+	t.Helper()
+	s, _ := screenstest.Send(New(), screenstest.Room, screens.ShownMsg{})
+	if v := s.View(); !strings.Contains(v, "Checking origin/main") {
+		t.Errorf("view while checking:\n%s", v)
+	}
+	s, cmd := screenstest.Send(s, msg)
+	pending, _ := screenstest.Find[PendingMsg](cmd)
+	return s, pending
+}
+
+func TestPreviewListsChanges(t *testing.T) {
+	s, pending := previewed(t, preview)
+	if pending.Commits != 2 {
+		t.Errorf("told the root %d commits are pending, want 2", pending.Commits)
+	}
+	v := s.View()
+	for _, want := range []string{"2 new commit(s) on origin/main, 1 release(s)", "abc1234", "Fix login", "Release v1.4.0", "Press Enter"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestEnterAsksFirst(t *testing.T) {
+	s, _ := previewed(t, preview)
+	s, _ = screenstest.Send(s, screenstest.Key("enter"))
+	if v := s.View(); !strings.Contains(v, "Pull 2 commit(s) and rebuild the containers? [y/n]") {
+		t.Errorf("Enter didn't ask first:\n%s", v)
+	}
+	s, _ = screenstest.Send(s, screenstest.Key("n"))
+	if s.confirm || s.running {
+		t.Error("n didn't cancel the update")
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	s, pending := previewed(t, updatePreviewMsg{})
+	if pending.Commits != 0 {
+		t.Errorf("told the root %d commits are pending, want 0", pending.Commits)
+	}
+	if v := s.View(); !strings.Contains(v, "up to date") {
+		t.Errorf("view:\n%s", v)
+	}
+	if s, _ = screenstest.Send(s, screenstest.Key("enter")); s.confirm {
+		t.Error("Enter offered an update with nothing to pull")
+	}
+}
+
+func TestRunningUpdateReportsSteps(t *testing.T) {
+	s, _ := previewed(t, preview)
+	events := make(chan update.Event)
+	s.running = true
+	s.job = &update.Job{Steps: []update.Step{{Name: "Pulling"}, {Name: "Rebuilding"}}, Events: events}
+	s.steps = s.job.Steps
+
+	s, cmd := screenstest.Send(s, updateEventMsg{event: update.Event{Step: 1, Line: "Building fetch-bridge"}})
+	if cmd == nil {
+		t.Fatal("the screen stopped listening to the update")
+	}
+	v := s.View()
+	for _, want := range []string{"Step 1/2: Pulling", "▸", "Building fetch-bridge"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+	if _, cmd := screenstest.Send(s, screens.ShownMsg{}); cmd != nil {
+		t.Error("coming back during the update checked for changes again")
+	}
+
+	s, cmd = screenstest.Send(s, updateEventMsg{event: update.Event{Step: 1, Done: true}})
+	if pending, ok := screenstest.Find[PendingMsg](cmd); !ok || pending.Commits != 0 {
+		t.Errorf("finishing reported %+v, want nothing pending", pending)
+	}
+	if v := s.View(); !strings.Contains(v, "Update complete") {
+		t.Errorf("view after the update:\n%s", v)
+	}
+}
+
+func TestRollbackAsksFirst(t *testing.T) {
+	msg := updatePreviewMsg{checkpoint: &update.Checkpoint{Commit: "0123456789abcdef", Subject: "Before the update", RecordedAt: time.Now()}}
+	s, _ := previewed(t, msg)
+	if v := s.View(); !strings.Contains(v, "Rollback point: 0123456") {
+		t.Errorf("view is missing the rollback point:\n%s", v)
+	}
+	s, _ = screenstest.Send(s, screenstest.Key("b"))
+	if v := s.View(); !strings.Contains(v, "Roll back to 0123456") {
+		t.Errorf("b didn't ask first:\n%s", v)
+	}
+	if s, _ = screenstest.Send(s, screenstest.Key("n")); s.confirmRollback || s.running {
+		t.Error("n didn't cancel the rollback")
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	s, _ := previewed(t, preview)
+	_, cmd := screenstest.Send(s, screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
@@ -0,0 +1,377 @@
+// Package version is the Version screen: the build of each part of Fetch
+// and the latest manager release, which it can install.
+package version
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+	"github.com/fetch/manager/internal/update"
+)
+
+// repoURL is the Fetch repository, linked from the screen
+const repoURL = "https://github.com/Traves-Theberge/Fetch"
+
+// ReleaseMsg carries the latest manager release from the root model's
+// background update check, so the menu and status bar can flag it.
+type ReleaseMsg struct {
+	Release *update.Release
+}
+
+// releaseCheckMsg carries the latest manager release from GitHub
+type releaseCheckMsg struct {
+	release *update.Release
+	err     error
+}
+
+// checkReleaseCmd looks up the latest manager release
+func checkReleaseCmd() tea.Cmd {
+	return func() tea.Msg {
+		rel, err := update.LatestRelease()
+		return releaseCheckMsg{release: rel, err: err}
+	}
+}
+
+// managerUpdateMsg carries the result of installing a manager release
+type managerUpdateMsg struct {
+	version string
+	err     error
+}
+
+// installReleaseCmd downloads, verifies, and swaps in a manager release
+func installReleaseCmd(rel *update.Release) tea.Cmd {
+	return func() tea.Msg {
+		return managerUpdateMsg{version: rel.Version, err: update.InstallRelease(rel)}
+	}
+}
+
+// componentVersions is what each part of Fetch reports about its build.
+// Commits are "" where unknown: a stopped container, an image built
+// without FETCH_COMMIT, or a bridge that predates /api/version.
+type componentVersions struct {
+	loaded         bool
+	checkout       string // Commit checked out in the project directory
+	bridgeVersion  string // From the bridge's package.json
+	bridgeCommit   string
+	bridgeNode     string
+	bridgeRunning  bool
+	kennelCommit   string
+	kennelRunning  bool
+	managerVersion string
+	managerCommit  string
+}
+
+// componentVersionsMsg carries the versions read for the Version screen
+type componentVersionsMsg struct {
+	versions componentVersions
+}
+
+// fetchComponentVersionsCmd asks the bridge API, the image labels, and the
+// checkout which commit each component was built from.
+func fetchComponentVersionsCmd(client *status.Client, info components.VersionInfo) tea.Cmd {
+	return func() tea.Msg {
+		v := componentVersions{
+			loaded:         true,
+			managerVersion: info.Version,
+			managerCommit:  knownCommit(info.GitCommit),
+			bridgeRunning:  docker.IsContainerRunning("fetch-bridge"),
+			kennelRunning:  docker.IsContainerRunning("fetch-kennel"),
+		}
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			v.checkout, _ = update.HeadCommit()
+		}()
+		go func() {
+			defer wg.Done()
+			if !v.bridgeRunning {
+				return
+			}
+			if bv, err := client.GetVersion(); err == nil {
+				if bv.Version != nil {
+					v.bridgeVersion = *bv.Version
+				}
+				if bv.Commit != nil {
+					v.bridgeCommit = *bv.Commit
+				}
+				v.bridgeNode = bv.Node
+			}
+			if v.bridgeCommit == "" {
+				v.bridgeCommit, _ = docker.ImageRevision("fetch-bridge")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if v.kennelRunning {
+				v.kennelCommit, _ = docker.ImageRevision("fetch-kennel")
+			}
+		}()
+		wg.Wait()
+		return componentVersionsMsg{versions: v}
+	}
+}
+
+// knownCommit maps the ldflags placeholder to ""
+func knownCommit(commit string) string {
+	if commit == "unknown" {
+		return ""
+	}
+	return commit
+}
+
+// sameCommit compares commits abbreviated to different lengths
+func sameCommit(a, b string) bool {
+	n := min(len(a), len(b))
+	return n > 0 && a[:n] == b[:n]
+}
+
+// shortCommit abbreviates a commit for display, or shows a dash if unknown
+func shortCommit(commit string) string {
+	switch {
+	case commit == "":
+		return "—"
+	case len(commit) > 7:
+		return commit[:7]
+	}
+	return commit
+}
+
+// skew lists the components built from a different commit than the one
+// checked out, or than the bridge when the checkout is unknown. Components
+// with no known commit can't be compared and are left out.
+func (v componentVersions) skew() (reference string, stale []string) {
+	reference = v.checkout
+	if reference == "" {
+		reference = v.bridgeCommit
+	}
+	if reference == "" {
+		return "", nil
+	}
+	for _, c := range []struct{ name, commit string }{
+		{"Manager", v.managerCommit},
+		{"Bridge", v.bridgeCommit},
+		{"Kennel", v.kennelCommit},
+	} {
+		if c.commit != "" && !sameCommit(c.commit, reference) {
+			stale = append(stale, c.name)
+		}
+	}
+	return reference, stale
+}
+
+// Model shows the build of each component and the latest manager
+// release, and installs that release.
+type Model struct {
+	screens.Room
+	client     *status.Client
+	info       components.VersionInfo
+	release    *update.Release // Latest release, if checked
+	checking   bool
+	installing bool
+	versions   componentVersions
+}
+
+func New(client *status.Client, info components.VersionInfo) Model {
+	return Model{client: client, info: info}
+}
+
+// NewRelease returns the latest release when it is newer than the running
+// manager, or nil.
+func (s Model) NewRelease() *update.Release {
+	if s.release != nil && s.release.Newer(s.info.Version) {
+		return s.release
+	}
+	return nil
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case screens.ShownMsg:
+		// Versions are read again on each visit; an update may have run
+		// in between
+		s.versions = componentVersions{}
+		return s, fetchComponentVersionsCmd(s.client, s.info)
+	case componentVersionsMsg:
+		s.versions = msg.versions
+	case releaseCheckMsg:
+		s.checking = false
+		if msg.err != nil {
+			return s, screens.Toast(fmt.Sprintf("Update check failed: %v", msg.err), components.SeverityError)
+		}
+		s.release = msg.release
+	case ReleaseMsg:
+		if msg.Release != nil {
+			s.release = msg.Release
+		}
+	case managerUpdateMsg:
+		s.installing = false
+		if msg.err != nil {
+			return s, screens.Toast(fmt.Sprintf("Manager update failed: %v", msg.err), components.SeverityError)
+		}
+		return s, screens.Toast(fmt.Sprintf("Manager updated to %s. Restart the manager to use it.", msg.version), components.SeveritySuccess)
+	case tea.KeyMsg:
+		return s.updateKeys(msg)
+	}
+	return s, nil
+}
+
+func (s Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		return s, screens.Show(screens.Menu)
+	case "c":
+		if !s.checking && !s.installing {
+			s.checking = true
+			return s, checkReleaseCmd()
+		}
+		return s, nil
+	case "r":
+		s.versions = componentVersions{}
+		return s, fetchComponentVersionsCmd(s.client, s.info)
+	case "u":
+		if rel := s.NewRelease(); rel != nil && !s.installing {
+			s.installing = true
+			return s, installReleaseCmd(rel)
+		}
+		return s, nil
+	}
+	return s, screens.LinkKey(s.links(), msg)
+}
+
+// links returns the numbered links below the info panel.
+func (s Model) links() []components.Link {
+	links := []components.Link{
+		{Label: "Repository", URL: repoURL},
+		{Label: "Documentation", URL: s.client.DocsURL()},
+	}
+	if s.release != nil && s.release.URL != "" {
+		links = append(links, components.Link{Label: "Release notes " + s.release.Version, URL: s.release.URL})
+	}
+	return links
+}
+
+func (s Model) HelpKeys() []string {
+	helpKeys := screens.KeyHelp(screens.Version, "r", "c")
+	if s.NewRelease() != nil {
+		helpKeys = append(helpKeys, screens.KeyHelp(screens.Version, "u")...)
+	}
+	helpKeys = append(helpKeys, components.LinkHelp(len(s.links()))...)
+	return append(helpKeys, screens.KeyHelp(screens.Version, "Esc")...)
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	// Component builds, manager release status, and the numbered links
+	details := s.renderComponentVersions() + "\n" + s.renderReleaseStatus() + "\n" + components.LinkList(s.links())
+
+	// The info panel is dropped when the terminal is too short for both;
+	// everything it says besides the Go version is in the table
+	info := components.Version(s.info, width)
+	if lipgloss.Height(info)+1+lipgloss.Height(details) <= height {
+		details = info + "\n\n" + details
+	}
+	// Columns that don't fit a narrow terminal are cut rather than wrapped
+	return lipgloss.NewStyle().MaxWidth(width).Render(details)
+}
+
+// renderComponentVersions lists the version and commit of each component
+// beside the checkout, flagging components built from a different commit:
+// a partial update that rebuilt one image but not the other, or a manager
+// binary left behind by a pull.
+func (s Model) renderComponentVersions() string {
+	v := s.versions
+	if !v.loaded {
+		return theme.StatusInfo().Render("   Reading component versions...") + "\n"
+	}
+
+	label := lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Width(10)
+	cell := lipgloss.NewStyle().Foreground(theme.Active().TextPrimary).Width(12)
+	muted := theme.Muted()
+	reference, stale := v.skew()
+
+	row := func(name, version, commit, note string, running bool) string {
+		line := "   " + label.Render(name) + cell.Render(version)
+		if !running {
+			return line + muted.Render("not running")
+		}
+		commitStyle := cell
+		if commit != "" && reference != "" && !sameCommit(commit, reference) {
+			commitStyle = commitStyle.Foreground(theme.Active().Warning)
+		}
+		line += commitStyle.Render(shortCommit(commit))
+		if note != "" {
+			line += muted.Render(note)
+		}
+		return line
+	}
+
+	bridgeVersion := v.bridgeVersion
+	if bridgeVersion == "" {
+		bridgeVersion = "—"
+	}
+	bridgeNote := ""
+	if v.bridgeNode != "" {
+		bridgeNote = "Node " + v.bridgeNode
+	}
+	lines := []string{
+		"   " + label.Render("") + muted.Width(12).Render("Version") + muted.Render("Commit"),
+		row("Manager", v.managerVersion, v.managerCommit, "", true),
+		row("Bridge", bridgeVersion, v.bridgeCommit, bridgeNote, v.bridgeRunning),
+		row("Kennel", "—", v.kennelCommit, "", v.kennelRunning),
+		row("Checkout", "", v.checkout, "", true),
+	}
+
+	switch {
+	case len(stale) > 0:
+		verb := "was"
+		if len(stale) > 1 {
+			verb = "were"
+		}
+		lines = append(lines, theme.StatusWarning().Render(fmt.Sprintf(
+			"   %s %s %s built from a different commit than %s",
+			theme.Cue("⚠", "Warning:"), strings.Join(stale, " and "), verb, shortCommit(reference))))
+		if slices.ContainsFunc(stale, func(name string) bool { return name != "Manager" }) {
+			lines = append(lines, muted.Render("     Run Update Fetch to rebuild the containers."))
+		}
+		if slices.Contains(stale, "Manager") {
+			lines = append(lines, muted.Render("     Rebuild the manager with manager/build.sh, or press 'c' for a release."))
+		}
+	case reference != "":
+		lines = append(lines, theme.StatusSuccess().Render(fmt.Sprintf(
+			"   %s Components match %s", theme.Cue("✓", "OK:"), shortCommit(reference))))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// renderReleaseStatus describes the latest manager release relative to
+// the running version
+func (s Model) renderReleaseStatus() string {
+	switch {
+	case s.installing:
+		return theme.StatusInfo().Render(fmt.Sprintf("   Downloading and verifying %s...", s.release.Version)) + "\n"
+	case s.checking:
+		return theme.StatusInfo().Render("   Checking GitHub for manager releases...") + "\n"
+	case s.release == nil:
+		return theme.Subtitle().Render("   Press 'c' to check for manager updates.") + "\n"
+	case s.release.Newer(s.info.Version):
+		return theme.StatusWarning().Render(fmt.Sprintf("   ● Manager %s available (running %s) — press 'u' to install", s.release.Version, s.info.Version)) + "\n"
+	default:
+		return theme.StatusSuccess().Render(fmt.Sprintf("   ✓ Manager is up to date (latest release %s)", s.release.Version)) + "\n"
+	}
+}
@@ -0,0 +1,95 @@
+package version
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/update"
+)
+
+var info = components.VersionInfo{Version: "v1.2.0", GitCommit: "abc1234"}
+
+func screen() Model {
+	s, _ := screenstest.Send(New(status.NewClient("http://localhost:8765", ""), info), screenstest.Room)
+	return s
+}
+
+func TestViewFlagsSkew(t *testing.T) {
+	s, _ := screenstest.Send(screen(), componentVersionsMsg{versions: componentVersions{
+		loaded:         true,
+		checkout:       "abc1234def",
+		managerVersion: "v1.2.0",
+		managerCommit:  "abc1234",
+		bridgeRunning:  true,
+		bridgeVersion:  "1.2.0",
+		bridgeCommit:   "9999999",
+	}})
+	v := s.View()
+	for _, want := range []string{"Bridge was built from a different commit than abc1234", "Run Update Fetch", "not running"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+	if strings.Contains(v, "manager/build.sh") {
+		t.Errorf("view flags the manager, which matches the checkout:\n%s", v)
+	}
+}
+
+func TestReleaseStatus(t *testing.T) {
+	s := screen()
+	if v := s.View(); !strings.Contains(v, "Press 'c' to check") {
+		t.Errorf("view before a check:\n%s", v)
+	}
+	if s.NewRelease() != nil {
+		t.Error("a release is offered before any check")
+	}
+
+	s, _ = screenstest.Send(s, ReleaseMsg{Release: &update.Release{Version: "v1.3.0"}})
+	if s.NewRelease() == nil {
+		t.Fatal("v1.3.0 isn't offered over v1.2.0")
+	}
+	if v := s.View(); !strings.Contains(v, "Manager v1.3.0 available (running v1.2.0)") {
+		t.Errorf("view with a newer release:\n%s", v)
+	}
+
+	s, _ = screenstest.Send(s, ReleaseMsg{Release: &update.Release{Version: "v1.2.0"}})
+	if s.NewRelease() != nil {
+		t.Error("the running release is offered as an update")
+	}
+	if v := s.View(); !strings.Contains(v, "up to date") {
+		t.Errorf("view with the running release:\n%s", v)
+	}
+}
+
+func TestFailedCheckToasts(t *testing.T) {
+	s, _ := screenstest.Send(screen(), screenstest.Key("c"))
+	if v := s.View(); !strings.Contains(v, "Checking GitHub") {
+		t.Errorf("view while checking:\n%s", v)
+	}
+	_, cmd := screenstest.Send(s, releaseCheckMsg{err: errors.New("rate limited")})
+	if toast, ok := screenstest.Find[screens.ToastMsg](cmd); !ok || !strings.Contains(toast.Message, "rate limited") {
+		t.Errorf("got toast %q for a failed check", toast.Message)
+	}
+}
+
+func TestInstallNeedsNewerRelease(t *testing.T) {
+	if _, cmd := screenstest.Send(screen(), screenstest.Key("u")); cmd != nil {
+		t.Error("u installed without a newer release")
+	}
+	_, cmd := screenstest.Send(screen(), managerUpdateMsg{version: "v1.3.0"})
+	if toast, ok := screenstest.Find[screens.ToastMsg](cmd); !ok || toast.Severity != components.SeveritySuccess {
+		t.Errorf("got toast %+v after installing", toast)
+	}
+}
+
+func TestEscReturnsToMenu(t *testing.T) {
+	_, cmd := screenstest.Send(screen(), screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
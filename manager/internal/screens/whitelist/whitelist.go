@@ -0,0 +1,79 @@
+// Package whitelist is the trusted numbers screen: who may talk to Fetch,
+// and in what role.
+package whitelist
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+)
+
+// Model is the trusted numbers screen. The manager is created on the first
+// visit and reloaded on later ones, keeping the cursor and any edit in
+// progress.
+type Model struct {
+	screens.Room
+	client  *status.Client
+	manager *config.WhitelistManager
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+// CapturingText is true while a number or expiry is being typed.
+func (s Model) CapturingText() bool {
+	return s.manager != nil && s.manager.IsEditing()
+}
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+	case screens.ShownMsg:
+		if s.manager != nil {
+			return s, s.manager.Refresh()
+		}
+		s.manager = config.NewWhitelistManager(s.client)
+		return s, s.manager.Init()
+	case config.WhitelistLoadedMsg:
+		if s.manager != nil {
+			return s, s.manager.Update(msg)
+		}
+	case tea.KeyMsg:
+		if s.manager == nil {
+			return s, nil
+		}
+		// Only leave when not editing a field
+		if !s.manager.IsEditing() {
+			switch msg.String() {
+			case "esc", "q":
+				return s, screens.Show(screens.Menu)
+			case "g":
+				return s, screens.Show(screens.Groups)
+			}
+		}
+		return s, s.manager.Update(msg)
+	}
+	return s, nil
+}
+
+func (s Model) HelpKeys() []string {
+	return screens.KeyHelp(screens.Whitelist, "↑/↓", "a", "d", "r", "Esc")
+}
+
+func (s Model) View() string {
+	width, _ := s.Size()
+	title := layout.SectionHeader("🔐 Trusted Numbers", width-4)
+	if s.manager == nil {
+		return title + "\n\n"
+	}
+	s.manager.SetWidth(width)
+	return title + "\n\n" + lipgloss.NewStyle().MaxWidth(width).Render(s.manager.View())
+}
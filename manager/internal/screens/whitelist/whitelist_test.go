@@ -0,0 +1,59 @@
+package whitelist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+)
+
+func loaded(t *testing.T) Model {
+	t.Helper()
+	screenstest.Project(t, "")
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{}, config.WhitelistLoadedMsg{
+		Whitelist: &status.WhitelistResponse{TrustedNumbers: []string{"15551234567", "442079460958"}},
+	})
+	return s
+}
+
+func TestViewListsNumbers(t *testing.T) {
+	v := loaded(t).View()
+	for _, want := range []string{"Trusted Numbers", "+15551234567", "+44 2079 460958"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestKeysLeave(t *testing.T) {
+	tests := []struct {
+		key  string
+		want screens.ID
+	}{
+		{"esc", screens.Menu},
+		{"q", screens.Menu},
+		{"g", screens.Groups},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			_, cmd := screenstest.Send(loaded(t), screenstest.Key(tt.key))
+			if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != tt.want {
+				t.Errorf("%s showed %v, want %v", tt.key, show.Screen, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypingStaysOnScreen(t *testing.T) {
+	s, _ := screenstest.Send(loaded(t), screenstest.Key("a"))
+	if !s.CapturingText() {
+		t.Fatal("a didn't open the number field")
+	}
+	_, cmd := screenstest.Send(s, screenstest.Key("q"))
+	if _, ok := screenstest.Find[screens.ShowMsg](cmd); ok {
+		t.Error("typing q left the screen")
+	}
+}
@@ -0,0 +1,96 @@
+// Package workspaces is the Workspaces screen, a browser for the kennel's
+// workspaces.
+package workspaces
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/workspaces"
+)
+
+// workspaceDeleteMsg is the confirmed deletion of a kennel workspace.
+type workspaceDeleteMsg struct {
+	id string
+}
+
+// Model browses the kennel workspaces, listing them again on each visit and
+// keeping the selection from the last one.
+type Model struct {
+	screens.Room
+	client  *status.Client
+	browser *workspaces.Browser
+}
+
+func New(client *status.Client) Model {
+	return Model{client: client}
+}
+
+func (s Model) Init() tea.Cmd { return nil }
+
+func (s Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Resize(msg)
+		return s, nil
+	case screens.ShownMsg:
+		if s.browser == nil {
+			s.browser = workspaces.NewBrowser(s.client)
+		}
+		return s, s.browser.Init()
+	case workspaceDeleteMsg:
+		return s, s.browser.DeleteCmd(msg.id)
+	case tea.KeyMsg:
+		if s.browser == nil {
+			return s, screens.Show(screens.Menu)
+		}
+		if !s.browser.InDiff() {
+			switch msg.String() {
+			case "esc", "q":
+				return s, screens.Show(screens.Menu)
+			case "d":
+				ws, ok := s.browser.Selected()
+				if !ok {
+					return s, nil
+				}
+				message := "Delete " + ws.Path + " from the kennel?"
+				if ws.Dirty || ws.Ahead > 0 {
+					message += " It has changes that were not pushed; they will be lost."
+				}
+				return s, screens.Confirm("Delete workspace", message, "Delete", workspaceDeleteMsg{id: ws.ID})
+			}
+		}
+	}
+	if s.browser == nil {
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.browser, cmd = s.browser.Update(msg)
+	return s, cmd
+}
+
+func (s Model) HelpKeys() []string {
+	if s.browser == nil {
+		return screens.KeyHelp(screens.Workspaces, "Esc")
+	}
+	return s.browser.HelpKeys()
+}
+
+func (s Model) View() string {
+	width, height := s.Size()
+
+	title := layout.SectionHeader("🗂 Workspaces", width-4)
+
+	var content strings.Builder
+	if s.browser != nil {
+		s.browser.SetHeight(height - 4)
+		// Columns that don't fit a narrow terminal are cut rather than wrapped
+		content.WriteString(lipgloss.NewStyle().MaxWidth(width).Render(s.browser.View(width)))
+	}
+	return title + "\n\n" + content.String()
+}
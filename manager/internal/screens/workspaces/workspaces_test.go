@@ -0,0 +1,58 @@
+package workspaces
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/screenstest"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/workspaces"
+)
+
+var loaded = workspaces.LoadedMsg{Workspaces: []status.Workspace{
+	{ID: "w1", Path: "/workspace/hello-world", Branch: "main", Dirty: true},
+	{ID: "w2", Path: "/workspace/docs", Branch: "main"},
+}}
+
+func TestViewListsWorkspaces(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screenstest.Room, screens.ShownMsg{}, loaded)
+	v := s.View()
+	for _, want := range []string{"Workspaces", "2 workspaces, 1 with uncommitted changes", "/workspace/hello-world", "w2"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestDeleteAsksFirst(t *testing.T) {
+	s, cmd := screenstest.Send(New(nil), screens.ShownMsg{}, loaded, screenstest.Key("d"))
+	confirm, ok := screenstest.Find[screens.ConfirmMsg](cmd)
+	if !ok {
+		t.Fatal("d didn't ask before deleting")
+	}
+	if !strings.Contains(confirm.Message, "/workspace/hello-world") || !strings.Contains(confirm.Message, "not pushed") {
+		t.Errorf("confirmation %q doesn't warn about the unpushed changes", confirm.Message)
+	}
+	if del, ok := confirm.Then.(workspaceDeleteMsg); !ok || del.id != "w1" {
+		t.Errorf("confirming sends %#v, want the deletion of w1", confirm.Then)
+	}
+
+	_, cmd = screenstest.Send(s, screenstest.Key("down"), screenstest.Key("d"))
+	confirm, _ = screenstest.Find[screens.ConfirmMsg](cmd)
+	if strings.Contains(confirm.Message, "not pushed") {
+		t.Errorf("confirmation %q warns about a clean workspace", confirm.Message)
+	}
+}
+
+func TestEscLeavesDiffFirst(t *testing.T) {
+	s, _ := screenstest.Send(New(nil), screens.ShownMsg{}, loaded, screenstest.Key("enter"))
+	s, cmd := screenstest.Send(s, workspaces.DiffMsg{ID: "w1", Diff: "+hello"}, screenstest.Key("esc"))
+	if _, ok := screenstest.Find[screens.ShowMsg](cmd); ok {
+		t.Fatal("Esc in a diff left the screen")
+	}
+	_, cmd = screenstest.Send(s, screenstest.Key("esc"))
+	if show, ok := screenstest.Find[screens.ShowMsg](cmd); !ok || show.Screen != screens.Menu {
+		t.Errorf("Esc showed %v, want the menu", show.Screen)
+	}
+}
@@ -0,0 +1,54 @@
+// Package session remembers where the manager was left between runs.
+//
+// The state is a small JSON file in the data directory. It is a convenience
+// only: a missing or unreadable file starts the manager fresh.
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/fetch/manager/internal/paths"
+)
+
+// State is what the manager restores on startup.
+type State struct {
+	// Screen is the palette action ID that reopens the last screen
+	// (e.g. "logs"); empty for the main menu.
+	Screen        string   `json:"screen,omitempty"`
+	LogFilter     string   `json:"logFilter,omitempty"`
+	HiddenLevels  []string `json:"hiddenLevels,omitempty"` // Log levels toggled off
+	ConfigField   string   `json:"configField,omitempty"`  // Env key the config cursor was on
+	ShowAllModels bool     `json:"showAllModels,omitempty"`
+}
+
+// statePath is where the state is stored.
+func statePath() string {
+	return filepath.Join(paths.ProjectDir, "data", "manager-state.json")
+}
+
+// Load returns the saved state, or the zero State if there is none.
+func Load() State {
+	var s State
+	data, err := os.ReadFile(statePath())
+	if err != nil {
+		return State{}
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return State{}
+	}
+	return s
+}
+
+// Save writes the state, creating the data directory if needed.
+func Save(s State) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(statePath()), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(statePath(), data, 0644)
+}
@@ -0,0 +1,76 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the per-number activity log.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ActivityEvent is one audited command or task.
+type ActivityEvent struct {
+	At     time.Time `json:"at"`
+	Kind   string    `json:"kind"`   // "command" or "task"
+	Detail string    `json:"detail"` // Command line, or task ID and goal
+}
+
+// NumberActivity is what one number has done through the bridge.
+type NumberActivity struct {
+	FirstSeen    time.Time       `json:"firstSeen"`
+	LastSeen     time.Time       `json:"lastSeen"`
+	Messages     int             `json:"messages"`
+	Commands     map[string]int  `json:"commands"` // Slash command → times issued
+	TasksStarted int             `json:"tasksStarted"`
+	Recent       []ActivityEvent `json:"recent"` // Newest last
+}
+
+// CommandCount is a slash command and how often it was issued.
+type CommandCount struct {
+	Name  string
+	Count int
+}
+
+// TopCommands returns the commands issued, most used first.
+func (a NumberActivity) TopCommands() []CommandCount {
+	counts := make([]CommandCount, 0, len(a.Commands))
+	for name, n := range a.Commands {
+		counts = append(counts, CommandCount{Name: name, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return counts
+}
+
+// GetActivity fetches the activity of every number the bridge has seen,
+// keyed by normalized number.
+func (c *Client) GetActivity() (map[string]NumberActivity, error) {
+	req, err := c.newRequest("GET", "/api/activity", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Numbers map[string]NumberActivity `json:"numbers"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Numbers, nil
+}
@@ -2,15 +2,21 @@
 package status
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
 const (
-	// DefaultStatusURL is the default bridge status API endpoint
-	DefaultStatusURL = "http://localhost:8765/api/status"
+	// DefaultBaseURL is the default bridge API base URL
+	DefaultBaseURL = "http://localhost:8765"
 	// RequestTimeout is the HTTP request timeout
 	RequestTimeout = 5 * time.Second
 )
@@ -18,33 +24,114 @@ const (
 // BridgeStatus represents the current state of the Fetch Bridge.
 // It includes WhatsApp connection state, authentication info, and metrics.
 type BridgeStatus struct {
-	State        string  `json:"state"`        // initializing, qr_pending, authenticated, disconnected, error
+	State        string  `json:"state"`        // initializing, qr_pending, authenticating, authenticated, disconnected, error
 	QRCode       *string `json:"qrCode"`       // Raw QR code data (if pending)
 	QRUrl        *string `json:"qrUrl"`        // URL to view QR code image
 	Uptime       int     `json:"uptime"`       // Seconds since start
 	MessageCount int     `json:"messageCount"` // Total messages processed
 	LastError    *string `json:"lastError"`    // Last error message (if any)
+	// Linked account; nil until authenticated, or from bridges that predate it
+	Device *LinkedDevice `json:"device"`
+}
+
+// LinkedDevice is the WhatsApp account the bridge is linked to.
+type LinkedDevice struct {
+	Name  *string `json:"name"`
+	Phone *string `json:"phone"` // Without the leading +
 }
 
 // Client provides HTTP access to the Fetch Bridge status and control APIs.
 type Client struct {
 	baseURL    string
+	token      string
 	httpClient *http.Client
+	ctx        context.Context // Set by WithContext; nil means none
 }
 
-// NewClient creates a new status client
-func NewClient() *Client {
+// NewClient creates a new status client for the bridge at baseURL.
+// An empty baseURL uses DefaultBaseURL; a non-empty token is sent as a
+// bearer token on every request.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
 	return &Client{
-		baseURL: DefaultStatusURL,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
 		httpClient: &http.Client{
 			Timeout: RequestTimeout,
 		},
 	}
 }
 
+// WithContext returns a copy of the client whose requests also stop when
+// ctx is done, for calls the user can cancel. Each request keeps its own
+// timeout.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+// ResolveBaseURL combines a base URL and an optional port override.
+// An empty rawURL falls back to DefaultBaseURL; a bare host ("fetch.lan")
+// is given an http:// scheme.
+func ResolveBaseURL(rawURL, port string) (string, error) {
+	if rawURL == "" {
+		rawURL = DefaultBaseURL
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid bridge URL %q: %w", rawURL, err)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid bridge URL %q: missing host", rawURL)
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(u.Hostname(), port)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// BaseURL returns the bridge API base URL this client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// DocsURL returns the URL of the documentation site served by the bridge.
+func (c *Client) DocsURL() string {
+	return c.baseURL + "/docs"
+}
+
+// newRequest builds a request against the bridge API, attaching the bearer
+// token when one is configured.
+func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
 // GetStatus fetches the current bridge status
 func (c *Client) GetStatus() (*BridgeStatus, error) {
-	resp, err := c.httpClient.Get(c.baseURL)
+	req, err := c.newRequest("GET", "/api/status", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
 	}
@@ -62,9 +149,45 @@ func (c *Client) GetStatus() (*BridgeStatus, error) {
 	return &status, nil
 }
 
+// BridgeVersion is the bridge's build information.
+type BridgeVersion struct {
+	Version *string `json:"version"` // From package.json
+	Commit  *string `json:"commit"`  // Commit the image was built from; nil for untagged builds
+	Node    string  `json:"node"`
+}
+
+// GetVersion fetches the bridge's build information. Bridges that predate
+// the endpoint answer 404.
+func (c *Client) GetVersion() (*BridgeVersion, error) {
+	req, err := c.newRequest("GET", "/api/version", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var version BridgeVersion
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &version, nil
+}
+
 // IsHealthy checks if the bridge is reachable
 func (c *Client) IsHealthy() bool {
-	resp, err := c.httpClient.Get("http://localhost:8765/api/health")
+	req, err := c.newRequest("GET", "/api/health", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return false
 	}
@@ -79,6 +202,8 @@ func (s *BridgeStatus) StateEmoji() string {
 		return "⏳"
 	case "qr_pending":
 		return "📱"
+	case "authenticating":
+		return "🔐"
 	case "authenticated":
 		return "✅"
 	case "disconnected":
@@ -97,6 +222,8 @@ func (s *BridgeStatus) StateDescription() string {
 		return "Starting up..."
 	case "qr_pending":
 		return "Waiting for QR scan"
+	case "authenticating":
+		return "Linked, loading chats..."
 	case "authenticated":
 		return "Connected to WhatsApp"
 	case "disconnected":
@@ -126,6 +253,41 @@ func (s *BridgeStatus) FormatUptime() string {
 	return fmt.Sprintf("%ds", seconds)
 }
 
+// ShortUptime returns a coarse uptime for places that refresh only every few
+// seconds, such as the status bar
+func (s *BridgeStatus) ShortUptime() string {
+	hours := s.Uptime / 3600
+	minutes := (s.Uptime % 3600) / 60
+
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	if minutes > 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%ds", s.Uptime)
+}
+
+// ShortState returns a one- or two-word WhatsApp state for the status bar
+func (s *BridgeStatus) ShortState() string {
+	switch s.State {
+	case "initializing":
+		return "starting"
+	case "qr_pending":
+		return "awaiting QR"
+	case "authenticating":
+		return "linking"
+	case "authenticated":
+		return "connected"
+	case "disconnected":
+		return "disconnected"
+	case "error":
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
 // LogoutResponse represents the response from the logout API
 type LogoutResponse struct {
 	Success bool   `json:"success"`
@@ -134,9 +296,9 @@ type LogoutResponse struct {
 
 // Logout disconnects WhatsApp by calling the logout API
 func (c *Client) Logout() (*LogoutResponse, error) {
-	req, err := http.NewRequest("POST", "http://localhost:8765/api/logout", nil)
+	req, err := c.newRequest("POST", "/api/logout", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -152,3 +314,115 @@ func (c *Client) Logout() (*LogoutResponse, error) {
 
 	return &result, nil
 }
+
+// PairingCodeResponse represents the response from the pairing-code API
+type PairingCodeResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RequestPairingCode asks the bridge for an 8-character WhatsApp pairing code
+// for the given phone number (digits with country code, no +), as an
+// alternative to scanning the QR code.
+func (c *Client) RequestPairingCode(phone string) (*PairingCodeResponse, error) {
+	body, err := json.Marshal(map[string]string{"phoneNumber": phone})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := c.newRequest("POST", "/api/pairing-code", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result PairingCodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if result.Message != "" {
+			return nil, fmt.Errorf("bridge refused pairing code: %s", result.Message)
+		}
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if result.Code == "" {
+		return nil, fmt.Errorf("bridge returned an empty pairing code")
+	}
+
+	return &result, nil
+}
+
+// FormatPairingCode splits an 8-character pairing code into two groups
+// ("ABCD-EFGH") the way WhatsApp displays it.
+func FormatPairingCode(code string) string {
+	if len(code) == 8 {
+		return code[:4] + "-" + code[4:]
+	}
+	return code
+}
+
+// HourStats holds message traffic counters for one hour.
+type HourStats struct {
+	Hour      time.Time `json:"hour"`      // Start of the hour bucket
+	Messages  int       `json:"messages"`  // Messages processed
+	ToolCalls int       `json:"toolCalls"` // Agent tool calls made
+	Errors    int       `json:"errors"`    // Failed message handlings
+}
+
+// TrafficStats represents per-hour traffic counters from the stats API,
+// oldest bucket first.
+type TrafficStats struct {
+	Hours []HourStats `json:"hours"`
+}
+
+// Totals sums every bucket.
+func (t *TrafficStats) Totals() (messages, toolCalls, errors int) {
+	for _, h := range t.Hours {
+		messages += h.Messages
+		toolCalls += h.ToolCalls
+		errors += h.Errors
+	}
+	return messages, toolCalls, errors
+}
+
+// ErrorRate returns errors as a fraction of messages (0 when idle).
+func (t *TrafficStats) ErrorRate() float64 {
+	messages, _, errors := t.Totals()
+	if messages == 0 {
+		return 0
+	}
+	return float64(errors) / float64(messages)
+}
+
+// GetStats fetches per-hour message, tool-call, and error counts
+func (c *Client) GetStats() (*TrafficStats, error) {
+	req, err := c.newRequest("GET", "/api/stats", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var stats TrafficStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,96 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the test console endpoint.
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TestMessageTimeout bounds a test console message. It is far longer than
+// RequestTimeout because the reply waits on the model and any tools it runs.
+const TestMessageTimeout = 3 * time.Minute
+
+// ToolCall is one tool the agent called while answering a test message.
+type ToolCall struct {
+	Name   string         `json:"name"`
+	Args   map[string]any `json:"args"`
+	Result any            `json:"result"`
+}
+
+// Succeeded reports whether the tool's result carries success: true. Results
+// without the field count as successful.
+func (t ToolCall) Succeeded() bool {
+	if r, ok := t.Result.(map[string]any); ok {
+		if success, ok := r["success"].(bool); ok {
+			return success
+		}
+	}
+	return true
+}
+
+// TokenUsage is the tokens spent across every completion for a message.
+type TokenUsage struct {
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	TotalTokens      int `json:"totalTokens"`
+}
+
+// TestMessageResult is the bridge's answer to a test console message.
+type TestMessageResult struct {
+	Responses  []string    `json:"responses"`  // Replies that would go back on WhatsApp
+	Progress   []string    `json:"progress"`   // Intermediate progress messages
+	ToolCalls  []ToolCall  `json:"toolCalls"`  // Tools the agent called
+	Usage      *TokenUsage `json:"usage"`      // Nil for slash commands and instinct replies
+	Mode       *string     `json:"mode"`       // Detected conversation mode
+	DurationMs int         `json:"durationMs"` // Time spent on the bridge
+}
+
+// SendTestMessage runs text through the bridge's agent pipeline as if it had
+// arrived on WhatsApp, using a session kept apart from real chats. It needs
+// the admin token.
+func (c *Client) SendTestMessage(text string) (*TestMessageResult, error) {
+	body, err := json.Marshal(map[string]string{"message": text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := c.newRequest("POST", "/api/test-message", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := *c.httpClient
+	client.Timeout = TestMessageTimeout
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("bridge rejected the admin token (set ADMIN_TOKEN in .env and restart the bridge)")
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("bridge doesn't support the test console; rebuild it")
+	default:
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&e) == nil && e.Error != "" {
+			return nil, fmt.Errorf("bridge error: %s", e.Error)
+		}
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result TestMessageResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
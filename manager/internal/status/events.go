@@ -0,0 +1,69 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the status event stream.
+package status
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WatchStatus follows the bridge's /api/events stream and sends the status
+// it reports: the current one first, then one per state change. The
+// channel is closed when ctx is cancelled or the stream ends; bridges
+// without the stream return an error and the caller keeps polling.
+func (c *Client) WatchStatus(ctx context.Context) (<-chan *BridgeStatus, error) {
+	req, err := c.newRequest("GET", "/api/events", nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+
+	// The stream stays open, so the request timeout can't apply
+	stream := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := stream.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	ch := make(chan *BridgeStatus)
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		event, data := "", ""
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				// A blank line ends the event
+				if event == "status" && data != "" {
+					var s BridgeStatus
+					if json.Unmarshal([]byte(data), &s) == nil {
+						select {
+						case ch <- &s:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+				event, data = "", ""
+			case strings.HasPrefix(line, "event:"):
+				event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				data += strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			}
+		}
+	}()
+	return ch, nil
+}
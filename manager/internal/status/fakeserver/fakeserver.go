@@ -0,0 +1,286 @@
+// Package fakeserver stands in for the bridge's status API: status, the
+// status event stream, health, and logout. Its WhatsApp state follows a
+// script, by default starting up, showing a QR code, and then linking, so
+// the setup flow can be exercised without a phone. Tests drive it with
+// their own clock; the manager's --mock-bridge flag serves it on a local
+// port.
+package fakeserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fetch/manager/internal/status"
+)
+
+// Step is one state in a script and how long it lasts. The last step lasts
+// until the script is moved on by hand.
+type Step struct {
+	State string
+	For   time.Duration
+}
+
+// DefaultScript starts up, waits for a QR scan, and links.
+var DefaultScript = []Step{
+	{State: "initializing", For: 3 * time.Second},
+	{State: "qr_pending", For: 30 * time.Second},
+	{State: "authenticating", For: 4 * time.Second},
+	{State: "authenticated"},
+}
+
+// states are the bridge's WhatsApp states.
+var states = []string{"initializing", "qr_pending", "authenticating", "authenticated", "disconnected", "error"}
+
+// qrRotation is how often the bridge replaces its QR code.
+const qrRotation = 20 * time.Second
+
+// eventPoll is how often the event stream checks for a state change, as
+// the script moves on lazily rather than on a timer.
+const eventPoll = 250 * time.Millisecond
+
+// ParseScript parses a script like "initializing:3s,qr_pending:30s,
+// authenticated", where the last step's duration may be left out.
+func ParseScript(s string) ([]Step, error) {
+	var script []Step
+	for _, part := range strings.Split(s, ",") {
+		state, dur, hasDur := strings.Cut(strings.TrimSpace(part), ":")
+		if !validState(state) {
+			return nil, fmt.Errorf("unknown state %q (use one of %s)", state, strings.Join(states, ", "))
+		}
+		step := Step{State: state}
+		if hasDur {
+			d, err := time.ParseDuration(dur)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("invalid duration %q for %s", dur, state)
+			}
+			step.For = d
+		}
+		script = append(script, step)
+	}
+	return script, nil
+}
+
+func validState(state string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// Server is a fake bridge. It is safe for concurrent use.
+type Server struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	token    string // Bearer token logout requires; empty allows any
+	script   []Step
+	step     int       // Index into script
+	stepAt   time.Time // When the current step began
+	started  time.Time
+	messages int
+	lastErr  string
+
+	srv *http.Server
+	url string
+}
+
+// New creates a server that follows script, or DefaultScript when it is
+// empty. Logout requires token unless it is empty.
+func New(token string, script []Step) *Server {
+	if len(script) == 0 {
+		script = DefaultScript
+	}
+	s := &Server{now: time.Now, token: token, script: script}
+	s.started = s.now()
+	s.stepAt = s.started
+	return s
+}
+
+// SetClock replaces the wall clock, so a test can move time by hand. The
+// current step and uptime restart from the new clock.
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+	s.started = now()
+	s.stepAt = s.started
+}
+
+// advance moves through every step whose time has run out.
+func (s *Server) advance() {
+	now := s.now()
+	for s.step < len(s.script)-1 {
+		d := s.script[s.step].For
+		if d <= 0 || now.Sub(s.stepAt) < d {
+			return
+		}
+		s.stepAt = s.stepAt.Add(d)
+		s.step++
+	}
+}
+
+// Next moves to the script's next step now, whatever its duration.
+func (s *Server) Next() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance()
+	if s.step < len(s.script)-1 {
+		s.step++
+		s.stepAt = s.now()
+	}
+}
+
+// SetState jumps to the first step in state, or, when the script has none,
+// holds state until the next jump. An error state reports message as the
+// last error.
+func (s *Server) SetState(state, message string) error {
+	if !validState(state) {
+		return fmt.Errorf("unknown state %q", state)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastErr = message
+	s.stepAt = s.now()
+	for i, step := range s.script {
+		if step.State == state {
+			s.step = i
+			return nil
+		}
+	}
+	s.script = []Step{{State: state}}
+	s.step = 0
+	return nil
+}
+
+// AddMessages counts n more handled messages.
+func (s *Server) AddMessages(n int) {
+	s.mu.Lock()
+	s.messages += n
+	s.mu.Unlock()
+}
+
+// Status returns the status the server reports now.
+func (s *Server) Status() status.BridgeStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance()
+	now := s.now()
+	st := status.BridgeStatus{
+		State:        s.script[s.step].State,
+		Uptime:       int(now.Sub(s.started) / time.Second),
+		MessageCount: s.messages,
+	}
+	switch st.State {
+	case "qr_pending":
+		// A new code each rotation, like the bridge's
+		qr := fmt.Sprintf("2@fetch-fake-bridge-%d", int(now.Sub(s.stepAt)/qrRotation))
+		st.QRCode = &qr
+	case "authenticated":
+		name, phone := "Fetch Fake", "15550100000"
+		st.Device = &status.LinkedDevice{Name: &name, Phone: &phone}
+	case "error":
+		message := s.lastErr
+		if message == "" {
+			message = "fake bridge error"
+		}
+		st.LastError = &message
+	}
+	return st
+}
+
+// Handler serves the bridge's status, events, health, and logout endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.Status())
+	})
+	mux.HandleFunc("GET /api/events", s.serveEvents)
+	mux.HandleFunc("GET /api/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]bool{"healthy": true})
+	})
+	mux.HandleFunc("POST /api/logout", func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+			writeJSON(w, http.StatusUnauthorized, status.LogoutResponse{Message: "Unauthorized"})
+			return
+		}
+		// The bridge waits for a new QR scan once the session is gone
+		s.SetState("qr_pending", "")
+		writeJSON(w, http.StatusOK, status.LogoutResponse{Success: true, Message: "Logged out successfully"})
+	})
+	return mux
+}
+
+// serveEvents sends the status as server-sent events: now, then on every
+// state change until the client leaves.
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	last := ""
+	ticker := time.NewTicker(eventPoll)
+	defer ticker.Stop()
+	for {
+		st := s.Status()
+		if st.State != last {
+			data, _ := json.Marshal(st)
+			fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+			flusher.Flush()
+			last = st.State
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Start serves on addr, such as "127.0.0.1:0" for any free port, until
+// Close.
+func (s *Server) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("fake bridge: %w", err)
+	}
+	s.srv = &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	s.url = "http://" + ln.Addr().String()
+	go s.srv.Serve(ln)
+	return nil
+}
+
+// URL is the base URL the server listens on, for status.NewClient.
+func (s *Server) URL() string {
+	return s.url
+}
+
+// Close stops the server and closes open event streams.
+func (s *Server) Close() error {
+	if s.srv == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.srv.Shutdown(ctx); err != nil {
+		return s.srv.Close()
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package fakeserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fetch/manager/internal/status"
+)
+
+// clock is a time the test moves by hand.
+type clock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *clock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *clock) add(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+func TestClientFollowsScript(t *testing.T) {
+	s := New("", []Step{
+		{State: "initializing", For: 3 * time.Second},
+		{State: "qr_pending", For: 30 * time.Second},
+		{State: "authenticated"},
+	})
+	c := &clock{t: time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)}
+	s.SetClock(c.now)
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+	client := status.NewClient(srv.URL, "")
+
+	for _, step := range []struct {
+		after time.Duration
+		state string
+	}{
+		{0, "initializing"},
+		{2 * time.Second, "initializing"},
+		{time.Second, "qr_pending"},
+		{29 * time.Second, "qr_pending"},
+		{time.Second, "authenticated"},
+		{time.Hour, "authenticated"},
+	} {
+		c.add(step.after)
+		st, err := client.GetStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.State != step.state {
+			t.Fatalf("state %q after %v, want %q", st.State, step.after, step.state)
+		}
+		if (st.QRCode != nil) != (st.State == "qr_pending") {
+			t.Errorf("%s: QR code %v", st.State, st.QRCode)
+		}
+		if (st.Device != nil) != (st.State == "authenticated") {
+			t.Errorf("%s: linked device %v", st.State, st.Device)
+		}
+	}
+}
+
+func TestWatchStatusFollowsScript(t *testing.T) {
+	// Steps without a duration only move on when the test says so
+	s := New("", []Step{{State: "initializing"}, {State: "qr_pending"}, {State: "authenticated"}})
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := status.NewClient(s.URL(), "").WatchStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := func(want string) {
+		t.Helper()
+		select {
+		case st, ok := <-events:
+			if !ok {
+				t.Fatalf("stream closed waiting for %s", want)
+			}
+			if st.State != want {
+				t.Fatalf("state %q, want %q", st.State, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no event in 5s, want %s", want)
+		}
+	}
+	next("initializing")
+	s.Next()
+	next("qr_pending")
+	s.Next()
+	next("authenticated")
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestLogoutReturnsToQR(t *testing.T) {
+	s := New("secret", []Step{{State: "authenticated"}})
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	if resp, err := status.NewClient(srv.URL, "wrong").Logout(); err != nil || resp.Success {
+		t.Errorf("logout with the wrong token: %+v, %v", resp, err)
+	}
+	if st := s.Status(); st.State != "authenticated" {
+		t.Fatalf("state %q after a refused logout", st.State)
+	}
+	if resp, err := status.NewClient(srv.URL, "secret").Logout(); err != nil || !resp.Success {
+		t.Fatalf("logout: %+v, %v", resp, err)
+	}
+	if st := s.Status(); st.State != "qr_pending" || st.QRCode == nil {
+		t.Errorf("state %q after logout, want qr_pending with a code", st.State)
+	}
+}
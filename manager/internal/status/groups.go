@@ -0,0 +1,51 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the WhatsApp group listing endpoint.
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrWhatsAppOffline is returned when the bridge can't list groups because
+// WhatsApp isn't connected.
+var ErrWhatsAppOffline = errors.New("WhatsApp is not connected")
+
+// Group is a WhatsApp group the linked account is in.
+type Group struct {
+	ID           string `json:"id"` // JID ending in @g.us
+	Name         string `json:"name"`
+	Participants int    `json:"participants"`
+}
+
+// GetGroups lists the WhatsApp groups the linked account is in
+func (c *Client) GetGroups() ([]Group, error) {
+	req, err := c.newRequest("GET", "/api/groups", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusServiceUnavailable:
+		return nil, ErrWhatsAppOffline
+	default:
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Groups []Group `json:"groups"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Groups, nil
+}
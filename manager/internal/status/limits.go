@@ -0,0 +1,80 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the circuit breaker and rate limiter endpoint.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Circuit breaker states, as reported per session.
+const (
+	BreakerClosed   = "closed"    // Requests go through
+	BreakerOpen     = "open"      // Requests are refused until the backoff ends
+	BreakerHalfOpen = "half_open" // Backoff ended; the next request decides
+)
+
+// SessionBreaker is the circuit breaker of one conversation session.
+type SessionBreaker struct {
+	SessionID   string     `json:"sessionId"`
+	State       string     `json:"state"`
+	ErrorCount  int        `json:"errorCount"`
+	LastErrorAt *time.Time `json:"lastErrorAt"`
+	RetryAt     *time.Time `json:"retryAt"` // When an open breaker lets a request through
+}
+
+// CircuitBreakerStatus is the breaker configuration and every session that
+// has recorded errors.
+type CircuitBreakerStatus struct {
+	Threshold int              `json:"threshold"` // FETCH_CB_THRESHOLD
+	BackoffMs []int            `json:"backoffMs"` // FETCH_CB_BACKOFF
+	ResetMs   int              `json:"resetMs"`   // FETCH_CB_RESET_MS
+	Sessions  []SessionBreaker `json:"sessions"`
+}
+
+// RateLimitKey is the request count of one sender within the window.
+type RateLimitKey struct {
+	Key       string `json:"key"` // Phone number
+	Count     int    `json:"count"`
+	Remaining int    `json:"remaining"`
+	Blocked   int    `json:"blocked"` // Requests refused in the window
+}
+
+// RateLimitStatus is the limiter configuration and its active senders.
+type RateLimitStatus struct {
+	MaxRequests int            `json:"maxRequests"` // FETCH_RATE_LIMIT_MAX
+	WindowMs    int            `json:"windowMs"`    // FETCH_RATE_LIMIT_WINDOW
+	Keys        []RateLimitKey `json:"keys"`
+}
+
+// LimitsStatus is the response from /api/limits.
+type LimitsStatus struct {
+	CircuitBreaker CircuitBreakerStatus `json:"circuitBreaker"`
+	RateLimit      RateLimitStatus      `json:"rateLimit"`
+}
+
+// GetLimits fetches the live circuit breaker and rate limiter state
+func (c *Client) GetLimits() (*LimitsStatus, error) {
+	req, err := c.newRequest("GET", "/api/limits", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result LimitsStatus
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
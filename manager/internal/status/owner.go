@@ -0,0 +1,78 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers changing the owner number.
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotOnWhatsApp is returned when a verification code can't be sent
+// because the number has no WhatsApp account.
+var ErrNotOnWhatsApp = errors.New("number is not on WhatsApp")
+
+// SendOwnerCode asks the bridge to send a verification code over WhatsApp
+// to phone (digits with country code, no +) before it becomes the owner.
+func (c *Client) SendOwnerCode(phone, code string) error {
+	body, err := json.Marshal(map[string]string{"phoneNumber": phone, "code": code})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := c.newRequest("POST", "/api/owner/verify", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.doOwnerRequest(req)
+}
+
+// SetOwner makes phone the owner on the running bridge, so the change
+// applies without a restart. The caller writes OWNER_PHONE_NUMBER to .env.
+func (c *Client) SetOwner(phone string) error {
+	body, err := json.Marshal(map[string]string{"phoneNumber": phone})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := c.newRequest("POST", "/api/owner", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.doOwnerRequest(req)
+}
+
+// doOwnerRequest executes an owner request, mapping 503 to
+// ErrWhatsAppOffline and 422 to ErrNotOnWhatsApp.
+func (c *Client) doOwnerRequest(req *http.Request) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusServiceUnavailable:
+		return ErrWhatsAppOffline
+	case http.StatusUnprocessableEntity:
+		return ErrNotOnWhatsApp
+	case http.StatusUnauthorized:
+		return fmt.Errorf("bridge rejected the admin token (set ADMIN_TOKEN in .env and restart the bridge)")
+	case http.StatusNotFound:
+		return fmt.Errorf("bridge doesn't support changing the owner; rebuild it")
+	default:
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&e) == nil && e.Error != "" {
+			return fmt.Errorf("bridge error: %s", e.Error)
+		}
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,82 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers preparing the bridge for a stop.
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ShutdownTimeout bounds the shutdown request. The bridge waits up to 15s
+// for messages in progress, so this leaves room for the flush on top.
+const ShutdownTimeout = 20 * time.Second
+
+// PrepareShutdown asks the bridge to stop taking messages, finish the ones
+// in progress, optionally say goodbye to the owner, and flush its data. It
+// returns how many messages were still in progress when the bridge gave up
+// waiting. The caller stops the containers afterwards either way.
+func (c *Client) PrepareShutdown(goodbye bool) (int, error) {
+	body, err := json.Marshal(map[string]bool{"goodbye": goodbye})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := c.newRequest("POST", "/api/shutdown", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Transport: c.httpClient.Transport, Timeout: ShutdownTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return 0, fmt.Errorf("bridge rejected the admin token")
+	case http.StatusNotFound:
+		return 0, fmt.Errorf("bridge doesn't support graceful shutdown")
+	default:
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Pending int `json:"pending"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Pending, nil
+}
+
+// CancelShutdown tells the bridge the stop after PrepareShutdown was called
+// off, so it takes messages again. The bridge also does this by itself a
+// couple of minutes after a shutdown request that no stop followed.
+func (c *Client) CancelShutdown() error {
+	req, err := c.newRequest("POST", "/api/shutdown/cancel", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized:
+		return fmt.Errorf("bridge rejected the admin token")
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
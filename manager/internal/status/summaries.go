@@ -0,0 +1,59 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the conversation summary endpoint.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ConversationSummary is a summary the bridge stored for a stretch of a
+// session's conversation. The agent reads these back instead of the
+// messages they cover.
+type ConversationSummary struct {
+	ID        string    `json:"id"`
+	SessionID string    `json:"sessionId"`
+	ThreadID  string    `json:"threadId"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SummaryFailure is a summarizer run that did not produce a summary.
+type SummaryFailure struct {
+	SessionID string    `json:"sessionId"`
+	Error     string    `json:"error"`
+	At        time.Time `json:"at"`
+}
+
+// SummariesResponse is the response from /api/summaries.
+type SummariesResponse struct {
+	Summaries []ConversationSummary `json:"summaries"`
+	Failures  []SummaryFailure      `json:"failures"`
+}
+
+// GetSummaries fetches the stored conversation summaries for every session
+// and the summarizer's recent failures
+func (c *Client) GetSummaries() (*SummariesResponse, error) {
+	req, err := c.newRequest("GET", "/api/summaries", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result SummariesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
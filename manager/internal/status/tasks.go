@@ -0,0 +1,189 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the kennel task queue endpoints.
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TaskProgress is a progress update emitted by a harness while a task runs.
+type TaskProgress struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+	Percent   *int      `json:"percent"`
+}
+
+// TaskResult captures the outcome of a finished task.
+type TaskResult struct {
+	Success   bool   `json:"success"`
+	Summary   string `json:"summary"`
+	Error     string `json:"error"`
+	RawOutput string `json:"rawOutput"`
+	ExitCode  int    `json:"exitCode"`
+}
+
+// Task mirrors a kennel task as reported by the bridge task API.
+type Task struct {
+	ID          string         `json:"id"`
+	Goal        string         `json:"goal"`
+	Workspace   string         `json:"workspace"`
+	Agent       string         `json:"agent"`  // claude, gemini, copilot
+	Status      string         `json:"status"` // pending, running, waiting_input, paused, completed, failed, cancelled
+	Progress    []TaskProgress `json:"progress"`
+	Result      *TaskResult    `json:"result"`
+	Question    string         `json:"pendingQuestion"` // Set while waiting_input
+	RetryCount  int            `json:"retryCount"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	StartedAt   *time.Time     `json:"startedAt"`
+	CompletedAt *time.Time     `json:"completedAt"`
+}
+
+// IsActive reports whether the task is queued or still executing.
+func (t Task) IsActive() bool {
+	switch t.Status {
+	case "pending", "running", "waiting_input", "paused":
+		return true
+	}
+	return false
+}
+
+// CanRetry reports whether the task ended in a state that can be retried.
+func (t Task) CanRetry() bool {
+	return t.Status == "failed" || t.Status == "cancelled"
+}
+
+// Duration returns how long the task has run, or ran, as of now.
+// Tasks that have not started report zero.
+func (t Task) Duration(now time.Time) time.Duration {
+	if t.StartedAt == nil {
+		return 0
+	}
+	end := now
+	if t.CompletedAt != nil {
+		end = *t.CompletedAt
+	}
+	return end.Sub(*t.StartedAt)
+}
+
+// LastOutput returns the most recent human-readable output for the task:
+// the error or summary for finished tasks, otherwise the latest progress line.
+func (t Task) LastOutput() string {
+	if t.Result != nil {
+		if t.Result.Error != "" {
+			return t.Result.Error
+		}
+		if t.Result.Summary != "" {
+			return t.Result.Summary
+		}
+	}
+	if n := len(t.Progress); n > 0 {
+		return t.Progress[n-1].Message
+	}
+	return ""
+}
+
+// pullRequestURL matches a link to a pull or merge request on GitHub,
+// GitLab, Gitea, or Bitbucket.
+var pullRequestURL = regexp.MustCompile(`https?://[^\s"'<>()\[\]]+/(?:pull|pulls|merge_requests|pull-requests)/\d+`)
+
+// PullRequestURL returns the pull request the task links to in its result
+// or, failing that, its latest progress line that has one. Harnesses report
+// the PR they opened in prose, so it is "" when none was mentioned.
+func (t Task) PullRequestURL() string {
+	if t.Result != nil {
+		for _, text := range []string{t.Result.Summary, t.Result.RawOutput} {
+			if u := pullRequestURL.FindString(text); u != "" {
+				return u
+			}
+		}
+	}
+	for i := len(t.Progress) - 1; i >= 0; i-- {
+		if u := pullRequestURL.FindString(t.Progress[i].Message); u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+// GetTasks fetches the task queue (pending, running, and recent tasks)
+func (c *Client) GetTasks() ([]Task, error) {
+	req, err := c.newRequest("GET", "/api/tasks", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Tasks []Task `json:"tasks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Tasks, nil
+}
+
+// CancelTask asks the bridge to cancel a pending or running task
+func (c *Client) CancelTask(id string) error {
+	return c.taskAction(id, "cancel", nil)
+}
+
+// RetryTask asks the bridge to re-queue a failed or cancelled task
+func (c *Client) RetryTask(id string) error {
+	return c.taskAction(id, "retry", nil)
+}
+
+// RespondToTask answers the question a task is waiting on, such as a
+// harness asking to run a command or push
+func (c *Client) RespondToTask(id, response string) error {
+	body, err := json.Marshal(map[string]string{"response": response})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	return c.taskAction(id, "respond", bytes.NewReader(body))
+}
+
+// taskAction POSTs to /api/tasks/{id}/{action} with an optional JSON body
+func (c *Client) taskAction(id, action string, body io.Reader) error {
+	req, err := c.newRequest("POST", "/api/tasks/"+url.PathEscape(id)+"/"+action, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&body) == nil && body.Message != "" {
+			return fmt.Errorf("%s failed: %s", action, strings.TrimSpace(body.Message))
+		}
+		return fmt.Errorf("%s failed: status %d", action, resp.StatusCode)
+	}
+	return nil
+}
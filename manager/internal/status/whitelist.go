@@ -0,0 +1,197 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the trusted-number whitelist endpoints.
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrWhitelistConflict is returned when the bridge's whitelist no longer
+// matches what the caller expected (number already added or already removed,
+// typically by a concurrent /trust command).
+var ErrWhitelistConflict = errors.New("whitelist changed on the bridge")
+
+// Role controls what a trusted number may ask Fetch to do.
+type Role string
+
+const (
+	RoleChat  Role = "chat"  // Conversation only, no tasks or code changes
+	RoleTasks Role = "tasks" // May start coding tasks (the default)
+	RoleAdmin Role = "admin" // Tasks plus /trust and other admin commands
+)
+
+// Roles lists the roles in order of increasing privilege.
+var Roles = []Role{RoleChat, RoleTasks, RoleAdmin}
+
+// Description returns a one-line explanation of the role.
+func (r Role) Description() string {
+	switch r {
+	case RoleChat:
+		return "Chat only — cannot run tasks against repos"
+	case RoleAdmin:
+		return "Tasks plus admin commands such as /trust"
+	default:
+		return "Chat and run coding tasks"
+	}
+}
+
+// TrustedContact holds the optional details kept alongside a trusted number.
+type TrustedContact struct {
+	Label   string `json:"label,omitempty"`   // Display name, e.g. "Mom"
+	Note    string `json:"note,omitempty"`    // Free-form note
+	AddedAt string `json:"addedAt,omitempty"` // RFC3339 timestamp
+	Role    Role   `json:"role,omitempty"`    // Empty means RoleTasks
+	// ExpiresAt is an RFC3339 timestamp after which the number is no longer
+	// trusted. Empty means the number never expires.
+	ExpiresAt string `json:"expiresAt,omitempty"`
+}
+
+// Expiry returns when the contact stops being trusted, if ever.
+func (c TrustedContact) Expiry() (time.Time, bool) {
+	if c.ExpiresAt == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, c.ExpiresAt)
+	return t, err == nil
+}
+
+// Expired reports whether the contact's expiry has passed.
+func (c TrustedContact) Expired(now time.Time) bool {
+	t, ok := c.Expiry()
+	return ok && !now.Before(t)
+}
+
+// EffectiveRole returns the contact's role, applying the default.
+func (c TrustedContact) EffectiveRole() Role {
+	if c.Role == "" {
+		return RoleTasks
+	}
+	return c.Role
+}
+
+// WhitelistResponse represents the bridge's current whitelist.
+type WhitelistResponse struct {
+	TrustedNumbers []string                  `json:"trustedNumbers"`
+	Contacts       map[string]TrustedContact `json:"contacts,omitempty"`
+	UpdatedAt      string                    `json:"updatedAt"`
+}
+
+// GetWhitelist fetches the trusted numbers the bridge currently enforces
+func (c *Client) GetWhitelist() (*WhitelistResponse, error) {
+	req, err := c.newRequest("GET", "/api/whitelist", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result WhitelistResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// AddTrustedNumber adds a number through the bridge so it takes effect
+// immediately and is persisted by the bridge itself
+func (c *Client) AddTrustedNumber(number string, contact TrustedContact) error {
+	body, err := json.Marshal(struct {
+		Number string `json:"number"`
+		TrustedContact
+	}{number, contact})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := c.newRequest("POST", "/api/whitelist", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.doWhitelistChange(req)
+}
+
+// UpdateTrustedContact replaces the details (label, note, role, expiry)
+// stored for a number
+func (c *Client) UpdateTrustedContact(number string, contact TrustedContact) error {
+	body, err := json.Marshal(contact)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := c.newRequest("PATCH", "/api/whitelist/"+url.PathEscape(number), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.doWhitelistChange(req)
+}
+
+// RemoveTrustedNumber removes a number through the bridge
+func (c *Client) RemoveTrustedNumber(number string) error {
+	req, err := c.newRequest("DELETE", "/api/whitelist/"+url.PathEscape(number), nil)
+	if err != nil {
+		return err
+	}
+	return c.doWhitelistChange(req)
+}
+
+// doWhitelistChange executes a whitelist mutation, mapping 409/404 to
+// ErrWhitelistConflict.
+func (c *Client) doWhitelistChange(req *http.Request) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusConflict, http.StatusNotFound:
+		return ErrWhitelistConflict
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
+// ReloadWhitelist asks the bridge to re-read data/whitelist.json after the
+// file was written directly, and returns how many numbers it now trusts.
+func (c *Client) ReloadWhitelist() (int, error) {
+	req, err := c.newRequest("POST", "/api/whitelist/reload", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Count, nil
+}
@@ -0,0 +1,143 @@
+// Package status provides a client for the Fetch Bridge status API.
+// This file covers the kennel workspace endpoints.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WorkspaceTask is the most recent task run in a workspace.
+type WorkspaceTask struct {
+	ID          string     `json:"id"`
+	Goal        string     `json:"goal"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completedAt"`
+}
+
+// Workspace mirrors a kennel workspace (a cloned repository or project
+// directory) as reported by the bridge workspace API.
+type Workspace struct {
+	ID             string         `json:"id"`
+	Path           string         `json:"path"`
+	ProjectType    string         `json:"projectType"`
+	Branch         string         `json:"branch"` // Empty outside git repositories
+	Dirty          bool           `json:"dirty"`
+	Ahead          int            `json:"ahead"`
+	Behind         int            `json:"behind"`
+	ChangedFiles   int            `json:"changedFiles"`
+	RemoteURL      string         `json:"remoteUrl"`
+	IsActive       bool           `json:"isActive"`
+	LastAccessedAt *time.Time     `json:"lastAccessedAt"`
+	LastTask       *WorkspaceTask `json:"lastTask"`
+}
+
+// LastActivity returns when the workspace was last used: the end of its
+// last task or its last selection, whichever is later.
+func (w Workspace) LastActivity() time.Time {
+	var last time.Time
+	if w.LastAccessedAt != nil {
+		last = *w.LastAccessedAt
+	}
+	if w.LastTask != nil && w.LastTask.CompletedAt != nil && w.LastTask.CompletedAt.After(last) {
+		last = *w.LastTask.CompletedAt
+	}
+	return last
+}
+
+// Stale reports whether the workspace has had no activity for longer than
+// age. Workspaces with no recorded activity count as stale.
+func (w Workspace) Stale(now time.Time, age time.Duration) bool {
+	return !w.IsActive && now.Sub(w.LastActivity()) > age
+}
+
+// GetWorkspaces fetches the workspaces in the kennel
+func (c *Client) GetWorkspaces() ([]Workspace, error) {
+	req, err := c.newRequest("GET", "/api/workspaces", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Workspaces []Workspace `json:"workspaces"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Workspaces, nil
+}
+
+// GetWorkspaceDiff fetches the uncommitted changes in a workspace, or the
+// last commit when the tree is clean, as unified diff text
+func (c *Client) GetWorkspaceDiff(id string) (string, error) {
+	req, err := c.newRequest("GET", "/api/workspaces/"+url.PathEscape(id)+"/diff", nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", workspaceError(resp, "diff")
+	}
+
+	var result struct {
+		Diff string `json:"diff"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Diff, nil
+}
+
+// DeleteWorkspace removes a workspace directory from the kennel
+func (c *Client) DeleteWorkspace(id string) error {
+	req, err := c.newRequest("DELETE", "/api/workspaces/"+url.PathEscape(id), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to bridge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return workspaceError(resp, "delete")
+	}
+}
+
+// workspaceError turns a failed response into an error, preferring the
+// bridge's message.
+func workspaceError(resp *http.Response, action string) error {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&body) == nil && body.Message != "" {
+		return fmt.Errorf("%s failed: %s", action, strings.TrimSpace(body.Message))
+	}
+	return fmt.Errorf("%s failed: status %d", action, resp.StatusCode)
+}
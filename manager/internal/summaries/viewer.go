@@ -0,0 +1,308 @@
+// Package summaries provides a read-only viewer for the conversation
+// summaries the bridge keeps in place of older messages.
+package summaries
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// expandedLines caps how much of the selected summary is shown.
+const expandedLines = 12
+
+// LoadedMsg is sent when the summaries have been fetched.
+type LoadedMsg struct {
+	Response *status.SummariesResponse
+	Err      error
+}
+
+// Viewer lists stored summaries per session, newest first, with a search
+// over their text.
+type Viewer struct {
+	client    *status.Client
+	all       []status.ConversationSummary
+	failures  []status.SummaryFailure
+	shown     []status.ConversationSummary // all, filtered by query
+	cursor    int
+	offset    int
+	height    int
+	loading   bool
+	err       error
+	query     string
+	searching bool
+}
+
+// NewViewer creates a summary viewer backed by the given bridge client.
+func NewViewer(client *status.Client) *Viewer {
+	return &Viewer{client: client, loading: true, height: 20}
+}
+
+// Init fetches the summaries.
+func (v *Viewer) Init() tea.Cmd {
+	return v.fetchCmd()
+}
+
+func (v *Viewer) fetchCmd() tea.Cmd {
+	client := v.client
+	return func() tea.Msg {
+		resp, err := client.GetSummaries()
+		return LoadedMsg{Response: resp, Err: err}
+	}
+}
+
+// IsEditing reports whether the search prompt takes keys.
+func (v *Viewer) IsEditing() bool {
+	return v.searching
+}
+
+// SetHeight sets the lines available to the viewer.
+func (v *Viewer) SetHeight(height int) {
+	v.height = max(8, height)
+	v.ensureVisible()
+}
+
+// Update handles summary messages and keyboard input.
+func (v *Viewer) Update(msg tea.Msg) (*Viewer, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		v.loading = false
+		v.err = msg.Err
+		if msg.Err == nil {
+			v.all = msg.Response.Summaries
+			v.failures = msg.Response.Failures
+			// Group by session, newest first within each
+			sort.SliceStable(v.all, func(i, j int) bool {
+				if v.all[i].SessionID != v.all[j].SessionID {
+					return v.all[i].SessionID < v.all[j].SessionID
+				}
+				return v.all[i].CreatedAt.After(v.all[j].CreatedAt)
+			})
+			sort.SliceStable(v.failures, func(i, j int) bool {
+				return v.failures[i].At.After(v.failures[j].At)
+			})
+			v.applyFilter()
+		}
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.searching {
+			v.updateSearch(msg)
+			return v, nil
+		}
+		return v, v.handleKey(msg)
+	}
+	return v, nil
+}
+
+func (v *Viewer) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case "down", "j":
+		if v.cursor < len(v.shown)-1 {
+			v.cursor++
+		}
+	case "home", "g":
+		v.cursor = 0
+	case "end", "G":
+		v.cursor = max(0, len(v.shown)-1)
+	case "/":
+		v.searching = true
+	case "r":
+		v.loading = true
+		return v.fetchCmd()
+	}
+	v.ensureVisible()
+	return nil
+}
+
+// updateSearch edits the query, filtering as it is typed.
+func (v *Viewer) updateSearch(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "esc":
+		v.query = ""
+		v.searching = false
+	case "enter":
+		v.searching = false
+	case "backspace":
+		if r := []rune(v.query); len(r) > 0 {
+			v.query = string(r[:len(r)-1])
+		}
+	default:
+		if msg.Type == tea.KeyRunes {
+			v.query += string(msg.Runes)
+		}
+	}
+	v.applyFilter()
+}
+
+// applyFilter keeps the summaries whose text or session contains every
+// word of the query.
+func (v *Viewer) applyFilter() {
+	words := strings.Fields(strings.ToLower(v.query))
+	v.shown = v.shown[:0]
+	for _, s := range v.all {
+		text := strings.ToLower(s.Content + " " + s.SessionID)
+		match := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			v.shown = append(v.shown, s)
+		}
+	}
+	v.cursor = min(v.cursor, max(0, len(v.shown)-1))
+	v.ensureVisible()
+}
+
+// listHeight is the number of summary rows that fit beside the header,
+// the failures and the expanded summary.
+func (v *Viewer) listHeight() int {
+	return max(3, v.height-expandedLines-6)
+}
+
+func (v *Viewer) ensureVisible() {
+	n := v.listHeight()
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+n {
+		v.offset = v.cursor - n + 1
+	}
+}
+
+// HelpKeys returns the help bar entries for the current view.
+func (v *Viewer) HelpKeys() []string {
+	if v.searching {
+		return []string{"Enter Done", "Esc Clear"}
+	}
+	return []string{"↑/↓ Navigate", "/ Search", "r Refresh", "Esc Back"}
+}
+
+// shortID trims a session ID such as "ses_abc123…" to a readable prefix.
+func shortID(id string) string {
+	if r := []rune(id); len(r) > 16 {
+		return string(r[:15]) + "…"
+	}
+	return id
+}
+
+// oneLine flattens s and shortens it to at most n runes.
+func oneLine(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if n <= 1 || len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// View renders the summaries for the given width.
+func (v *Viewer) View(width int) string {
+	var s strings.Builder
+
+	switch {
+	case v.loading && v.all == nil:
+		s.WriteString(theme.StatusInfo().Render("   Loading summaries...") + "\n")
+		return s.String()
+	case v.err != nil && v.all == nil:
+		s.WriteString(theme.StatusError().Render("   ● Summary API unavailable") + "\n")
+		s.WriteString(theme.Subtitle().Render("   "+v.err.Error()) + "\n")
+		return s.String()
+	}
+
+	sessions := map[string]bool{}
+	for _, sm := range v.all {
+		sessions[sm.SessionID] = true
+	}
+	s.WriteString("   " + theme.Subtitle().Render(fmt.Sprintf("%d summaries across %d sessions", len(v.all), len(sessions))) + "\n")
+
+	// Summarizer failures first; they mean the agent is forgetting context
+	if n := len(v.failures); n > 0 {
+		last := v.failures[0]
+		s.WriteString(theme.StatusError().Render(fmt.Sprintf("   ● %d summarizer failures · last %s: %s",
+			n, last.At.Local().Format("Jan 2 15:04"), oneLine(last.Error, max(20, width-50)))) + "\n")
+	}
+
+	if v.searching || v.query != "" {
+		cursor := ""
+		if v.searching {
+			cursor = "█"
+		}
+		s.WriteString("   " + theme.Value().Bold(true).Render("Search: ") + theme.StatusInfo().Render(v.query+cursor))
+		s.WriteString(theme.Muted().Render(fmt.Sprintf("  %d matching", len(v.shown))) + "\n")
+	}
+	s.WriteString("\n")
+
+	if len(v.shown) == 0 {
+		if len(v.all) == 0 {
+			s.WriteString(theme.Subtitle().Render("   No summaries yet. The bridge writes one after a long stretch of conversation.") + "\n")
+		} else {
+			s.WriteString(theme.Subtitle().Render("   No summaries match.") + "\n")
+		}
+		return s.String()
+	}
+
+	end := min(len(v.shown), v.offset+v.listHeight())
+	prevSession := ""
+	if v.offset > 0 {
+		prevSession = v.shown[v.offset-1].SessionID
+	}
+	for i := v.offset; i < end; i++ {
+		sm := v.shown[i]
+		prefix := "   "
+		textStyle := theme.Value()
+		if i == v.cursor {
+			prefix = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render(" ▸ ")
+			textStyle = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true)
+		}
+		session := ""
+		if sm.SessionID != prevSession {
+			session = shortID(sm.SessionID)
+		}
+		prevSession = sm.SessionID
+		s.WriteString(fmt.Sprintf("%s%s %s %s\n",
+			prefix,
+			theme.StatusInfo().Width(17).Render(session),
+			theme.Muted().Width(13).Render(sm.CreatedAt.Local().Format("Jan 2 15:04")),
+			textStyle.Render(oneLine(sm.Content, max(10, width-40)))))
+	}
+	if len(v.shown) > v.listHeight() {
+		s.WriteString(theme.Muted().Render(fmt.Sprintf("   %d–%d of %d", v.offset+1, end, len(v.shown))) + "\n")
+	}
+
+	// Full text of the selected summary
+	if v.cursor < len(v.shown) {
+		sm := v.shown[v.cursor]
+		s.WriteString("\n")
+		header := sm.SessionID
+		if sm.ThreadID != "" {
+			header += " • thread " + sm.ThreadID
+		}
+		header += " • " + sm.CreatedAt.Local().Format(time.RFC1123)
+		s.WriteString("   " + theme.Muted().Render(header) + "\n")
+		wrapped := lipgloss.NewStyle().Width(max(20, width-8)).Render(strings.TrimSpace(sm.Content))
+		lines := strings.Split(wrapped, "\n")
+		if len(lines) > expandedLines {
+			lines = append(lines[:expandedLines-1], "…")
+		}
+		for _, line := range lines {
+			s.WriteString("   " + theme.Value().Render(line) + "\n")
+		}
+	}
+
+	return s.String()
+}
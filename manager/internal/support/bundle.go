@@ -0,0 +1,154 @@
+// Package support writes support bundles: a zip of what a maintainer asks
+// for first on a GitHub issue, with secrets and phone numbers redacted.
+// Bundles are kept in the project's data/ directory.
+package support
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/config"
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/redact"
+)
+
+const (
+	prefix     = "support-bundle-"
+	timeLayout = "20060102-150405"
+	// logLines is how much of each container's log is included
+	logLines = 500
+)
+
+// Dir returns the directory bundles are written to.
+func Dir() string {
+	return filepath.Join(paths.ProjectDir, "data")
+}
+
+// Create collects a bundle into a new zip in Dir and returns its path. A
+// part that can't be collected, such as logs while Docker is down, is
+// replaced by a note saying why, so the bundle is written anyway. The zip is
+// written to a temporary file first so a failed bundle never looks complete.
+func Create(info components.VersionInfo) (string, error) {
+	if err := os.MkdirAll(Dir(), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", Dir(), err)
+	}
+
+	now := time.Now()
+	path := filepath.Join(Dir(), prefix+now.Format(timeLayout)+".zip")
+	tmp, err := os.CreateTemp(Dir(), ".support-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp, info, now); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to save bundle: %w", err)
+	}
+	return path, nil
+}
+
+// write streams the bundle into w.
+func write(w io.Writer, info components.VersionInfo, now time.Time) error {
+	settings, settingsErr := config.Settings(nil, false)
+	redactor, _ := config.Redactor()
+
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"manager.txt", managerInfo(info, now)},
+		{"compose-ps.txt", composePS()},
+		{"config.txt", configDump(settings, settingsErr)},
+		{"bridge.log", redactor.String(containerLogs("fetch-bridge"))},
+		{"kennel.log", redactor.String(containerLogs("fetch-kennel"))},
+	}
+	for _, f := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", f.name, err)
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return fmt.Errorf("failed to add %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+// managerInfo describes the manager build and the machine it runs on.
+func managerInfo(info components.VersionInfo, now time.Time) string {
+	dockerVersion, err := docker.DaemonVersion()
+	if err != nil {
+		dockerVersion = "unavailable (" + err.Error() + ")"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Fetch Manager %s\n", info.Label())
+	fmt.Fprintf(&b, "Commit:     %s\n", info.GitCommit)
+	fmt.Fprintf(&b, "Built:      %s\n", info.BuildDate)
+	fmt.Fprintf(&b, "Go:         %s\n", info.GoVersion)
+	fmt.Fprintf(&b, "OS:         %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Docker:     %s\n", dockerVersion)
+	fmt.Fprintf(&b, "Generated:  %s\n", now.Format(time.RFC3339))
+	return b.String()
+}
+
+// composePS lists the compose containers, or why it couldn't.
+func composePS() string {
+	out, err := docker.ComposePS()
+	if err != nil {
+		return "docker compose ps failed: " + err.Error() + "\n"
+	}
+	return out
+}
+
+// containerLogs returns the end of a container's log, or why it couldn't.
+func containerLogs(name string) string {
+	lines, err := docker.TailLogs(name, logLines)
+	if err != nil {
+		return fmt.Sprintf("logs for %s unavailable: %v\n", name, err)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// configDump lists every setting with secrets and personal values withheld,
+// in the format of fetch-manager config get.
+func configDump(settings []config.Setting, err error) string {
+	if err != nil {
+		return "settings unavailable: " + err.Error() + "\n"
+	}
+	var b strings.Builder
+	for _, s := range settings {
+		line := s.Key + "="
+		switch {
+		case s.Redacted || s.Value != "" && config.IsPersonal(s.Key):
+			line += redact.Mask
+		case s.Value == "" && s.Default != "":
+			line += "  # default " + s.Default
+		default:
+			line += s.Value
+		}
+		if s.Source != "" {
+			line += "  [" + s.Source + "]"
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
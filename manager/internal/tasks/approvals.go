@@ -0,0 +1,266 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// Replies sent to a harness that asked to go ahead.
+const (
+	approveReply = "yes"
+	denyReply    = "no"
+)
+
+// ApprovalsLoadedMsg is sent when the pending approvals have been fetched.
+type ApprovalsLoadedMsg struct {
+	Tasks []status.Task
+	Err   error
+}
+
+// ApprovalMsg is sent when an approve or deny reply has been delivered.
+type ApprovalMsg struct {
+	TaskID   string
+	Approved bool
+	Err      error
+}
+
+// ApprovalsTickMsg triggers a periodic refresh while the approvals are open.
+type ApprovalsTickMsg time.Time
+
+// Approvals lists tasks stopped on a harness question, such as asking to
+// run a shell command or push, and answers them like a WhatsApp reply.
+type Approvals struct {
+	client  *status.Client
+	pending []status.Task
+	cursor  int
+	loading bool
+	err     error
+	message string
+	msgErr  bool
+}
+
+// NewApprovals creates an approvals list backed by the given bridge client.
+func NewApprovals(client *status.Client) *Approvals {
+	return &Approvals{client: client, loading: true}
+}
+
+// Init fetches the pending approvals and starts the refresh loop.
+func (a *Approvals) Init() tea.Cmd {
+	return tea.Batch(a.fetchCmd(), approvalsTickCmd())
+}
+
+func approvalsTickCmd() tea.Cmd {
+	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
+		return ApprovalsTickMsg(t)
+	})
+}
+
+func (a *Approvals) fetchCmd() tea.Cmd {
+	client := a.client
+	return func() tea.Msg {
+		all, err := client.GetTasks()
+		return ApprovalsLoadedMsg{Tasks: all, Err: err}
+	}
+}
+
+func (a *Approvals) replyCmd(task status.Task, approve bool) tea.Cmd {
+	client := a.client
+	return func() tea.Msg {
+		reply := denyReply
+		if approve {
+			reply = approveReply
+		}
+		return ApprovalMsg{TaskID: task.ID, Approved: approve, Err: client.RespondToTask(task.ID, reply)}
+	}
+}
+
+// Count returns how many tasks are waiting on an answer.
+func (a *Approvals) Count() int {
+	return len(a.pending)
+}
+
+// Update handles approval messages and keyboard input.
+func (a *Approvals) Update(msg tea.Msg) (*Approvals, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ApprovalsLoadedMsg:
+		a.loading = false
+		a.err = msg.Err
+		if msg.Err == nil {
+			a.setPending(msg.Tasks)
+		}
+		return a, nil
+
+	case ApprovalMsg:
+		if msg.Err != nil {
+			a.message, a.msgErr = msg.Err.Error(), true
+		} else if msg.Approved {
+			a.message, a.msgErr = "Approved "+msg.TaskID, false
+		} else {
+			a.message, a.msgErr = "Denied "+msg.TaskID, false
+		}
+		return a, a.fetchCmd()
+
+	case ApprovalsTickMsg:
+		return a, tea.Batch(a.fetchCmd(), approvalsTickCmd())
+
+	case tea.KeyMsg:
+		return a.handleKey(msg)
+	}
+	return a, nil
+}
+
+func (a *Approvals) handleKey(msg tea.KeyMsg) (*Approvals, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if a.cursor > 0 {
+			a.cursor--
+		}
+	case "down", "j":
+		if a.cursor < len(a.pending)-1 {
+			a.cursor++
+		}
+	case "r":
+		a.loading = true
+		return a, a.fetchCmd()
+	case "y", "a":
+		if task, ok := a.selected(); ok {
+			return a, a.replyCmd(task, true)
+		}
+	case "n", "d":
+		if task, ok := a.selected(); ok {
+			return a, a.replyCmd(task, false)
+		}
+	}
+	return a, nil
+}
+
+// setPending keeps the tasks waiting on a question, oldest first so
+// approvals are answered in the order they were asked, keeping the cursor
+// on the same task where possible.
+func (a *Approvals) setPending(all []status.Task) {
+	var selectedID string
+	if task, ok := a.selected(); ok {
+		selectedID = task.ID
+	}
+
+	a.pending = a.pending[:0]
+	for _, t := range all {
+		if t.Status == "waiting_input" {
+			a.pending = append(a.pending, t)
+		}
+	}
+
+	a.cursor = 0
+	for i, t := range a.pending {
+		if t.ID == selectedID {
+			a.cursor = i
+			break
+		}
+	}
+}
+
+func (a *Approvals) selected() (status.Task, bool) {
+	if a.cursor >= 0 && a.cursor < len(a.pending) {
+		return a.pending[a.cursor], true
+	}
+	return status.Task{}, false
+}
+
+// HelpKeys returns the help bar entries for the current selection.
+func (a *Approvals) HelpKeys() []string {
+	keys := []string{"↑/↓ Navigate"}
+	if _, ok := a.selected(); ok {
+		keys = append(keys, "y Approve", "n Deny")
+	}
+	return append(keys, "r Refresh", "Esc Back")
+}
+
+// approvalKind names what a harness question asks to do.
+func approvalKind(question string) string {
+	q := strings.ToLower(question)
+	switch {
+	case strings.Contains(q, "git push") || strings.Contains(q, "push to"):
+		return "git push"
+	case strings.Contains(q, "delete") || strings.Contains(q, "remove") || strings.Contains(q, "rm -"):
+		return "delete"
+	case strings.Contains(q, "run") || strings.Contains(q, "execute") || strings.Contains(q, "command") ||
+		strings.Contains(q, "$ "):
+		return "shell command"
+	default:
+		return "question"
+	}
+}
+
+// View renders the pending approvals for the given width.
+func (a *Approvals) View(width int) string {
+	var s strings.Builder
+
+	switch {
+	case a.loading && a.pending == nil:
+		s.WriteString(theme.StatusInfo().Render("   Loading approvals...") + "\n")
+		return s.String()
+	case a.err != nil && a.pending == nil:
+		s.WriteString(theme.StatusError().Render("   ● Task API unavailable") + "\n")
+		s.WriteString(theme.Subtitle().Render("   "+a.err.Error()) + "\n")
+		return s.String()
+	case len(a.pending) == 0:
+		s.WriteString(theme.StatusSuccess().Render("   ✓ Nothing is waiting for approval.") + "\n")
+		s.WriteString(theme.Subtitle().Render("   This list refreshes on its own while it is open.") + "\n")
+	default:
+		s.WriteString("   " + theme.Subtitle().Render(fmt.Sprintf("%d waiting for an answer", len(a.pending))) + "\n\n")
+	}
+
+	textWidth := max(20, width-10)
+	now := time.Now()
+	for i, t := range a.pending {
+		prefix := "   "
+		goalStyle := theme.Value()
+		if i == a.cursor {
+			prefix = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render(" ▸ ")
+			goalStyle = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true)
+		}
+		agent := t.Agent
+		if agent == "" {
+			agent = "auto"
+		}
+		s.WriteString(fmt.Sprintf("%s%s %s %s %s\n",
+			prefix,
+			theme.StatusWarning().Width(15).Render("? "+approvalKind(t.Question)),
+			theme.Muted().Width(9).Render(agent),
+			theme.Muted().Width(8).Render(formatDuration(t.Duration(now))),
+			goalStyle.Render(truncate(t.Goal, max(10, width-46)))))
+
+		question := t.Question
+		if question == "" {
+			question = "(the agent did not say what it is asking)"
+		}
+		indent := "      "
+		s.WriteString(indent + theme.Value().Render(truncate(question, textWidth)) + "\n")
+		if i == a.cursor {
+			s.WriteString(indent + theme.Muted().Render(t.ID))
+			if t.Workspace != "" {
+				s.WriteString(theme.Muted().Render(" • " + t.Workspace))
+			}
+			s.WriteString("\n")
+		}
+	}
+
+	if a.message != "" {
+		s.WriteString("\n")
+		if a.msgErr {
+			s.WriteString(theme.StatusError().Render("   ❌ " + a.message))
+		} else {
+			s.WriteString(theme.StatusSuccess().Render("   ✅ " + a.message))
+		}
+		s.WriteString("\n")
+	}
+
+	return s.String()
+}
@@ -0,0 +1,355 @@
+// Package tasks provides the kennel task queue dashboard.
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// refreshInterval is how often the board re-polls the task API while open.
+const refreshInterval = 3 * time.Second
+
+// LoadedMsg is sent when the task list has been fetched.
+type LoadedMsg struct {
+	Tasks []status.Task
+	Err   error
+}
+
+// ActionMsg is sent when a cancel or retry request completes.
+type ActionMsg struct {
+	Action string
+	TaskID string
+	Err    error
+}
+
+// TickMsg triggers a periodic refresh while the board is open.
+type TickMsg time.Time
+
+// Board lists pending, running, and finished tasks with cancel/retry actions.
+type Board struct {
+	client  *status.Client
+	tasks   []status.Task
+	table   *components.Table
+	loading bool
+	err     error
+	message string
+	msgErr  bool
+}
+
+// NewBoard creates a task board backed by the given bridge client.
+func NewBoard(client *status.Client) *Board {
+	b := &Board{client: client, loading: true}
+	b.table = components.NewTable(
+		components.TableColumn{Title: "Status", Width: 12, Less: b.lessStatus},
+		components.TableColumn{Title: "Agent", Width: 9},
+		components.TableColumn{Title: "Time", Width: 8, Less: b.lessDuration},
+		components.TableColumn{Title: "Goal", Width: 28},
+	)
+	return b
+}
+
+// Init fetches the task list and starts the refresh loop.
+func (b *Board) Init() tea.Cmd {
+	return tea.Batch(b.fetchCmd(), tickCmd())
+}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
+		return TickMsg(t)
+	})
+}
+
+func (b *Board) fetchCmd() tea.Cmd {
+	client := b.client
+	return func() tea.Msg {
+		tasks, err := client.GetTasks()
+		return LoadedMsg{Tasks: tasks, Err: err}
+	}
+}
+
+func (b *Board) actionCmd(action string, task status.Task) tea.Cmd {
+	client := b.client
+	return func() tea.Msg {
+		var err error
+		if action == "cancel" {
+			err = client.CancelTask(task.ID)
+		} else {
+			err = client.RetryTask(task.ID)
+		}
+		return ActionMsg{Action: action, TaskID: task.ID, Err: err}
+	}
+}
+
+// Update handles task messages and keyboard input.
+func (b *Board) Update(msg tea.Msg) (*Board, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		b.loading = false
+		b.err = msg.Err
+		if msg.Err == nil {
+			b.setTasks(msg.Tasks)
+		}
+		return b, nil
+
+	case ActionMsg:
+		if msg.Err != nil {
+			b.message = msg.Err.Error()
+			b.msgErr = true
+		} else {
+			if msg.Action == "cancel" {
+				b.message = "Cancellation requested for " + msg.TaskID
+			} else {
+				b.message = "Retry queued for " + msg.TaskID
+			}
+			b.msgErr = false
+		}
+		return b, b.fetchCmd()
+
+	case TickMsg:
+		return b, tea.Batch(b.fetchCmd(), tickCmd())
+
+	case tea.KeyMsg:
+		return b.handleKey(msg)
+	}
+	return b, nil
+}
+
+func (b *Board) handleKey(msg tea.KeyMsg) (*Board, tea.Cmd) {
+	if b.table.Update(msg) {
+		return b, nil
+	}
+
+	switch msg.String() {
+	case "r":
+		b.loading = true
+		return b, b.fetchCmd()
+	case "c":
+		if task, ok := b.selected(); ok && task.IsActive() {
+			return b, b.actionCmd("cancel", task)
+		}
+	case "R":
+		if task, ok := b.selected(); ok && task.CanRetry() {
+			return b, b.actionCmd("retry", task)
+		}
+	}
+	return b, nil
+}
+
+// setTasks stores tasks with active ones first, newest first within each
+// group, unless the table is sorted by a column. The selection stays on
+// the same task where possible.
+func (b *Board) setTasks(tasks []status.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].IsActive() != tasks[j].IsActive() {
+			return tasks[i].IsActive()
+		}
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+	b.tasks = tasks
+	b.syncTable(time.Now())
+}
+
+// syncTable rebuilds the table rows from the tasks, with run times as of
+// now.
+func (b *Board) syncTable(now time.Time) {
+	rows := make([]components.TableRow, 0, len(b.tasks))
+	for _, t := range b.tasks {
+		label, _ := statusBadge(t.Status)
+		agent := t.Agent
+		if agent == "" {
+			agent = "auto"
+		}
+		rows = append(rows, components.TableRow{Key: t.ID, Cells: []string{
+			label, agent, formatDuration(t.Duration(now)), strings.Join(strings.Fields(t.Goal), " "),
+		}})
+	}
+	b.table.SetRows(rows)
+}
+
+func (b *Board) task(id string) (status.Task, bool) {
+	for _, t := range b.tasks {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return status.Task{}, false
+}
+
+func (b *Board) selected() (status.Task, bool) {
+	return b.task(b.table.SelectedKey())
+}
+
+// lessStatus orders active tasks before finished ones, then by status.
+func (b *Board) lessStatus(x, y components.TableRow) bool {
+	tx, _ := b.task(x.Key)
+	ty, _ := b.task(y.Key)
+	if tx.IsActive() != ty.IsActive() {
+		return tx.IsActive()
+	}
+	return tx.Status < ty.Status
+}
+
+// lessDuration orders tasks by how long they ran, shortest first.
+func (b *Board) lessDuration(x, y components.TableRow) bool {
+	tx, _ := b.task(x.Key)
+	ty, _ := b.task(y.Key)
+	now := time.Now()
+	return tx.Duration(now) < ty.Duration(now)
+}
+
+// Links returns a numbered link to each task's pull request, in the order
+// of the task list.
+func (b *Board) Links() []components.Link {
+	var links []components.Link
+	for _, t := range b.tasks {
+		if u := t.PullRequestURL(); u != "" {
+			links = append(links, components.Link{Label: "PR " + t.ID, URL: u})
+		}
+	}
+	return links
+}
+
+// HelpKeys returns the help bar entries for the current selection.
+func (b *Board) HelpKeys() []string {
+	keys := []string{"↑/↓ Navigate", "s/S Sort"}
+	if task, ok := b.selected(); ok {
+		if task.IsActive() {
+			keys = append(keys, "c Cancel")
+		}
+		if task.CanRetry() {
+			keys = append(keys, "R Retry")
+		}
+	}
+	for _, t := range b.tasks {
+		if t.Status == "waiting_input" {
+			keys = append(keys, "a Approvals")
+			break
+		}
+	}
+	keys = append(keys, components.LinkHelp(len(b.Links()))...)
+	return append(keys, "r Refresh", "Esc Back")
+}
+
+// statusBadge returns the icon and style for a task status.
+func statusBadge(s string) (string, lipgloss.Style) {
+	switch s {
+	case "running":
+		return "▶ running", theme.StatusInfo()
+	case "pending":
+		return "◌ pending", theme.Muted()
+	case "waiting_input":
+		return "? waiting", theme.StatusWarning()
+	case "paused":
+		return "‖ paused", theme.StatusWarning()
+	case "completed":
+		return "✓ done", theme.StatusSuccess()
+	case "failed":
+		return "✗ failed", theme.StatusError()
+	case "cancelled":
+		return "⊘ cancelled", theme.Muted()
+	default:
+		return s, theme.Value()
+	}
+}
+
+// formatDuration renders a duration compactly ("45s", "3m12s", "1h05m").
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "—"
+	}
+	d = d.Round(time.Second)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh%02dm", h, m)
+	case m > 0:
+		return fmt.Sprintf("%dm%02ds", m, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
+
+// truncate shortens s to at most n runes, adding an ellipsis.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if n <= 1 || len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// View renders the task list for the given width.
+func (b *Board) View(width int) string {
+	var s strings.Builder
+
+	switch {
+	case b.loading && b.tasks == nil:
+		s.WriteString(theme.StatusInfo().Render("   Loading tasks...") + "\n")
+		return s.String()
+	case b.err != nil && b.tasks == nil:
+		s.WriteString(theme.StatusError().Render("   ● Task API unavailable") + "\n")
+		s.WriteString(theme.Subtitle().Render("   "+b.err.Error()) + "\n")
+		return s.String()
+	case len(b.tasks) == 0:
+		s.WriteString(theme.Subtitle().Render("   No tasks yet. Ask Fetch to do something on WhatsApp!") + "\n")
+		return s.String()
+	}
+
+	active := 0
+	for _, t := range b.tasks {
+		if t.IsActive() {
+			active++
+		}
+	}
+	s.WriteString("   " + theme.Subtitle().Render(fmt.Sprintf("%d active, %d finished", active, len(b.tasks)-active)) + "\n\n")
+
+	b.syncTable(time.Now()) // Keep running times current
+	b.table.SetColumnWidth(3, max(10, width-52))
+	s.WriteString(lipgloss.NewStyle().MarginLeft(3).Render(b.table.View()) + "\n")
+
+	// Details for the selected task
+	if t, ok := b.selected(); ok {
+		indent := "      "
+		label, style := statusBadge(t.Status)
+		s.WriteString(indent + style.Render(label) + theme.Muted().Render(" • "+t.ID))
+		if t.Workspace != "" {
+			s.WriteString(theme.Muted().Render(" • " + t.Workspace))
+		}
+		if t.RetryCount > 0 {
+			s.WriteString(theme.Muted().Render(fmt.Sprintf(" • %d retries", t.RetryCount)))
+		}
+		s.WriteString("\n")
+		if out := t.LastOutput(); out != "" {
+			s.WriteString(indent + theme.Subtitle().Render(truncate(out, max(20, width-10))) + "\n")
+		}
+	}
+
+	// Pull requests the tasks opened
+	if links := b.Links(); len(links) > 0 {
+		s.WriteString("\n" + components.LinkList(links))
+	}
+
+	if b.message != "" {
+		s.WriteString("\n")
+		if b.msgErr {
+			s.WriteString(theme.StatusError().Render("   ❌ " + b.message))
+		} else {
+			s.WriteString(theme.StatusSuccess().Render("   ✅ " + b.message))
+		}
+		s.WriteString("\n")
+	}
+
+	return s.String()
+}
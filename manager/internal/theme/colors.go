@@ -1,38 +1,206 @@
 // Package theme provides a consistent design system for the Fetch TUI.
 package theme
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"strings"
 
-// Brand Colors
-var (
-	Primary   = lipgloss.Color("#FF6B35") // Fetch Orange
-	Secondary = lipgloss.Color("#00BFA5") // Teal Accent
+	"github.com/charmbracelet/lipgloss"
 )
 
-// Status Colors
-var (
-	Success = lipgloss.Color("#00E676") // Green
-	Warning = lipgloss.Color("#FFD600") // Yellow
-	Error   = lipgloss.Color("#FF5252") // Red
-	Info    = lipgloss.Color("#448AFF") // Blue
-)
+// Theme is a color palette. Components read colors from the active theme
+// when they render, so switching themes takes effect on the next frame.
+type Theme struct {
+	Name string
 
-// Neutral Colors
-var (
-	Background    = lipgloss.Color("#0D1117") // Dark background
-	Surface       = lipgloss.Color("#161B22") // Card/panel background
-	Border        = lipgloss.Color("#30363D") // Border color
-	BorderFocused = lipgloss.Color("#58A6FF") // Focused border
-	TextPrimary   = lipgloss.Color("#E6EDF3") // Primary text
-	TextSecondary = lipgloss.Color("#8B949E") // Secondary/muted text
-	TextMuted     = lipgloss.Color("#484F58") // Very muted text
-)
+	// Brand Colors
+	Primary   lipgloss.TerminalColor
+	Secondary lipgloss.TerminalColor
 
-// Gradient Colors (for progress bars, etc.)
-var (
-	GradientStart = lipgloss.Color("#FF6B35")
-	GradientEnd   = lipgloss.Color("#00BFA5")
-)
+	// Status Colors
+	Success lipgloss.TerminalColor
+	Warning lipgloss.TerminalColor
+	Error   lipgloss.TerminalColor
+	Info    lipgloss.TerminalColor
+
+	// Neutral Colors
+	Background    lipgloss.TerminalColor // Screen background
+	Surface       lipgloss.TerminalColor // Card/panel background
+	Border        lipgloss.TerminalColor // Border color
+	BorderFocused lipgloss.TerminalColor // Focused border
+	TextPrimary   lipgloss.TerminalColor // Primary text
+	TextSecondary lipgloss.TerminalColor // Secondary/muted text
+	TextMuted     lipgloss.TerminalColor // Very muted text
+
+	// Gradient Colors (for progress bars, etc.)
+	GradientStart lipgloss.TerminalColor
+	GradientEnd   lipgloss.TerminalColor
+}
+
+// Dark is the original Fetch palette for dark terminals.
+var Dark = Theme{
+	Name:          "dark",
+	Primary:       lipgloss.Color("#FF6B35"), // Fetch Orange
+	Secondary:     lipgloss.Color("#00BFA5"), // Teal Accent
+	Success:       lipgloss.Color("#00E676"),
+	Warning:       lipgloss.Color("#FFD600"),
+	Error:         lipgloss.Color("#FF5252"),
+	Info:          lipgloss.Color("#448AFF"),
+	Background:    lipgloss.Color("#0D1117"),
+	Surface:       lipgloss.Color("#161B22"),
+	Border:        lipgloss.Color("#30363D"),
+	BorderFocused: lipgloss.Color("#58A6FF"),
+	TextPrimary:   lipgloss.Color("#E6EDF3"),
+	TextSecondary: lipgloss.Color("#8B949E"),
+	TextMuted:     lipgloss.Color("#484F58"),
+	GradientStart: lipgloss.Color("#FF6B35"),
+	GradientEnd:   lipgloss.Color("#00BFA5"),
+}
+
+// Light keeps the brand colors but darkens them enough to read on a white
+// background.
+var Light = Theme{
+	Name:          "light",
+	Primary:       lipgloss.Color("#C2410C"),
+	Secondary:     lipgloss.Color("#00796B"),
+	Success:       lipgloss.Color("#1A7F37"),
+	Warning:       lipgloss.Color("#9A6700"),
+	Error:         lipgloss.Color("#CF222E"),
+	Info:          lipgloss.Color("#0969DA"),
+	Background:    lipgloss.Color("#FFFFFF"),
+	Surface:       lipgloss.Color("#F3F4F6"),
+	Border:        lipgloss.Color("#D0D7DE"),
+	BorderFocused: lipgloss.Color("#0969DA"),
+	TextPrimary:   lipgloss.Color("#1F2328"),
+	TextSecondary: lipgloss.Color("#59636E"),
+	TextMuted:     lipgloss.Color("#818B98"),
+	GradientStart: lipgloss.Color("#C2410C"),
+	GradientEnd:   lipgloss.Color("#00796B"),
+}
+
+// HighContrast uses pure, saturated colors on black for low vision and
+// washed-out displays.
+var HighContrast = Theme{
+	Name:          "high-contrast",
+	Primary:       lipgloss.Color("#FFAF00"),
+	Secondary:     lipgloss.Color("#00FFFF"),
+	Success:       lipgloss.Color("#00FF00"),
+	Warning:       lipgloss.Color("#FFFF00"),
+	Error:         lipgloss.Color("#FF5F5F"),
+	Info:          lipgloss.Color("#87AFFF"),
+	Background:    lipgloss.Color("#000000"),
+	Surface:       lipgloss.Color("#000000"),
+	Border:        lipgloss.Color("#FFFFFF"),
+	BorderFocused: lipgloss.Color("#FFFF00"),
+	TextPrimary:   lipgloss.Color("#FFFFFF"),
+	TextSecondary: lipgloss.Color("#FFFFFF"),
+	TextMuted:     lipgloss.Color("#D0D0D0"),
+	GradientStart: lipgloss.Color("#FFAF00"),
+	GradientEnd:   lipgloss.Color("#00FFFF"),
+}
+
+// Solarized is Ethan Schoonover's Solarized dark palette.
+var Solarized = Theme{
+	Name:          "solarized",
+	Primary:       lipgloss.Color("#CB4B16"), // orange
+	Secondary:     lipgloss.Color("#2AA198"), // cyan
+	Success:       lipgloss.Color("#859900"), // green
+	Warning:       lipgloss.Color("#B58900"), // yellow
+	Error:         lipgloss.Color("#DC322F"), // red
+	Info:          lipgloss.Color("#268BD2"), // blue
+	Background:    lipgloss.Color("#002B36"), // base03
+	Surface:       lipgloss.Color("#073642"), // base02
+	Border:        lipgloss.Color("#586E75"), // base01
+	BorderFocused: lipgloss.Color("#268BD2"),
+	TextPrimary:   lipgloss.Color("#93A1A1"), // base1
+	TextSecondary: lipgloss.Color("#839496"), // base0
+	TextMuted:     lipgloss.Color("#586E75"), // base01
+	GradientStart: lipgloss.Color("#CB4B16"),
+	GradientEnd:   lipgloss.Color("#2AA198"),
+}
+
+// Auto picks Light or Dark per color from the terminal's background, using
+// lipgloss adaptive colors.
+var Auto = adaptive("auto", Light, Dark)
+
+// themes lists the selectable themes in the order they are cycled.
+var themes = []*Theme{&Auto, &Dark, &Light, &HighContrast, &Solarized}
+
+var active = &Auto
+
+// Active returns the theme components should render with.
+func Active() *Theme {
+	return active
+}
+
+// Set makes the named theme active. An empty name selects auto.
+func Set(name string) error {
+	t, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+	active = t
+	return nil
+}
+
+// Lookup returns the named theme. An empty name selects auto.
+func Lookup(name string) (*Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return &Auto, nil
+	}
+	for _, t := range themes {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown theme %q (want one of %s)", name, strings.Join(Names(), ", "))
+}
+
+// Names lists the selectable theme names.
+func Names() []string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Next returns the name of the theme after the active one, wrapping around.
+func Next() string {
+	for i, t := range themes {
+		if t == active {
+			return themes[(i+1)%len(themes)].Name
+		}
+	}
+	return themes[0].Name
+}
+
+// adaptive combines two themes into one that follows the terminal
+// background.
+func adaptive(name string, light, dark Theme) Theme {
+	pick := func(l, d lipgloss.TerminalColor) lipgloss.TerminalColor {
+		return lipgloss.AdaptiveColor{Light: string(l.(lipgloss.Color)), Dark: string(d.(lipgloss.Color))}
+	}
+	return Theme{
+		Name:          name,
+		Primary:       pick(light.Primary, dark.Primary),
+		Secondary:     pick(light.Secondary, dark.Secondary),
+		Success:       pick(light.Success, dark.Success),
+		Warning:       pick(light.Warning, dark.Warning),
+		Error:         pick(light.Error, dark.Error),
+		Info:          pick(light.Info, dark.Info),
+		Background:    pick(light.Background, dark.Background),
+		Surface:       pick(light.Surface, dark.Surface),
+		Border:        pick(light.Border, dark.Border),
+		BorderFocused: pick(light.BorderFocused, dark.BorderFocused),
+		TextPrimary:   pick(light.TextPrimary, dark.TextPrimary),
+		TextSecondary: pick(light.TextSecondary, dark.TextSecondary),
+		TextMuted:     pick(light.TextMuted, dark.TextMuted),
+		GradientStart: pick(light.GradientStart, dark.GradientStart),
+		GradientEnd:   pick(light.GradientEnd, dark.GradientEnd),
+	}
+}
 
 // AdaptiveColor returns an adaptive color that changes based on light/dark mode
 func AdaptiveColor(light, dark string) lipgloss.AdaptiveColor {
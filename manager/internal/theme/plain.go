@@ -0,0 +1,25 @@
+// Package theme provides the accessibility (plain) display mode.
+package theme
+
+// plain is set in accessibility mode: no emoji or Braille art, words next
+// to every color cue, and simpler layouts for screen readers.
+var plain bool
+
+// SetPlain turns accessibility mode on or off.
+func SetPlain(on bool) {
+	plain = on
+}
+
+// Plain reports whether accessibility mode is on.
+func Plain() bool {
+	return plain
+}
+
+// Cue returns symbol normally and word in accessibility mode, for
+// indicators that would otherwise rely on color or shape alone.
+func Cue(symbol, word string) string {
+	if plain {
+		return word
+	}
+	return symbol
+}
@@ -6,89 +6,121 @@ import "github.com/charmbracelet/lipgloss"
 // ===== TEXT STYLES =====
 
 // Title is for main screen titles
-var Title = lipgloss.NewStyle().
-	Bold(true).
-	Foreground(Primary).
-	MarginBottom(1)
+func Title() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(Active().Primary).
+		MarginBottom(1)
+}
 
 // Subtitle is for secondary headings
-var Subtitle = lipgloss.NewStyle().
-	Foreground(TextSecondary).
-	Italic(true)
+func Subtitle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextSecondary).
+		Italic(true)
+}
 
 // Label is for form field labels
-var Label = lipgloss.NewStyle().
-	Foreground(TextSecondary).
-	Width(20)
+func Label() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextSecondary).
+		Width(20)
+}
 
 // Value is for field values
-var Value = lipgloss.NewStyle().
-	Foreground(TextPrimary)
+func Value() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextPrimary)
+}
 
 // Muted is for hints and help text
-var Muted = lipgloss.NewStyle().
-	Foreground(TextMuted).
-	Italic(true)
+func Muted() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextMuted).
+		Italic(true)
+}
 
 // Help is for keyboard shortcut hints
-var Help = lipgloss.NewStyle().
-	Foreground(TextMuted).
-	MarginTop(1)
+func Help() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextMuted).
+		MarginTop(1)
+}
 
 // ===== STATUS STYLES =====
 
 // StatusSuccess for success messages
-var StatusSuccess = lipgloss.NewStyle().
-	Foreground(Success).
-	Bold(true)
+func StatusSuccess() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Success).
+		Bold(true)
+}
 
 // StatusError for error messages
-var StatusError = lipgloss.NewStyle().
-	Foreground(Error).
-	Bold(true)
+func StatusError() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Error).
+		Bold(true)
+}
 
 // StatusWarning for warning messages
-var StatusWarning = lipgloss.NewStyle().
-	Foreground(Warning).
-	Bold(true)
+func StatusWarning() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Warning).
+		Bold(true)
+}
 
 // StatusInfo for info messages
-var StatusInfo = lipgloss.NewStyle().
-	Foreground(Info)
+func StatusInfo() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Info)
+}
 
 // StatusRunning for running state indicator
-var StatusRunning = lipgloss.NewStyle().
-	Foreground(Success).
-	SetString("● Running")
+func StatusRunning() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Success).
+		SetString("● Running")
+}
 
 // StatusStopped for stopped state indicator
-var StatusStopped = lipgloss.NewStyle().
-	Foreground(Error).
-	SetString("● Stopped")
+func StatusStopped() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Error).
+		SetString("● Stopped")
+}
 
 // StatusPartial for partial state indicator
-var StatusPartial = lipgloss.NewStyle().
-	Foreground(Warning).
-	SetString("● Partial")
+func StatusPartial() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Warning).
+		SetString("● Partial")
+}
 
 // ===== MENU STYLES =====
 
 // MenuItem is for unselected menu items
-var MenuItem = lipgloss.NewStyle().
-	Foreground(TextPrimary).
-	PaddingLeft(2)
+func MenuItem() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextPrimary).
+		PaddingLeft(2)
+}
 
 // MenuItemSelected is for the currently selected menu item
-var MenuItemSelected = lipgloss.NewStyle().
-	Foreground(Primary).
-	Bold(true).
-	PaddingLeft(0).
-	SetString("▸ ")
+func MenuItemSelected() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Primary).
+		Bold(true).
+		PaddingLeft(0).
+		SetString("▸ ")
+}
 
 // MenuItemDim is for disabled menu items
-var MenuItemDim = lipgloss.NewStyle().
-	Foreground(TextMuted).
-	PaddingLeft(2)
+func MenuItemDim() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextMuted).
+		PaddingLeft(2)
+}
 
 // ===== PANEL STYLES =====
 
@@ -96,7 +128,7 @@ var MenuItemDim = lipgloss.NewStyle().
 func Panel(width int) lipgloss.Style {
 	return lipgloss.NewStyle().
 		Border(PanelBorder).
-		BorderForeground(Border).
+		BorderForeground(Active().Border).
 		Padding(1, 2).
 		Width(width)
 }
@@ -105,7 +137,7 @@ func Panel(width int) lipgloss.Style {
 func PanelFocused(width int) lipgloss.Style {
 	return lipgloss.NewStyle().
 		Border(PanelBorder).
-		BorderForeground(Primary).
+		BorderForeground(Active().Primary).
 		Padding(1, 2).
 		Width(width)
 }
@@ -114,7 +146,7 @@ func PanelFocused(width int) lipgloss.Style {
 func PanelWithTitle(title string, width int) lipgloss.Style {
 	return lipgloss.NewStyle().
 		Border(PanelBorder).
-		BorderForeground(Border).
+		BorderForeground(Active().Border).
 		BorderTop(true).
 		BorderLeft(true).
 		BorderRight(true).
@@ -129,8 +161,8 @@ func PanelWithTitle(title string, width int) lipgloss.Style {
 func Header(width int) lipgloss.Style {
 	return lipgloss.NewStyle().
 		Bold(true).
-		Foreground(Primary).
-		Background(Surface).
+		Foreground(Active().Primary).
+		Background(Active().Surface).
 		Padding(0, 2).
 		Width(width).
 		Align(lipgloss.Center)
@@ -141,8 +173,8 @@ func Header(width int) lipgloss.Style {
 // StatusBar creates the bottom status bar style
 func StatusBar(width int) lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(TextSecondary).
-		Background(Surface).
+		Foreground(Active().TextSecondary).
+		Background(Active().Surface).
 		Padding(0, 2).
 		Width(width)
 }
@@ -150,39 +182,45 @@ func StatusBar(width int) lipgloss.Style {
 // ===== SPECIAL STYLES =====
 
 // QRBox for displaying QR codes
-var QRBox = lipgloss.NewStyle().
-	Border(PanelBorder).
-	BorderForeground(Primary).
-	Padding(1, 2).
-	Align(lipgloss.Center)
+func QRBox() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(PanelBorder).
+		BorderForeground(Active().Primary).
+		Padding(1, 2).
+		Align(lipgloss.Center)
+}
 
 // LogLine styles for log entries
-var (
-	LogDebug = lipgloss.NewStyle().Foreground(TextMuted)
-	LogInfo  = lipgloss.NewStyle().Foreground(Info)
-	LogWarn  = lipgloss.NewStyle().Foreground(Warning)
-	LogError = lipgloss.NewStyle().Foreground(Error)
-)
+func LogDebug() lipgloss.Style { return lipgloss.NewStyle().Foreground(Active().TextMuted) }
+func LogInfo() lipgloss.Style  { return lipgloss.NewStyle().Foreground(Active().Info) }
+func LogWarn() lipgloss.Style  { return lipgloss.NewStyle().Foreground(Active().Warning) }
+func LogError() lipgloss.Style { return lipgloss.NewStyle().Foreground(Active().Error) }
 
 // Category is for grouping headers (like in model selector)
-var Category = lipgloss.NewStyle().
-	Foreground(Secondary).
-	Bold(true).
-	MarginTop(1)
+func Category() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Secondary).
+		Bold(true).
+		MarginTop(1)
+}
 
 // Price is for cost information
-var Price = lipgloss.NewStyle().
-	Foreground(TextMuted)
+func Price() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextMuted)
+}
 
 // Current marks the currently selected/active item
-var Current = lipgloss.NewStyle().
-	Foreground(Warning).
-	Bold(true)
+func Current() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Warning).
+		Bold(true)
+}
 
 // Divider creates a horizontal line
 func Divider(width int) string {
 	return lipgloss.NewStyle().
-		Foreground(Border).
+		Foreground(Active().Border).
 		Width(width).
 		Render(repeatChar("─", width))
 }
@@ -199,27 +237,35 @@ func repeatChar(char string, n int) string {
 // ===== SPINNER STYLES =====
 
 // Spinner style for loading indicators
-var Spinner = lipgloss.NewStyle().
-	Foreground(Primary)
+func Spinner() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Primary)
+}
 
 // ===== TABLE STYLES =====
 
 // TableHeader for table column headers
-var TableHeader = lipgloss.NewStyle().
-	Bold(true).
-	Foreground(TextPrimary).
-	BorderStyle(lipgloss.NormalBorder()).
-	BorderBottom(true).
-	BorderForeground(Border).
-	Padding(0, 1)
+func TableHeader() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(Active().TextPrimary).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(Active().Border).
+		Padding(0, 1)
+}
 
 // TableCell for table data cells
-var TableCell = lipgloss.NewStyle().
-	Foreground(TextPrimary).
-	Padding(0, 1)
+func TableCell() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().TextPrimary).
+		Padding(0, 1)
+}
 
 // TableRowSelected for selected table rows
-var TableRowSelected = lipgloss.NewStyle().
-	Foreground(Primary).
-	Bold(true).
-	Padding(0, 1)
+func TableRowSelected() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(Active().Primary).
+		Bold(true).
+		Padding(0, 1)
+}
@@ -0,0 +1,278 @@
+// Package update provides git-based update functionality for Fetch.
+// This file handles self-updating the manager binary from GitHub Releases.
+package update
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// releasesURL is the GitHub API endpoint for the latest release.
+const releasesURL = "https://api.github.com/repos/Traves-Theberge/Fetch/releases/latest"
+
+// releasesListURL lists recent releases, newest first.
+const releasesListURL = "https://api.github.com/repos/Traves-Theberge/Fetch/releases?per_page=20"
+
+// checksumsAsset is the sha256sum-format file published with each release.
+const checksumsAsset = "checksums.txt"
+
+var httpClient = &http.Client{Timeout: 60 * time.Second}
+
+// Release is a published manager release.
+type Release struct {
+	Version string  `json:"tag_name"`
+	Name    string  `json:"name"`
+	Notes   string  `json:"body"`
+	URL     string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a downloadable file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// AssetName returns the binary name for the current platform, e.g.
+// "fetch-manager-linux-arm64" or "fetch-manager-windows-amd64.exe".
+func AssetName() string {
+	name := fmt.Sprintf("fetch-manager-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// fixedReleases replaces GitHub's answer when set by UseFixedReleases.
+var fixedReleases []Release
+
+// UseFixedReleases makes release lookups return releases, newest first,
+// instead of asking GitHub, for demo mode.
+func UseFixedReleases(releases []Release) {
+	fixedReleases = releases
+}
+
+// LatestRelease queries GitHub for the newest manager release.
+func LatestRelease() (*Release, error) {
+	if fixedReleases != nil {
+		if len(fixedReleases) == 0 {
+			return nil, fmt.Errorf("GitHub releases returned %d", http.StatusNotFound)
+		}
+		rel := fixedReleases[0]
+		return &rel, nil
+	}
+	var rel Release
+	if err := getJSON(releasesURL, &rel); err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// ReleasesSince returns the published releases newer than version, newest
+// first. An empty version returns only the latest release.
+func ReleasesSince(version string) ([]Release, error) {
+	all := append([]Release(nil), fixedReleases...)
+	if fixedReleases == nil {
+		if err := getJSON(releasesListURL, &all); err != nil {
+			return nil, err
+		}
+	}
+	if version == "" {
+		return all[:min(1, len(all))], nil
+	}
+	var newer []Release
+	for _, rel := range all {
+		if rel.Newer(version) {
+			newer = append(newer, rel)
+		}
+	}
+	return newer, nil
+}
+
+// getJSON fetches a GitHub API URL and decodes the response into v.
+func getJSON(url string, v any) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub releases returned %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode release: %w", err)
+	}
+	return nil
+}
+
+// asset finds an asset by name.
+func (r *Release) asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Newer reports whether the release is newer than current. Development
+// builds ("-dev", or git describe output past a tag) compare by their
+// base version.
+func (r *Release) Newer(current string) bool {
+	return compareVersions(r.Version, current) > 0
+}
+
+// compareVersions compares two "vMAJOR.MINOR.PATCH[-suffix]" strings,
+// returning -1, 0, or 1. Suffixes are ignored.
+func compareVersions(a, b string) int {
+	pa, pb := parseVersion(a), parseVersion(b)
+	for i := range pa {
+		switch {
+		case pa[i] > pb[i]:
+			return 1
+		case pa[i] < pb[i]:
+			return -1
+		}
+	}
+	return 0
+}
+
+func parseVersion(v string) [3]int {
+	var parts [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	for i, p := range strings.SplitN(v, ".", 3) {
+		parts[i], _ = strconv.Atoi(p)
+	}
+	return parts
+}
+
+// InstallRelease downloads the release binary for this platform, verifies
+// it against the release checksums, and replaces the running executable.
+// The previous binary is kept next to it with a ".old" suffix; if the swap
+// fails part-way the original is restored.
+func InstallRelease(rel *Release) error {
+	name := AssetName()
+	bin, ok := rel.asset(name)
+	if !ok {
+		return fmt.Errorf("release %s has no build for %s/%s", rel.Version, runtime.GOOS, runtime.GOARCH)
+	}
+	sums, ok := rel.asset(checksumsAsset)
+	if !ok {
+		return fmt.Errorf("release %s has no %s; refusing to install unverified binary", rel.Version, checksumsAsset)
+	}
+
+	want, err := fetchChecksum(sums.URL, name)
+	if err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate running binary: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("cannot resolve running binary: %w", err)
+	}
+
+	// Download next to the executable so the final rename stays on one filesystem
+	newPath := exe + ".new"
+	if err := download(bin.URL, newPath, want); err != nil {
+		os.Remove(newPath)
+		return err
+	}
+
+	return swapBinary(exe, newPath)
+}
+
+// fetchChecksum returns the expected sha256 for name from a checksums file.
+func fetchChecksum(url, name string) (string, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download checksums: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checksums download returned %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		// Format: "<sha256>  <filename>"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum listed for %s", name)
+}
+
+// download saves url to path, failing if the content's sha256 doesn't match.
+func download(url, path, wantSum string) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download binary: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("binary download returned %d", resp.StatusCode)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, h), resp.Body); err != nil {
+		f.Close()
+		return fmt.Errorf("download interrupted: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); got != wantSum {
+		return fmt.Errorf("checksum mismatch for downloaded binary (got %s, want %s)", got[:12], wantSum[:min(12, len(wantSum))])
+	}
+	return nil
+}
+
+// swapBinary moves exe aside to exe.old and newPath into its place,
+// restoring the original if the second rename fails.
+func swapBinary(exe, newPath string) error {
+	oldPath := exe + ".old"
+	os.Remove(oldPath)
+
+	if err := os.Rename(exe, oldPath); err != nil {
+		os.Remove(newPath)
+		return fmt.Errorf("cannot move current binary aside: %w", err)
+	}
+	if err := os.Rename(newPath, exe); err != nil {
+		if rbErr := os.Rename(oldPath, exe); rbErr != nil {
+			return fmt.Errorf("install failed (%v) and restore failed (%v); previous binary is at %s", err, rbErr, oldPath)
+		}
+		return fmt.Errorf("install failed, previous binary restored: %w", err)
+	}
+	return nil
+}
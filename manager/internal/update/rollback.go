@@ -0,0 +1,166 @@
+// Package update provides git-based update functionality for Fetch.
+// This file records the pre-update state and rolls back to it.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/runner"
+)
+
+// Checkpoint is the state of the installation before an update.
+type Checkpoint struct {
+	Commit     string            `json:"commit"`
+	Subject    string            `json:"subject"`
+	Images     map[string]string `json:"images"` // Image name → image ID
+	RecordedAt time.Time         `json:"recordedAt"`
+}
+
+// ShortCommit returns the abbreviated commit hash.
+func (c *Checkpoint) ShortCommit() string {
+	if len(c.Commit) > 7 {
+		return c.Commit[:7]
+	}
+	return c.Commit
+}
+
+// checkpointPath is where the last checkpoint is stored.
+func checkpointPath() string {
+	return filepath.Join(paths.ProjectDir, "data", "update-checkpoint.json")
+}
+
+// gitOutput runs a git command in the project directory.
+func gitOutput(args ...string) (string, error) {
+	cmd := runner.New("git", args...)
+	cmd.Dir = paths.ProjectDir
+	out, err := runner.Run(context.Background(), cmd)
+	return strings.TrimSpace(string(out)), err
+}
+
+// HeadCommit returns the short hash of the commit checked out in the
+// project directory.
+func HeadCommit() (string, error) {
+	return gitOutput("rev-parse", "--short", "HEAD")
+}
+
+// SaveCheckpoint records the current commit and the IDs of the compose
+// images so a later rollback can restore both.
+func SaveCheckpoint() error {
+	commit, err := gitOutput("rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("git rev-parse failed: %w", err)
+	}
+	subject, _ := gitOutput("log", "-1", "--format=%s", commit)
+
+	cp := Checkpoint{
+		Commit:     commit,
+		Subject:    subject,
+		Images:     composeImageIDs(),
+		RecordedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(checkpointPath()), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(checkpointPath(), data, 0644)
+}
+
+// LoadCheckpoint returns the last recorded checkpoint, or nil if no update
+// has been run yet.
+func LoadCheckpoint() (*Checkpoint, error) {
+	data, err := os.ReadFile(checkpointPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file: %w", err)
+	}
+	return &cp, nil
+}
+
+// composeImageIDs maps each image used by the compose project to its
+// current local image ID. Images that don't exist yet are skipped.
+func composeImageIDs() map[string]string {
+	ids := make(map[string]string)
+
+	cmd := runner.New("docker", "compose", "config", "--images")
+	cmd.Dir = paths.ProjectDir
+	out, err := runner.Run(context.Background(), cmd)
+	if err != nil {
+		return ids
+	}
+	for _, image := range strings.Fields(string(out)) {
+		id, err := runner.Output("docker", "image", "inspect", "-f", "{{.Id}}", image)
+		if err == nil {
+			ids[image] = strings.TrimSpace(string(id))
+		}
+	}
+	return ids
+}
+
+// StartRollback checks out the checkpoint's commit and restores its
+// images. If every recorded image still exists locally it is re-tagged,
+// which takes seconds; otherwise the images are rebuilt from the old code.
+func StartRollback(cp *Checkpoint) *Job {
+	steps := []Step{
+		{Name: "Checking out " + cp.ShortCommit(), Args: []string{"git", "checkout", "--detach", cp.Commit}},
+		{Name: "Restoring container images", Func: func(emit func(string)) error {
+			return restoreImages(cp, emit)
+		}},
+	}
+	return run(steps)
+}
+
+// restoreImages re-tags the checkpoint's images, falling back to a rebuild.
+func restoreImages(cp *Checkpoint, emit func(string)) error {
+	names := make([]string, 0, len(cp.Images))
+	for name := range cp.Images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	available := len(names) > 0
+	for _, name := range names {
+		if _, err := runner.Output("docker", "image", "inspect", cp.Images[name]); err != nil {
+			emit(fmt.Sprintf("Previous image for %s was pruned", name))
+			available = false
+		}
+	}
+
+	if !available {
+		emit("Rebuilding images from " + cp.ShortCommit() + "...")
+		return runCommand([]string{"docker", "compose", "build"}, emit)
+	}
+
+	for _, name := range names {
+		id := cp.Images[name]
+		short := strings.TrimPrefix(id, "sha256:")
+		if len(short) > 12 {
+			short = short[:12]
+		}
+		emit(fmt.Sprintf("Tagging %s → %s", short, name))
+		tag := runner.New("docker", "tag", id, name)
+		tag.Combined = true
+		if out, err := runner.Run(context.Background(), tag); err != nil {
+			return fmt.Errorf("docker tag %s: %s", name, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
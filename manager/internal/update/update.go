@@ -2,20 +2,175 @@
 package update
 
 import (
-	"os/exec"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
 
 	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/runner"
 )
 
-// PullAndRebuild performs a git pull and rebuilds Docker containers.
+// remoteBranch is the branch updates are pulled from.
+const remoteBranch = "main"
+
+// Step is one stage of an update. It either runs a command (Args) in the
+// project directory or calls Func, which reports output through emit.
+type Step struct {
+	Name string
+	Args []string
+	Func func(emit func(string)) error
+}
+
+// updateSteps are run in order by Start. Checking out main first means an
+// update also recovers from a rollback's detached HEAD.
+var updateSteps = []Step{
+	{Name: "Checking out " + remoteBranch, Args: []string{"git", "checkout", remoteBranch}},
+	{Name: "Pulling latest code", Args: []string{"git", "pull", "--ff-only", "origin", remoteBranch}},
+	{Name: "Rebuilding containers", Args: []string{"docker", "compose", "build"}},
+}
+
+// buildCommit is the commit docker compose stamps into the images it
+// builds, read when each step starts so a rebuild after a pull or rollback
+// records the new checkout.
+func buildCommit() string {
+	commit, err := HeadCommit()
+	if err != nil || commit == "" {
+		return "unknown"
+	}
+	return commit
+}
+
+// Event reports progress while a job runs. Exactly one event has Done
+// set, and it is always the last one sent before the channel closes.
+type Event struct {
+	Step int    // Index into the job's Steps
+	Line string // One line of command output
+	Done bool
+	Err  error // Set on the Done event if a step failed
+}
+
+// Job is a running update or rollback.
+type Job struct {
+	Steps  []Step
+	Events <-chan Event
+}
+
+// Change is a commit that an update would bring in.
+type Change struct {
+	Hash    string
+	Subject string
+}
+
+// PendingChanges fetches the remote and lists the commits between the
+// local checkout and origin/main, newest first.
+func PendingChanges() ([]Change, error) {
+	fetch := runner.New("git", "fetch", "origin", remoteBranch)
+	fetch.Dir, fetch.Combined = paths.ProjectDir, true
+	if out, err := runner.Run(context.Background(), fetch); err != nil {
+		return nil, fmt.Errorf("git fetch failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	out, err := gitOutput("log", "--oneline", "--no-decorate", "HEAD..origin/"+remoteBranch)
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	var changes []Change
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		hash, subject, _ := strings.Cut(line, " ")
+		changes = append(changes, Change{Hash: hash, Subject: subject})
+	}
+	return changes, nil
+}
+
+// LocalVersion returns the most recent tag reachable from the checkout,
+// or "" if the checkout has no tags.
+func LocalVersion() string {
+	tag, err := gitOutput("describe", "--tags", "--abbrev=0", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return tag
+}
+
+// Start records a rollback checkpoint and then pulls and rebuilds in the
+// background, streaming each step's output.
+func Start() (*Job, error) {
+	if err := SaveCheckpoint(); err != nil {
+		return nil, fmt.Errorf("could not record rollback point: %w", err)
+	}
+	return run(updateSteps), nil
+}
+
+// run executes steps in order on a goroutine.
+func run(steps []Step) *Job {
+	events := make(chan Event, 64)
+	go func() {
+		defer close(events)
+		for i, step := range steps {
+			emit := func(line string) { events <- Event{Step: i, Line: line} }
+			var err error
+			if step.Func != nil {
+				err = step.Func(emit)
+			} else {
+				err = runCommand(step.Args, emit)
+			}
+			if err != nil {
+				events <- Event{Step: i, Done: true, Err: fmt.Errorf("%s: %w", step.Name, err)}
+				return
+			}
+		}
+		events <- Event{Step: len(steps) - 1, Done: true}
+	}()
+	return &Job{Steps: steps, Events: events}
+}
+
+// runCommand runs a command in the project directory, forwarding stdout
+// and stderr line by line.
+func runCommand(args []string, emit func(string)) error {
+	pr, pw := io.Pipe()
+	cmd := runner.New(args[0], args[1:]...)
+	cmd.Dir = paths.ProjectDir
+	cmd.Env = []string{"FETCH_COMMIT=" + buildCommit()}
+	cmd.Combined, cmd.Stdout = true, pw
+
+	scanned := make(chan struct{})
+	go func() {
+		defer close(scanned)
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			// Progress output redraws with \r; keep the final state
+			line := scanner.Text()
+			if cr := strings.LastIndex(line, "\r"); cr >= 0 {
+				line = line[cr+1:]
+			}
+			emit(line)
+		}
+		io.Copy(io.Discard, pr)
+	}()
+
+	_, err := runner.Run(context.Background(), cmd)
+	pw.Close()
+	<-scanned
+	return err
+}
+
+// PullAndRebuild performs a git pull and rebuilds Docker containers,
+// blocking until both finish.
 func PullAndRebuild() error {
-	gitCmd := exec.Command("git", "pull", "origin", "main")
-	gitCmd.Dir = paths.ProjectDir
-	if err := gitCmd.Run(); err != nil {
+	job, err := Start()
+	if err != nil {
 		return err
 	}
-
-	buildCmd := exec.Command("docker", "compose", "build")
-	buildCmd.Dir = paths.ProjectDir
-	return buildCmd.Run()
+	for ev := range job.Events {
+		if ev.Done {
+			return ev.Err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,206 @@
+package update
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/runner"
+	"github.com/fetch/manager/internal/runner/runnertest"
+)
+
+// useProject points paths at an empty project directory until the test
+// ends.
+func useProject(t *testing.T) {
+	prev := paths.ProjectDir
+	paths.Use(t.TempDir())
+	t.Cleanup(func() { paths.Use(prev) })
+}
+
+// finish reads a job's events to the end, returning the output lines and
+// the final error.
+func finish(t *testing.T, job *Job) ([]string, error) {
+	t.Helper()
+	var lines []string
+	for ev := range job.Events {
+		if ev.Done {
+			return lines, ev.Err
+		}
+		lines = append(lines, ev.Line)
+	}
+	t.Fatal("events closed without a Done event")
+	return nil, nil
+}
+
+func TestPendingChanges(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Reply("git fetch origin main", "")
+	fake.Reply("git log --oneline", "abc1234 Fix the thing\ndef5678 Add the other thing\n")
+
+	changes, err := PendingChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Change{{"abc1234", "Fix the thing"}, {"def5678", "Add the other thing"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %+v, want %+v", changes, want)
+	}
+}
+
+func TestPendingChangesFetchFails(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Fail("git fetch", 128, "fatal: unable to access 'https://github.com/...': Could not resolve host\n")
+
+	_, err := PendingChanges()
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve host") {
+		t.Errorf("err = %v, want git's message", err)
+	}
+	if calls := fake.Calls(); len(calls) != 1 {
+		t.Errorf("ran %q after the fetch failed", calls[1:])
+	}
+}
+
+func TestStart(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Reply("git rev-parse HEAD", "0123456789abcdef\n")
+	fake.Reply("git rev-parse --short HEAD", "0123456\n")
+	fake.Reply("git log -1", "Current release\n")
+	fake.Reply("docker compose config --images", "fetch-bridge:latest\n")
+	fake.Reply("docker image inspect -f {{.Id}} fetch-bridge:latest", "sha256:aaaa\n")
+	fake.Reply("git checkout main", "Already on 'main'\n")
+	fake.Reply("git pull", "Fast-forward\n")
+	fake.Reply("docker compose build", "Building 50%\rBuilding 100%\n")
+
+	job, err := Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := finish(t, job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Already on 'main'", "Fast-forward", "Building 100%"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %q, want the last redraw of each line", lines)
+	}
+
+	cp, err := LoadCheckpoint()
+	if err != nil || cp == nil {
+		t.Fatalf("checkpoint %+v, %v", cp, err)
+	}
+	if cp.Commit != "0123456789abcdef" || cp.Images["fetch-bridge:latest"] != "sha256:aaaa" {
+		t.Errorf("checkpoint = %+v", cp)
+	}
+
+	for _, c := range fake.Commands() {
+		if c.Name == "docker" && len(c.Args) > 1 && c.Args[1] == "build" {
+			if !reflect.DeepEqual(c.Env, []string{"FETCH_COMMIT=0123456"}) {
+				t.Errorf("build env = %q, want the checked-out commit", c.Env)
+			}
+		}
+	}
+}
+
+func TestStartFailedStep(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Reply("git rev-parse", "0123456\n")
+	fake.Reply("git log -1", "Current release\n")
+	fake.Fail("docker compose config", 1, "")
+	fake.Reply("git checkout main", "")
+	fake.Fail("git pull", 1, "fatal: Not possible to fast-forward, aborting.\n")
+
+	job, err := Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := finish(t, job)
+	var exit *runner.ExitError
+	if !errors.As(err, &exit) || exit.Code != 1 {
+		t.Fatalf("err = %v, want exit status 1", err)
+	}
+	if !strings.HasPrefix(err.Error(), "Pulling latest code:") {
+		t.Errorf("err = %v, want it to name the step", err)
+	}
+	if len(lines) != 1 || lines[0] != "fatal: Not possible to fast-forward, aborting." {
+		t.Errorf("lines = %q, want git's output", lines)
+	}
+	for _, call := range fake.Calls() {
+		if strings.HasPrefix(call, "docker compose build") {
+			t.Error("rebuilt after the pull failed")
+		}
+	}
+}
+
+func TestStartWithoutCheckpoint(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Fail("git rev-parse", 128, "fatal: not a git repository\n")
+
+	if _, err := Start(); err == nil || !strings.Contains(err.Error(), "rollback point") {
+		t.Errorf("err = %v, want the checkpoint failure", err)
+	}
+	if _, err := os.Stat(checkpointPath()); !errors.Is(err, os.ErrNotExist) {
+		t.Error("wrote a checkpoint anyway")
+	}
+}
+
+func TestRollbackRetags(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Reply("git checkout --detach", "HEAD is now at 0123456\n")
+	fake.Reply("docker image inspect", "[]\n")
+	fake.Reply("docker tag", "")
+
+	cp := &Checkpoint{Commit: "0123456789", Images: map[string]string{"fetch-bridge:latest": "sha256:0123456789abcdef"}}
+	if _, err := finish(t, StartRollback(cp)); err != nil {
+		t.Fatal(err)
+	}
+	want := "docker tag sha256:0123456789abcdef fetch-bridge:latest"
+	if calls := fake.Calls(); calls[len(calls)-1] != want {
+		t.Errorf("last call %q, want %q", calls[len(calls)-1], want)
+	}
+}
+
+func TestRollbackRebuildsPrunedImages(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Reply("git checkout --detach", "")
+	fake.Fail("docker image inspect", 1, "Error: No such image\n")
+	fake.Reply("git rev-parse", "0123456\n")
+	fake.Reply("docker compose build", "")
+
+	cp := &Checkpoint{Commit: "0123456789", Images: map[string]string{"fetch-bridge:latest": "sha256:gone"}}
+	lines, err := finish(t, StartRollback(cp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) < 2 || lines[0] != "Previous image for fetch-bridge:latest was pruned" {
+		t.Errorf("lines = %q", lines)
+	}
+	for _, call := range fake.Calls() {
+		if strings.HasPrefix(call, "docker tag") {
+			t.Error("tagged a pruned image")
+		}
+	}
+}
+
+func TestRollbackTagFails(t *testing.T) {
+	useProject(t)
+	fake := runnertest.Install(t)
+	fake.Reply("git checkout --detach", "")
+	fake.Reply("docker image inspect", "[]\n")
+	fake.Fail("docker tag", 1, "Error response from daemon: no such image\n")
+
+	cp := &Checkpoint{Commit: "0123456789", Images: map[string]string{"fetch-bridge:latest": "sha256:0123"}}
+	_, err := finish(t, StartRollback(cp))
+	if err == nil || !strings.Contains(err.Error(), "docker tag fetch-bridge:latest: Error response from daemon") {
+		t.Errorf("err = %v", err)
+	}
+}
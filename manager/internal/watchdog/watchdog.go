@@ -0,0 +1,241 @@
+// Package watchdog keeps Fetch running without the TUI: it polls the
+// containers and the bridge, restarts whatever crashed with exponential
+// backoff, and notifies when restarts keep failing.
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/health"
+	"github.com/fetch/manager/internal/notify"
+	"github.com/fetch/manager/internal/status"
+)
+
+const (
+	// DefaultInterval is the time between checks.
+	DefaultInterval = 30 * time.Second
+	// firstBackoff is the wait after the first failed restart; it doubles
+	// with each further failure up to maxBackoff.
+	firstBackoff = 15 * time.Second
+	maxBackoff   = 10 * time.Minute
+	// alertAfter is how many restarts in a row may fail before notifying.
+	alertAfter = 3
+	// whatsappGrace is how many checks WhatsApp may stay disconnected
+	// before the bridge is restarted to reconnect.
+	whatsappGrace = 3
+)
+
+// whatsappService tracks bridge restarts made to recover the WhatsApp
+// connection rather than a crashed container.
+const whatsappService = "whatsapp"
+
+// recovery tracks restarts of one service.
+type recovery struct {
+	attempts    int       // Restarts since it was last healthy
+	nextAttempt time.Time // Backoff: no restart before this
+	alerted     bool      // Repeated failures were already reported
+	badChecks   int       // Checks in a row with a problem (WhatsApp only)
+}
+
+// Watchdog is a long-running monitor. Create one with New.
+type Watchdog struct {
+	client   *status.Client
+	notifier *notify.Notifier
+	tracker  *notify.Tracker
+	interval time.Duration
+	log      *log.Logger
+
+	recoveries map[string]*recovery
+
+	// Restart actions; replaced in tests
+	start         func(service string) error
+	restart       func(service string) error
+	restartBridge func() error
+}
+
+// New creates a watchdog that checks every interval (DefaultInterval when
+// zero) and logs to logger.
+func New(client *status.Client, notifier *notify.Notifier, interval time.Duration, logger *log.Logger) *Watchdog {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Watchdog{
+		client:        client,
+		notifier:      notifier,
+		tracker:       notify.NewTracker(),
+		interval:      interval,
+		log:           logger,
+		recoveries:    make(map[string]*recovery),
+		start:         startService,
+		restart:       restartService,
+		restartBridge: restartBridge,
+	}
+}
+
+// Recoveries aren't cancelled: Docker's own timeouts bound them, and the
+// next check runs once they return.
+func startService(service string) error {
+	return docker.StartService(context.Background(), service)
+}
+
+func restartService(service string) error {
+	return docker.RestartService(context.Background(), service)
+}
+
+func restartBridge() error {
+	return docker.RestartBridge(context.Background())
+}
+
+// Run checks until ctx is cancelled.
+func (w *Watchdog) Run(ctx context.Context) error {
+	targets := "none"
+	if w.notifier.Enabled() {
+		targets = fmt.Sprint(w.notifier.Targets())
+	}
+	w.log.Printf("watchdog started: checking every %s, notifications: %s", w.interval, targets)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.Step(health.Check(w.client))
+		select {
+		case <-ctx.Done():
+			w.log.Printf("watchdog stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Step acts on one snapshot: notify on changes, then restart what's down.
+func (w *Watchdog) Step(s health.Snapshot) {
+	for _, c := range s.Containers {
+		if e, ok := w.tracker.Container(c.Name, c.Running(), false); ok {
+			w.send(e)
+		}
+	}
+	if e, ok := w.tracker.WhatsApp(s.WhatsAppState(), false); ok {
+		w.send(e)
+	}
+
+	bridgeRestarted := false
+	for _, c := range s.Containers {
+		restarted := false
+		switch {
+		case !c.Running():
+			restarted = w.recover(c.Name, s.At, "is "+stateOrMissing(c.State), func() error {
+				return w.start(c.Name)
+			})
+		case c.Unhealthy():
+			restarted = w.recover(c.Name, s.At, "is unhealthy", func() error {
+				return w.restart(c.Name)
+			})
+		default:
+			w.healthy(c.Name)
+		}
+		if restarted && c.Name == "fetch-bridge" {
+			bridgeRestarted = true
+		}
+	}
+
+	// A running bridge that lost WhatsApp usually reconnects on a restart.
+	// A pending QR code needs a person, so it's only reported.
+	if !s.Running("fetch-bridge") || bridgeRestarted {
+		return
+	}
+	switch state := s.WhatsAppState(); state {
+	case "authenticated", "authenticating", "initializing", "qr_pending":
+		w.healthy(whatsappService)
+	default:
+		r := w.recovery(whatsappService)
+		r.badChecks++
+		if r.badChecks < whatsappGrace {
+			return
+		}
+		reason := "is " + state
+		if state == "" {
+			reason = "isn't answering"
+		}
+		w.recover(whatsappService, s.At, reason, w.restartBridge)
+	}
+}
+
+func stateOrMissing(state string) string {
+	if state == "" {
+		return "missing"
+	}
+	return state
+}
+
+func (w *Watchdog) recovery(service string) *recovery {
+	r := w.recoveries[service]
+	if r == nil {
+		r = &recovery{}
+		w.recoveries[service] = r
+	}
+	return r
+}
+
+// healthy resets a service's backoff once it checks out.
+func (w *Watchdog) healthy(service string) {
+	r := w.recoveries[service]
+	if r == nil {
+		return
+	}
+	if r.attempts > 0 {
+		w.log.Printf("%s recovered after %d restart(s)", service, r.attempts)
+	}
+	delete(w.recoveries, service)
+}
+
+// recover restarts a service unless it is backing off, and reports whether
+// a restart was attempted.
+func (w *Watchdog) recover(service string, now time.Time, reason string, restart func() error) bool {
+	r := w.recovery(service)
+	if now.Before(r.nextAttempt) {
+		return false
+	}
+
+	r.attempts++
+	w.log.Printf("%s %s; restarting (attempt %d)", service, reason, r.attempts)
+	err := restart()
+	if err != nil {
+		w.log.Printf("restarting %s failed: %v", service, err)
+	}
+	r.nextAttempt = now.Add(backoff(r.attempts))
+
+	if r.attempts >= alertAfter && !r.alerted {
+		r.alerted = true
+		msg := fmt.Sprintf("The watchdog has restarted %s %d times and it %s.", service, r.attempts, reason)
+		if err != nil {
+			msg += " Last error: " + err.Error()
+		}
+		w.log.Printf("%s keeps failing; notifying", service)
+		w.send(notify.Event{
+			Kind:    notify.KindRepeatedFailure,
+			Title:   "Fetch: " + service + " keeps failing",
+			Message: msg + " Check the logs with the manager.",
+		})
+	}
+	return true
+}
+
+// backoff returns the wait after the given number of restarts.
+func backoff(attempts int) time.Duration {
+	d := firstBackoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
+
+func (w *Watchdog) send(e notify.Event) {
+	w.log.Printf("notify: %s — %s", e.Title, e.Message)
+	if err := w.notifier.Send(e); err != nil {
+		w.log.Printf("notification failed: %v", err)
+	}
+}
@@ -0,0 +1,391 @@
+// Package workspaces provides the kennel workspace browser.
+package workspaces
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// StaleAge is how long a workspace can go unused before it is marked stale.
+const StaleAge = 14 * 24 * time.Hour
+
+// LoadedMsg is sent when the workspace list has been fetched.
+type LoadedMsg struct {
+	Workspaces []status.Workspace
+	Err        error
+}
+
+// DiffMsg is sent when a workspace diff has been fetched.
+type DiffMsg struct {
+	ID   string
+	Diff string
+	Err  error
+}
+
+// DeletedMsg is sent when a delete request completes.
+type DeletedMsg struct {
+	ID  string
+	Err error
+}
+
+// Browser lists kennel workspaces and shows their recent changes.
+type Browser struct {
+	client     *status.Client
+	workspaces []status.Workspace
+	cursor     int
+	loading    bool
+	err        error
+	message    string
+	msgErr     bool
+
+	// Diff view for one workspace; diffID is empty while the list shows
+	diffID      string
+	diffLines   []string
+	diffOffset  int
+	diffLoading bool
+	height      int
+}
+
+// NewBrowser creates a workspace browser backed by the given bridge client.
+func NewBrowser(client *status.Client) *Browser {
+	return &Browser{client: client, loading: true, height: 20}
+}
+
+// Init fetches the workspace list.
+func (b *Browser) Init() tea.Cmd {
+	return b.fetchCmd()
+}
+
+func (b *Browser) fetchCmd() tea.Cmd {
+	client := b.client
+	return func() tea.Msg {
+		list, err := client.GetWorkspaces()
+		return LoadedMsg{Workspaces: list, Err: err}
+	}
+}
+
+func (b *Browser) diffCmd(id string) tea.Cmd {
+	client := b.client
+	return func() tea.Msg {
+		diff, err := client.GetWorkspaceDiff(id)
+		return DiffMsg{ID: id, Diff: diff, Err: err}
+	}
+}
+
+// DeleteCmd removes the workspace with the given ID. The caller confirms
+// first, since the directory and any unpushed work are lost.
+func (b *Browser) DeleteCmd(id string) tea.Cmd {
+	client := b.client
+	return func() tea.Msg {
+		return DeletedMsg{ID: id, Err: client.DeleteWorkspace(id)}
+	}
+}
+
+// Update handles workspace messages and keyboard input.
+func (b *Browser) Update(msg tea.Msg) (*Browser, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		b.loading = false
+		b.err = msg.Err
+		if msg.Err == nil {
+			b.setWorkspaces(msg.Workspaces)
+		}
+		return b, nil
+
+	case DiffMsg:
+		if msg.ID != b.diffID {
+			return b, nil
+		}
+		b.diffLoading = false
+		switch {
+		case msg.Err != nil:
+			b.diffLines = []string{theme.StatusError().Render("❌ " + msg.Err.Error())}
+		case strings.TrimSpace(msg.Diff) == "":
+			b.diffLines = []string{theme.Subtitle().Render("No changes in this workspace.")}
+		default:
+			b.diffLines = colorDiff(msg.Diff)
+		}
+		return b, nil
+
+	case DeletedMsg:
+		if msg.Err != nil {
+			b.message, b.msgErr = msg.Err.Error(), true
+			return b, nil
+		}
+		b.message, b.msgErr = "Deleted "+msg.ID, false
+		return b, b.fetchCmd()
+
+	case tea.KeyMsg:
+		if b.InDiff() {
+			return b, b.handleDiffKey(msg)
+		}
+		return b, b.handleKey(msg)
+	}
+	return b, nil
+}
+
+func (b *Browser) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if b.cursor > 0 {
+			b.cursor--
+		}
+	case "down", "j":
+		if b.cursor < len(b.workspaces)-1 {
+			b.cursor++
+		}
+	case "enter", "v":
+		if ws, ok := b.Selected(); ok {
+			b.diffID, b.diffLines, b.diffOffset, b.diffLoading = ws.ID, nil, 0, true
+			return b.diffCmd(ws.ID)
+		}
+	case "r":
+		b.loading = true
+		return b.fetchCmd()
+	}
+	return nil
+}
+
+func (b *Browser) handleDiffKey(msg tea.KeyMsg) tea.Cmd {
+	page := max(1, b.diffHeight()-1)
+	last := max(0, len(b.diffLines)-b.diffHeight())
+	switch msg.String() {
+	case "esc", "q":
+		b.diffID, b.diffLines = "", nil
+	case "up", "k":
+		b.diffOffset--
+	case "down", "j":
+		b.diffOffset++
+	case "pgup", "b":
+		b.diffOffset -= page
+	case "pgdown", " ", "f":
+		b.diffOffset += page
+	case "home", "g":
+		b.diffOffset = 0
+	case "end", "G":
+		b.diffOffset = last
+	}
+	b.diffOffset = max(0, min(b.diffOffset, last))
+	return nil
+}
+
+// setWorkspaces stores the list, keeping the cursor on the same workspace
+// where possible.
+func (b *Browser) setWorkspaces(list []status.Workspace) {
+	var selectedID string
+	if ws, ok := b.Selected(); ok {
+		selectedID = ws.ID
+	}
+	b.workspaces = list
+	b.cursor = 0
+	for i, ws := range list {
+		if ws.ID == selectedID {
+			b.cursor = i
+			break
+		}
+	}
+}
+
+// Selected returns the highlighted workspace.
+func (b *Browser) Selected() (status.Workspace, bool) {
+	if b.cursor >= 0 && b.cursor < len(b.workspaces) {
+		return b.workspaces[b.cursor], true
+	}
+	return status.Workspace{}, false
+}
+
+// InDiff reports whether a workspace diff is showing.
+func (b *Browser) InDiff() bool {
+	return b.diffID != ""
+}
+
+// SetHeight sets the lines available to the browser.
+func (b *Browser) SetHeight(height int) {
+	b.height = max(8, height)
+}
+
+func (b *Browser) diffHeight() int {
+	return max(4, b.height-3)
+}
+
+// HelpKeys returns the help bar entries for the current view.
+func (b *Browser) HelpKeys() []string {
+	if b.InDiff() {
+		return []string{"↑/↓ Scroll", "PgUp/PgDn Page", "Esc Back"}
+	}
+	keys := []string{"↑/↓ Navigate"}
+	if _, ok := b.Selected(); ok {
+		keys = append(keys, "Enter Diff", "d Delete")
+	}
+	return append(keys, "r Refresh", "Esc Back")
+}
+
+// colorDiff styles unified diff lines by kind.
+func colorDiff(diff string) []string {
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+			strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "commit "):
+			lines[i] = theme.Value().Bold(true).Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = theme.StatusInfo().Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = theme.StatusSuccess().Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = theme.StatusError().Render(line)
+		default:
+			lines[i] = theme.Muted().Render(line)
+		}
+	}
+	return lines
+}
+
+// formatAge renders how long ago t was compactly ("5m ago", "3d ago").
+func formatAge(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// truncate shortens s to at most n runes, adding an ellipsis.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if n <= 1 || len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// View renders the workspace list, or the selected diff, for the given width.
+func (b *Browser) View(width int) string {
+	if b.InDiff() {
+		return b.viewDiff(width)
+	}
+
+	var s strings.Builder
+	switch {
+	case b.loading && b.workspaces == nil:
+		s.WriteString(theme.StatusInfo().Render("   Loading workspaces...") + "\n")
+		return s.String()
+	case b.err != nil && b.workspaces == nil:
+		s.WriteString(theme.StatusError().Render("   ● Workspace API unavailable") + "\n")
+		s.WriteString(theme.Subtitle().Render("   "+b.err.Error()) + "\n")
+		return s.String()
+	case len(b.workspaces) == 0:
+		s.WriteString(theme.Subtitle().Render("   No workspaces yet. Clone a repository with /clone on WhatsApp.") + "\n")
+		return s.String()
+	}
+
+	now := time.Now()
+	dirty, stale := 0, 0
+	for _, ws := range b.workspaces {
+		if ws.Dirty {
+			dirty++
+		}
+		if ws.Stale(now, StaleAge) {
+			stale++
+		}
+	}
+	s.WriteString("   " + theme.Subtitle().Render(fmt.Sprintf("%d workspaces, %d with uncommitted changes, %d stale", len(b.workspaces), dirty, stale)) + "\n\n")
+
+	for i, ws := range b.workspaces {
+		prefix := "   "
+		nameStyle := theme.Value()
+		if i == b.cursor {
+			prefix = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render(" ▸ ")
+			nameStyle = lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true)
+		}
+
+		branch := ws.Branch
+		if branch == "" {
+			branch = "—"
+		}
+		state := theme.StatusSuccess().Render(theme.Cue("✓", "clean"))
+		if ws.Dirty {
+			state = theme.StatusWarning().Render(theme.Cue(fmt.Sprintf("● %d", ws.ChangedFiles), fmt.Sprintf("%d changed", ws.ChangedFiles)))
+		}
+		age := theme.Muted().Render(formatAge(ws.LastActivity(), now))
+		if ws.Stale(now, StaleAge) {
+			age = theme.StatusWarning().Render(formatAge(ws.LastActivity(), now) + " · stale")
+		}
+		s.WriteString(fmt.Sprintf("%s%s %s %s %s\n",
+			prefix,
+			nameStyle.Width(24).Render(truncate(ws.ID, 23)),
+			theme.Muted().Width(18).Render(truncate(branch, 17)),
+			lipgloss.NewStyle().Width(12).Render(state),
+			age))
+
+		// Details for the selected workspace
+		if i == b.cursor {
+			indent := "      "
+			s.WriteString(indent + theme.Muted().Render(ws.Path))
+			if ws.ProjectType != "" && ws.ProjectType != "unknown" {
+				s.WriteString(theme.Muted().Render(" • " + ws.ProjectType))
+			}
+			if ws.Ahead > 0 || ws.Behind > 0 {
+				s.WriteString(theme.Muted().Render(fmt.Sprintf(" • ↑%d ↓%d", ws.Ahead, ws.Behind)))
+			}
+			if ws.IsActive {
+				s.WriteString(theme.StatusInfo().Render(" • active"))
+			}
+			s.WriteString("\n")
+			if t := ws.LastTask; t != nil {
+				s.WriteString(indent + theme.Subtitle().Render(truncate(fmt.Sprintf("Last task (%s): %s", t.Status, t.Goal), max(20, width-10))) + "\n")
+			}
+		}
+	}
+
+	if b.message != "" {
+		s.WriteString("\n")
+		if b.msgErr {
+			s.WriteString(theme.StatusError().Render("   ❌ " + b.message))
+		} else {
+			s.WriteString(theme.StatusSuccess().Render("   ✅ " + b.message))
+		}
+		s.WriteString("\n")
+	}
+
+	return s.String()
+}
+
+func (b *Browser) viewDiff(width int) string {
+	var s strings.Builder
+	s.WriteString("   " + theme.Value().Bold(true).Render(b.diffID))
+	if b.diffLoading {
+		s.WriteString("\n\n" + theme.StatusInfo().Render("   Loading diff...") + "\n")
+		return s.String()
+	}
+
+	height := b.diffHeight()
+	end := min(len(b.diffLines), b.diffOffset+height)
+	if len(b.diffLines) > height {
+		s.WriteString(theme.Muted().Render(fmt.Sprintf("  lines %d–%d of %d", b.diffOffset+1, end, len(b.diffLines))))
+	}
+	s.WriteString("\n\n")
+
+	lineStyle := lipgloss.NewStyle().MaxWidth(max(20, width-4))
+	for _, line := range b.diffLines[b.diffOffset:end] {
+		s.WriteString("  " + lineStyle.Render(line) + "\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// helpScreen is the keymap shown for the current view. The model picker
+// runs inside the config screen but has its own keys.
+func (m model) helpScreen() screens.ID {
+	if m.screen == screens.Config && m.config.Picking() {
+		return screens.Models
+	}
+	return m.screen
+}
+
+// capturingText reports whether keys are going into a text field, where
+// "?" must be typed rather than open help.
+func (m model) capturingText() bool {
+	slot, ok := screenModelFor(m.screen)
+	if !ok {
+		return false
+	}
+	input, ok := slot.get(m).(screens.TextInput)
+	return ok && input.CapturingText()
+}
+
+// renderHelpOverlay renders the ? modal for the current screen.
+func (m model) renderHelpOverlay(width int) string {
+	km := screens.Keymaps[m.helpScreen()]
+	boxWidth := min(70, width-4)
+
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Width(12)
+	section := lipgloss.NewStyle().Foreground(theme.Active().Secondary).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render(km.Title) + "\n")
+	if km.Summary != "" {
+		b.WriteString(theme.Muted().Width(boxWidth-4).Render(km.Summary) + "\n")
+	}
+	b.WriteString("\n")
+	for _, bind := range km.Bindings {
+		b.WriteString(keyStyle.Render(bind.Key) + theme.Value().Render(bind.Desc) + "\n")
+	}
+	if m.inTabs() {
+		b.WriteString("\n" + section.Render("Tabs") + "\n")
+		for _, bind := range screens.TabBindings {
+			b.WriteString(keyStyle.Render(bind.Key) + theme.Value().Render(bind.Desc) + "\n")
+		}
+	}
+	b.WriteString("\n" + section.Render("Everywhere") + "\n")
+	for _, bind := range screens.GlobalBindings {
+		b.WriteString(keyStyle.Render(bind.Key) + theme.Value().Render(bind.Desc) + "\n")
+	}
+	b.WriteString("\n" + theme.Muted().Render("Press any key to close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Active().Primary).
+		Padding(0, 1).
+		Width(boxWidth).
+		Render(b.String())
+}
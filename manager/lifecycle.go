@@ -0,0 +1,36 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/screens"
+)
+
+// enter opens a screen and brings it up right away, for actions that send
+// the screen a message before returning.
+func (m model) enter(s screens.ID) (model, tea.Cmd) {
+	m.screen = s
+	return m.changeScreen()
+}
+
+// changeScreen sends screens.HiddenMsg to the screen left and
+// screens.ShownMsg to the one shown when the screen differs from the one
+// it last ran for. Update calls it after every message, so screens
+// changed by any route are covered.
+func (m model) changeScreen() (model, tea.Cmd) {
+	if m.screen == m.activeScreen {
+		return m, nil
+	}
+	if m.activeScreen == screens.Logs {
+		// Errors shown while the logs were open no longer count for the
+		// menu badge
+		m.logsSeenAt = time.Now()
+		m.logErrors = 0
+	}
+	m, hidden := m.sendScreen(m.activeScreen, screens.HiddenMsg{})
+	m.activeScreen = m.screen
+	m, shown := m.sendScreen(m.screen, screens.ShownMsg{})
+	return m, tea.Batch(hidden, shown)
+}
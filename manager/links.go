@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/browser"
+)
+
+// openDocsCmd opens the documentation site served by the bridge
+func openDocsCmd(docsURL string) tea.Cmd {
+	return func() tea.Msg {
+		err := browser.Open(docsURL)
+		if err != nil {
+			return actionResultMsg{success: false, message: fmt.Sprintf("Failed to open docs: %v", err)}
+		}
+		return actionResultMsg{success: true, message: "📚 Documentation opened in browser"}
+	}
+}
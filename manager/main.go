@@ -7,999 +7,512 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
-	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	qrcode "github.com/skip2/go-qrcode"
 
 	"github.com/fetch/manager/internal/components"
-	"github.com/fetch/manager/internal/config"
 	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/doctor"
 	"github.com/fetch/manager/internal/layout"
-	"github.com/fetch/manager/internal/logs"
-	"github.com/fetch/manager/internal/models"
+	"github.com/fetch/manager/internal/notify"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/approvals"
+	configscreen "github.com/fetch/manager/internal/screens/config"
+	"github.com/fetch/manager/internal/screens/console"
+	"github.com/fetch/manager/internal/screens/gitproviders"
+	"github.com/fetch/manager/internal/screens/groups"
+	"github.com/fetch/manager/internal/screens/limits"
+	logsscreen "github.com/fetch/manager/internal/screens/logs"
+	"github.com/fetch/manager/internal/screens/menu"
+	modelsscreen "github.com/fetch/manager/internal/screens/models"
+	"github.com/fetch/manager/internal/screens/notifications"
+	"github.com/fetch/manager/internal/screens/owner"
+	"github.com/fetch/manager/internal/screens/repos"
+	setupscreen "github.com/fetch/manager/internal/screens/setup"
+	"github.com/fetch/manager/internal/screens/stats"
+	statusscreen "github.com/fetch/manager/internal/screens/status"
+	"github.com/fetch/manager/internal/screens/summaries"
+	taskscreen "github.com/fetch/manager/internal/screens/tasks"
+	"github.com/fetch/manager/internal/screens/updates"
+	"github.com/fetch/manager/internal/screens/version"
+	"github.com/fetch/manager/internal/screens/whitelist"
+	"github.com/fetch/manager/internal/screens/workspaces"
+	"github.com/fetch/manager/internal/session"
 	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/tasks"
 	"github.com/fetch/manager/internal/theme"
 )
 
-// screen represents the current TUI screen.
-type screen int
-
-// Screen constants for navigation
-const (
-	screenSplash    screen = iota // Initial splash screen
-	screenMenu                    // Main menu
-	screenConfig                  // Configuration editor
-	screenLogs                    // Log viewer
-	screenStatus                  // System status
-	screenSetup                   // WhatsApp setup wizard
-	screenModels                  // AI model selector
-	screenVersion                 // Version information
-	screenWhitelist               // Trusted numbers manager
-	screenGitHub                  // GitHub authentication screen
-)
-
 // Bubble Tea messages for async operations
 
-// statusMsg carries Docker container status updates
-type statusMsg struct {
-	bridgeRunning bool
-	kennelRunning bool
-	err           error
-}
-
 // actionResultMsg carries results from user-initiated actions
 type actionResultMsg struct {
 	success bool
 	message string
 }
 
-// logMsg carries log lines from container logs
-type logMsg struct {
-	lines []string
-}
-
-// bridgeStatusMsg carries Bridge API status updates
-type bridgeStatusMsg struct {
-	status *status.BridgeStatus
-	err    error
+// toastExpiredMsg dismisses a toast once its timeout elapses
+type toastExpiredMsg struct {
+	id int
 }
 
-// ghAuthResultMsg carries the result of gh auth login
-type ghAuthResultMsg struct {
-	err error
-}
-
-// ghAccount represents a single GitHub account from gh auth status
-type ghAccount struct {
-	user     string
-	active   bool
-	protocol string
-	scopes   string
-}
-
-// ghStatusMsg carries the result of checking gh auth status
-type ghStatusMsg struct {
-	accounts []ghAccount
-	err      error
-}
-
-// ghSwitchMsg carries the result of gh auth switch or gh auth logout
-type ghSwitchMsg struct {
-	err error
-}
-
-// tickMsg triggers periodic status updates
-type tickMsg time.Time
-
-// qrRefreshTickMsg triggers the QR code refresh countdown
-type qrRefreshTickMsg time.Time
-
-// splashDoneMsg signals splash screen timeout
-type splashDoneMsg struct{}
-
-// QR code refresh interval (WhatsApp QR codes expire after ~20 seconds)
-const qrRefreshInterval = 20 * time.Second
-
 // model is the main Bubble Tea model for the TUI
 type model struct {
-	screen           screen
-	choices          []string
-	cursor           int
-	quitting         bool
-	bridgeRunning    bool
-	kennelRunning    bool
-	statusLoaded     bool
-	actionMessage    string
-	actionSuccess    bool
-	logLines         []string
-	logViewer        *components.LogViewer
-	configEditor     *config.Editor
-	modelSelector    *models.Selector
-	whitelistManager *config.WhitelistManager
-	width            int
-	height           int
-	bridgeStatus     *status.BridgeStatus
-	statusClient     *status.Client
-	versionInfo      components.VersionInfo
-	// Config sub-screen: 0=sub-menu, 1=editor, 2=model selector
-	configMode       int
-	// GitHub auth state
-	ghAccounts      []ghAccount // All GitHub accounts from gh auth status
-	ghAccountCursor int         // Cursor for account selection
-	ghChecking      bool        // Whether we're currently checking status
-	// QR code refresh state
-	qrProgress     progress.Model
-	qrCountdown    int // Seconds remaining until refresh
-	qrMaxCountdown int // Total countdown time
-}
-
-func initialModel() model {
-	// Create progress bar for QR countdown
-	prog := progress.New(
-		progress.WithDefaultGradient(),
-		progress.WithWidth(30),
-		progress.WithoutPercentage(),
-	)
-
-	qrCountdown := int(qrRefreshInterval.Seconds())
+	screen        screens.ID
+	activeScreen  screens.ID // Screen the lifecycle last ran for; see changeScreen
+	quitting      bool
+	bridgeRunning bool
+	kennelRunning bool
+	statusLoaded  bool
+	dockerDown    bool // The Docker daemon didn't answer the last check
+	// Preflight checks run behind the splash screen
+	preflight        [len(preflightLabels)]preflightResult
+	splashMinElapsed bool
+	splashSpinner    *components.Spinner
+	crash            *crashReport // Caught panic shown in place of the screen
+	renderCrash      *crashSlot   // Panic caught in View, waiting for Update
+	// Restored from and saved to data/manager-state.json
+	restored      session.State
+	lastScreen    screens.ID
+	toasts        *components.Toasts
+	palette       *components.Palette // Command palette, nil when closed
+	showHelp      bool                // ? overlay is open
+	confirm       *components.Confirm // Pending confirmation, nil when none
+	confirmAction func(m model) (model, tea.Cmd)
+	width         int
+	height        int
+	bridgeStatus  *status.BridgeStatus
+	statusClient  *status.Client
+	versionInfo   components.VersionInfo
+	// Background update checks; zero interval disables them
+	updateCheckInterval time.Duration
+	updatesPending      int  // Commits available, from the last check
+	bundleRunning       bool // A support bundle is being collected
+	// Webhook notifications for state changes
+	notifier          *notify.Notifier
+	notifyTracker     *notify.Tracker
+	servicesStopped   bool // Stopped from the manager; not a crash
+	whatsappLoggedOut bool // Logged out from the manager; not a disconnect
+	// Screens, each a model of its own; see screenModelSlots
+	menu          menu.Model
+	config        configscreen.Model
+	models        modelsscreen.Model
+	whitelist     whitelist.Model
+	owner         owner.Model
+	notifications notifications.Model
+	status        statusscreen.Model
+	logs          logsscreen.Model
+	setup         setupscreen.Model
+	gitProviders  gitproviders.Model
+	repos         repos.Model
+	groups        groups.Model
+	stats         stats.Model
+	tasks         taskscreen.Model
+	approvals     approvals.Model
+	workspaces    workspaces.Model
+	summaries     summaries.Model
+	limits        limits.Model
+	console       console.Model
+	updates       updates.Model
+	version       version.Model
+	fitted        screenFit   // Room last sent to the current screen model
+	health        menu.Health // Last sent to the menu
+
+	logErrors  int       // Bridge errors logged since the logs were last open, for the menu badge
+	logsSeenAt time.Time // When the logs were last left; zero before the first visit
+
+	poll poller // Container and bridge status polling
+
+	startup *startupRun // Start Fetch progress, until closed
+
+	// Stop Fetch or a bridge restart in progress; Esc cancels it
+	running *runningAction
+}
+
+func initialModel(opts options) model {
+	// Progress announcements replace animation everywhere, the splash included
+	var splashSpinner *components.Spinner
+	if !opts.announceProgress {
+		splashSpinner = components.NewSpinner(components.SpinnerMiniDot, "")
+	}
+
+	// Pick up where the last run left off
+	restored := session.Load()
+	client := status.NewClient(opts.apiURL, opts.apiToken)
+	versionInfo := components.DefaultVersionInfo()
+	notifier := notify.New(notify.ConfigFromEnv(envOrDotEnv))
+	toasts := components.NewToasts()
 
 	return model{
-		screen:         screenSplash,
-		statusClient:   status.NewClient(),
-		versionInfo:    components.DefaultVersionInfo(),
-		logViewer:      components.NewLogViewer(80, 24),
-		qrProgress:     prog,
-		qrCountdown:    qrCountdown,
-		qrMaxCountdown: qrCountdown,
-		choices: []string{
-			"📱 Setup WhatsApp",
-			"� GitHub Auth",
-			"🚀 Start Fetch",
-			"🛑 Stop Fetch",
-			"⚙️  Configure",
-			"🔐 Trusted Numbers",
-			"📜 View Logs",
-			"📚 Documentation",
-			"ℹ️  Version",
-			"❌ Exit",
-		},
+		restored:            restored,
+		renderCrash:         &crashSlot{},
+		splashSpinner:       splashSpinner,
+		screen:              screens.Splash,
+		statusClient:        client,
+		versionInfo:         versionInfo,
+		toasts:              toasts,
+		updateCheckInterval: opts.updateCheckInterval,
+		notifier:            notifier,
+		notifyTracker:       notify.NewTracker(),
+		poll:                poller{interval: pollNormal, lastInput: time.Now()},
+		menu:                menu.New(),
+		config:              configscreen.New(restored.ConfigField, restored.ShowAllModels),
+		models:              modelsscreen.New(restored.ShowAllModels),
+		whitelist:           whitelist.New(client),
+		owner:               owner.New(client),
+		notifications:       notifications.New(toasts, notifier),
+		status:              statusscreen.New(doctorChecks(client), opts.backupSchedule, opts.backupKeep),
+		logs:                logsscreen.New(tailLogs, restored.LogFilter, restored.HiddenLevels),
+		setup:               setupscreen.New(client, opts.qrStyle, opts.announceProgress),
+		gitProviders:        gitproviders.New(),
+		repos:               repos.New(),
+		groups:              groups.New(client),
+		stats:               stats.New(client),
+		tasks:               taskscreen.New(client),
+		approvals:           approvals.New(client),
+		workspaces:          workspaces.New(client),
+		summaries:           summaries.New(client),
+		limits:              limits.New(client),
+		console:             console.New(client),
+		updates:             updates.New(),
+		version:             version.New(client, versionInfo),
+	}
+}
+
+// tailLogs reads a container's logs from Docker, or the demo's.
+func tailLogs(container string, n int) ([]string, error) {
+	if demoWorld != nil {
+		return demoWorld.Logs(container, n)
+	}
+	return docker.TailLogs(container, n)
+}
+
+// doctorChecks returns the diagnostics System Status runs.
+func doctorChecks(client *status.Client) func() []doctor.Check {
+	return func() []doctor.Check {
+		if demoWorld != nil {
+			return demoWorld.Checks()
+		}
+		return doctor.Run(client)
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	// Show splash for 2 seconds, then check status
-	return tea.Batch(
-		tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
-			return splashDoneMsg{}
-		}),
+	// Run preflight checks behind the splash, then check status
+	cmds := []tea.Cmd{
+		splashTimersCmd(),
+		preflightCmds(m.statusClient),
 		checkStatus,
-	)
-}
-
-// Check Docker container status
-func checkStatus() tea.Msg {
-	bridgeRunning := docker.IsContainerRunning("fetch-bridge")
-	kennelRunning := docker.IsContainerRunning("fetch-kennel")
-	return statusMsg{
-		bridgeRunning: bridgeRunning,
-		kennelRunning: kennelRunning,
+		statusPollCmd(m.poll.gen, m.poll.interval),
+		checkUpdatesCmd(m.updateCheckInterval),
+	}
+	for _, slot := range screenModelSlots {
+		cmds = append(cmds, slot.get(m).Init())
 	}
+	if m.splashSpinner != nil {
+		cmds = append(cmds, m.splashSpinner.Init())
+	}
+	return tea.Batch(cmds...)
 }
 
-// fetchBridgeStatusCmd fetches the current bridge status as a tea.Cmd
-func fetchBridgeStatusCmd(client *status.Client) tea.Cmd {
-	return func() tea.Msg {
-		s, err := client.GetStatus()
-		return bridgeStatusMsg{status: s, err: err}
+// Update is the error boundary around update: a panic while handling a
+// message, or in a command it returns, shows the error screen instead of
+// killing the program. The model is kept as it was before the message.
+func (m model) Update(msg tea.Msg) (next tea.Model, cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.crash = newCrashReport(r, m.screen)
+			next, cmd = m, nil
+		}
+	}()
+
+	// View can't change the model, so a panic while rendering is taken
+	// on here, where the error screen's keys can act on it
+	if m.renderCrash != nil && m.renderCrash.report != nil {
+		if m.crash == nil {
+			m.crash = m.renderCrash.report
+		}
+		m.renderCrash.report = nil
+	}
+	if pm, ok := msg.(panicMsg); ok {
+		m.crash = pm.report
+		return m, nil
+	}
+	// Input goes to the error screen; background messages keep flowing
+	if m.crash != nil {
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			return m.updateCrash(msg)
+		}
 	}
-}
 
-// Tick for polling bridge status
-func tickCmd() tea.Cmd {
-	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
-}
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		m.poll.lastInput = time.Now()
+	}
 
-// Tick for QR code refresh countdown (every second)
-func qrRefreshTickCmd() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
-		return qrRefreshTickMsg(t)
-	})
+	next, cmd = m.update(msg)
+	if nm, ok := next.(model); ok {
+		var lifecycle, fit, retune tea.Cmd
+		nm, lifecycle = nm.changeScreen()
+		nm, fit = nm.fitScreen()
+		nm, retune = nm.retunePoll()
+		next = nm.tellMenu().rememberState()
+		cmd = tea.Batch(cmd, lifecycle, fit, retune)
+	}
+	return next, safeCmd(cmd, m.screen)
 }
 
-func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		// fitScreen passes the room on to the screen
 		m.width = msg.Width
 		m.height = msg.Height
-		if m.logViewer != nil {
-			m.logViewer.SetSize(msg.Width, msg.Height)
-		}
 		return m, nil
 
 	case splashDoneMsg:
-		m.screen = screenMenu
-		return m, nil
-
-	case statusMsg:
-		m.bridgeRunning = msg.bridgeRunning
-		m.kennelRunning = msg.kennelRunning
-		m.statusLoaded = true
-		return m, nil
-
-	case actionResultMsg:
-		m.actionMessage = msg.message
-		m.actionSuccess = msg.success
-		return m, checkStatus
-
-	case logMsg:
-		m.logLines = msg.lines
-		if m.logViewer != nil {
-			entries := make([]components.LogEntry, 0, len(msg.lines))
-			for _, line := range msg.lines {
-				entries = append(entries, logs.ParseLogLine(line, "bridge"))
-			}
-			m.logViewer.SetLogs(entries)
-		}
-		return m, nil
-
-	case bridgeStatusMsg:
-		if msg.err == nil {
-			oldQRCode := ""
-			if m.bridgeStatus != nil && m.bridgeStatus.QRCode != nil {
-				oldQRCode = *m.bridgeStatus.QRCode
-			}
-			m.bridgeStatus = msg.status
-			// Only reset countdown when we get a NEW QR code (different from before)
-			if msg.status != nil && msg.status.State == "qr_pending" && msg.status.QRCode != nil {
-				newQRCode := *msg.status.QRCode
-				if oldQRCode != newQRCode {
-					m.qrCountdown = m.qrMaxCountdown
-				}
-			}
+		if msg.deadline || m.preflightDone() {
+			m, cmd := m.finishSplash()
+			return m, cmd
 		}
+		m.splashMinElapsed = true
 		return m, nil
 
-	case ghAuthResultMsg:
-		if msg.err != nil {
-			m.actionMessage = fmt.Sprintf("GitHub auth failed: %v", msg.err)
-			m.actionSuccess = false
-		} else {
-			m.actionMessage = "✅ GitHub authenticated! Restart Fetch to apply."
-			m.actionSuccess = true
-		}
-		// Re-check status after login attempt
-		if m.screen == screenGitHub {
-			m.ghChecking = true
-			return m, checkGhStatusCmd()
-		}
-		return m, nil
+	case preflightMsg:
+		m, cmd := m.updatePreflight(msg)
+		return m, cmd
 
-	case ghStatusMsg:
-		m.ghChecking = false
-		m.ghAccounts = msg.accounts
-		// Clamp cursor
-		if m.ghAccountCursor >= len(m.ghAccounts) {
-			m.ghAccountCursor = 0
+	case spinner.TickMsg:
+		// Each spinner only takes its own ticks
+		var cmds []tea.Cmd
+		if m.splashSpinner != nil {
+			var cmd tea.Cmd
+			m.splashSpinner, cmd = m.splashSpinner.Update(msg)
+			cmds = append(cmds, cmd)
 		}
-		return m, nil
-
-	case ghSwitchMsg:
-		if msg.err != nil {
-			m.actionMessage = fmt.Sprintf("GitHub operation failed: %v", msg.err)
-			m.actionSuccess = false
+		if m.startup != nil && !m.startup.done {
+			_, cmd := m.startup.spinner.Update(msg)
+			cmds = append(cmds, cmd)
 		}
-		// Re-check status after switch/logout
-		m.ghChecking = true
-		return m, checkGhStatusCmd()
+		return m, tea.Batch(cmds...)
 
-	case models.ModelsLoadedMsg:
-		if m.modelSelector != nil {
-			m.modelSelector, _ = m.modelSelector.Update(msg)
+	case startupMsg:
+		// A status read while starting is as good as a polled one
+		var bridge tea.Cmd
+		if msg.bridge != nil {
+			m.bridgeStatus = msg.bridge
+			m, bridge = m.broadcast(screens.BridgeMsg{Status: msg.bridge})
 		}
-		return m, nil
+		m, cmd := m.updateStartup(msg)
+		return m, tea.Batch(bridge, cmd)
 
-	case models.ModelSavedMsg:
-		if m.modelSelector != nil {
-			m.modelSelector, _ = m.modelSelector.Update(msg)
-		}
-		// If we're in config screen with model picker, update editor and return to editor
-		if m.screen == screenConfig && m.configMode == 2 {
-			if msg.Err == nil && m.modelSelector != nil && m.configEditor != nil {
-				m.configEditor.SetFieldValue("AGENT_MODEL", m.modelSelector.SelectedModel())
-			}
-			// Brief delay so user sees "Saved!" then return to editor
-		}
-		return m, nil
-
-	case progress.FrameMsg:
-		// Handle progress bar animation
-		progressModel, cmd := m.qrProgress.Update(msg)
-		m.qrProgress = progressModel.(progress.Model)
+	case startupPollMsg, startupCloseMsg:
+		m, cmd := m.updateStartup(msg)
 		return m, cmd
 
-	case qrRefreshTickMsg:
-		// Only countdown if on setup screen and QR is pending
-		if m.screen == screenSetup && m.bridgeStatus != nil && m.bridgeStatus.State == "qr_pending" {
-			m.qrCountdown--
-			if m.qrCountdown <= 0 {
-				// Auto-refresh: fetch new status
-				m.qrCountdown = m.qrMaxCountdown
-				return m, tea.Batch(fetchBridgeStatusCmd(m.statusClient), qrRefreshTickCmd())
+	case statusMsg:
+		m.bridgeRunning = msg.bridgeRunning
+		m.kennelRunning = msg.kennelRunning
+		m.dockerDown = msg.dockerDown
+		m.statusLoaded = true
+		return m, m.observeContainers()
+
+	case screens.BridgeMsg:
+		state := ""
+		if msg.Err == nil && msg.Status != nil {
+			state = msg.Status.State
+			if state == "authenticated" {
+				m.whatsappLoggedOut = false
 			}
-			// Update progress bar
-			percent := float64(m.qrCountdown) / float64(m.qrMaxCountdown)
-			cmd := m.qrProgress.SetPercent(percent)
-			return m, tea.Batch(cmd, qrRefreshTickCmd())
-		}
-		return m, nil
-
-	case tickMsg:
-		// Only poll if on setup screen AND we don't have status yet
-		if m.screen == screenSetup && m.bridgeStatus == nil {
-			return m, tea.Batch(fetchBridgeStatusCmd(m.statusClient), tickCmd())
-		}
-		return m, nil
-
-	case tea.KeyMsg:
-		// Allow skipping splash with any key
-		if m.screen == screenSplash {
-			m.screen = screenMenu
-			return m, nil
 		}
-
-		// Clear action message on any key
-		m.actionMessage = ""
-
-		switch m.screen {
-		case screenMenu:
-			return m.updateMenu(msg)
-		case screenConfig:
-			return m.updateConfig(msg)
-		case screenWhitelist:
-			return m.updateWhitelist(msg)
-		case screenLogs:
-			return m.updateLogs(msg)
-		case screenStatus:
-			return m.updateStatus(msg)
-		case screenSetup:
-			return m.updateSetup(msg)
-		case screenModels:
-			return m.updateModels(msg)
-		case screenVersion:
-			return m.updateVersion(msg)
-		case screenGitHub:
-			return m.updateGitHub(msg)
-		}
-	}
-
-	return m, nil
-}
-
-func (m model) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
-		m.quitting = true
-		return m, tea.Quit
-
-	case "up", "k":
-		if m.cursor > 0 {
-			m.cursor--
+		notifyCmd := m.observeWhatsApp(state)
+		if msg.Err == nil {
+			m.bridgeStatus = msg.Status
 		}
+		m, cmd := m.broadcast(msg)
+		return m, tea.Batch(notifyCmd, cmd)
 
-	case "down", "j":
-		if m.cursor < len(m.choices)-1 {
-			m.cursor++
-		}
+	case statusPollMsg, tea.FocusMsg, tea.BlurMsg, tea.ResumeMsg:
+		m, cmd := m.updatePoll(msg)
+		return m, cmd
 
-	case "enter", " ":
+	case actionResultMsg:
+		return m, tea.Batch(m.notifyResult(msg.message, msg.success), checkStatus)
 
-		switch m.cursor {
-		case 0: // Setup WhatsApp
-			m.screen = screenSetup
-			m.qrCountdown = m.qrMaxCountdown // Reset countdown
-			return m, tea.Batch(fetchBridgeStatusCmd(m.statusClient), tickCmd(), qrRefreshTickCmd())
-		case 1: // GitHub Auth — show auth status screen
-			m.screen = screenGitHub
-			m.ghChecking = true
-			return m, checkGhStatusCmd()
-		case 2: // Start
-			return m, startFetchCmd()
-		case 3: // Stop
-			return m, stopFetchCmd()
-		case 4: // Configure — go straight to editor
-			m.screen = screenConfig
-			m.configMode = 1 // Editor mode directly
-			m.configEditor = config.NewEditor()
-			m.configEditor.SetSize(m.height - 8)
-			return m, nil
-		case 5: // Trusted Numbers
-			m.screen = screenWhitelist
-			m.whitelistManager = config.NewWhitelistManager()
-			return m, nil
-		case 6: // Logs
-			m.screen = screenLogs
-			return m, fetchLogs
-		case 7: // Documentation
-			return m, openDocs
-		case 8: // Version
-			m.screen = screenVersion
-			return m, nil
-		case 9: // Exit
-			m.quitting = true
-			return m, tea.Quit
-		}
-	}
-	return m, nil
-}
+	case runningDoneMsg:
+		return m.updateRunningDone(msg)
 
-func (m model) updateSetup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
-		m.screen = screenMenu
-		return m, nil
-	case "o":
-		// Open QR URL in browser
-		if m.bridgeStatus != nil && m.bridgeStatus.QRUrl != nil {
-			exec.Command("xdg-open", *m.bridgeStatus.QRUrl).Start()
-		}
+	case toastExpiredMsg:
+		m.toasts.Dismiss(msg.id)
 		return m, nil
-	}
-	return m, nil
-}
 
-func (m model) updateConfig(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch m.configMode {
-	case 1: // Editor mode
-		if m.configEditor != nil && !m.configEditor.ModelPickerRequested() {
-			switch msg.String() {
-			case "esc":
-				m.screen = screenMenu
-				return m, nil
-			}
-		}
-		if m.configEditor != nil {
-			m.configEditor.Update(msg)
-			// Check if editor wants the model picker
-			if m.configEditor.ModelPickerRequested() {
-				m.configEditor.ClearModelPickerRequest()
-				m.configMode = 2
-				m.modelSelector = models.NewSelector()
-				return m, models.FetchModelsCmd
-			}
+	case logsscreen.ErrorsMsg:
+		// A count taken before the logs were last open is stale
+		if msg.Since.Equal(m.logsSeenAt) {
+			m.logErrors = msg.Count
 		}
 		return m, nil
 
-	case 2: // Model picker overlay
-		switch msg.String() {
-		case "esc":
-			m.configMode = 1
-			m.modelSelector = nil
-			return m, nil
-		}
-		if m.modelSelector != nil {
-			var cmd tea.Cmd
-			m.modelSelector, cmd = m.modelSelector.Update(msg)
-			return m, cmd
+	case logsscreen.TickMsg:
+		// Stop following once the tabs are closed; the next visit to Logs
+		// restarts it
+		if !m.inTabs() {
+			return m.sendScreen(screens.Logs, logsscreen.PausedMsg{})
 		}
-		return m, nil
-	}
+		return m.sendScreen(screens.Logs, msg)
 
-	return m, nil
-}
-
-func (m model) updateWhitelist(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Only allow escape when not in add mode
-	if !m.whitelistManager.IsAdding() {
-		switch msg.String() {
-		case "esc", "q":
-			m.screen = screenMenu
-			return m, nil
+	case tasks.TickMsg:
+		// Stop refreshing once the tabs are closed; the next visit to
+		// Tasks restarts it
+		if !m.inTabs() {
+			return m.sendScreen(screens.Tasks, taskscreen.PausedMsg{})
 		}
-	}
+		return m.sendScreen(screens.Tasks, msg)
 
-	if m.whitelistManager != nil {
-		m.whitelistManager.Update(msg)
-	}
-
-	return m, nil
-}
-
-func (m model) updateModels(_ tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Model selection is now handled within the config screen
-	m.screen = screenMenu
-	return m, nil
-}
-
-func (m model) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
-		m.screen = screenMenu
+	case updates.PendingMsg:
+		m.updatesPending = msg.Commits
 		return m, nil
-	}
-	// Delegate all other keys to LogViewer (scroll, copy, wrap, etc.)
-	if m.logViewer != nil {
-		m.logViewer, _ = m.logViewer.Update(msg)
-	}
-	return m, nil
-}
 
-func (m model) updateStatus(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
-		m.screen = screenMenu
-		return m, nil
-	case "r":
-		return m, checkStatus
-	}
-	return m, nil
-}
+	case updateCheckMsg:
+		m.updatesPending = msg.commits
+		m, cmd := m.sendScreen(screens.Version, version.ReleaseMsg{Release: msg.release})
+		return m, tea.Batch(cmd, m.observeUpdates(), tea.Tick(m.updateCheckInterval, func(time.Time) tea.Msg {
+			return updateCheckTickMsg{}
+		}))
 
-func (m model) updateVersion(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
-		m.screen = screenMenu
-		return m, nil
-	}
-	return m, nil
-}
+	case updateCheckTickMsg:
+		return m, checkUpdatesCmd(m.updateCheckInterval)
 
-func (m model) updateGitHub(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
-		m.screen = screenMenu
-		return m, nil
-	case "up", "k":
-		if m.ghAccountCursor > 0 {
-			m.ghAccountCursor--
-		}
-		return m, nil
-	case "down", "j":
-		if m.ghAccountCursor < len(m.ghAccounts)-1 {
-			m.ghAccountCursor++
-		}
-		return m, nil
-	case "a":
-		// Add new account via gh auth login
-		c := exec.Command("gh", "auth", "login")
-		return m, tea.ExecProcess(c, func(err error) tea.Msg {
-			return ghAuthResultMsg{err: err}
-		})
-	case "s":
-		// Switch active account to selected
-		if len(m.ghAccounts) > 0 && m.ghAccountCursor < len(m.ghAccounts) {
-			acct := m.ghAccounts[m.ghAccountCursor]
-			if !acct.active {
-				return m, switchGhAccountCmd(acct.user)
-			}
-		}
-		return m, nil
-	case "d":
-		// Remove selected account
-		if len(m.ghAccounts) > 0 && m.ghAccountCursor < len(m.ghAccounts) {
-			acct := m.ghAccounts[m.ghAccountCursor]
-			return m, logoutGhAccountCmd(acct.user)
-		}
-		return m, nil
-	case "r":
-		// Manual refresh
-		m.ghChecking = true
-		return m, checkGhStatusCmd()
-	}
-	return m, nil
-}
+	case supportBundleMsg:
+		return m.updateSupportBundle(msg)
 
-// Commands
+	case screens.ShowMsg, screens.ToastMsg, screens.ConfirmMsg, screens.RunMsg, screens.RefreshMsg:
+		return m.updateScreenRequest(msg)
 
-// startFetchCmd returns a command that starts Docker services
-func startFetchCmd() tea.Cmd {
-	return func() tea.Msg {
-		err := docker.StartServices()
-		if err != nil {
-			return actionResultMsg{success: false, message: fmt.Sprintf("Failed to start: %v", err)}
-		}
-		return actionResultMsg{success: true, message: "✅ Fetch services started!"}
-	}
-}
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
 
-// stopFetchCmd returns a command that stops Docker services
-func stopFetchCmd() tea.Cmd {
-	return func() tea.Msg {
-		err := docker.StopServices()
-		if err != nil {
-			return actionResultMsg{success: false, message: fmt.Sprintf("Failed to stop: %v", err)}
-		}
-		return actionResultMsg{success: true, message: "🛑 Fetch services stopped."}
-	}
-}
-
-func fetchLogs() tea.Msg {
-	lines := logs.GetRecentLogs("fetch-bridge", 200)
-	return logMsg{lines: lines}
-}
+	case tea.KeyMsg:
+		return m.updateKeys(msg)
 
-func openDocs() tea.Msg {
-	docsURL := "http://localhost:8765/docs"
-	err := exec.Command("xdg-open", docsURL).Start()
-	if err != nil {
-		return actionResultMsg{success: false, message: fmt.Sprintf("Failed to open docs: %v", err)}
+	default:
+		// Loads and ticks for the screens
+		return m.broadcast(msg)
 	}
-	return actionResultMsg{success: true, message: "📚 Documentation opened in browser"}
 }
 
-// checkGhStatusCmd checks current GitHub auth status via gh CLI
-func checkGhStatusCmd() tea.Cmd {
-	return func() tea.Msg {
-		out, err := exec.Command("gh", "auth", "status").CombinedOutput()
-		if err != nil && len(out) == 0 {
-			// gh not installed or no accounts
-			return ghStatusMsg{accounts: nil, err: nil}
-		}
-		// Parse all accounts from output
-		// Format:
-		//   ✓ Logged in to github.com account USERNAME (keyring)
-		//   - Active account: true/false
-		//   - Git operations protocol: https
-		//   - Token scopes: 'gist', 'read:org', 'repo', 'workflow'
-		var accounts []ghAccount
-		var current *ghAccount
-		for _, line := range strings.Split(string(out), "\n") {
-			line = strings.TrimSpace(line)
-			if strings.Contains(line, "Logged in to") && strings.Contains(line, "account") {
-				// Start a new account
-				if current != nil {
-					accounts = append(accounts, *current)
-				}
-				current = &ghAccount{}
-				parts := strings.Split(line, "account ")
-				if len(parts) >= 2 {
-					user := strings.TrimSpace(parts[1])
-					if idx := strings.Index(user, " "); idx > 0 {
-						user = user[:idx]
-					}
-					current.user = user
-				}
-			} else if current != nil {
-				if strings.HasPrefix(line, "- Active account:") {
-					current.active = strings.Contains(line, "true")
-				} else if strings.HasPrefix(line, "- Git operations protocol:") {
-					current.protocol = strings.TrimPrefix(line, "- Git operations protocol: ")
-					current.protocol = strings.TrimSpace(current.protocol)
-				} else if strings.HasPrefix(line, "- Token scopes:") {
-					current.scopes = strings.TrimPrefix(line, "- Token scopes: ")
-					current.scopes = strings.TrimSpace(current.scopes)
-				}
-			}
-		}
-		if current != nil {
-			accounts = append(accounts, *current)
-		}
-		return ghStatusMsg{accounts: accounts, err: nil}
+// updateKeys handles the keys every screen shares, then hands the rest to
+// the current screen.
+func (m model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Any key skips the splash without waiting for preflight checks
+	if m.screen == screens.Splash {
+		m, cmd := m.finishSplash()
+		return m, cmd
 	}
-}
 
-// switchGhAccountCmd switches the active GitHub account
-func switchGhAccountCmd(user string) tea.Cmd {
-	return func() tea.Msg {
-		err := exec.Command("gh", "auth", "switch", "-u", user).Run()
-		return ghSwitchMsg{err: err}
+	if msg.String() == "ctrl+z" {
+		m, cmd := m.suspend()
+		return m, cmd
 	}
-}
 
-// logoutGhAccountCmd removes a GitHub account
-func logoutGhAccountCmd(user string) tea.Cmd {
-	return func() tea.Msg {
-		err := exec.Command("gh", "auth", "logout", "-u", user).Run()
-		return ghSwitchMsg{err: err}
+	// The command palette takes all keys while open
+	if m.palette != nil {
+		return m.updatePalette(msg)
 	}
-}
-
-func (m model) View() string {
-	if m.quitting {
-		return "\n  👋 Goodbye! Fetch is resting.\n\n"
+	if msg.String() == "ctrl+p" {
+		m.showHelp = false
+		m.palette = components.NewPalette(paletteItems())
+		return m, nil
 	}
 
-	switch m.screen {
-	case screenSplash:
-		return m.viewSplash()
-	case screenConfig:
-		return m.viewConfig()
-	case screenWhitelist:
-		return m.viewWhitelist()
-	case screenLogs:
-		return m.viewLogs()
-	case screenStatus:
-		return m.viewStatus()
-	case screenSetup:
-		return m.viewSetup()
-	case screenModels:
-		return m.viewModels()
-	case screenVersion:
-		return m.viewVersion()
-	case screenGitHub:
-		return m.viewGitHub()
-	default:
-		return m.viewMenu()
+	// A pending confirmation takes all keys until answered
+	if m.confirm != nil {
+		return m.updateConfirmDialog(msg)
 	}
-}
-
-func (m model) viewMenu() string {
-	width := m.width
-	if width == 0 {
-		width = 80
+	if m.startup != nil && !m.startup.hidden {
+		return m.updateStartupKeys(msg)
 	}
-	height := m.height
-	if height == 0 {
-		height = 24
+	// Esc cancels a running action before it goes back a screen
+	if m.running != nil && !m.running.cancelled && msg.String() == "esc" {
+		m, cmd := m.cancelRunning()
+		return m, cmd
 	}
 
-	// Status bar at very bottom
-	statusBar := components.CombinedStatusBar(
-		components.StatusBarState{
-			BridgeRunning: m.bridgeRunning,
-			KennelRunning: m.kennelRunning,
-		},
-		[]string{"↑/↓ Navigate", "Enter Select", "q Quit"},
-		width,
-	)
-	statusBarHeight := lipgloss.Height(statusBar)
-
-	// Available height for main content (above status bar)
-	contentHeight := height - statusBarHeight
-
-	// Get ASCII dog art (left side)
-	dogArt := components.Header(width, contentHeight, m.getStatusString())
-
-	// Build menu panel (right side)
-	menuPanel := m.renderMenuPanel()
-
-	// Action message (show above menu if present)
-	var actionMsg string
-	if m.actionMessage != "" {
-		actionMsg = components.ActionMessage(m.actionMessage, m.actionSuccess) + "\n\n"
+	// Quick-action hotkeys work from every screen
+	if a, ok := hotkeyAction(msg.String()); ok {
+		m.showHelp = false
+		return a.run(m)
 	}
 
-	// Right side: FETCH title + menu
-	fetchTitle := lipgloss.NewStyle().
-		Foreground(theme.Primary).
-		Bold(true).
-		Render(`███████╗███████╗████████╗ ██████╗██╗  ██╗
-██╔════╝██╔════╝╚══██╔══╝██╔════╝██║  ██║
-█████╗  █████╗     ██║   ██║     ███████║
-██╔══╝  ██╔══╝     ██║   ██║     ██╔══██║
-██║     ███████╗   ██║   ╚██████╗██║  ██║
-╚═╝     ╚══════╝   ╚═╝    ╚═════╝╚═╝  ╚═╝`)
-
-	tagline := lipgloss.NewStyle().
-		Foreground(theme.TextSecondary).
-		Italic(true).
-		Render("Your Faithful Code Companion")
-
-	rightContent := lipgloss.JoinVertical(lipgloss.Left,
-		fetchTitle,
-		tagline,
-		"",
-		actionMsg+menuPanel,
-	)
-
-	// Join horizontally: dog on left, menu on right
-	mainContent := lipgloss.JoinHorizontal(lipgloss.Top,
-		dogArt,
-		"    ", // gap between dog and menu
-		rightContent,
-	)
-	mainContentHeight := lipgloss.Height(mainContent)
-
-	// Calculate spacer to push content to bottom
-	spacerHeight := contentHeight - mainContentHeight
-	if spacerHeight < 0 {
-		spacerHeight = 0
+	// Any key closes the help overlay
+	if m.showHelp {
+		m.showHelp = false
+		return m, nil
 	}
-	topSpacer := strings.Repeat("\n", spacerHeight)
-
-	// Horizontal layout: dog left, content right - aligned to bottom
-	return lipgloss.JoinVertical(lipgloss.Left,
-		topSpacer,
-		mainContent,
-		statusBar,
-	)
-}
-
-func (m model) getStatusString() string {
-	if m.bridgeRunning && m.kennelRunning {
-		return "running"
-	} else if m.bridgeRunning || m.kennelRunning {
-		return "partial"
+	if msg.String() == "?" && !m.capturingText() {
+		m.showHelp = true
+		return m, nil
 	}
-	return "stopped"
-}
-
-func (m model) renderMenuPanel() string {
-	var b strings.Builder
 
-	// Menu title with visible styling (aligned with status bar padding)
-	menuTitle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(theme.Secondary).
-		Background(theme.Surface).
-		Padding(0, 1).
-		Render("✨ Main Menu ✨")
-
-	b.WriteString("  " + menuTitle + "\n")
-
-	// Menu items (aligned with status bar's 2-space padding)
-	for i, choice := range m.choices {
-		if m.cursor == i {
-			// Selected item
-			cursor := lipgloss.NewStyle().
-				Foreground(theme.Primary).
-				Bold(true).
-				Render("▸ ")
-			item := lipgloss.NewStyle().
-				Foreground(theme.Primary).
-				Bold(true).
-				Render(choice)
-			b.WriteString(" " + cursor + item + "\n")
-		} else {
-			// Normal item
-			item := lipgloss.NewStyle().
-				Foreground(theme.TextPrimary).
-				Render(choice)
-			b.WriteString("   " + item + "\n")
-		}
+	if next, cmd, ok := m.updateTabKeys(msg); ok {
+		return next, cmd
 	}
-
-	return b.String()
+	return m.sendScreen(m.screen, msg)
 }
 
-func (m model) viewSplash() string {
-	width := m.width
-	height := m.height
-	if width == 0 {
-		width = 80
-	}
-	if height == 0 {
-		height = 24
-	}
-	return components.Splash(width, height)
+// notify shows a toast and schedules its dismissal
+func (m model) notify(message string, severity components.Severity) tea.Cmd {
+	id := m.toasts.Push(message, severity)
+	return tea.Tick(severity.Timeout(), func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
 }
 
-func (m model) viewVersion() string {
-	width := m.width
-	if width == 0 {
-		width = 80
-	}
-	height := m.height
-	if height == 0 {
-		height = 24
-	}
-
-	// Version content
-	versionContent := components.Version(m.versionInfo, width)
-	versionHeight := lipgloss.Height(versionContent)
-
-	// Help bar
-	helpBar := components.HelpBar([]string{"Esc Back"}, width)
-	helpHeight := lipgloss.Height(helpBar)
-
-	// Spacer at top to push content to bottom
-	spacerHeight := height - versionHeight - helpHeight
-	if spacerHeight < 0 {
-		spacerHeight = 0
+// notifyResult shows a success or error toast for an action result
+func (m model) notifyResult(message string, success bool) tea.Cmd {
+	if success {
+		return m.notify(message, components.SeveritySuccess)
 	}
-	topSpacer := strings.Repeat("\n", spacerHeight)
-
-	return lipgloss.JoinVertical(lipgloss.Left,
-		topSpacer,
-		versionContent,
-		helpBar,
-	)
+	return m.notify(message, components.SeverityError)
 }
 
-func (m model) viewConfig() string {
-	width := m.width
-	if width == 0 {
-		width = 80
-	}
-	height := m.height
-	if height == 0 {
-		height = 24
+// View renders the current screen, or the error screen if rendering panics.
+// The report is kept in renderCrash for the next Update to record, so it
+// is built once however often the broken screen is drawn.
+func (m model) View() (out string) {
+	if m.crash != nil && !m.quitting {
+		return present(m.viewCrash(m.crash))
 	}
-
-	var titleStr string
-	var content strings.Builder
-	var helpKeys []string
-
-	switch m.configMode {
-	case 2: // Model picker overlay
-		titleStr = layout.SectionHeader("🤖 Select Model", width-4)
-		if m.modelSelector != nil {
-			content.WriteString(m.modelSelector.View())
-		} else {
-			content.WriteString(theme.StatusInfo.Render("   Loading models...") + "\n")
-		}
-		helpKeys = []string{"↑/↓ Navigate", "Enter Select", "Tab Toggle", "Esc Back"}
-
-	default: // Editor mode
-		titleStr = layout.SectionHeader("⚙️  Configuration", width-4)
-		if m.configEditor != nil {
-			m.configEditor.SetSize(height - 8)
-			content.WriteString(m.configEditor.View())
+	defer func() {
+		if r := recover(); r != nil {
+			slot := m.renderCrash
+			if slot == nil {
+				slot = &crashSlot{}
+			}
+			if slot.report == nil {
+				slot.report = newCrashReport(r, m.screen)
+			}
+			out = present(m.viewCrash(slot.report))
 		}
-		helpKeys = []string{"↑/↓ Navigate", "Enter Edit", "s Save", "Esc Back"}
-	}
-
-	helpBar := components.HelpBar(helpKeys, width)
-	helpHeight := lipgloss.Height(helpBar)
-
-	// Content area
-	configContent := titleStr + "\n\n" + content.String()
-	contentHeight := lipgloss.Height(configContent)
-
-	// Spacer at top to push content to bottom
-	spacerHeight := height - contentHeight - helpHeight
-	if spacerHeight < 0 {
-		spacerHeight = 0
-	}
-	topSpacer := strings.Repeat("\n", spacerHeight)
-
-	return lipgloss.JoinVertical(lipgloss.Left,
-		topSpacer,
-		configContent,
-		helpBar,
-	)
+	}()
+	return m.view()
 }
 
-func (m model) viewWhitelist() string {
-	width := m.width
-	if width == 0 {
-		width = 80
-	}
-	height := m.height
-	if height == 0 {
-		height = 24
+func (m model) view() string {
+	if m.quitting {
+		return present("\n  👋 Goodbye! Fetch is resting.\n\n")
 	}
-
-	// Title
-	title := layout.SectionHeader("🔐 Trusted Numbers", width-4)
-
-	var content strings.Builder
-	if m.whitelistManager != nil {
-		content.WriteString(m.whitelistManager.View())
+	if m.width > 0 && layout.TooSmall(m.width, m.height) {
+		return present(m.viewTooSmall())
 	}
-
-	// Help bar
-	helpBar := components.HelpBar(
-		[]string{"↑/↓ Navigate", "a Add", "d Delete", "r Refresh", "Esc Back"},
-		width,
-	)
-	helpHeight := lipgloss.Height(helpBar)
-
-	// Content area
-	whitelistContent := title + "\n\n" + content.String()
-	contentHeight := lipgloss.Height(whitelistContent)
-
-	// Spacer at top to push content to bottom
-	spacerHeight := height - contentHeight - helpHeight
-	if spacerHeight < 0 {
-		spacerHeight = 0
+	if m.screen == screens.Splash {
+		return present(m.viewSplash())
 	}
-	topSpacer := strings.Repeat("\n", spacerHeight)
 
-	return lipgloss.JoinVertical(lipgloss.Left,
-		topSpacer,
-		whitelistContent,
-		helpBar,
-	)
-}
-
-func (m model) viewModels() string {
 	width := m.width
 	if width == 0 {
 		width = 80
@@ -1009,368 +522,137 @@ func (m model) viewModels() string {
 		height = 24
 	}
 
-	// Title
-	title := layout.SectionHeader("🤖 Select Model", width-4)
-
-	var content strings.Builder
-	if m.modelSelector != nil {
-		content.WriteString(m.modelSelector.View())
+	var view string
+	if m.inTabs() {
+		view = m.viewTabs(width, height)
 	} else {
-		content.WriteString(theme.StatusInfo.Render("   Loading model selector...") + "\n")
+		view = m.viewScreen()
 	}
-
-	// Help bar
-	helpKeys := []string{"↑/↓ Navigate", "Enter Select", "Tab Toggle", "Esc Back"}
-	helpBar := components.HelpBar(helpKeys, width)
-	helpHeight := lipgloss.Height(helpBar)
-
-	// Content area
-	modelContent := title + "\n\n" + content.String()
-	contentHeight := lipgloss.Height(modelContent)
-
-	spacerHeight := height - contentHeight - helpHeight
-	if spacerHeight < 0 {
-		spacerHeight = 0
+	view = present(view)
+	switch {
+	case m.palette != nil:
+		view = components.PlaceOverlay(view, present(m.palette.View(width)), width, height)
+	case m.confirm != nil:
+		view = components.PlaceOverlay(view, present(m.confirm.View(width)), width, height)
+	case m.startup != nil && !m.startup.hidden:
+		view = components.PlaceOverlay(view, present(m.viewStartup(width)), width, height)
+	case m.showHelp:
+		view = components.PlaceOverlay(view, present(m.renderHelpOverlay(width)), width, height)
 	}
-	topSpacer := strings.Repeat("\n", spacerHeight)
-
-	return lipgloss.JoinVertical(lipgloss.Left,
-		topSpacer,
-		modelContent,
-		helpBar,
-	)
+	return present(components.OverlayToasts(view, m.toasts.Active(), width))
 }
 
-func (m model) viewGitHub() string {
-	width := m.width
-	if width == 0 {
-		width = 80
-	}
-	height := m.height
-	if height == 0 {
-		height = 24
-	}
-
-	// Title
-	title := layout.SectionHeader("🔑 GitHub Authentication", width-4)
-
-	var content strings.Builder
-
-	if m.ghChecking {
-		content.WriteString(theme.StatusInfo.Render("   Checking GitHub auth status...") + "\n")
-	} else if len(m.ghAccounts) == 0 {
-		content.WriteString(theme.StatusError.Render("   ● No Accounts") + "\n\n")
-		content.WriteString(theme.Subtitle.Render("   GitHub auth is required for Fetch to access repositories") + "\n")
-		content.WriteString(theme.Subtitle.Render("   and manage pull requests via the coding agents.") + "\n\n")
-		content.WriteString(theme.StatusInfo.Render("   Press 'a' to add a GitHub account.") + "\n")
-	} else {
-		content.WriteString(fmt.Sprintf("   %s\n\n", theme.Subtitle.Render(fmt.Sprintf("%d account(s) on github.com", len(m.ghAccounts)))))
-		for i, acct := range m.ghAccounts {
-			// Cursor indicator
-			prefix := "   "
-			if i == m.ghAccountCursor {
-				prefix = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).Render(" ▸ ")
-			}
-
-			// Active badge
-			var badge string
-			if acct.active {
-				badge = theme.StatusSuccess.Render("● Active")
-			} else {
-				badge = lipgloss.NewStyle().Foreground(theme.TextMuted).Render("○ Inactive")
-			}
-
-			// Username styling
-			var userStyle lipgloss.Style
-			if i == m.ghAccountCursor {
-				userStyle = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
-			} else {
-				userStyle = theme.Value
-			}
-
-			content.WriteString(fmt.Sprintf("%s%s  %s\n", prefix, userStyle.Render(acct.user), badge))
-
-			// Show details for selected account
-			if i == m.ghAccountCursor {
-				detailIndent := "      "
-				if acct.protocol != "" {
-					content.WriteString(fmt.Sprintf("%sProtocol: %s\n", detailIndent, theme.Subtitle.Render(acct.protocol)))
-				}
-				if acct.scopes != "" {
-					content.WriteString(fmt.Sprintf("%sScopes:   %s\n", detailIndent, theme.Subtitle.Render(acct.scopes)))
-				}
-			}
-			content.WriteString("\n")
-		}
-	}
-
-	// Help bar
-	helpKeys := []string{"↑/↓ Navigate", "s Switch", "a Add", "d Remove", "r Refresh", "Esc Back"}
-	helpBar := components.HelpBar(helpKeys, width)
-	helpHeight := lipgloss.Height(helpBar)
-
-	// Content area
-	ghContent := title + "\n\n" + content.String()
-	contentHeight := lipgloss.Height(ghContent)
-
-	// Spacer at top to push content to bottom
-	spacerHeight := height - contentHeight - helpHeight
-	if spacerHeight < 0 {
-		spacerHeight = 0
-	}
-	topSpacer := strings.Repeat("\n", spacerHeight)
-
-	return lipgloss.JoinVertical(lipgloss.Left,
-		topSpacer,
-		ghContent,
-		helpBar,
-	)
+// viewTooSmall asks for a bigger terminal instead of drawing a broken layout.
+func (m model) viewTooSmall() string {
+	msg := fmt.Sprintf("Please enlarge your terminal to at least %dx%d.\n\nIt is %dx%d now.",
+		layout.MinWidth, layout.MinHeight, m.width, m.height)
+	box := lipgloss.NewStyle().
+		Foreground(theme.Active().Warning).
+		Width(max(1, min(m.width-2, 40))).
+		Align(lipgloss.Center).
+		Render(msg)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
 }
 
-func (m model) viewLogs() string {
-	width := m.width
-	if width == 0 {
-		width = 80
-	}
-	height := m.height
-	if height == 0 {
-		height = 24
-	}
-
-	if m.logViewer != nil {
-		m.logViewer.SetSize(width, height)
-		return m.logViewer.View()
-	}
-
-	// Fallback if logViewer not initialized
-	title := layout.SectionHeader("📜 Recent Logs", width-4)
-
-	var content strings.Builder
-	if len(m.logLines) == 0 {
-		content.WriteString(theme.StatusInfo.Render("No logs available. Is Fetch running?") + "\n")
-	} else {
-		for _, line := range m.logLines {
-			content.WriteString(line + "\n")
-		}
+// present applies accessibility mode to rendered output. Each layer is
+// converted before overlays are placed so their columns still line up.
+func present(s string) string {
+	if theme.Plain() {
+		return components.PlainText(s)
 	}
-
-	helpBar := components.HelpBar(
-		[]string{"Esc Back"},
-		width,
-	)
-
-	return lipgloss.JoinVertical(lipgloss.Left,
-		title,
-		content.String(),
-		helpBar,
-	)
+	return s
 }
 
-func (m model) viewStatus() string {
-	width := m.width
-	if width == 0 {
-		width = 80
+// viewScreen renders the current screen without overlays
+func (m model) viewScreen() string {
+	slot, ok := screenModelFor(m.screen)
+	if !ok {
+		slot, _ = screenModelFor(screens.Menu)
 	}
-	height := m.height
-	if height == 0 {
-		height = 24
-	}
-
-	// Title
-	title := layout.SectionHeader("ℹ️  System Status", width-4)
-
-	var content strings.Builder
+	return m.viewScreenModel(slot.get(m))
+}
 
-	// Bridge status
-	bridgeIcon := "●"
-	bridgeLabel := "Stopped"
-	bridgeStyle := theme.StatusError
-	if m.bridgeRunning {
-		bridgeLabel = "Running"
-		bridgeStyle = theme.StatusSuccess
+// altScreenSupported reports whether the terminal can be trusted with the
+// alternate screen. Dumb terminals, CI runners, and redirected output fall
+// back to inline rendering instead of leaving escape codes behind.
+func altScreenSupported() bool {
+	if term := os.Getenv("TERM"); term == "" || term == "dumb" {
+		return false
 	}
-	content.WriteString(fmt.Sprintf("   Bridge (WhatsApp):  %s\n", bridgeStyle.Render(bridgeIcon+" "+bridgeLabel)))
-
-	// Kennel status
-	kennelIcon := "●"
-	kennelLabel := "Stopped"
-	kennelStyle := theme.StatusError
-	if m.kennelRunning {
-		kennelLabel = "Running"
-		kennelStyle = theme.StatusSuccess
+	if os.Getenv("CI") != "" {
+		return false
 	}
-	content.WriteString(fmt.Sprintf("   Kennel (AI Agents): %s\n", kennelStyle.Render(kennelIcon+" "+kennelLabel)))
-
-	// Help bar
-	helpBar := components.HelpBar(
-		[]string{"r Refresh", "Esc Back"},
-		width,
-	)
-	helpHeight := lipgloss.Height(helpBar)
-
-	// Content area
-	statusContent := title + "\n\n" + content.String()
-	contentHeight := lipgloss.Height(statusContent)
-
-	// Spacer at top to push content to bottom
-	spacerHeight := height - contentHeight - helpHeight
-	if spacerHeight < 0 {
-		spacerHeight = 0
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
 	}
-	topSpacer := strings.Repeat("\n", spacerHeight)
+	return info.Mode()&os.ModeCharDevice != 0
+}
 
-	return lipgloss.JoinVertical(lipgloss.Left,
-		topSpacer,
-		statusContent,
-		helpBar,
-	)
+func main() {
+	os.Exit(run())
 }
 
-func (m model) viewSetup() string {
-	width := m.width
-	if width == 0 {
-		width = 80
+// run is main with an exit code, so deferred cleanup (the demo's temp
+// dirs, the mock bridge) runs before the process exits.
+func run() int {
+	opts := parseOptions()
+	if opts.traceCommands != "" {
+		stop, err := traceCommands(opts.traceCommands)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer stop()
 	}
-	height := m.height
-	if height == 0 {
-		height = 24
+	if opts.demo {
+		cleanup, err := startDemo(&opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer cleanup()
 	}
-
-	// Title
-	title := layout.SectionHeader("📱 WhatsApp Setup", width-4)
-
-	var content strings.Builder
-
-	if m.bridgeStatus == nil {
-		content.WriteString(theme.StatusInfo.Render("Connecting to Fetch Bridge...") + "\n")
-		content.WriteString(theme.Subtitle.Render("Make sure Fetch is running (Start Fetch from menu)") + "\n")
-	} else {
-		// Show status
-		stateEmoji := m.bridgeStatus.StateEmoji()
-		stateDesc := m.bridgeStatus.StateDescription()
-		content.WriteString(fmt.Sprintf("Status: %s %s\n\n", stateEmoji, stateDesc))
-
-		switch m.bridgeStatus.State {
-		case "qr_pending":
-			content.WriteString(theme.StatusInfo.Render("📱 Scan this QR code with WhatsApp:") + "\n\n")
-
-			if m.bridgeStatus.QRCode != nil {
-				// Render QR code in terminal (compact)
-				qrText := renderQRCodeCompact(*m.bridgeStatus.QRCode)
-				content.WriteString(qrText + "\n")
-
-				// Show countdown progress bar
-				content.WriteString(fmt.Sprintf("\n⏱️  Auto-refresh in %ds ", m.qrCountdown))
-				content.WriteString(m.qrProgress.View() + "\n\n")
-				content.WriteString(theme.Subtitle.Render("'o' open in browser | Esc go back") + "\n")
-			} else if m.bridgeStatus.QRUrl != nil {
-				content.WriteString(theme.QRBox.Render(
-					"Press 'o' to open QR in browser:\n\n"+*m.bridgeStatus.QRUrl,
-				) + "\n\n")
-			} else {
-				content.WriteString(theme.Subtitle.Render("QR code generating... wait a moment.") + "\n")
-			}
-
-		case "authenticated":
-			content.WriteString(theme.StatusSuccess.Render("✅ WhatsApp is connected and ready!") + "\n\n")
-			content.WriteString(fmt.Sprintf("Uptime: %s\n", m.bridgeStatus.FormatUptime()))
-			content.WriteString(fmt.Sprintf("Messages: %d\n", m.bridgeStatus.MessageCount))
-
-		case "disconnected":
-			content.WriteString(theme.StatusError.Render("WhatsApp disconnected.") + "\n")
-			if m.bridgeStatus.LastError != nil {
-				content.WriteString(theme.Subtitle.Render(fmt.Sprintf("Reason: %s", *m.bridgeStatus.LastError)) + "\n")
-			}
-			content.WriteString("\nTry restarting Fetch to reconnect.\n")
-
-		case "error":
-			content.WriteString(theme.StatusError.Render("An error occurred.") + "\n")
-			if m.bridgeStatus.LastError != nil {
-				content.WriteString(theme.Subtitle.Render(fmt.Sprintf("Error: %s", *m.bridgeStatus.LastError)) + "\n")
-			}
-
-		default:
-			content.WriteString(theme.Subtitle.Render("Starting up...") + "\n")
+	if opts.mockBridge {
+		stop, err := startMockBridge(&opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
 		}
+		defer stop()
 	}
-
-	// Help bar
-	helpKeys := []string{"Esc Back"}
-	if m.bridgeStatus != nil && m.bridgeStatus.State == "qr_pending" {
-		helpKeys = []string{"o Open QR", "Esc Back"}
+	if c := findCommand(opts.command); c != nil {
+		if err := c.run(opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
 	}
-	helpBar := components.HelpBar(helpKeys, width)
-	helpHeight := lipgloss.Height(helpBar)
-
-	// Content area
-	setupContent := title + "\n\n" + content.String()
-	contentHeight := lipgloss.Height(setupContent)
-
-	// Spacer at top to push content to bottom
-	spacerHeight := height - contentHeight - helpHeight
-	if spacerHeight < 0 {
-		spacerHeight = 0
+	if opts.metricsAddr != "" {
+		if err := runMetrics(opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error serving metrics: %v\n", err)
+			return 1
+		}
+		return 0
 	}
-	topSpacer := strings.Repeat("\n", spacerHeight)
-
-	return lipgloss.JoinVertical(lipgloss.Left,
-		topSpacer,
-		setupContent,
-		helpBar,
-	)
-}
-
-// renderQRCodeCompact renders a smaller QR code using Low error correction
-// and skipping every other pixel for a more compact display
-func renderQRCodeCompact(data string) string {
-	// Use Low error correction for smaller QR code
-	qr, err := qrcode.New(data, qrcode.Low)
-	if err != nil {
-		return "   Error generating QR code"
+	var programOpts []tea.ProgramOption
+	if !opts.inline && altScreenSupported() {
+		programOpts = append(programOpts, tea.WithAltScreen())
 	}
-
-	// Get the QR code as a bitmap
-	bitmap := qr.Bitmap()
-
-	// Style for the QR code box
-	boxStyle := lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#FF6B35")).
-		Padding(0, 1)
-
-	var qrContent strings.Builder
-
-	// Use unicode block characters - combine 2 rows into 1 line
-	for y := 0; y < len(bitmap)-1; y += 2 {
-		for x := 0; x < len(bitmap[y]); x++ {
-			top := bitmap[y][x]
-			bottom := false
-			if y+1 < len(bitmap) {
-				bottom = bitmap[y+1][x]
-			}
-
-			// Use half-block characters for 2:1 aspect ratio
-			if top && bottom {
-				qrContent.WriteString("█")
-			} else if top {
-				qrContent.WriteString("▀")
-			} else if bottom {
-				qrContent.WriteString("▄")
-			} else {
-				qrContent.WriteString(" ")
-			}
-		}
-		qrContent.WriteString("\n")
+	if !opts.noMouse {
+		programOpts = append(programOpts, tea.WithMouseCellMotion())
 	}
-
-	// Wrap in a styled box
-	return boxStyle.Render(qrContent.String())
-}
-
-func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	// Focus reports let status polling slow down in a background window
+	programOpts = append(programOpts, tea.WithReportFocus())
+	p := tea.NewProgram(initialModel(opts), programOpts...)
+	final, err := p.Run()
+	if err != nil {
 		fmt.Printf("Error running Fetch Manager: %v", err)
-		os.Exit(1)
+		return 1
+	}
+	if fm, ok := final.(model); ok && !opts.demo {
+		// Losing the saved screen is harmless; don't fail the exit over it
+		_ = session.Save(fm.sessionState())
 	}
+	return 0
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/health"
+	"github.com/fetch/manager/internal/metrics"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/update"
+)
+
+// runMetrics serves Prometheus metrics on addr instead of starting the TUI,
+// until interrupted. Containers and the bridge are checked on every scrape;
+// updates are checked in the background on the update-check interval since
+// that means a git fetch.
+func runMetrics(opts options) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := status.NewClient(opts.apiURL, opts.apiToken)
+	version := components.DefaultVersionInfo().Version
+
+	var mu sync.Mutex
+	var updates metrics.Snapshot // Only the update fields are used
+	if opts.updateCheckInterval > 0 {
+		go func() {
+			for {
+				var s metrics.Snapshot
+				if changes, err := update.PendingChanges(); err == nil {
+					s.UpdatesChecked = true
+					s.UpdatesPending = len(changes)
+				}
+				if rel, err := update.LatestRelease(); err == nil {
+					s.ManagerOutdated = rel.Newer(version)
+				}
+				mu.Lock()
+				updates = s
+				mu.Unlock()
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(opts.updateCheckInterval):
+				}
+			}
+		}()
+	}
+
+	collect := func() metrics.Snapshot {
+		h := health.Check(client)
+		s := metrics.Snapshot{
+			Containers: make(map[string]bool, len(h.Containers)),
+			Bridge:     h.Bridge,
+			Version:    version,
+		}
+		for _, c := range h.Containers {
+			s.Containers[c.Name] = c.Running()
+		}
+		mu.Lock()
+		s.UpdatesChecked = updates.UpdatesChecked
+		s.UpdatesPending = updates.UpdatesPending
+		s.ManagerOutdated = updates.ManagerOutdated
+		mu.Unlock()
+		return s
+	}
+
+	fmt.Printf("Serving Fetch metrics on %s/metrics (Ctrl+C to stop)\n", opts.metricsAddr)
+	return metrics.Serve(ctx, opts.metricsAddr, collect)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fetch/manager/internal/status/fakeserver"
+)
+
+// startMockBridge serves a fake bridge on a free local port and points the
+// manager at it, so the WhatsApp setup flow can be worked on without a
+// phone or containers. The returned function stops it.
+func startMockBridge(opts *options) (stop func(), err error) {
+	if opts.demo {
+		return nil, errors.New("--mock-bridge can't be combined with --demo")
+	}
+	var script []fakeserver.Step
+	if opts.mockScript != "" {
+		script, err = fakeserver.ParseScript(opts.mockScript)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --mock-script: %w", err)
+		}
+	}
+	server := fakeserver.New(opts.apiToken, script)
+	if err := server.Start("127.0.0.1:0"); err != nil {
+		return nil, err
+	}
+	opts.apiURL = server.URL()
+	return func() { server.Close() }, nil
+}
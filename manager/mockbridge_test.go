@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/exp/teatest"
+
+	"github.com/fetch/manager/internal/backup"
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/runner/runnertest"
+	"github.com/fetch/manager/internal/status/fakeserver"
+)
+
+// startWithMockBridge runs the manager against a fake bridge that starts
+// in the first of states and moves on only when the test calls Next. The
+// project is a throwaway one with a .env, and the containers are reported
+// running, so the splash goes where it would for a real deployment.
+func startWithMockBridge(t *testing.T, states ...string) (*fakeserver.Server, harness, *teatest.TestModel) {
+	t.Helper()
+	prev := paths.ProjectDir
+	paths.Use(t.TempDir())
+	t.Cleanup(func() { paths.Use(prev) })
+	if err := os.WriteFile(paths.EnvFile, []byte("OWNER_PHONE_NUMBER=15550100042\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	script := make([]fakeserver.Step, len(states))
+	for i, state := range states {
+		script[i] = fakeserver.Step{State: state}
+	}
+	server := fakeserver.New("", script)
+	if err := server.Start("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	fake := runnertest.Install(t)
+	fake.Reply("docker info", "27.3.1\n")
+	fake.Reply("docker inspect -f {{.State.Status}}", "running healthy\n")
+
+	// As parseOptions leaves them without flags
+	opts := options{apiURL: server.URL(), backupSchedule: backup.Off, backupKeep: backup.DefaultKeep}
+	h := newHarness(initialModel(opts))
+	tm := teatest.NewTestModel(t, h, teatest.WithInitialTermSize(120, 50))
+	t.Cleanup(func() { tm.Quit() })
+	h.settle(t)
+	tm.Send(splashDoneMsg{deadline: true})
+	h.settle(t)
+	return server, h, tm
+}
+
+func TestSetupFollowsMockBridge(t *testing.T) {
+	// An unlinked bridge sends the splash straight to setup. Status polls
+	// are dropped by the harness, so the screen only moves on through the
+	// event stream.
+	server, h, tm := startWithMockBridge(t, "initializing", "qr_pending", "authenticating", "authenticated")
+	h.waitForView(t, tm, "Status: ⏳ Starting up...")
+	h.waitForView(t, tm, "WhatsApp starting")
+
+	server.Next()
+	v := h.waitForView(t, tm, "Status: 📱 Waiting for QR scan")
+	if !strings.Contains(v, "Scan this QR code with WhatsApp") {
+		t.Errorf("no QR code while waiting for a scan:\n%s", v)
+	}
+	h.waitForView(t, tm, "WhatsApp awaiting QR")
+
+	server.Next()
+	h.waitForView(t, tm, "Scanned. WhatsApp is loading your chats...")
+
+	server.Next()
+	v = h.waitForView(t, tm, "Status: ✅ Connected to WhatsApp")
+	for _, want := range []string{"✓ Waiting for your phone", "✓ Linked, loading chats", "✓ Ready", "WhatsApp connected"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("linked screen is missing %q:\n%s", want, v)
+		}
+	}
+}
+
+func TestStatusScreenFollowsMockBridge(t *testing.T) {
+	server, h, tm := startWithMockBridge(t, "qr_pending", "authenticated")
+	h.waitForView(t, tm, "WhatsApp Setup")
+	// Esc asks for the menu with a command, so let it land before the 5
+	tm.Send(press("esc")[0])
+	h.settle(t)
+	tm.Send(press("5")[0])
+	h.waitForView(t, tm, "System Status")
+	h.waitForView(t, tm, "WhatsApp             Waiting for QR scan")
+
+	server.Next()
+	tm.Send(press("r")[0])
+	v := h.waitForView(t, tm, "WhatsApp             Connected to WhatsApp")
+	if !strings.Contains(v, "System Status") {
+		t.Errorf("left the status screen:\n%s", v)
+	}
+}
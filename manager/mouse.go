@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+)
+
+// updateMouse handles clicks and the scroll wheel. Overlays are keyboard
+// only, so mouse input is ignored while one is open.
+func (m model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.screen == screens.Splash || m.palette != nil || m.showHelp || m.confirm != nil || layout.TooSmall(m.width, m.height) {
+		return m, nil
+	}
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		return m.scrollMouse(msg)
+	case tea.MouseButtonLeft:
+		return m.clickMouse(msg)
+	}
+	return m, nil
+}
+
+// scrollMouse scrolls viewports directly and moves the cursor on list
+// screens as if the arrow keys were pressed.
+func (m model) scrollMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	switch m.screen {
+	case screens.Logs, screens.Update:
+		return m.sendScreen(m.screen, msg)
+	case screens.Config:
+		if m.config.Picking() {
+			return m.sendScreen(screens.Config, msg)
+		}
+	case screens.Menu, screens.Whitelist, screens.Tasks, screens.GitHub, screens.Workspaces, screens.Approvals, screens.Summaries:
+	default:
+		return m, nil
+	}
+
+	if m.capturingText() {
+		return m, nil
+	}
+	key := tea.KeyMsg{Type: tea.KeyDown}
+	if msg.Button == tea.MouseButtonWheelUp {
+		key = tea.KeyMsg{Type: tea.KeyUp}
+	}
+	return m.Update(key)
+}
+
+// clickMouse switches tabs and hands other clicks to the screen, which
+// selects menu items and config fields, and jumps to new logs from the
+// Logs screen's note.
+func (m model) clickMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.inTabs() && msg.Y == 0 {
+		i := components.TabAt(tabTitles(), tabIndex(m.screen), m.viewWidth(), msg.X)
+		if i < 0 || tabs[i].screen == m.screen {
+			return m, nil
+		}
+		return tabs[i].open(m)
+	}
+	return m.sendScreen(m.screen, screens.ClickMsg{X: msg.X, Line: m.lineAt(msg.Y)})
+}
+
+// lineAt returns row y of the current view as plain text.
+func (m model) lineAt(y int) string {
+	lines := strings.Split(m.View(), "\n")
+	if y < 0 || y >= len(lines) {
+		return ""
+	}
+	return ansi.Strip(lines[y])
+}
+
+// viewWidth is the width the view is rendered at.
+func (m model) viewWidth() int {
+	if m.width == 0 {
+		return 80
+	}
+	return m.width
+}
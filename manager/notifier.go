@@ -0,0 +1,41 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/screens/notifications"
+)
+
+// observeContainers feeds a container poll to the tracker.
+func (m model) observeContainers() tea.Cmd {
+	var cmds []tea.Cmd
+	for name, running := range map[string]bool{"fetch-bridge": m.bridgeRunning, "fetch-kennel": m.kennelRunning} {
+		if e, ok := m.notifyTracker.Container(name, running, m.servicesStopped); ok {
+			cmds = append(cmds, notifications.Send(m.notifier, e))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// observeWhatsApp feeds a bridge status poll to the tracker; state is empty
+// when the bridge didn't answer.
+func (m model) observeWhatsApp(state string) tea.Cmd {
+	e, ok := m.notifyTracker.WhatsApp(state, m.servicesStopped || m.whatsappLoggedOut)
+	if !ok {
+		return nil
+	}
+	return notifications.Send(m.notifier, e)
+}
+
+// observeUpdates feeds a background update check to the tracker.
+func (m model) observeUpdates() tea.Cmd {
+	release := ""
+	if rel := m.version.NewRelease(); rel != nil {
+		release = rel.Version
+	}
+	e, ok := m.notifyTracker.Updates(m.updatesPending, release)
+	if !ok {
+		return nil
+	}
+	return notifications.Send(m.notifier, e)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fetch/manager/internal/backup"
+	"github.com/fetch/manager/internal/config"
+	setupscreen "github.com/fetch/manager/internal/screens/setup"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+	"github.com/fetch/manager/internal/watchdog"
+)
+
+// options holds command-line and environment settings for the manager
+type options struct {
+	announceProgress bool   // Emit coarse text updates instead of redrawing progress bars
+	noMouse          bool   // Leave the mouse to the terminal so text can be selected
+	inline           bool   // Render in the normal buffer so output stays in scrollback
+	accessible       bool   // Plain text for screen readers: no emoji, art, or color-only cues
+	apiURL           string // Bridge API base URL
+	apiToken         string // Optional bearer token for the bridge API
+	qrStyle          setupscreen.QRStyle
+	// How often to check for updates in the background; 0 disables
+	updateCheckInterval time.Duration
+	backupSchedule      backup.Schedule
+	backupKeep          int // Archives kept by the retention policy
+	// Serve Prometheus metrics on this address instead of running the TUI
+	metricsAddr string
+	// Subcommand run instead of the TUI ("watchdog", "status", ...) and its
+	// arguments; empty for the TUI
+	command     string
+	commandArgs []string
+	jsonOutput  bool   // Subcommands print JSON instead of text
+	short       bool   // status: one line for status bars
+	colors      string // status --short: auto, ansi, tmux, polybar, or none
+	allModels   bool   // models: include models without tool support
+	reveal      bool   // config get: print secret values
+	// Time between watchdog checks
+	watchdogInterval time.Duration
+	// Show a made-up deployment instead of Docker, the bridge, and OpenRouter
+	demo     bool
+	demoSeed int64 // Same seed, same demo
+	// Serve a fake bridge and use it instead of the real one
+	mockBridge bool
+	mockScript string // WhatsApp states the fake bridge goes through
+	// Log every docker, git, and gh command to this file with its duration
+	traceCommands string
+}
+
+// defaultUpdateCheckInterval is used when FETCH_UPDATE_CHECK is unset
+const defaultUpdateCheckInterval = 6 * time.Hour
+
+// parseOptions reads manager options from flags, falling back to environment
+// variables and then to the project's .env file
+func parseOptions() options {
+	var opts options
+	var apiURL, apiPort string
+	flag.BoolVar(&opts.announceProgress, "announce-progress", envBool("FETCH_ANNOUNCE_PROGRESS"),
+		"show coarse textual countdowns instead of animated progress bars (screen readers, slow SSH)")
+	flag.BoolVar(&opts.accessible, "accessible", envBool("FETCH_ACCESSIBLE"),
+		"accessibility mode: plain text without emoji or art, words for every status color")
+	flag.BoolVar(&opts.noMouse, "no-mouse", envBool("FETCH_NO_MOUSE"),
+		"disable mouse support, keeping the terminal's own text selection")
+	flag.BoolVar(&opts.inline, "inline", envBool("FETCH_INLINE"),
+		"run without the alternate screen so output stays in scrollback after exit")
+	flag.StringVar(&apiURL, "api-url", envOrDotEnv("FETCH_API_URL"),
+		"bridge API base URL (default "+status.DefaultBaseURL+")")
+	flag.StringVar(&apiPort, "api-port", envOrDotEnv("FETCH_API_PORT"),
+		"bridge API port, overriding the port in --api-url")
+	flag.StringVar(&opts.apiToken, "api-token", envOrDotEnv("ADMIN_TOKEN"),
+		"bearer token for the bridge API (the bridge's ADMIN_TOKEN)")
+	var themeName string
+	flag.StringVar(&themeName, "theme", envOrDotEnv("FETCH_THEME"),
+		"color theme: "+strings.Join(theme.Names(), ", ")+" (default auto)")
+	var qrStyleName string
+	flag.StringVar(&qrStyleName, "qr-style", envOrDotEnv("FETCH_QR_STYLE"),
+		"how to draw the WhatsApp QR code: auto, half, block, ascii, inverse, or png (default auto)")
+	var updateCheck string
+	flag.StringVar(&updateCheck, "update-check", envOrDotEnv("FETCH_UPDATE_CHECK"),
+		"how often to check for updates, e.g. 6h or 1d; \"off\" disables (default 6h)")
+	var backupSchedule string
+	flag.StringVar(&backupSchedule, "backup-schedule", envOrDotEnv("FETCH_BACKUP_SCHEDULE"),
+		"back up .env and data/ automatically: daily, weekly, or off (default off)")
+	var backupKeep string
+	flag.StringVar(&backupKeep, "backup-keep", envOrDotEnv("FETCH_BACKUP_KEEP"),
+		fmt.Sprintf("number of backups to keep (default %d)", backup.DefaultKeep))
+	flag.BoolVar(&opts.demo, "demo", envBool("FETCH_DEMO"),
+		"demo mode: made-up containers, logs, and models instead of a live deployment")
+	flag.Int64Var(&opts.demoSeed, "demo-seed", 1,
+		"demo only: seed for the made-up data")
+	flag.BoolVar(&opts.mockBridge, "mock-bridge", false,
+		"development: use a fake bridge that starts up, shows a QR code, and links")
+	flag.StringVar(&opts.mockScript, "mock-script", "",
+		"mock-bridge only: states and durations, e.g. initializing:3s,qr_pending:30s,authenticated")
+	flag.StringVar(&opts.traceCommands, "trace-commands", envOrDotEnv("FETCH_TRACE_COMMANDS"),
+		"append every docker, git, and gh command run, with its duration, to this file")
+	flag.StringVar(&opts.metricsAddr, "metrics", "",
+		"serve Prometheus metrics on this address (e.g. :9091) instead of starting the TUI")
+	var watchdogInterval string
+	flag.StringVar(&watchdogInterval, "interval", envOrDotEnv("FETCH_WATCHDOG_INTERVAL"),
+		fmt.Sprintf("watchdog only: time between health checks (default %s)", watchdog.DefaultInterval))
+
+	flag.BoolVar(&opts.jsonOutput, "json", false,
+		"status, models, config get: print JSON for scripts")
+	flag.BoolVar(&opts.short, "short", false,
+		"status only: print one line for tmux or polybar status bars")
+	flag.StringVar(&opts.colors, "colors", "auto",
+		"status --short only: color markup, one of auto, ansi, tmux, polybar, or none")
+	flag.BoolVar(&opts.allModels, "all", false,
+		"models only: include models without tool support")
+	flag.BoolVar(&opts.reveal, "reveal", false,
+		"config get only: print secret values instead of withholding them")
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, "Usage: %s [command] [flags]\n\nCommands (the TUI starts without one):\n", os.Args[0])
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, c := range commands {
+			fmt.Fprintf(w, "  %s\t%s\n", c.usage, c.help)
+		}
+		w.Flush()
+		fmt.Fprintln(out, "\nFlags:")
+		flag.PrintDefaults()
+	}
+
+	// Flags may come before or after the command and its arguments
+	var positional []string
+	rest := os.Args[1:]
+	for {
+		flag.CommandLine.Parse(rest)
+		rest = flag.Args()
+		if len(rest) == 0 {
+			break
+		}
+		positional = append(positional, rest[0])
+		rest = rest[1:]
+	}
+	if len(positional) > 0 {
+		opts.command, opts.commandArgs = positional[0], positional[1:]
+		if findCommand(opts.command) == nil {
+			fmt.Fprintf(os.Stderr, "Error: unknown command %q\n", opts.command)
+			flag.Usage()
+			os.Exit(2)
+		}
+	}
+
+	if err := theme.Set(themeName); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	style, err := setupscreen.ParseQRStyle(qrStyleName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	opts.qrStyle = style
+	theme.SetPlain(opts.accessible)
+	if opts.accessible {
+		// Screen readers can't follow redrawing progress bars either
+		opts.announceProgress = true
+	}
+
+	interval, err := parseUpdateInterval(updateCheck)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	opts.updateCheckInterval = interval
+
+	if watchdogInterval != "" {
+		opts.watchdogInterval, err = time.ParseDuration(strings.TrimSpace(watchdogInterval))
+		if err != nil || opts.watchdogInterval < time.Second {
+			fmt.Fprintf(os.Stderr, "Error: invalid watchdog interval %q (use a duration like 30s or 2m)\n", watchdogInterval)
+			os.Exit(2)
+		}
+	}
+
+	opts.backupSchedule, err = backup.ParseSchedule(backupSchedule)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	opts.backupKeep = backup.DefaultKeep
+	if backupKeep != "" {
+		keep, err := strconv.Atoi(strings.TrimSpace(backupKeep))
+		if err != nil || keep < 1 {
+			fmt.Fprintf(os.Stderr, "Error: invalid backup count %q (use a number of 1 or more)\n", backupKeep)
+			os.Exit(2)
+		}
+		opts.backupKeep = keep
+	}
+
+	resolved, err := status.ResolveBaseURL(apiURL, apiPort)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	opts.apiURL = resolved
+	return opts
+}
+
+// parseUpdateInterval parses the update check interval. Empty means the
+// default; "off", "false", and "0" disable background checks.
+func parseUpdateInterval(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "":
+		return defaultUpdateCheckInterval, nil
+	case "off", "false", "no", "0":
+		return 0, nil
+	}
+	// Accept days, which time.ParseDuration doesn't
+	unit := time.Duration(1)
+	value := s
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		value, unit = days+"h", 24
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid update check interval %q", s)
+	}
+	d *= unit
+	// Checks run git fetch and hit the GitHub API; don't hammer either
+	return max(d, 10*time.Minute), nil
+}
+
+// envOrDotEnv returns an environment variable, falling back to the .env file
+func envOrDotEnv(key string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return config.EnvValue(key)
+}
+
+// envBool reports whether an environment variable (or .env entry) is set to
+// a truthy value
+func envBool(key string) bool {
+	switch strings.ToLower(envOrDotEnv(key)) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
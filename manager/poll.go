@@ -0,0 +1,149 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/health"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+)
+
+// Status polling keeps the container and bridge status fresh on every
+// screen from one loop. It polls quickly while the status is the point of
+// the screen, backs off when nobody is looking, and stops while the
+// manager is suspended.
+const (
+	pollFast   = 2 * time.Second  // Menu, Status and Setup screens
+	pollNormal = 10 * time.Second // Every other screen
+	pollIdle   = time.Minute      // No input for idleAfter, or the terminal lost focus
+	idleAfter  = 5 * time.Minute
+)
+
+// statusPollMsg refreshes the container and bridge status. gen ties it to
+// the loop that scheduled it so ticks from a replaced loop are dropped.
+type statusPollMsg struct {
+	gen int
+}
+
+// poller is the state of the status polling loop.
+type poller struct {
+	gen       int           // Current loop
+	interval  time.Duration // Interval the current loop is waiting out
+	paused    bool          // Suspended with ctrl+z
+	blurred   bool          // The terminal reported losing focus
+	lastInput time.Time
+}
+
+func statusPollCmd(gen int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return statusPollMsg{gen: gen}
+	})
+}
+
+// statusMsg carries Docker container status updates
+type statusMsg struct {
+	bridgeRunning bool
+	kennelRunning bool
+	dockerDown    bool // Only checked when no container is running
+	err           error
+}
+
+// Check Docker container status
+func checkStatus() tea.Msg {
+	if demoWorld != nil {
+		running := demoWorld.Running()
+		return statusMsg{bridgeRunning: running, kennelRunning: running}
+	}
+	s := health.Snapshot{Containers: health.CheckContainers()}
+	msg := statusMsg{
+		bridgeRunning: s.Running("fetch-bridge"),
+		kennelRunning: s.Running("fetch-kennel"),
+	}
+	// A running container means the daemon is up; otherwise ask it
+	if !msg.bridgeRunning && !msg.kennelRunning {
+		_, err := docker.DaemonVersion()
+		msg.dockerDown = err != nil
+	}
+	return msg
+}
+
+// fetchBridgeStatusCmd fetches the current bridge status as a tea.Cmd
+func fetchBridgeStatusCmd(client *status.Client) tea.Cmd {
+	return func() tea.Msg {
+		if demoWorld != nil {
+			s, err := demoWorld.BridgeStatus()
+			return screens.BridgeMsg{Status: s, Err: err}
+		}
+		s, err := client.GetStatus()
+		return screens.BridgeMsg{Status: s, Err: err}
+	}
+}
+
+// pollInterval is how often the status should be checked right now.
+func (m model) pollInterval() time.Duration {
+	if m.poll.blurred || time.Since(m.poll.lastInput) >= idleAfter {
+		return pollIdle
+	}
+	switch m.screen {
+	case screens.Menu, screens.Status, screens.Setup:
+		return pollFast
+	}
+	return pollNormal
+}
+
+// pollStatus checks the status now and schedules the next check.
+func (m model) pollStatus() (model, tea.Cmd) {
+	if m.poll.paused {
+		return m, nil
+	}
+	m.poll.interval = m.pollInterval()
+	cmds := []tea.Cmd{checkStatus, fetchBridgeStatusCmd(m.statusClient), statusPollCmd(m.poll.gen, m.poll.interval)}
+	// The menu's View Logs badge counts errors; other screens don't show it
+	if m.screen == screens.Menu && m.bridgeRunning {
+		cmds = append(cmds, m.logs.CountErrors(m.logsSeenAt))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// retunePoll restarts the loop when the status should be checked sooner
+// than the pending tick, e.g. on returning to the menu or to the keyboard.
+// Slowing down needs nothing: the next tick picks the longer interval.
+func (m model) retunePoll() (model, tea.Cmd) {
+	if m.poll.paused || m.pollInterval() >= m.poll.interval {
+		return m, nil
+	}
+	m.poll.gen++
+	return m.pollStatus()
+}
+
+// updatePoll handles the polling loop's own messages and input activity.
+func (m model) updatePoll(msg tea.Msg) (model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case statusPollMsg:
+		if msg.gen != m.poll.gen {
+			return m, nil
+		}
+		return m.pollStatus()
+	case tea.KeyMsg, tea.MouseMsg:
+		m.poll.lastInput = time.Now()
+	case tea.FocusMsg:
+		m.poll.blurred = false
+	case tea.BlurMsg:
+		m.poll.blurred = true
+	case tea.ResumeMsg:
+		m.poll.paused = false
+		m.poll.gen++
+		return m.pollStatus()
+	}
+	return m, nil
+}
+
+// suspend stops polling and hands the terminal back to the shell.
+func (m model) suspend() (model, tea.Cmd) {
+	m.poll.paused = true
+	m.poll.gen++
+	return m, tea.Suspend
+}
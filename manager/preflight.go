@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/paths"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// The splash stays up for at least splashMinimum so it doesn't flash, and
+// gives up waiting on slow checks after splashDeadline.
+const (
+	splashMinimum  = time.Second
+	splashDeadline = 6 * time.Second
+)
+
+// splashDoneMsg signals that the splash has been up for its minimum time,
+// or with deadline set, that it should stop waiting on preflight checks
+type splashDoneMsg struct {
+	deadline bool
+}
+
+// preflightCheck identifies one of the checks run behind the splash screen.
+type preflightCheck int
+
+const (
+	preflightDocker preflightCheck = iota
+	preflightEnv
+	preflightBridge
+)
+
+var preflightLabels = [...]string{
+	preflightDocker: "Docker",
+	preflightEnv:    "Configuration",
+	preflightBridge: "Bridge",
+}
+
+// preflightResult is the outcome of one check; done is false while it runs.
+type preflightResult struct {
+	done   bool
+	ok     bool
+	detail string
+}
+
+// preflightMsg reports a finished check. The bridge check also carries the
+// status it fetched so the landing screen can be chosen from it.
+type preflightMsg struct {
+	check  preflightCheck
+	ok     bool
+	detail string
+	bridge *status.BridgeStatus
+}
+
+// preflightCmds starts every check in parallel.
+func preflightCmds(client *status.Client) tea.Cmd {
+	return tea.Batch(
+		func() tea.Msg {
+			if demoWorld != nil {
+				return preflightMsg{check: preflightDocker, ok: true, detail: "demo"}
+			}
+			version, err := docker.DaemonVersion()
+			if err != nil {
+				return preflightMsg{check: preflightDocker, detail: "not reachable"}
+			}
+			return preflightMsg{check: preflightDocker, ok: true, detail: "v" + version}
+		},
+		func() tea.Msg {
+			if _, err := os.Stat(paths.EnvFile); err != nil {
+				return preflightMsg{check: preflightEnv, detail: "no .env file"}
+			}
+			return preflightMsg{check: preflightEnv, ok: true, detail: ".env found"}
+		},
+		func() tea.Msg {
+			get := client.GetStatus
+			if demoWorld != nil {
+				get = demoWorld.BridgeStatus
+			}
+			st, err := get()
+			if err != nil {
+				return preflightMsg{check: preflightBridge, detail: "not running"}
+			}
+			return preflightMsg{check: preflightBridge, ok: true, detail: "WhatsApp " + st.ShortState(), bridge: st}
+		},
+	)
+}
+
+// splashTimersCmd schedules the minimum and maximum splash durations.
+func splashTimersCmd() tea.Cmd {
+	return tea.Batch(
+		tea.Tick(splashMinimum, func(time.Time) tea.Msg { return splashDoneMsg{} }),
+		tea.Tick(splashDeadline, func(time.Time) tea.Msg { return splashDoneMsg{deadline: true} }),
+	)
+}
+
+// preflightDone reports whether every check has finished.
+func (m model) preflightDone() bool {
+	for _, r := range m.preflight {
+		if !r.done {
+			return false
+		}
+	}
+	return true
+}
+
+// updatePreflight records a finished check and leaves the splash once all
+// checks are in and the minimum time has passed.
+func (m model) updatePreflight(msg preflightMsg) (model, tea.Cmd) {
+	m.preflight[msg.check] = preflightResult{done: true, ok: msg.ok, detail: msg.detail}
+	if msg.check == preflightDocker {
+		m.dockerDown = !msg.ok
+	}
+	var cmd tea.Cmd
+	if msg.bridge != nil {
+		m.bridgeStatus = msg.bridge
+		m, cmd = m.broadcast(screens.BridgeMsg{Status: msg.bridge})
+	}
+	if m.splashMinElapsed && m.preflightDone() {
+		m, finish := m.finishSplash()
+		return m, tea.Batch(cmd, finish)
+	}
+	return m, cmd
+}
+
+// finishSplash lands on the screen the preflight results call for: the
+// config editor without a .env, the WhatsApp setup wizard when the bridge
+// is up but unlinked, and otherwise the screen the last run was left on.
+// Checks that haven't finished count as passed.
+func (m model) finishSplash() (model, tea.Cmd) {
+	if m.screen != screens.Splash {
+		return m, nil
+	}
+	m.screen = screens.Menu
+	m.splashSpinner = nil
+
+	failed := func(c preflightCheck) bool {
+		return m.preflight[c].done && !m.preflight[c].ok
+	}
+	switch {
+	case failed(preflightEnv):
+		m, cmd := m.openConfigure()
+		return m, tea.Batch(cmd, m.notify("No .env found: fill in the required settings and press s to save", components.SeverityInfo))
+	case failed(preflightDocker):
+		return m, m.notify("Docker isn't reachable: start Docker, then choose Start Fetch", components.SeverityWarning)
+	case m.bridgeStatus != nil && m.bridgeStatus.State != "authenticated":
+		return m.openSetup()
+	}
+	return m.restoreScreen()
+}
+
+// viewPreflight renders the check list shown under the splash art.
+func (m model) viewPreflight() string {
+	var b strings.Builder
+	for c, r := range m.preflight {
+		label := preflightLabels[c]
+		switch {
+		case !r.done && (theme.Plain() || m.splashSpinner == nil):
+			fmt.Fprintf(&b, "Checking %s...\n", strings.ToLower(label))
+		case !r.done:
+			// The spinner's empty label already leaves a space after it
+			fmt.Fprintf(&b, "%s%s\n", m.splashSpinner.View(), theme.Muted().Render(label))
+		case r.ok:
+			fmt.Fprintf(&b, "%s %s %s\n", theme.StatusSuccess().Render(theme.Cue("✓", "PASS")),
+				theme.Value().Render(label), theme.Muted().Render(r.detail))
+		default:
+			fmt.Fprintf(&b, "%s %s %s\n", theme.StatusWarning().Render(theme.Cue("!", "WARN")),
+				theme.Value().Render(label), theme.Muted().Render(r.detail))
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m model) viewSplash() string {
+	width := m.width
+	height := m.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+	if theme.Plain() {
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, "Fetch Manager\n\n"+m.viewPreflight())
+	}
+	return components.Splash(width, height, m.viewPreflight())
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+)
+
+// runningAction is a long Docker or bridge action in progress. The status
+// bar shows it on every screen, and Esc cancels it. One runs at a time.
+type runningAction struct {
+	label     string // What it's doing, e.g. "Stopping Fetch"
+	started   time.Time
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// runningDoneMsg carries the result of a running action.
+type runningDoneMsg struct {
+	run    *runningAction
+	result tea.Msg
+}
+
+// runCancellable runs work as the running action. work should stop early
+// once ctx is cancelled; its result is handled as if it had been sent
+// directly.
+func (m model) runCancellable(label string, work func(ctx context.Context) tea.Msg) (model, tea.Cmd) {
+	if m.running != nil {
+		return m, m.notify(m.running.label+" is still running (Esc to cancel)", components.SeverityInfo)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &runningAction{label: label, started: time.Now(), cancel: cancel}
+	m.running = run
+	return m, func() tea.Msg {
+		defer cancel()
+		return runningDoneMsg{run: run, result: work(ctx)}
+	}
+}
+
+// cancelRunning asks the running action to stop. It reports back once the
+// command it was waiting on has been killed.
+func (m model) cancelRunning() (model, tea.Cmd) {
+	m.running.cancelled = true
+	m.running.cancel()
+	return m, nil
+}
+
+// updateRunningDone clears the finished action and handles its result.
+// A cancelled action that failed is reported as cancelled, since the
+// failure is just the killed command.
+func (m model) updateRunningDone(msg runningDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.run == m.running {
+		m.running = nil
+	}
+	if r, ok := msg.result.(actionResultMsg); ok && msg.run.cancelled && !r.success {
+		return m, tea.Batch(checkStatus,
+			m.notify(msg.run.label+" cancelled; check Status for what was left running", components.SeverityWarning))
+	}
+	return m.update(msg.result)
+}
+
+// runningStatus is the status bar's note on the running action, or empty.
+func (m model) runningStatus() string {
+	if m.running == nil {
+		return ""
+	}
+	if m.running.cancelled {
+		return m.running.label + " · cancelling"
+	}
+	return m.running.label + " " + formatElapsed(time.Since(m.running.started)) + " · Esc cancel"
+}
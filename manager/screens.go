@@ -0,0 +1,240 @@
+package main
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/layout"
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/screens/approvals"
+	configscreen "github.com/fetch/manager/internal/screens/config"
+	"github.com/fetch/manager/internal/screens/console"
+	"github.com/fetch/manager/internal/screens/gitproviders"
+	"github.com/fetch/manager/internal/screens/groups"
+	"github.com/fetch/manager/internal/screens/limits"
+	logsscreen "github.com/fetch/manager/internal/screens/logs"
+	"github.com/fetch/manager/internal/screens/menu"
+	modelsscreen "github.com/fetch/manager/internal/screens/models"
+	"github.com/fetch/manager/internal/screens/notifications"
+	"github.com/fetch/manager/internal/screens/owner"
+	"github.com/fetch/manager/internal/screens/repos"
+	setupscreen "github.com/fetch/manager/internal/screens/setup"
+	"github.com/fetch/manager/internal/screens/stats"
+	statusscreen "github.com/fetch/manager/internal/screens/status"
+	"github.com/fetch/manager/internal/screens/summaries"
+	taskscreen "github.com/fetch/manager/internal/screens/tasks"
+	"github.com/fetch/manager/internal/screens/updates"
+	"github.com/fetch/manager/internal/screens/version"
+	"github.com/fetch/manager/internal/screens/whitelist"
+	"github.com/fetch/manager/internal/screens/workspaces"
+)
+
+// screenSlot reads and stores a screen model in its field of model.
+type screenSlot struct {
+	screen screens.ID
+	get    func(m model) screens.Model
+	set    func(m *model, next tea.Model)
+}
+
+// slot is the screenSlot for the screen model field points to.
+func slot[S screens.Model](s screens.ID, field func(m *model) *S) screenSlot {
+	return screenSlot{
+		screen: s,
+		get:    func(m model) screens.Model { return *field(&m) },
+		set:    func(m *model, next tea.Model) { *field(m) = next.(S) },
+	}
+}
+
+// screenModelSlots lists every screen but the splash and the error screen,
+// which the root model draws itself. Adding one is a package under
+// internal/screens, a field in model, and a line here.
+var screenModelSlots = []screenSlot{
+	slot(screens.Menu, func(m *model) *menu.Model { return &m.menu }),
+	slot(screens.Config, func(m *model) *configscreen.Model { return &m.config }),
+	slot(screens.Models, func(m *model) *modelsscreen.Model { return &m.models }),
+	slot(screens.Whitelist, func(m *model) *whitelist.Model { return &m.whitelist }),
+	slot(screens.Owner, func(m *model) *owner.Model { return &m.owner }),
+	slot(screens.Notifications, func(m *model) *notifications.Model { return &m.notifications }),
+	slot(screens.Status, func(m *model) *statusscreen.Model { return &m.status }),
+	slot(screens.Logs, func(m *model) *logsscreen.Model { return &m.logs }),
+	slot(screens.Setup, func(m *model) *setupscreen.Model { return &m.setup }),
+	slot(screens.GitHub, func(m *model) *gitproviders.Model { return &m.gitProviders }),
+	slot(screens.Repos, func(m *model) *repos.Model { return &m.repos }),
+	slot(screens.Groups, func(m *model) *groups.Model { return &m.groups }),
+	slot(screens.Stats, func(m *model) *stats.Model { return &m.stats }),
+	slot(screens.Tasks, func(m *model) *taskscreen.Model { return &m.tasks }),
+	slot(screens.Approvals, func(m *model) *approvals.Model { return &m.approvals }),
+	slot(screens.Workspaces, func(m *model) *workspaces.Model { return &m.workspaces }),
+	slot(screens.Summaries, func(m *model) *summaries.Model { return &m.summaries }),
+	slot(screens.Limits, func(m *model) *limits.Model { return &m.limits }),
+	slot(screens.Console, func(m *model) *console.Model { return &m.console }),
+	slot(screens.Update, func(m *model) *updates.Model { return &m.updates }),
+	slot(screens.Version, func(m *model) *version.Model { return &m.version }),
+}
+
+// screenModelFor returns the slot of a screen.
+func screenModelFor(s screens.ID) (screenSlot, bool) {
+	for _, slot := range screenModelSlots {
+		if slot.screen == s {
+			return slot, true
+		}
+	}
+	return screenSlot{}, false
+}
+
+// sendScreen delivers msg to the model of screen s, shown or not.
+func (m model) sendScreen(s screens.ID, msg tea.Msg) (model, tea.Cmd) {
+	slot, ok := screenModelFor(s)
+	if !ok {
+		return m, nil
+	}
+	next, cmd := slot.get(m).Update(msg)
+	slot.set(&m, next)
+	return m, cmd
+}
+
+// broadcast delivers a message the root model doesn't handle to every
+// screen model, so loads finish while the screen is hidden. Each screen
+// ignores what isn't for it.
+func (m model) broadcast(msg tea.Msg) (model, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0, len(screenModelSlots))
+	for _, slot := range screenModelSlots {
+		next, cmd := slot.get(m).Update(msg)
+		slot.set(&m, next)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// updateScreenRequest acts on what a screen model asked for.
+func (m model) updateScreenRequest(msg tea.Msg) (model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case screens.ShowMsg:
+		m.screen = msg.Screen
+	case screens.ToastMsg:
+		return m, m.notify(msg.Message, msg.Severity)
+	case screens.ConfirmMsg:
+		from := m.screen
+		return m.askConfirm(msg.Title, msg.Message, msg.Label, func(m model) (model, tea.Cmd) {
+			return m.sendScreen(from, msg.Then)
+		})
+	case screens.RunMsg:
+		return m.runAction(msg.Action)
+	case screens.RefreshMsg:
+		return m, tea.Batch(checkStatus, fetchBridgeStatusCmd(m.statusClient))
+	}
+	return m, nil
+}
+
+// tellMenu sends the menu what it shows about Fetch when that changed.
+func (m model) tellMenu() model {
+	h := menu.Health{
+		Loaded:         m.statusLoaded,
+		BridgeRunning:  m.bridgeRunning,
+		KennelRunning:  m.kennelRunning,
+		DockerDown:     m.dockerDown,
+		Bridge:         m.bridgeStatus,
+		LogErrors:      m.logErrors,
+		UpdatesPending: m.updatesPending > 0,
+		NewRelease:     m.version.NewRelease() != nil,
+		GitHubProblem:  m.gitProviders.Problem(),
+	}
+	if h == m.health {
+		return m
+	}
+	m.health = h
+	m, _ = m.sendScreen(screens.Menu, h)
+	return m
+}
+
+// screenFit is the room last sent to a screen model.
+type screenFit struct {
+	screen screens.ID
+	room   tea.WindowSizeMsg
+}
+
+// fitScreen sends the current screen model the room left above the status
+// and help bars whenever it differs from what the screen was last sent:
+// after a resize, when the screen is opened, or when either bar changes
+// height.
+func (m model) fitScreen() (model, tea.Cmd) {
+	slot, ok := screenModelFor(m.screen)
+	if !ok || m.width == 0 {
+		return m, nil
+	}
+	height := m.height
+	if m.inTabs() {
+		height -= lipgloss.Height(m.tabBar(m.width))
+	}
+	bars := m.screenBars(slot.get(m), m.width)
+	fit := screenFit{m.screen, tea.WindowSizeMsg{Width: m.width, Height: height - lipgloss.Height(bars)}}
+	if fit == m.fitted {
+		return m, nil
+	}
+	m.fitted = fit
+	return m.sendScreen(m.screen, fit.room)
+}
+
+// viewScreenModel draws a screen model above the status and help bars.
+func (m model) viewScreenModel(sm screens.Model) string {
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+	height := m.height
+	if height == 0 {
+		height = 24
+	}
+	bars := m.screenBars(sm, width)
+	if sm.HelpKeys() == nil {
+		// The screen's own help line goes right above the status bar
+		return lipgloss.JoinVertical(lipgloss.Left, sm.View(), bars)
+	}
+	return layout.Bottom(sm.View(), bars, height)
+}
+
+// screenBars renders the bars below a screen: the status bar with the
+// screen's keys on its line for the menu, the status bar alone for a
+// screen that draws its own help line, and otherwise the status bar above
+// a help bar.
+func (m model) screenBars(sm screens.Model, width int) string {
+	keys := sm.HelpKeys()
+	if inline, ok := sm.(screens.InlineHelp); ok && inline.InlineHelp() {
+		return components.CombinedStatusBar(m.statusBarState(), append(slices.Clip(keys), screens.GlobalHelp("?")...), width)
+	}
+	if keys == nil {
+		return components.StatusBar(m.statusBarState(), width)
+	}
+	return m.helpBar(keys, width)
+}
+
+// helpBar renders a screen's help bar under the status line, always
+// advertising the ? overlay
+func (m model) helpBar(keys []string, width int) string {
+	return lipgloss.JoinVertical(lipgloss.Left,
+		components.StatusBar(m.statusBarState(), width),
+		components.HelpBar(append(slices.Clip(keys), screens.GlobalHelp("?")...), width),
+	)
+}
+
+// statusBarState collects what the status bar shows. Bridge metrics are
+// left out while the container is stopped so a stale poll isn't shown.
+func (m model) statusBarState() components.StatusBarState {
+	state := components.StatusBarState{
+		BridgeRunning:   m.bridgeRunning,
+		KennelRunning:   m.kennelRunning,
+		UpdateAvailable: m.updateAvailable(),
+		Hints:           m.quickActionHints(),
+		Running:         m.runningStatus(),
+	}
+	if m.bridgeRunning && m.bridgeStatus != nil {
+		state.MessageCount = m.bridgeStatus.MessageCount
+		state.Uptime = m.bridgeStatus.ShortUptime()
+		state.WhatsApp = m.bridgeStatus.ShortState()
+		state.WhatsAppLinked = m.bridgeStatus.State == "authenticated"
+	}
+	return state
+}
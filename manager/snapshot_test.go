@@ -0,0 +1,610 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/charmbracelet/x/exp/teatest"
+
+	"github.com/fetch/manager/internal/backup"
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/limits"
+	"github.com/fetch/manager/internal/runner/runnertest"
+	"github.com/fetch/manager/internal/screens/gitproviders"
+	logsscreen "github.com/fetch/manager/internal/screens/logs"
+	setupscreen "github.com/fetch/manager/internal/screens/setup"
+	statusscreen "github.com/fetch/manager/internal/screens/status"
+	"github.com/fetch/manager/internal/screens/version"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/tasks"
+)
+
+// snapshotSizes are the terminal sizes every scenario is drawn at: the
+// smallest the TUI supports, the classic 80x24, and a wide terminal, so
+// each layout breakpoint is covered.
+var snapshotSizes = []struct{ width, height int }{
+	{40, 20},
+	{80, 24},
+	{120, 36},
+}
+
+// feedMsg in a scenario is delivered as it is, even where the harness
+// would drop a message of its type.
+type feedMsg struct{ msg tea.Msg }
+
+// settleStep in a scenario waits for the screen to settle before the next
+// message, for keys that act on data a screen loads.
+type settleStep struct{}
+
+// snapshotScenario drives the TUI from the main menu to one screen.
+type snapshotScenario struct {
+	name  string
+	steps []tea.Msg
+}
+
+// snapshotScenarios are the screens TestSnapshots draws. Every command
+// the model returns runs, against the demo, the fixture bridge, and a fake
+// runner, and the screen is captured once they are done.
+var snapshotScenarios = []snapshotScenario{
+	{"menu", nil},
+	{"setup", press("1")},
+	{"status", press("5")},
+	{"config", press("8")},
+	{"models", join(press("8"), []tea.Msg{settleStep{}}, repeat("down", 6), press("enter"))},
+	{"whitelist", press("9")},
+	{"logs", join(press("0"), []tea.Msg{settleStep{}, feedMsg{demoLogsMsg()}})},
+	{"help", press("?")},
+	{"palette", press("ctrl+p")},
+	{"stop-confirm", press("ctrl+x")},
+	{"owner", open("Change owner phone")},
+	{"notifications", open("Notifications")},
+	{"tasks", open("Tasks")},
+	{"stats", open("Statistics")},
+	{"approvals", open("Approvals")},
+	{"workspaces", open("Workspaces")},
+	{"version", open("Version")},
+	{"git-providers", open("Git Providers")},
+	{"updates", open("Update Fetch")},
+	{"limits", open("Rate limits")},
+	{"summaries", open("Conversation summaries")},
+	{"console", join(open("Test Console"), typeText("/status"), press("enter"))},
+	{"groups", open("Group chats")},
+}
+
+func join(parts ...[]tea.Msg) []tea.Msg {
+	var out []tea.Msg
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// snapshotKeys are the named keys press understands; anything else is
+// typed as runes.
+var snapshotKeys = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"ctrl+p":    tea.KeyCtrlP,
+	"ctrl+x":    tea.KeyCtrlX,
+}
+
+// press types keys, named as in msg.String().
+func press(keys ...string) []tea.Msg {
+	out := make([]tea.Msg, len(keys))
+	for i, key := range keys {
+		if t, ok := snapshotKeys[key]; ok {
+			out[i] = tea.KeyMsg{Type: t}
+		} else {
+			out[i] = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+	}
+	return out
+}
+
+func repeat(key string, n int) []tea.Msg {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = key
+	}
+	return press(keys...)
+}
+
+// typeText types text a rune at a time.
+func typeText(text string) []tea.Msg {
+	var out []tea.Msg
+	for _, r := range text {
+		out = append(out, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return out
+}
+
+// open runs the palette action whose title best matches query.
+func open(query string) []tea.Msg {
+	return join(press("ctrl+p"), typeText(query), press("enter"), []tea.Msg{settleStep{}})
+}
+
+// TestSnapshots draws each scenario at each size in demo mode and compares
+// the screens with the golden files in testdata/TestSnapshots, or rewrites
+// them with -update. Colors are stripped, so a snapshot changes with layout
+// and text, not the theme, and wall-clock times are masked. A screen larger
+// than the terminal fails either way: Bubble Tea would cut it off, which
+// otherwise goes unnoticed. Pick scenarios with -run, e.g.
+// -run 'TestSnapshots/menu'.
+func TestSnapshots(t *testing.T) {
+	// Dates on screen shouldn't depend on the machine's zone
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	// The bridge is the fixture, at the address a real one would have, so
+	// links to it don't change from run to run
+	bridge := httptest.NewServer(fixtureBridge())
+	t.Cleanup(bridge.Close)
+	transport := http.DefaultTransport
+	http.DefaultTransport = fixtureTransport{bridge: bridge.Listener.Addr().String(), next: transport}
+	t.Cleanup(func() { http.DefaultTransport = transport })
+
+	for _, sc := range snapshotScenarios {
+		for _, size := range snapshotSizes {
+			t.Run(fmt.Sprintf("%s-%dx%d", sc.name, size.width, size.height), func(t *testing.T) {
+				got := renderSnapshot(t, sc, size.width, size.height)
+				if overflow := snapshotOverflow(got, size.width, size.height); overflow != "" {
+					t.Errorf("screen doesn't fit: %s", overflow)
+				}
+				golden.RequireEqual(t, []byte(got))
+			})
+		}
+	}
+}
+
+// renderSnapshot runs a scenario at one size in a fresh demo, so scenarios
+// don't see each other's clock, containers, or saved state.
+func renderSnapshot(t *testing.T, sc snapshotScenario, width, height int) string {
+	t.Helper()
+	// As parseOptions leaves them without flags
+	opts := options{demoSeed: 1, backupSchedule: backup.Off, backupKeep: backup.DefaultKeep}
+	cleanup, err := startDemo(&opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		demoWorld = nil
+		cleanup()
+	})
+	opts.apiURL = status.DefaultBaseURL
+	fake := runnertest.Install(t)
+	fakeCommands(fake)
+
+	m := initialModel(opts)
+	// The Version screen shows the toolchain the test was built with
+	m.versionInfo.GoVersion = "go1.24.0"
+	m.version = version.New(m.statusClient, m.versionInfo)
+	h := newHarness(m)
+	tm := teatest.NewTestModel(t, h, teatest.WithInitialTermSize(width, height))
+	h.settle(t)
+	tm.Send(splashDoneMsg{deadline: true})
+	h.settle(t)
+	for _, msg := range sc.steps {
+		if _, ok := msg.(settleStep); ok {
+			h.settle(t)
+			continue
+		}
+		tm.Send(msg)
+	}
+	h.settle(t)
+
+	if err := tm.Quit(); err != nil {
+		t.Fatal(err)
+	}
+	final := tm.FinalModel(t, teatest.WithFinalTimeout(5*time.Second)).(harness)
+	return maskClock(ansi.Strip(final.model.View())) + "\n"
+}
+
+// fakeCommands answers the commands the demo still runs: the update
+// check's git calls, the git provider logins, and the container builds
+// the Version screen reads.
+func fakeCommands(fake *runnertest.Fake) {
+	fake.Reply("git fetch", "")
+	fake.Reply("git log --oneline", "9f8e7d6 Show PR links on the task board\n1a2b3c4 Fit the logs help line in 80 columns\n")
+	fake.Reply("git rev-parse --short HEAD", "0123456\n")
+	fake.Reply("git rev-parse", "0123456789abcdef0123456789abcdef01234567\n")
+	fake.Reply("git describe", "v3.4.2\n")
+	fake.Reply("gh auth status", "github.com\n  ✓ Logged in to github.com account fetch-demo (keyring)\n")
+	fake.Reply("gh api user", `{"login":"fetch-demo"}`)
+	fake.Reply("docker compose config --format json", `{"services":{"fetch-bridge":{"environment":{"OWNER_PHONE_NUMBER":"+15550100042","AGENT_MODEL":"anthropic/claude-sonnet-4"}}}}`)
+	fake.Reply("docker inspect -f {{.State.Running}}", "true\n")
+	fake.Reply("docker inspect -f {{index .Config.Labels", "0123456789abcdef0123456789abcdef01234567\n")
+	fake.Fail("glab", 1, "")
+}
+
+// clockPattern matches a wall-clock time of day, which a screen shows for
+// things that happened while the snapshot ran.
+var clockPattern = regexp.MustCompile(`\b\d\d:\d\d:\d\d\b`)
+
+func maskClock(s string) string {
+	return clockPattern.ReplaceAllString(s, "hh:mm:ss")
+}
+
+// settleGrace is how long the screen must go without a message, and a
+// command must run, before settle counts it as a timer rather than work
+// still to finish.
+const settleGrace = 200 * time.Millisecond
+
+// harness runs the model under teatest. It drops timer messages, so a
+// screen holds still once its commands are done, and tracks the commands
+// still running so settle can tell when that is.
+type harness struct {
+	model tea.Model
+	cmds  *cmdTracker
+}
+
+// cmdTracker counts the commands the model has returned that haven't
+// finished.
+type cmdTracker struct {
+	mu      sync.Mutex
+	next    int
+	running map[int]time.Time // When each unfinished command started
+	lastMsg time.Time         // When the model last got a message
+}
+
+func newHarness(m tea.Model) harness {
+	return harness{model: m, cmds: &cmdTracker{running: map[int]time.Time{}, lastMsg: time.Now()}}
+}
+
+func (h harness) Init() tea.Cmd {
+	return h.cmds.track(h.model.Init())
+}
+
+func (h harness) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	h.cmds.mu.Lock()
+	h.cmds.lastMsg = time.Now()
+	h.cmds.mu.Unlock()
+	if probe, ok := msg.(viewProbeMsg); ok {
+		probe <- h.model.View()
+		return h, nil
+	}
+	if fed, ok := msg.(feedMsg); ok {
+		msg = fed.msg
+	} else if timerMsg(msg) || parsedLogMsg(msg) {
+		return h, nil
+	}
+	var cmd tea.Cmd
+	h.model, cmd = h.model.Update(msg)
+	return h, h.cmds.track(cmd)
+}
+
+func (h harness) View() string { return h.model.View() }
+
+// viewProbeMsg asks the harness for the screen as drawn now. The view is
+// read in the program's goroutine, so it doesn't race with Update.
+type viewProbeMsg chan string
+
+// view returns the screen as drawn now, without styling.
+func (h harness) view(t *testing.T, tm *teatest.TestModel) string {
+	t.Helper()
+	probe := make(viewProbeMsg, 1)
+	tm.Send(probe)
+	select {
+	case v := <-probe:
+		return ansi.Strip(v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the program didn't draw in 5s")
+		return ""
+	}
+}
+
+// waitForView waits up to 10s for the screen to show want.
+func (h harness) waitForView(t *testing.T, tm *teatest.TestModel, want string) string {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		v := h.view(t, tm)
+		if strings.Contains(v, want) {
+			return v
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("screen never showed %q; last drawn:\n%s", want, v)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// timerMsg reports whether msg comes from a timer: animation, polling,
+// debouncing, and expiry, which would keep the screen moving.
+func timerMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case spinner.TickMsg, setupscreen.TickMsg, statusPollMsg, logsscreen.TickMsg,
+		toastExpiredMsg, statusscreen.BackupTickMsg, gitproviders.RefreshMsg, updateCheckTickMsg,
+		startupPollMsg, startupCloseMsg, setupscreen.ServeStopMsg, logsscreen.SettledMsg,
+		setupscreen.DoneMsg, tasks.TickMsg, tasks.ApprovalsTickMsg, limits.TickMsg:
+		return true
+	case splashDoneMsg:
+		// The harness ends the splash itself once startup has settled
+		return !msg.(splashDoneMsg).deadline
+	}
+	return false
+}
+
+// parsedLogMsg reports whether msg carries log lines read from the demo
+// containers. What they look like is up to the log parser, not the
+// screens, so the logs scenario feeds its own already parsed.
+func parsedLogMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case logsscreen.Msg, logsscreen.ErrorsMsg:
+		return true
+	}
+	return false
+}
+
+var cmdType = reflect.TypeOf(tea.Cmd(nil))
+
+// track wraps cmd to count it as running until it returns. Batches and
+// sequences come back as lists of commands, which are wrapped in turn.
+func (c *cmdTracker) track(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	c.mu.Lock()
+	id := c.next
+	c.next++
+	c.running[id] = time.Now()
+	c.mu.Unlock()
+
+	return func() tea.Msg {
+		defer func() {
+			c.mu.Lock()
+			delete(c.running, id)
+			c.mu.Unlock()
+		}()
+		msg := cmd()
+		if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().Elem() == cmdType {
+			wrapped := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			for i := range v.Len() {
+				inner, _ := v.Index(i).Interface().(tea.Cmd)
+				wrapped.Index(i).Set(reflect.ValueOf(c.track(inner)))
+			}
+			return wrapped.Interface()
+		}
+		return msg
+	}
+}
+
+// settled reports whether no message has arrived for settleGrace and every
+// command still running has run at least that long, i.e. is waiting on a
+// timer or a stream rather than doing work.
+func (c *cmdTracker) settled(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.lastMsg) < settleGrace {
+		return false
+	}
+	for _, started := range c.running {
+		if now.Sub(started) < settleGrace {
+			return false
+		}
+	}
+	return true
+}
+
+// settle waits for the messages sent so far, and the commands they start,
+// to be handled.
+func (h harness) settle(t *testing.T) {
+	t.Helper()
+	time.Sleep(settleGrace)
+	deadline := time.Now().Add(10 * time.Second)
+	for !h.cmds.settled(time.Now()) {
+		if time.Now().After(deadline) {
+			t.Fatal("screen didn't settle in 10s")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// fixtureBridge serves the bridge endpoints the data screens read, with a
+// small made-up deployment. Times are relative to the request, so ages on
+// screen stay the same from run to run.
+func fixtureBridge() http.Handler {
+	// Half a second short, so an age rounded to the second comes out the
+	// same however long the screen takes to draw, up to a second
+	ago := func(d time.Duration) *time.Time {
+		at := time.Now().Add(-d + 500*time.Millisecond)
+		return &at
+	}
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	percent := 60
+	version, commit := "3.4.2", "0123456"
+	mode := "chat"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tasks", func(w http.ResponseWriter, r *http.Request) {
+		writeFixture(w, map[string][]status.Task{"tasks": {
+			{
+				ID: "tsk_7KmQ2a", Goal: "Fix the flaky login test in fetch-web", Workspace: "fetch-web",
+				Agent: "claude", Status: "running", CreatedAt: *ago(4 * time.Minute), StartedAt: ago(3 * time.Minute),
+				Progress: []status.TaskProgress{{Timestamp: *ago(time.Minute), Message: "Running npm test", Percent: &percent}},
+			},
+			{
+				ID: "tsk_3VnX8d", Goal: "Bump lodash to 4.17.21", Workspace: "fetch-api",
+				Agent: "copilot", Status: "waiting_input", CreatedAt: *ago(12 * time.Minute), StartedAt: ago(11 * time.Minute),
+				Question: "The lockfile also pins lodash.merge. Update it too?",
+			},
+			{
+				ID: "tsk_9LpR4c", Goal: "Add dark mode to the settings page", Workspace: "fetch-web",
+				Agent: "claude", Status: "completed", CreatedAt: *ago(time.Hour), StartedAt: ago(59 * time.Minute), CompletedAt: ago(47 * time.Minute),
+				Result: &status.TaskResult{Success: true, Summary: "Opened https://github.com/fetch-demo/fetch-web/pull/42"},
+			},
+			{
+				ID: "tsk_1HdW6e", Goal: "Push the release branch", Workspace: "fetch-api",
+				Agent: "gemini", Status: "failed", CreatedAt: *ago(2 * time.Hour), StartedAt: ago(2 * time.Hour), CompletedAt: ago(118 * time.Minute),
+				Result: &status.TaskResult{Error: "push was rejected (non-fast-forward)", ExitCode: 1},
+			},
+		}})
+	})
+	mux.HandleFunc("GET /api/stats", func(w http.ResponseWriter, r *http.Request) {
+		var stats status.TrafficStats
+		for i := range 12 {
+			stats.Hours = append(stats.Hours, status.HourStats{
+				Hour:      day.Add(time.Duration(8+i) * time.Hour),
+				Messages:  []int{3, 8, 14, 21, 17, 9, 12, 25, 19, 7, 4, 2}[i],
+				ToolCalls: []int{1, 4, 9, 15, 11, 5, 8, 18, 12, 3, 2, 0}[i],
+				Errors:    []int{0, 0, 1, 0, 2, 0, 0, 1, 0, 0, 0, 0}[i],
+			})
+		}
+		writeFixture(w, stats)
+	})
+	mux.HandleFunc("GET /api/workspaces", func(w http.ResponseWriter, r *http.Request) {
+		writeFixture(w, map[string][]status.Workspace{"workspaces": {
+			{
+				ID: "fetch-web", Path: "/workspace/fetch-web", ProjectType: "node", Branch: "fix/login",
+				Dirty: true, Ahead: 2, ChangedFiles: 3, RemoteURL: "https://github.com/fetch-demo/fetch-web",
+				IsActive: true, LastAccessedAt: ago(3*time.Minute + 30*time.Second),
+				LastTask: &status.WorkspaceTask{ID: "tsk_9LpR4c", Goal: "Add dark mode to the settings page", Status: "completed", CompletedAt: ago(47 * time.Minute)},
+			},
+			{
+				ID: "fetch-api", Path: "/workspace/fetch-api", ProjectType: "go", Branch: "main",
+				Behind: 4, RemoteURL: "https://github.com/fetch-demo/fetch-api", LastAccessedAt: ago(26 * time.Hour),
+			},
+			{ID: "notes", Path: "/workspace/notes", ProjectType: "unknown", LastAccessedAt: ago(40 * 24 * time.Hour)},
+		}})
+	})
+	mux.HandleFunc("GET /api/summaries", func(w http.ResponseWriter, r *http.Request) {
+		writeFixture(w, status.SummariesResponse{
+			Summaries: []status.ConversationSummary{
+				{
+					ID: "sum_1", SessionID: "15550100042", ThreadID: "main",
+					Content:   "The owner asked why CI fails on fetch-web. A task fixed the flaky login test and opened PR #42.",
+					CreatedAt: day.Add(9*time.Hour + 40*time.Minute),
+				},
+				{
+					ID: "sum_2", SessionID: "15550100077", ThreadID: "main",
+					Content:   "Sam asked for the lodash bump on fetch-api and is waiting on the lockfile question.",
+					CreatedAt: day.Add(11*time.Hour + 5*time.Minute),
+				},
+			},
+			Failures: []status.SummaryFailure{
+				{SessionID: "15550100077", Error: "OpenRouter returned 429 Too Many Requests", At: day.Add(10*time.Hour + 15*time.Minute)},
+			},
+		})
+	})
+	mux.HandleFunc("GET /api/limits", func(w http.ResponseWriter, r *http.Request) {
+		writeFixture(w, status.LimitsStatus{
+			CircuitBreaker: status.CircuitBreakerStatus{
+				Threshold: 3, BackoffMs: []int{1000, 5000, 30000}, ResetMs: 300000,
+				Sessions: []status.SessionBreaker{
+					{SessionID: "15550100077", State: status.BreakerOpen, ErrorCount: 3, LastErrorAt: ago(10 * time.Second), RetryAt: ago(-20 * time.Second)},
+					{SessionID: "15550100042", State: status.BreakerClosed, ErrorCount: 1, LastErrorAt: ago(5 * time.Minute)},
+				},
+			},
+			RateLimit: status.RateLimitStatus{
+				MaxRequests: 30, WindowMs: 60000,
+				Keys: []status.RateLimitKey{
+					{Key: "15550100042", Count: 4, Remaining: 26},
+					{Key: "15550100077", Count: 30, Blocked: 2},
+				},
+			},
+		})
+	})
+	mux.HandleFunc("GET /api/groups", func(w http.ResponseWriter, r *http.Request) {
+		writeFixture(w, map[string][]status.Group{"groups": {
+			{ID: "120363025746182934@g.us", Name: "Fetch maintainers", Participants: 4},
+			{ID: "120363041187523316@g.us", Name: "Family", Participants: 7},
+		}})
+	})
+	mux.HandleFunc("GET /api/version", func(w http.ResponseWriter, r *http.Request) {
+		writeFixture(w, status.BridgeVersion{Version: &version, Commit: &commit, Node: "v20.11.1"})
+	})
+	mux.HandleFunc("POST /api/test-message", func(w http.ResponseWriter, r *http.Request) {
+		writeFixture(w, status.TestMessageResult{
+			Responses:  []string{"🟢 Fetch is running. WhatsApp is connected and 1 task is in progress."},
+			ToolCalls:  []status.ToolCall{{Name: "task_status", Args: map[string]any{}, Result: map[string]any{"success": true}}},
+			Usage:      &status.TokenUsage{PromptTokens: 812, CompletionTokens: 41, TotalTokens: 853},
+			Mode:       &mode,
+			DurationMs: 1240,
+		})
+	})
+	return mux
+}
+
+// demoLogsMsg is a few bridge lines, one per level, already parsed so the
+// snapshot follows the viewer's layout rather than the log parser.
+func demoLogsMsg() tea.Msg {
+	at := time.Date(2025, 6, 2, 9, 32, 38, 0, time.UTC)
+	lines := []struct {
+		after   time.Duration
+		level   string
+		message string
+	}{
+		{0, "INFO", `💬 Message from Owner: "what's failing in CI on fetch-web?"`},
+		{2 * time.Second, "DEBUG", "🔍 Routing intent: chat (confidence 0.87)"},
+		{9 * time.Second, "WARN", "⚠️ Rate limit: Sam sent 5 messages in 60s, slowing down"},
+		{22 * time.Second, "ERROR", "Task tsk_9LpR4c failed: push to fix/login was rejected (non-fast-forward)"},
+		{24 * time.Second, "INFO", "📘 Task tsk_9LpR4c completed in 1m12s"},
+	}
+	var msg logsscreen.Msg
+	for _, l := range lines {
+		msg.Entries = append(msg.Entries, components.LogEntry{
+			Timestamp: at.Add(l.after),
+			Level:     l.level,
+			Source:    "bridge",
+			Message:   l.message,
+			Raw:       l.message,
+		})
+	}
+	return msg
+}
+
+// fixtureTransport sends requests for the bridge's default address to the
+// fixture bridge.
+type fixtureTransport struct {
+	bridge string // Host and port the fixture listens on
+	next   http.RoundTripper
+}
+
+func (t fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if "http://"+req.URL.Host == status.DefaultBaseURL {
+		req = req.Clone(req.Context())
+		req.URL.Host = t.bridge
+	}
+	return t.next.RoundTrip(req)
+}
+
+func writeFixture(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// snapshotOverflow describes how a screen doesn't fit the terminal, or
+// returns "" when it fits.
+func snapshotOverflow(got string, width, height int) string {
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) > height {
+		return fmt.Sprintf("%d lines for a %d line terminal", len(lines), height)
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w > width {
+			return fmt.Sprintf("line %d is %d columns wide", i+1, w)
+		}
+	}
+	return ""
+}
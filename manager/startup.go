@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/docker"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/theme"
+)
+
+// Start Fetch waits for the bridge to answer before calling Fetch started,
+// since compose returning doesn't mean Fetch can take messages yet.
+const (
+	startupPollInterval = time.Second
+	startupDeadline     = 2 * time.Minute // Gives up on the bridge and WhatsApp after this
+	startupCloseDelay   = 1500 * time.Millisecond
+)
+
+// startupStep is one stage of bringing Fetch up, in order.
+type startupStep int
+
+const (
+	startupContainers startupStep = iota
+	startupBridge
+	startupWhatsApp
+)
+
+var startupLabels = [...]string{
+	startupContainers: "Containers",
+	startupBridge:     "Bridge",
+	startupWhatsApp:   "WhatsApp",
+}
+
+// startupOutcome is where one step stands.
+type startupOutcome int
+
+const (
+	startupPending startupOutcome = iota
+	startupRunning
+	startupOK
+	startupWarn   // Fetch is up but needs attention, e.g. a QR scan
+	startupFailed // Fetch isn't usable
+)
+
+type startupResult struct {
+	outcome startupOutcome
+	detail  string
+}
+
+// startupRun tracks one Start Fetch. Messages carry the run they belong to,
+// so a second start ignores the first one's stragglers.
+type startupRun struct {
+	started time.Time
+	steps   [len(startupLabels)]startupResult
+	spinner *components.Spinner
+	hidden  bool // Hidden while it runs; toasts still report the outcome
+	done    bool
+	cancel  context.CancelFunc // Stops compose up while the containers start
+}
+
+// startupMsg advances a run: the containers report err, the bridge
+// healthy, and WhatsApp the status fetched (nil if the bridge didn't answer).
+type startupMsg struct {
+	run     *startupRun
+	step    startupStep
+	err     error
+	healthy bool
+	bridge  *status.BridgeStatus
+}
+
+// startupPollMsg checks the current step again.
+type startupPollMsg struct {
+	run  *startupRun
+	step startupStep
+}
+
+// startupCloseMsg closes the panel after a clean start.
+type startupCloseMsg struct {
+	run *startupRun
+}
+
+// startServices starts the containers and shows their progress until the
+// bridge answers and WhatsApp reports its state.
+func (m model) startServices() (model, tea.Cmd) {
+	if demoWorld != nil {
+		return m.demoServices("start")
+	}
+	if m.startup != nil && !m.startup.done {
+		m.startup.hidden = false
+		return m, nil
+	}
+	m.servicesStopped = false
+	run := &startupRun{
+		started: time.Now(),
+		spinner: components.NewSpinner(components.SpinnerMiniDot, ""),
+	}
+	run.steps[startupContainers].outcome = startupRunning
+	ctx, cancel := context.WithCancel(context.Background())
+	run.cancel = cancel
+	m.startup = run
+	return m, tea.Batch(run.spinner.Init(), func() tea.Msg {
+		defer cancel()
+		return startupMsg{run: run, step: startupContainers, err: docker.StartServices(ctx)}
+	})
+}
+
+// startupCheckCmd runs the check for step once.
+func startupCheckCmd(client *status.Client, run *startupRun, step startupStep) tea.Cmd {
+	return func() tea.Msg {
+		switch step {
+		case startupBridge:
+			return startupMsg{run: run, step: step, healthy: client.IsHealthy()}
+		default:
+			s, _ := client.GetStatus()
+			return startupMsg{run: run, step: step, bridge: s}
+		}
+	}
+}
+
+func startupPollCmd(run *startupRun, step startupStep) tea.Cmd {
+	return tea.Tick(startupPollInterval, func(time.Time) tea.Msg {
+		return startupPollMsg{run: run, step: step}
+	})
+}
+
+// updateStartup advances the run a message belongs to.
+func (m model) updateStartup(msg tea.Msg) (model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case startupPollMsg:
+		if msg.run != m.startup || msg.run.done {
+			return m, nil
+		}
+		return m, startupCheckCmd(m.statusClient, msg.run, msg.step)
+	case startupCloseMsg:
+		if msg.run == m.startup {
+			m.startup = nil
+		}
+		return m, nil
+	case startupMsg:
+		run := msg.run
+		if run != m.startup || run.done {
+			return m, nil
+		}
+		elapsed := formatElapsed(time.Since(run.started))
+		timedOut := time.Since(run.started) > startupDeadline
+
+		switch msg.step {
+		case startupContainers:
+			if errors.Is(msg.err, context.Canceled) {
+				run.steps[startupContainers] = startupResult{startupFailed, "cancelled"}
+				run.done = true
+				if run.hidden {
+					m.startup = nil
+				}
+				return m, tea.Batch(checkStatus, m.notify("Start cancelled; containers already up keep running", components.SeverityWarning))
+			}
+			if msg.err != nil {
+				run.steps[startupContainers] = startupResult{startupFailed, "compose up failed"}
+				run.done = true
+				if run.hidden {
+					m.startup = nil
+				}
+				return m, tea.Batch(checkStatus, m.notify(fmt.Sprintf("Failed to start: %v", msg.err), components.SeverityError))
+			}
+			run.steps[startupContainers] = startupResult{startupOK, "created"}
+			run.steps[startupBridge] = startupResult{startupRunning, "waiting for /api/health"}
+			return m, tea.Batch(checkStatus, startupCheckCmd(m.statusClient, run, startupBridge))
+
+		case startupBridge:
+			switch {
+			case msg.healthy:
+				run.steps[startupBridge] = startupResult{startupOK, "healthy after " + elapsed}
+				run.steps[startupWhatsApp] = startupResult{startupRunning, "starting"}
+				return m, tea.Batch(m.notify("Fetch started!", components.SeveritySuccess),
+					startupCheckCmd(m.statusClient, run, startupWhatsApp))
+			case timedOut:
+				run.steps[startupBridge] = startupResult{startupFailed, "no answer after " + elapsed + "; check Logs"}
+				run.done = true
+				if run.hidden {
+					m.startup = nil
+				}
+				return m, m.notify("Containers are up but the bridge isn't answering", components.SeverityError)
+			}
+			return m, startupPollCmd(run, startupBridge)
+
+		case startupWhatsApp:
+			state := ""
+			if msg.bridge != nil {
+				state = msg.bridge.State
+			}
+			switch state {
+			case "authenticated":
+				run.steps[startupWhatsApp] = startupResult{startupOK, "connected"}
+				run.done = true
+				return m, tea.Tick(startupCloseDelay, func(time.Time) tea.Msg { return startupCloseMsg{run: run} })
+			case "qr_pending":
+				run.steps[startupWhatsApp] = startupResult{startupWarn, "not linked yet"}
+				return m.endStartup(run, "WhatsApp isn't linked yet: open Setup WhatsApp to scan the QR code")
+			case "disconnected", "error":
+				run.steps[startupWhatsApp] = startupResult{startupWarn, msg.bridge.StateDescription()}
+				return m.endStartup(run, "WhatsApp: "+msg.bridge.StateDescription())
+			}
+			if timedOut {
+				run.steps[startupWhatsApp] = startupResult{startupWarn, "still starting after " + elapsed}
+				return m.endStartup(run, "WhatsApp is still starting; check Logs if it doesn't connect")
+			}
+			if msg.bridge != nil {
+				run.steps[startupWhatsApp].detail = msg.bridge.ShortState()
+			}
+			return m, startupPollCmd(run, startupWhatsApp)
+		}
+	}
+	return m, nil
+}
+
+// endStartup finishes a run that needs attention. A hidden panel is
+// dropped and message shown as a toast instead.
+func (m model) endStartup(run *startupRun, message string) (model, tea.Cmd) {
+	run.done = true
+	if !run.hidden {
+		return m, nil
+	}
+	m.startup = nil
+	return m, m.notify(message, components.SeverityWarning)
+}
+
+// updateStartupKeys handles keys while the panel shows: Esc cancels
+// compose up while the containers start and hides the panel after that
+// (the run carries on), h hides it at any point, and Enter opens WhatsApp
+// setup once linking is needed.
+func (m model) updateStartupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	run := m.startup
+	switch msg.String() {
+	case "esc", "q":
+		switch {
+		case run.done:
+			m.startup = nil
+		case run.steps[startupContainers].outcome == startupRunning:
+			run.cancel()
+			run.steps[startupContainers].detail = "cancelling"
+		default:
+			run.hidden = true
+		}
+	case "h":
+		if !run.done {
+			run.hidden = true
+		}
+	case "enter":
+		if !run.done {
+			return m, nil
+		}
+		m.startup = nil
+		if m.bridgeStatus != nil && m.bridgeStatus.State == "qr_pending" {
+			return m.openSetup()
+		}
+	}
+	return m, nil
+}
+
+// viewStartup renders the start progress panel.
+func (m model) viewStartup(width int) string {
+	run := m.startup
+	boxWidth := min(56, width-4)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(theme.Active().Primary).Bold(true).Render("🚀 Starting Fetch") + "\n\n")
+	for step, r := range run.steps {
+		label := startupLabels[step]
+		var mark string
+		switch r.outcome {
+		case startupPending:
+			mark = theme.Muted().Render("·")
+		case startupRunning:
+			if theme.Plain() {
+				mark = "..."
+			} else {
+				mark = strings.TrimSpace(run.spinner.View())
+			}
+		case startupOK:
+			mark = theme.StatusSuccess().Render(theme.Cue("✓", "PASS"))
+		case startupWarn:
+			mark = theme.StatusWarning().Render(theme.Cue("!", "WARN"))
+		case startupFailed:
+			mark = theme.StatusError().Render(theme.Cue("✗", "FAIL"))
+		}
+		fmt.Fprintf(&b, "%s %s %s\n", mark, theme.Value().Render(fmt.Sprintf("%-11s", label)), theme.Muted().Render(r.detail))
+	}
+
+	hint := "Esc hide (keeps starting)"
+	if run.steps[startupContainers].outcome == startupRunning {
+		hint = "Esc cancel · h hide"
+	}
+	if run.done {
+		hint = "Esc close"
+		if m.bridgeStatus != nil && m.bridgeStatus.State == "qr_pending" {
+			hint = "Enter open WhatsApp setup · Esc close"
+		}
+	}
+	b.WriteString("\n" + theme.Muted().Render(hint))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Active().Primary).
+		Padding(0, 1).
+		Width(boxWidth).
+		Render(b.String())
+}
+
+// formatElapsed formats a running time, e.g. "14s" or "1m05s".
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/screens"
+	"github.com/fetch/manager/internal/session"
+)
+
+// screenActions maps the screens that are restored on startup to the palette
+// action that opens them. The update screen is left out so a restart never
+// lands mid-update.
+var screenActions = map[screens.ID]string{
+	screens.Status:        "status",
+	screens.Logs:          "logs",
+	screens.Config:        "configure",
+	screens.Tasks:         "tasks",
+	screens.Setup:         "setup",
+	screens.Stats:         "stats",
+	screens.Whitelist:     "whitelist",
+	screens.GitHub:        "git-providers",
+	screens.Version:       "version",
+	screens.Notifications: "notifications",
+	screens.Console:       "console",
+	screens.Repos:         "repos",
+	screens.Groups:        "groups",
+	screens.Workspaces:    "workspaces",
+	screens.Approvals:     "approvals",
+	screens.Summaries:     "summaries",
+	screens.Limits:        "limits",
+}
+
+// rememberState tracks the last screen before returning to the menu,
+// which is saved on exit but would be lost by then.
+func (m model) rememberState() model {
+	if _, ok := screenActions[m.screen]; ok {
+		m.lastScreen = m.screen
+	}
+	return m
+}
+
+// sessionState collects the state written to data/manager-state.json.
+func (m model) sessionState() session.State {
+	return session.State{
+		Screen:        screenActions[m.lastScreen],
+		ShowAllModels: m.config.ShowAllModels(),
+		LogFilter:     m.logs.Filter(),
+		HiddenLevels:  m.logs.HiddenLevels(),
+		ConfigField:   m.config.FocusedKey(),
+	}
+}
+
+// restoreScreen reopens the screen the manager was left on.
+func (m model) restoreScreen() (model, tea.Cmd) {
+	if m.restored.Screen == "" {
+		return m, nil
+	}
+	return m.runAction(m.restored.Screen)
+}
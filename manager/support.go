@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/support"
+)
+
+// supportBundleMsg reports a support bundle written to data/
+type supportBundleMsg struct {
+	path string
+	err  error
+}
+
+// supportBundleCmd collects a support bundle in the background; reading
+// the container logs can take a few seconds.
+func supportBundleCmd(info components.VersionInfo) tea.Cmd {
+	return func() tea.Msg {
+		path, err := support.Create(info)
+		return supportBundleMsg{path: path, err: err}
+	}
+}
+
+func (m model) generateSupportBundle() (model, tea.Cmd) {
+	if m.bundleRunning {
+		return m, nil
+	}
+	m.bundleRunning = true
+	return m, tea.Batch(m.notify("Collecting a support bundle...", components.SeverityInfo), supportBundleCmd(m.versionInfo))
+}
+
+// updateSupportBundle reports where the bundle was written.
+func (m model) updateSupportBundle(msg supportBundleMsg) (model, tea.Cmd) {
+	m.bundleRunning = false
+	if msg.err != nil {
+		return m, m.notify(fmt.Sprintf("Support bundle failed: %v", msg.err), components.SeverityError)
+	}
+	return m, m.notify(fmt.Sprintf("Support bundle saved to %s — attach it to your GitHub issue", filepath.Join("data", filepath.Base(msg.path))), components.SeveritySuccess)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/fetch/manager/internal/components"
+	"github.com/fetch/manager/internal/screens"
+)
+
+// tab is a screen reachable from the tab bar. Tab screens keep their state
+// while another tab is shown, so switching back resumes where you left off.
+type tab struct {
+	screen screens.ID
+	title  string
+	open   func(m model) (model, tea.Cmd)
+}
+
+// tabs lists the tab bar in display order; number keys follow this order.
+var tabs = []tab{
+	{screens.Status, "Status", model.openStatus},
+	{screens.Logs, "Logs", model.openLogs},
+	{screens.Config, "Config", model.openConfigure},
+	{screens.Tasks, "Tasks", model.openTasks},
+	{screens.Setup, "Setup", model.openSetup},
+}
+
+// tabIndex returns the position of s in the tab bar, or -1 when s is not
+// a tab.
+func tabIndex(s screens.ID) int {
+	for i, t := range tabs {
+		if t.screen == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// inTabs reports whether a tab screen is showing. Background refreshes for
+// tabs run only while this is true.
+func (m model) inTabs() bool {
+	return tabIndex(m.screen) >= 0
+}
+
+// updateTabKeys switches tabs with tab/shift+tab or the number keys. It
+// reports false when the key belongs to the screen instead, including the
+// number keys on screens that use them.
+func (m model) updateTabKeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	current := tabIndex(m.screen)
+	if current < 0 || m.capturingText() {
+		return m, nil, false
+	}
+
+	next := -1
+	switch key := msg.String(); key {
+	case "tab", "shift+tab":
+		// The model picker uses tab to switch lists
+		if m.helpScreen() == screens.Models {
+			return m, nil, false
+		}
+		step := 1
+		if key == "shift+tab" {
+			step = len(tabs) - 1
+		}
+		next = (current + step) % len(tabs)
+	default:
+		if len(key) == 1 && key[0] >= '1' && int(key[0]-'0') <= len(tabs) && !m.screenUsesDigits() {
+			next = int(key[0] - '1')
+		}
+	}
+	if next < 0 {
+		return m, nil, false
+	}
+	if next == current {
+		return m, nil, true
+	}
+	m, cmd := tabs[next].open(m)
+	return m, cmd, true
+}
+
+// screenUsesDigits reports whether the current tab screen has its own
+// number keys: the Logs level toggles, Setup's numbered QR code link, or
+// the task queue's pull request links. Tab and shift+tab still switch tabs
+// there.
+func (m model) screenUsesDigits() bool {
+	slot, ok := screenModelFor(m.screen)
+	if !ok {
+		return false
+	}
+	keys, ok := slot.get(m).(screens.NumberKeys)
+	return ok && keys.UsesNumberKeys()
+}
+
+// tabBar renders the tab row shown above tab screens.
+func (m model) tabBar(width int) string {
+	return components.TabBar(tabTitles(), tabIndex(m.screen), width)
+}
+
+func tabTitles() []string {
+	titles := make([]string, len(tabs))
+	for i, t := range tabs {
+		titles[i] = t.title
+	}
+	return titles
+}
+
+// viewTabs renders the current tab screen below the tab bar.
+func (m model) viewTabs(width, height int) string {
+	bar := m.tabBar(width)
+	inner := m
+	inner.height = height - lipgloss.Height(bar)
+	return lipgloss.JoinVertical(lipgloss.Left, bar, inner.viewScreen())
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/fetch/manager/internal/runner"
+)
+
+// traceCommands appends a line to path for every docker, git, and gh
+// command the manager runs: when it finished, how long it took, and how it
+// failed. Output and environment aren't written, so tokens stay out of the
+// file. The returned function stops tracing.
+func traceCommands(path string) (stop func(), err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening the command trace: %w", err)
+	}
+	logger := log.New(f, "", log.LstdFlags|log.Lmicroseconds)
+	runner.Observe(func(c runner.Call) {
+		line := fmt.Sprintf("%6dms %s", c.Took.Milliseconds(), c.Command)
+		if c.Err != nil {
+			line += "  (" + c.Err.Error() + ")"
+		}
+		logger.Print(line)
+	})
+	return func() {
+		runner.Observe(nil)
+		f.Close()
+	}, nil
+}
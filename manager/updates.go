@@ -0,0 +1,42 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/fetch/manager/internal/update"
+)
+
+// updateCheckMsg carries the result of a background update check
+type updateCheckMsg struct {
+	commits int             // Commits on origin/main not yet pulled
+	release *update.Release // Latest manager release, nil if the check failed
+}
+
+// updateCheckTickMsg schedules the next background update check
+type updateCheckTickMsg struct{}
+
+// checkUpdatesCmd looks for new commits and a newer manager release in the
+// background. It returns nil when checks are disabled.
+func checkUpdatesCmd(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		var msg updateCheckMsg
+		if changes, err := update.PendingChanges(); err == nil {
+			msg.commits = len(changes)
+		}
+		if rel, err := update.LatestRelease(); err == nil {
+			msg.release = rel
+		}
+		return msg
+	}
+}
+
+// updateAvailable reports whether the last check found new commits or a
+// newer manager release.
+func (m model) updateAvailable() bool {
+	return m.updatesPending > 0 || m.version.NewRelease() != nil
+}
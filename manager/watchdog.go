@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fetch/manager/internal/notify"
+	"github.com/fetch/manager/internal/status"
+	"github.com/fetch/manager/internal/watchdog"
+)
+
+// runWatchdog monitors Fetch without the TUI until interrupted, restarting
+// crashed containers and notifying through the FETCH_NOTIFY_* targets.
+func runWatchdog(opts options) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+	w := watchdog.New(
+		status.NewClient(opts.apiURL, opts.apiToken),
+		notify.New(notify.ConfigFromEnv(envOrDotEnv)),
+		opts.watchdogInterval,
+		logger,
+	)
+	return w.Run(ctx)
+}